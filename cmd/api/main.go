@@ -11,23 +11,51 @@
 // @host localhost:8080
 // @BasePath /
 // @schemes http https
-// @produce json
+// @produce json application/msgpack
 // @accept json
+// @securityDefinitions.apikey AdminBearer
+// @in header
+// @name Authorization
+// @description Admin API key as "Bearer <ADMIN_API_KEY>"
+// @securityDefinitions.apikey TenantAPIKey
+// @in header
+// @name X-API-Key
+// @description Tenant API key from TENANT_API_KEYS; scopes tracked wallets, portfolios and webhooks to the tenant
 package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"hylo-wallet-tracker-api/internal/selfcheck"
 	"hylo-wallet-tracker-api/internal/server"
 )
 
-func gracefulShutdown(apiServer *http.Server, done chan bool) {
+// runSelfCheck validates configuration and external dependencies, prints the report and returns the exit code
+func runSelfCheck(format string) int {
+	report := selfcheck.Run(context.Background())
+
+	var err error
+	if format == "json" {
+		err = report.WriteJSON(os.Stdout)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		log.Printf("Failed to write self-check report: %v", err)
+		return 1
+	}
+	return report.ExitCode()
+}
+
+func gracefulShutdown(app *server.Server, done chan bool) {
 	// Create context that listens for the interrupt signal from the OS.
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -41,10 +69,15 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 	// the request it is currently handling
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	if err := apiServer.Shutdown(ctx); err != nil {
+	if err := app.HTTPServer().Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown with error: %v", err)
 	}
 
+	// Persist the wallet store and cache snapshot so the next start is warm
+	if err := app.Close(ctx); err != nil {
+		log.Printf("Failed to persist state on shutdown: %v", err)
+	}
+
 	log.Println("Server exiting")
 
 	// Notify the main goroutine that the shutdown is complete
@@ -52,14 +85,22 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 }
 
 func main() {
+	check := flag.Bool("check", false, "Validate configuration, RPC, Hylo programs, token mints and SOL/USD price, then exit non-zero on failure")
+	checkFormat := flag.String("check-format", "text", "Self-check report format: text or json")
+	flag.Parse()
+
+	if *check {
+		os.Exit(runSelfCheck(*checkFormat))
+	}
 
-	server := server.NewServer()
+	app := server.New()
+	server := app.HTTPServer()
 
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 
 	// Run graceful shutdown in a separate goroutine
-	go gracefulShutdown(server, done)
+	go gracefulShutdown(app, done)
 
 	fmt.Printf("🚀 Server is running on http://localhost%s\n", server.Addr)
 	fmt.Println("📖 Swagger documentation is available at http://localhost:8080/swagger/index.html")
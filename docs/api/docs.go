@@ -9,7 +9,7 @@ const docTemplate = `{
         "application/json"
     ],
     "produces": [
-        "application/json"
+        "json application/msgpack"
     ],
     "swagger": "2.0",
     "info": {
@@ -28,57 +28,120 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/admin/audit": {
             "get": {
-                "description": "Check the health and connectivity of the service and Solana RPC",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "List recent outbound Solana RPC and DexScreener calls (newest first) with duration, status and retry count. Requires an admin bearer token, and when ADMIN_SIGNING_SECRET or ADMIN_SIGNING_PUBLIC_KEY is set, X-Hylo-Timestamp (unix seconds) and X-Hylo-Signature (hex HMAC-SHA256 or Ed25519 signature of \"\u003ctimestamp\u003e.\u003cMETHOD\u003e.\u003crequest URI\u003e.\u003cbody\u003e\").",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
+                ],
+                "summary": "Query recent outbound calls",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries to return (1-1000, default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by target (solana-rpc, dexscreener)",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by RPC method, e.g. getAccountInfo",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by HTTP status code",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Health check endpoint",
                 "responses": {
                     "200": {
-                        "description": "Service is healthy",
+                        "description": "Recent audit entries",
                         "schema": {
-                            "$ref": "#/definitions/internal_server.HealthResponse"
+                            "$ref": "#/definitions/internal_server.AuditEntriesResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service is unhealthy - Solana RPC issues",
+                    "400": {
+                        "description": "Validation error",
                         "schema": {
-                            "$ref": "#/definitions/internal_server.HealthResponse"
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token or signature",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/price": {
+        "/admin/logs": {
             "get": {
-                "description": "Fetch current prices for SOL/USD, xSOL/SOL, and xSOL/USD with caching",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "List recent in-memory log records (newest first) emitted while handling a wallet: its API requests, background indexing and digests. Records are kept per process in a ring of LOG_BUFFER_SIZE entries and only at the configured LOG_LEVEL. Requires an admin bearer token, and when ADMIN_SIGNING_SECRET or ADMIN_SIGNING_PUBLIC_KEY is set, X-Hylo-Timestamp and X-Hylo-Signature as for /admin/audit.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "price"
+                    "admin"
+                ],
+                "summary": "Query recent logs for a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address",
+                        "name": "wallet",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum level (debug, info, warn, error)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries to return (1-1000, default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current asset prices",
                 "responses": {
                     "200": {
-                        "description": "Current asset prices",
+                        "description": "Recent log records for the wallet",
                         "schema": {
-                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.CombinedPriceResponse"
+                            "$ref": "#/definitions/internal_server.WalletLogsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Network connectivity error",
+                    "401": {
+                        "description": "Missing or invalid admin token or signature",
                         "schema": {
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
@@ -86,30 +149,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/wallet/{address}/balances": {
+        "/admin/maintenance": {
             "get": {
-                "description": "Fetch balances for hyUSD, sHYUSD, and xSOL tokens for a specific wallet address",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Report whether maintenance mode is on. While it is, POST, PUT and DELETE requests to /wallets, /portfolios and /webhooks return 503 with a MAINTENANCE error code; read endpoints keep serving. Requires an admin bearer token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
+                    "admin"
                 ],
-                "summary": "Get wallet token balances",
+                "summary": "Get maintenance mode",
+                "responses": {
+                    "200": {
+                        "description": "Current maintenance mode",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.MaintenanceStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token or signature",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Turn maintenance mode on or off, e.g. around a store migration. The message is returned by rejected writes and defaults to MAINTENANCE_MESSAGE. The switch lasts until the next one or a restart, which starts in MAINTENANCE_MODE. Requires an admin bearer token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Switch maintenance mode",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Wallet address (base58 encoded)",
-                        "name": "address",
-                        "in": "path",
-                        "required": true
+                        "description": "Maintenance mode",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.MaintenanceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Wallet token balances",
+                        "description": "Updated maintenance mode",
                         "schema": {
-                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.WalletBalances"
+                            "$ref": "#/definitions/internal_server.MaintenanceStatus"
                         }
                     },
                     "400": {
@@ -118,14 +220,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Missing or invalid admin token or signature",
                         "schema": {
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Network connectivity error",
+                    "413": {
+                        "description": "Request body too large",
                         "schema": {
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
@@ -133,42 +235,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/wallet/{address}/trades": {
+        "/admin/reconcile": {
             "get": {
-                "description": "Fetch paginated xSOL trade history for a specific wallet address with real-time RPC data",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "List the latest reconciliation report of every wallet, from the background job (INDEXER_RECONCILE_INTERVAL_SEC) or on-demand runs, kept in memory since startup. Requires an admin bearer token, and a request signature as for /admin/audit when a signing key is set.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
+                    "admin"
                 ],
-                "summary": "Get wallet xSOL trade history",
+                "summary": "Latest trade index reconciliations",
+                "responses": {
+                    "200": {
+                        "description": "Latest reports",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ReconcileReportsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token or signature",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reconcile/{address}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Re-scan the wallet's most recent trades from Solana RPC and diff them against the stored trade index: trades missing from the index, indexed trades RPC no longer returns, and trades whose side, status, amounts, counter asset, slot or block time changed. Only the slot range both sides cover is compared; trades newer than the sync cursor are counted as pending. The index is not modified. Requires an admin bearer token, and a request signature as for /admin/audit when a signing key is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reconcile a wallet's trade index",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Wallet address (base58 encoded)",
+                        "description": "Tracked wallet address (base58 encoded)",
                         "name": "address",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Maximum number of trades to return (1-50, default 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Cursor for pagination - signature to fetch trades before",
-                        "name": "before",
+                        "description": "Recent trades to re-scan (1-1000, default INDEXER_RECONCILE_DEPTH)",
+                        "name": "depth",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Wallet xSOL trade history",
+                        "description": "Reconciliation report",
                         "schema": {
-                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.TradeResponse"
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_indexer.ReconcileReport"
                         }
                     },
                     "400": {
@@ -177,223 +309,5862 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Missing or invalid admin token or signature",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not tracked",
                         "schema": {
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
                     },
                     "502": {
-                        "description": "Network connectivity error",
+                        "description": "Solana RPC error",
                         "schema": {
                             "$ref": "#/definitions/internal_server.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "hylo-wallet-tracker-api_internal_hylo.XSOLTrade": {
-            "type": "object",
-            "properties": {
-                "blockTime": {
-                    "description": "Unix timestamp",
-                    "type": "integer"
-                },
-                "counterAmount": {
-                    "description": "Formatted counter-asset amount",
-                    "type": "string"
-                },
-                "counterAsset": {
-                    "description": "\"SOL\", \"hyUSD\", \"USDC\", etc.",
-                    "type": "string"
-                },
-                "explorerUrl": {
-                    "description": "Solscan transaction URL",
-                    "type": "string"
-                },
-                "historical_price_usd": {
-                    "description": "Historical pricing (new field)",
-                    "type": "string"
-                },
-                "side": {
-                    "description": "Trade details",
-                    "type": "string"
-                },
-                "signature": {
-                    "description": "Transaction identifiers",
-                    "type": "string"
-                },
-                "slot": {
-                    "description": "Solana slot number",
-                    "type": "integer"
-                },
-                "timestamp": {
-                    "description": "Display fields",
-                    "type": "string"
-                },
-                "xsolAmount": {
-                    "description": "Formatted xSOL amount (e.g., \"1.5\")",
-                    "type": "string"
+        },
+        "/debug/stats": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Goroutine count, heap and GC statistics, in-memory cache sizes, tracked wallets, and WebSocket and SSE stream subscription counts. Only served when DEBUG_ENDPOINTS_ENABLED is true, and requires an admin bearer token. pprof profiles are served under /debug/pprof/ and expvar under /debug/vars with the same guard; keep profile durations under the 30 second write timeout.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Runtime diagnostics",
+                "responses": {
+                    "200": {
+                        "description": "Runtime diagnostics",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.DebugStatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "hylo-wallet-tracker-api_internal_price.CombinedPriceResponse": {
-            "type": "object",
-            "properties": {
-                "sol_usd": {
-                    "description": "SOLUSD is the current SOL price in USD",
-                    "type": "number"
-                },
-                "updated_at": {
-                    "description": "UpdatedAt indicates the timestamp of the most recent price update",
-                    "type": "string"
-                },
-                "xsol_sol": {
-                    "description": "XSOLInSOL is the current xSOL price in SOL terms",
-                    "type": "number"
-                },
-                "xsol_usd": {
-                    "description": "XSOLInUSD is the current xSOL price in USD terms",
-                    "type": "number"
+        "/health": {
+            "get": {
+                "description": "Check the health and connectivity of the service and Solana RPC. Also lists the latest check of each token's configured decimals against its on-chain mint (TOKEN_DECIMALS_CHECK_INTERVAL_SEC); while any token mismatches, its amounts are withheld from balances and the status is degraded. The parser's error and low-confidence rates over the last hour are reported too; the status is degraded while either is above its threshold (HYLO_PARSE_ERROR_RATE_THRESHOLD, HYLO_PARSE_LOW_CONFIDENCE_RATE_THRESHOLD), which usually means an upstream format changed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Service is healthy",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service is unhealthy - Solana RPC issues",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.HealthResponse"
+                        }
+                    }
                 }
             }
         },
-        "hylo-wallet-tracker-api_internal_tokens.TokenBalance": {
-            "type": "object",
-            "properties": {
+        "/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Top wallets tracked by the tenant by the chosen metric, computed from stored snapshots and the trade index (no live RPC calls). realized_pnl is the USD realized by xSOL sells in the window against each wallet's average buy cost over its whole trade index; volume is the USD value of xSOL bought and sold in the window; xsol_balance is the xSOL held in the latest snapshot regardless of window. Trades are valued at their historical price when known, otherwise at the xSOL price of the nearest earlier snapshot. anonymize truncates addresses and hides labels for public display.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Tracked wallet leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ranking metric: realized_pnl, volume or xsol_balance (default realized_pnl)",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lookback window in hours for P\u0026L and volume; 0 for all indexed history (default 168)",
+                        "name": "window_hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of wallets to return (1-100, default 10)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Truncate wallet addresses and omit labels (default false)",
+                        "name": "anonymize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.Leaderboard"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/limits": {
+            "get": {
+                "description": "Report the caller's per-client request bucket, the shared Solana RPC budget, the server-wide in-flight limits and per-request deadlines, so integrators can pace requests instead of running into 429 or 503 responses. Limited endpoints also return X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers for the client bucket and X-RPC-Budget-Remaining and X-RPC-Budget-Reset for the RPC budget. Calling this endpoint does not consume the client's quota",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get current rate limits and quotas",
+                "responses": {
+                    "200": {
+                        "description": "Current limits",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.LimitsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/portfolios": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "List the tenant's named wallet groups, oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "List portfolios",
+                "responses": {
+                    "200": {
+                        "description": "Portfolios",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.PortfoliosResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Define a named group of wallets whose balances, trades and P\u0026L are reported together under /portfolios/{id}/summary. Addresses are normalized and de-duplicated. Members that are not tracked yet are registered for background indexing under the tenant; paused members are reactivated. Counts against the TENANT_MAX_PORTFOLIOS and TENANT_MAX_WALLETS quotas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Create a portfolio",
+                "parameters": [
+                    {
+                        "description": "Portfolio definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.CreatePortfolioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Portfolio created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.PortfolioCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant portfolio or wallet quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/portfolios/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Get a portfolio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Portfolio",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.Portfolio"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Portfolio not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Remove a wallet group. Member wallets stay tracked; untrack them under /wallets if they are no longer needed.",
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Delete a portfolio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Portfolio deleted"
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Portfolio not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/portfolios/{id}/summary": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Totals across the portfolio's wallets computed from stored snapshots and the trade index (no live RPC calls): combined holdings and USD value per token, trade count and volume within the window, the wallets with the largest USD change, and realized P\u0026L per wallet and in total. P\u0026L uses the same average-cost method as /leaderboard. jitoSOL held by the wallets is converted to SOL at the Sanctum stake pool rate (or its market rate when Sanctum is unavailable) and valued in USD; lst_exposure reports the rate applied, and the value is included in the holding, wallet and portfolio totals.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "portfolios"
+                ],
+                "summary": "Portfolio summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Portfolio ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of top movers to return (1-50, default 5)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lookback window in hours for trades, movers and P\u0026L (default 24)",
+                        "name": "window_hours",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Portfolio summary",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.PortfolioSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Portfolio not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/price": {
+            "get": {
+                "description": "Fetch current prices for SOL/USD, xSOL/SOL, and xSOL/USD. With stale-while-revalidate configured (PRICE_SOFT_TTL_SEC), cached prices are returned immediately with their age and both TTLs under cache: past the soft TTL a background refresh replaces them, and only past the hard TTL does the request wait for fresh prices. With currency, sol_usd and xsol_usd are converted to that fiat currency at the daily reference rate returned under currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price"
+                ],
+                "summary": "Get current asset prices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert USD prices to, e.g. EUR",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current asset prices",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.CombinedPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown currency",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/price/xsol/metrics": {
+            "get": {
+                "description": "Annualized realized volatility of xSOL/USD over 7 and 30 days, its maximum drawdown and beta against SOL over 30 days, recomputed on every price refresh from prices sampled every PRICE_HISTORY_INTERVAL_SEC. Metrics are null until their window holds enough prices; the history survives restarts through the cache snapshot",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price"
+                ],
+                "summary": "Get xSOL volatility and drawdown metrics",
+                "responses": {
+                    "200": {
+                        "description": "xSOL risk metrics",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.XSOLMetrics"
+                        }
+                    },
+                    "503": {
+                        "description": "Price history disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/price/{symbol}": {
+            "get": {
+                "description": "Price one token in a shape shared by every token, with how the price was derived under method and where it came from under source. hyUSD is read from the peg monitor (method peg, with its deviation under peg), sHYUSD from the stability pool assets backing one share (method exchange_rate, with the per-share assets under backing), xSOL from Hylo protocol math (method protocol), and SOL and jitoSOL from DexScreener markets (method market). With currency, price_usd is converted to that fiat currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price"
+                ],
+                "summary": "Get a token price quote",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token symbol (hyUSD, sHYUSD, xSOL, SOL, jitoSOL; case-insensitive)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert price_usd to, e.g. EUR",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token price quote",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.PriceQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown currency",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not supported",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/protocol/peg": {
+            "get": {
+                "description": "Compare hyUSD's liquidity-weighted DexScreener market price with its $1 design peg. Returns the current deviation, the largest deviation sampled in the last 24 hours, and whether the depeg alert is raised. Crossing PEG_ALERT_THRESHOLD_BPS publishes a peg.depeg webhook event, and peg.restored once the deviation falls back under half the threshold",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "protocol"
+                ],
+                "summary": "Get hyUSD peg status",
+                "responses": {
+                    "200": {
+                        "description": "hyUSD peg status",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_peg.Status"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/protocol/scenarios": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Project the collateral ratio, effective leverage and xSOL NAV if SOL fell by sol_drop percent with supplies and the SOL reserve unchanged, and report which stability modes would trigger. Mode 1 and mode 2 start below the collateral ratios HYLO_STABILITY_MODE1_CR and HYLO_STABILITY_MODE2_CR; below 100% the protocol is undercollateralized and xSOL is worthless. drop_to_mode_1_pct and drop_to_mode_2_pct give the drops at which each mode starts. Each of the caller's tracked wallets is repriced from its latest snapshot with xSOL at the shocked price, hardest hit first; wallets without a snapshot are left out. With tenants configured the wallets are only listed for requests carrying a valid X-API-Key header, and wallets is empty otherwise",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "protocol"
+                ],
+                "summary": "Analyze a SOL price shock",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "SOL price drop in percent (above 0, at most 99)",
+                        "name": "sol_drop",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Projected protocol state and wallet impact",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.Scenario"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sol_drop",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/protocol/stats/history": {
+            "get": {
+                "description": "Time series of protocol health: collateral ratio, effective leverage, hyUSD and xSOL supply, SOL reserve and prices. Snapshots are recorded from price reads and at least every PROTOCOL_SNAPSHOT_INTERVAL_SEC, and kept for PROTOCOL_SNAPSHOT_RETENTION_HOURS. Ranges holding more than 500 snapshots are split into 500 buckets and the last snapshot of each is returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "protocol"
+                ],
+                "summary": "Get protocol state history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Window ending now, in hours or days such as 24h or 30d (default 30d, max 365d)",
+                        "name": "range",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Protocol state history",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_indexer.ProtocolHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Protocol snapshots disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/protocol/supply/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of hyUSD, xSOL and sHYUSD mint supply, fed by WebSocket subscriptions to the mint accounts rather than polling. A \"supply\" event carrying a []stream.TokenSupply payload with the latest known supplies is sent on connect; a \"supply.changed\" event carrying a stream.SupplyChange payload follows each mint or burn, with the slot as its id. Each change is also published as a protocol.supply_changed webhook event. Counts against the HTTP_MAX_SSE_STREAMS slots shared with wallet streams",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "protocol"
+                ],
+                "summary": "Stream protocol token supply",
+                "responses": {
+                    "200": {
+                        "description": "Stream of supply change events",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_stream.SupplyChange"
+                        }
+                    },
+                    "429": {
+                        "description": "Client rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Supply watcher disabled or stream slots at capacity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/protocol/tvl": {
+            "get": {
+                "description": "Total value locked in USD and SOL terms with a per-LST breakdown: vault balance, SOL conversion rate, value and share of total. Rates are Sanctum stake pool rates, falling back to the LST's DexScreener USD price over SOL/USD; rate_source reports which was used. Vaults are the token accounts listed in HYLO_LST_VAULTS",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "protocol"
+                ],
+                "summary": "Get protocol TVL",
+                "responses": {
+                    "200": {
+                        "description": "Protocol TVL",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.ProtocolTVL"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No LST vaults configured",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ready": {
+            "get": {
+                "description": "Returns 503 while the optional startup warm-up (WARMUP_ENABLED) preloads balance and trade caches for tracked wallets, and 200 once it finished or ran out of its time budget. Without a warm-up the service is ready as soon as it listens. Point load balancer readiness probes here and liveness probes at /health.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Ready for traffic",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Still warming caches",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/simulate/trade": {
+            "post": {
+                "description": "Estimate output amount, fees, xSOL NAV price impact and resulting collateral ratio for a hypothetical BUY (mint) or SELL (redeem) using current protocol state. Read-only, no transaction is built. fee_source is estimated when fee rates were read from the exchange state account, whose layout is inferred without the program IDL, and default when the protocol's default rates were used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simulate"
+                ],
+                "summary": "Simulate an xSOL trade",
+                "parameters": [
+                    {
+                        "description": "Trade to simulate (amount is in the input asset: counter asset for BUY, xSOL for SELL)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.TradeSimulationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Simulated trade outcome",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.TradeSimulationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/{symbol}/stats": {
+            "get": {
+                "description": "Read total supply, decimals and mint/freeze authorities from the SPL mint account. Set holders to include the largest token accounts (via getTokenLargestAccounts) with their share of supply. metadata carries the name, symbol, URI and icon from the Metaplex metadata account once resolved at startup (TOKEN_METADATA_ENABLED)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tokens"
+                ],
+                "summary": "Get token supply and holder stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token symbol (hyUSD, sHYUSD, xSOL, USDC, jitoSOL; case-insensitive)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of largest token accounts to include (0-20, default 0)",
+                        "name": "holders",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token stats",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.TokenStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not supported",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trades/{signature}": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Find the xSOL trade made by a transaction, regardless of wallet. Signatures in the trade index are served from it with indexed true; other signatures are fetched and parsed for every wallet whose xSOL the transaction moved, with source rpc. Each trade reports whether its wallet is tracked and whether the trade was indexed, answering whether a transaction was counted in tracked history. Tracked wallets are those of the X-API-Key's tenant when tenants are configured; without a key no wallet is reported as tracked. With include_raw the response also carries the transaction's undecoded instructions: base64 data, the resolved account key list including lookup table accounts, and the tree of inner instructions each one invoked, for decoding programs the API does not",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trades"
+                ],
+                "summary": "Look up a trade by signature",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction signature (base58 encoded)",
+                        "name": "signature",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include raw instruction data, account keys and inner instruction trees (default false)",
+                        "name": "include_raw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer",
+                        "name": "explorer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trade and affected wallets",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.SignatureLookup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid X-API-Key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown transaction or no xSOL trade",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/validate/address": {
+            "post": {
+                "description": "Check whether an address is a valid Solana wallet address. Returns the normalized form, derived associated token accounts for hyUSD, sHYUSD and xSOL, and suggestions for common input mistakes. No RPC calls are made.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validate"
+                ],
+                "summary": "Validate a wallet address",
+                "parameters": [
+                    {
+                        "description": "Address to validate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.AddressValidationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Validation result (invalid addresses return valid=false with suggestions)",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.AddressValidationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/activity": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Counts of the wallet's indexed Hylo interactions (trades, failed attempts included) bucketed by day-of-week and hour in the tz time zone over the last days days, for rendering activity heatmaps. heatmap[weekday][hour] uses weekday 0 = Sunday. Computed from the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet activity heatmap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lookback window in days (1-365, default 90)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time zone of the weekdays and hours: IANA name (Asia/Singapore) or UTC offset (+08:00), default BUCKET_TIMEZONE",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Activity heatmap",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.WalletActivity"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key or admin token when tenants are configured",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet is not tracked by the caller's tenant",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/approvals": {
+            "get": {
+                "description": "Lists every active delegate on the wallet's hyUSD, sHYUSD and xSOL token accounts. A delegate can transfer up to its delegated amount without the owner signing again, so outstanding approvals left behind by dApps are a common drain vector. Each approval is graded unlimited (approved for the maximum u64 amount), full_balance (the delegated amount covers the account balance) or partial; at_risk is set when any approval is unlimited or full_balance. Read live from the chain: the associated token accounts, or every token account of the wallet when token account discovery is enabled",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet token approvals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Active token approvals",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.WalletApprovals"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/balances": {
+            "get": {
+                "description": "Fetch balances for hyUSD, sHYUSD, and xSOL tokens for a specific wallet address. With slot or at, balances are instead reconstructed at that past point from stored snapshots and the trade index (no archival RPC): the newest snapshot at or before the point is rolled forward by the indexed trades after it, or the latest snapshot rolled back when the point predates every snapshot. Reconstructed balances carry a reconstructed object, are valued at the base snapshot's prices, do not reflect transfers the trade index does not cover, and require the wallet to be tracked by the caller's tenant: the X-API-Key's tenant, or the operator's with the admin bearer token, when tenants are configured",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet token balances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reconstruct balances at this slot",
+                        "name": "slot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reconstruct balances at this RFC 3339 timestamp",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated token balance fields to return, e.g. formatted_amount,usd_value",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert USD values to, e.g. EUR; the applied rate is returned under currency",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer",
+                        "name": "explorer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet token balances",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.WalletBalances"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Historical query without a valid tenant API key or admin token when tenants are configured",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Historical query for a wallet the caller's tenant does not track or without a snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/balances/delta": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Per-token balance changes between the newest stored snapshot at or before since and the latest snapshot. Each change is split into the part explained by indexed trades (from_trades) and the remainder (other: transfers and activity the trade index does not cover). Tokens that did not change are omitted. Computed from stored snapshots and the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet balance changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Slot number or RFC 3339 timestamp",
+                        "name": "since",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance changes",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.BalanceDelta"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key or admin token when tenants are configured",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet is not tracked by the caller's tenant or has no snapshot at or before since",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/events": {
+            "get": {
+                "description": "Fetch paginated wallet activity: xSOL trades (BUY, SELL, RECEIVE) and plain transfers of xSOL, hyUSD and sHYUSD between owners (TRANSFER_IN, TRANSFER_OUT) with source and destination owner addresses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet activity events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events to return (1-50, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor for pagination - signature to fetch events before",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include reverted Hylo transactions as failed trade events with their failure reason (default false)",
+                        "name": "include_failed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated event fields to return, e.g. type,signature,timestamp",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer",
+                        "name": "explorer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet activity events",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.EventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/risk": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Risk score from 0 to 100 with its components: leverage (xSOL share of the wallet's USD value), concentration (Herfindahl index of the holdings), frequency (executed trades per day over the last days days) and drawdown (unrealized loss of the open xSOL position against its average cost). Each component scores 0-100 and contributes by its weight; the total maps to low, moderate, high or severe. Computed from the latest stored snapshot and the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet risk score",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trading frequency window in days (1-365, default 30)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Risk score and components",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.WalletRisk"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key or admin token when tenants are configured",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet is not tracked by the caller's tenant or has no snapshot",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of xSOL, hyUSD and sHYUSD token account changes for a wallet. Each \"account\" event carries a stream.Update JSON payload. For wallets tracked by the indexer, a \"balance.changed\" event carrying an indexer.BalanceChange payload follows each sync that moved a token balance; its id is the webhook event ID. Upstream Solana subscriptions are shared between all clients watching the same wallet. At most HTTP_MAX_SSE_STREAMS wallet and supply streams run at once and at most STREAM_MAX_WALLETS distinct wallets are streamed; beyond either cap the stream is rejected with 503 and Retry-After",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Stream wallet account updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of account update events",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_stream.Update"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Client rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Stream slots or streamed wallets at capacity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/summary": {
+            "get": {
+                "description": "Find the wallet's earliest successful transaction on its xSOL, hyUSD or sHYUSD token accounts and report it as first_hylo_activity with account_age_days. Histories longer than a page are binary searched by slot for their tail; where the tail cannot be located the lookup walks at most 50,000 signatures per token account, reports truncated and resumes the walk on the next lookup. Complete results are cached for the 10,000 most recently found wallets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer",
+                        "name": "explorer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet summary",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.WalletSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/trades": {
+            "get": {
+                "description": "Fetch paginated xSOL trade history for a specific wallet address with real-time RPC data. Each trade carries protocolState, the collateral ratio and effective leverage from the protocol state snapshot recorded nearest its slot, when one lies within about an hour of it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get wallet xSOL trade history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of trades to return (1-50, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor for pagination - signature to fetch trades before",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Stop at this signature (exclusive) - e.g. newest signature from a previous sync",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include trades at or after this slot",
+                        "name": "min_slot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include trades at or before this slot",
+                        "name": "max_slot",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include reverted Hylo transactions with their failure reason (default false)",
+                        "name": "include_failed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only include trades with at least this classification confidence (0-1)",
+                        "name": "min_confidence",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only include low-confidence trades flagged for manual review (default false)",
+                        "name": "needs_review",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include trades below the configured minimum trade size (HYLO_DUST_MIN_XSOL_RAW, HYLO_DUST_MIN_USD); default false, with the dust left out counted under dust",
+                        "name": "include_dust",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "BUY",
+                            "SELL",
+                            "RECEIVE"
+                        ],
+                        "type": "string",
+                        "description": "Only include trades on this side",
+                        "name": "side",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hyUSD",
+                            "SOL",
+                            "USDC"
+                        ],
+                        "type": "string",
+                        "description": "Only include trades against this counter asset",
+                        "name": "counter_asset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include trades of at least this xSOL amount (decimal)",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include trades of at most this xSOL amount (decimal)",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated trade fields to return, e.g. signature,side,xsol_amount",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer",
+                        "name": "explorer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet xSOL trade history",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.TradeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "408": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/trades/aggregate": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Per-bucket totals of the wallet's indexed trades for charting: trade counts, buy and sell volume, net xSOL flow and the volume-weighted USD price of trades against stablecoins. Buckets start at midnight in the tz time zone, so daily volumes match the calendar day of users outside UTC. Computed from the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant. Every bucket in the window is returned, oldest first, including empty ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Get aggregated wallet trades",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket size: day or week (ISO weeks starting Monday), default day",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lookback window in days (1-365, default 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time zone buckets start at midnight in: IANA name (Asia/Singapore) or UTC offset (+08:00), default BUCKET_TIMEZONE",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Aggregated trades",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.TradeAggregate"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key or admin token when tenants are configured",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet is not tracked by the caller's tenant",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/{address}/trades/full": {
+            "get": {
+                "description": "Walk the wallet's entire signature history, newest first, writing each trade as a {\"type\":\"trade\"} line as soon as it is parsed instead of paging through /trades. The stream ends with a {\"type\":\"done\"} line whose summary reports the signatures scanned and whether the walk reached the oldest signature, or with a {\"type\":\"error\"} line when it failed after trades were sent; pass summary.cursor as before to resume. Disconnecting stops the walk and its RPC requests immediately",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Stream complete wallet xSOL trade history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start below this signature, e.g. the cursor of an interrupted walk",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include reverted Hylo transactions with their failure reason (default false)",
+                        "name": "include_failed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer",
+                        "name": "explorer",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One line per trade, then a done or error line",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.TradeStreamLine"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Network connectivity error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "List wallets registered for background balance snapshots and trade indexing with their status (active, paused or error). When tenants are configured only the wallets of the X-API-Key's tenant are listed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "List tracked wallets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return wallets with this status (active, paused, error)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tracked wallets",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.TrackedWalletsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Register a wallet for periodic balance snapshots and trade indexing. Returns 201 when newly tracked and 200 when it was already tracked. Re-tracking a paused wallet reactivates it and resumes indexing from its stored cursor instead of backfilling again. Each tenant keeps its own registration and label; a tenant may keep at most TENANT_MAX_WALLETS wallets active.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Track a wallet",
+                "parameters": [
+                    {
+                        "description": "Wallet to track",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.TrackWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet already tracked or reactivated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.TrackWalletResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Wallet tracked",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.TrackWalletResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant wallet quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{address}": {
+            "delete": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Pause background indexing for a wallet. Snapshots, indexed trades and the sync cursor are kept; tracking the wallet again resumes from the cursor. Only the tenant's own registration is paused; the wallet keeps being indexed while another tenant tracks it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Untrack a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Paused wallet",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.TrackedWallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not tracked",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{address}/digest": {
+            "put": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Send a daily or weekly digest of the wallet's net worth change, executed trades and sHYUSD yield. Digests go out at the configured hour in the wallet's time zone as wallet.digest webhook events and to the configured Telegram chat and email recipients; digests of a tenant's wallets only go to that tenant's webhooks. Updating an existing subscription keeps its last reported period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Subscribe a wallet to digests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Digest schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.DigestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet with its digest preference",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.TrackedWallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not tracked",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Stop sending change digests for the wallet. Tracking and indexing are unaffected.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Unsubscribe a wallet from digests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet without a digest preference",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.TrackedWallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not tracked",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallets/{address}/sync": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Report the wallet's tracking status, its sync cursor and the transactions that could not be fetched while its trades were indexed. Pending transactions are refetched with exponential backoff at the end of sync cycles (INDEXER_RETRY_BACKOFF_SEC, doubling); after INDEXER_RETRY_MAX_ATTEMPTS failed fetches they are listed under failed, and their trades, if any, are missing from the index. Only the most recent INDEXER_RETRY_MAX_FAILED failed transactions are kept. complete is true once the wallet was synced with nothing pending or failed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Wallet sync status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address (base58 encoded)",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sync status",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_indexer.SyncStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not tracked",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlist/summary": {
+            "get": {
+                "security": [
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Totals across the tenant's tracked wallets (all of them when tenants are not configured) computed from stored snapshots and the trade index (no live RPC calls): combined holdings and USD value per token, trade count and volume within the window, and the wallets with the largest USD change.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Watchlist summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of top movers to return (1-50, default 5)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lookback window in hours for trades and movers (default 24)",
+                        "name": "window_hours",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Watchlist summary",
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.Summary"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "List registered webhook subscriptions (secrets are not included). A tenant API key lists the tenant's subscriptions; an admin bearer token lists the operator's.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Subscriptions",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.WebhooksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token or tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Register an endpoint for event notifications. Each delivery is POSTed with X-Hylo-Delivery-Id (stable across retries), X-Hylo-Event and X-Hylo-Signature (t=\u003cunix\u003e,v1=\u003chex HMAC-SHA256 of \"\u003ct\u003e.\u003cbody\u003e\"\u003e). Failed deliveries are retried with exponential backoff and then listed under /webhooks/{id}/failures. The signing secret is only returned in this response. Requires a tenant API key, whose subscriptions receive protocol events and events of the tenant's tracked wallets, or an admin bearer token, whose subscriptions receive every event. A tenant may hold at most TENANT_MAX_WEBHOOKS subscriptions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription parameters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_webhook.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscription created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.WebhookCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token or tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant webhook quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "Remove a subscription and its dead-letter list. Requires the owning tenant's API key, or an admin bearer token for operator subscriptions.",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Subscription deleted"
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token or tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode: writes are disabled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/failures": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    },
+                    {
+                        "TenantAPIKey": []
+                    }
+                ],
+                "description": "List deliveries that exhausted their retries or were rejected by the subscriber (newest first), so consumers can reconcile missed events. Requires the owning tenant's API key, or an admin bearer token for operator subscriptions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List failed webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dead-lettered deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.WebhookFailuresResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token or tenant API key",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_server.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "hylo-wallet-tracker-api_internal_audit.Entry": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "retry_count": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_fx.Conversion": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "Units of Currency per 1 USD",
+                    "type": "number"
+                },
+                "rate_date": {
+                    "description": "RateDate is the publication date of the reference rate; FetchedAt when it was retrieved",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.HolderSummary": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.TokenHolder"
+                    }
+                },
+                "total_share_bps": {
+                    "description": "Combined share of supply held by Accounts, in basis points",
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.LSTHolding": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "Formatted with the mint's decimals",
+                    "type": "string"
+                },
+                "balance_raw": {
+                    "description": "Raw units",
+                    "type": "integer"
+                },
+                "mint": {
+                    "type": "string"
+                },
+                "rate_source": {
+                    "description": "sanctum or dexscreener",
+                    "type": "string"
+                },
+                "share": {
+                    "description": "Fraction of total TVL, 0-1",
+                    "type": "number"
+                },
+                "sol_per_token": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "value_sol": {
+                    "type": "number"
+                },
+                "value_usd": {
+                    "type": "number"
+                },
+                "vault": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.ParseAnomalyStatus": {
+            "type": "object",
+            "properties": {
+                "anomalous": {
+                    "type": "boolean"
+                },
+                "anomalous_since": {
+                    "type": "string"
+                },
+                "error_rate": {
+                    "type": "number"
+                },
+                "error_rate_threshold": {
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "low_confidence": {
+                    "type": "integer"
+                },
+                "low_confidence_rate": {
+                    "type": "number"
+                },
+                "low_confidence_rate_threshold": {
+                    "type": "number"
+                },
+                "parses": {
+                    "type": "integer"
+                },
+                "reasons": {
+                    "description": "error_rate and/or low_confidence_rate",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trades": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "window_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.ProtocolTVL": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "description": "Context is the slot, commitment and fetch time of the chain data behind the response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.DataContext"
+                        }
+                    ]
+                },
+                "lsts": {
+                    "description": "Largest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.LSTHolding"
+                    }
+                },
+                "sol_price_source": {
+                    "type": "string"
+                },
+                "sol_price_usd": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_sol": {
+                    "type": "number"
+                },
+                "total_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.ScenarioState": {
+            "type": "object",
+            "properties": {
+                "collateral_ratio": {
+                    "type": "number"
+                },
+                "effective_leverage": {
+                    "description": "EffectiveLeverage, XSOLNAVInSOL and XSOLPriceUSD are 0 once xSOL is worthless",
+                    "type": "number"
+                },
+                "sol_price_usd": {
+                    "type": "number"
+                },
+                "stability_mode": {
+                    "type": "string"
+                },
+                "xsol_nav_sol": {
+                    "type": "number"
+                },
+                "xsol_price_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.StabilityThresholds": {
+            "type": "object",
+            "properties": {
+                "mode_1_collateral_ratio": {
+                    "type": "number"
+                },
+                "mode_2_collateral_ratio": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.TokenHolder": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "amount_raw": {
+                    "type": "integer"
+                },
+                "share_bps": {
+                    "description": "Share of total supply in basis points",
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.TokenStats": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "description": "Context is the slot, commitment and fetch time of the chain data behind the response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.DataContext"
+                        }
+                    ]
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "fetched_at": {
+                    "type": "string"
+                },
+                "freeze_authority": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Metadata is the token's Metaplex name, symbol, URI and icon, once resolved at startup",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.TokenMetadata"
+                        }
+                    ]
+                },
+                "mint": {
+                    "type": "string"
+                },
+                "mint_authority": {
+                    "type": "string"
+                },
+                "supply": {
+                    "description": "Formatted with the mint's decimals",
+                    "type": "string"
+                },
+                "supply_raw": {
+                    "description": "Raw units",
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "top_holders": {
+                    "description": "Only when requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.HolderSummary"
+                        }
+                    ]
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.TokenTransfer": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "blockTime": {
+                    "type": "integer"
+                },
+                "explorerUrl": {
+                    "description": "Transaction URL on the configured block explorer",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "Owner wallets resolved from the token accounts' owners, plus the token accounts themselves",
+                    "type": "string"
+                },
+                "fromExplorerUrl": {
+                    "description": "Explorer pages of the source and destination owners",
+                    "type": "string"
+                },
+                "fromTokenAccount": {
+                    "type": "string"
+                },
+                "mint": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "toExplorerUrl": {
+                    "type": "string"
+                },
+                "toTokenAccount": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "TRANSFER_IN or TRANSFER_OUT",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.TradeProtocolState": {
+            "type": "object",
+            "properties": {
+                "capturedAt": {
+                    "type": "string"
+                },
+                "collateralRatio": {
+                    "type": "number"
+                },
+                "effectiveLeverage": {
+                    "type": "number"
+                },
+                "slot": {
+                    "description": "Slot of the state snapshot",
+                    "type": "integer"
+                },
+                "slotDistance": {
+                    "description": "Slots between the snapshot and the trade",
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.TradeSimulationRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "10.5"
+                },
+                "counter_asset": {
+                    "type": "string",
+                    "example": "SOL"
+                },
+                "side": {
+                    "type": "string",
+                    "example": "BUY"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.TradeSimulationResult": {
+            "type": "object",
+            "properties": {
+                "collateral_ratio_after": {
+                    "type": "number"
+                },
+                "collateral_ratio_before": {
+                    "type": "number"
+                },
+                "counter_asset": {
+                    "type": "string"
+                },
+                "effective_leverage_after": {
+                    "type": "number"
+                },
+                "effective_leverage_before": {
+                    "type": "number"
+                },
+                "fee_amount": {
+                    "type": "string"
+                },
+                "fee_asset": {
+                    "type": "string"
+                },
+                "fee_rate_bps": {
+                    "type": "integer"
+                },
+                "fee_source": {
+                    "type": "string"
+                },
+                "input_amount": {
+                    "type": "string"
+                },
+                "input_asset": {
+                    "type": "string"
+                },
+                "output_amount": {
+                    "type": "string"
+                },
+                "output_asset": {
+                    "type": "string"
+                },
+                "price_impact_pct": {
+                    "type": "number"
+                },
+                "side": {
+                    "type": "string"
+                },
+                "simulated_at": {
+                    "type": "string"
+                },
+                "sol_price_usd": {
+                    "type": "number"
+                },
+                "xsol_nav_sol_after": {
+                    "type": "number"
+                },
+                "xsol_nav_sol_before": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.TradeStep": {
+            "type": "object",
+            "properties": {
+                "inputAmount": {
+                    "type": "string"
+                },
+                "inputAsset": {
+                    "type": "string"
+                },
+                "instruction": {
+                    "description": "mint_levercoin, redeem_levercoin, user_deposit or user_withdraw",
+                    "type": "string"
+                },
+                "outputAmount": {
+                    "type": "string"
+                },
+                "outputAsset": {
+                    "type": "string"
+                },
+                "program": {
+                    "description": "Program ID that executed the step",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_hylo.XSOLTrade": {
+            "type": "object",
+            "properties": {
+                "blockTime": {
+                    "description": "Unix timestamp",
+                    "type": "integer"
+                },
+                "confidence": {
+                    "description": "Classification confidence (0-1): Hylo instructions score higher than balance-change inference,\nambiguous counter-asset matches lower. Trades below ReviewConfidenceThreshold need manual review",
+                    "type": "number"
+                },
+                "confidenceReasons": {
+                    "description": "Why the score is below 1",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "counterAmount": {
+                    "description": "Formatted counter-asset amount",
+                    "type": "string"
+                },
+                "counterAsset": {
+                    "description": "\"SOL\", \"hyUSD\", \"USDC\", etc.",
+                    "type": "string"
+                },
+                "errorReason": {
+                    "description": "Reason extracted from meta.Err or program logs",
+                    "type": "string"
+                },
+                "explorerUrl": {
+                    "description": "Transaction URL on the configured block explorer",
+                    "type": "string"
+                },
+                "historical_price_usd": {
+                    "description": "Historical pricing, from the counter amount of trades against a configured stablecoin",
+                    "type": "string"
+                },
+                "needsReview": {
+                    "type": "boolean"
+                },
+                "network_fee_sol": {
+                    "description": "Fee breakdown: network fee from meta.fee and the Hylo mint/redeem fee in the counter asset",
+                    "type": "string"
+                },
+                "protocolState": {
+                    "description": "Protocol health nearest the trade's slot; set on responses, not stored with the trade",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.TradeProtocolState"
+                        }
+                    ]
+                },
+                "protocol_fee": {
+                    "description": "Protocol fee denominated in counterAsset, only for Hylo trades",
+                    "type": "string"
+                },
+                "side": {
+                    "description": "Trade details",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Transaction identifiers",
+                    "type": "string"
+                },
+                "slot": {
+                    "description": "Solana slot number",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Origin of the trade: hylo_app, aggregator, direct_cpi or unknown (see TradeSource constants)",
+                    "type": "string"
+                },
+                "sourceProgram": {
+                    "description": "Aggregator name or calling program ID, when known",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Failure details, only set for reverted transactions when failed trades are requested",
+                    "type": "string"
+                },
+                "steps": {
+                    "description": "Legs of a transaction that also staked or unstaked sHYUSD, in execution order; empty for single-step trades",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.TradeStep"
+                    }
+                },
+                "timestamp": {
+                    "description": "Display fields",
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Sanity checks the parsed amounts failed, e.g. xsol_amount_exceeds_supply; the amounts are kept as parsed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "xsolAmount": {
+                    "description": "Formatted xSOL amount (e.g., \"1.5\")",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_indexer.FieldDiff": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "indexed": {
+                    "type": "string"
+                },
+                "live": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_indexer.ProtocolHistory": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "interval_seconds": {
+                    "description": "IntervalSeconds is the bucket width points were sampled at; 0 when every snapshot is returned",
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_indexer.ProtocolHistoryPoint"
+                    }
+                },
+                "range": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_indexer.ProtocolHistoryPoint": {
+            "type": "object",
+            "properties": {
+                "collateral_ratio": {
+                    "type": "number"
+                },
+                "effective_leverage": {
+                    "type": "number"
+                },
+                "hyusd_supply": {
+                    "type": "number"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "sol_price_usd": {
+                    "type": "number"
+                },
+                "sol_reserve": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "xsol_price_usd": {
+                    "type": "number"
+                },
+                "xsol_supply": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_indexer.ReconcileReport": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "description": "Trades whose indexed fields differ from a fresh parse",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_indexer.TradeDiff"
+                    }
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "depth": {
+                    "description": "Recent trades requested from RPC",
+                    "type": "integer"
+                },
+                "drift": {
+                    "type": "boolean"
+                },
+                "extra": {
+                    "description": "Indexed trades RPC no longer returns",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.XSOLTrade"
+                    }
+                },
+                "from_slot": {
+                    "description": "Oldest slot compared; 0 when the whole history was scanned",
+                    "type": "integer"
+                },
+                "matched": {
+                    "description": "Trades identical on both sides",
+                    "type": "integer"
+                },
+                "missing": {
+                    "description": "Live trades absent from the index",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.XSOLTrade"
+                    }
+                },
+                "pending": {
+                    "description": "Live trades newer than the cursor, indexed by the next sync",
+                    "type": "integer"
+                },
+                "scanned": {
+                    "description": "Trades RPC returned",
+                    "type": "integer"
+                },
+                "to_slot": {
+                    "description": "Sync cursor slot; newer trades are pending",
+                    "type": "integer"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_indexer.SyncStatus": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "description": "Complete is true once the wallet was synced and no transaction is pending or failed",
+                    "type": "boolean"
+                },
+                "cursor": {
+                    "description": "Newest indexed signature; absent before the first sync",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.Cursor"
+                        }
+                    ]
+                },
+                "failed": {
+                    "description": "Failed are transactions whose retries ran out; their trades, if any, are missing from the index",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.RetryEntry"
+                    }
+                },
+                "pending": {
+                    "description": "Pending are transactions that could not be fetched during sync and are still being retried",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.RetryEntry"
+                    }
+                },
+                "wallet": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.TrackedWallet"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_indexer.TradeDiff": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_indexer.FieldDiff"
+                    }
+                },
+                "signature": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_logger.Entry": {
+            "type": "object",
+            "properties": {
+                "attrs": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "component": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_peg.Status": {
+            "type": "object",
+            "properties": {
+                "alert_threshold_bps": {
+                    "type": "number"
+                },
+                "depegged": {
+                    "description": "Depegged is true while the alert raised at AlertThresholdBps is active",
+                    "type": "boolean"
+                },
+                "depegged_since": {
+                    "type": "string"
+                },
+                "deviation": {
+                    "type": "number"
+                },
+                "deviation_bps": {
+                    "type": "number"
+                },
+                "liquidity_usd": {
+                    "type": "number"
+                },
+                "max_deviation_24h": {
+                    "description": "MaxDeviation24h is the sampled deviation furthest from the peg within the window",
+                    "type": "number"
+                },
+                "max_deviation_24h_at": {
+                    "type": "string"
+                },
+                "max_deviation_24h_bps": {
+                    "type": "number"
+                },
+                "mint": {
+                    "type": "string"
+                },
+                "peg_usd": {
+                    "type": "number"
+                },
+                "pools": {
+                    "type": "integer"
+                },
+                "price_usd": {
+                    "type": "number"
+                },
+                "samples": {
+                    "description": "Samples within the window",
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.CacheStatus": {
+            "type": "object",
+            "properties": {
+                "age_seconds": {
+                    "type": "number"
+                },
+                "hard_ttl_seconds": {
+                    "description": "Older prices are never served; the request waits for a refresh",
+                    "type": "number"
+                },
+                "soft_ttl_seconds": {
+                    "description": "Older prices are served while a background refresh runs",
+                    "type": "number"
+                },
+                "stale": {
+                    "description": "Older than the soft TTL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.CombinedPriceResponse": {
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "description": "Cache is set when the prices were served from the stale-while-revalidate cache",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.CacheStatus"
+                        }
+                    ]
+                },
+                "context": {
+                    "description": "Context is the slot, commitment and fetch time of the chain data behind the response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.DataContext"
+                        }
+                    ]
+                },
+                "currency": {
+                    "description": "Currency is set when the USD prices were converted to another fiat currency",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_fx.Conversion"
+                        }
+                    ]
+                },
+                "sol_usd": {
+                    "description": "SOLUSD is the current SOL price in USD",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt indicates the timestamp of the most recent price update",
+                    "type": "string"
+                },
+                "xsol_sol": {
+                    "description": "XSOLInSOL is the current xSOL price in SOL terms",
+                    "type": "number"
+                },
+                "xsol_usd": {
+                    "description": "XSOLInUSD is the current xSOL price in USD terms",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.Drawdown": {
+            "type": "object",
+            "properties": {
+                "pct": {
+                    "type": "number"
+                },
+                "peak": {
+                    "type": "number"
+                },
+                "peak_at": {
+                    "type": "string"
+                },
+                "trough": {
+                    "type": "number"
+                },
+                "trough_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.PriceQuote": {
+            "type": "object",
+            "properties": {
+                "backing": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.QuoteBacking"
+                },
+                "currency": {
+                    "description": "Currency is set when price_usd was converted to another fiat currency",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_fx.Conversion"
+                        }
+                    ]
+                },
+                "method": {
+                    "description": "Method is market, protocol, exchange_rate or peg",
+                    "type": "string"
+                },
+                "mint": {
+                    "description": "Mint is empty for native SOL",
+                    "type": "string"
+                },
+                "peg": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.QuotePeg"
+                },
+                "price_sol": {
+                    "type": "number"
+                },
+                "price_usd": {
+                    "type": "number"
+                },
+                "source": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.QuoteSource"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.QuoteBacking": {
+            "type": "object",
+            "properties": {
+                "hyusd_per_share": {
+                    "type": "number"
+                },
+                "xsol_per_share": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.QuotePeg": {
+            "type": "object",
+            "properties": {
+                "depegged": {
+                    "type": "boolean"
+                },
+                "deviation_bps": {
+                    "type": "number"
+                },
+                "peg_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.QuoteSource": {
+            "type": "object",
+            "properties": {
+                "liquidity_usd": {
+                    "type": "number"
+                },
+                "pair": {
+                    "description": "Pair, liquidity and pool count describe the market the price was read from; liquidity stays in USD",
+                    "type": "string"
+                },
+                "pools": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "description": "Provider is dexscreener for market prices and hylo for prices derived from protocol state",
+                    "type": "string"
+                },
+                "sol_price_source": {
+                    "description": "SOLPriceSource is the SOL/USD source used for price_sol and for protocol math",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_price.XSOLMetrics": {
+            "type": "object",
+            "properties": {
+                "beta_30d": {
+                    "description": "Beta30D is the sensitivity of xSOL returns to SOL returns (cov(xSOL, SOL) / var(SOL))",
+                    "type": "number"
+                },
+                "from": {
+                    "description": "Oldest price in the 30 day window",
+                    "type": "string"
+                },
+                "max_drawdown_30d": {
+                    "description": "MaxDrawdown30D is the largest peak-to-trough decline of xSOL/USD within 30 days",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_price.Drawdown"
+                        }
+                    ]
+                },
+                "samples_30d": {
+                    "type": "integer"
+                },
+                "samples_7d": {
+                    "type": "integer"
+                },
+                "sol_volatility_30d_pct": {
+                    "description": "SOLVolatility30DPct is SOL's realized volatility over the same 30 days, for comparison",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "Newest price",
+                    "type": "string"
+                },
+                "volatility_30d_pct": {
+                    "type": "number"
+                },
+                "volatility_7d_pct": {
+                    "description": "Annualized realized volatility of xSOL/USD log returns",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_scheduler.Budget": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Whole tokens in the bucket now",
+                    "type": "integer"
+                },
+                "background_paused": {
+                    "description": "Background calls are backing off on degraded latency",
+                    "type": "boolean"
+                },
+                "burst": {
+                    "type": "integer"
+                },
+                "interactive_reserve": {
+                    "type": "integer"
+                },
+                "refill_seconds": {
+                    "description": "Seconds until the bucket is full again",
+                    "type": "number"
+                },
+                "rps": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_solana.ChainID": {
+            "type": "string",
+            "enum": [
+                "solana"
+            ],
+            "x-enum-varnames": [
+                "ChainSolana"
+            ]
+        },
+        "hylo-wallet-tracker-api_internal_solana.Commitment": {
+            "type": "string",
+            "enum": [
+                "processed",
+                "confirmed",
+                "finalized"
+            ],
+            "x-enum-varnames": [
+                "CommitmentProcessed",
+                "CommitmentConfirmed",
+                "CommitmentFinalized"
+            ]
+        },
+        "hylo-wallet-tracker-api_internal_solana.DataContext": {
+            "type": "object",
+            "properties": {
+                "commitment": {
+                    "description": "Weakest commitment of the reads",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.Commitment"
+                        }
+                    ]
+                },
+                "fetched_at": {
+                    "description": "When the oldest read was answered, including reads served from cache",
+                    "type": "string"
+                },
+                "slot": {
+                    "description": "Lowest slot of the reads; 0 when no read reported one",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.Slot"
+                        }
+                    ]
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_solana.Slot": {
+            "type": "integer",
+            "format": "int64",
+            "enum": [
+                432000
+            ],
+            "x-enum-varnames": [
+                "DefaultArchiveMinSlotAge"
+            ]
+        },
+        "hylo-wallet-tracker-api_internal_solana.WSStats": {
+            "type": "object",
+            "properties": {
+                "connected": {
+                    "type": "boolean"
+                },
+                "disconnects": {
+                    "type": "integer"
+                },
+                "dropped_notifications": {
+                    "type": "integer"
+                },
+                "last_slot": {
+                    "type": "integer"
+                },
+                "last_slot_at": {
+                    "type": "string"
+                },
+                "reconnects": {
+                    "type": "integer"
+                },
+                "resubscribed": {
+                    "type": "integer"
+                },
+                "subscriptions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_store.Cursor": {
+            "type": "object",
+            "properties": {
+                "last_signature": {
+                    "type": "string"
+                },
+                "last_slot": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_store.DigestPreference": {
+            "type": "object",
+            "properties": {
+                "frequency": {
+                    "description": "daily or weekly",
+                    "type": "string"
+                },
+                "last_sent_at": {
+                    "description": "LastSentAt is the end of the last reported period; the opt-in time until the first digest is sent",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA time zone the send time is evaluated in",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_store.Portfolio": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenant": {
+                    "type": "string"
+                },
+                "wallets": {
+                    "description": "Addresses in the order they were given",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_store.RetryEntry": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Fetches that failed, including the one during sync",
+                    "type": "integer"
+                },
+                "backfill": {
+                    "description": "Failed during the wallet's first sync, so a recovered trade is not announced",
+                    "type": "boolean"
+                },
+                "exhausted": {
+                    "description": "Attempts ran out; no longer retried",
+                    "type": "boolean"
+                },
+                "first_failed_at": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_store.TrackedWallet": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "digest": {
+                    "description": "Digest is the wallet's opt-in to periodic change digests; nil when not subscribed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.DigestPreference"
+                        }
+                    ]
+                },
+                "label": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "description": "Set while Status is error",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "active, paused or error",
+                    "type": "string"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "tenant": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_stream.Stats": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "integer"
+                },
+                "upstream_subscriptions": {
+                    "type": "integer"
+                },
+                "wallets": {
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_stream.SupplyChange": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "string"
+                },
+                "current_raw": {
+                    "type": "integer"
+                },
+                "delta": {
+                    "description": "Signed: negative when tokens were burned",
+                    "type": "string"
+                },
+                "mint": {
+                    "type": "string"
+                },
+                "previous": {
+                    "description": "Formatted with the token's decimals",
+                    "type": "string"
+                },
+                "previous_raw": {
+                    "type": "integer"
+                },
+                "previous_slot": {
+                    "type": "integer"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_stream.Update": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.AddressValidationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.AddressValidationResult": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "atas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "normalized": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_mint": {
+                    "description": "Symbol when the address is a supported token mint",
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.DecimalsStatus": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "configured_decimals": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "mint": {
+                    "type": "string"
+                },
+                "mismatch": {
+                    "type": "boolean"
+                },
+                "onchain_decimals": {
+                    "description": "OnChain is omitted while the mint could not be read; the token is then served as configured",
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.Reconstruction": {
+            "type": "object",
+            "properties": {
+                "base_slot": {
+                    "description": "BaseSlot and BaseTimestamp identify the snapshot the balances were rebuilt from",
+                    "type": "integer"
+                },
+                "base_timestamp": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "Direction is forward when trades after an earlier snapshot were replayed,\nbackward when trades before a later snapshot were unwound",
+                    "type": "string"
+                },
+                "replayed_trades": {
+                    "description": "ReplayedTrades is the number of indexed trades applied to the base snapshot",
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.StabilityPoolClaim": {
+            "type": "object",
+            "properties": {
+                "hyusd": {
+                    "description": "HyUSD is the wallet's share of pool hyUSD",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.TokenBalance"
+                        }
+                    ]
+                },
+                "pool_share": {
+                    "description": "PoolShare is the fraction of total sHYUSD supply held by the wallet (0-1)",
+                    "type": "number"
+                },
+                "source": {
+                    "description": "Source is how the pool balances behind the claim were read, e.g. PoolSourceEstimated",
+                    "type": "string"
+                },
+                "xsol": {
+                    "description": "XSOL is the wallet's share of pool xSOL",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.TokenBalance"
+                        }
+                    ]
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.TokenApproval": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "balance_raw_amount": {
+                    "type": "integer"
+                },
+                "delegate": {
+                    "type": "string"
+                },
+                "delegated_amount": {
+                    "type": "string"
+                },
+                "delegated_raw_amount": {
+                    "type": "integer"
+                },
+                "mint": {
+                    "type": "string"
+                },
+                "risk": {
+                    "description": "unlimited, full_balance or partial",
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_account": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.TokenBalance": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Accounts is the number of token accounts summed when token account discovery is enabled",
+                    "type": "integer"
+                },
                 "decimals": {
                     "description": "Decimals is the number of decimal places for this token",
                     "type": "integer"
                 },
-                "formatted_amount": {
-                    "description": "FormattedAmount is the human-readable amount with proper decimal adjustment",
+                "formatted_amount": {
+                    "description": "FormattedAmount is the human-readable amount with proper decimal adjustment",
+                    "type": "string"
+                },
+                "icon_url": {
+                    "description": "IconURL is the token's logo from its Metaplex metadata, once resolved",
+                    "type": "string"
+                },
+                "raw_amount": {
+                    "description": "RawAmount is the raw token amount as stored on-chain (without decimal adjustment)",
+                    "type": "integer"
+                },
+                "underlying": {
+                    "description": "Underlying is the pro-rata stability pool claim backing an sHYUSD balance (optional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.StabilityPoolClaim"
+                        }
+                    ]
+                },
+                "usd_value": {
+                    "description": "USDValue is the USD value of this token balance (optional, for display)",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.TokenMetadata": {
+            "type": "object",
+            "properties": {
+                "icon_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.WalletApprovals": {
+            "type": "object",
+            "properties": {
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.TokenApproval"
+                    }
+                },
+                "at_risk": {
+                    "description": "AtRisk is set when any approval is unlimited or covers the account's whole balance",
+                    "type": "boolean"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_tokens.WalletBalances": {
+            "type": "object",
+            "properties": {
+                "balances": {
+                    "description": "Balances is a map of token symbol to token balance",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.TokenBalance"
+                    }
+                },
+                "chain": {
+                    "description": "Chain is the SVM chain these balances were read from",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.ChainID"
+                        }
+                    ]
+                },
+                "context": {
+                    "description": "Context is the slot, commitment and fetch time of the chain data behind the response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.DataContext"
+                        }
+                    ]
+                },
+                "currency": {
+                    "description": "Currency is set when the USD values were converted to another fiat currency",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_fx.Conversion"
+                        }
+                    ]
+                },
+                "explorer_url": {
+                    "description": "ExplorerURL is the wallet's page on the requested block explorer",
+                    "type": "string"
+                },
+                "reconstructed": {
+                    "description": "Reconstructed is set when the balances were rebuilt for a past slot or time from stored data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.Reconstruction"
+                        }
+                    ]
+                },
+                "slot": {
+                    "description": "Slot is the Solana slot when these balances were fetched, the slot of Context for live reads",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.Slot"
+                        }
+                    ]
+                },
+                "total_usd_value": {
+                    "description": "TotalUSDValue is the sum of all token balances in USD (optional)",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt is the timestamp when these balances were last updated",
+                    "type": "string"
+                },
+                "wallet": {
+                    "description": "Wallet is the wallet address these balances belong to",
+                    "type": "string"
+                },
+                "withheld": {
+                    "description": "Withheld lists tokens left out of balances because their configured decimals do not match the on-chain mint",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.DustSummary": {
+            "type": "object",
+            "properties": {
+                "filtered": {
+                    "description": "Dust trades left out; request include_dust=true to see them",
+                    "type": "integer"
+                },
+                "minUsd": {
+                    "description": "Trades worth less are dust",
+                    "type": "number"
+                },
+                "minXsolRaw": {
+                    "description": "Trades moving fewer raw xSOL units are dust",
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.EventsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.WalletEvent"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.PaginationInfo"
+                },
+                "requestedAt": {
+                    "type": "string"
+                },
+                "walletAddress": {
+                    "type": "string"
+                },
+                "walletExplorerUrl": {
+                    "description": "Wallet page on the requested block explorer",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.FirstActivity": {
+            "type": "object",
+            "properties": {
+                "explorer_url": {
+                    "description": "Transaction URL on the requested block explorer",
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Token account the transaction was found on (xSOL, hyUSD or sHYUSD)",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.HistorySummary": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "description": "The walk reached the wallet's oldest signature",
+                    "type": "boolean"
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "elapsed_ms": {
+                    "type": "integer"
+                },
+                "signatures": {
+                    "description": "Signatures scanned",
+                    "type": "integer"
+                },
+                "trades": {
+                    "description": "Trades emitted",
+                    "type": "integer"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.PaginationInfo": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Count is the number of items in the current response",
+                    "type": "integer"
+                },
+                "filters": {
+                    "description": "Filters echoes the trade filters applied to this page; pass them again with NextCursor",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.TradeFilters"
+                        }
+                    ]
+                },
+                "hasMore": {
+                    "description": "HasMore indicates if there are more trades available",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Limit is the maximum number of items per page",
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "description": "NextCursor is the signature cursor for the next page\nOnly present when HasMore is true",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.RawAccountKey": {
+            "type": "object",
+            "properties": {
+                "pubkey": {
+                    "type": "string"
+                },
+                "signer": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "writable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.RawInstruction": {
+            "type": "object",
+            "properties": {
+                "accountIndexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "data": {
+                    "description": "Base64 encoded",
+                    "type": "string"
+                },
+                "innerInstructions": {
+                    "description": "InnerInstructions are the instructions this one invoked through CPI",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.RawInstruction"
+                    }
+                },
+                "programId": {
+                    "type": "string"
+                },
+                "stackHeight": {
+                    "description": "1 for top-level instructions",
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.RawTransaction": {
+            "type": "object",
+            "properties": {
+                "accountKeys": {
+                    "description": "AccountKeys lists every account the transaction referenced, in account index order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.RawAccountKey"
+                    }
+                },
+                "instructions": {
+                    "description": "Instructions are the top-level instructions, each with the instructions it invoked nested below it",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.RawInstruction"
+                    }
+                },
+                "recentBlockhash": {
+                    "type": "string"
+                },
+                "slot": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.Slot"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.SignatureLookup": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.ChainID"
+                },
+                "explorerUrl": {
+                    "type": "string"
+                },
+                "indexed": {
+                    "description": "Indexed is true when any wallet's trade in the transaction is in the trade index",
+                    "type": "boolean"
+                },
+                "raw": {
+                    "description": "Raw is the transaction's undecoded instructions, when requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.RawTransaction"
+                        }
+                    ]
+                },
+                "requestedAt": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is index when the trade index had the signature and rpc when the transaction was parsed instead",
+                    "type": "string"
+                },
+                "trackedWallets": {
+                    "description": "TrackedWallets lists the affected wallets the caller's tenant tracks",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trades": {
+                    "description": "Trades lists the trade the transaction made for each affected wallet",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.SignatureTrade"
+                    }
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.SignatureTrade": {
+            "type": "object",
+            "properties": {
+                "indexed": {
+                    "description": "Indexed is true when the trade is in the trade index, so it counts towards stored history and P\u0026L",
+                    "type": "boolean"
+                },
+                "tracked": {
+                    "description": "Tracked is true when the caller's tenant registered the wallet and has not paused it",
+                    "type": "boolean"
+                },
+                "trade": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.XSOLTrade"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.TradeFilters": {
+            "type": "object",
+            "properties": {
+                "counterAsset": {
+                    "description": "hyUSD, SOL or USDC",
+                    "type": "string"
+                },
+                "maxAmount": {
+                    "type": "string"
+                },
+                "minAmount": {
+                    "description": "Inclusive xSOL amount bounds as decimal strings",
+                    "type": "string"
+                },
+                "side": {
+                    "description": "BUY, SELL or RECEIVE",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.TradeResponse": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "description": "SVM chain the trades were read from",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.ChainID"
+                        }
+                    ]
+                },
+                "context": {
+                    "description": "Context is the slot, commitment and fetch time of the chain data behind the response",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.DataContext"
+                        }
+                    ]
+                },
+                "count": {
+                    "description": "Number of trades returned",
+                    "type": "integer"
+                },
+                "dust": {
+                    "description": "Dust reports the trades left out as dust; absent when no dust threshold is configured or dust was included",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.DustSummary"
+                        }
+                    ]
+                },
+                "pagination": {
+                    "description": "Pagination metadata for frontend navigation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.PaginationInfo"
+                        }
+                    ]
+                },
+                "requestedAt": {
+                    "type": "string"
+                },
+                "trades": {
+                    "description": "Trades is the array of xSOL trades for this wallet",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.XSOLTrade"
+                    }
+                },
+                "walletAddress": {
+                    "description": "Request metadata",
+                    "type": "string"
+                },
+                "walletExplorerUrl": {
+                    "description": "Wallet page on the requested block explorer",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.WalletEvent": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "trade": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.XSOLTrade"
+                },
+                "transfer": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.TokenTransfer"
+                },
+                "type": {
+                    "description": "BUY, SELL, RECEIVE, TRANSFER_IN or TRANSFER_OUT; empty for a failed trade of unknown side",
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_trades.WalletSummary": {
+            "type": "object",
+            "properties": {
+                "account_age_days": {
+                    "description": "Whole days since FirstHyloActivity",
+                    "type": "integer"
+                },
+                "explorer_url": {
+                    "description": "Wallet page on the requested block explorer",
+                    "type": "string"
+                },
+                "first_hylo_activity": {
+                    "description": "Nil when the wallet has never touched a Hylo token",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.FirstActivity"
+                        }
+                    ]
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "description": "History exceeded the scan cap; first activity may be older and the next lookup resumes the scan",
+                    "type": "boolean"
+                },
+                "wallet_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_warmup.Result": {
+            "type": "object",
+            "properties": {
+                "budget_exceeded": {
+                    "type": "boolean"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "description": "Skipped wallets were not finished within the time budget",
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "wallets": {
+                    "type": "integer"
+                },
+                "warmed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.BalanceDelta": {
+            "type": "object",
+            "properties": {
+                "change_usd": {
+                    "type": "number"
+                },
+                "from": {
+                    "description": "From is the newest snapshot at or before the requested point; To is the latest snapshot",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.DeltaPoint"
+                        }
+                    ]
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "to": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.DeltaPoint"
+                },
+                "tokens": {
+                    "description": "Tokens lists every snapshotted token whose balance or trade flow changed, keyed by symbol\nTrade legs in assets the snapshots do not hold (e.g. SOL) are left out",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.TokenDelta"
+                    }
+                },
+                "trade_count": {
+                    "description": "Indexed trades between the two snapshots",
+                    "type": "integer"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.DeltaPoint": {
+            "type": "object",
+            "properties": {
+                "slot": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "total_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.LSTExposure": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "rate_source": {
+                    "description": "sanctum or dexscreener",
+                    "type": "string"
+                },
+                "sol_equivalent": {
+                    "type": "number"
+                },
+                "sol_per_token": {
+                    "description": "Conversion rate applied",
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "usd_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.Leaderboard": {
+            "type": "object",
+            "properties": {
+                "anonymized": {
+                    "type": "boolean"
+                },
+                "entries": {
+                    "description": "Best first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.LeaderboardEntry"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "wallet_count": {
+                    "type": "integer"
+                },
+                "window_hours": {
+                    "description": "0 means all indexed history",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "realized_pnl_usd": {
+                    "type": "number"
+                },
+                "trade_count": {
+                    "description": "Executed trades in the window",
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "volume_usd": {
+                    "type": "number"
+                },
+                "wallet": {
+                    "description": "Truncated when anonymized",
+                    "type": "string"
+                },
+                "xsol_balance": {
+                    "type": "number"
+                },
+                "xsol_balance_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.Mover": {
+            "type": "object",
+            "properties": {
+                "change_pct": {
+                    "type": "number"
+                },
+                "change_usd": {
+                    "type": "number"
+                },
+                "current_usd": {
+                    "type": "number"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "previous_usd": {
+                    "type": "number"
+                },
+                "trade_count": {
+                    "type": "integer"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.PortfolioSummary": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "type": "string"
+                },
+                "holdings": {
+                    "description": "Keyed by token symbol",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.TokenHolding"
+                    }
+                },
+                "lst_exposure": {
+                    "description": "LSTExposure lists liquid staking tokens valued through their SOL conversion rate and included in the totals",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.LSTExposure"
+                    }
+                },
+                "oldest_snapshot_at": {
+                    "description": "OldestSnapshotAt is the least recent latest-snapshot across wallets, indicating data freshness",
+                    "type": "string"
+                },
+                "portfolio": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.Portfolio"
+                },
+                "realized_pnl_usd": {
+                    "description": "RealizedPnLUSD is the sum of the wallets' realized P\u0026L in the window",
+                    "type": "number"
+                },
+                "top_movers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.Mover"
+                    }
+                },
+                "total_usd": {
+                    "type": "number"
+                },
+                "trades": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.TradeActivity"
+                },
+                "wallet_count": {
+                    "type": "integer"
+                },
+                "wallets": {
+                    "description": "In portfolio order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.PortfolioWallet"
+                    }
+                },
+                "window_hours": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.PortfolioWallet": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "realized_pnl_usd": {
+                    "type": "number"
+                },
+                "snapshot_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Tracked wallet status; untracked when the wallet is not registered",
+                    "type": "string"
+                },
+                "total_usd": {
+                    "type": "number"
+                },
+                "trade_count": {
+                    "description": "Executed trades in the window",
+                    "type": "integer"
+                },
+                "volume_usd": {
+                    "type": "number"
+                },
+                "wallet": {
+                    "type": "string"
+                },
+                "xsol_balance": {
+                    "type": "number"
+                },
+                "xsol_balance_usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.RiskComponent": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "description": "Points added to the total score",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "0-100",
+                    "type": "number"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.Scenario": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.DataContext"
+                },
+                "current": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.ScenarioState"
+                },
+                "drop_to_mode_1_pct": {
+                    "description": "DropToMode1Pct and DropToMode2Pct are the SOL price drops from now that would trigger each mode; 0 when already active",
+                    "type": "number"
+                },
+                "drop_to_mode_2_pct": {
+                    "type": "number"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "shocked": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.ScenarioState"
+                },
+                "sol_drop_pct": {
+                    "type": "number"
+                },
+                "thresholds": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.StabilityThresholds"
+                },
+                "total_change_usd": {
+                    "description": "TotalChangeUSD is the combined USD change of the wallets",
+                    "type": "number"
+                },
+                "triggered": {
+                    "description": "Triggered lists the stability modes the shock would enter that are not active now, mildest first",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "wallets": {
+                    "description": "Wallets lists the impact on each tracked wallet with a snapshot, hardest hit first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.WalletShock"
+                    }
+                },
+                "xsol_price_change_pct": {
+                    "description": "XSOLPriceChangePct is the change of the xSOL USD price under the shock",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.Summary": {
+            "type": "object",
+            "properties": {
+                "generated_at": {
+                    "type": "string"
+                },
+                "holdings": {
+                    "description": "Keyed by token symbol",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.TokenHolding"
+                    }
+                },
+                "oldest_snapshot_at": {
+                    "description": "OldestSnapshotAt is the least recent latest-snapshot across wallets, indicating data freshness",
+                    "type": "string"
+                },
+                "top_movers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.Mover"
+                    }
+                },
+                "total_usd": {
+                    "type": "number"
+                },
+                "trades": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.TradeActivity"
+                },
+                "wallet_count": {
+                    "type": "integer"
+                },
+                "window_hours": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.TokenDelta": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "string"
+                },
+                "change_usd": {
+                    "type": "number"
+                },
+                "current": {
+                    "type": "string"
+                },
+                "from_trades": {
+                    "type": "string"
+                },
+                "other": {
+                    "type": "string"
+                },
+                "previous": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.TokenHolding": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "usd_value": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.TradeActivity": {
+            "type": "object",
+            "properties": {
+                "buy_count": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "sell_count": {
+                    "type": "integer"
+                },
+                "volume_usd": {
+                    "description": "VolumeUSD uses each trade's historical price when known, otherwise the current xSOL price",
+                    "type": "number"
+                },
+                "volume_xsol": {
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.TradeAggregate": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.TradeBucket"
+                    }
+                },
+                "from": {
+                    "description": "Start of the first bucket",
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "description": "End of the window (exclusive)",
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.TradeBucket": {
+            "type": "object",
+            "properties": {
+                "buy_count": {
+                    "type": "integer"
+                },
+                "buy_volume_xsol": {
+                    "type": "number"
+                },
+                "net_flow_xsol": {
+                    "description": "Buy minus sell volume",
+                    "type": "number"
+                },
+                "sell_count": {
+                    "type": "integer"
+                },
+                "sell_volume_xsol": {
+                    "type": "number"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "trade_count": {
+                    "type": "integer"
+                },
+                "vwap_usd": {
+                    "description": "VWAPUSD is the volume-weighted xSOL price over trades with a known USD price (stablecoin\ncounter assets); nil when no trade in the bucket could be priced",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.WalletActivity": {
+            "type": "object",
+            "properties": {
+                "by_hour": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "by_weekday": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "heatmap": {
+                    "description": "Heatmap[weekday][hour] is the number of interactions in that hour of that weekday",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.WalletRisk": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_watchlist.RiskComponent"
+                    }
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "level": {
+                    "description": "low, moderate, high or severe",
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "snapshot_at": {
+                    "description": "Snapshot the holdings were read from",
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                },
+                "xsol_cost_basis_usd": {
+                    "type": "number"
+                },
+                "xsol_position": {
+                    "description": "XSOLPosition and XSOLCostBasisUSD are the open position the drawdown is measured on",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_watchlist.WalletShock": {
+            "type": "object",
+            "properties": {
+                "change_pct": {
+                    "description": "Of TotalUSD",
+                    "type": "number"
+                },
+                "change_usd": {
+                    "type": "number"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "shocked_total_usd": {
+                    "type": "number"
+                },
+                "shocked_xsol_value_usd": {
+                    "type": "number"
+                },
+                "snapshot_at": {
+                    "type": "string"
+                },
+                "total_usd": {
+                    "description": "TotalUSD is the wallet's holdings with xSOL at the current price, and ShockedTotalUSD at the shocked price",
+                    "type": "number"
+                },
+                "wallet": {
+                    "type": "string"
+                },
+                "xsol_balance": {
+                    "type": "number"
+                },
+                "xsol_value_usd": {
+                    "description": "XSOL values are at the current and the shocked xSOL price",
+                    "type": "number"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_webhook.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "event": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_webhook.Event"
+                },
+                "first_attempt_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "subscription_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_webhook.Event": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {},
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_webhook.Subscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "tenant": {
+                    "description": "Empty for the operator's subscriptions, which receive every tenant's events",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "wallets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "hylo-wallet-tracker-api_internal_webhook.SubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events restricts deliveries to these event types; empty matches all events",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/hylo"
+                },
+                "wallets": {
+                    "description": "Wallets restricts deliveries to these wallets; empty matches all tracked wallets",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_server.AuditEntriesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_audit.Entry"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_server.ClientLimits": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "reset_seconds": {
+                    "description": "Until the bucket is full again",
+                    "type": "integer"
+                },
+                "rps": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_server.ConcurrencyLimits": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_in_flight": {
+                    "type": "integer"
+                },
+                "max_sse_streams": {
+                    "description": "MaxSSEStreams caps concurrent wallet and supply SSE streams; 0 when uncapped",
+                    "type": "integer"
+                },
+                "max_trade_streams": {
+                    "description": "MaxTradeStreams caps concurrent full trade history streams; 0 when uncapped",
+                    "type": "integer"
+                },
+                "queue_depth": {
+                    "type": "integer"
+                },
+                "queue_timeout_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_server.CreatePortfolioRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Treasury"
+                },
+                "wallets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+                    ]
+                }
+            }
+        },
+        "internal_server.DebugCacheSizes": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Address -\u003e account info, invalidated by account notifications",
+                    "type": "integer"
+                },
+                "block_times": {
+                    "description": "Slot -\u003e block time",
+                    "type": "integer"
+                },
+                "first_activity_wallets": {
+                    "description": "Wallets with a cached first trade",
+                    "type": "integer"
+                },
+                "fx_rates": {
+                    "description": "Currency conversion rates",
+                    "type": "integer"
+                },
+                "price_window": {
+                    "description": "Accepted SOL/USD samples kept for price bounds",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_server.DebugStatsResponse": {
+            "type": "object",
+            "properties": {
+                "caches": {
+                    "$ref": "#/definitions/internal_server.DebugCacheSizes"
+                },
+                "cpus": {
+                    "type": "integer"
+                },
+                "gc_cpu_fraction": {
+                    "type": "number"
+                },
+                "gc_pause_total_ms": {
+                    "type": "number"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "goroutines": {
+                    "type": "integer"
+                },
+                "heap_alloc_bytes": {
+                    "type": "integer"
+                },
+                "heap_inuse_bytes": {
+                    "type": "integer"
+                },
+                "heap_objects": {
+                    "type": "integer"
+                },
+                "num_gc": {
+                    "type": "integer"
+                },
+                "streams": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_stream.Stats"
+                },
+                "sys_bytes": {
+                    "type": "integer"
+                },
+                "timestamp": {
                     "type": "string"
                 },
-                "raw_amount": {
-                    "description": "RawAmount is the raw token amount as stored on-chain (without decimal adjustment)",
+                "total_alloc_bytes": {
                     "type": "integer"
                 },
-                "usd_value": {
-                    "description": "USDValue is the USD value of this token balance (optional, for display)",
-                    "type": "number"
+                "tracked_wallets": {
+                    "type": "integer"
+                },
+                "websocket": {
+                    "description": "Null when the WebSocket client is closed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.WSStats"
+                        }
+                    ]
                 }
             }
         },
-        "hylo-wallet-tracker-api_internal_tokens.WalletBalances": {
+        "internal_server.DigestRequest": {
             "type": "object",
             "properties": {
-                "balances": {
-                    "description": "Balances is a map of token symbol to token balance",
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.TokenBalance"
-                    }
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly"
+                    ],
+                    "example": "daily"
                 },
-                "slot": {
-                    "description": "Slot is the Solana slot when these balances were fetched",
-                    "type": "integer"
+                "timezone": {
+                    "description": "IANA time zone; defaults to UTC",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                }
+            }
+        },
+        "internal_server.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "For categorizing errors",
+                    "type": "string"
                 },
-                "total_usd_value": {
-                    "description": "TotalUSDValue is the sum of all token balances in USD (optional)",
-                    "type": "number"
+                "details": {
+                    "type": "string"
                 },
-                "updated_at": {
-                    "description": "UpdatedAt is the timestamp when these balances were last updated",
+                "error": {
                     "type": "string"
                 },
-                "wallet": {
-                    "description": "Wallet is the wallet address these balances belong to",
+                "request_id": {
+                    "type": "string"
+                },
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "hylo-wallet-tracker-api_internal_trades.PaginationInfo": {
+        "internal_server.HealthResponse": {
             "type": "object",
             "properties": {
-                "count": {
-                    "description": "Count is the number of items in the current response",
-                    "type": "integer"
+                "chain": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_solana.ChainID"
                 },
-                "hasMore": {
-                    "description": "HasMore indicates if there are more trades available",
+                "parser": {
+                    "description": "Parser is the transaction parser's error and low-confidence rates over the last hour",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.ParseAnomalyStatus"
+                        }
+                    ]
+                },
+                "solana": {},
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "token_decimals": {
+                    "description": "TokenDecimals is the latest check of the registry's decimals against the on-chain mints\nOmitted until the first check ran",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_tokens.DecimalsStatus"
+                    }
+                }
+            }
+        },
+        "internal_server.LimitsResponse": {
+            "type": "object",
+            "properties": {
+                "client": {
+                    "$ref": "#/definitions/internal_server.ClientLimits"
+                },
+                "concurrency": {
+                    "$ref": "#/definitions/internal_server.ConcurrencyLimits"
+                },
+                "requests": {
+                    "$ref": "#/definitions/internal_server.RequestLimits"
+                },
+                "rpc_budget": {
+                    "description": "Shared by all clients and background jobs; null when unlimited",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_scheduler.Budget"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal_server.MaintenanceRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "limit": {
-                    "description": "Limit is the maximum number of items per page",
-                    "type": "integer"
+                "message": {
+                    "description": "Defaults to MAINTENANCE_MESSAGE",
+                    "type": "string",
+                    "example": "Migrating the wallet store, back by 14:00 UTC"
+                }
+            }
+        },
+        "internal_server.MaintenanceStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
                 },
-                "nextCursor": {
-                    "description": "NextCursor is the signature cursor for the next page\nOnly present when HasMore is true",
+                "message": {
+                    "description": "Returned by rejected writes",
+                    "type": "string"
+                },
+                "since": {
+                    "description": "When maintenance mode was switched on",
+                    "type": "string"
+                },
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "hylo-wallet-tracker-api_internal_trades.TradeResponse": {
+        "internal_server.PortfolioCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "portfolio": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.Portfolio"
+                },
+                "tracked": {
+                    "description": "Members newly tracked or reactivated for indexing",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_server.PortfoliosResponse": {
             "type": "object",
             "properties": {
                 "count": {
-                    "description": "Number of trades returned",
                     "type": "integer"
                 },
-                "pagination": {
-                    "description": "Pagination metadata for frontend navigation",
+                "portfolios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.Portfolio"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_server.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "ready or warming",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "warmup": {
+                    "description": "Omitted when no warm-up ran",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.PaginationInfo"
+                            "$ref": "#/definitions/hylo-wallet-tracker-api_internal_warmup.Result"
                         }
                     ]
+                }
+            }
+        },
+        "internal_server.ReconcileReportsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "requestedAt": {
-                    "type": "string"
+                "drifted": {
+                    "description": "Reports with missing, extra or changed trades",
+                    "type": "integer"
                 },
-                "trades": {
-                    "description": "Trades is the array of xSOL trades for this wallet",
+                "reports": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.XSOLTrade"
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_indexer.ReconcileReport"
                     }
                 },
-                "walletAddress": {
-                    "description": "Request metadata",
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "internal_server.ErrorResponse": {
+        "internal_server.RequestLimits": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "For categorizing errors",
-                    "type": "string"
+                "balances_timeout_ms": {
+                    "type": "integer"
                 },
-                "details": {
+                "default_timeout_ms": {
+                    "type": "integer"
+                },
+                "max_body_bytes": {
+                    "type": "integer"
+                },
+                "trades_timeout_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_server.TrackWalletRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "treasury"
+                }
+            }
+        },
+        "internal_server.TrackWalletResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "boolean"
+                },
+                "reactivated": {
+                    "description": "Was paused; indexing resumes from the stored cursor",
+                    "type": "boolean"
+                },
+                "wallet": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.TrackedWallet"
+                }
+            }
+        },
+        "internal_server.TrackedWalletsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "timestamp": {
                     "type": "string"
                 },
+                "wallets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_store.TrackedWallet"
+                    }
+                }
+            }
+        },
+        "internal_server.TradeStreamLine": {
+            "type": "object",
+            "properties": {
                 "error": {
-                    "type": "string"
+                    "$ref": "#/definitions/internal_server.ErrorResponse"
                 },
-                "request_id": {
+                "summary": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_trades.HistorySummary"
+                },
+                "trade": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_hylo.XSOLTrade"
+                },
+                "type": {
+                    "description": "trade, done or error",
                     "type": "string"
+                }
+            }
+        },
+        "internal_server.WalletLogsResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_logger.Entry"
+                    }
                 },
                 "timestamp": {
                     "type": "string"
+                },
+                "wallet": {
+                    "type": "string"
                 }
             }
         },
-        "internal_server.HealthResponse": {
+        "internal_server.WebhookCreatedResponse": {
             "type": "object",
             "properties": {
-                "solana": {},
-                "status": {
+                "secret": {
+                    "type": "string"
+                },
+                "subscription": {
+                    "$ref": "#/definitions/hylo-wallet-tracker-api_internal_webhook.Subscription"
+                }
+            }
+        },
+        "internal_server.WebhookFailuresResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_webhook.Delivery"
+                    }
+                },
+                "subscription_id": {
+                    "type": "string"
+                },
+                "timestamp": {
                     "type": "string"
+                }
+            }
+        },
+        "internal_server.WebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/hylo-wallet-tracker-api_internal_webhook.Subscription"
+                    }
                 },
                 "timestamp": {
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "AdminBearer": {
+            "description": "Admin API key as \"Bearer \u003cADMIN_API_KEY\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "TenantAPIKey": {
+            "description": "Tenant API key from TENANT_API_KEYS; scopes tracked wallets, portfolios and webhooks to the tenant",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        }
     }
 }`
 
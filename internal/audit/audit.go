@@ -0,0 +1,230 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// Entry records a single outbound HTTP call
+type Entry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Target     string    `json:"target"`
+	Method     string    `json:"method"`
+	Host       string    `json:"host"`
+	Path       string    `json:"path"`
+	DurationMs float64   `json:"duration_ms"`
+	StatusCode int       `json:"status_code"`
+	RetryCount int       `json:"retry_count"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Query filters recent audit entries; zero values match everything
+type Query struct {
+	Target     string
+	Method     string
+	StatusCode int
+	Since      time.Time
+	Limit      int
+}
+
+// Log keeps recent outbound call entries in memory and optionally appends them to a rotating JSONL file
+type Log struct {
+	mu      sync.RWMutex
+	entries []Entry
+	next    int
+	full    bool
+	file    *rotatingFile
+	logger  *logger.Logger
+
+	// writeFailing is set while appends to the audit file fail, so only the first failure of a run is logged
+	writeFailing atomic.Bool
+}
+
+// NewLog creates an audit log from config
+func NewLog(config *Config) (*Log, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	bufferSize := config.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	l := &Log{entries: make([]Entry, bufferSize), logger: logger.NewFromEnv().WithComponent("audit-log")}
+
+	if config.FilePath != "" {
+		file, err := openRotatingFile(config.FilePath, int64(config.MaxSizeMB)*bytesPerMB, config.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		l.file = file
+	}
+
+	return l, nil
+}
+
+// Record stores an entry in the ring buffer and appends it to the audit file when configured
+func (l *Log) Record(entry Entry) {
+	l.mu.Lock()
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+	l.mu.Unlock()
+
+	if l.file != nil {
+		l.append(entry)
+	}
+}
+
+// append writes entry to the audit file; failures are counted in hylo_audit_write_errors_total and logged
+// when a run of them starts and ends, so a full disk does not flood the log
+func (l *Log) append(entry Entry) {
+	line, err := json.Marshal(entry)
+	if err == nil {
+		_, err = l.file.Write(append(line, '\n'))
+	}
+	if err != nil {
+		metrics.RecordAuditWriteError()
+		if !l.writeFailing.Swap(true) {
+			l.logger.Warn("Failed to write audit log entry, further failures are only counted",
+				slog.String("path", l.file.path),
+				slog.String("error", err.Error()))
+		}
+		return
+	}
+	if l.writeFailing.Swap(false) {
+		l.logger.Info("Audit log writes recovered", slog.String("path", l.file.path))
+	}
+}
+
+// Recent returns matching entries, newest first
+func (l *Log) Recent(query Query) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	count := l.next
+	if l.full {
+		count = len(l.entries)
+	}
+
+	result := make([]Entry, 0)
+	for i := 0; i < count; i++ {
+		idx := (l.next - 1 - i + len(l.entries)) % len(l.entries)
+		entry := l.entries[idx]
+
+		if !query.matches(entry) {
+			continue
+		}
+		result = append(result, entry)
+		if query.Limit > 0 && len(result) >= query.Limit {
+			break
+		}
+	}
+
+	return result
+}
+
+// Close flushes and closes the audit file
+func (l *Log) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+func (q Query) matches(entry Entry) bool {
+	if q.Target != "" && entry.Target != q.Target {
+		return false
+	}
+	if q.Method != "" && entry.Method != q.Method {
+		return false
+	}
+	if q.StatusCode != 0 && entry.StatusCode != q.StatusCode {
+		return false
+	}
+	if !q.Since.IsZero() && entry.Timestamp.Before(q.Since) {
+		return false
+	}
+	return true
+}
+
+type callKey struct{}
+
+type callInfo struct {
+	target  string
+	method  string
+	attempt int
+}
+
+// WithCall annotates ctx with the logical call being made so the audit transport can label it
+// attempt is zero-based; method may be empty to default to "<HTTP method> <path>"
+func WithCall(ctx context.Context, target, method string, attempt int) context.Context {
+	return context.WithValue(ctx, callKey{}, callInfo{target: target, method: method, attempt: attempt})
+}
+
+// Transport is an http.RoundTripper that records every request into a Log
+type Transport struct {
+	Next http.RoundTripper
+	Log  *Log
+}
+
+// NewTransport wraps next so that every outbound request is recorded into log
+func NewTransport(next http.RoundTripper, log *Log) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{Next: next, Log: log}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.Next.RoundTrip(req)
+
+	info, _ := req.Context().Value(callKey{}).(callInfo)
+	entry := Entry{
+		Timestamp:  start.UTC(),
+		RequestID:  logger.GetRequestID(req.Context()),
+		Target:     info.target,
+		Method:     info.method,
+		Host:       req.URL.Host,
+		Path:       req.URL.Path, // Query strings are dropped: RPC URLs carry API keys
+		DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+		RetryCount: info.attempt,
+	}
+	if entry.Target == "" {
+		entry.Target = req.URL.Host
+	}
+	if entry.Method == "" {
+		entry.Method = fmt.Sprintf("%s %s", req.Method, req.URL.Path)
+	}
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	t.Log.Record(entry)
+	return resp, err
+}
+
+// CloseIdleConnections forwards to the wrapped transport so http.Client.CloseIdleConnections keeps working
+func (t *Transport) CloseIdleConnections() {
+	if closer, ok := t.Next.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
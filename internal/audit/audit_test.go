@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLog_RecentNewestFirstAndFiltered(t *testing.T) {
+	log, err := NewLog(&Config{BufferSize: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	base := time.Now()
+	for i, method := range []string{"getAccountInfo", "getTransaction", "getAccountInfo", "getSignaturesForAddress"} {
+		log.Record(Entry{Timestamp: base.Add(time.Duration(i) * time.Second), Target: "solana-rpc", Method: method, StatusCode: http.StatusOK})
+	}
+
+	recent := log.Recent(Query{})
+	if len(recent) != 3 {
+		t.Fatalf("Expected ring buffer to keep 3 entries, got %d", len(recent))
+	}
+	if recent[0].Method != "getSignaturesForAddress" || recent[2].Method != "getTransaction" {
+		t.Errorf("Expected newest first with oldest entry evicted, got %v", recent)
+	}
+
+	filtered := log.Recent(Query{Method: "getAccountInfo"})
+	if len(filtered) != 1 {
+		t.Errorf("Expected 1 getAccountInfo entry, got %d", len(filtered))
+	}
+
+	limited := log.Recent(Query{Limit: 1})
+	if len(limited) != 1 || limited[0].Method != "getSignaturesForAddress" {
+		t.Errorf("Expected limit to return the newest entry, got %v", limited)
+	}
+}
+
+func TestTransport_RecordsCallMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	log, _ := NewLog(nil)
+	client := &http.Client{Transport: NewTransport(nil, log)}
+
+	req, _ := http.NewRequestWithContext(WithCall(context.Background(), "solana-rpc", "getAccountInfo", 2), http.MethodPost, server.URL+"/rpc?api-key=secret", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	entries := log.Recent(Query{})
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Target != "solana-rpc" || entry.Method != "getAccountInfo" || entry.RetryCount != 2 {
+		t.Errorf("Unexpected call metadata: %+v", entry)
+	}
+	if entry.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429, got %d", entry.StatusCode)
+	}
+	if entry.Path != "/rpc" {
+		t.Errorf("Expected query string to be dropped from path, got %q", entry.Path)
+	}
+}
+
+func TestTransport_DefaultsAndErrors(t *testing.T) {
+	log, _ := NewLog(nil)
+	client := &http.Client{Transport: NewTransport(nil, log)}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:0/latest", nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("Expected connection error")
+	}
+
+	entry := log.Recent(Query{})[0]
+	if entry.Method != "GET /latest" || entry.Target != "127.0.0.1:0" {
+		t.Errorf("Expected defaults derived from request, got %+v", entry)
+	}
+	if entry.Error == "" || entry.StatusCode != 0 {
+		t.Errorf("Expected transport error to be recorded, got %+v", entry)
+	}
+}
+
+func TestLog_RotatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := NewLog(&Config{FilePath: path, BufferSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer log.Close()
+
+	// Force rotation on every write after the first
+	log.file.maxBytes = 1
+	for i := 0; i < 4; i++ {
+		log.Record(Entry{Target: "dexscreener", Method: strings.Repeat("x", i+1)})
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatalf("Expected %s to exist: %v", name, err)
+		}
+		scanner := bufio.NewScanner(file)
+		if !scanner.Scan() {
+			t.Errorf("Expected %s to contain an entry", name)
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Errorf("Expected JSONL entry in %s: %v", name, err)
+		}
+		file.Close()
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected only %d backups to be retained", 2)
+	}
+}
+
+func TestLog_ReportsWriteErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := NewLog(&Config{FilePath: path, BufferSize: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Writes to the closed file fail, but the entry is still kept in memory
+	log.file.file.Close()
+	log.Record(Entry{Target: "dexscreener", Method: "GET"})
+	if !log.writeFailing.Load() {
+		t.Error("Expected the failed write to be reported")
+	}
+	if recent := log.Recent(Query{}); len(recent) != 1 {
+		t.Errorf("Expected the entry in the ring buffer, got %d", len(recent))
+	}
+
+	if err := log.file.open(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer log.Close()
+	log.Record(Entry{Target: "dexscreener", Method: "GET"})
+	if log.writeFailing.Load() {
+		t.Error("Expected a successful write to clear the failure")
+	}
+}
+
+func TestNewConfigFromEnvironment(t *testing.T) {
+	t.Setenv("AUDIT_LOG_FILE", "/tmp/audit.jsonl")
+	t.Setenv("AUDIT_BUFFER_SIZE", "50")
+	t.Setenv("AUDIT_LOG_MAX_SIZE_MB", "invalid")
+	t.Setenv("AUDIT_LOG_MAX_BACKUPS", "0")
+
+	config := NewConfigFromEnvironment()
+	if config.FilePath != "/tmp/audit.jsonl" || config.BufferSize != 50 {
+		t.Errorf("Expected file path and buffer size from env, got %+v", config)
+	}
+	if config.MaxSizeMB != DefaultMaxSizeMB {
+		t.Errorf("Expected invalid size to fall back to default, got %d", config.MaxSizeMB)
+	}
+	if config.MaxBackups != 0 {
+		t.Errorf("Expected zero backups to be allowed, got %d", config.MaxBackups)
+	}
+}
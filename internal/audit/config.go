@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Default audit log settings
+const (
+	DefaultBufferSize = 1000 // Recent entries kept in memory for the admin endpoint
+	DefaultMaxSizeMB  = 10   // Rotate the JSONL file once it reaches this size
+	DefaultMaxBackups = 5    // Number of rotated files to keep
+
+	bytesPerMB = 1024 * 1024
+)
+
+// Config holds audit log configuration
+type Config struct {
+	// FilePath is the JSONL file entries are appended to; empty keeps entries in memory only
+	FilePath string
+
+	// BufferSize is the number of recent entries kept in memory
+	BufferSize int
+
+	// MaxSizeMB is the file size that triggers rotation
+	MaxSizeMB int
+
+	// MaxBackups is the number of rotated files retained
+	MaxBackups int
+}
+
+// DefaultConfig returns an in-memory audit configuration
+func DefaultConfig() *Config {
+	return &Config{
+		BufferSize: DefaultBufferSize,
+		MaxSizeMB:  DefaultMaxSizeMB,
+		MaxBackups: DefaultMaxBackups,
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	config.FilePath = strings.TrimSpace(os.Getenv("AUDIT_LOG_FILE"))
+
+	if value := os.Getenv("AUDIT_BUFFER_SIZE"); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			config.BufferSize = size
+		}
+	}
+
+	if value := os.Getenv("AUDIT_LOG_MAX_SIZE_MB"); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			config.MaxSizeMB = size
+		}
+	}
+
+	if value := os.Getenv("AUDIT_LOG_MAX_BACKUPS"); value != "" {
+		if backups, err := strconv.Atoi(value); err == nil && backups >= 0 {
+			config.MaxBackups = backups
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,393 @@
+// Package casing renders response values with their JSON field names converted to snake_case or
+// camelCase, so one set of structs can serve clients expecting either convention
+// Only names that come from struct fields are converted; map keys such as token symbols and
+// wallet addresses are data and are rendered unchanged
+package casing
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Style is a field naming convention
+type Style string
+
+// Supported styles; StyleDeclared keeps the names in the json tags, which mix both conventions
+const (
+	StyleDeclared Style = ""
+	StyleSnake    Style = "snake_case"
+	StyleCamel    Style = "camelCase"
+)
+
+// ParseStyle resolves a style name case-insensitively; "declared" selects StyleDeclared
+func ParseStyle(name string) (Style, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "declared":
+		return StyleDeclared, true
+	case "snake_case":
+		return StyleSnake, true
+	case "camelcase":
+		return StyleCamel, true
+	}
+	return StyleDeclared, false
+}
+
+// Convert returns name in style
+func Convert(name string, style Style) string {
+	switch style {
+	case StyleSnake:
+		return toSnake(name)
+	case StyleCamel:
+		return toCamel(name)
+	}
+	return name
+}
+
+// toSnake splits words at lower-to-upper transitions and before the last capital of an acronym,
+// so xsolAmount becomes xsol_amount and hyUSDBalance hy_usd_balance
+func toSnake(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && runes[i-1] != '_' {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// toCamel joins underscore-separated words, capitalizing all but the first: xsol_amount becomes xsolAmount
+func toCamel(name string) string {
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(part)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	if b.Len() == 0 {
+		return name
+	}
+	return b.String()
+}
+
+// Field is one member of a rendered object
+type Field struct {
+	Name  string // Declared JSON name, or the map key
+	Key   string // Name as rendered
+	Value interface{}
+}
+
+// Object is a rendered struct or map whose fields encode in order under their rendered keys
+type Object []Field
+
+// MarshalJSON implements json.Marshaler
+func (o Object) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", field.Key, err)
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
+
+// Get returns the value of the field declared as name
+func (o Object) Get(name string) (interface{}, bool) {
+	for _, field := range o {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Project returns a copy with every item of the collection field trimmed to the selected declared names,
+// the rendered counterpart of fieldset.Project. Items may be listed in a slice or keyed in a map
+func (o Object) Project(collection string, selected map[string]bool) Object {
+	projected := make(Object, len(o))
+	copy(projected, o)
+	for i, field := range projected {
+		if field.Name != collection {
+			continue
+		}
+		switch items := field.Value.(type) {
+		case []interface{}:
+			trimmed := make([]interface{}, len(items))
+			for j, item := range items {
+				trimmed[j] = keep(item, selected)
+			}
+			projected[i].Value = trimmed
+		case Object:
+			trimmed := make(Object, len(items))
+			for j, item := range items {
+				trimmed[j] = Field{Name: item.Name, Key: item.Key, Value: keep(item.Value, selected)}
+			}
+			projected[i].Value = trimmed
+		}
+	}
+	return projected
+}
+
+// keep trims a rendered item to the fields whose declared name is selected
+func keep(item interface{}, selected map[string]bool) interface{} {
+	object, ok := item.(Object)
+	if !ok {
+		return item
+	}
+	kept := make(Object, 0, len(selected))
+	for _, field := range object {
+		if selected[field.Name] {
+			kept = append(kept, field)
+		}
+	}
+	return kept
+}
+
+// Render converts v into Objects, slices and scalars that encode like v does with encoding/json,
+// except that struct field names are converted to style. StyleDeclared returns v unchanged
+// Values with their own MarshalJSON are left to it and keep their names; times stay time.Time so
+// binary encoders can still use native timestamps
+func Render(v interface{}, style Style) interface{} {
+	if style == StyleDeclared || v == nil {
+		return v
+	}
+	return render(reflect.ValueOf(v), style)
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	objectType        = reflect.TypeOf(Object(nil))
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func render(v reflect.Value, style Style) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	t := v.Type()
+	switch {
+	case t == timeType || t == objectType:
+		return v.Interface()
+	case t.Implements(marshalerType):
+		if t.Kind() == reflect.Pointer && v.IsNil() {
+			return nil
+		}
+		return v.Interface()
+	case t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(marshalerType):
+		copied := reflect.New(t)
+		copied.Elem().Set(v)
+		return copied.Interface()
+	case t.Implements(textMarshalerType) && t.Kind() != reflect.Pointer:
+		return v.Interface()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return render(v.Elem(), style)
+	case reflect.Struct:
+		return renderStruct(v, style)
+	case reflect.Map:
+		return renderMap(v, style)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return v.Interface() // []byte encodes as base64
+		}
+		fallthrough
+	case reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = render(v.Index(i), style)
+		}
+		return items
+	}
+	return v.Interface()
+}
+
+func renderStruct(v reflect.Value, style Style) Object {
+	fields := structFields(v.Type())
+	object := make(Object, 0, len(fields))
+	for _, field := range fields {
+		value, ok := fieldByIndex(v, field.index)
+		if !ok || (field.omitEmpty && isEmpty(value)) {
+			continue
+		}
+		object = append(object, Field{Name: field.name, Key: Convert(field.name, style), Value: render(value, style)})
+	}
+	return object
+}
+
+// renderMap keeps keys as they are and sorts them like encoding/json does
+func renderMap(v reflect.Value, style Style) interface{} {
+	if v.IsNil() {
+		return nil
+	}
+	object := make(Object, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key := mapKey(iter.Key())
+		object = append(object, Field{Name: key, Key: key, Value: render(iter.Value(), style)})
+	}
+	sort.Slice(object, func(i, j int) bool { return object[i].Key < object[j].Key })
+	return object
+}
+
+func mapKey(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+	if marshaler, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(key.Interface())
+}
+
+// fieldByIndex follows an embedded field path, reporting false when it passes through a nil pointer
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, position := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(position)
+	}
+	return v, true
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// encodedField is a struct field encoding/json would write
+type encodedField struct {
+	name      string
+	index     []int
+	depth     int
+	omitEmpty bool
+}
+
+// fieldCache holds the encoded fields of each struct type
+var fieldCache sync.Map // map[reflect.Type][]encodedField
+
+// structFields lists the fields of t in declaration order, with untagged embedded structs promoted
+// When names collide the shallowest field wins, as with encoding/json
+func structFields(t reflect.Type) []encodedField {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]encodedField)
+	}
+
+	all := collectFields(t, nil)
+	depths := make(map[string]int, len(all))
+	for _, field := range all {
+		if depth, ok := depths[field.name]; !ok || field.depth < depth {
+			depths[field.name] = field.depth
+		}
+	}
+	fields := make([]encodedField, 0, len(all))
+	seen := make(map[string]bool, len(all))
+	for _, field := range all {
+		if field.depth != depths[field.name] || seen[field.name] {
+			continue
+		}
+		seen[field.name] = true
+		fields = append(fields, field)
+	}
+
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+func collectFields(t reflect.Type, index []int) []encodedField {
+	var fields []encodedField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		path := append(append([]int(nil), index...), i)
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, collectFields(embedded, path)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, encodedField{
+			name:      name,
+			index:     path,
+			depth:     len(index),
+			omitEmpty: strings.Contains(","+options+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
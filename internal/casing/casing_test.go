@@ -0,0 +1,103 @@
+package casing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		name, snake, camel string
+	}{
+		{"xsolAmount", "xsol_amount", "xsolAmount"},
+		{"historical_price_usd", "historical_price_usd", "historicalPriceUsd"},
+		{"hyUSDBalance", "hy_usd_balance", "hyUSDBalance"},
+		{"price_24h_pct", "price_24h_pct", "price24hPct"},
+		{"blockTime", "block_time", "blockTime"},
+		{"status", "status", "status"},
+	}
+	for _, tc := range cases {
+		if got := Convert(tc.name, StyleSnake); got != tc.snake {
+			t.Errorf("Convert(%q, snake) = %q, want %q", tc.name, got, tc.snake)
+		}
+		if got := Convert(tc.name, StyleCamel); got != tc.camel {
+			t.Errorf("Convert(%q, camel) = %q, want %q", tc.name, got, tc.camel)
+		}
+	}
+}
+
+type inner struct {
+	TokenSymbol string `json:"token_symbol"`
+}
+
+type Embedded struct {
+	RequestID string `json:"request_id,omitempty"`
+}
+
+type outer struct {
+	Embedded
+	BlockTime int64              `json:"blockTime"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	Balances  map[string]inner   `json:"balances"`
+	Items     []*inner           `json:"items,omitempty"`
+	Raw       json.RawMessage    `json:"raw_json"`
+	Optional  *inner             `json:"optional_value,omitempty"`
+	Skipped   string             `json:"-"`
+	Nested    map[string]float64 `json:"nested_map,omitempty"`
+	hidden    string
+}
+
+func TestRender(t *testing.T) {
+	value := outer{
+		Embedded:  Embedded{RequestID: "req-1"},
+		BlockTime: 10,
+		UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Balances:  map[string]inner{"hyUSD": {TokenSymbol: "hyUSD"}},
+		Raw:       json.RawMessage(`{"kept_as":1}`),
+		Skipped:   "x",
+		hidden:    "y",
+	}
+
+	encoded, err := json.Marshal(Render(value, StyleCamel))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"requestId":"req-1","blockTime":10,"updatedAt":"2025-01-01T00:00:00Z","balances":{"hyUSD":{"tokenSymbol":"hyUSD"}},"rawJson":{"kept_as":1}}`
+	if string(encoded) != want {
+		t.Errorf("Render camelCase:\n got %s\nwant %s", encoded, want)
+	}
+
+	encoded, _ = json.Marshal(Render(&value, StyleSnake))
+	want = `{"request_id":"req-1","block_time":10,"updated_at":"2025-01-01T00:00:00Z","balances":{"hyUSD":{"token_symbol":"hyUSD"}},"raw_json":{"kept_as":1}}`
+	if string(encoded) != want {
+		t.Errorf("Render snake_case:\n got %s\nwant %s", encoded, want)
+	}
+
+	if rendered := Render(value, StyleDeclared); rendered.(outer).BlockTime != 10 {
+		t.Errorf("Expected the declared style to return the value unchanged, got %#v", rendered)
+	}
+}
+
+func TestObjectProject(t *testing.T) {
+	value := outer{
+		Balances: map[string]inner{"xSOL": {TokenSymbol: "xSOL"}},
+		Items:    []*inner{{TokenSymbol: "hyUSD"}},
+	}
+	object := Render(value, StyleCamel).(Object)
+
+	projected := object.Project("items", map[string]bool{"token_symbol": true})
+	items, _ := projected.Get("items")
+	if encoded, _ := json.Marshal(items); string(encoded) != `[{"tokenSymbol":"hyUSD"}]` {
+		t.Errorf("Expected items selected by declared name, got %s", encoded)
+	}
+
+	projected = object.Project("balances", map[string]bool{"tokenSymbol": true})
+	balances, _ := projected.Get("balances")
+	if encoded, _ := json.Marshal(balances); string(encoded) != `{"xSOL":{}}` {
+		t.Errorf("Expected rendered names not to select fields, got %s", encoded)
+	}
+	if original, _ := object.Get("balances"); len(original.(Object)[0].Value.(Object)) != 1 {
+		t.Error("Expected the rendered object to be left untouched")
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers carrying the admin request signature
+const (
+	HeaderAdminTimestamp = "X-Hylo-Timestamp" // Unix seconds the request was signed at
+	HeaderAdminSignature = "X-Hylo-Signature" // Hex HMAC-SHA256 or Ed25519 signature of the signing payload
+)
+
+// DefaultAdminSignatureTolerance is the clock skew accepted between signer and server; override via ADMIN_SIGNATURE_TOLERANCE_SEC
+const DefaultAdminSignatureTolerance = 5 * time.Minute
+
+// Admin request signature schemes, selected by which key is configured
+const (
+	AdminSignatureNone    = ""
+	AdminSignatureHMAC    = "hmac"
+	AdminSignatureEd25519 = "ed25519"
+)
+
+// AdminSignature requires /admin requests to be signed with a configured key
+// The signed payload is "<timestamp>.<METHOD>.<request URI>.<body>", so a captured signature
+// cannot be replayed against another endpoint or outside the tolerance window
+type AdminSignature struct {
+	Secret    string            // HMAC-SHA256 shared secret (ADMIN_SIGNING_SECRET)
+	PublicKey ed25519.PublicKey // Ed25519 public key (ADMIN_SIGNING_PUBLIC_KEY, hex or base64)
+	Tolerance time.Duration     // Accepted clock skew in either direction
+
+	// publicKeyErr records a malformed ADMIN_SIGNING_PUBLIC_KEY for Validate
+	publicKeyErr error
+}
+
+// Scheme returns the configured signature scheme, or AdminSignatureNone when signing is disabled
+func (a *AdminSignature) Scheme() string {
+	switch {
+	case a == nil:
+		return AdminSignatureNone
+	case a.Secret != "":
+		return AdminSignatureHMAC
+	case len(a.PublicKey) > 0:
+		return AdminSignatureEd25519
+	default:
+		return AdminSignatureNone
+	}
+}
+
+// Enabled reports whether admin requests must be signed
+func (a *AdminSignature) Enabled() bool {
+	return a.Scheme() != AdminSignatureNone
+}
+
+// NewAdminSignatureFromEnvironment loads the admin signing key, falling back to the default tolerance for missing or invalid values
+func NewAdminSignatureFromEnvironment() *AdminSignature {
+	signature := &AdminSignature{
+		Secret:    os.Getenv("ADMIN_SIGNING_SECRET"),
+		Tolerance: DefaultAdminSignatureTolerance,
+	}
+
+	if value := strings.TrimSpace(os.Getenv("ADMIN_SIGNING_PUBLIC_KEY")); value != "" {
+		signature.PublicKey, signature.publicKeyErr = parseEd25519PublicKey(value)
+	}
+
+	if value := os.Getenv("ADMIN_SIGNATURE_TOLERANCE_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			signature.Tolerance = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return signature
+}
+
+// Validate rejects malformed keys and ambiguous configuration so signing never silently turns off
+func (a *AdminSignature) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.publicKeyErr != nil {
+		return fmt.Errorf("invalid ADMIN_SIGNING_PUBLIC_KEY: %w", a.publicKeyErr)
+	}
+	if a.Secret != "" && len(a.PublicKey) > 0 {
+		return errors.New("ADMIN_SIGNING_SECRET and ADMIN_SIGNING_PUBLIC_KEY are mutually exclusive")
+	}
+	return nil
+}
+
+// SignAdminRequest returns the hex HMAC-SHA256 signature of a request for the HMAC scheme
+func SignAdminRequest(secret string, timestamp int64, method, requestURI string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(adminSigningPayload(timestamp, method, requestURI, body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a request's timestamp and signature headers against its method, URI and body
+func (a *AdminSignature) Verify(method, requestURI, timestampHeader, signatureHeader string, body []byte, now time.Time) error {
+	if timestampHeader == "" || signatureHeader == "" {
+		return fmt.Errorf("missing %s or %s header", HeaderAdminTimestamp, HeaderAdminSignature)
+	}
+
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: must be unix seconds", HeaderAdminTimestamp)
+	}
+	skew := now.Sub(time.Unix(timestamp, 0))
+	if skew > a.Tolerance || skew < -a.Tolerance {
+		return fmt.Errorf("signature timestamp outside the %s tolerance", a.Tolerance)
+	}
+
+	signature, err := hex.DecodeString(signatureHeader)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: must be hex encoded", HeaderAdminSignature)
+	}
+
+	switch a.Scheme() {
+	case AdminSignatureHMAC:
+		expected, _ := hex.DecodeString(SignAdminRequest(a.Secret, timestamp, method, requestURI, body))
+		if !hmac.Equal(expected, signature) {
+			return errors.New("invalid signature")
+		}
+	case AdminSignatureEd25519:
+		if !ed25519.Verify(a.PublicKey, adminSigningPayload(timestamp, method, requestURI, body), signature) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return errors.New("admin request signing is not configured")
+	}
+	return nil
+}
+
+// adminSigningPayload builds the bytes covered by an admin request signature
+func adminSigningPayload(timestamp int64, method, requestURI string, body []byte) []byte {
+	payload := fmt.Appendf(nil, "%d.%s.%s.", timestamp, strings.ToUpper(method), requestURI)
+	return append(payload, body...)
+}
+
+// parseEd25519PublicKey accepts a raw 32-byte public key encoded as hex or standard base64
+func parseEd25519PublicKey(value string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(value)
+	if err != nil {
+		if key, err = base64.StdEncoding.DecodeString(value); err != nil {
+			return nil, errors.New("must be hex or base64 encoded")
+		}
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("must be %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAdminSignatureHMAC(t *testing.T) {
+	signing := &AdminSignature{Secret: "admin-secret", Tolerance: DefaultAdminSignatureTolerance}
+	now := time.Unix(1_700_000_000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"limit":5}`)
+	signature := SignAdminRequest("admin-secret", now.Unix(), "GET", "/admin/audit?limit=5", body)
+
+	if err := signing.Verify("GET", "/admin/audit?limit=5", timestamp, signature, body, now.Add(time.Minute)); err != nil {
+		t.Errorf("Expected valid signature within tolerance, got %v", err)
+	}
+
+	for name, verify := range map[string]func() error{
+		"missing headers": func() error { return signing.Verify("GET", "/admin/audit?limit=5", "", "", body, now) },
+		"other endpoint":  func() error { return signing.Verify("GET", "/admin/audit?limit=500", timestamp, signature, body, now) },
+		"other method":    func() error { return signing.Verify("POST", "/admin/audit?limit=5", timestamp, signature, body, now) },
+		"tampered body": func() error {
+			return signing.Verify("GET", "/admin/audit?limit=5", timestamp, signature, []byte(`{}`), now)
+		},
+		"stale": func() error {
+			return signing.Verify("GET", "/admin/audit?limit=5", timestamp, signature, body, now.Add(6*time.Minute))
+		},
+		"future": func() error {
+			return signing.Verify("GET", "/admin/audit?limit=5", timestamp, signature, body, now.Add(-6*time.Minute))
+		},
+		"not hex": func() error { return signing.Verify("GET", "/admin/audit?limit=5", timestamp, "zz", body, now) },
+	} {
+		if err := verify(); err == nil {
+			t.Errorf("%s: expected the request to be rejected", name)
+		}
+	}
+}
+
+func TestAdminSignatureEd25519(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	t.Setenv("ADMIN_SIGNING_SECRET", "")
+	t.Setenv("ADMIN_SIGNING_PUBLIC_KEY", base64.StdEncoding.EncodeToString(public))
+	t.Setenv("ADMIN_SIGNATURE_TOLERANCE_SEC", "30")
+
+	signing := NewAdminSignatureFromEnvironment()
+	if err := signing.Validate(); err != nil || signing.Scheme() != AdminSignatureEd25519 || signing.Tolerance != 30*time.Second {
+		t.Fatalf("Expected an Ed25519 config with 30s tolerance, got %s %v (%v)", signing.Scheme(), signing.Tolerance, err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	signature := hex.EncodeToString(ed25519.Sign(private, adminSigningPayload(now.Unix(), "GET", "/admin/audit", nil)))
+	if err := signing.Verify("GET", "/admin/audit", strconv.FormatInt(now.Unix(), 10), signature, nil, now); err != nil {
+		t.Errorf("Expected valid Ed25519 signature, got %v", err)
+	}
+	if err := signing.Verify("GET", "/admin/audit", strconv.FormatInt(now.Unix(), 10), signature, nil, now.Add(time.Minute)); err == nil {
+		t.Error("Expected a signature older than the tolerance to be rejected")
+	}
+}
+
+func TestAdminSignatureValidate(t *testing.T) {
+	t.Setenv("ADMIN_SIGNING_SECRET", "")
+	t.Setenv("ADMIN_SIGNING_PUBLIC_KEY", "")
+	if signing := NewAdminSignatureFromEnvironment(); signing.Enabled() || signing.Validate() != nil {
+		t.Error("Expected signing to be disabled without a key")
+	}
+
+	t.Setenv("ADMIN_SIGNING_PUBLIC_KEY", "abcd")
+	if err := NewAdminSignatureFromEnvironment().Validate(); err == nil {
+		t.Error("Expected a short public key to be rejected")
+	}
+
+	t.Setenv("ADMIN_SIGNING_SECRET", "secret")
+	t.Setenv("ADMIN_SIGNING_PUBLIC_KEY", hex.EncodeToString(make([]byte, ed25519.PublicKeySize)))
+	if err := NewAdminSignatureFromEnvironment().Validate(); err == nil {
+		t.Error("Expected both keys together to be rejected")
+	}
+}
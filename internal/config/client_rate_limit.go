@@ -0,0 +1,75 @@
+package config
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Default per-client request limits; override via HTTP_CLIENT_RPS, HTTP_CLIENT_BURST and HTTP_CLIENT_KEY_HEADER
+const (
+	DefaultClientRPS   = 10.0
+	DefaultClientBurst = 40
+)
+
+// ClientRateLimit is the token bucket each API client draws from; requests beyond it get 429 with Retry-After
+type ClientRateLimit struct {
+	RPS   float64 // Sustained requests per second per client; 0 disables the limiter
+	Burst int     // Requests a client may send back to back after an idle period
+
+	// KeyHeader names a request header identifying the client, such as X-Forwarded-For behind a
+	// trusted proxy or an API key header; the remote IP is used when it is empty or absent. For
+	// comma-separated lists the right-most entry is used, the one the trusted proxy appended, since
+	// every entry before it is sent by the client
+	KeyHeader string
+}
+
+// ClientKey identifies the client a request is counted against
+func (c *ClientRateLimit) ClientKey(r *http.Request) string {
+	if c.KeyHeader != "" {
+		values := r.Header.Values(c.KeyHeader)
+		if len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if key := strings.TrimSpace(entries[len(entries)-1]); key != "" {
+				return key
+			}
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// Enabled reports whether requests are limited per client
+func (c *ClientRateLimit) Enabled() bool {
+	return c != nil && c.RPS > 0
+}
+
+// NewClientRateLimitFromEnvironment loads per-client limits, falling back to defaults for missing or invalid values
+func NewClientRateLimitFromEnvironment() *ClientRateLimit {
+	limit := &ClientRateLimit{
+		RPS:   DefaultClientRPS,
+		Burst: DefaultClientBurst,
+	}
+
+	if value := os.Getenv("HTTP_CLIENT_RPS"); value != "" {
+		if rps, err := strconv.ParseFloat(value, 64); err == nil && rps >= 0 {
+			limit.RPS = rps
+		}
+	}
+
+	if value := os.Getenv("HTTP_CLIENT_BURST"); value != "" {
+		if burst, err := strconv.Atoi(value); err == nil && burst > 0 {
+			limit.Burst = burst
+		}
+	}
+
+	if value := strings.TrimSpace(os.Getenv("HTTP_CLIENT_KEY_HEADER")); value != "" {
+		limit.KeyHeader = http.CanonicalHeaderKey(value)
+	}
+
+	return limit
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientRateLimitClientKey(t *testing.T) {
+	limit := &ClientRateLimit{RPS: 1, Burst: 1, KeyHeader: "X-Forwarded-For"}
+
+	request := func(remoteAddr string, forwarded ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/price", nil)
+		r.RemoteAddr = remoteAddr
+		for _, value := range forwarded {
+			r.Header.Add("X-Forwarded-For", value)
+		}
+		return r
+	}
+
+	// The proxy appends the address it saw, so spoofed entries in front of it never change the key
+	for _, spoofed := range []string{"1.1.1.1", "2.2.2.2, 3.3.3.3", ""} {
+		r := request("10.0.0.1:4000", spoofed+", 203.0.113.7")
+		if key := limit.ClientKey(r); key != "203.0.113.7" {
+			t.Errorf("Expected the proxy-appended address behind spoofed %q, got %q", spoofed, key)
+		}
+	}
+	if key := limit.ClientKey(request("10.0.0.1:4000", "1.1.1.1", "203.0.113.7")); key != "203.0.113.7" {
+		t.Errorf("Expected the last header line to win, got %q", key)
+	}
+	if key := limit.ClientKey(request("10.0.0.1:4000", "203.0.113.7, ")); key != "10.0.0.1" {
+		t.Errorf("Expected an empty trailing entry to fall back to the remote IP, got %q", key)
+	}
+	if key := limit.ClientKey(request("10.0.0.1:4000")); key != "10.0.0.1" {
+		t.Errorf("Expected the remote IP without the header, got %q", key)
+	}
+
+	limit.KeyHeader = ""
+	if key := limit.ClientKey(request("10.0.0.1:4000", "203.0.113.7")); key != "10.0.0.1" {
+		t.Errorf("Expected the header to be ignored when not configured, got %q", key)
+	}
+}
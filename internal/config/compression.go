@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultCompressionMinBytes is the smallest response body worth compressing
+const DefaultCompressionMinBytes = 1024
+
+// DefaultCompressionExcludedTypes are never compressed; SSE and NDJSON streams must reach clients unbuffered
+var DefaultCompressionExcludedTypes = []string{"text/event-stream", "application/x-ndjson"}
+
+// Compression controls gzip/brotli response compression negotiated via Accept-Encoding
+type Compression struct {
+	Enabled       bool
+	MinBytes      int
+	ExcludedTypes []string // Media types sent uncompressed, matched without parameters
+}
+
+// NewCompressionFromEnvironment loads compression settings, falling back to defaults for missing or invalid values
+func NewCompressionFromEnvironment() *Compression {
+	compression := &Compression{
+		Enabled:       true,
+		MinBytes:      DefaultCompressionMinBytes,
+		ExcludedTypes: DefaultCompressionExcludedTypes,
+	}
+
+	if value := os.Getenv("HTTP_COMPRESSION_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			compression.Enabled = enabled
+		}
+	}
+
+	if value := os.Getenv("HTTP_COMPRESSION_MIN_BYTES"); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size >= 0 {
+			compression.MinBytes = size
+		}
+	}
+
+	if value := os.Getenv("HTTP_COMPRESSION_EXCLUDED_TYPES"); value != "" {
+		var types []string
+		for _, mediaType := range strings.Split(value, ",") {
+			if mediaType = strings.ToLower(strings.TrimSpace(mediaType)); mediaType != "" {
+				types = append(types, mediaType)
+			}
+		}
+		compression.ExcludedTypes = types
+	}
+
+	return compression
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default in-flight request limits; override via HTTP_MAX_IN_FLIGHT, HTTP_QUEUE_DEPTH, HTTP_QUEUE_TIMEOUT_MS,
+// HTTP_MAX_TRADE_STREAMS and HTTP_MAX_SSE_STREAMS
+const (
+	DefaultMaxInFlight     = 64
+	DefaultQueueDepth      = 128
+	DefaultQueueTimeout    = 2 * time.Second
+	DefaultMaxTradeStreams = 4
+	DefaultMaxSSEStreams   = 100
+)
+
+// Concurrency bounds how many API requests are served at once before new ones queue or are shed with 503
+type Concurrency struct {
+	MaxInFlight  int           // Requests handled concurrently; 0 disables the limiter
+	QueueDepth   int           // Requests allowed to wait for a slot; 0 sheds as soon as every slot is busy
+	QueueTimeout time.Duration // Longest a queued request waits for a slot
+
+	// MaxTradeStreams caps concurrent full trade history walks, which outlive the in-flight limiter; 0 disables the cap
+	MaxTradeStreams int
+
+	// MaxSSEStreams caps concurrent wallet and supply Server-Sent Event streams, which outlive the in-flight
+	// limiter too; 0 disables the cap
+	MaxSSEStreams int
+}
+
+// Enabled reports whether in-flight requests are limited
+func (c *Concurrency) Enabled() bool {
+	return c != nil && c.MaxInFlight > 0
+}
+
+// NewConcurrencyFromEnvironment loads in-flight limits, falling back to defaults for missing or invalid values
+func NewConcurrencyFromEnvironment() *Concurrency {
+	concurrency := &Concurrency{
+		MaxInFlight:     DefaultMaxInFlight,
+		QueueDepth:      DefaultQueueDepth,
+		QueueTimeout:    DefaultQueueTimeout,
+		MaxTradeStreams: DefaultMaxTradeStreams,
+		MaxSSEStreams:   DefaultMaxSSEStreams,
+	}
+
+	for env, target := range map[string]*int{
+		"HTTP_MAX_IN_FLIGHT":     &concurrency.MaxInFlight,
+		"HTTP_QUEUE_DEPTH":       &concurrency.QueueDepth,
+		"HTTP_MAX_TRADE_STREAMS": &concurrency.MaxTradeStreams,
+		"HTTP_MAX_SSE_STREAMS":   &concurrency.MaxSSEStreams,
+	} {
+		if value := os.Getenv(env); value != "" {
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				*target = n
+			}
+		}
+	}
+
+	if value := os.Getenv("HTTP_QUEUE_TIMEOUT_MS"); value != "" {
+		if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+			concurrency.QueueTimeout = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	return concurrency
+}
@@ -0,0 +1,271 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"hylo-wallet-tracker-api/internal/audit"
+	"hylo-wallet-tracker-api/internal/digest"
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/fx"
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/indexer"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/peg"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/sanctum"
+	"hylo-wallet-tracker-api/internal/scheduler"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/stream"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/warmstart"
+	"hylo-wallet-tracker-api/internal/warmup"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// EnvConfigFile points to an optional dotenv-style file loaded before the environment is read
+// Variables already set in the process environment take precedence over the file
+const EnvConfigFile = "CONFIG_FILE"
+
+// DefaultPort is used when PORT is not set
+const DefaultPort = 8080
+
+// Solana client overrides applied on top of solana.NewConfig defaults for the API server
+const (
+	solanaMaxBackoff       = 10 * time.Second
+	solanaReconnectTimeout = 30 * time.Second
+)
+
+// Config is the complete application configuration, loaded and validated once at startup
+// Each service receives its typed sub-config instead of reading the environment itself
+type Config struct {
+	// Network is the Solana network profile the RPC endpoints and Hylo addresses must match
+	Network Network
+
+	HTTP                HTTPConfig
+	Logger              logger.Config
+	Solana              *solana.Config
+	Scheduler           *scheduler.Config
+	Tokens              *tokens.Config
+	Hylo                *hylo.Config
+	ClassificationRules *hylo.ClassificationRules
+	Stablecoins         *hylo.Stablecoins
+	Price               *price.PriceConfig
+	Sanctum             *sanctum.Config
+	FX                  *fx.Config
+	Explorer            *explorer.Config
+	Audit               *audit.Config
+	Store               *store.Config
+	Indexer             *indexer.Config
+	Webhook             *webhook.Config
+	Digest              *digest.Config
+	Peg                 *peg.Config
+	Warmstart           *warmstart.Config
+	Warmup              *warmup.Config
+	Supply              *stream.SupplyConfig
+	Stream              *stream.Config
+}
+
+// HTTPConfig holds API server settings
+type HTTPConfig struct {
+	// Port the HTTP server listens on
+	Port int
+
+	// AdminAPIKey is the bearer token for /admin endpoints; empty disables them
+	AdminAPIKey string
+
+	// AdminSignature optionally requires /admin requests to carry a timestamped HMAC or Ed25519 signature
+	AdminSignature *AdminSignature
+
+	// RouteLimits holds per-route deadlines and the POST body size limit
+	RouteLimits *RouteLimits
+
+	// Compression configures Accept-Encoding negotiated response compression
+	Compression *Compression
+
+	// Concurrency bounds in-flight requests and the queue in front of them
+	Concurrency *Concurrency
+
+	// ClientRateLimit bounds the request rate of each API client
+	ClientRateLimit *ClientRateLimit
+
+	// Debug exposes pprof, expvar and runtime stats under /debug
+	Debug *Debug
+
+	// ResponseProfile is the default field naming and envelope of response bodies
+	ResponseProfile *ResponseProfile
+
+	// BucketTimezone is the default zone trade aggregates and activity heatmaps are bucketed in
+	BucketTimezone *time.Location
+
+	// Maintenance is the read-only mode the server starts in
+	Maintenance *Maintenance
+
+	// Tenants maps API keys to tenants and holds their quotas; empty keeps the API single-tenant
+	Tenants *Tenants
+}
+
+// Load reads CONFIG_FILE (if set) and the environment, then validates the result
+// Returns every validation problem at once so misconfiguration is fixed in one pass
+func Load() (*Config, error) {
+	if path := strings.TrimSpace(os.Getenv(EnvConfigFile)); path != "" {
+		if err := godotenv.Load(path); err != nil {
+			return nil, fmt.Errorf("failed to load %s %s: %w", EnvConfigFile, path, err)
+		}
+	}
+
+	config, err := loadFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// loadFromEnvironment builds every sub-config from environment variables without validating them
+func loadFromEnvironment() (*Config, error) {
+	config := &Config{
+		Network: Network(strings.ToLower(strings.TrimSpace(getEnv("SOLANA_NETWORK", string(NetworkMainnet))))),
+		HTTP: HTTPConfig{
+			Port:            DefaultPort,
+			AdminAPIKey:     os.Getenv("ADMIN_API_KEY"),
+			AdminSignature:  NewAdminSignatureFromEnvironment(),
+			RouteLimits:     NewRouteLimitsFromEnvironment(),
+			Compression:     NewCompressionFromEnvironment(),
+			Concurrency:     NewConcurrencyFromEnvironment(),
+			ClientRateLimit: NewClientRateLimitFromEnvironment(),
+			Debug:           NewDebugFromEnvironment(),
+			ResponseProfile: NewResponseProfileFromEnvironment(),
+			BucketTimezone:  NewBucketTimezoneFromEnvironment(),
+			Maintenance:     NewMaintenanceFromEnvironment(),
+			Tenants:         NewTenantsFromEnvironment(),
+		},
+		Logger: logger.Config{
+			Level:       getEnv("LOG_LEVEL", "info"),
+			Format:      getEnv("LOG_FORMAT", "json"),
+			ServiceName: getEnv("SERVICE_NAME", "wallet-tracker-api"),
+			Version:     getEnv("SERVICE_VERSION", "dev"),
+		},
+		Scheduler: scheduler.NewConfigFromEnvironment(),
+		Tokens:    tokens.NewConfig(),
+		Hylo:      hylo.NewConfig(),
+		Price:     price.NewConfigFromEnvironment(),
+		Sanctum:   sanctum.NewConfigFromEnvironment(),
+		FX:        fx.NewConfigFromEnvironment(),
+		Explorer:  explorer.NewConfigFromEnvironment(),
+		Audit:     audit.NewConfigFromEnvironment(),
+		Store:     store.NewConfigFromEnvironment(),
+		Indexer:   indexer.NewConfigFromEnvironment(),
+		Webhook:   webhook.NewConfigFromEnvironment(),
+		Digest:    digest.NewConfigFromEnvironment(),
+		Peg:       peg.NewConfigFromEnvironment(),
+		Warmstart: warmstart.NewConfigFromEnvironment(),
+		Warmup:    warmup.NewConfigFromEnvironment(),
+		Supply:    stream.NewSupplyConfigFromEnvironment(),
+		Stream:    stream.NewConfigFromEnvironment(),
+	}
+
+	if value := os.Getenv("PORT"); value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PORT %q: must be an integer", value)
+		}
+		config.HTTP.Port = port
+	}
+
+	config.Solana = solana.NewConfig(os.Getenv("RPC_HTTP_URL"), os.Getenv("RPC_WS_URL"))
+	config.Solana.Chain = solana.ChainID(strings.ToLower(strings.TrimSpace(getEnv("CHAIN_ID", string(solana.ChainSolana)))))
+	config.Solana.MaxBackoff = solanaMaxBackoff
+	config.Solana.ReconnectTimeout = solanaReconnectTimeout
+	if value := os.Getenv("SOLANA_RPC_TIMEOUT_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.Solana.RequestTimeout = time.Duration(seconds) * time.Second
+		}
+	}
+	config.Solana.LoadTransportFromEnvironment()
+	config.Solana.LoadWebSocketFromEnvironment()
+	config.Solana.LoadArchiveFromEnvironment()
+
+	rules, err := hylo.LoadClassificationRulesFromEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trade classification rules: %w", err)
+	}
+	config.ClassificationRules = rules
+
+	stablecoins, err := hylo.LoadStablecoinsFromEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stablecoin pegs: %w", err)
+	}
+	config.Stablecoins = stablecoins
+
+	return config, nil
+}
+
+// Validate checks every sub-config and the consistency between them
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(section string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", section, err))
+		}
+	}
+
+	if c.HTTP.Port < 0 || c.HTTP.Port > 65535 {
+		check("http", fmt.Errorf("invalid port %d: must be between 0 and 65535", c.HTTP.Port))
+	}
+	check("http", c.HTTP.AdminSignature.Validate())
+	check("http", c.HTTP.Tenants.Validate())
+	check("logger", validateLogger(c.Logger))
+	check("solana", c.Solana.Validate())
+	check("tokens", c.Tokens.Validate())
+	check("hylo", c.Hylo.Validate())
+	check("price", c.Price.Validate())
+	check("indexer", validateIndexer(c.Indexer))
+	check("network", c.validateNetwork())
+
+	return errors.Join(errs...)
+}
+
+func validateLogger(config logger.Config) error {
+	switch strings.ToLower(config.Level) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		return fmt.Errorf("invalid LOG_LEVEL %q: must be debug, info, warn or error", config.Level)
+	}
+
+	switch strings.ToLower(config.Format) {
+	case "json", "text":
+	default:
+		return fmt.Errorf("invalid LOG_FORMAT %q: must be json or text", config.Format)
+	}
+
+	return nil
+}
+
+// validateIndexer rejects malformed TRACKED_WALLETS entries instead of failing on the first sync
+func validateIndexer(config *indexer.Config) error {
+	for _, wallet := range config.SeedWallets {
+		if _, err := tokens.SanitizeAddress(wallet); err != nil {
+			return fmt.Errorf("invalid TRACKED_WALLETS entry %q: %w", wallet, err)
+		}
+	}
+	return nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
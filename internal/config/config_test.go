@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+func setMainnetEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("RPC_HTTP_URL", "https://mainnet.helius-rpc.com/?api-key=test")
+	t.Setenv("RPC_WS_URL", "wss://mainnet.helius-rpc.com/?api-key=test")
+	t.Setenv("SOLANA_NETWORK", "")
+	t.Setenv("CHAIN_ID", "")
+	t.Setenv("PORT", "")
+}
+
+func TestLoadDefaults(t *testing.T) {
+	setMainnetEnv(t)
+	t.Setenv("SOLANA_RPC_TIMEOUT_SEC", "12")
+	t.Setenv("HTTP_TIMEOUT_TRADES_SEC", "45")
+	t.Setenv("HTTP_COMPRESSION_EXCLUDED_TYPES", " Text/Event-Stream, application/x-ndjson ")
+	t.Setenv("HTTP_MAX_IN_FLIGHT", "0")
+	t.Setenv("HTTP_QUEUE_TIMEOUT_MS", "-5")
+	t.Setenv("HTTP_CLIENT_BURST", "0")
+	t.Setenv("HTTP_CLIENT_KEY_HEADER", "x-api-key")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Network != NetworkMainnet {
+		t.Errorf("Expected network %s, got %s", NetworkMainnet, cfg.Network)
+	}
+	if cfg.Solana.Chain != solana.ChainSolana {
+		t.Errorf("Expected default chain %s, got %s", solana.ChainSolana, cfg.Solana.Chain)
+	}
+	if cfg.HTTP.Port != DefaultPort {
+		t.Errorf("Expected default port %d, got %d", DefaultPort, cfg.HTTP.Port)
+	}
+	if cfg.Solana.RequestTimeout != 12*time.Second {
+		t.Errorf("Expected RPC timeout 12s, got %v", cfg.Solana.RequestTimeout)
+	}
+	if cfg.Solana.MaxBackoff != solanaMaxBackoff {
+		t.Errorf("Expected solana max backoff %v, got %v", solanaMaxBackoff, cfg.Solana.MaxBackoff)
+	}
+	if cfg.HTTP.RouteLimits.TradesTimeout != 45*time.Second {
+		t.Errorf("Expected trades timeout 45s, got %v", cfg.HTTP.RouteLimits.TradesTimeout)
+	}
+	if got := cfg.HTTP.Compression.ExcludedTypes; len(got) != 2 || got[0] != "text/event-stream" || got[1] != "application/x-ndjson" {
+		t.Errorf("Expected normalized excluded content types, got %v", got)
+	}
+	if cfg.HTTP.Compression.MinBytes != DefaultCompressionMinBytes {
+		t.Errorf("Expected default compression min size %d, got %d", DefaultCompressionMinBytes, cfg.HTTP.Compression.MinBytes)
+	}
+	if cfg.HTTP.Concurrency.Enabled() {
+		t.Error("Expected HTTP_MAX_IN_FLIGHT=0 to disable the in-flight limiter")
+	}
+	if cfg.HTTP.Concurrency.QueueTimeout != DefaultQueueTimeout {
+		t.Errorf("Expected invalid queue timeout to fall back to %v, got %v", DefaultQueueTimeout, cfg.HTTP.Concurrency.QueueTimeout)
+	}
+	if cfg.HTTP.Concurrency.MaxTradeStreams != DefaultMaxTradeStreams {
+		t.Errorf("Expected trade streams capped at %d, got %d", DefaultMaxTradeStreams, cfg.HTTP.Concurrency.MaxTradeStreams)
+	}
+	if cfg.HTTP.Concurrency.MaxSSEStreams != DefaultMaxSSEStreams {
+		t.Errorf("Expected SSE streams capped at %d, got %d", DefaultMaxSSEStreams, cfg.HTTP.Concurrency.MaxSSEStreams)
+	}
+	if limit := cfg.HTTP.ClientRateLimit; !limit.Enabled() || limit.Burst != DefaultClientBurst || limit.KeyHeader != "X-Api-Key" {
+		t.Errorf("Expected default client limits keyed by X-Api-Key, got %+v", limit)
+	}
+	if cfg.Tokens == nil || cfg.Hylo == nil || cfg.Price == nil || cfg.ClassificationRules == nil {
+		t.Error("Expected all sub-configs to be populated")
+	}
+}
+
+func TestLoadFromConfigFile(t *testing.T) {
+	setMainnetEnv(t)
+	t.Setenv("PORT", "9090")
+
+	path := filepath.Join(t.TempDir(), "app.env")
+	if err := os.WriteFile(path, []byte("PORT=7070\nADMIN_API_KEY=from-file\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Setenv(EnvConfigFile, path)
+	t.Setenv("ADMIN_API_KEY", "")
+	os.Unsetenv("ADMIN_API_KEY")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.HTTP.Port != 9090 {
+		t.Errorf("Expected process environment to win over the file, got port %d", cfg.HTTP.Port)
+	}
+	if cfg.HTTP.AdminAPIKey != "from-file" {
+		t.Errorf("Expected ADMIN_API_KEY from file, got %q", cfg.HTTP.AdminAPIKey)
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		wantError []string
+	}{
+		{
+			name:      "devnet profile with mainnet RPC",
+			env:       map[string]string{"SOLANA_NETWORK": "devnet"},
+			wantError: []string{"RPC_HTTP_URL host mainnet.helius-rpc.com looks like mainnet-beta"},
+		},
+		{
+			name: "devnet profile without Hylo overrides",
+			env: map[string]string{
+				"SOLANA_NETWORK": "devnet",
+				"RPC_HTTP_URL":   "https://api.devnet.solana.com",
+				"RPC_WS_URL":     "wss://api.devnet.solana.com",
+			},
+			wantError: []string{"requires HYLO_EXCHANGE_PROGRAM_ID"},
+		},
+		{
+			name:      "unknown network",
+			env:       map[string]string{"SOLANA_NETWORK": "moonnet"},
+			wantError: []string{"invalid SOLANA_NETWORK"},
+		},
+		{
+			name:      "chain without a client implementation",
+			env:       map[string]string{"CHAIN_ID": "eclipse"},
+			wantError: []string{`unsupported chain "eclipse"`},
+		},
+		{
+			name:      "websocket URL with http scheme",
+			env:       map[string]string{"RPC_WS_URL": "https://mainnet.helius-rpc.com"},
+			wantError: []string{"invalid RPC_WS_URL scheme"},
+		},
+		{
+			name:      "errors are reported together",
+			env:       map[string]string{"LOG_FORMAT": "xml", "TRACKED_WALLETS": "not-a-wallet", "RPC_HTTP_URL": ""},
+			wantError: []string{"LOG_FORMAT", "TRACKED_WALLETS", "HttpURL is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setMainnetEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := Load()
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			for _, want := range tt.wantError {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error containing %q, got %v", want, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// Debug controls the /debug profiling and diagnostics endpoints
+// They are off by default and, when enabled, still require the ADMIN_API_KEY bearer token
+type Debug struct {
+	Enabled bool
+}
+
+// NewDebugFromEnvironment loads debug endpoint settings from DEBUG_ENDPOINTS_ENABLED
+func NewDebugFromEnvironment() *Debug {
+	debug := &Debug{}
+
+	if value := os.Getenv("DEBUG_ENDPOINTS_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			debug.Enabled = enabled
+		}
+	}
+
+	return debug
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaintenanceMessage is returned by rejected writes when MAINTENANCE_MESSAGE is unset
+const DefaultMaintenanceMessage = "The API is in maintenance mode; read endpoints remain available"
+
+// Maintenance is the read-only mode the server starts in
+// While enabled, writes to tracked wallets, portfolios and webhooks are rejected with 503; it can be
+// switched at runtime through /admin/maintenance, which does not outlive a restart
+type Maintenance struct {
+	Enabled bool
+	Message string
+}
+
+// NewMaintenanceFromEnvironment loads MAINTENANCE_MODE and MAINTENANCE_MESSAGE
+func NewMaintenanceFromEnvironment() *Maintenance {
+	maintenance := &Maintenance{Message: DefaultMaintenanceMessage}
+
+	if value := os.Getenv("MAINTENANCE_MODE"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			maintenance.Enabled = enabled
+		}
+	}
+
+	if value := strings.TrimSpace(os.Getenv("MAINTENANCE_MESSAGE")); value != "" {
+		maintenance.Message = value
+	}
+
+	return maintenance
+}
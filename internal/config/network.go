@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Network is a Solana cluster profile selected via SOLANA_NETWORK
+type Network string
+
+// Supported network profiles
+const (
+	NetworkMainnet  Network = "mainnet-beta"
+	NetworkDevnet   Network = "devnet"
+	NetworkTestnet  Network = "testnet"
+	NetworkLocalnet Network = "localnet" // Local validator or fixtures; may clone mainnet programs
+)
+
+// validateNetwork checks that RPC endpoints and Hylo addresses match the selected network profile
+func (c *Config) validateNetwork() error {
+	switch c.Network {
+	case NetworkMainnet, NetworkDevnet, NetworkTestnet, NetworkLocalnet:
+	default:
+		return fmt.Errorf("invalid SOLANA_NETWORK %q: must be %s, %s, %s or %s",
+			c.Network, NetworkMainnet, NetworkDevnet, NetworkTestnet, NetworkLocalnet)
+	}
+
+	if err := checkEndpoint("RPC_HTTP_URL", c.Solana.HttpURL, c.Network, "http", "https"); err != nil {
+		return err
+	}
+	if err := checkEndpoint("RPC_WS_URL", c.Solana.WebSocketURL, c.Network, "ws", "wss"); err != nil {
+		return err
+	}
+	if c.Solana.ArchiveHttpURL != "" {
+		if err := checkEndpoint("SOLANA_ARCHIVE_RPC_URL", c.Solana.ArchiveHttpURL, c.Network, "http", "https"); err != nil {
+			return err
+		}
+	}
+
+	// Hylo is deployed on mainnet-beta only, so other public clusters need explicit addresses
+	if c.Network == NetworkDevnet || c.Network == NetworkTestnet {
+		if c.Hylo.ExchangeProgramID == hylo.ExchangeProgram {
+			return fmt.Errorf("SOLANA_NETWORK %s requires HYLO_EXCHANGE_PROGRAM_ID: the default is the mainnet-beta program", c.Network)
+		}
+		if c.Tokens.XSOLMint == tokens.XSOLMint {
+			return fmt.Errorf("SOLANA_NETWORK %s requires XSOL_MINT: the default is the mainnet-beta mint", c.Network)
+		}
+	}
+
+	return nil
+}
+
+// checkEndpoint validates the URL scheme and rejects hosts that name a different cluster
+func checkEndpoint(name, raw string, network Network, schemes ...string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	validScheme := false
+	for _, scheme := range schemes {
+		if parsed.Scheme == scheme {
+			validScheme = true
+		}
+	}
+	if !validScheme {
+		return fmt.Errorf("invalid %s scheme %q: must be %s", name, parsed.Scheme, strings.Join(schemes, " or "))
+	}
+
+	if detected := networkFromHost(parsed.Hostname()); detected != "" && detected != network {
+		return fmt.Errorf("%s host %s looks like %s but SOLANA_NETWORK is %s", name, parsed.Hostname(), detected, network)
+	}
+
+	return nil
+}
+
+// networkFromHost infers the cluster from well-known RPC host names; empty when it cannot tell
+// Local hosts are not classified since local proxies commonly front mainnet endpoints
+func networkFromHost(host string) Network {
+	host = strings.ToLower(host)
+	switch {
+	case strings.Contains(host, "devnet"):
+		return NetworkDevnet
+	case strings.Contains(host, "testnet"):
+		return NetworkTestnet
+	case strings.Contains(host, "mainnet"):
+		return NetworkMainnet
+	}
+	return ""
+}
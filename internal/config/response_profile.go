@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"hylo-wallet-tracker-api/internal/casing"
+)
+
+// ResponseProfile sets the default field naming and envelope of response bodies
+// Clients override either per request with the Accept-Profile header
+type ResponseProfile struct {
+	// Naming converts struct field names to snake_case or camelCase; declared keeps the json tags as written
+	Naming casing.Style
+
+	// Envelope wraps every body as {data, meta, error}
+	Envelope bool
+}
+
+// NewResponseProfileFromEnvironment loads RESPONSE_FIELD_NAMING and RESPONSE_ENVELOPE, ignoring invalid values
+func NewResponseProfileFromEnvironment() *ResponseProfile {
+	profile := &ResponseProfile{}
+
+	if value := os.Getenv("RESPONSE_FIELD_NAMING"); value != "" {
+		if style, ok := casing.ParseStyle(value); ok {
+			profile.Naming = style
+		}
+	}
+
+	if value := os.Getenv("RESPONSE_ENVELOPE"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			profile.Envelope = enabled
+		}
+	}
+
+	return profile
+}
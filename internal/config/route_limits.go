@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default per-route limits; override via HTTP_TIMEOUT_*_SEC and HTTP_MAX_BODY_BYTES
+const (
+	DefaultRouteTimeout    = 10 * time.Second
+	DefaultBalancesTimeout = 5 * time.Second
+	DefaultTradesTimeout   = 20 * time.Second
+	DefaultMaxBodyBytes    = 4096
+)
+
+// RouteLimits holds per-route request deadlines and the POST body size limit
+type RouteLimits struct {
+	DefaultTimeout  time.Duration
+	BalancesTimeout time.Duration
+	TradesTimeout   time.Duration
+	MaxBodyBytes    int64
+}
+
+// NewRouteLimitsFromEnvironment loads route limits, falling back to defaults for missing or invalid values
+func NewRouteLimitsFromEnvironment() *RouteLimits {
+	limits := &RouteLimits{
+		DefaultTimeout:  DefaultRouteTimeout,
+		BalancesTimeout: DefaultBalancesTimeout,
+		TradesTimeout:   DefaultTradesTimeout,
+		MaxBodyBytes:    DefaultMaxBodyBytes,
+	}
+
+	for env, target := range map[string]*time.Duration{
+		"HTTP_TIMEOUT_DEFAULT_SEC":  &limits.DefaultTimeout,
+		"HTTP_TIMEOUT_BALANCES_SEC": &limits.BalancesTimeout,
+		"HTTP_TIMEOUT_TRADES_SEC":   &limits.TradesTimeout,
+	} {
+		if value := os.Getenv(env); value != "" {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				*target = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if value := os.Getenv("HTTP_MAX_BODY_BYTES"); value != "" {
+		if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > 0 {
+			limits.MaxBodyBytes = size
+		}
+	}
+
+	return limits
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HeaderTenantAPIKey carries the API key a tenant authenticates with
+const HeaderTenantAPIKey = "X-API-Key"
+
+// MaxTenantNameLength bounds tenant names configured in TENANT_API_KEYS
+const MaxTenantNameLength = 64
+
+// Tenants namespaces tracked wallets, portfolios and webhooks by the API key a request carries
+// Without any key configured the API is single-tenant and every request belongs to the default tenant
+type Tenants struct {
+	// Keys maps each API key to its tenant; a tenant may hold several keys so they can be rotated
+	Keys map[string]string
+
+	// Per-tenant quotas; 0 means unlimited
+	MaxWallets    int // Tracked wallets that are not paused
+	MaxPortfolios int
+	MaxWebhooks   int
+
+	// parseErr records a malformed TENANT_API_KEYS for Validate
+	parseErr error
+}
+
+// Enabled reports whether requests must identify their tenant with an API key
+func (t *Tenants) Enabled() bool {
+	return t != nil && len(t.Keys) > 0
+}
+
+// Resolve returns the tenant owning an API key
+// Every key is compared in constant time so response timing does not reveal how much of a key matched
+func (t *Tenants) Resolve(key string) (string, bool) {
+	if !t.Enabled() || key == "" {
+		return "", false
+	}
+	tenant, found := "", false
+	for candidate, owner := range t.Keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(candidate)) == 1 {
+			tenant, found = owner, true
+		}
+	}
+	return tenant, found
+}
+
+// NewTenantsFromEnvironment loads TENANT_API_KEYS ("tenant:key,tenant:key") and the TENANT_MAX_* quotas,
+// ignoring invalid quota values
+func NewTenantsFromEnvironment() *Tenants {
+	tenants := &Tenants{}
+
+	if value := strings.TrimSpace(os.Getenv("TENANT_API_KEYS")); value != "" {
+		tenants.Keys, tenants.parseErr = parseTenantKeys(value)
+	}
+
+	for env, quota := range map[string]*int{
+		"TENANT_MAX_WALLETS":    &tenants.MaxWallets,
+		"TENANT_MAX_PORTFOLIOS": &tenants.MaxPortfolios,
+		"TENANT_MAX_WEBHOOKS":   &tenants.MaxWebhooks,
+	} {
+		if value := os.Getenv(env); value != "" {
+			if limit, err := strconv.Atoi(value); err == nil && limit >= 0 {
+				*quota = limit
+			}
+		}
+	}
+
+	return tenants
+}
+
+// Validate rejects a malformed TENANT_API_KEYS so tenant isolation never silently turns off
+func (t *Tenants) Validate() error {
+	if t == nil || t.parseErr == nil {
+		return nil
+	}
+	return fmt.Errorf("invalid TENANT_API_KEYS: %w", t.parseErr)
+}
+
+// parseTenantKeys parses comma-separated tenant:key pairs
+func parseTenantKeys(value string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		tenant, key, ok := strings.Cut(entry, ":")
+		tenant, key = strings.TrimSpace(tenant), strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("entry %q must be tenant:key", entry)
+		}
+		if err := validateTenantName(tenant); err != nil {
+			return nil, err
+		}
+		if _, duplicate := keys[key]; duplicate {
+			return nil, fmt.Errorf("key of tenant %q is already assigned", tenant)
+		}
+		keys[key] = tenant
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no tenant:key entries")
+	}
+	return keys, nil
+}
+
+// validateTenantName accepts letters, digits, '-' and '_' so tenant names are safe in logs and file names
+func validateTenantName(tenant string) error {
+	if tenant == "" || len(tenant) > MaxTenantNameLength {
+		return fmt.Errorf("tenant name %q must be 1 to %d characters", tenant, MaxTenantNameLength)
+	}
+	for _, c := range tenant {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return fmt.Errorf("tenant name %q may only contain letters, digits, '-' and '_'", tenant)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestTenantsFromEnvironment(t *testing.T) {
+	t.Setenv("TENANT_API_KEYS", "")
+	if tenants := NewTenantsFromEnvironment(); tenants.Enabled() || tenants.Validate() != nil {
+		t.Error("Expected tenants to be disabled without keys")
+	}
+
+	t.Setenv("TENANT_API_KEYS", " acme:key-1, acme:key-2 ,globex:key-3")
+	t.Setenv("TENANT_MAX_WALLETS", "10")
+	t.Setenv("TENANT_MAX_WEBHOOKS", "-1")
+	tenants := NewTenantsFromEnvironment()
+	if err := tenants.Validate(); err != nil || !tenants.Enabled() {
+		t.Fatalf("Expected valid tenants, got %v", err)
+	}
+	for key, want := range map[string]string{"key-1": "acme", "key-2": "acme", "key-3": "globex"} {
+		if tenant, ok := tenants.Resolve(key); !ok || tenant != want {
+			t.Errorf("Expected %s to resolve to %s, got %q", key, want, tenant)
+		}
+	}
+	if _, ok := tenants.Resolve("key-4"); ok {
+		t.Error("Expected an unknown key not to resolve")
+	}
+	if tenants.MaxWallets != 10 || tenants.MaxWebhooks != 0 {
+		t.Errorf("Expected the valid quota to apply and the negative one to be ignored, got %+v", tenants)
+	}
+
+	for _, value := range []string{"acme", "acme:", "ac me:key", "acme:key,globex:key"} {
+		t.Setenv("TENANT_API_KEYS", value)
+		if err := NewTenantsFromEnvironment().Validate(); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/watchlist"
+)
+
+// NewBucketTimezoneFromEnvironment loads BUCKET_TIMEZONE, the zone daily and weekly trade buckets start
+// at midnight in unless a request passes tz. Unset or invalid values mean UTC
+func NewBucketTimezoneFromEnvironment() *time.Location {
+	loc, err := watchlist.ParseTimezone(os.Getenv("BUCKET_TIMEZONE"))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,124 @@
+package digest
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default digest settings
+const (
+	DefaultCheckInterval  = 5 * time.Minute // Time between checks for due digests
+	DefaultSendHour       = 8               // Local hour digests are sent at in each wallet's time zone
+	DefaultWeeklyDay      = time.Monday     // Day weekly digests are sent on
+	DefaultTelegramAPIURL = "https://api.telegram.org"
+)
+
+// Config holds digest scheduling and notifier configuration
+type Config struct {
+	// Enabled starts the background digest job
+	Enabled bool
+
+	// CheckInterval is the time between checks for wallets with a due digest
+	CheckInterval time.Duration
+
+	// SendHour is the hour (0-23) digests are sent at, evaluated in each wallet's time zone
+	SendHour int
+
+	// WeeklyDay is the weekday weekly digests are sent on
+	WeeklyDay time.Weekday
+
+	// Telegram delivers digests to a chat; disabled unless a bot token and chat ID are set
+	Telegram TelegramConfig
+
+	// Email delivers digests over SMTP; disabled unless a server, sender and recipient are set
+	Email EmailConfig
+}
+
+// TelegramConfig configures the Telegram Bot API notifier
+type TelegramConfig struct {
+	APIURL   string
+	BotToken string
+	ChatID   string
+}
+
+// Enabled reports whether digests are sent to Telegram
+func (c TelegramConfig) Enabled() bool {
+	return c.BotToken != "" && c.ChatID != ""
+}
+
+// EmailConfig configures the SMTP notifier
+type EmailConfig struct {
+	SMTPAddr string // host:port
+	Username string // Optional; PLAIN auth is used when set
+	Password string
+	From     string
+	To       []string
+}
+
+// Enabled reports whether digests are sent by email
+func (c EmailConfig) Enabled() bool {
+	return c.SMTPAddr != "" && c.From != "" && len(c.To) > 0
+}
+
+// DefaultConfig returns the default digest configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:       true,
+		CheckInterval: DefaultCheckInterval,
+		SendHour:      DefaultSendHour,
+		WeeklyDay:     DefaultWeeklyDay,
+		Telegram:      TelegramConfig{APIURL: DefaultTelegramAPIURL},
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("DIGEST_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			config.Enabled = enabled
+		}
+	}
+
+	if value := os.Getenv("DIGEST_CHECK_INTERVAL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.CheckInterval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("DIGEST_SEND_HOUR"); value != "" {
+		if hour, err := strconv.Atoi(value); err == nil && hour >= 0 && hour < 24 {
+			config.SendHour = hour
+		}
+	}
+
+	if value := os.Getenv("DIGEST_WEEKLY_DAY"); value != "" {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(strings.TrimSpace(value), day.String()) {
+				config.WeeklyDay = day
+			}
+		}
+	}
+
+	if value := os.Getenv("DIGEST_TELEGRAM_API_URL"); value != "" {
+		config.Telegram.APIURL = strings.TrimRight(value, "/")
+	}
+	config.Telegram.BotToken = os.Getenv("DIGEST_TELEGRAM_BOT_TOKEN")
+	config.Telegram.ChatID = os.Getenv("DIGEST_TELEGRAM_CHAT_ID")
+
+	config.Email.SMTPAddr = os.Getenv("DIGEST_SMTP_ADDR")
+	config.Email.Username = os.Getenv("DIGEST_SMTP_USERNAME")
+	config.Email.Password = os.Getenv("DIGEST_SMTP_PASSWORD")
+	config.Email.From = os.Getenv("DIGEST_EMAIL_FROM")
+	for _, recipient := range strings.Split(os.Getenv("DIGEST_EMAIL_TO"), ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			config.Email.To = append(config.Email.To, recipient)
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,180 @@
+package digest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Wallet time zones must resolve in minimal containers without zoneinfo
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// Digest summarizes how a tracked wallet changed over one daily or weekly period
+type Digest struct {
+	Tenant      string    `json:"-"` // Tenant whose registration opted in; only its webhooks receive the digest
+	Wallet      string    `json:"wallet"`
+	Label       string    `json:"label,omitempty"`
+	Frequency   string    `json:"frequency"`
+	Timezone    string    `json:"timezone"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+
+	// Net worth at the period bounds, from the newest snapshot at or before each bound
+	// Omitted when snapshot retention does not reach back to the period start
+	StartValueUSD *float64 `json:"start_value_usd,omitempty"`
+	EndValueUSD   *float64 `json:"end_value_usd,omitempty"`
+	NetChangeUSD  *float64 `json:"net_change_usd,omitempty"`
+
+	// YieldAccruedUSD is the estimated value growth of the sHYUSD position per share held at the end
+	YieldAccruedUSD float64 `json:"yield_accrued_usd"`
+
+	TradeCount  int       `json:"trade_count"` // Executed BUY and SELL trades in the period
+	Buys        int       `json:"buys"`
+	Sells       int       `json:"sells"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// ValidatePreference checks a digest frequency and IANA time zone
+func ValidatePreference(frequency, timezone string) error {
+	if frequency != store.DigestDaily && frequency != store.DigestWeekly {
+		return fmt.Errorf("invalid frequency %q: must be %s or %s", frequency, store.DigestDaily, store.DigestWeekly)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: must be an IANA time zone such as Europe/Berlin", timezone)
+	}
+	return nil
+}
+
+// OptIn subscribes a tenant's tracked wallet to digests; an empty timezone means UTC
+// Changing an existing subscription keeps its last period so no digest is repeated or skipped
+func OptIn(st store.Store, tenant, address, frequency, timezone string, now time.Time) (store.TrackedWallet, error) {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if err := ValidatePreference(frequency, timezone); err != nil {
+		return store.TrackedWallet{}, err
+	}
+
+	wallet, ok := st.GetWallet(tenant, address)
+	if !ok {
+		return store.TrackedWallet{}, store.ErrWalletNotTracked
+	}
+
+	preference := &store.DigestPreference{Frequency: frequency, Timezone: timezone, LastSentAt: now.UTC()}
+	if wallet.Digest != nil {
+		preference.LastSentAt = wallet.Digest.LastSentAt
+	}
+	return st.SetWalletDigest(tenant, address, preference)
+}
+
+// OptOut stops digests for a tenant's tracked wallet
+func OptOut(st store.Store, tenant, address string) (store.TrackedWallet, error) {
+	return st.SetWalletDigest(tenant, address, nil)
+}
+
+// periodEnd returns the most recent scheduled send time at or before now
+// Daily digests are due every day at sendHour, weekly ones on weeklyDay at sendHour, in loc
+func periodEnd(frequency string, loc *time.Location, sendHour int, weeklyDay time.Weekday, now time.Time) time.Time {
+	local := now.In(loc)
+	end := time.Date(local.Year(), local.Month(), local.Day(), sendHour, 0, 0, 0, loc)
+	if end.After(local) {
+		end = end.AddDate(0, 0, -1)
+	}
+	if frequency == store.DigestWeekly {
+		end = end.AddDate(0, 0, -int((end.Weekday()-weeklyDay+7)%7))
+	}
+	return end
+}
+
+// periodStart returns the start of the period ending at end
+func periodStart(frequency string, end time.Time) time.Time {
+	if frequency == store.DigestWeekly {
+		return end.AddDate(0, 0, -7)
+	}
+	return end.AddDate(0, 0, -1)
+}
+
+// Compile builds the digest for a wallet over [start, end) from stored snapshots and indexed trades
+func Compile(st store.Store, wallet store.TrackedWallet, start, end, now time.Time) *Digest {
+	digest := &Digest{
+		Tenant:      wallet.Tenant,
+		Wallet:      wallet.Address,
+		Label:       wallet.Label,
+		PeriodStart: start.UTC(),
+		PeriodEnd:   end.UTC(),
+		GeneratedAt: now.UTC(),
+	}
+	if wallet.Digest != nil {
+		digest.Frequency = wallet.Digest.Frequency
+		digest.Timezone = wallet.Digest.Timezone
+	}
+
+	from, okFrom := st.SnapshotAt(wallet.Address, start)
+	to, okTo := st.SnapshotAt(wallet.Address, end)
+	if okFrom && okTo {
+		startValue, endValue, change := from.TotalUSD, to.TotalUSD, to.TotalUSD-from.TotalUSD
+		digest.StartValueUSD = &startValue
+		digest.EndValueUSD = &endValue
+		digest.NetChangeUSD = &change
+		digest.YieldAccruedUSD = yieldAccrued(from.Holdings[tokens.SHyUSDSymbol], to.Holdings[tokens.SHyUSDSymbol])
+	}
+
+	for _, record := range st.TradesSince(wallet.Address, start) {
+		trade := record.Trade
+		if !trade.Timestamp.Before(end) || trade.Status == hylo.TradeStatusFailed {
+			continue
+		}
+		switch trade.Side {
+		case hylo.TradeSideBuy:
+			digest.Buys++
+		case hylo.TradeSideSell:
+			digest.Sells++
+		default:
+			continue
+		}
+		digest.TradeCount++
+	}
+
+	return digest
+}
+
+// yieldAccrued values the change in sHYUSD's per-share claim over the shares held at the end,
+// so deposits and withdrawals during the period are not counted as yield
+func yieldAccrued(from, to store.Holding) float64 {
+	if from.RawAmount == 0 || to.RawAmount == 0 {
+		return 0
+	}
+	startValue := utils.DecimalFromFloat(from.USDValue).Mul(utils.NewDecimalFromRaw(to.RawAmount, 0))
+	startValue, _ = startValue.Div(utils.NewDecimalFromRaw(from.RawAmount, 0))
+	return utils.DecimalFromFloat(to.USDValue).Sub(startValue).Float64()
+}
+
+// Text renders the digest as a short plain-text message for chat and email notifiers
+func (d *Digest) Text() string {
+	var b strings.Builder
+
+	name := d.Label
+	if name == "" {
+		name = d.Wallet
+	}
+	fmt.Fprintf(&b, "Hylo %s digest for %s\n", d.Frequency, name)
+
+	loc, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	fmt.Fprintf(&b, "%s - %s (%s)\n", d.PeriodStart.In(loc).Format("Jan 2 15:04"), d.PeriodEnd.In(loc).Format("Jan 2 15:04"), loc)
+
+	if d.NetChangeUSD != nil {
+		fmt.Fprintf(&b, "Net worth: $%.2f -> $%.2f (%+.2f USD)\n", *d.StartValueUSD, *d.EndValueUSD, *d.NetChangeUSD)
+	} else {
+		b.WriteString("Net worth: no snapshot covers the period start\n")
+	}
+	fmt.Fprintf(&b, "Trades: %d (%d buys, %d sells)\n", d.TradeCount, d.Buys, d.Sells)
+	fmt.Fprintf(&b, "sHYUSD yield accrued: %+.2f USD\n", d.YieldAccruedUSD)
+
+	return b.String()
+}
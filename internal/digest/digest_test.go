@@ -0,0 +1,122 @@
+package digest
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+const testWallet = "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+
+func addTrade(t *testing.T, st store.Store, signature, side string, at time.Time) {
+	t.Helper()
+	trade := hylo.NewXSOLTrade(signature, uint64(at.Unix()), at.Unix())
+	trade.SetTradeDetails(side, 1_000_000, 1_000_000, tokens.HyUSDSymbol)
+	if _, err := st.AddTrades([]store.TradeRecord{store.NewTradeRecord(testWallet, trade)}); err != nil {
+		t.Fatalf("AddTrades failed: %v", err)
+	}
+}
+
+func TestPeriodEnd(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	// Wednesday 2025-01-15 07:30 UTC is 08:30 in Berlin
+	now := time.Date(2025, 1, 15, 7, 30, 0, 0, time.UTC)
+
+	daily := periodEnd(store.DigestDaily, berlin, 8, time.Monday, now)
+	if want := time.Date(2025, 1, 15, 8, 0, 0, 0, berlin); !daily.Equal(want) {
+		t.Errorf("Expected today's 08:00 Berlin, got %v", daily)
+	}
+
+	if early := periodEnd(store.DigestDaily, time.UTC, 8, time.Monday, now); !early.Equal(time.Date(2025, 1, 14, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected yesterday's 08:00 UTC before the send hour, got %v", early)
+	}
+
+	weekly := periodEnd(store.DigestWeekly, berlin, 8, time.Monday, now)
+	if want := time.Date(2025, 1, 13, 8, 0, 0, 0, berlin); !weekly.Equal(want) {
+		t.Errorf("Expected Monday 08:00 Berlin, got %v", weekly)
+	}
+	if start := periodStart(store.DigestWeekly, weekly); !start.Equal(time.Date(2025, 1, 6, 8, 0, 0, 0, berlin)) {
+		t.Errorf("Expected the previous Monday as period start, got %v", start)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	end := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -1)
+
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: testWallet, Timestamp: start.Add(-time.Minute), TotalUSD: 1000,
+		Holdings: map[string]store.Holding{tokens.SHyUSDSymbol: {RawAmount: 100_000_000, USDValue: 100}},
+	})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: testWallet, Timestamp: end.Add(-time.Minute), TotalUSD: 1250,
+		// Twice the shares, each now worth 1.02
+		Holdings: map[string]store.Holding{tokens.SHyUSDSymbol: {RawAmount: 200_000_000, USDValue: 204}},
+	})
+
+	addTrade(t, st, "sig1", hylo.TradeSideBuy, start.Add(time.Hour))
+	addTrade(t, st, "sig2", hylo.TradeSideSell, start.Add(2*time.Hour))
+	addTrade(t, st, "sig3", hylo.TradeSideBuy, end) // Belongs to the next period
+	addTrade(t, st, "sig0", hylo.TradeSideBuy, start.Add(-time.Hour))
+
+	wallet := store.TrackedWallet{Address: testWallet, Label: "treasury", Digest: &store.DigestPreference{Frequency: store.DigestDaily, Timezone: "UTC"}}
+	digest := Compile(st, wallet, start, end, end)
+
+	if digest.NetChangeUSD == nil || *digest.NetChangeUSD != 250 {
+		t.Fatalf("Expected +$250 net change, got %v", digest.NetChangeUSD)
+	}
+	if digest.TradeCount != 2 || digest.Buys != 1 || digest.Sells != 1 {
+		t.Errorf("Expected one buy and one sell in the period, got %+v", digest)
+	}
+	if math.Abs(digest.YieldAccruedUSD-4) > 1e-9 {
+		t.Errorf("Expected $4 yield on 200 shares, got %f", digest.YieldAccruedUSD)
+	}
+
+	text := digest.Text()
+	for _, want := range []string{"daily digest for treasury", "+250.00 USD", "Trades: 2 (1 buys, 1 sells)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected digest text to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	if empty := Compile(st, wallet, start.AddDate(0, 0, -30), end, end); empty.NetChangeUSD != nil {
+		t.Errorf("Expected no value change without a snapshot at the period start, got %v", *empty.NetChangeUSD)
+	}
+}
+
+func TestOptIn(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	if _, err := OptIn(st, store.DefaultTenant, testWallet, store.DigestDaily, "", now); !errors.Is(err, store.ErrWalletNotTracked) {
+		t.Errorf("Expected ErrWalletNotTracked, got %v", err)
+	}
+
+	st.TrackWallet(store.TrackedWallet{Address: testWallet})
+	for _, tc := range [][2]string{{"hourly", "UTC"}, {store.DigestDaily, "Mars/Olympus"}} {
+		if _, err := OptIn(st, store.DefaultTenant, testWallet, tc[0], tc[1], now); err == nil {
+			t.Errorf("Expected %q in %q to be rejected", tc[0], tc[1])
+		}
+	}
+
+	wallet, err := OptIn(st, store.DefaultTenant, testWallet, store.DigestDaily, "", now)
+	if err != nil || wallet.Digest == nil || wallet.Digest.Timezone != "UTC" || !wallet.Digest.LastSentAt.Equal(now) {
+		t.Fatalf("Expected daily UTC digest starting now, got %+v (%v)", wallet.Digest, err)
+	}
+
+	wallet, _ = OptIn(st, store.DefaultTenant, testWallet, store.DigestWeekly, "Asia/Tokyo", now.Add(time.Hour))
+	if wallet.Digest.Frequency != store.DigestWeekly || !wallet.Digest.LastSentAt.Equal(now) {
+		t.Errorf("Expected updated preference to keep its last period, got %+v", wallet.Digest)
+	}
+
+	if wallet, _ := OptOut(st, store.DefaultTenant, testWallet); wallet.Digest != nil {
+		t.Errorf("Expected opt-out to clear the preference, got %+v", wallet.Digest)
+	}
+}
@@ -0,0 +1,120 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// Job periodically sends digests to wallets whose daily or weekly period has ended
+type Job struct {
+	store     store.Store
+	notifiers []Notifier
+	config    *Config
+	logger    *logger.Logger
+	now       func() time.Time
+}
+
+// NewJob creates a digest job reading from st and delivering through notifiers
+func NewJob(st store.Store, config *Config, notifiers []Notifier) *Job {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Job{
+		store:     st,
+		notifiers: notifiers,
+		config:    config,
+		logger:    logger.NewFromEnv().WithComponent("digest"),
+		now:       time.Now,
+	}
+}
+
+// Run sends due digests every check interval until ctx is cancelled
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.SendDue(ctx); err != nil {
+			j.logger.LogHandlerError(ctx, "digest_send", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue compiles and delivers the digest of every opted-in, indexed wallet whose period has ended
+// After downtime only the latest period is sent. A wallet's period only advances once at least one
+// notifier accepted it, so a digest no channel could deliver is retried on the next check
+func (j *Job) SendDue(ctx context.Context) error {
+	now := j.now()
+
+	var errs []error
+	for _, wallet := range j.store.ListWallets() {
+		if wallet.Digest == nil || !wallet.Indexed() {
+			continue
+		}
+
+		loc, err := time.LoadLocation(wallet.Digest.Timezone)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("wallet %s: %w", wallet.Address, err))
+			continue
+		}
+
+		end := periodEnd(wallet.Digest.Frequency, loc, j.config.SendHour, j.config.WeeklyDay, now)
+		if !wallet.Digest.LastSentAt.Before(end) {
+			continue
+		}
+
+		digest := Compile(j.store, wallet, periodStart(wallet.Digest.Frequency, end), end, now)
+		if !j.deliver(logger.WithWallet(ctx, wallet.Address), digest) {
+			errs = append(errs, fmt.Errorf("wallet %s: no notifier delivered the digest", wallet.Address))
+			continue
+		}
+
+		preference := *wallet.Digest
+		preference.LastSentAt = end.UTC()
+		if _, err := j.store.SetWalletDigest(wallet.Tenant, wallet.Address, &preference); err != nil {
+			errs = append(errs, fmt.Errorf("wallet %s: %w", wallet.Address, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver sends the digest through every notifier and reports whether any of them succeeded
+func (j *Job) deliver(ctx context.Context, digest *Digest) bool {
+	delivered := false
+	for _, notifier := range j.notifiers {
+		// Chat and email recipients are the operator's; other tenants' digests only go to their webhooks
+		if digest.Tenant != store.DefaultTenant && notifier.Name() != webhookNotifierName {
+			continue
+		}
+		if err := notifier.Notify(ctx, digest); err != nil {
+			j.logger.WarnContext(ctx, "Digest notification failed",
+				slog.String("wallet", digest.Wallet),
+				slog.String("notifier", notifier.Name()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		delivered = true
+	}
+
+	if delivered {
+		j.logger.InfoContext(ctx, "Digest sent",
+			slog.String("wallet", digest.Wallet),
+			slog.String("frequency", digest.Frequency),
+			slog.Time("period_end", digest.PeriodEnd))
+	}
+	return delivered
+}
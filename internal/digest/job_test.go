@@ -0,0 +1,100 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// recordingNotifier collects digests and fails while err is set
+type recordingNotifier struct {
+	digests []*Digest
+	err     error
+}
+
+func (n *recordingNotifier) Name() string { return "recording" }
+
+func (n *recordingNotifier) Notify(ctx context.Context, digest *Digest) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.digests = append(n.digests, digest)
+	return nil
+}
+
+func TestJobSendDue(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	optedIn := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+
+	st.TrackWallet(store.TrackedWallet{Address: testWallet})
+	if _, err := OptIn(st, store.DefaultTenant, testWallet, store.DigestDaily, "UTC", optedIn); err != nil {
+		t.Fatalf("OptIn failed: %v", err)
+	}
+
+	notifier := &recordingNotifier{err: errors.New("unreachable")}
+	job := NewJob(st, &Config{SendHour: 8, WeeklyDay: time.Monday}, []Notifier{notifier})
+
+	job.now = func() time.Time { return optedIn.Add(time.Hour) }
+	if err := job.SendDue(context.Background()); err != nil {
+		t.Fatalf("Expected nothing due before the send hour, got %v", err)
+	}
+
+	job.now = func() time.Time { return optedIn.Add(3 * time.Hour) }
+	if err := job.SendDue(context.Background()); err == nil {
+		t.Error("Expected an error when no notifier delivered")
+	}
+	if wallet, _ := st.GetWallet(store.DefaultTenant, testWallet); !wallet.Digest.LastSentAt.Equal(optedIn) {
+		t.Errorf("Expected the period to stay pending after a failed delivery, got %v", wallet.Digest.LastSentAt)
+	}
+
+	notifier.err = nil
+	if err := job.SendDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.digests) != 1 {
+		t.Fatalf("Expected one digest, got %d", len(notifier.digests))
+	}
+	end := time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC)
+	if digest := notifier.digests[0]; !digest.PeriodEnd.Equal(end) || !digest.PeriodStart.Equal(end.AddDate(0, 0, -1)) {
+		t.Errorf("Expected the day ending at 08:00, got %v - %v", digest.PeriodStart, digest.PeriodEnd)
+	}
+
+	if err := job.SendDue(context.Background()); err != nil || len(notifier.digests) != 1 {
+		t.Errorf("Expected the sent period not to repeat, got %d digests (%v)", len(notifier.digests), err)
+	}
+
+	// Paused wallets keep their preference but receive nothing
+	st.SetWalletStatus(store.DefaultTenant, testWallet, store.WalletStatusPaused, "")
+	job.now = func() time.Time { return optedIn.Add(27 * time.Hour) }
+	if job.SendDue(context.Background()); len(notifier.digests) != 1 {
+		t.Errorf("Expected no digest for a paused wallet, got %d", len(notifier.digests))
+	}
+}
+
+func TestJobSendDueTenantDigests(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	optedIn := time.Date(2025, 1, 15, 6, 0, 0, 0, time.UTC)
+
+	st.TrackWallet(store.TrackedWallet{Tenant: "acme", Address: testWallet})
+	if _, err := OptIn(st, "acme", testWallet, store.DigestDaily, "UTC", optedIn); err != nil {
+		t.Fatalf("OptIn failed: %v", err)
+	}
+
+	events := &recordingPublisher{}
+	operator := &recordingNotifier{}
+	job := NewJob(st, &Config{SendHour: 8, WeeklyDay: time.Monday}, []Notifier{&WebhookNotifier{events: events}, operator})
+	job.now = func() time.Time { return optedIn.Add(3 * time.Hour) }
+	if err := job.SendDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(operator.digests) != 0 {
+		t.Errorf("Expected a tenant digest to skip the operator's channels, got %d", len(operator.digests))
+	}
+	if len(events.events) != 1 || events.events[0].Tenant != "acme" {
+		t.Errorf("Expected one webhook event addressed to the tenant, got %+v", events.events)
+	}
+}
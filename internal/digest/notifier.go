@@ -0,0 +1,178 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// notifierTimeout bounds a single Telegram request
+const notifierTimeout = 10 * time.Second
+
+// maxErrorBodyBytes bounds how much of a failed response body is included in errors
+const maxErrorBodyBytes = 512
+
+// Notifier delivers a compiled digest to one channel
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, digest *Digest) error
+}
+
+// EventPublisher receives digests as webhook events and reports how many deliveries it queued
+type EventPublisher interface {
+	Deliver(event webhook.Event) int
+}
+
+// NewNotifiers returns the webhook notifier plus every chat and email notifier the config enables
+// Webhook deliveries go to subscriptions that accept wallet.digest events
+func NewNotifiers(config *Config, events EventPublisher) []Notifier {
+	var notifiers []Notifier
+	if events != nil {
+		notifiers = append(notifiers, &WebhookNotifier{events: events})
+	}
+	if config.Telegram.Enabled() {
+		notifiers = append(notifiers, NewTelegramNotifier(config.Telegram))
+	}
+	if config.Email.Enabled() {
+		notifiers = append(notifiers, NewEmailNotifier(config.Email))
+	}
+	return notifiers
+}
+
+// WebhookNotifier publishes digests as wallet.digest webhook events
+// Delivery retries and dead-lettering are handled by the webhook dispatcher
+type WebhookNotifier struct {
+	events EventPublisher
+}
+
+// webhookNotifierName is the only channel tenant digests are delivered through
+const webhookNotifierName = "webhook"
+
+// Name implements Notifier
+func (n *WebhookNotifier) Name() string { return webhookNotifierName }
+
+// Notify implements Notifier; it fails when no subscription of the wallet's tenant accepts the digest,
+// so the digest is not marked sent
+func (n *WebhookNotifier) Notify(ctx context.Context, digest *Digest) error {
+	queued := n.events.Deliver(webhook.Event{
+		Type:   webhook.EventWalletDigest,
+		Tenant: digest.Tenant,
+		Wallet: digest.Wallet,
+		Data:   digest,
+	})
+	if queued == 0 {
+		return fmt.Errorf("no webhook subscription accepts %s events for tenant %q", webhook.EventWalletDigest, digest.Tenant)
+	}
+	return nil
+}
+
+// TelegramNotifier sends digests to a chat through the Telegram Bot API
+type TelegramNotifier struct {
+	config TelegramConfig
+	client *http.Client
+}
+
+// NewTelegramNotifier creates a Telegram notifier
+func NewTelegramNotifier(config TelegramConfig) *TelegramNotifier {
+	if config.APIURL == "" {
+		config.APIURL = DefaultTelegramAPIURL
+	}
+	return &TelegramNotifier{config: config, client: &http.Client{Timeout: notifierTimeout}}
+}
+
+// Name implements Notifier
+func (n *TelegramNotifier) Name() string { return "telegram" }
+
+// Notify implements Notifier
+func (n *TelegramNotifier) Notify(ctx context.Context, digest *Digest) error {
+	body, err := json.Marshal(map[string]string{"chat_id": n.config.ChatID, "text": digest.Text()})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", n.config.APIURL, n.config.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// The request URL embeds the bot token; report the cause without it
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return fmt.Errorf("telegram returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// EmailNotifier sends digests as plain-text email over SMTP
+type EmailNotifier struct {
+	config EmailConfig
+	send   func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailNotifier creates an SMTP notifier
+func NewEmailNotifier(config EmailConfig) *EmailNotifier {
+	return &EmailNotifier{config: config, send: smtp.SendMail}
+}
+
+// Name implements Notifier
+func (n *EmailNotifier) Name() string { return "email" }
+
+// Notify implements Notifier; smtp.SendMail does not take a context, so ctx is only checked up front
+func (n *EmailNotifier) Notify(ctx context.Context, digest *Digest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		host, _, err := net.SplitHostPort(n.config.SMTPAddr)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %w", n.config.SMTPAddr, err)
+		}
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, host)
+	}
+
+	// Labels are user supplied; strip line breaks so they cannot inject headers
+	name := digest.Label
+	if name == "" {
+		name = digest.Wallet
+	}
+	name = strings.NewReplacer("\r", "", "\n", " ").Replace(name)
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: Hylo %s digest for %s\r\n", digest.Frequency, name)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(digest.Text(), "\n", "\r\n"))
+
+	if err := n.send(n.config.SMTPAddr, auth, n.config.From, n.config.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("smtp send failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+type recordingPublisher struct {
+	events      []webhook.Event
+	unsubscribe bool
+}
+
+func (p *recordingPublisher) Deliver(event webhook.Event) int {
+	if p.unsubscribe {
+		return 0
+	}
+	p.events = append(p.events, event)
+	return 1
+}
+
+func testDigest() *Digest {
+	return &Digest{Wallet: testWallet, Label: "ops\r\nBcc: attacker@example.com", Frequency: store.DigestDaily, Timezone: "UTC"}
+}
+
+func TestNewNotifiers(t *testing.T) {
+	config := DefaultConfig()
+	if notifiers := NewNotifiers(config, nil); len(notifiers) != 0 {
+		t.Errorf("Expected no notifiers without configuration, got %d", len(notifiers))
+	}
+
+	config.Telegram.BotToken, config.Telegram.ChatID = "token", "42"
+	config.Email = EmailConfig{SMTPAddr: "smtp.example.com:587", From: "digest@example.com", To: []string{"ops@example.com"}}
+	notifiers := NewNotifiers(config, &recordingPublisher{})
+	if len(notifiers) != 3 || notifiers[0].Name() != "webhook" || notifiers[1].Name() != "telegram" || notifiers[2].Name() != "email" {
+		t.Errorf("Expected webhook, telegram and email notifiers, got %d", len(notifiers))
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	publisher := &recordingPublisher{}
+	if err := (&WebhookNotifier{events: publisher}).Notify(context.Background(), testDigest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != webhook.EventWalletDigest || publisher.events[0].Wallet != testWallet {
+		t.Errorf("Expected one wallet.digest event, got %+v", publisher.events)
+	}
+
+	unsubscribed := &WebhookNotifier{events: &recordingPublisher{unsubscribe: true}}
+	if err := unsubscribed.Notify(context.Background(), testDigest()); err == nil {
+		t.Error("Expected an error when no subscription accepts the digest")
+	}
+}
+
+func TestTelegramNotifier(t *testing.T) {
+	var path string
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&payload)
+		if payload["chat_id"] == "blocked" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"ok":false,"description":"bot was blocked by the user"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	notifier := NewTelegramNotifier(TelegramConfig{APIURL: server.URL, BotToken: "123:abc", ChatID: "42"})
+	if err := notifier.Notify(context.Background(), testDigest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/bot123:abc/sendMessage" || payload["chat_id"] != "42" || !strings.Contains(payload["text"], "daily digest") {
+		t.Errorf("Unexpected request %s %+v", path, payload)
+	}
+
+	blocked := NewTelegramNotifier(TelegramConfig{APIURL: server.URL, BotToken: "123:abc", ChatID: "blocked"})
+	if err := blocked.Notify(context.Background(), testDigest()); err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Errorf("Expected HTTP 403 error, got %v", err)
+	}
+
+	unreachable := NewTelegramNotifier(TelegramConfig{APIURL: "http://127.0.0.1:1", BotToken: "secret-token", ChatID: "42"})
+	if err := unreachable.Notify(context.Background(), testDigest()); err == nil || strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Expected a connection error without the bot token, got %v", err)
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	notifier := NewEmailNotifier(EmailConfig{SMTPAddr: "smtp.example.com:587", Username: "user", Password: "pass", From: "digest@example.com", To: []string{"ops@example.com"}})
+
+	var sentTo []string
+	var message string
+	notifier.send = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		if addr != "smtp.example.com:587" || auth == nil || from != "digest@example.com" {
+			t.Errorf("Unexpected SMTP parameters %s %v %s", addr, auth, from)
+		}
+		sentTo, message = to, string(msg)
+		return nil
+	}
+
+	if err := notifier.Notify(context.Background(), testDigest()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sentTo) != 1 || sentTo[0] != "ops@example.com" {
+		t.Errorf("Expected delivery to ops@example.com, got %v", sentTo)
+	}
+	if !strings.Contains(message, "Subject: Hylo daily digest for ops Bcc: attacker@example.com\r\n") {
+		t.Errorf("Expected label line breaks to be stripped from the subject, got:\n%s", message)
+	}
+}
@@ -0,0 +1,351 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// testWallet owns the recorded hyUSD, sHYUSD and xSOL token accounts in testdata/fixtures
+const testWallet = "GjyMZn6iKZBQ8QfANf1J3Fw8kP8mB6HWyzu6xyKE3zAJ"
+
+// testTradeSignature is testWallet's recorded xSOL mint transaction
+const testTradeSignature = "29xqjJL9Fitk3Q6ndWHWd5UHUxFkXcnehvBLu7FLxLbv3uLYr6WcmwJtwo4xSaPX7EGdwZzRKb8tECMXUUAz5MKT"
+
+// testFailedSignature is testWallet's reverted xSOL mint, listed only on pages of the limit=3 events request
+const testFailedSignature = "3WXNFMvqeLwMGVFW6S7EJDEk7cmQHSFy6PCE1XwzHNoPMwEb8ifWiJEHpMaCGtxwEY2hTBXNqewvURTEzNVGCao1"
+
+// contract is a single request and the status and schema its response must have
+type contract struct {
+	name    string
+	method  string
+	path    string
+	body    string
+	admin   bool   // Send the admin bearer token
+	accept  string // Accept header; empty sends none
+	profile string // Accept-Profile header; empty sends none
+	status  int
+	schema  string // Golden file testdata/contracts/<schema>.json; empty skips the schema check
+}
+
+// run sends the request, checks its status and schema and returns the response body
+func (c contract) run(t *testing.T) []byte {
+	t.Helper()
+
+	method := c.method
+	if method == "" {
+		method = http.MethodGet
+	}
+	header := http.Header{}
+	if c.admin {
+		header.Set("Authorization", "Bearer "+adminAPIKey)
+	}
+	if c.accept != "" {
+		header.Set("Accept", c.accept)
+	}
+	if c.profile != "" {
+		header.Set("Accept-Profile", c.profile)
+	}
+
+	resp := do(t, method, c.path, c.body, header)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+
+	if resp.StatusCode != c.status {
+		t.Errorf("%s %s: expected status %d, got %d: %s", method, c.path, c.status, resp.StatusCode, body)
+	}
+	if c.schema != "" {
+		assertContract(t, resp.Header, body, c.schema)
+	}
+	return body
+}
+
+// TestContracts covers every read endpoint, in order; /admin/audit runs last so it sees the calls before it
+func TestContracts(t *testing.T) {
+	contracts := []contract{
+		{name: "health", path: "/health", status: http.StatusOK, schema: "health"},
+		{name: "ready", path: "/ready", status: http.StatusOK, schema: "ready"},
+		{name: "limits", path: "/limits", status: http.StatusOK, schema: "limits"},
+		{name: "limits with rpc usage", path: "/limits?debug=true", status: http.StatusOK, schema: "limits_rpc_usage"},
+		// Before any price request, so the history is still empty
+		{name: "xsol metrics", path: "/price/xsol/metrics", status: http.StatusOK, schema: "xsol_metrics"},
+
+		{name: "price", path: "/price", status: http.StatusOK, schema: "price"},
+		{name: "price in EUR", path: "/price?currency=eur", status: http.StatusOK, schema: "price_eur"},
+		{name: "price invalid currency", path: "/price?currency=EURO", status: http.StatusBadRequest, schema: "error"},
+		{name: "price as msgpack", path: "/price", accept: "application/msgpack", status: http.StatusOK, schema: "price"},
+		{name: "price preferring json", path: "/price", accept: "application/json, application/msgpack;q=0.5", status: http.StatusOK, schema: "price"},
+		{name: "price debug", path: "/price/debug", status: http.StatusOK, schema: "price_debug"},
+		{name: "price quote sol", path: "/price/sol", status: http.StatusOK, schema: "price_quote_sol"},
+		{name: "price quote xsol", path: "/price/xSOL", status: http.StatusOK, schema: "price_quote_xsol"},
+		{name: "price quote shyusd", path: "/price/sHYUSD", status: http.StatusOK, schema: "price_quote_shyusd"},
+		{name: "price quote hyusd", path: "/price/hyUSD", status: http.StatusOK, schema: "price_quote_hyusd"},
+		{name: "price quote xsol in snake_case", path: "/price/xSOL", profile: "snake_case", status: http.StatusOK, schema: "price_quote_xsol"},
+		{name: "price quote jitosol in EUR", path: "/price/jitoSOL?currency=EUR", status: http.StatusOK, schema: "price_quote_jitosol_eur"},
+		{name: "price quote unsupported", path: "/price/USDC", status: http.StatusNotFound, schema: "not_found"},
+
+		{name: "token stats", path: "/tokens/xsol/stats?holders=3", status: http.StatusOK, schema: "token_stats"},
+		{name: "token stats unknown symbol", path: "/tokens/BONK/stats", status: http.StatusNotFound, schema: "not_found"},
+		{name: "protocol peg", path: "/protocol/peg", status: http.StatusOK, schema: "protocol_peg"},
+		{name: "protocol tvl not configured", path: "/protocol/tvl", status: http.StatusServiceUnavailable, schema: "error"},
+		{name: "protocol history invalid range", path: "/protocol/stats/history?range=30m", status: http.StatusBadRequest, schema: "error"},
+		{name: "protocol scenarios", path: "/protocol/scenarios?sol_drop=30", status: http.StatusOK, schema: "protocol_scenarios"},
+		{name: "protocol scenarios missing drop", path: "/protocol/scenarios", status: http.StatusBadRequest, schema: "error"},
+		{name: "protocol scenarios invalid drop", path: "/protocol/scenarios?sol_drop=100", status: http.StatusBadRequest, schema: "error"},
+		{name: "protocol supply stream disabled", path: "/protocol/supply/stream", status: http.StatusServiceUnavailable, schema: "error"},
+		{name: "token stats holders out of range", path: "/tokens/xSOL/stats?holders=21", status: http.StatusBadRequest, schema: "error"},
+
+		{name: "simulate trade", method: http.MethodPost, path: "/simulate/trade",
+			body: `{"side":"BUY","amount":"10","counter_asset":"SOL"}`, status: http.StatusOK, schema: "simulate_trade"},
+		{name: "simulate trade invalid side", method: http.MethodPost, path: "/simulate/trade",
+			body: `{"side":"HOLD","amount":"10","counter_asset":"SOL"}`, status: http.StatusBadRequest, schema: "error"},
+
+		{name: "validate address", method: http.MethodPost, path: "/validate/address",
+			body: `{"address":"` + testWallet + `"}`, status: http.StatusOK, schema: "validate_address"},
+
+		{name: "wallet balances", path: "/wallet/" + testWallet + "/balances", status: http.StatusOK, schema: "wallet_balances"},
+		{name: "wallet balances as msgpack", path: "/wallet/" + testWallet + "/balances", accept: "application/msgpack", status: http.StatusOK, schema: "wallet_balances"},
+		{name: "wallet balances invalid address", path: "/wallet/not-a-wallet/balances", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet balances conflicting point", path: "/wallet/" + testWallet + "/balances?slot=100&at=2025-01-01T00:00:00Z", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet balances at slot untracked", path: "/wallet/" + unknownWallet + "/balances?slot=100", status: http.StatusNotFound, schema: "not_found"},
+		{name: "wallet approvals", path: "/wallet/" + testWallet + "/approvals", status: http.StatusOK, schema: "wallet_approvals"},
+		{name: "wallet approvals invalid address", path: "/wallet/not-a-wallet/approvals", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet trades", path: "/wallet/" + testWallet + "/trades", status: http.StatusOK, schema: "wallet_trades"},
+		{name: "wallet trades invalid limit", path: "/wallet/" + testWallet + "/trades?limit=500", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet trades filtered", path: "/wallet/" + testWallet + "/trades?side=SELL&counter_asset=hyUSD&min_amount=0.5", status: http.StatusOK, schema: "wallet_trades_filtered"},
+		{name: "wallet trades sparse fields", path: "/wallet/" + testWallet + "/trades?fields=signature,side,xsol_amount", status: http.StatusOK, schema: "wallet_trades_fields"},
+		{name: "wallet trades as msgpack", path: "/wallet/" + testWallet + "/trades", accept: "application/x-msgpack", status: http.StatusOK, schema: "wallet_trades"},
+		{name: "wallet trades sparse fields as msgpack", path: "/wallet/" + testWallet + "/trades?fields=signature,side,xsol_amount", accept: "application/msgpack", status: http.StatusOK, schema: "wallet_trades_fields"},
+		{name: "wallet trades invalid limit as msgpack", path: "/wallet/" + testWallet + "/trades?limit=500", accept: "application/msgpack", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet trades in camelCase", path: "/wallet/" + testWallet + "/trades", profile: "camelCase", status: http.StatusOK, schema: "wallet_trades_camel"},
+		{name: "wallet trades sparse fields in camelCase", path: "/wallet/" + testWallet + "/trades?fields=signature,side,xsolAmount", profile: "camelCase", status: http.StatusOK, schema: "wallet_trades_fields_camel"},
+		{name: "wallet trades sparse fields in camelCase as msgpack", path: "/wallet/" + testWallet + "/trades?fields=signature,side,xsolAmount", accept: "application/msgpack", profile: "camelCase", status: http.StatusOK, schema: "wallet_trades_fields_camel"},
+		{name: "wallet trades enveloped", path: "/wallet/" + testWallet + "/trades", profile: "envelope", status: http.StatusOK, schema: "wallet_trades_envelope"},
+		{name: "wallet trades invalid limit enveloped", path: "/wallet/" + testWallet + "/trades?limit=500", profile: "camelCase, envelope", status: http.StatusBadRequest, schema: "error_envelope"},
+		{name: "wallet trades invalid fields", path: "/wallet/" + testWallet + "/trades?fields=signature,bogus", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet trades invalid side", path: "/wallet/" + testWallet + "/trades?side=SWAP", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet trades invalid include_dust", path: "/wallet/" + testWallet + "/trades?include_dust=maybe", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet trades on another explorer", path: "/wallet/" + testWallet + "/trades?explorer=solana.fm", status: http.StatusOK, schema: "wallet_trades"},
+		{name: "wallet trades invalid explorer", path: "/wallet/" + testWallet + "/trades?explorer=etherscan", status: http.StatusBadRequest, schema: "error"},
+		{name: "trade by signature", path: "/trades/" + testTradeSignature, status: http.StatusOK, schema: "trade_by_signature"},
+		{name: "trade by signature raw", path: "/trades/" + testTradeSignature + "?include_raw=true", status: http.StatusOK, schema: "trade_by_signature_raw"},
+		{name: "trade by signature bad include_raw", path: "/trades/" + testTradeSignature + "?include_raw=maybe", status: http.StatusBadRequest, schema: "error"},
+		{name: "trade by invalid signature", path: "/trades/not-a-signature", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet events", path: "/wallet/" + testWallet + "/events", status: http.StatusOK, schema: "wallet_events"},
+		{name: "wallet events invalid include_failed", path: "/wallet/" + testWallet + "/events?include_failed=maybe", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet summary", path: "/wallet/" + testWallet + "/summary", status: http.StatusOK, schema: "wallet_summary"},
+		{name: "wallet summary as msgpack", path: "/wallet/" + testWallet + "/summary", accept: "application/msgpack", status: http.StatusOK, schema: "wallet_summary"},
+		{name: "wallet stream invalid address", path: "/wallet/not-a-wallet/stream", status: http.StatusBadRequest, schema: "error"},
+		{name: "wallet full trades invalid address", path: "/wallet/not-a-wallet/trades/full", status: http.StatusBadRequest, schema: "error"},
+
+		{name: "watchlist summary", path: "/watchlist/summary", status: http.StatusOK, schema: "watchlist_summary"},
+		{name: "leaderboard", path: "/leaderboard?metric=volume&anonymize=true", status: http.StatusOK, schema: "leaderboard"},
+		{name: "leaderboard invalid metric", path: "/leaderboard?metric=roi", status: http.StatusBadRequest, schema: "error"},
+
+		{name: "debug stats unauthorized", path: "/debug/stats", status: http.StatusUnauthorized, schema: "error"},
+		{name: "debug stats", path: "/debug/stats", admin: true, status: http.StatusOK, schema: "debug_stats"},
+		{name: "debug pprof index", path: "/debug/pprof/", admin: true, status: http.StatusOK},
+		{name: "debug vars", path: "/debug/vars", admin: true, status: http.StatusOK},
+
+		{name: "admin audit unauthorized", path: "/admin/audit", status: http.StatusUnauthorized, schema: "error"},
+		{name: "admin audit", path: "/admin/audit?limit=5", admin: true, status: http.StatusOK, schema: "admin_audit"},
+		{name: "admin logs invalid wallet", path: "/admin/logs?wallet=not-a-wallet", admin: true, status: http.StatusBadRequest, schema: "error"},
+	}
+
+	for _, c := range contracts {
+		t.Run(c.name, func(t *testing.T) {
+			c.run(t)
+		})
+	}
+}
+
+// TestWalletEventsIncludeFailed checks a reverted mint is served as a failed BUY event only when include_failed is set
+func TestWalletEventsIncludeFailed(t *testing.T) {
+	type eventsBody struct {
+		Events []struct {
+			Kind      string `json:"kind"`
+			Type      string `json:"type"`
+			Signature string `json:"signature"`
+			Trade     *struct {
+				Status      string `json:"status"`
+				ErrorReason string `json:"errorReason"`
+			} `json:"trade"`
+		} `json:"events"`
+	}
+
+	body := contract{path: "/wallet/" + testWallet + "/events?limit=3&include_failed=true", status: http.StatusOK, schema: "wallet_events_failed"}.run(t)
+	var withFailed eventsBody
+	if err := json.Unmarshal(body, &withFailed); err != nil {
+		t.Fatalf("failed to decode events: %v", err)
+	}
+	if len(withFailed.Events) != 3 {
+		t.Fatalf("expected the failed mint and both trades, got %d events", len(withFailed.Events))
+	}
+	failed := withFailed.Events[0]
+	if failed.Signature != testFailedSignature || failed.Kind != "trade" || failed.Type != "BUY" || failed.Trade == nil {
+		t.Fatalf("expected the failed mint first as a BUY trade event, got %+v", failed)
+	}
+	if failed.Trade.Status != "failed" || failed.Trade.ErrorReason != "Slippage tolerance exceeded" {
+		t.Errorf("expected failed status with the Anchor error message, got %q %q", failed.Trade.Status, failed.Trade.ErrorReason)
+	}
+
+	body = contract{path: "/wallet/" + testWallet + "/events?limit=3", status: http.StatusOK, schema: "wallet_events"}.run(t)
+	var withoutFailed eventsBody
+	if err := json.Unmarshal(body, &withoutFailed); err != nil {
+		t.Fatalf("failed to decode events: %v", err)
+	}
+	for _, event := range withoutFailed.Events {
+		if event.Signature == testFailedSignature {
+			t.Errorf("expected the failed mint to be skipped without include_failed")
+		}
+	}
+}
+
+// TestWalletRegistryContracts tracks, lists, reconciles, queries the trade index of and untracks a wallet
+func TestWalletRegistryContracts(t *testing.T) {
+	steps := []contract{
+		{name: "track", method: http.MethodPost, path: "/wallets",
+			body: `{"address":"` + testWallet + `","label":"e2e"}`, status: http.StatusCreated, schema: "wallet_tracked"},
+		{name: "track invalid address", method: http.MethodPost, path: "/wallets",
+			body: `{"address":"not-a-wallet"}`, status: http.StatusBadRequest, schema: "error"},
+		{name: "digest opt-in", method: http.MethodPut, path: "/wallets/" + testWallet + "/digest",
+			body: `{"frequency":"daily","timezone":"Europe/Berlin"}`, status: http.StatusOK, schema: "wallet_digest"},
+		{name: "digest invalid frequency", method: http.MethodPut, path: "/wallets/" + testWallet + "/digest",
+			body: `{"frequency":"hourly"}`, status: http.StatusBadRequest, schema: "error"},
+		{name: "digest opt-out", method: http.MethodDelete, path: "/wallets/" + testWallet + "/digest", status: http.StatusOK, schema: "wallet_untracked"},
+		{name: "list", path: "/wallets", status: http.StatusOK, schema: "wallets"},
+		{name: "sync status", path: "/wallets/" + testWallet + "/sync", status: http.StatusOK, schema: "wallet_sync"},
+		{name: "sync status untracked", path: "/wallets/" + unknownWallet + "/sync", status: http.StatusNotFound, schema: "not_found"},
+		{name: "reconcile", path: "/admin/reconcile/" + testWallet + "?depth=10", admin: true, status: http.StatusOK, schema: "admin_reconcile"},
+		{name: "reconcile invalid depth", path: "/admin/reconcile/" + testWallet + "?depth=0", admin: true, status: http.StatusBadRequest, schema: "error"},
+		{name: "reconcile untracked", path: "/admin/reconcile/" + unknownWallet, admin: true, status: http.StatusNotFound, schema: "not_found"},
+		{name: "reconcile reports", path: "/admin/reconcile", admin: true, status: http.StatusOK, schema: "admin_reconcile_reports"},
+		{name: "list invalid status", path: "/wallets?status=deleted", status: http.StatusBadRequest, schema: "error"},
+		{name: "trade aggregate", path: "/wallet/" + testWallet + "/trades/aggregate?bucket=week&days=14", status: http.StatusOK, schema: "trade_aggregate"},
+		{name: "trade aggregate in timezone", path: "/wallet/" + testWallet + "/trades/aggregate?days=3&tz=%2B08:00", status: http.StatusOK, schema: "trade_aggregate"},
+		{name: "activity", path: "/wallet/" + testWallet + "/activity?days=7", status: http.StatusOK, schema: "wallet_activity"},
+		{name: "balance delta without snapshots", path: "/wallet/" + testWallet + "/balances/delta?since=2025-01-01T00:00:00Z", status: http.StatusNotFound, schema: "not_found"},
+		{name: "balance delta invalid since", path: "/wallet/" + testWallet + "/balances/delta?since=yesterday", status: http.StatusBadRequest, schema: "error"},
+		{name: "activity invalid days", path: "/wallet/" + testWallet + "/activity?days=0", status: http.StatusBadRequest, schema: "error"},
+		{name: "risk without snapshots", path: "/wallet/" + testWallet + "/risk", status: http.StatusNotFound, schema: "not_found"},
+		{name: "risk invalid days", path: "/wallet/" + testWallet + "/risk?days=400", status: http.StatusBadRequest, schema: "error"},
+		{name: "trade aggregate invalid bucket", path: "/wallet/" + testWallet + "/trades/aggregate?bucket=month", status: http.StatusBadRequest, schema: "error"},
+		{name: "trade aggregate invalid tz", path: "/wallet/" + testWallet + "/trades/aggregate?tz=Mars/Olympus", status: http.StatusBadRequest, schema: "error"},
+		{name: "untrack", method: http.MethodDelete, path: "/wallets/" + testWallet, status: http.StatusOK, schema: "wallet_untracked"},
+		{name: "untrack unknown", method: http.MethodDelete, path: "/wallets/" + unknownWallet, status: http.StatusNotFound, schema: "not_found"},
+		{name: "untrack invalid address", method: http.MethodDelete, path: "/wallets/not-a-wallet", status: http.StatusBadRequest, schema: "error"},
+		{name: "digest invalid address", method: http.MethodDelete, path: "/wallets/not-a-wallet/digest", status: http.StatusBadRequest, schema: "error"},
+		{name: "trade aggregate untracked", path: "/wallet/" + unknownWallet + "/trades/aggregate", status: http.StatusNotFound, schema: "not_found"},
+	}
+
+	for _, step := range steps {
+		if !t.Run(step.name, func(t *testing.T) { step.run(t) }) {
+			return
+		}
+	}
+}
+
+// unknownWallet is a valid address that is never tracked
+const unknownWallet = "So11111111111111111111111111111111111111112"
+
+// TestWebhookContracts creates, lists, inspects and deletes a webhook subscription
+func TestWebhookContracts(t *testing.T) {
+	var id string
+	t.Run("create", func(t *testing.T) {
+		body := contract{method: http.MethodPost, path: "/webhooks", admin: true,
+			body:   `{"url":"https://example.com/hooks/hylo","events":["trade.new"]}`,
+			status: http.StatusCreated, schema: "webhook"}.run(t)
+
+		var created struct {
+			Subscription struct {
+				ID string `json:"id"`
+			} `json:"subscription"`
+		}
+		if err := json.Unmarshal(body, &created); err != nil || created.Subscription.ID == "" {
+			t.Fatalf("expected subscription id in response: %v", err)
+		}
+		id = created.Subscription.ID
+	})
+	if id == "" {
+		t.FailNow()
+	}
+
+	steps := []contract{
+		{name: "list", path: "/webhooks", admin: true, status: http.StatusOK, schema: "webhooks"},
+		{name: "list unauthorized", path: "/webhooks", status: http.StatusUnauthorized, schema: "error"},
+		{name: "failures", path: "/webhooks/" + id + "/failures", admin: true, status: http.StatusOK, schema: "webhook_failures"},
+		{name: "delete", method: http.MethodDelete, path: "/webhooks/" + id, admin: true, status: http.StatusNoContent},
+		{name: "delete unknown", method: http.MethodDelete, path: "/webhooks/" + id, admin: true, status: http.StatusNotFound, schema: "not_found"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) { step.run(t) })
+	}
+}
+
+// TestPortfolioContracts creates, summarizes and deletes a portfolio, then untracks its member again
+func TestPortfolioContracts(t *testing.T) {
+	var id string
+	t.Run("create", func(t *testing.T) {
+		body := contract{method: http.MethodPost, path: "/portfolios",
+			body:   `{"name":"e2e","wallets":["` + testWallet + `","` + testWallet + `"]}`,
+			status: http.StatusCreated, schema: "portfolio_created"}.run(t)
+
+		var created struct {
+			Portfolio struct {
+				ID string `json:"id"`
+			} `json:"portfolio"`
+		}
+		if err := json.Unmarshal(body, &created); err != nil || created.Portfolio.ID == "" {
+			t.Fatalf("expected portfolio id in response: %v", err)
+		}
+		id = created.Portfolio.ID
+	})
+	if id == "" {
+		t.FailNow()
+	}
+
+	steps := []contract{
+		{name: "create invalid address", method: http.MethodPost, path: "/portfolios",
+			body: `{"name":"e2e","wallets":["not-a-wallet"]}`, status: http.StatusBadRequest, schema: "error"},
+		{name: "list", path: "/portfolios", status: http.StatusOK, schema: "portfolios"},
+		{name: "get", path: "/portfolios/" + id, status: http.StatusOK, schema: "portfolio"},
+		{name: "summary", path: "/portfolios/" + id + "/summary?window_hours=168", status: http.StatusOK, schema: "portfolio_summary"},
+		{name: "summary invalid window", path: "/portfolios/" + id + "/summary?window_hours=0", status: http.StatusBadRequest, schema: "error"},
+		{name: "delete", method: http.MethodDelete, path: "/portfolios/" + id, status: http.StatusNoContent},
+		{name: "summary unknown", path: "/portfolios/" + id + "/summary", status: http.StatusNotFound, schema: "not_found"},
+		{name: "untrack member", method: http.MethodDelete, path: "/wallets/" + testWallet, status: http.StatusOK, schema: "wallet_untracked"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) { step.run(t) })
+	}
+}
+
+// TestMaintenanceContracts switches maintenance mode on, checks writes are rejected and reads served, then switches it off
+func TestMaintenanceContracts(t *testing.T) {
+	steps := []contract{
+		{name: "status", path: "/admin/maintenance", admin: true, status: http.StatusOK, schema: "admin_maintenance"},
+		{name: "enable", method: http.MethodPut, path: "/admin/maintenance", admin: true,
+			body: `{"enabled":true,"message":"e2e migration"}`, status: http.StatusOK, schema: "admin_maintenance_enabled"},
+		{name: "track rejected", method: http.MethodPost, path: "/wallets",
+			body: `{"address":"` + testWallet + `"}`, status: http.StatusServiceUnavailable, schema: "error"},
+		{name: "portfolio rejected", method: http.MethodPost, path: "/portfolios",
+			body: `{"name":"e2e","wallets":["` + testWallet + `"]}`, status: http.StatusServiceUnavailable, schema: "error"},
+		{name: "list served", path: "/wallets", status: http.StatusOK, schema: "wallets"},
+		{name: "invalid body", method: http.MethodPut, path: "/admin/maintenance", admin: true, body: `{`, status: http.StatusBadRequest, schema: "error"},
+		{name: "disable", method: http.MethodPut, path: "/admin/maintenance", admin: true,
+			body: `{"enabled":false}`, status: http.StatusOK, schema: "admin_maintenance"},
+	}
+
+	for _, step := range steps {
+		if !t.Run(step.name, func(t *testing.T) { step.run(t) }) {
+			return
+		}
+	}
+}
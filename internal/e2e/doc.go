@@ -0,0 +1,20 @@
+// Package e2e runs HTTP contract tests against the fully wired API server
+//
+// The server is built with server.NewServer exactly as in production, but every Solana RPC and
+// DexScreener request is answered from recorded responses in testdata/fixtures (see internal/fixtures).
+// Each endpoint's response is reduced to a JSON schema skeleton (field names and value types) and
+// compared with the golden file in testdata/contracts, so handler and service refactors cannot
+// silently rename, drop or retype a field.
+//
+// Run the contract suite:
+//
+//	go test ./internal/e2e
+//
+// Accept an intentional response change by rewriting the golden schemas:
+//
+//	go test ./internal/e2e -update
+//
+// Re-record the fixtures against live endpoints (requires network access):
+//
+//	FIXTURE_RECORD=true RPC_HTTP_URL=https://<mainnet-rpc> go test ./internal/e2e -update
+package e2e
@@ -0,0 +1,119 @@
+package e2e
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/fixtures"
+	"hylo-wallet-tracker-api/internal/server"
+)
+
+// adminAPIKey authorizes the /admin and /webhooks contract requests
+const adminAPIKey = "e2e-admin-key"
+
+// Replay endpoints; fixtures mode never dials them, but config validation requires mainnet URLs
+const (
+	defaultRPCHTTPURL = "https://api.mainnet-beta.solana.com"
+	defaultRPCWSURL   = "wss://api.mainnet-beta.solana.com"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/contracts from the current responses")
+
+// baseURL is the address of the server under test, set by TestMain
+var baseURL string
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	if err := configureEnvironment(); err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: %v\n", err)
+		os.Exit(1)
+	}
+
+	apiServer := httptest.NewServer(server.NewServer().Handler)
+	baseURL = apiServer.URL
+
+	code := m.Run()
+	apiServer.Close()
+	os.Exit(code)
+}
+
+// configureEnvironment points the server at the fixture directory and isolates it from local settings
+// RPC_HTTP_URL and DEXSCREENER_API_URL are kept when set so FIXTURE_RECORD can target live endpoints
+func configureEnvironment() error {
+	dir, err := filepath.Abs(filepath.Join("testdata", "fixtures"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve fixture dir: %w", err)
+	}
+
+	env := map[string]string{
+		fixtures.EnvFixtureDir:              dir,
+		"CONFIG_FILE":                       "",
+		"SOLANA_NETWORK":                    "mainnet-beta",
+		"CHAIN_ID":                          "solana",
+		"ADMIN_API_KEY":                     adminAPIKey,
+		"ADMIN_SIGNING_SECRET":              "",
+		"ADMIN_SIGNING_PUBLIC_KEY":          "",
+		"LOG_LEVEL":                         "error",
+		"INDEXER_ENABLED":                   "false",
+		"DIGEST_ENABLED":                    "false",
+		"PEG_MONITOR_ENABLED":               "false",
+		"HYLO_LST_VAULTS":                   "",
+		"SANCTUM_ENABLED":                   "false",
+		"SOLANA_RPC_BATCH_SIZE":             "0",
+		"TRACKED_WALLETS":                   "",
+		"STORE_PATH":                        "",
+		"AUDIT_LOG_FILE":                    "",
+		"HTTP_COMPRESSION_ENABLED":          "false",
+		"HYLO_PRICE_AUDIT":                  "false",
+		"SOLANA_ARCHIVE_RPC_URL":            "",
+		"PRICE_BOUNDS_AUTO_CALIBRATE":       "false",
+		"DEXSCREENER_PAIR_ADDRESS":          "",
+		"RPC_RPS_LIMIT":                     "0",
+		"CACHE_SNAPSHOT_PATH":               "",
+		"HTTP_MAX_IN_FLIGHT":                "64",
+		"HTTP_CLIENT_RPS":                   "0",
+		"DEBUG_ENDPOINTS_ENABLED":           "true",
+		"EXPLORER_PROVIDER":                 "solscan",
+		"TOKEN_DECIMALS_CHECK_INTERVAL_SEC": "0",
+		"SUPPLY_WATCHER_ENABLED":            "false",
+		"TOKEN_METADATA_ENABLED":            "false",
+	}
+	if os.Getenv("RPC_HTTP_URL") == "" {
+		env["RPC_HTTP_URL"] = defaultRPCHTTPURL
+	}
+	if os.Getenv("RPC_WS_URL") == "" {
+		env["RPC_WS_URL"] = defaultRPCWSURL
+	}
+
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// do sends a request to the server under test; body is sent as JSON when non-empty
+func do(t *testing.T, method, path, body string, header http.Header) *http.Response {
+	t.Helper()
+
+	req, err := newRequest(method, baseURL+path, body)
+	if err != nil {
+		t.Fatalf("failed to build %s %s: %v", method, path, err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	return resp
+}
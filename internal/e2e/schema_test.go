@@ -0,0 +1,203 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// JSON value types used in schema skeletons
+const (
+	typeNull    = "null"
+	typeBoolean = "boolean"
+	typeNumber  = "number"
+	typeString  = "string"
+	typeAbsent  = "absent" // Key missing from some array elements (omitempty)
+)
+
+// schemaOf reduces a decoded JSON value to its shape: objects keep their keys, scalars become type names
+// Array elements are merged into a single element schema so fixture row counts do not matter
+func schemaOf(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return typeNull
+	case bool:
+		return typeBoolean
+	case float64, json.Number:
+		return typeNumber
+	case string:
+		return typeString
+	case []interface{}:
+		if len(v) == 0 {
+			return []interface{}{}
+		}
+		element := schemaOf(v[0])
+		for _, item := range v[1:] {
+			element = mergeSchema(element, schemaOf(item))
+		}
+		return []interface{}{element}
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[key] = schemaOf(item)
+		}
+		return object
+	default:
+		return typeString
+	}
+}
+
+// mergeSchema combines two element schemas; differing scalars become a "|"-separated union
+func mergeSchema(a, b interface{}) interface{} {
+	if reflect.DeepEqual(a, b) {
+		return a
+	}
+
+	objectA, okA := a.(map[string]interface{})
+	objectB, okB := b.(map[string]interface{})
+	if okA && okB {
+		merged := make(map[string]interface{}, len(objectA))
+		for key, value := range objectA {
+			other, ok := objectB[key]
+			if !ok {
+				other = typeAbsent
+			}
+			merged[key] = mergeSchema(value, other)
+		}
+		for key, value := range objectB {
+			if _, ok := objectA[key]; !ok {
+				merged[key] = mergeSchema(typeAbsent, value)
+			}
+		}
+		return merged
+	}
+
+	arrayA, okA := a.([]interface{})
+	arrayB, okB := b.([]interface{})
+	if okA && okB {
+		switch {
+		case len(arrayA) == 0:
+			return arrayB
+		case len(arrayB) == 0:
+			return arrayA
+		}
+		return []interface{}{mergeSchema(arrayA[0], arrayB[0])}
+	}
+
+	nameA, okA := a.(string)
+	nameB, okB := b.(string)
+	if okA && okB {
+		return unionTypes(nameA, nameB)
+	}
+
+	// A composite that is sometimes null (pointer fields) keeps its shape; the golden file records the union
+	return map[string]interface{}{"anyOf": []interface{}{a, b}}
+}
+
+func unionTypes(a, b string) string {
+	seen := make(map[string]bool)
+	for _, name := range append(strings.Split(a, "|"), strings.Split(b, "|")...) {
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+// assertContract decodes the response body and compares its schema with testdata/contracts/<name>.json
+// With -update the golden file is rewritten instead
+func assertContract(t *testing.T, header http.Header, body []byte, name string) {
+	t.Helper()
+
+	var decoded interface{}
+	switch contentType := header.Get("Content-Type"); {
+	case strings.HasPrefix(contentType, "application/json"):
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			t.Fatalf("response is not valid JSON: %v: %s", err, body)
+		}
+	case strings.HasPrefix(contentType, "application/msgpack"):
+		// MessagePack responses must have the same shape as JSON ones; timestamps re-encode as strings
+		var value interface{}
+		if err := msgpack.Unmarshal(body, &value); err != nil {
+			t.Fatalf("response is not valid MessagePack: %v", err)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("failed to re-encode MessagePack response: %v", err)
+		}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("failed to decode re-encoded MessagePack response: %v", err)
+		}
+	default:
+		t.Fatalf("expected application/json or application/msgpack response, got %q: %s", contentType, body)
+	}
+
+	actual, err := json.MarshalIndent(schemaOf(decoded), "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode schema: %v", err)
+	}
+	actual = append(actual, '\n')
+
+	path := filepath.Join("testdata", "contracts", name+".json")
+	if *update {
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read contract %s (run with -update to create it): %v", path, err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("response schema does not match %s\nexpected:\n%s\nactual:\n%s\nresponse:\n%s", path, expected, actual, body)
+	}
+}
+
+func newRequest(method, url, body string) (*http.Request, error) {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+func TestSchemaOf(t *testing.T) {
+	var decoded interface{}
+	raw := `{"a":1,"b":"x","c":null,"d":[{"e":true},{"e":null,"f":2}],"g":[]}`
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"a": typeNumber,
+		"b": typeString,
+		"c": typeNull,
+		"d": []interface{}{map[string]interface{}{"e": "boolean|null", "f": "absent|number"}},
+		"g": []interface{}{},
+	}
+	if actual := schemaOf(decoded); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("schemaOf = %#v, want %#v", actual, expected)
+	}
+}
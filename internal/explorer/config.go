@@ -0,0 +1,28 @@
+package explorer
+
+import "os"
+
+// Config holds block explorer link configuration
+type Config struct {
+	// Provider is the explorer transaction and account links point to unless a request overrides it
+	Provider Provider
+}
+
+// DefaultConfig returns the default explorer configuration
+func DefaultConfig() *Config {
+	return &Config{Provider: DefaultProvider}
+}
+
+// NewConfigFromEnvironment creates a Config loading EXPLORER_PROVIDER
+// Falls back to the default for a missing or unknown provider
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("EXPLORER_PROVIDER"); value != "" {
+		if provider, err := ParseProvider(value); err == nil {
+			config.Provider = provider
+		}
+	}
+
+	return config
+}
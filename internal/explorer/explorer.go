@@ -0,0 +1,96 @@
+package explorer
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Provider is a Solana block explorer that transaction and account links point to
+type Provider string
+
+// Supported explorers
+const (
+	Solscan        Provider = "solscan"         // https://solscan.io
+	SolanaFM       Provider = "solanafm"        // https://solana.fm
+	XRay           Provider = "xray"            // https://xray.helius.xyz
+	SolanaExplorer Provider = "solana_explorer" // https://explorer.solana.com
+)
+
+// DefaultProvider is used when EXPLORER_PROVIDER is not set
+const DefaultProvider = Solscan
+
+// links holds the base URLs of one explorer's transaction and account pages
+type links struct {
+	transaction string
+	account     string
+}
+
+var providerLinks = map[Provider]links{
+	Solscan:        {transaction: "https://solscan.io/tx/", account: "https://solscan.io/account/"},
+	SolanaFM:       {transaction: "https://solana.fm/tx/", account: "https://solana.fm/address/"},
+	XRay:           {transaction: "https://xray.helius.xyz/tx/", account: "https://xray.helius.xyz/account/"},
+	SolanaExplorer: {transaction: "https://explorer.solana.com/tx/", account: "https://explorer.solana.com/address/"},
+}
+
+// aliases accepts explorer hostnames and common spellings alongside the provider names
+var aliases = map[string]Provider{
+	"solscan.io":          Solscan,
+	"solana.fm":           SolanaFM,
+	"solana_fm":           SolanaFM,
+	"xray.helius.xyz":     XRay,
+	"explorer.solana.com": SolanaExplorer,
+	"solana-explorer":     SolanaExplorer,
+	"explorer":            SolanaExplorer,
+}
+
+// Providers lists the supported explorers
+func Providers() []Provider {
+	return []Provider{Solscan, SolanaFM, XRay, SolanaExplorer}
+}
+
+// ParseProvider resolves an explorer name or hostname, case-insensitively
+func ParseProvider(name string) (Provider, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := providerLinks[Provider(name)]; ok {
+		return Provider(name), nil
+	}
+	if provider, ok := aliases[name]; ok {
+		return provider, nil
+	}
+	return "", fmt.Errorf("unknown explorer %q: must be solscan, solanafm, xray or solana_explorer", name)
+}
+
+// TransactionURL links to a transaction on the explorer
+func (p Provider) TransactionURL(signature string) string {
+	return p.links().transaction + signature
+}
+
+// AccountURL links to a wallet, token account or program on the explorer
+func (p Provider) AccountURL(address string) string {
+	return p.links().account + address
+}
+
+// links returns the provider's base URLs, falling back to the default for unknown providers
+func (p Provider) links() links {
+	if l, ok := providerLinks[p]; ok {
+		return l
+	}
+	return providerLinks[DefaultProvider]
+}
+
+// defaultProvider is the explorer links are generated for when a request does not choose one
+var defaultProvider atomic.Pointer[Provider]
+
+// SetDefault sets the explorer used for links generated at parse time and for requests without an override
+func SetDefault(provider Provider) {
+	defaultProvider.Store(&provider)
+}
+
+// Default returns the configured explorer, or DefaultProvider when none was set
+func Default() Provider {
+	if provider := defaultProvider.Load(); provider != nil {
+		return *provider
+	}
+	return DefaultProvider
+}
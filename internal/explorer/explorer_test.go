@@ -0,0 +1,77 @@
+package explorer
+
+import "testing"
+
+func TestParseProvider(t *testing.T) {
+	tests := []struct {
+		name string
+		want Provider
+	}{
+		{"solscan", Solscan},
+		{"Solscan", Solscan},
+		{"solana.fm", SolanaFM},
+		{"solanafm", SolanaFM},
+		{"xray.helius.xyz", XRay},
+		{" xray ", XRay},
+		{"explorer.solana.com", SolanaExplorer},
+		{"solana_explorer", SolanaExplorer},
+	}
+	for _, tt := range tests {
+		got, err := ParseProvider(tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseProvider(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParseProvider("etherscan"); err == nil {
+		t.Error("Expected an error for an unknown explorer")
+	}
+}
+
+func TestProviderURLs(t *testing.T) {
+	const signature = "5sig"
+	const address = "Wallet111"
+
+	tests := []struct {
+		provider    Provider
+		transaction string
+		account     string
+	}{
+		{Solscan, "https://solscan.io/tx/5sig", "https://solscan.io/account/Wallet111"},
+		{SolanaFM, "https://solana.fm/tx/5sig", "https://solana.fm/address/Wallet111"},
+		{XRay, "https://xray.helius.xyz/tx/5sig", "https://xray.helius.xyz/account/Wallet111"},
+		{SolanaExplorer, "https://explorer.solana.com/tx/5sig", "https://explorer.solana.com/address/Wallet111"},
+	}
+	for _, tt := range tests {
+		if got := tt.provider.TransactionURL(signature); got != tt.transaction {
+			t.Errorf("%s transaction URL = %q, want %q", tt.provider, got, tt.transaction)
+		}
+		if got := tt.provider.AccountURL(address); got != tt.account {
+			t.Errorf("%s account URL = %q, want %q", tt.provider, got, tt.account)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	t.Cleanup(func() { SetDefault(DefaultProvider) })
+
+	if got := Default(); got != Solscan {
+		t.Errorf("Expected solscan before SetDefault, got %q", got)
+	}
+	SetDefault(XRay)
+	if got := Default(); got != XRay {
+		t.Errorf("Expected xray after SetDefault, got %q", got)
+	}
+}
+
+func TestNewConfigFromEnvironment(t *testing.T) {
+	t.Setenv("EXPLORER_PROVIDER", "explorer.solana.com")
+	if got := NewConfigFromEnvironment().Provider; got != SolanaExplorer {
+		t.Errorf("Expected solana_explorer, got %q", got)
+	}
+
+	t.Setenv("EXPLORER_PROVIDER", "etherscan")
+	if got := NewConfigFromEnvironment().Provider; got != DefaultProvider {
+		t.Errorf("Expected an unknown provider to fall back to %q, got %q", DefaultProvider, got)
+	}
+}
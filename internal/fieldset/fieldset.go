@@ -0,0 +1,230 @@
+// Package fieldset implements sparse fieldsets: clients pass ?fields=signature,side,xsol_amount
+// to receive only those fields of each item in a response's collection
+package fieldset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidFields is returned for a fields parameter naming fields the items do not have
+var ErrInvalidFields = errors.New("invalid fields")
+
+// Set is a parsed fields parameter mapping each normalized name to the name as requested
+// A nil Set selects every field
+type Set map[string]string
+
+// Parse splits a comma-separated fields parameter into a Set
+// Names are matched against JSON tags ignoring case and underscores, so xsol_amount selects xsolAmount
+func Parse(raw string) Set {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+
+	set := make(Set)
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			set[normalize(name)] = name
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	return set
+}
+
+// Project returns value with every item of its collection field trimmed to the Set's fields
+// value must be a struct (or pointer to one) and collection the JSON name of a slice or map field
+// of structs. Fields outside the collection are kept as is. A nil Set returns value unchanged
+func Project(value interface{}, collection string, set Set) (interface{}, error) {
+	if set == nil {
+		return value, nil
+	}
+
+	itemType, err := collectionItemType(reflect.TypeOf(value), collection)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := set.resolve(itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if raw, ok := fields[collection]; ok {
+		projected, err := projectCollection(raw, selected)
+		if err != nil {
+			return nil, err
+		}
+		fields[collection] = projected
+	}
+	return fields, nil
+}
+
+// Validate checks the Set against the item type of value's collection without encoding anything
+// value may be a typed nil pointer, so handlers can reject a bad fields parameter before doing work
+func Validate(value interface{}, collection string, set Set) error {
+	_, err := Select(value, collection, set)
+	return err
+}
+
+// Select resolves the Set to the JSON names it selects on the items of value's collection
+// Returns nil for a nil Set, which selects every field
+func Select(value interface{}, collection string, set Set) (map[string]bool, error) {
+	if set == nil {
+		return nil, nil
+	}
+	itemType, err := collectionItemType(reflect.TypeOf(value), collection)
+	if err != nil {
+		return nil, err
+	}
+	return set.resolve(itemType)
+}
+
+// resolve maps requested names to the item type's JSON names, rejecting unknown names
+func (s Set) resolve(itemType reflect.Type) (map[string]bool, error) {
+	byName := make(map[string]string)
+	for _, name := range jsonNames(itemType) {
+		byName[normalize(name)] = name
+	}
+
+	selected := make(map[string]bool, len(s))
+	var unknown []string
+	for name, requested := range s {
+		field, ok := byName[name]
+		if !ok {
+			unknown = append(unknown, requested)
+			continue
+		}
+		selected[field] = true
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: unknown field(s) %s", ErrInvalidFields, strings.Join(unknown, ", "))
+	}
+	return selected, nil
+}
+
+// projectCollection trims each item of an encoded JSON array or object to the selected fields
+func projectCollection(raw json.RawMessage, selected map[string]bool) (json.RawMessage, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if trimmed == "null" {
+		return raw, nil
+	}
+
+	var projected interface{}
+	if trimmed[0] == '[' {
+		var items []map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, fmt.Errorf("failed to decode collection: %w", err)
+		}
+		for _, item := range items {
+			keep(item, selected)
+		}
+		projected = items
+	} else {
+		var items map[string]map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, fmt.Errorf("failed to decode collection: %w", err)
+		}
+		for _, item := range items {
+			keep(item, selected)
+		}
+		projected = items
+	}
+
+	encoded, err := json.Marshal(projected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collection: %w", err)
+	}
+	return encoded, nil
+}
+
+// keep deletes the fields of an encoded item that are not selected
+func keep(item map[string]json.RawMessage, selected map[string]bool) {
+	for name := range item {
+		if !selected[name] {
+			delete(item, name)
+		}
+	}
+}
+
+// collectionItemType returns the struct type of the items in the named slice or map field
+func collectionItemType(t reflect.Type, collection string) (reflect.Type, error) {
+	t = indirect(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("fieldset: %v is not a struct", t)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name, ok := jsonName(field); !ok || name != collection {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if item := indirect(field.Type.Elem()); item.Kind() == reflect.Struct {
+				return item, nil
+			}
+		}
+		return nil, fmt.Errorf("fieldset: field %q of %v is not a collection of structs", collection, t)
+	}
+	return nil, fmt.Errorf("fieldset: %v has no field %q", t, collection)
+}
+
+// jsonNames lists the JSON names of a struct's encoded fields, including promoted embedded fields
+func jsonNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			if embedded := indirect(field.Type); embedded.Kind() == reflect.Struct {
+				names = append(names, jsonNames(embedded)...)
+				continue
+			}
+		}
+		if name, ok := jsonName(field); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// jsonName returns the name a field is encoded under, or false when it is not encoded
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// normalize folds a field name so snake_case and camelCase spellings match
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,89 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testItem struct {
+	Signature  string  `json:"signature"`
+	XSOLAmount string  `json:"xsolAmount"`
+	Fee        string  `json:"network_fee_sol,omitempty"`
+	Raw        uint64  `json:"-"`
+	Confidence float64 `json:"confidence"`
+}
+
+type testResponse struct {
+	Items    []*testItem          `json:"items"`
+	BySymbol map[string]*testItem `json:"by_symbol"`
+	Count    int                  `json:"count"`
+}
+
+func encode(t *testing.T, value interface{}) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	return decoded
+}
+
+func TestProject(t *testing.T) {
+	response := &testResponse{
+		Items:    []*testItem{{Signature: "a", XSOLAmount: "1.5", Fee: "0.000005", Raw: 1, Confidence: 1}},
+		BySymbol: map[string]*testItem{"xSOL": {Signature: "b", XSOLAmount: "2"}},
+		Count:    1,
+	}
+
+	projected, err := Project(response, "items", Parse(" signature, xsol_amount ,"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := encode(t, projected)
+
+	wantItems := []interface{}{map[string]interface{}{"signature": "a", "xsolAmount": "1.5"}}
+	if !reflect.DeepEqual(got["items"], wantItems) {
+		t.Errorf("Expected items trimmed to signature and xsolAmount, got %v", got["items"])
+	}
+	if got["count"] != float64(1) || len(got["by_symbol"].(map[string]interface{})["xSOL"].(map[string]interface{})) != 3 {
+		t.Errorf("Expected fields outside the collection untouched, got %v", got)
+	}
+
+	projected, err = Project(response, "by_symbol", Parse("Confidence"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bySymbol := encode(t, projected)["by_symbol"]; !reflect.DeepEqual(bySymbol, map[string]interface{}{"xSOL": map[string]interface{}{"confidence": float64(0)}}) {
+		t.Errorf("Expected map values projected, got %v", bySymbol)
+	}
+}
+
+func TestProjectAllFields(t *testing.T) {
+	response := &testResponse{Count: 2}
+	projected, err := Project(response, "items", Parse(" , "))
+	if err != nil || projected != response {
+		t.Errorf("Expected an empty fields parameter to return the value unchanged, got %v (%v)", projected, err)
+	}
+}
+
+func TestProjectUnknownField(t *testing.T) {
+	for _, fields := range []string{"signature,bogus", "raw"} {
+		if _, err := Project(&testResponse{}, "items", Parse(fields)); !errors.Is(err, ErrInvalidFields) {
+			t.Errorf("Project(%q) error = %v, want ErrInvalidFields", fields, err)
+		}
+	}
+
+	if err := Validate((*testResponse)(nil), "items", Parse("signature,bogus")); !errors.Is(err, ErrInvalidFields) {
+		t.Errorf("Validate error = %v, want ErrInvalidFields", err)
+	}
+
+	if _, err := Project(&testResponse{}, "count", Parse("signature")); err == nil || errors.Is(err, ErrInvalidFields) {
+		t.Errorf("Expected a programming error for a non-collection field, got %v", err)
+	}
+}
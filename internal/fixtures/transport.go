@@ -0,0 +1,172 @@
+package fixtures
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables controlling fixtures mode
+const (
+	EnvFixtureDir    = "FIXTURE_DIR"    // Directory of recorded responses; enables fixtures mode when set
+	EnvFixtureRecord = "FIXTURE_RECORD" // Record live responses into FIXTURE_DIR instead of serving from it
+)
+
+// keyHashLength is the number of hex characters of the params hash used in fixture file names
+const keyHashLength = 16
+
+// Transport is an http.RoundTripper that serves canned responses from JSON files
+// JSON-RPC requests are keyed by method + params hash, other requests by HTTP method + URL path/query hash
+type Transport struct {
+	// Dir is the directory containing fixture files
+	Dir string
+
+	// Record forwards requests to Next and writes responses into Dir
+	Record bool
+
+	// Next is the live transport used in record mode
+	Next http.RoundTripper
+}
+
+// NewTransport creates a fixture transport for dir
+// In record mode, requests are forwarded to next and successful responses are written to dir
+func NewTransport(dir string, record bool, next http.RoundTripper) *Transport {
+	return &Transport{Dir: dir, Record: record, Next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	key, err := RequestKey(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Record {
+		return t.record(req, body, key)
+	}
+
+	return t.serve(req, key)
+}
+
+// serve returns the fixture for key, falling back to a method-wide fixture, or a 404 response
+func (t *Transport) serve(req *http.Request, key string) (*http.Response, error) {
+	for _, name := range []string{key, fallbackKey(key)} {
+		data, err := os.ReadFile(filepath.Join(t.Dir, name+".json"))
+		if err == nil {
+			return newResponse(req, http.StatusOK, data), nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read fixture %s: %w", name, err)
+		}
+	}
+
+	return newResponse(req, http.StatusNotFound, []byte(fmt.Sprintf("fixture not found: %s.json", key))), nil
+}
+
+// record forwards the request to the live transport and stores successful responses
+func (t *Transport) record(req *http.Request, body []byte, key string) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create fixture dir: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(t.Dir, key+".json"), data, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write fixture %s: %w", key, err)
+		}
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
+
+// RequestKey returns the fixture file name (without extension) for a request
+func RequestKey(req *http.Request, body []byte) (string, error) {
+	if req.Method == http.MethodPost && len(body) > 0 {
+		var rpc struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(body, &rpc); err == nil && rpc.Method != "" {
+			params, err := canonicalJSON(rpc.Params)
+			if err != nil {
+				return "", fmt.Errorf("failed to canonicalize params: %w", err)
+			}
+			return rpc.Method + "_" + hashKey(params), nil
+		}
+	}
+
+	return strings.ToLower(req.Method) + "_" + hashKey([]byte(req.URL.RequestURI())), nil
+}
+
+// canonicalJSON re-encodes JSON so that object key order does not affect the key
+func canonicalJSON(raw json.RawMessage) ([]byte, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func hashKey(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:keyHashLength]
+}
+
+// fallbackKey strips the hash so a single "<method>.json" fixture can answer any params
+func fallbackKey(key string) string {
+	if idx := strings.LastIndex(key, "_"); idx != -1 {
+		return key[:idx]
+	}
+	return key
+}
+
+func newResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
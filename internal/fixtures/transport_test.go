@@ -0,0 +1,132 @@
+package fixtures
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testRPCURL = "http://rpc.invalid"
+
+func newRPCRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, testRPCURL, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	return req
+}
+
+func TestRequestKey(t *testing.T) {
+	a := []byte(`{"jsonrpc":"2.0","id":1,"method":"getAccountInfo","params":["addr",{"encoding":"base64","commitment":"finalized"}]}`)
+	b := []byte(`{"jsonrpc":"2.0","id":7,"method":"getAccountInfo","params":["addr",{"commitment":"finalized","encoding":"base64"}]}`)
+	c := []byte(`{"jsonrpc":"2.0","id":1,"method":"getAccountInfo","params":["other",{"encoding":"base64"}]}`)
+
+	keyA, err := RequestKey(newRPCRequest(t, string(a)), a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keyB, _ := RequestKey(newRPCRequest(t, string(b)), b)
+	keyC, _ := RequestKey(newRPCRequest(t, string(c)), c)
+
+	if keyA != keyB {
+		t.Errorf("expected request id and key order to be ignored: %s != %s", keyA, keyB)
+	}
+	if keyA == keyC {
+		t.Errorf("expected different params to produce different keys")
+	}
+	if fallbackKey(keyA) != "getAccountInfo" {
+		t.Errorf("expected method fallback key, got %s", fallbackKey(keyA))
+	}
+
+	get, _ := http.NewRequest(http.MethodGet, "https://api.example.com/latest/dex/search?q=SOL", nil)
+	getKey, _ := RequestKey(get, nil)
+	if fallbackKey(getKey) != "get" {
+		t.Errorf("expected GET requests to be keyed by HTTP method, got %s", getKey)
+	}
+}
+
+func TestTransportServe(t *testing.T) {
+	dir := t.TempDir()
+	body := `{"jsonrpc":"2.0","id":1,"method":"getTransaction","params":["sig"]}`
+	key, _ := RequestKey(newRPCRequest(t, body), []byte(body))
+
+	exact := `{"jsonrpc":"2.0","id":1,"result":"exact"}`
+	fallback := `{"jsonrpc":"2.0","id":1,"result":"fallback"}`
+	os.WriteFile(filepath.Join(dir, key+".json"), []byte(exact), 0o644)
+	os.WriteFile(filepath.Join(dir, "getSignaturesForAddress.json"), []byte(fallback), 0o644)
+
+	client := &http.Client{Transport: NewTransport(dir, false, nil)}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "exact fixture", body: body, wantStatus: http.StatusOK, wantBody: exact},
+		{name: "method fallback", body: `{"method":"getSignaturesForAddress","params":["any"]}`, wantStatus: http.StatusOK, wantBody: fallback},
+		{name: "missing fixture", body: `{"method":"getBalance","params":["addr"]}`, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.Do(newRPCRequest(t, tt.body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if tt.wantBody != "" {
+				data, _ := io.ReadAll(resp.Body)
+				if string(data) != tt.wantBody {
+					t.Errorf("expected body %s, got %s", tt.wantBody, data)
+				}
+			}
+		})
+	}
+}
+
+func TestTransportRecord(t *testing.T) {
+	live := `{"jsonrpc":"2.0","id":1,"result":{"value":null}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(live))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "recorded")
+	body := `{"jsonrpc":"2.0","id":1,"method":"getAccountInfo","params":["addr"]}`
+
+	recorder := &http.Client{Transport: NewTransport(dir, true, http.DefaultTransport)}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString(body))
+	resp, err := recorder.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(data) != live {
+		t.Errorf("expected live response to pass through, got %s", data)
+	}
+
+	// The recorded fixture must now be served without the live server
+	server.Close()
+	replay := &http.Client{Transport: NewTransport(dir, false, nil)}
+	resp, err = replay.Do(newRPCRequest(t, body))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	data, _ = io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(data) != live {
+		t.Errorf("expected recorded fixture, got %d %s", resp.StatusCode, data)
+	}
+}
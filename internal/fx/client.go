@@ -0,0 +1,201 @@
+// Package fx converts USD amounts to other fiat currencies using daily reference rates
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/fixtures"
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+// BaseCurrency is the currency every API amount is denominated in before conversion
+const BaseCurrency = "USD"
+
+// Source identifies rates served by this client
+const Source = "frankfurter"
+
+// currencyCode matches ISO 4217 alphabetic codes
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Conversion errors
+var (
+	ErrInvalidCurrency     = errors.New("invalid currency: must be an ISO 4217 code such as EUR")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// Conversion is the USD exchange rate applied to a response's USD amounts
+type Conversion struct {
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"` // Units of Currency per 1 USD
+	Source   string  `json:"source"`
+	// RateDate is the publication date of the reference rate; FetchedAt when it was retrieved
+	RateDate  string    `json:"rate_date,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// Convert returns a USD amount in the conversion currency
+func (c *Conversion) Convert(usd float64) float64 {
+	return usd * c.Rate
+}
+
+// ConvertPtr converts an optional USD amount
+func (c *Conversion) ConvertPtr(usd *float64) *float64 {
+	if usd == nil {
+		return nil
+	}
+	converted := c.Convert(*usd)
+	return &converted
+}
+
+// ParseCurrency normalizes a currency parameter to an upper-case ISO 4217 code
+func ParseCurrency(value string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if !currencyCode.MatchString(code) {
+		return "", fmt.Errorf("%w, got %q", ErrInvalidCurrency, value)
+	}
+	return code, nil
+}
+
+// latestResponse is the body of GET /latest?from=USD&to=<currency>
+type latestResponse struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// Client fetches USD reference rates and caches them per currency
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	cacheTTL   time.Duration
+	logger     *logger.Logger
+	now        func() time.Time
+
+	mu    sync.Mutex
+	rates map[string]*Conversion
+}
+
+// NewClient creates an FX client from config
+func NewClient(config *Config) *Client {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	httpClient := &http.Client{Timeout: config.Timeout}
+	if config.FixtureDir != "" {
+		httpClient.Transport = fixtures.NewTransport(config.FixtureDir, config.FixtureRecord, http.DefaultTransport)
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(config.APIURL, "/"),
+		cacheTTL:   config.CacheTTL,
+		logger:     logger.NewFromEnv().WithComponent("fx-client"),
+		now:        time.Now,
+		rates:      make(map[string]*Conversion),
+	}
+}
+
+// CacheSize returns the number of cached currency rates, including expired ones not yet refreshed
+func (c *Client) CacheSize() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.rates)
+}
+
+// Conversion returns the USD rate for a currency code; USD itself converts at 1 without a request
+func (c *Client) Conversion(ctx context.Context, currency string) (*Conversion, error) {
+	currency, err := ParseCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if currency == BaseCurrency {
+		return &Conversion{Currency: BaseCurrency, Rate: 1, Source: "identity", FetchedAt: c.now().UTC()}, nil
+	}
+
+	c.mu.Lock()
+	cached, ok := c.rates[currency]
+	c.mu.Unlock()
+	if ok && c.now().Sub(cached.FetchedAt) < c.cacheTTL {
+		return cached, nil
+	}
+
+	conversion, err := c.fetch(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.rates[currency] = conversion
+	c.mu.Unlock()
+	return conversion, nil
+}
+
+// fetch requests the latest reference rate from USD to currency
+func (c *Client) fetch(ctx context.Context, currency string) (*Conversion, error) {
+	query := url.Values{"from": {BaseCurrency}, "to": {currency}}
+	requestURL := c.baseURL + "/latest?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.LogExternalAPIError(ctx, Source, "latest", err, 0)
+		return nil, fmt.Errorf("fx rate request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fx rate response: %w", err)
+	}
+	// Frankfurter answers 404 for currencies it does not publish
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("fx rate API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		c.logger.LogExternalAPIError(ctx, Source, "latest", err, resp.StatusCode)
+		return nil, err
+	}
+
+	var latest latestResponse
+	if err := json.Unmarshal(body, &latest); err != nil {
+		return nil, fmt.Errorf("malformed fx rate response: %w", err)
+	}
+	rate, ok := latest.Rates[currency]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	if rate <= 0 {
+		return nil, fmt.Errorf("malformed fx rate %v for %s", rate, currency)
+	}
+
+	c.logger.DebugContext(ctx, "Fetched FX rate",
+		slog.String("currency", currency),
+		slog.Float64("rate", rate),
+		slog.String("rate_date", latest.Date))
+
+	return &Conversion{
+		Currency:  currency,
+		Rate:      rate,
+		Source:    Source,
+		RateDate:  latest.Date,
+		FetchedAt: c.now().UTC(),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *time.Time) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := DefaultConfig()
+	config.APIURL = server.URL
+	client := NewClient(config)
+	clock := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return clock }
+	return client, &clock
+}
+
+func TestClientConversion(t *testing.T) {
+	var requests int
+	client, clock := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/latest" || r.URL.Query().Get("from") != "USD" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		switch to := r.URL.Query().Get("to"); to {
+		case "EUR":
+			fmt.Fprint(w, `{"amount":1.0,"base":"USD","date":"2025-08-29","rates":{"EUR":0.92}}`)
+		default:
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		}
+	})
+
+	conversion, err := client.Conversion(context.Background(), " eur ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conversion.Currency != "EUR" || conversion.Rate != 0.92 || conversion.RateDate != "2025-08-29" || conversion.Source != Source {
+		t.Errorf("Unexpected conversion %+v", conversion)
+	}
+	if got := conversion.Convert(100); got != 92 {
+		t.Errorf("Expected $100 to be 92 EUR, got %v", got)
+	}
+
+	client.Conversion(context.Background(), "EUR")
+	if requests != 1 {
+		t.Errorf("Expected the second lookup to be served from cache, got %d requests", requests)
+	}
+	*clock = clock.Add(DefaultCacheTTL)
+	client.Conversion(context.Background(), "EUR")
+	if requests != 2 {
+		t.Errorf("Expected an expired rate to be refetched, got %d requests", requests)
+	}
+
+	if usd, err := client.Conversion(context.Background(), "usd"); err != nil || usd.Rate != 1 || requests != 2 {
+		t.Errorf("Expected USD to convert at 1 without a request, got %+v (%v)", usd, err)
+	}
+	if _, err := client.Conversion(context.Background(), "XYZ"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("Expected ErrUnsupportedCurrency, got %v", err)
+	}
+	if _, err := client.Conversion(context.Background(), "EURO"); !errors.Is(err, ErrInvalidCurrency) {
+		t.Errorf("Expected ErrInvalidCurrency, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package fx
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/fixtures"
+)
+
+// Default FX client settings
+const (
+	DefaultAPIURL   = "https://api.frankfurter.app"
+	DefaultTimeout  = 10 * time.Second
+	DefaultCacheTTL = time.Hour // Reference rates are published once per business day
+)
+
+// Config holds fiat exchange rate client configuration
+type Config struct {
+	// APIURL is the base URL of a Frankfurter-compatible reference rate API
+	APIURL string
+
+	// Timeout bounds each API request
+	Timeout time.Duration
+
+	// CacheTTL is how long a fetched rate is served before it is refreshed
+	CacheTTL time.Duration
+
+	// FixtureDir and FixtureRecord replay or record API responses (see internal/fixtures)
+	FixtureDir    string
+	FixtureRecord bool
+}
+
+// DefaultConfig returns the default FX client configuration
+func DefaultConfig() *Config {
+	return &Config{
+		APIURL:   DefaultAPIURL,
+		Timeout:  DefaultTimeout,
+		CacheTTL: DefaultCacheTTL,
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if url := os.Getenv("FX_API_URL"); url != "" {
+		config.APIURL = strings.TrimSpace(url)
+	}
+
+	if value := os.Getenv("FX_TIMEOUT_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.Timeout = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("FX_RATE_CACHE_TTL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			config.CacheTTL = time.Duration(seconds) * time.Second
+		}
+	}
+
+	config.FixtureDir = os.Getenv(fixtures.EnvFixtureDir)
+	if record, err := strconv.ParseBool(os.Getenv(fixtures.EnvFixtureRecord)); err == nil {
+		config.FixtureRecord = record
+	}
+
+	return config
+}
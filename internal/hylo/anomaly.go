@@ -0,0 +1,221 @@
+package hylo
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// Default parser anomaly thresholds
+const (
+	DefaultParseAnomalyWindow          = time.Hour // Rolling window parse outcomes are counted over
+	DefaultParseErrorRateThreshold     = 0.05      // Share of parses returning an error that raises an anomaly
+	DefaultParseLowConfidenceThreshold = 0.20      // Share of parsed trades flagged for review that raises an anomaly
+	DefaultParseAnomalyMinSamples      = 20        // Parses (or trades, for the low confidence rate) needed before a rate is judged
+	parseAnomalyBucketSize             = time.Minute
+)
+
+// ParseAnomalyStatus is the parser's error and low-confidence rates over the rolling window
+// Anomalous is raised when either rate crosses its threshold, usually because an upstream
+// program or RPC format changed, and clears once both rates fall back under half their threshold
+type ParseAnomalyStatus struct {
+	Anomalous      bool       `json:"anomalous"`
+	Reasons        []string   `json:"reasons,omitempty"` // error_rate and/or low_confidence_rate
+	AnomalousSince *time.Time `json:"anomalous_since,omitempty"`
+
+	WindowSeconds     int     `json:"window_seconds"`
+	Parses            int     `json:"parses"`
+	Errors            int     `json:"errors"`
+	ErrorRate         float64 `json:"error_rate"`
+	Trades            int     `json:"trades"`
+	LowConfidence     int     `json:"low_confidence"`
+	LowConfidenceRate float64 `json:"low_confidence_rate"`
+
+	ErrorRateThreshold         float64   `json:"error_rate_threshold"`
+	LowConfidenceRateThreshold float64   `json:"low_confidence_rate_threshold"`
+	UpdatedAt                  time.Time `json:"updated_at"`
+}
+
+// Anomaly reasons reported in ParseAnomalyStatus.Reasons
+const (
+	ParseAnomalyErrorRate         = "error_rate"
+	ParseAnomalyLowConfidenceRate = "low_confidence_rate"
+)
+
+// ParseAnomalyAlerter is called when the parser anomaly is raised and again when it clears
+type ParseAnomalyAlerter func(status ParseAnomalyStatus)
+
+// parseBucket counts the parse outcomes of one minute
+type parseBucket struct {
+	start         time.Time
+	parses        int
+	errors        int
+	trades        int
+	lowConfidence int
+}
+
+// ParseAnomalyMonitor tracks rolling parse failure and low-confidence rates
+// Every ParseTransaction call feeds the monitor registered with SetParseAnomalyMonitor
+type ParseAnomalyMonitor struct {
+	errorThreshold         float64
+	lowConfidenceThreshold float64
+	minSamples             int
+	window                 time.Duration
+	alerter                ParseAnomalyAlerter
+	logger                 *logger.Logger
+	now                    func() time.Time
+
+	mu        sync.Mutex
+	buckets   []parseBucket
+	anomalous bool
+	since     time.Time
+	reasons   []string
+}
+
+// NewParseAnomalyMonitor creates a monitor using the thresholds of config
+func NewParseAnomalyMonitor(config *Config) *ParseAnomalyMonitor {
+	return &ParseAnomalyMonitor{
+		errorThreshold:         config.ParseErrorRateThreshold,
+		lowConfidenceThreshold: config.ParseLowConfidenceRateThreshold,
+		minSamples:             config.ParseAnomalyMinSamples,
+		window:                 DefaultParseAnomalyWindow,
+		logger:                 logger.NewFromEnv().WithComponent("hylo-parser"),
+		now:                    time.Now,
+	}
+}
+
+// SetAlerter sets the callback notified when the anomaly is raised or cleared
+func (m *ParseAnomalyMonitor) SetAlerter(alerter ParseAnomalyAlerter) {
+	m.alerter = alerter
+}
+
+// parseMonitor receives the outcome of every parse; nil until SetParseAnomalyMonitor is called
+var parseMonitor atomic.Pointer[ParseAnomalyMonitor]
+
+// SetParseAnomalyMonitor registers the monitor fed by every ParseTransaction call
+func SetParseAnomalyMonitor(m *ParseAnomalyMonitor) {
+	parseMonitor.Store(m)
+}
+
+// Record counts one parse outcome and alerts when the anomaly state changes
+func (m *ParseAnomalyMonitor) Record(failed, trade, lowConfidence bool) {
+	now := m.now()
+
+	m.mu.Lock()
+	if n := len(m.buckets); n == 0 || now.Sub(m.buckets[n-1].start) >= parseAnomalyBucketSize {
+		m.buckets = append(m.buckets, parseBucket{start: now.Truncate(parseAnomalyBucketSize)})
+	}
+	bucket := &m.buckets[len(m.buckets)-1]
+	bucket.parses++
+	if failed {
+		bucket.errors++
+	}
+	if trade {
+		bucket.trades++
+		if lowConfidence {
+			bucket.lowConfidence++
+		}
+	}
+	status, changed := m.evaluateLocked(now)
+	m.mu.Unlock()
+
+	m.notify(status, changed)
+}
+
+// Status returns the rates over the window, re-evaluating the anomaly as old buckets expire
+func (m *ParseAnomalyMonitor) Status() ParseAnomalyStatus {
+	m.mu.Lock()
+	status, changed := m.evaluateLocked(m.now())
+	m.mu.Unlock()
+
+	m.notify(status, changed)
+	return status
+}
+
+// evaluateLocked prunes expired buckets, updates the anomaly state and reports whether it changed
+// A rate is only judged once it has MinSamples behind it, so quiet periods neither raise nor clear the anomaly;
+// the caller holds mu
+func (m *ParseAnomalyMonitor) evaluateLocked(now time.Time) (ParseAnomalyStatus, bool) {
+	cutoff := now.Add(-m.window)
+	keep := 0
+	for keep < len(m.buckets) && !m.buckets[keep].start.After(cutoff) {
+		keep++
+	}
+	m.buckets = m.buckets[keep:]
+
+	status := ParseAnomalyStatus{
+		WindowSeconds:              int(m.window / time.Second),
+		ErrorRateThreshold:         m.errorThreshold,
+		LowConfidenceRateThreshold: m.lowConfidenceThreshold,
+		UpdatedAt:                  now.UTC(),
+	}
+	for _, bucket := range m.buckets {
+		status.Parses += bucket.parses
+		status.Errors += bucket.errors
+		status.Trades += bucket.trades
+		status.LowConfidence += bucket.lowConfidence
+	}
+	if status.Parses > 0 {
+		status.ErrorRate = float64(status.Errors) / float64(status.Parses)
+	}
+	if status.Trades > 0 {
+		status.LowConfidenceRate = float64(status.LowConfidence) / float64(status.Trades)
+	}
+
+	errorsJudged := status.Parses >= m.minSamples
+	lowConfidenceJudged := status.Trades >= m.minSamples
+
+	var reasons []string
+	if errorsJudged && m.errorThreshold > 0 && status.ErrorRate > m.errorThreshold {
+		reasons = append(reasons, ParseAnomalyErrorRate)
+	}
+	if lowConfidenceJudged && m.lowConfidenceThreshold > 0 && status.LowConfidenceRate > m.lowConfidenceThreshold {
+		reasons = append(reasons, ParseAnomalyLowConfidenceRate)
+	}
+
+	changed := false
+	switch {
+	case !m.anomalous && len(reasons) > 0:
+		m.anomalous, m.since, m.reasons, changed = true, now.UTC(), reasons, true
+	case m.anomalous && len(reasons) > 0:
+		m.reasons = reasons
+	case m.anomalous && errorsJudged && status.ErrorRate <= m.errorThreshold/2 &&
+		(!lowConfidenceJudged || status.LowConfidenceRate <= m.lowConfidenceThreshold/2):
+		m.anomalous, m.since, m.reasons, changed = false, time.Time{}, nil, true
+	}
+
+	status.Anomalous, status.Reasons = m.anomalous, m.reasons
+	if m.anomalous {
+		since := m.since
+		status.AnomalousSince = &since
+	}
+	return status, changed
+}
+
+// notify exports the anomaly state, logs it and calls the alerter when it changed
+func (m *ParseAnomalyMonitor) notify(status ParseAnomalyStatus, changed bool) {
+	if !changed {
+		return
+	}
+	metrics.SetParserAnomaly(status.Anomalous)
+
+	attrs := []any{
+		slog.Int("parses", status.Parses),
+		slog.Float64("error_rate", status.ErrorRate),
+		slog.Float64("low_confidence_rate", status.LowConfidenceRate),
+	}
+	if status.Anomalous {
+		m.logger.ErrorContext(context.Background(), "Parser anomaly raised, upstream format may have changed",
+			append(attrs, slog.Any("reasons", status.Reasons))...)
+	} else {
+		m.logger.InfoContext(context.Background(), "Parser anomaly cleared", attrs...)
+	}
+	if m.alerter != nil {
+		m.alerter(status)
+	}
+}
@@ -0,0 +1,105 @@
+package hylo
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestParseMonitor(clock *time.Time) (*ParseAnomalyMonitor, *[]ParseAnomalyStatus) {
+	config := &Config{
+		ParseErrorRateThreshold:         DefaultParseErrorRateThreshold,
+		ParseLowConfidenceRateThreshold: DefaultParseLowConfidenceThreshold,
+		ParseAnomalyMinSamples:          DefaultParseAnomalyMinSamples,
+	}
+	monitor := NewParseAnomalyMonitor(config)
+	monitor.now = func() time.Time { return *clock }
+
+	var alerts []ParseAnomalyStatus
+	monitor.SetAlerter(func(status ParseAnomalyStatus) { alerts = append(alerts, status) })
+	return monitor, &alerts
+}
+
+func TestParseAnomalyMonitorErrorRate(t *testing.T) {
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	monitor, alerts := newTestParseMonitor(&clock)
+
+	// Failures below the minimum sample count are not judged
+	for i := 0; i < 5; i++ {
+		monitor.Record(true, false, false)
+	}
+	if status := monitor.Status(); status.Anomalous || len(*alerts) != 0 {
+		t.Fatalf("expected no anomaly before %d samples, got %+v", DefaultParseAnomalyMinSamples, status)
+	}
+
+	for i := 0; i < 45; i++ {
+		monitor.Record(false, false, false)
+	}
+	status := monitor.Status()
+	if !status.Anomalous || len(*alerts) != 1 {
+		t.Fatalf("expected one anomaly alert at a 10%% error rate, got %d alerts and %+v", len(*alerts), status)
+	}
+	if len(status.Reasons) != 1 || status.Reasons[0] != ParseAnomalyErrorRate {
+		t.Errorf("expected the error rate as reason, got %v", status.Reasons)
+	}
+	if status.Parses != 50 || status.Errors != 5 || status.ErrorRate != 0.1 {
+		t.Errorf("unexpected counts: %+v", status)
+	}
+
+	// 4% is under the threshold but above half of it, so the anomaly holds
+	for i := 0; i < 75; i++ {
+		monitor.Record(false, false, false)
+	}
+	if !monitor.Status().Anomalous {
+		t.Fatal("expected the anomaly to hold until the rate falls under half the threshold")
+	}
+
+	// The failures expire with the window
+	clock = clock.Add(30 * time.Minute)
+	for i := 0; i < 30; i++ {
+		monitor.Record(false, false, false)
+	}
+	clock = clock.Add(31 * time.Minute)
+	status = monitor.Status()
+	if status.Anomalous || len(*alerts) != 2 || (*alerts)[1].Anomalous {
+		t.Fatalf("expected a recovery alert once the failures left the window, got %d alerts and %+v", len(*alerts), status)
+	}
+	if status.Parses != 30 || status.Errors != 0 {
+		t.Errorf("expected only the last 30 parses in the window, got %+v", status)
+	}
+}
+
+func TestParseAnomalyMonitorLowConfidenceRate(t *testing.T) {
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	monitor, alerts := newTestParseMonitor(&clock)
+
+	for i := 0; i < 40; i++ {
+		monitor.Record(false, true, i%3 == 0)
+	}
+	status := monitor.Status()
+	if !status.Anomalous || len(*alerts) != 1 {
+		t.Fatalf("expected an anomaly with a third of trades flagged, got %+v", status)
+	}
+	if len(status.Reasons) != 1 || status.Reasons[0] != ParseAnomalyLowConfidenceRate {
+		t.Errorf("expected the low confidence rate as reason, got %v", status.Reasons)
+	}
+	if status.AnomalousSince == nil || !status.AnomalousSince.Equal(clock) {
+		t.Errorf("expected anomalous_since to be set, got %v", status.AnomalousSince)
+	}
+}
+
+func TestRecordParseMetricsFeedsMonitor(t *testing.T) {
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	monitor, _ := newTestParseMonitor(&clock)
+	SetParseAnomalyMonitor(monitor)
+	defer SetParseAnomalyMonitor(nil)
+
+	recordParseMetrics(time.Millisecond, &TradeParseResult{Error: "transaction failed"}, nil)
+	recordParseMetrics(time.Millisecond, &TradeParseResult{Error: "unknown Hylo instruction"}, nil)
+	recordParseMetrics(time.Millisecond, &TradeParseResult{Trade: &XSOLTrade{NeedsReview: true}}, nil)
+	recordParseMetrics(time.Millisecond, &TradeParseResult{}, nil)
+
+	status := monitor.Status()
+	if status.Parses != 4 || status.Errors != 1 || status.Trades != 1 || status.LowConfidence != 1 {
+		t.Errorf("expected failed transactions not to count as errors, got %+v", status)
+	}
+}
@@ -0,0 +1,270 @@
+package hylo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Default counter-asset priorities; higher priority assets are preferred in multi-hop transactions
+const (
+	PriorityHyUSD   = 100 // Hylo native stablecoin - highest priority
+	PriorityUSDC    = 90  // USD stablecoin - very high priority
+	PriorityPYUSD   = 85  // Token-2022 USD stablecoin - very high priority
+	PrioritySHyUSD  = 80  // Staked hyUSD - high priority
+	PrioritySOL     = 50  // Native SOL - medium priority
+	PriorityJitoSOL = 30  // Liquid staking token - lower priority (often intermediate)
+	PriorityUnknown = 10  // Unknown tokens - lowest priority
+
+	// UnknownTokenSymbol labels token balance changes whose mint is not in the rules
+	UnknownTokenSymbol = "TOKEN"
+	// UnknownToken2022Symbol labels unrecognized mints held by the Token-2022 program
+	UnknownToken2022Symbol = "TOKEN2022"
+)
+
+// Environment variables controlling trade classification rules
+const (
+	EnvClassificationRulesFile = "HYLO_CLASSIFICATION_RULES_FILE" // JSON file replacing the default rules
+	EnvAssetPriorities         = "HYLO_ASSET_PRIORITIES"          // Comma-separated SYMBOL[:MINT]=PRIORITY overrides
+)
+
+// AssetRule maps a token mint to a counter-asset symbol and its selection priority
+// Mint is empty for assets that are not SPL tokens (native SOL). Program pins the token program that
+// owns the mint; Token-2022 mints set it so their balances are only matched when the program agrees
+type AssetRule struct {
+	Symbol   string         `json:"symbol"`
+	Mint     solana.Address `json:"mint,omitempty"`
+	Program  solana.Address `json:"program,omitempty"` // Empty matches any token program
+	Priority int            `json:"priority"`
+}
+
+// UnknownTokenRule controls how token balance changes with unrecognized mints are classified
+type UnknownTokenRule struct {
+	Symbol string `json:"symbol"`
+	// Token2022Symbol labels unrecognized mints whose balances report the Token-2022 program
+	Token2022Symbol string `json:"token_2022_symbol,omitempty"`
+	Priority        int    `json:"priority"`
+	// Ignore excludes unknown tokens from counter-asset selection entirely
+	Ignore bool `json:"ignore"`
+}
+
+// ClassificationRules drives counter-asset detection in the trade parser
+type ClassificationRules struct {
+	Assets       []AssetRule      `json:"assets"`
+	UnknownToken UnknownTokenRule `json:"unknown_token"`
+
+	priorities map[string]int
+	mints      map[solana.Address]AssetRule
+}
+
+// DefaultClassificationRules returns the built-in priority table for Hylo, stablecoin and LST counter assets
+func DefaultClassificationRules() *ClassificationRules {
+	rules := &ClassificationRules{
+		Assets: []AssetRule{
+			{Symbol: tokens.HyUSDSymbol, Mint: tokens.HyUSDMint, Priority: PriorityHyUSD},
+			{Symbol: tokens.USDCSymbol, Mint: tokens.USDCMint, Priority: PriorityUSDC},
+			{Symbol: tokens.SHyUSDSymbol, Mint: tokens.SHyUSDMint, Priority: PrioritySHyUSD},
+			{Symbol: tokens.XSOLSymbol, Mint: tokens.XSOLMint, Priority: PriorityUnknown},
+			{Symbol: tokens.SOLSymbol, Priority: PrioritySOL},
+			{Symbol: tokens.JitoSOLSymbol, Mint: tokens.JitoSOLMint, Priority: PriorityJitoSOL},
+			{Symbol: tokens.PYUSDSymbol, Mint: tokens.PYUSDMint, Program: tokens.Token2022ProgramID, Priority: PriorityPYUSD},
+		},
+		UnknownToken: UnknownTokenRule{Symbol: UnknownTokenSymbol, Token2022Symbol: UnknownToken2022Symbol, Priority: PriorityUnknown},
+	}
+	rules.buildIndex()
+	return rules
+}
+
+// LoadClassificationRulesFromEnvironment builds rules from HYLO_CLASSIFICATION_RULES_FILE (if set)
+// and applies HYLO_ASSET_PRIORITIES overrides on top; defaults are used when neither is set
+func LoadClassificationRulesFromEnvironment() (*ClassificationRules, error) {
+	rules := DefaultClassificationRules()
+
+	if path := strings.TrimSpace(os.Getenv(EnvClassificationRulesFile)); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read classification rules: %w", err)
+		}
+		if rules, err = ParseClassificationRules(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if overrides := strings.TrimSpace(os.Getenv(EnvAssetPriorities)); overrides != "" {
+		if err := rules.applyOverrides(overrides); err != nil {
+			return nil, err
+		}
+	}
+
+	return rules, nil
+}
+
+// ParseClassificationRules decodes and validates JSON classification rules
+func ParseClassificationRules(data []byte) (*ClassificationRules, error) {
+	var rules ClassificationRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid classification rules: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	if rules.UnknownToken.Symbol == "" {
+		rules.UnknownToken.Symbol = UnknownTokenSymbol
+	}
+	if rules.UnknownToken.Token2022Symbol == "" {
+		rules.UnknownToken.Token2022Symbol = UnknownToken2022Symbol
+	}
+	rules.buildIndex()
+	return &rules, nil
+}
+
+// Validate checks for missing symbols, malformed mints, unknown token programs and duplicate mappings
+func (r *ClassificationRules) Validate() error {
+	symbols := make(map[string]bool, len(r.Assets))
+	mints := make(map[solana.Address]bool, len(r.Assets))
+
+	for _, asset := range r.Assets {
+		if asset.Symbol == "" {
+			return fmt.Errorf("invalid classification rules: asset symbol is required")
+		}
+		if symbols[asset.Symbol] {
+			return fmt.Errorf("invalid classification rules: duplicate symbol %s", asset.Symbol)
+		}
+		symbols[asset.Symbol] = true
+
+		if asset.Mint == "" {
+			if asset.Program != "" {
+				return fmt.Errorf("invalid classification rules: %s has a program but no mint", asset.Symbol)
+			}
+			continue
+		}
+		switch asset.Program {
+		case "", tokens.SPLTokenProgramID, tokens.Token2022ProgramID:
+		default:
+			return fmt.Errorf("invalid classification rules: program for %s must be %s or %s", asset.Symbol, tokens.SPLTokenProgramID, tokens.Token2022ProgramID)
+		}
+		if err := asset.Mint.Validate(); err != nil {
+			return fmt.Errorf("invalid classification rules: mint for %s: %w", asset.Symbol, err)
+		}
+		if mints[asset.Mint] {
+			return fmt.Errorf("invalid classification rules: duplicate mint %s", asset.Mint)
+		}
+		mints[asset.Mint] = true
+	}
+
+	return nil
+}
+
+// applyOverrides parses SYMBOL[:MINT]=PRIORITY entries, updating existing assets or adding new ones
+func (r *ClassificationRules) applyOverrides(overrides string) error {
+	for _, entry := range strings.Split(overrides, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid %s entry %q: expected SYMBOL[:MINT]=PRIORITY", EnvAssetPriorities, entry)
+		}
+		priority, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid %s entry %q: priority must be an integer", EnvAssetPriorities, entry)
+		}
+		symbol, mint, _ := strings.Cut(strings.TrimSpace(key), ":")
+
+		if symbol == UnknownTokenSymbol || symbol == r.UnknownToken.Symbol {
+			r.UnknownToken.Priority = priority
+			continue
+		}
+
+		updated := false
+		for i := range r.Assets {
+			if r.Assets[i].Symbol == symbol {
+				r.Assets[i].Priority = priority
+				if mint != "" {
+					r.Assets[i].Mint = solana.Address(mint)
+				}
+				updated = true
+			}
+		}
+		if !updated {
+			r.Assets = append(r.Assets, AssetRule{Symbol: symbol, Mint: solana.Address(mint), Priority: priority})
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	r.buildIndex()
+	return nil
+}
+
+func (r *ClassificationRules) buildIndex() {
+	r.priorities = make(map[string]int, len(r.Assets))
+	r.mints = make(map[solana.Address]AssetRule, len(r.Assets))
+	for _, asset := range r.Assets {
+		r.priorities[asset.Symbol] = asset.Priority
+		if asset.Mint != "" {
+			r.mints[asset.Mint] = asset
+		}
+	}
+}
+
+// Priority returns the selection priority for a counter-asset symbol
+func (r *ClassificationRules) Priority(symbol string) int {
+	if priority, ok := r.priorities[symbol]; ok {
+		return priority
+	}
+	return r.UnknownToken.Priority
+}
+
+// SymbolForMint returns the configured symbol for a mint, or the unknown token symbol
+func (r *ClassificationRules) SymbolForMint(mint solana.Address) (string, bool) {
+	return r.SymbolForToken(mint, "")
+}
+
+// SymbolForToken returns the configured symbol for a mint held by program, which is empty when the
+// RPC node did not report it. A rule pinned to another program does not match, and unrecognized
+// Token-2022 mints get the Token-2022 unknown symbol so they are not confused with SPL tokens
+func (r *ClassificationRules) SymbolForToken(mint, program solana.Address) (string, bool) {
+	if asset, ok := r.mints[mint]; ok && (asset.Program == "" || program == "" || asset.Program == program) {
+		return asset.Symbol, true
+	}
+	if program == tokens.Token2022ProgramID && r.UnknownToken.Token2022Symbol != "" {
+		return r.UnknownToken.Token2022Symbol, false
+	}
+	return r.UnknownToken.Symbol, false
+}
+
+// IsUnknownToken reports whether symbol is one of the labels given to unrecognized mints
+func (r *ClassificationRules) IsUnknownToken(symbol string) bool {
+	return symbol == r.UnknownToken.Symbol || (r.UnknownToken.Token2022Symbol != "" && symbol == r.UnknownToken.Token2022Symbol)
+}
+
+// activeRules holds the classification rules used by the transaction parser
+var activeRules atomic.Pointer[ClassificationRules]
+
+func init() {
+	activeRules.Store(DefaultClassificationRules())
+}
+
+// SetClassificationRules replaces the rules used by the transaction parser; nil restores the defaults
+func SetClassificationRules(rules *ClassificationRules) {
+	if rules == nil {
+		rules = DefaultClassificationRules()
+	}
+	activeRules.Store(rules)
+}
+
+// GetClassificationRules returns the rules currently used by the transaction parser
+func GetClassificationRules() *ClassificationRules {
+	return activeRules.Load()
+}
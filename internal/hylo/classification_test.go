@@ -0,0 +1,153 @@
+package hylo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// testUSDTMint is the mainnet USDT mint used to exercise custom stablecoin rules
+const testUSDTMint = "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"
+
+func TestLoadClassificationRulesFromEnvironment(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv(EnvClassificationRulesFile, "")
+		t.Setenv(EnvAssetPriorities, "")
+
+		rules, err := LoadClassificationRulesFromEnvironment()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rules.Priority(tokens.HyUSDSymbol) != PriorityHyUSD || rules.Priority("UNLISTED") != PriorityUnknown {
+			t.Errorf("Expected default priorities, got hyUSD=%d unknown=%d", rules.Priority(tokens.HyUSDSymbol), rules.Priority("UNLISTED"))
+		}
+	})
+
+	t.Run("env overrides add and update assets", func(t *testing.T) {
+		t.Setenv(EnvClassificationRulesFile, "")
+		t.Setenv(EnvAssetPriorities, "USDT:"+testUSDTMint+"=95, jitoSOL=60, TOKEN=5")
+
+		rules, err := LoadClassificationRulesFromEnvironment()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if symbol, ok := rules.SymbolForMint(testUSDTMint); !ok || symbol != "USDT" {
+			t.Errorf("Expected USDT mint to be recognized, got %q", symbol)
+		}
+		if rules.Priority("USDT") != 95 || rules.Priority(tokens.JitoSOLSymbol) != 60 || rules.Priority("UNLISTED") != 5 {
+			t.Errorf("Expected overridden priorities, got USDT=%d jitoSOL=%d unknown=%d",
+				rules.Priority("USDT"), rules.Priority(tokens.JitoSOLSymbol), rules.Priority("UNLISTED"))
+		}
+	})
+
+	t.Run("rules file replaces defaults", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rules.json")
+		content := `{"assets":[{"symbol":"USDT","mint":"` + testUSDTMint + `","priority":90},{"symbol":"SOL","priority":50}],"unknown_token":{"symbol":"OTHER","priority":1,"ignore":true}}`
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(EnvClassificationRulesFile, path)
+		t.Setenv(EnvAssetPriorities, "")
+
+		rules, err := LoadClassificationRulesFromEnvironment()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if symbol, ok := rules.SymbolForMint(tokens.HyUSDMint); ok || symbol != "OTHER" {
+			t.Errorf("Expected hyUSD to be unknown under custom rules, got %q", symbol)
+		}
+		if !rules.UnknownToken.Ignore {
+			t.Error("Expected unknown tokens to be ignored")
+		}
+	})
+
+	t.Run("invalid entries are rejected", func(t *testing.T) {
+		for _, value := range []string{"USDT", "USDT=high", "USDT:not-a-mint=90", "USDC:" + string(tokens.HyUSDMint) + "=90"} {
+			t.Setenv(EnvClassificationRulesFile, "")
+			t.Setenv(EnvAssetPriorities, value)
+			if _, err := LoadClassificationRulesFromEnvironment(); err == nil || !strings.Contains(err.Error(), "invalid") {
+				t.Errorf("Expected error for %q, got %v", value, err)
+			}
+		}
+	})
+}
+
+func TestAnalyzeTokenBalanceChangesWithCustomRules(t *testing.T) {
+	rules := DefaultClassificationRules()
+	if err := rules.applyOverrides("USDT:" + testUSDTMint + "=120"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	SetClassificationRules(rules)
+	defer SetClassificationRules(nil)
+
+	tx := &solana.TransactionDetails{
+		Meta: &solana.TxMeta{
+			PreTokenBalances: []solana.TokenBalance{
+				{AccountIndex: 1, Mint: string(tokens.HyUSDMint), UITokenAmount: &solana.UITokenAmount{Amount: "5000000"}},
+				{AccountIndex: 2, Mint: testUSDTMint, UITokenAmount: &solana.UITokenAmount{Amount: "1000000"}},
+			},
+			PostTokenBalances: []solana.TokenBalance{
+				{AccountIndex: 1, Mint: string(tokens.HyUSDMint), UITokenAmount: &solana.UITokenAmount{Amount: "0"}},
+				{AccountIndex: 2, Mint: testUSDTMint, UITokenAmount: &solana.UITokenAmount{Amount: "0"}},
+			},
+		},
+	}
+
+	amount, asset := analyzeTokenBalanceChanges(tx, 0, TradeSideBuy)
+	if asset != "USDT" || amount != 1000000 {
+		t.Errorf("Expected configured USDT to outrank hyUSD, got %s %d", asset, amount)
+	}
+}
+
+func TestAnalyzeTokenBalanceChangesToken2022(t *testing.T) {
+	program := tokens.Token2022ProgramID
+	tx := &solana.TransactionDetails{
+		Meta: &solana.TxMeta{
+			PreTokenBalances: []solana.TokenBalance{
+				{AccountIndex: 1, Mint: string(tokens.PYUSDMint), ProgramId: &program, UITokenAmount: &solana.UITokenAmount{Amount: "2000000"}},
+				{AccountIndex: 2, Mint: testUSDTMint, ProgramId: &program, UITokenAmount: &solana.UITokenAmount{Amount: "9000000"}},
+			},
+			PostTokenBalances: []solana.TokenBalance{
+				{AccountIndex: 1, Mint: string(tokens.PYUSDMint), ProgramId: &program, UITokenAmount: &solana.UITokenAmount{Amount: "0"}},
+				{AccountIndex: 2, Mint: testUSDTMint, ProgramId: &program, UITokenAmount: &solana.UITokenAmount{Amount: "0"}},
+			},
+		},
+	}
+
+	amount, asset := analyzeTokenBalanceChanges(tx, 0, TradeSideBuy)
+	if asset != tokens.PYUSDSymbol || amount != 2000000 {
+		t.Errorf("Expected the registered Token-2022 stablecoin to win, got %s %d", asset, amount)
+	}
+
+	// Without PYUSD the larger unregistered Token-2022 balance is labeled as such
+	tx.Meta.PreTokenBalances, tx.Meta.PostTokenBalances = tx.Meta.PreTokenBalances[1:], tx.Meta.PostTokenBalances[1:]
+	if _, asset := analyzeTokenBalanceChanges(tx, 0, TradeSideBuy); asset != UnknownToken2022Symbol {
+		t.Errorf("Expected %s, got %s", UnknownToken2022Symbol, asset)
+	}
+}
+
+func TestParseClassificationRulesPrograms(t *testing.T) {
+	rules, err := ParseClassificationRules([]byte(`{"assets":[{"symbol":"PYUSD","mint":"` + string(tokens.PYUSDMint) + `","program":"` + tokens.Token2022ProgramID + `","priority":85}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules.UnknownToken.Token2022Symbol != UnknownToken2022Symbol {
+		t.Errorf("Expected the default Token-2022 unknown symbol, got %q", rules.UnknownToken.Token2022Symbol)
+	}
+	if !rules.IsUnknownToken(UnknownToken2022Symbol) || rules.IsUnknownToken(tokens.PYUSDSymbol) {
+		t.Error("Expected only the unknown labels to count as unknown tokens")
+	}
+
+	for _, content := range []string{
+		`{"assets":[{"symbol":"PYUSD","mint":"` + string(tokens.PYUSDMint) + `","program":"` + tokens.SystemProgramID + `"}]}`,
+		`{"assets":[{"symbol":"SOL","program":"` + tokens.Token2022ProgramID + `"}]}`,
+	} {
+		if _, err := ParseClassificationRules([]byte(content)); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("Expected error for %s, got %v", content, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package hylo
+
+import (
+	"context"
+	"log/slog"
+	"math"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// ReviewConfidenceThreshold is the score below which a parsed trade is flagged for manual review
+const ReviewConfidenceThreshold = 0.6
+
+// tradeSource is how a trade was recognized, which sets its base confidence
+type tradeSource int
+
+const (
+	sourceHyloInstruction  tradeSource = iota // Mint/redeem instruction found in the transaction
+	sourceBalanceInference                    // Inferred from the xSOL balance change alone
+	sourceFundingInference                    // First xSOL balance with no instruction or counter asset
+)
+
+// base returns the starting score for the source and the reason reported when it is below 1
+func (s tradeSource) base() (float64, string) {
+	switch s {
+	case sourceBalanceInference:
+		return 0.7, ConfidenceReasonBalanceInference
+	case sourceFundingInference:
+		return 0.5, ConfidenceReasonFundingInference
+	default:
+		return 1.0, ""
+	}
+}
+
+// Reasons a trade's confidence was lowered, reported in XSOLTrade.ConfidenceReasons
+const (
+	ConfidenceReasonBalanceInference  = "balance_inference"         // No Hylo instruction; side came from the balance change
+	ConfidenceReasonFundingInference  = "funding_inference"         // RECEIVE inferred from a first-time xSOL balance
+	ConfidenceReasonNoCounterAsset    = "counter_asset_not_found"   // Nothing moved the other way; SOL is a guess
+	ConfidenceReasonUnknownAsset      = "unknown_counter_asset"     // Counter asset mint is not in the classification rules
+	ConfidenceReasonCompetingAssets   = "competing_counter_assets"  // Another asset of equal priority moved the same way
+	ConfidenceReasonUnscopedSOLChange = "wallet_owner_not_recorded" // SOL was picked from every account, not just the wallet's
+)
+
+// Score deductions for each ambiguity found in the counter-asset match
+const (
+	penaltyNoCounterAsset    = 0.3
+	penaltyUnknownAsset      = 0.2
+	penaltyCompetingAssets   = 0.15
+	penaltyUnscopedSOLChange = 0.1
+)
+
+// applyConfidence scores how reliably the trade was classified and flags low scores for review
+// The source sets the starting score; each ambiguity in the counter-asset match deducts from it
+func applyConfidence(ctx context.Context, trade *XSOLTrade, tx *solana.TransactionDetails, xsolIndex int, source tradeSource, log *logger.Logger) {
+	score, reason := source.base()
+	var reasons []string
+	if reason != "" {
+		reasons = append(reasons, reason)
+	}
+	deduct := func(reason string, penalty float64) {
+		score -= penalty
+		reasons = append(reasons, reason)
+	}
+
+	if trade.Side == TradeSideBuy || trade.Side == TradeSideSell {
+		rules := GetClassificationRules()
+		switch {
+		case trade.CounterAmountRaw == 0:
+			deduct(ConfidenceReasonNoCounterAsset, penaltyNoCounterAsset)
+		case rules.IsUnknownToken(trade.CounterAsset):
+			deduct(ConfidenceReasonUnknownAsset, penaltyUnknownAsset)
+		case trade.CounterAsset == tokens.SOLSymbol && walletSOLAccounts(tx, xsolIndex) == nil:
+			deduct(ConfidenceReasonUnscopedSOLChange, penaltyUnscopedSOLChange)
+		}
+
+		if trade.CounterAmountRaw != 0 && hasCompetingCounterAsset(tx, xsolIndex, trade.Side, trade.CounterAsset) {
+			deduct(ConfidenceReasonCompetingAssets, penaltyCompetingAssets)
+		}
+	}
+
+	trade.Confidence = math.Round(math.Max(score, 0)*100) / 100
+	trade.ConfidenceReasons = reasons
+	trade.NeedsReview = trade.Confidence < ReviewConfidenceThreshold
+
+	if trade.NeedsReview && log != nil {
+		log.WarnContext(ctx, "Low-confidence trade flagged for review",
+			slog.String("signature", trade.Signature),
+			slog.String("side", trade.Side),
+			slog.String("counter_asset", trade.CounterAsset),
+			slog.Float64("confidence", trade.Confidence),
+			slog.Any("reasons", reasons))
+	}
+}
+
+// hasCompetingCounterAsset reports whether a token other than the chosen counter asset moved in the
+// trade's direction with the same selection priority, i.e. priorities alone did not settle the choice
+func hasCompetingCounterAsset(tx *solana.TransactionDetails, xsolIndex int, tradeSide, chosen string) bool {
+	rules := GetClassificationRules()
+	chosenPriority := rules.Priority(chosen)
+
+	for _, pre := range tx.Meta.PreTokenBalances {
+		if int(pre.AccountIndex) == xsolIndex || pre.Mint == "" {
+			continue
+		}
+		post := findTokenBalance(tx.Meta.PostTokenBalances, pre.AccountIndex)
+		if post == nil {
+			continue
+		}
+
+		preAmount, err := parseTokenAmount(pre.UITokenAmount)
+		if err != nil {
+			continue
+		}
+		postAmount, err := parseTokenAmount(post.UITokenAmount)
+		if err != nil {
+			continue
+		}
+
+		spent := tradeSide == TradeSideBuy && postAmount < preAmount
+		received := tradeSide == TradeSideSell && postAmount > preAmount
+		if !spent && !received {
+			continue
+		}
+
+		asset := detectTokenAssetType(pre.Mint, tokenAccountProgram(&pre, post))
+		if rules.UnknownToken.Ignore && rules.IsUnknownToken(asset) {
+			continue
+		}
+		if asset != chosen && rules.Priority(asset) == chosenPriority {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package hylo
+
+import (
+	"context"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestApplyConfidence(t *testing.T) {
+	owner := "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+	tokenBalance := func(index uint32, mint, amount string) solana.TokenBalance {
+		return solana.TokenBalance{AccountIndex: index, Mint: mint, Owner: &owner, UITokenAmount: &solana.UITokenAmount{Amount: amount}}
+	}
+	newTx := func(pre, post []solana.TokenBalance) *solana.TransactionDetails {
+		return &solana.TransactionDetails{
+			Meta: &solana.TxMeta{PreTokenBalances: pre, PostTokenBalances: post},
+			Transaction: solana.Transaction{
+				Message: solana.TxMessage{AccountKeys: []string{owner, "hyusd-ata", tokens.TestXSOLATA1, "usdt-ata"}},
+			},
+		}
+	}
+	newTrade := func(counterRaw uint64, counterAsset string) *XSOLTrade {
+		trade := NewXSOLTrade("sig", 1, 0)
+		trade.SetTradeDetails(TradeSideBuy, 1_000_000, counterRaw, counterAsset)
+		return trade
+	}
+
+	hyusdBuy := newTx(
+		[]solana.TokenBalance{tokenBalance(1, string(tokens.HyUSDMint), "5000000"), tokenBalance(2, string(tokens.XSOLMint), "0")},
+		[]solana.TokenBalance{tokenBalance(1, string(tokens.HyUSDMint), "0"), tokenBalance(2, string(tokens.XSOLMint), "1000000")},
+	)
+
+	t.Run("hylo instruction with a clean counter asset", func(t *testing.T) {
+		trade := newTrade(5_000_000, tokens.HyUSDSymbol)
+		applyConfidence(context.Background(), trade, hyusdBuy, 2, sourceHyloInstruction, nil)
+		if trade.Confidence != 1 || trade.NeedsReview || len(trade.ConfidenceReasons) != 0 {
+			t.Errorf("Expected full confidence, got %v %v", trade.Confidence, trade.ConfidenceReasons)
+		}
+	})
+
+	t.Run("balance inference without a counter asset", func(t *testing.T) {
+		trade := newTrade(0, tokens.SOLSymbol)
+		applyConfidence(context.Background(), trade, hyusdBuy, 2, sourceBalanceInference, nil)
+		if trade.Confidence != 0.4 || !trade.NeedsReview {
+			t.Errorf("Expected 0.4 flagged for review, got %v (review %t)", trade.Confidence, trade.NeedsReview)
+		}
+		want := []string{ConfidenceReasonBalanceInference, ConfidenceReasonNoCounterAsset}
+		if len(trade.ConfidenceReasons) != 2 || trade.ConfidenceReasons[0] != want[0] || trade.ConfidenceReasons[1] != want[1] {
+			t.Errorf("Expected reasons %v, got %v", want, trade.ConfidenceReasons)
+		}
+	})
+
+	t.Run("competing counter assets of equal priority", func(t *testing.T) {
+		rules := DefaultClassificationRules()
+		if err := rules.applyOverrides("USDT:" + testUSDTMint + "=100"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		SetClassificationRules(rules)
+		defer SetClassificationRules(nil)
+
+		tx := newTx(
+			[]solana.TokenBalance{tokenBalance(1, string(tokens.HyUSDMint), "5000000"), tokenBalance(3, testUSDTMint, "2000000")},
+			[]solana.TokenBalance{tokenBalance(1, string(tokens.HyUSDMint), "0"), tokenBalance(3, testUSDTMint, "0")},
+		)
+		trade := newTrade(5_000_000, tokens.HyUSDSymbol)
+		applyConfidence(context.Background(), trade, tx, 2, sourceHyloInstruction, nil)
+		if trade.Confidence != 0.85 || trade.NeedsReview {
+			t.Errorf("Expected 0.85 without review, got %v (review %t)", trade.Confidence, trade.NeedsReview)
+		}
+		if len(trade.ConfidenceReasons) != 1 || trade.ConfidenceReasons[0] != ConfidenceReasonCompetingAssets {
+			t.Errorf("Expected competing assets reason, got %v", trade.ConfidenceReasons)
+		}
+	})
+
+	t.Run("unscoped SOL when the wallet owner is not recorded", func(t *testing.T) {
+		tx := newTx(nil, nil)
+		trade := newTrade(1_000_000_000, tokens.SOLSymbol)
+		applyConfidence(context.Background(), trade, tx, 2, sourceBalanceInference, nil)
+		if trade.Confidence != 0.6 || trade.NeedsReview {
+			t.Errorf("Expected 0.6 at the review threshold, got %v (review %t)", trade.Confidence, trade.NeedsReview)
+		}
+	})
+
+	t.Run("initial funding", func(t *testing.T) {
+		trade := NewXSOLTrade("sig", 1, 0)
+		trade.SetTradeDetails(TradeSideReceive, 1_000_000, 0, "")
+		applyConfidence(context.Background(), trade, hyusdBuy, 2, sourceFundingInference, nil)
+		if trade.Confidence != 0.5 || !trade.NeedsReview {
+			t.Errorf("Expected 0.5 flagged for review, got %v (review %t)", trade.Confidence, trade.NeedsReview)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package hylo
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"hylo-wallet-tracker-api/internal/solana"
@@ -16,6 +17,37 @@ type Config struct {
 	// StabilityPoolProgramID can be overridden via HYLO_STABILITY_POOL_PROGRAM_ID environment variable
 	StabilityPoolProgramID solana.Address
 
+	// PriceAudit cross-checks every price calculation with independent formulas (HYLO_PRICE_AUDIT)
+	PriceAudit bool
+
+	// LSTVaults are the exchange's LST collateral token accounts read for GET /protocol/tvl (HYLO_LST_VAULTS)
+	LSTVaults []LSTVault
+
+	// MaxTradeSupplyFraction flags trades moving more than this share of the xSOL supply (HYLO_MAX_TRADE_SUPPLY_FRACTION, 0 disables)
+	MaxTradeSupplyFraction float64
+
+	// DustMinXSOLRaw and DustMinUSD are the minimum trade size in raw xSOL units and in USD; smaller trades are
+	// left out of trade history as dust (HYLO_DUST_MIN_XSOL_RAW, HYLO_DUST_MIN_USD, 0 disables each)
+	DustMinXSOLRaw uint64
+	DustMinUSD     float64
+
+	// ParseErrorRateThreshold is the share of parses failing over the last hour that marks the parser anomalous
+	// (HYLO_PARSE_ERROR_RATE_THRESHOLD, 0 disables)
+	ParseErrorRateThreshold float64
+
+	// ParseLowConfidenceRateThreshold is the share of parsed trades flagged for review over the last hour that
+	// marks the parser anomalous (HYLO_PARSE_LOW_CONFIDENCE_RATE_THRESHOLD, 0 disables)
+	ParseLowConfidenceRateThreshold float64
+
+	// ParseAnomalyMinSamples is the number of parses, or trades for the low confidence rate, needed before
+	// a rate is judged (HYLO_PARSE_ANOMALY_MIN_SAMPLES)
+	ParseAnomalyMinSamples int
+
+	// StabilityMode1Ratio and StabilityMode2Ratio are the collateral ratios below which the protocol enters
+	// stability mode 1 and 2, used by GET /protocol/scenarios (HYLO_STABILITY_MODE1_CR, HYLO_STABILITY_MODE2_CR)
+	StabilityMode1Ratio float64
+	StabilityMode2Ratio float64
+
 	// programRegistry is an internal map for fast program lookups
 	programRegistry map[solana.Address]string
 }
@@ -27,6 +59,14 @@ func NewConfig() *Config {
 		// Default to mainnet addresses from constants
 		ExchangeProgramID:      ExchangeProgram,
 		StabilityPoolProgramID: StabilityPoolProgram,
+		MaxTradeSupplyFraction: DefaultMaxTradeSupplyFraction,
+
+		ParseErrorRateThreshold:         DefaultParseErrorRateThreshold,
+		ParseLowConfidenceRateThreshold: DefaultParseLowConfidenceThreshold,
+		ParseAnomalyMinSamples:          DefaultParseAnomalyMinSamples,
+
+		StabilityMode1Ratio: DefaultStabilityMode1Ratio,
+		StabilityMode2Ratio: DefaultStabilityMode2Ratio,
 	}
 
 	// Load configuration from environment variables
@@ -50,6 +90,78 @@ func (c *Config) loadFromEnvironment() {
 	if stabilityPoolID := os.Getenv("HYLO_STABILITY_POOL_PROGRAM_ID"); stabilityPoolID != "" {
 		c.StabilityPoolProgramID = solana.Address(strings.TrimSpace(stabilityPoolID))
 	}
+
+	// Enable the price calculation audit if requested
+	if audit, err := strconv.ParseBool(os.Getenv("HYLO_PRICE_AUDIT")); err == nil {
+		c.PriceAudit = audit
+	}
+
+	// Trade amount sanity threshold; negative or malformed values keep the default
+	if value := os.Getenv("HYLO_MAX_TRADE_SUPPLY_FRACTION"); value != "" {
+		if fraction, err := strconv.ParseFloat(value, 64); err == nil && fraction >= 0 {
+			c.MaxTradeSupplyFraction = fraction
+		}
+	}
+
+	// Dust thresholds; negative or malformed values keep the filter disabled
+	if value := os.Getenv("HYLO_DUST_MIN_XSOL_RAW"); value != "" {
+		if raw, err := strconv.ParseUint(value, 10, 64); err == nil {
+			c.DustMinXSOLRaw = raw
+		}
+	}
+	if value := os.Getenv("HYLO_DUST_MIN_USD"); value != "" {
+		if usd, err := strconv.ParseFloat(value, 64); err == nil && usd >= 0 {
+			c.DustMinUSD = usd
+		}
+	}
+
+	// Parser anomaly thresholds; rates must lie in [0, 1]
+	if value := os.Getenv("HYLO_PARSE_ERROR_RATE_THRESHOLD"); value != "" {
+		if rate, err := strconv.ParseFloat(value, 64); err == nil && rate >= 0 && rate <= 1 {
+			c.ParseErrorRateThreshold = rate
+		}
+	}
+	if value := os.Getenv("HYLO_PARSE_LOW_CONFIDENCE_RATE_THRESHOLD"); value != "" {
+		if rate, err := strconv.ParseFloat(value, 64); err == nil && rate >= 0 && rate <= 1 {
+			c.ParseLowConfidenceRateThreshold = rate
+		}
+	}
+	if value := os.Getenv("HYLO_PARSE_ANOMALY_MIN_SAMPLES"); value != "" {
+		if samples, err := strconv.Atoi(value); err == nil && samples > 0 {
+			c.ParseAnomalyMinSamples = samples
+		}
+	}
+
+	// Stability mode thresholds; ratios must be above 1 (100% collateralized)
+	if value := os.Getenv("HYLO_STABILITY_MODE1_CR"); value != "" {
+		if ratio, err := strconv.ParseFloat(value, 64); err == nil && ratio > 1 {
+			c.StabilityMode1Ratio = ratio
+		}
+	}
+	if value := os.Getenv("HYLO_STABILITY_MODE2_CR"); value != "" {
+		if ratio, err := strconv.ParseFloat(value, 64); err == nil && ratio > 1 {
+			c.StabilityMode2Ratio = ratio
+		}
+	}
+
+	// LST vaults as comma-separated symbol:vault pairs; malformed entries are skipped
+	if vaults := os.Getenv("HYLO_LST_VAULTS"); vaults != "" {
+		c.LSTVaults = parseLSTVaults(vaults)
+	}
+}
+
+// parseLSTVaults parses "jitoSOL:<token account>,<symbol>:<token account>" into vaults
+func parseLSTVaults(value string) []LSTVault {
+	var vaults []LSTVault
+	for _, entry := range strings.Split(value, ",") {
+		symbol, address, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		vault := LSTVault{Symbol: strings.TrimSpace(symbol), Vault: solana.Address(strings.TrimSpace(address))}
+		if !ok || vault.Symbol == "" || vault.Vault.Validate() != nil {
+			continue
+		}
+		vaults = append(vaults, vault)
+	}
+	return vaults
 }
 
 // buildProgramRegistry builds an internal registry for fast program lookups
@@ -77,6 +189,12 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("exchange and stability pool programs cannot have the same address")
 	}
 
+	// Stability mode 2 is the deeper mode, so it starts at a lower collateral ratio
+	if c.StabilityMode2Ratio >= c.StabilityMode1Ratio {
+		return fmt.Errorf("stability mode 2 collateral ratio %.2f must be below the mode 1 ratio %.2f",
+			c.StabilityMode2Ratio, c.StabilityMode1Ratio)
+	}
+
 	return nil
 }
 
@@ -36,6 +36,22 @@ const (
 	TradeSideReceive = "RECEIVE" // User receives xSOL through transfer/mint (initial funding)
 )
 
+// TradeStatusFailed marks a Hylo interaction whose transaction reverted on-chain
+const TradeStatusFailed = "failed"
+
+// Exchange Fee Constants
+// Defaults are used by trade simulation when fee rates cannot be read from the exchange state account
+const (
+	BasisPointsDenominator = 10000 // 100% expressed in basis points
+
+	DefaultMintFeeRateBps   uint16 = 50  // 0.5% fee charged when minting xSOL
+	DefaultRedeemFeeRateBps uint16 = 50  // 0.5% fee charged when redeeming xSOL
+	MaxReasonableFeeRateBps uint16 = 500 // Parsed fee rates above 5% are treated as unparseable
+
+	FeeSourceEstimated = "estimated" // Fee rates read at assumed offsets of the exchange state account, not decoded with the program IDL
+	FeeSourceDefault   = "default"   // Fee rates fall back to protocol defaults
+)
+
 // Hylo Program IDs as solana.Address types for consistency with existing codebase
 var (
 	// ExchangeProgram represents the main Hylo exchange program address
@@ -0,0 +1,224 @@
+package hylo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mr-tron/base58"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Recording flags; TestRecordCorpusEntry only runs when -record-signature is set
+var (
+	recordSignature   = flag.String("record-signature", "", "mainnet transaction to fetch into testdata/corpus as a recorded entry")
+	recordWallet      = flag.String("record-wallet", "", "wallet the recorded transaction is parsed for; defaults to its fee payer")
+	recordName        = flag.String("record-name", "", "corpus entry name, e.g. buy_hyusd_recorded_01")
+	recordCategory    = flag.String("record-category", "", "corpus category of the recorded transaction")
+	recordDescription = flag.String("record-description", "", "what the recorded transaction does")
+)
+
+// corpusPseudonymSalt keeps sanitized addresses stable across recordings without being the sha256 of the real one alone
+const corpusPseudonymSalt = "hylo-corpus:"
+
+// TestRecordCorpusEntry fetches a mainnet transaction from RPC_HTTP_URL, sanitizes it and writes it to testdata/corpus
+// Run go test ./internal/hylo -run TestRecordCorpusEntry -record-signature <sig> -record-name <name> -record-category <category>,
+// then -run TestParserCorpus -update, and check the golden file against an explorer before committing both
+func TestRecordCorpusEntry(t *testing.T) {
+	if *recordSignature == "" {
+		t.Skip("set -record-signature to record a corpus entry")
+	}
+	if *recordName == "" || *recordCategory == "" {
+		t.Fatal("-record-name and -record-category are required")
+	}
+	rpcURL := os.Getenv("RPC_HTTP_URL")
+	if rpcURL == "" {
+		t.Fatal("RPC_HTTP_URL must point at a mainnet RPC endpoint")
+	}
+
+	client, err := solana.NewHTTPClient(solana.NewConfig(rpcURL, strings.Replace(rpcURL, "http", "ws", 1)), quietLogger())
+	if err != nil {
+		t.Fatalf("failed to create RPC client: %v", err)
+	}
+	tx, err := client.GetTransaction(context.Background(), solana.Signature(*recordSignature))
+	if err != nil {
+		t.Fatalf("failed to fetch %s: %v", *recordSignature, err)
+	}
+
+	wallet := solana.Address(*recordWallet)
+	if wallet == "" && len(tx.Transaction.Message.AccountKeys) > 0 {
+		wallet = solana.Address(tx.Transaction.Message.AccountKeys[0])
+	}
+	entry, err := sanitizeCorpusEntry(tx, wallet)
+	if err != nil {
+		t.Fatalf("failed to sanitize %s: %v", *recordSignature, err)
+	}
+	entry.Description = *recordDescription
+	entry.Category = *recordCategory
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode entry: %v", err)
+	}
+	path := filepath.Join("testdata", "corpus", *recordName+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	t.Logf("recorded %s as %s", *recordSignature, path)
+}
+
+// sanitizeCorpusEntry turns a fetched transaction into a recorded corpus entry for wallet
+// Signers, the wallet and the token accounts they own are replaced by stable pseudonyms everywhere they appear,
+// and the signatures by pseudonymous ones; programs, mints and protocol accounts are kept so the parser sees
+// the same layout. The transaction is modified in place
+func sanitizeCorpusEntry(tx *solana.TransactionDetails, wallet solana.Address) (*corpusEntry, error) {
+	xsolATA, err := tokens.DeriveAssociatedTokenAddress(wallet, tokens.XSOLMint)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &tx.Transaction.Message
+	users := map[string]bool{wallet.String(): true}
+	for i := 0; i < message.Header.NumRequiredSignatures && i < len(message.AccountKeys); i++ {
+		users[message.AccountKeys[i]] = true
+	}
+
+	pseudonyms := map[string]string{xsolATA.String(): corpusPseudonym(xsolATA.String(), 32)}
+	for user := range users {
+		pseudonyms[user] = corpusPseudonym(user, 32)
+	}
+	if tx.Meta != nil {
+		for _, balances := range [][]solana.TokenBalance{tx.Meta.PreTokenBalances, tx.Meta.PostTokenBalances} {
+			for _, balance := range balances {
+				if balance.Owner != nil && users[*balance.Owner] && int(balance.AccountIndex) < len(message.AccountKeys) {
+					account := message.AccountKeys[balance.AccountIndex]
+					pseudonyms[account] = corpusPseudonym(account, 32)
+				}
+			}
+		}
+	}
+
+	replace := func(address string) string {
+		if pseudonym, ok := pseudonyms[address]; ok {
+			return pseudonym
+		}
+		return address
+	}
+	for i, key := range message.AccountKeys {
+		message.AccountKeys[i] = replace(key)
+	}
+	for i, signature := range tx.Transaction.Signatures {
+		tx.Transaction.Signatures[i] = corpusPseudonym(signature, 64)
+	}
+	if tx.Meta != nil {
+		for _, balances := range [][]solana.TokenBalance{tx.Meta.PreTokenBalances, tx.Meta.PostTokenBalances} {
+			for i := range balances {
+				if owner := balances[i].Owner; owner != nil {
+					sanitized := replace(*owner)
+					balances[i].Owner = &sanitized
+				}
+			}
+		}
+		if loaded := tx.Meta.LoadedAddresses; loaded != nil {
+			for _, addresses := range [][]string{loaded.Writable, loaded.Readonly} {
+				for i, address := range addresses {
+					addresses[i] = replace(address)
+				}
+			}
+		}
+		for i, message := range tx.Meta.LogMessages {
+			for address, pseudonym := range pseudonyms {
+				message = strings.ReplaceAll(message, address, pseudonym)
+			}
+			tx.Meta.LogMessages[i] = message
+		}
+	}
+
+	return &corpusEntry{
+		Source:      corpusSourceRecorded,
+		Wallet:      solana.Address(replace(wallet.String())),
+		XSOLATA:     solana.Address(replace(xsolATA.String())),
+		Transaction: tx,
+	}, nil
+}
+
+// corpusPseudonym derives a stable base58 stand-in of size bytes for a real address or signature
+func corpusPseudonym(value string, size int) string {
+	var raw []byte
+	for counter := byte(0); len(raw) < size; counter++ {
+		sum := sha256.Sum256(append([]byte(corpusPseudonymSalt+value), counter))
+		raw = append(raw, sum[:]...)
+	}
+	return base58.Encode(raw[:size])
+}
+
+func TestSanitizeCorpusEntry(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "corpus", "buy_hyusd_01.json"))
+	if err != nil {
+		t.Fatalf("failed to read corpus entry: %v", err)
+	}
+	var original corpusEntry
+	if err := json.Unmarshal(data, &original); err != nil {
+		t.Fatalf("failed to decode corpus entry: %v", err)
+	}
+	before := parseCorpusEntry(t, &original)
+
+	// Decode again so the original stays untouched by the in-place sanitization
+	var fetched corpusEntry
+	if err := json.Unmarshal(data, &fetched); err != nil {
+		t.Fatalf("failed to decode corpus entry: %v", err)
+	}
+	// Recording derives the xSOL account from the wallet; point the synthetic entry's key at it like a real one
+	derived, err := tokens.DeriveAssociatedTokenAddress(original.Wallet, tokens.XSOLMint)
+	if err != nil {
+		t.Fatalf("failed to derive xSOL account: %v", err)
+	}
+	for i, key := range fetched.Transaction.Transaction.Message.AccountKeys {
+		if key == original.XSOLATA.String() {
+			fetched.Transaction.Transaction.Message.AccountKeys[i] = derived.String()
+		}
+	}
+
+	entry, err := sanitizeCorpusEntry(fetched.Transaction, original.Wallet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Source != corpusSourceRecorded || entry.Wallet == original.Wallet || entry.XSOLATA == derived {
+		t.Fatalf("Expected a recorded entry with pseudonymous wallet and xSOL account, got %s %s %s", entry.Source, entry.Wallet, entry.XSOLATA)
+	}
+
+	sanitized, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("failed to encode entry: %v", err)
+	}
+	for _, real := range []string{original.Wallet.String(), derived.String(), original.Transaction.Transaction.Signatures[0]} {
+		if strings.Contains(string(sanitized), real) {
+			t.Errorf("Expected %s to be replaced everywhere", real)
+		}
+	}
+	for _, kept := range []solana.Address{ExchangeProgram, tokens.XSOLMint, tokens.HyUSDMint} {
+		if !strings.Contains(string(sanitized), kept.String()) {
+			t.Errorf("Expected %s to be kept", kept)
+		}
+	}
+
+	// The parser must report the same trade for the sanitized transaction
+	after := parseCorpusEntry(t, entry)
+	if before.Trade == nil || after.Trade == nil {
+		t.Fatalf("Expected a trade before and after sanitizing, got %+v and %+v", before.Trade, after.Trade)
+	}
+	if after.Trade.Side != before.Trade.Side || after.Trade.XSOLAmount != before.Trade.XSOLAmount ||
+		after.Trade.CounterAsset != before.Trade.CounterAsset || after.Trade.CounterAmount != before.Trade.CounterAmount {
+		t.Errorf("Expected the sanitized trade to match, got %+v want %+v", after.Trade, before.Trade)
+	}
+	if after.Trade.Signature != entry.Transaction.Transaction.Signatures[0] {
+		t.Errorf("Expected the trade to carry the pseudonymous signature, got %s", after.Trade.Signature)
+	}
+}
@@ -0,0 +1,171 @@
+package hylo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/corpus/golden from the current parser output")
+
+// corpusMinEntries keeps the corpus from silently shrinking below the shapes it is meant to cover
+const corpusMinEntries = 50
+
+// corpusCategories must each have at least one corpus entry
+var corpusCategories = []string{"buy", "sell", "first_mint", "jupiter", "direct_cpi", "stake", "unstake", "transfer", "failed", "receive"}
+
+// Corpus entry sources
+const (
+	corpusSourceRecorded  = "recorded"  // Fetched from mainnet with getTransaction and sanitized
+	corpusSourceSynthetic = "synthetic" // Rebuilt from the layout of mainnet transactions; amounts and addresses are not real
+)
+
+// corpusEntry is one transaction of testdata/corpus, as returned by getTransaction, with the wallet it was recorded for
+type corpusEntry struct {
+	Description string                     `json:"description"`
+	Category    string                     `json:"category"`
+	Source      string                     `json:"source"`
+	KnownGap    *corpusKnownGap            `json:"knownGap,omitempty"`
+	Wallet      solana.Address             `json:"wallet"`
+	XSOLATA     solana.Address             `json:"xsolAta"`
+	Transaction *solana.TransactionDetails `json:"transaction"`
+}
+
+// corpusKnownGap is what the parser should report for an entry it gets wrong today
+// Such entries are expected failures without a golden file: the test passes while the parser still gets the side or
+// counter asset wrong, and fails once it gets both right so the gap is closed and a golden file recorded
+type corpusKnownGap struct {
+	Reason       string `json:"reason"`
+	Side         string `json:"side"`
+	CounterAsset string `json:"counterAsset,omitempty"`
+}
+
+// closedBy reports whether the parsed result matches what the gap says the parser should report
+func (gap *corpusKnownGap) closedBy(result *corpusResult) bool {
+	trade := result.Trade
+	if trade == nil {
+		trade = result.Failed
+	}
+	return trade != nil && trade.Side == gap.Side && (gap.CounterAsset == "" || trade.CounterAsset == gap.CounterAsset)
+}
+
+// corpusResult is everything the trade and event pipelines derive from a corpus transaction
+type corpusResult struct {
+	Trade         *XSOLTrade     `json:"trade"`         // ParseTransaction, as served by /trades
+	Transfer      *TokenTransfer `json:"transfer"`      // xSOL transfer found by ParseTransaction
+	Error         string         `json:"error"`         // ParseTransaction result error
+	TokenTransfer *TokenTransfer `json:"tokenTransfer"` // ParseTokenTransfer, as served by /events
+	Failed        *XSOLTrade     `json:"failed"`        // ParseFailedTransaction, as served with include_failed
+}
+
+// parseCorpusEntry runs a corpus transaction through every parser the trade service uses
+func parseCorpusEntry(t *testing.T, entry *corpusEntry) *corpusResult {
+	t.Helper()
+
+	result, err := ParseTransactionWithContext(context.Background(), entry.Transaction, entry.XSOLATA, quietLogger())
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	return &corpusResult{
+		Trade:         result.Trade,
+		Transfer:      result.Transfer,
+		Error:         result.Error,
+		TokenTransfer: ParseTokenTransfer(entry.Transaction, entry.Wallet),
+		Failed:        ParseFailedTransaction(entry.Transaction),
+	}
+}
+
+// TestParserCorpus parses every corpus transaction and compares the result with its golden file
+// Run with -update after an intended parser change and review the golden diff
+func TestParserCorpus(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "corpus", "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list corpus: %v", err)
+	}
+	if len(paths) < corpusMinEntries {
+		t.Fatalf("Expected at least %d corpus entries, found %d", corpusMinEntries, len(paths))
+	}
+
+	covered := make(map[string]bool)
+	recorded := make(map[string]bool)
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", path, err)
+			}
+			var entry corpusEntry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				t.Fatalf("failed to decode %s: %v", path, err)
+			}
+			if entry.Transaction == nil || entry.Wallet == "" || entry.XSOLATA == "" {
+				t.Fatalf("%s needs transaction, wallet and xsolAta", path)
+			}
+			if entry.Source != corpusSourceRecorded && entry.Source != corpusSourceSynthetic {
+				t.Fatalf("%s needs a source of %s or %s", path, corpusSourceRecorded, corpusSourceSynthetic)
+			}
+			covered[entry.Category] = true
+			if entry.Source == corpusSourceRecorded {
+				recorded[entry.Category] = true
+			}
+
+			parsed := parseCorpusEntry(t, &entry)
+			golden := filepath.Join("testdata", "corpus", "golden", name+".json")
+
+			// Known-bad output is never locked in as golden
+			if gap := entry.KnownGap; gap != nil {
+				if _, err := os.Stat(golden); err == nil {
+					t.Fatalf("%s has a known gap and must not have a golden file", name)
+				}
+				if gap.closedBy(parsed) {
+					t.Fatalf("%s now parses as %s %s; remove its knownGap and run with -update", name, gap.Side, gap.CounterAsset)
+				}
+				t.Skipf("known gap: %s", gap.Reason)
+			}
+
+			actual, err := json.MarshalIndent(parsed, "", "  ")
+			if err != nil {
+				t.Fatalf("failed to encode result: %v", err)
+			}
+			actual = append(actual, '\n')
+
+			if *update {
+				if err := os.WriteFile(golden, actual, 0o644); err != nil {
+					t.Fatalf("failed to write %s: %v", golden, err)
+				}
+				return
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden %s (run with -update to create it): %v", golden, err)
+			}
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("%s (%s) parsed differently from %s\nexpected:\n%s\nactual:\n%s", name, entry.Description, golden, expected, actual)
+			}
+		})
+	}
+
+	// Synthetic entries only pin the parser's output for hand-built layouts, so categories still lacking a recorded
+	// mainnet transaction are reported on every run until TestRecordCorpusEntry fills them
+	var unrecorded []string
+	for _, category := range corpusCategories {
+		if !covered[category] {
+			t.Errorf("No corpus entry covers %s", category)
+		}
+		if !recorded[category] {
+			unrecorded = append(unrecorded, category)
+		}
+	}
+	if len(unrecorded) > 0 {
+		t.Logf("No recorded mainnet transaction covers %s", strings.Join(unrecorded, ", "))
+	}
+}
@@ -0,0 +1,114 @@
+package hylo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Anchor log markers used to recover instruction names and error messages from failed transactions
+const (
+	anchorInstructionLogPrefix = "Program log: Instruction: "
+	anchorErrorMessageMarker   = "Error Message: "
+	mintLeverCoinLogName       = "MintLevercoin"
+	redeemLeverCoinLogName     = "RedeemLevercoin"
+)
+
+// ParseFailedTransaction builds a failed trade entry for a reverted transaction that invoked a Hylo program
+// Returns nil when the transaction succeeded or did not interact with Hylo
+func ParseFailedTransaction(tx *solana.TransactionDetails) *XSOLTrade {
+	if tx == nil || tx.Meta == nil || tx.Meta.Err == nil || !invokesHyloProgram(tx) {
+		return nil
+	}
+
+	signature := ""
+	if len(tx.Transaction.Signatures) > 0 {
+		signature = tx.Transaction.Signatures[0]
+	}
+
+	var blockTime int64
+	if tx.BlockTime != nil {
+		blockTime = *tx.BlockTime
+	}
+
+	trade := NewXSOLTrade(signature, uint64(tx.Slot), blockTime)
+	trade.Side = sideFromLogs(tx.Meta.LogMessages)
+	trade.Status = TradeStatusFailed
+	trade.ErrorReason = FailureReason(tx.Meta.Err, tx.Meta.LogMessages)
+	trade.NetworkFeeSOL = formatAmount(tx.Meta.Fee, tokens.SOLDecimals) // Fees are charged even when the transaction reverts
+	applySource(trade, tx)
+	return trade
+}
+
+// FailureReason returns a human-readable reason for a failed transaction
+// Prefers the Anchor error message from program logs and falls back to the meta.Err structure
+func FailureReason(txErr interface{}, logMessages []string) string {
+	for i := len(logMessages) - 1; i >= 0; i-- {
+		if idx := strings.Index(logMessages[i], anchorErrorMessageMarker); idx != -1 {
+			return strings.TrimSuffix(strings.TrimSpace(logMessages[i][idx+len(anchorErrorMessageMarker):]), ".")
+		}
+	}
+
+	return formatTransactionError(txErr)
+}
+
+// formatTransactionError renders meta.Err values such as {"InstructionError":[0,{"Custom":6001}]}
+func formatTransactionError(txErr interface{}) string {
+	switch value := txErr.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case map[string]interface{}:
+		if details, ok := value["InstructionError"].([]interface{}); ok && len(details) == 2 {
+			return fmt.Sprintf("instruction %v failed: %s", details[0], formatInstructionError(details[1]))
+		}
+	}
+
+	encoded, err := json.Marshal(txErr)
+	if err != nil {
+		return fmt.Sprintf("%v", txErr)
+	}
+	return string(encoded)
+}
+
+func formatInstructionError(detail interface{}) string {
+	switch value := detail.(type) {
+	case string:
+		return value
+	case map[string]interface{}:
+		if code, ok := value["Custom"]; ok {
+			return fmt.Sprintf("custom program error %v", code)
+		}
+	}
+
+	encoded, _ := json.Marshal(detail)
+	return string(encoded)
+}
+
+// invokesHyloProgram reports whether any top-level instruction targets a Hylo program
+func invokesHyloProgram(tx *solana.TransactionDetails) bool {
+	accountKeys := tx.Transaction.Message.AccountKeys
+	for _, instruction := range tx.Transaction.Message.Instructions {
+		if int(instruction.ProgramIdIndex) < len(accountKeys) && IsHyloProgram(solana.Address(accountKeys[instruction.ProgramIdIndex])) {
+			return true
+		}
+	}
+	return false
+}
+
+// sideFromLogs infers BUY/SELL from Anchor instruction logs; empty when unknown
+func sideFromLogs(logMessages []string) string {
+	for _, message := range logMessages {
+		switch strings.TrimPrefix(message, anchorInstructionLogPrefix) {
+		case mintLeverCoinLogName:
+			return TradeSideBuy
+		case redeemLeverCoinLogName:
+			return TradeSideSell
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package hylo
+
+import (
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+func TestFailureReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		txErr    interface{}
+		logs     []string
+		expected string
+	}{
+		{
+			name:     "anchor error message from logs",
+			txErr:    map[string]interface{}{"InstructionError": []interface{}{float64(1), map[string]interface{}{"Custom": float64(6003)}}},
+			logs:     []string{"Program log: AnchorError occurred. Error Code: StaleOracle. Error Number: 6003. Error Message: Oracle price is stale."},
+			expected: "Oracle price is stale",
+		},
+		{
+			name:     "custom instruction error without logs",
+			txErr:    map[string]interface{}{"InstructionError": []interface{}{float64(2), map[string]interface{}{"Custom": float64(1)}}},
+			expected: "instruction 2 failed: custom program error 1",
+		},
+		{
+			name:     "named instruction error",
+			txErr:    map[string]interface{}{"InstructionError": []interface{}{float64(0), "InvalidAccountData"}},
+			expected: "instruction 0 failed: InvalidAccountData",
+		},
+		{
+			name:     "transaction level error",
+			txErr:    "InsufficientFundsForFee",
+			expected: "InsufficientFundsForFee",
+		},
+		{
+			name:     "unknown structure falls back to JSON",
+			txErr:    map[string]interface{}{"InsufficientFundsForRent": map[string]interface{}{"account_index": float64(3)}},
+			expected: `{"InsufficientFundsForRent":{"account_index":3}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FailureReason(tt.txErr, tt.logs); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseFailedTransaction(t *testing.T) {
+	blockTime := int64(1757360080)
+	tx := &solana.TransactionDetails{
+		BlockTime: &blockTime,
+		Slot:      365528389,
+		Meta: &solana.TxMeta{
+			Err:         "InsufficientFundsForFee",
+			LogMessages: []string{"Program log: Instruction: RedeemLevercoin"},
+		},
+		Transaction: solana.Transaction{
+			Message: solana.TxMessage{
+				AccountKeys:  []string{"A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g", ExchangeProgramID},
+				Instructions: []solana.TxInstruction{{ProgramIdIndex: 1}},
+			},
+			Signatures: []string{"failedSig"},
+		},
+	}
+
+	trade := ParseFailedTransaction(tx)
+	if trade == nil {
+		t.Fatal("Expected failed trade for reverted Hylo transaction")
+	}
+	if trade.Side != TradeSideSell || trade.Status != TradeStatusFailed || trade.ErrorReason != "InsufficientFundsForFee" {
+		t.Errorf("Unexpected failed trade: %+v", trade)
+	}
+
+	tx.Transaction.Message.Instructions = []solana.TxInstruction{{ProgramIdIndex: 0}}
+	if ParseFailedTransaction(tx) != nil {
+		t.Error("Expected nil for failed transaction without Hylo instructions")
+	}
+
+	tx.Meta.Err = nil
+	if ParseFailedTransaction(tx) != nil {
+		t.Error("Expected nil for successful transaction")
+	}
+}
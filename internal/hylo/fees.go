@@ -0,0 +1,85 @@
+package hylo
+
+import (
+	"math/big"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// applyFeeBreakdown records the network fee and, for Hylo exchange trades, the protocol fee
+// collected in the counter asset, so the trade shows its total cost rather than just the swap amounts
+func applyFeeBreakdown(trade *XSOLTrade, tx *solana.TransactionDetails, xsolIndex int) {
+	if trade == nil || tx == nil || tx.Meta == nil {
+		return
+	}
+
+	trade.NetworkFeeSOL = formatAmount(tx.Meta.Fee, tokens.SOLDecimals)
+
+	if (trade.Side != TradeSideBuy && trade.Side != TradeSideSell) || !invokesHyloProgram(tx) {
+		return
+	}
+	if fee := protocolFeeAmount(tx, xsolIndex, trade.CounterAsset, trade.CounterAmountRaw); fee > 0 {
+		trade.ProtocolFeeRaw = fee
+		trade.ProtocolFee = formatCounterAmount(fee, trade.CounterAsset)
+	}
+}
+
+// protocolFeeAmount finds the Hylo fee transfer in a trade transaction
+// The exchange credits the fee to a protocol-owned vault in the counter asset: on BUY the reserve
+// receives the net deposit and the fee vault the rest, on SELL the fee is withheld from the redeemed
+// amount. The fee is the largest counter-asset credit to an account not owned by the trading wallet
+// that is within MaxReasonableFeeRateBps of the counter amount; reserve deposits are far larger.
+// Native SOL counter amounts are not tracked in token balances and report no protocol fee.
+func protocolFeeAmount(tx *solana.TransactionDetails, xsolIndex int, counterAsset string, counterAmount uint64) uint64 {
+	if counterAsset == "" || counterAmount == 0 {
+		return 0
+	}
+
+	rules := GetClassificationRules()
+	walletOwner := tokenAccountOwner(
+		findTokenBalance(tx.Meta.PreTokenBalances, uint32(xsolIndex)),
+		findTokenBalance(tx.Meta.PostTokenBalances, uint32(xsolIndex)))
+	maxFee := maxReasonableFee(counterAmount)
+
+	var fee uint64
+	for i := range tx.Meta.PostTokenBalances {
+		post := &tx.Meta.PostTokenBalances[i]
+		if int(post.AccountIndex) == xsolIndex {
+			continue
+		}
+		if symbol, known := rules.SymbolForMint(solana.Address(post.Mint)); !known || symbol != counterAsset {
+			continue
+		}
+		pre := findTokenBalance(tx.Meta.PreTokenBalances, post.AccountIndex)
+		if owner := tokenAccountOwner(pre, post); owner == "" || owner == walletOwner {
+			continue
+		}
+
+		postAmount, err := parseTokenAmount(post.UITokenAmount)
+		if err != nil {
+			continue
+		}
+		var preAmount uint64
+		if pre != nil {
+			if preAmount, err = parseTokenAmount(pre.UITokenAmount); err != nil {
+				continue
+			}
+		}
+
+		if postAmount > preAmount {
+			if credit := postAmount - preAmount; credit <= maxFee && credit > fee {
+				fee = credit
+			}
+		}
+	}
+
+	return fee
+}
+
+// maxReasonableFee returns MaxReasonableFeeRateBps of amount, multiplying before dividing in 128 bits
+// so amounts below BasisPointsDenominator do not round the bound to zero and large ones do not overflow
+func maxReasonableFee(amount uint64) uint64 {
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(int64(MaxReasonableFeeRateBps)))
+	return fee.Div(fee, big.NewInt(BasisPointsDenominator)).Uint64()
+}
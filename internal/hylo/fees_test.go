@@ -0,0 +1,88 @@
+package hylo
+
+import (
+	"math"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// newHyloBuyTx mints xSOL for 100 hyUSD: 99.5 hyUSD is burned via the reserve account and 0.5 hyUSD goes to the fee vault
+func newHyloBuyTx() *solana.TransactionDetails {
+	return &solana.TransactionDetails{
+		Slot: 365528389,
+		Meta: &solana.TxMeta{
+			Fee: 5000,
+			PreTokenBalances: []solana.TokenBalance{
+				tokenBalance(1, tokens.XSOLMint, transferSender, "0"),
+				tokenBalance(2, tokens.HyUSDMint, transferSender, "100000000"),
+				tokenBalance(3, tokens.HyUSDMint, "hyloReserveAuthority", "0"),
+				tokenBalance(4, tokens.HyUSDMint, "hyloFeeAuthority", "1000000"),
+			},
+			PostTokenBalances: []solana.TokenBalance{
+				tokenBalance(1, tokens.XSOLMint, transferSender, "9950000"),
+				tokenBalance(2, tokens.HyUSDMint, transferSender, "0"),
+				tokenBalance(3, tokens.HyUSDMint, "hyloReserveAuthority", "99500000"),
+				tokenBalance(4, tokens.HyUSDMint, "hyloFeeAuthority", "1500000"),
+			},
+		},
+		Transaction: solana.Transaction{
+			Message: solana.TxMessage{
+				AccountKeys:  []string{transferSender, "xsolATA", "hyusdATA", "reserve", "feeVault", ExchangeProgramID},
+				Instructions: []solana.TxInstruction{{ProgramIdIndex: 5}},
+			},
+			Signatures: []string{"buySig"},
+		},
+	}
+}
+
+func TestApplyFeeBreakdown(t *testing.T) {
+	tx := newHyloBuyTx()
+	trade := NewXSOLTrade("buySig", uint64(tx.Slot), 0)
+	trade.SetTradeDetails(TradeSideBuy, 9_950_000, 100_000_000, tokens.HyUSDSymbol)
+
+	applyFeeBreakdown(trade, tx, 1)
+
+	if trade.NetworkFeeSOL != "0.000005" {
+		t.Errorf("Expected network fee 0.000005, got %s", trade.NetworkFeeSOL)
+	}
+	if trade.ProtocolFee != "0.5" || trade.ProtocolFeeRaw != 500_000 {
+		t.Errorf("Expected protocol fee 0.5 hyUSD, got %s (%d)", trade.ProtocolFee, trade.ProtocolFeeRaw)
+	}
+}
+
+func TestApplyFeeBreakdownSmallTrade(t *testing.T) {
+	// 0.009999 hyUSD in, 0.0004 hyUSD of it to the fee vault: below 5% once multiplied before dividing
+	tx := newHyloBuyTx()
+	tx.Meta.PreTokenBalances[1] = tokenBalance(2, tokens.HyUSDMint, transferSender, "9999")
+	tx.Meta.PostTokenBalances[2] = tokenBalance(3, tokens.HyUSDMint, "hyloReserveAuthority", "9599")
+	tx.Meta.PostTokenBalances[3] = tokenBalance(4, tokens.HyUSDMint, "hyloFeeAuthority", "1000400")
+	trade := NewXSOLTrade("buySig", uint64(tx.Slot), 0)
+	trade.SetTradeDetails(TradeSideBuy, 1_000, 9_999, tokens.HyUSDSymbol)
+
+	applyFeeBreakdown(trade, tx, 1)
+
+	if trade.ProtocolFeeRaw != 400 {
+		t.Errorf("Expected a protocol fee of 400 raw hyUSD, got %d", trade.ProtocolFeeRaw)
+	}
+	if fee := maxReasonableFee(math.MaxUint64); fee != math.MaxUint64/20 {
+		t.Errorf("Expected 5%% of the largest amount without overflow, got %d", fee)
+	}
+}
+
+func TestApplyFeeBreakdownWithoutHyloProgram(t *testing.T) {
+	tx := newHyloBuyTx()
+	tx.Transaction.Message.Instructions = []solana.TxInstruction{{ProgramIdIndex: 0}}
+	trade := NewXSOLTrade("buySig", uint64(tx.Slot), 0)
+	trade.SetTradeDetails(TradeSideBuy, 9_950_000, 100_000_000, tokens.HyUSDSymbol)
+
+	applyFeeBreakdown(trade, tx, 1)
+
+	if trade.NetworkFeeSOL != "0.000005" {
+		t.Errorf("Expected network fee 0.000005, got %s", trade.NetworkFeeSOL)
+	}
+	if trade.ProtocolFee != "" {
+		t.Errorf("Expected no protocol fee outside Hylo exchange, got %s", trade.ProtocolFee)
+	}
+}
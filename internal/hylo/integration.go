@@ -3,8 +3,11 @@ package hylo
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"hylo-wallet-tracker-api/internal/logger"
 	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/scheduler"
 	"hylo-wallet-tracker-api/internal/solana"
 )
 
@@ -14,10 +17,15 @@ type PriceService struct {
 	stateReader       *StateReader
 	priceCalculator   *PriceCalculator
 	dexScreenerClient *price.DexScreenerClient
+	lstRates          LSTRateSource   // Optional; TVL falls back to market rates without it
+	pegReader         PegReader       // Optional; hyUSD quotes fall back to market prices without it
+	history           *price.History  // Nil when price history is disabled
+	swr               *price.SWRCache // Nil when stale-while-revalidate is disabled
+	logger            *logger.Logger
 }
 
 // NewPriceService creates a new PriceService with all required dependencies
-func NewPriceService(solanaClient *solana.HTTPClient, config *Config, priceConfig *price.PriceConfig) *PriceService {
+func NewPriceService(solanaClient solana.ChainClient, config *Config, priceConfig *price.PriceConfig) *PriceService {
 	// Create state reader
 	stateReader := NewStateReader(solanaClient, config)
 
@@ -27,11 +35,27 @@ func NewPriceService(solanaClient *solana.HTTPClient, config *Config, priceConfi
 	// Create DexScreener client for SOL/USD prices
 	dexScreenerClient := price.NewDexScreenerClient(priceConfig)
 
-	return &PriceService{
+	service := &PriceService{
 		stateReader:       stateReader,
 		priceCalculator:   priceCalculator,
 		dexScreenerClient: dexScreenerClient,
+		history:           price.NewHistory(priceConfig),
+		logger:            logger.NewFromEnv().WithComponent("hylo-price-service"),
 	}
+	if priceConfig.SWREnabled() {
+		service.swr = price.NewSWRCache(priceConfig, service.GetCombinedPriceResponse)
+	}
+	return service
+}
+
+// SetLSTRates sets the source of LST to SOL exchange rates used to value the LST vaults
+func (ps *PriceService) SetLSTRates(source LSTRateSource) {
+	ps.lstRates = source
+}
+
+// SetStateRecorder sets the recorder every protocol state read for pricing is passed to
+func (ps *PriceService) SetStateRecorder(recorder StateRecorder) {
+	ps.priceCalculator.recorder = recorder
 }
 
 // GetCurrentXSOLPrice fetches the current xSOL price in both SOL and USD terms
@@ -65,12 +89,74 @@ func (ps *PriceService) GetCombinedPriceResponse(ctx context.Context) (*price.Co
 	}
 
 	// Step 2: Calculate combined price response
-	response, err := ps.priceCalculator.CalculateCombinedPriceResponse(ctx, solPrice)
+	return ps.combinedPrices(ctx, solPrice)
+}
+
+// GetServedPriceResponse returns the price response for the /price API endpoint, served from the
+// stale-while-revalidate cache when it is enabled and fetched fresh otherwise
+func (ps *PriceService) GetServedPriceResponse(ctx context.Context) (*price.CombinedPriceResponse, error) {
+	if ps.swr == nil {
+		return ps.GetCombinedPriceResponse(ctx)
+	}
+	return ps.swr.Get(ctx)
+}
+
+// CachedPrices returns the prices held by the stale-while-revalidate cache and when they were fetched
+// False when the cache is disabled or empty
+func (ps *PriceService) CachedPrices() (*price.CombinedPriceResponse, time.Time, bool) {
+	if ps.swr == nil {
+		return nil, time.Time{}, false
+	}
+	return ps.swr.Cached()
+}
+
+// RestoreCachedPrices seeds the stale-while-revalidate cache with prices saved before a restart, served
+// as stale until the first refresh; false when the cache is disabled or the prices are past the hard TTL
+func (ps *PriceService) RestoreCachedPrices(prices *price.CombinedPriceResponse, fetchedAt time.Time) bool {
+	if ps.swr == nil {
+		return false
+	}
+	return ps.swr.Restore(prices, fetchedAt)
+}
+
+// GetProtocolState reads the current protocol state at the live SOL/USD price
+func (ps *PriceService) GetProtocolState(ctx context.Context) (*HyloProtocolState, error) {
+	solPrice, err := ps.dexScreenerClient.FetchSOLPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SOL/USD price: %w", err)
+	}
+
+	protocolState, err := ps.stateReader.ReadProtocolState(ctx, solPrice.Price)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate combined price response: %w", err)
+		return nil, fmt.Errorf("failed to read protocol state: %w", err)
 	}
+	return protocolState, nil
+}
 
-	return response, nil
+// GetPriceHistory returns the sampled price history, or nil when it is disabled
+func (ps *PriceService) GetPriceHistory() *price.History {
+	return ps.history
+}
+
+// RunPriceHistory refreshes prices every interval until ctx is cancelled, so the history keeps
+// growing while no API requests fetch prices
+func (ps *PriceService) RunPriceHistory(ctx context.Context, interval time.Duration) {
+	ctx = scheduler.WithPriority(ctx, scheduler.PriorityBackground)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := ps.GetCombinedPriceResponse(ctx); err != nil {
+			ps.logger.LogHandlerError(ctx, "price_history_refresh", err)
+		}
+	}
 }
 
 // GetProtocolHealthStatus returns comprehensive protocol health information
@@ -157,10 +243,31 @@ func (ps *PriceService) GetPriceCalculationDetails(ctx context.Context) (map[str
 	details["sol_price_pair"] = solPrice.Pair
 	details["sol_price_liquidity"] = solPrice.Liquidity
 	details["sol_price_volume_24h"] = solPrice.Volume24h
+	details["sol_price_band"] = ps.dexScreenerClient.PriceBand()
 
 	return details, nil
 }
 
+// SimulateTrade estimates a hypothetical xSOL mint or redeem against current protocol state
+// Read-only: uses live SOL/USD price, on-chain supplies and exchange fee rates
+func (ps *PriceService) SimulateTrade(ctx context.Context, req *TradeSimulationRequest) (*TradeSimulationResult, error) {
+	// Step 1: Fetch current SOL/USD price
+	solPrice, err := ps.dexScreenerClient.FetchSOLPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SOL/USD price: %w", err)
+	}
+
+	// Step 2: Read protocol state
+	protocolState, err := ps.stateReader.ReadProtocolState(ctx, solPrice.Price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol state: %w", err)
+	}
+
+	// Step 3: Simulate the trade with current fee rates
+	fees := ps.stateReader.ReadExchangeFees(ctx)
+	return SimulateTrade(protocolState, fees, req)
+}
+
 // Close performs cleanup of all resources
 func (ps *PriceService) Close() error {
 	// Close DexScreener client
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"sync"
 	"time"
 
 	"hylo-wallet-tracker-api/internal/logger"
@@ -12,19 +13,53 @@ import (
 	"hylo-wallet-tracker-api/internal/tokens"
 )
 
+// Parser parses transactions against one Hylo configuration
+// The configuration and logger are built once and shared read-only, so a Parser is safe for concurrent use
+type Parser struct {
+	config *Config
+	logger *logger.Logger
+}
+
+// NewParser creates a parser using config; nil loads the configuration from the environment
+func NewParser(config *Config) *Parser {
+	if config == nil {
+		config = NewConfig()
+	}
+	return &Parser{
+		config: config,
+		logger: logger.NewFromEnv().WithComponent("hylo-parser"),
+	}
+}
+
+// defaultParser backs the package-level parse functions; built on first use
+var defaultParser = sync.OnceValue(func() *Parser { return NewParser(nil) })
+
+// DefaultParser returns the parser used by the package-level functions, configured from the environment
+func DefaultParser() *Parser {
+	return defaultParser()
+}
+
 // ParseTransaction analyzes a Solana transaction to determine if it contains an xSOL trade
 // It uses balance-change analysis to identify BUY/SELL operations and calculate amounts
 func ParseTransaction(tx *solana.TransactionDetails, walletXSOLATA solana.Address) (*TradeParseResult, error) {
-	return ParseTransactionWithContext(context.Background(), tx, walletXSOLATA, nil)
+	return DefaultParser().ParseTransaction(context.Background(), tx, walletXSOLATA, nil)
 }
 
-// ParseTransactionWithContext analyzes a Solana transaction with logging context
+// ParseTransactionWithContext analyzes a Solana transaction with logging context using the default parser
+// Callers parsing many transactions should hold a Parser built from their own configuration instead
 func ParseTransactionWithContext(ctx context.Context, tx *solana.TransactionDetails, walletXSOLATA solana.Address, log *logger.Logger) (*TradeParseResult, error) {
+	return DefaultParser().ParseTransaction(ctx, tx, walletXSOLATA, log)
+}
+
+// ParseTransaction analyzes a Solana transaction with logging context
+// A nil log uses the parser's logger
+func (p *Parser) ParseTransaction(ctx context.Context, tx *solana.TransactionDetails, walletXSOLATA solana.Address, log *logger.Logger) (result *TradeParseResult, err error) {
 	startTime := time.Now()
+	defer func() { recordParseMetrics(time.Since(startTime), result, err) }()
 
-	// Use default logger if none provided
+	// Use the parser's logger if none provided
 	if log == nil {
-		log = logger.NewFromEnv().WithComponent("hylo-parser")
+		log = p.logger
 	}
 
 	// Get transaction signature for logging
@@ -78,18 +113,35 @@ func ParseTransactionWithContext(ctx context.Context, tx *solana.TransactionDeta
 
 	// PRIORITY: Check for Hylo program instructions first
 	// This handles cases where users trade via Hylo Exchange, including first-time trades
-	hyloInstructionType := detectHyloInstructions(tx)
+	hyloInstructionType := detectHyloInstructions(tx, p.config)
 	if hyloInstructionType != "" {
 		log.DebugContext(ctx, "Detected Hylo instruction, parsing as trade",
 			slog.String("signature", signature),
 			slog.String("instruction_type", hyloInstructionType))
-		return parseHyloTrade(ctx, tx, walletXSOLATA, xsolAccountIndex, hyloInstructionType, signature, log)
+		return parseHyloTrade(ctx, p.config, tx, walletXSOLATA, xsolAccountIndex, hyloInstructionType, signature, log)
 	}
 
 	// FALLBACK: Look for xSOL token balance changes for non-Hylo transactions
 	preTokenBalance := findTokenBalance(tx.Meta.PreTokenBalances, uint32(xsolAccountIndex))
 	postTokenBalance := findTokenBalance(tx.Meta.PostTokenBalances, uint32(xsolAccountIndex))
 
+	// Plain transfers between owners are reported as a transfer alongside the balance change, which
+	// stays in the trade list as before (RECEIVE for a first funding, otherwise BUY or SELL)
+	if owner := tokenAccountOwner(preTokenBalance, postTokenBalance); owner != "" {
+		if transfer := ParseTokenTransfer(tx, solana.Address(owner)); transfer != nil {
+			log.DebugContext(ctx, "Detected xSOL transfer",
+				slog.String("signature", signature),
+				slog.String("type", transfer.Type),
+				slog.String("from", transfer.From),
+				slog.String("to", transfer.To))
+			defer func() {
+				if result != nil {
+					result.Transfer = transfer
+				}
+			}()
+		}
+	}
+
 	// Handle different balance scenarios for non-Hylo transactions:
 	// 1. Both pre and post balance exist -> external trade or transfer
 	// 2. Only post balance exists -> initial funding/transfer (RECEIVE)
@@ -176,8 +228,12 @@ func ParseTransactionWithContext(ctx context.Context, tx *solana.TransactionDeta
 
 	// Set trade details
 	trade.SetTradeDetails(tradeSide, xsolAmount, counterAmount, counterAsset)
+	applyStabilityPoolSteps(trade, tx, xsolAccountIndex, p.config)
+	applyFeeBreakdown(trade, tx, xsolAccountIndex)
+	applyConfidence(ctx, trade, tx, xsolAccountIndex, sourceBalanceInference, log)
+	applySource(trade, tx)
 
-	// Calculate historical price for hyUSD trades
+	// Calculate historical price for stablecoin trades
 	trade.HistoricalPriceUSD = CalculateHistoricalXSOLPrice(trade)
 
 	// Log successful trade parsing with historical price info
@@ -190,8 +246,9 @@ func ParseTransactionWithContext(ctx context.Context, tx *solana.TransactionDeta
 		slog.String("signature", signature),
 		slog.String("side", tradeSide),
 		slog.Uint64("xsol_amount", xsolAmount),
-		slog.Uint64("counter_amount", counterAmount),
-		slog.String("counter_asset", counterAsset),
+		slog.Uint64("counter_amount", trade.CounterAmountRaw),
+		slog.String("counter_asset", trade.CounterAsset),
+		slog.Int("steps", len(trade.Steps)),
 		slog.String("historical_price", priceInfo),
 		slog.Duration("parse_time", time.Since(startTime)))
 
@@ -200,6 +257,26 @@ func ParseTransactionWithContext(ctx context.Context, tx *solana.TransactionDeta
 	}, nil
 }
 
+// tokenAccountOwner returns the owner wallet recorded for a token account, or empty when unknown
+func tokenAccountOwner(balances ...*solana.TokenBalance) string {
+	for _, balance := range balances {
+		if balance != nil && balance.Owner != nil {
+			return *balance.Owner
+		}
+	}
+	return ""
+}
+
+// tokenAccountProgram returns the token program reported for a token account, or "" when the RPC node omitted it
+func tokenAccountProgram(balances ...*solana.TokenBalance) string {
+	for _, balance := range balances {
+		if balance != nil && balance.ProgramId != nil {
+			return *balance.ProgramId
+		}
+	}
+	return ""
+}
+
 // findAccountIndex finds the index of a target account in the account keys array
 func findAccountIndex(accountKeys []string, targetAccount string) int {
 	for i, account := range accountKeys {
@@ -214,16 +291,43 @@ func findAccountIndex(accountKeys []string, targetAccount string) int {
 // Returns the counter amount and asset type (SOL, hyUSD, etc.)
 func analyzeCounterAssetChanges(tx *solana.TransactionDetails, xsolIndex int, tradeSide string) (uint64, string) {
 	// Look for the largest balance change in the opposite direction of xSOL
+	// 1. Check native SOL balance changes, limited to accounts the wallet owns when its owner is known
+	maxChange, counterAsset := nativeSOLChange(tx, xsolIndex, tradeSide)
+
+	// 2. Check token balance changes (this is where hyUSD/sHYUSD trades are detected)
+	maxTokenChange, tokenAsset := analyzeTokenBalanceChanges(tx, xsolIndex, tradeSide)
+
+	// Use the larger balance change (either native SOL or token)
+	if maxTokenChange > maxChange {
+		maxChange = maxTokenChange
+		counterAsset = tokenAsset
+	}
+
+	// Default to SOL if we couldn't determine the asset type
+	if counterAsset == "" {
+		counterAsset = "SOL"
+	}
+
+	return maxChange, counterAsset
+}
+
+// nativeSOLChange returns the largest native SOL change in the trade's counter direction
+func nativeSOLChange(tx *solana.TransactionDetails, xsolIndex int, tradeSide string) (uint64, string) {
 	var maxChange uint64
 	var counterAsset string
 
-	// 1. Check native SOL balance changes
+	walletAccounts := walletSOLAccounts(tx, xsolIndex)
 	for i, preBalance := range tx.Meta.PreBalances {
 		// Skip the xSOL account and accounts with no change
 		if i == xsolIndex || i >= len(tx.Meta.PostBalances) {
 			continue
 		}
 
+		// Program, pool and fee accounts also move lamports but are never the wallet's counter asset
+		if walletAccounts != nil && !walletAccounts[i] {
+			continue
+		}
+
 		// Bounds check: ensure the account index exists in AccountKeys
 		if i >= len(tx.Transaction.Message.AccountKeys) {
 			continue
@@ -259,40 +363,39 @@ func analyzeCounterAssetChanges(tx *solana.TransactionDetails, xsolIndex int, tr
 		}
 	}
 
-	// 2. Check token balance changes (this is where hyUSD/sHYUSD trades are detected)
-	maxTokenChange, tokenAsset := analyzeTokenBalanceChanges(tx, xsolIndex, tradeSide)
-
-	// Use the larger balance change (either native SOL or token)
-	if maxTokenChange > maxChange {
-		maxChange = maxTokenChange
-		counterAsset = tokenAsset
-	}
+	return maxChange, counterAsset
+}
 
-	// Default to SOL if we couldn't determine the asset type
-	if counterAsset == "" {
-		counterAsset = "SOL"
+// walletSOLAccounts returns the account indexes holding the trading wallet's native SOL: the wallet
+// address itself and its wrapped SOL token accounts. The wallet is the owner of the xSOL token account;
+// nil is returned when that owner is not recorded, in which case every account is considered
+func walletSOLAccounts(tx *solana.TransactionDetails, xsolIndex int) map[int]bool {
+	owner := tokenAccountOwner(
+		findTokenBalance(tx.Meta.PreTokenBalances, uint32(xsolIndex)),
+		findTokenBalance(tx.Meta.PostTokenBalances, uint32(xsolIndex)))
+	if owner == "" {
+		return nil
+	}
+
+	accounts := make(map[int]bool)
+	if index := findAccountIndex(tx.Transaction.Message.AccountKeys, owner); index != -1 {
+		accounts[index] = true
+	}
+	for _, balances := range [][]solana.TokenBalance{tx.Meta.PreTokenBalances, tx.Meta.PostTokenBalances} {
+		for i := range balances {
+			balance := &balances[i]
+			if balance.Mint == string(tokens.WSOLMint) && tokenAccountOwner(balance) == owner {
+				accounts[int(balance.AccountIndex)] = true
+			}
+		}
 	}
-
-	return maxChange, counterAsset
+	return accounts
 }
 
 // getAssetPriority returns priority score for counter asset selection
 // Higher priority assets are preferred over lower priority ones in multi-hop transactions
 func getAssetPriority(asset string) int {
-	switch asset {
-	case "hyUSD":
-		return 100 // Hylo native stablecoin - highest priority
-	case "USDC":
-		return 90 // USD stablecoin - very high priority
-	case "sHYUSD":
-		return 80 // Staked hyUSD - high priority
-	case "SOL":
-		return 50 // Native SOL - medium priority
-	case "jitoSOL":
-		return 30 // Liquid staking token - lower priority (often intermediate)
-	default:
-		return 10 // Unknown tokens - lowest priority
-	}
+	return GetClassificationRules().Priority(asset)
 }
 
 // shouldReplaceCounterAsset determines if a new candidate should replace the current counter asset
@@ -367,7 +470,12 @@ func analyzeTokenBalanceChanges(tx *solana.TransactionDetails, xsolIndex int, tr
 			// Detect token type from mint address
 			var candidateAsset string
 			if preTokenBalance.Mint != "" {
-				candidateAsset = detectTokenAssetType(preTokenBalance.Mint)
+				candidateAsset = detectTokenAssetType(preTokenBalance.Mint, tokenAccountProgram(&preTokenBalance, postTokenBalance))
+			}
+
+			// Unknown tokens can be excluded from counter-asset selection via classification rules
+			if rules := GetClassificationRules(); rules.UnknownToken.Ignore && rules.IsUnknownToken(candidateAsset) {
+				continue
 			}
 
 			// Use priority-based selection instead of just largest amount
@@ -403,39 +511,27 @@ func detectAssetType(accountAddress string) string {
 	return "SOL"
 }
 
-// detectTokenAssetType identifies token asset type from mint address
-func detectTokenAssetType(mintAddress string) string {
-	mint := solana.Address(mintAddress)
-
-	// Check against known token mints (Hylo protocol + supported external tokens)
-	switch mint {
-	case tokens.HyUSDMint:
-		return "hyUSD"
-	case tokens.SHyUSDMint:
-		return "sHYUSD"
-	case tokens.XSOLMint:
-		return "xSOL"
-	case tokens.USDCMint:
-		return "USDC"
-	case tokens.JitoSOLMint:
-		return "jitoSOL"
-	default:
-		// Unknown token - could be wrapped SOL or other SPL tokens
-		// For now, we'll treat unknown tokens as generic tokens
-		return "TOKEN"
-	}
+// detectTokenAssetType identifies token asset type from mint address and the token program holding it
+// Mints are resolved through the active classification rules; unrecognized mints map to the unknown token
+// symbol, or its Token-2022 variant when the balance was reported under the Token-2022 program
+func detectTokenAssetType(mintAddress, programID string) string {
+	symbol, _ := GetClassificationRules().SymbolForToken(solana.Address(mintAddress), solana.Address(programID))
+	return symbol
+}
+
+// IsXSOLTrade checks if a transaction contains xSOL-related instructions using the default parser
+func IsXSOLTrade(tx *solana.TransactionDetails) bool {
+	return DefaultParser().IsXSOLTrade(tx)
 }
 
 // IsXSOLTrade checks if a transaction contains xSOL-related instructions
 // This is a secondary validation method that can be used alongside balance analysis
-func IsXSOLTrade(tx *solana.TransactionDetails) bool {
+func (p *Parser) IsXSOLTrade(tx *solana.TransactionDetails) bool {
 	// Check if any instructions are from Hylo programs
-	hyloConfig := NewConfig()
-
 	for _, instruction := range tx.Transaction.Message.Instructions {
 		if int(instruction.ProgramIdIndex) < len(tx.Transaction.Message.AccountKeys) {
 			programId := solana.Address(tx.Transaction.Message.AccountKeys[instruction.ProgramIdIndex])
-			if hyloConfig.IsHyloProgramID(programId) {
+			if p.config.IsHyloProgramID(programId) {
 				return true
 			}
 		}
@@ -511,9 +607,7 @@ func analyzeCounterAssetChangesWithLogging(ctx context.Context, tx *solana.Trans
 }
 
 // detectHyloInstructions checks if the transaction contains Hylo program instructions
-func detectHyloInstructions(tx *solana.TransactionDetails) string {
-	hyloConfig := NewConfig()
-
+func detectHyloInstructions(tx *solana.TransactionDetails, hyloConfig *Config) string {
 	// Check each instruction for Hylo program involvement
 	for _, instruction := range tx.Transaction.Message.Instructions {
 		if int(instruction.ProgramIdIndex) >= len(tx.Transaction.Message.AccountKeys) {
@@ -579,7 +673,7 @@ func inferHyloInstructionType(tx *solana.TransactionDetails) string {
 }
 
 // parseHyloTrade parses a transaction that contains Hylo program instructions
-func parseHyloTrade(ctx context.Context, tx *solana.TransactionDetails, walletXSOLATA solana.Address, xsolAccountIndex int, instructionType, signature string, log *logger.Logger) (*TradeParseResult, error) {
+func parseHyloTrade(ctx context.Context, config *Config, tx *solana.TransactionDetails, walletXSOLATA solana.Address, xsolAccountIndex int, instructionType, signature string, log *logger.Logger) (*TradeParseResult, error) {
 	startTime := time.Now()
 
 	log.DebugContext(ctx, "Parsing Hylo trade transaction",
@@ -674,8 +768,12 @@ func parseHyloTrade(ctx context.Context, tx *solana.TransactionDetails, walletXS
 
 	// Set trade details
 	trade.SetTradeDetails(tradeSide, xsolAmount, counterAmount, counterAsset)
+	applyStabilityPoolSteps(trade, tx, xsolAccountIndex, config)
+	applyFeeBreakdown(trade, tx, xsolAccountIndex)
+	applyConfidence(ctx, trade, tx, xsolAccountIndex, sourceHyloInstruction, log)
+	applySource(trade, tx)
 
-	// Calculate historical price for hyUSD trades
+	// Calculate historical price for stablecoin trades
 	trade.HistoricalPriceUSD = CalculateHistoricalXSOLPrice(trade)
 
 	// Log successful trade parsing with historical price info
@@ -688,8 +786,9 @@ func parseHyloTrade(ctx context.Context, tx *solana.TransactionDetails, walletXS
 		slog.String("signature", signature),
 		slog.String("side", tradeSide),
 		slog.Uint64("xsol_amount", xsolAmount),
-		slog.Uint64("counter_amount", counterAmount),
-		slog.String("counter_asset", counterAsset),
+		slog.Uint64("counter_amount", trade.CounterAmountRaw),
+		slog.String("counter_asset", trade.CounterAsset),
+		slog.Int("steps", len(trade.Steps)),
 		slog.String("historical_price", priceInfo),
 		slog.Duration("parse_time", time.Since(startTime)))
 
@@ -735,6 +834,9 @@ func parseInitialFundingTransaction(ctx context.Context, tx *solana.TransactionD
 	// Set trade details for RECEIVE operation
 	// For initial funding, there's no counter asset exchange, so we leave it empty
 	trade.SetTradeDetails(TradeSideReceive, receivedAmount, 0, "")
+	trade.NetworkFeeSOL = formatAmount(tx.Meta.Fee, tokens.SOLDecimals)
+	applyConfidence(ctx, trade, tx, int(postTokenBalance.AccountIndex), sourceFundingInference, log)
+	applySource(trade, tx)
 
 	log.InfoContext(ctx, "Successfully parsed initial xSOL funding",
 		slog.String("signature", signature),
@@ -0,0 +1,63 @@
+package hylo
+
+import (
+	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// Parse error types exported as the "type" label of hylo_parser_errors_total
+const (
+	ParseErrorInvalidInput       = "invalid_input"
+	ParseErrorFailedTransaction  = "failed_transaction"
+	ParseErrorAmount             = "amount_parse"
+	ParseErrorMissingBalance     = "missing_balance"
+	ParseErrorUnknownInstruction = "unknown_instruction"
+	ParseErrorOther              = "other"
+)
+
+// recordParseMetrics exports timing, classification and error metrics for a ParseTransaction call
+// and feeds the outcome to the registered parse anomaly monitor
+func recordParseMetrics(duration time.Duration, result *TradeParseResult, err error) {
+	switch {
+	case err != nil:
+		metrics.RecordParseError(ParseErrorInvalidInput)
+		metrics.ObserveParse(metrics.ParseOutcomeError, duration)
+	case result != nil && result.Error != "":
+		metrics.RecordParseError(classifyParseError(result.Error))
+		metrics.ObserveParse(metrics.ParseOutcomeError, duration)
+	case result != nil && result.Trade != nil:
+		metrics.RecordTrade(result.Trade.Side, result.Trade.CounterAsset)
+		metrics.RecordTradeSource(result.Trade.Source)
+		if result.Trade.NeedsReview {
+			metrics.RecordLowConfidenceTrade()
+		}
+		metrics.ObserveParse(metrics.ParseOutcomeTrade, duration)
+	default:
+		metrics.ObserveParse(metrics.ParseOutcomeNoTrade, duration)
+	}
+
+	// Transactions that failed on-chain are not parser failures
+	if monitor := parseMonitor.Load(); monitor != nil {
+		failed := err != nil || (result != nil && result.Error != "" && result.Error != "transaction failed")
+		trade := err == nil && result != nil && result.Error == "" && result.Trade != nil
+		monitor.Record(failed, trade, trade && result.Trade.NeedsReview)
+	}
+}
+
+// classifyParseError maps TradeParseResult.Error messages to a bounded set of label values
+func classifyParseError(message string) string {
+	switch {
+	case message == "transaction failed":
+		return ParseErrorFailedTransaction
+	case strings.Contains(message, "token amount"):
+		return ParseErrorAmount
+	case strings.Contains(message, "balance not found"):
+		return ParseErrorMissingBalance
+	case strings.HasPrefix(message, "unknown Hylo instruction"):
+		return ParseErrorUnknownInstruction
+	default:
+		return ParseErrorOther
+	}
+}
@@ -0,0 +1,76 @@
+package hylo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+func TestClassifyParseError(t *testing.T) {
+	tests := map[string]string{
+		"transaction failed":                            ParseErrorFailedTransaction,
+		"failed to parse pre-token amount: bad":         ParseErrorAmount,
+		"failed to parse received token amount: bad":    ParseErrorAmount,
+		"post token balance not found for Hylo trade":   ParseErrorMissingBalance,
+		"unknown Hylo instruction type: something_else": ParseErrorUnknownInstruction,
+		"unexpected": ParseErrorOther,
+	}
+
+	for message, want := range tests {
+		if got := classifyParseError(message); got != want {
+			t.Errorf("classifyParseError(%q) = %q, want %q", message, got, want)
+		}
+	}
+}
+
+// parseObservations returns how many parses were observed with outcome
+func parseObservations(t *testing.T, outcome string) uint64 {
+	t.Helper()
+
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "hylo_parser_parse_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "outcome" && label.GetValue() == outcome {
+					return metric.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestRecordParseMetrics(t *testing.T) {
+	tradesBefore := parseObservations(t, metrics.ParseOutcomeTrade)
+	errorsBefore := parseObservations(t, metrics.ParseOutcomeError)
+
+	trade := NewXSOLTrade("sig", 1, 0)
+	trade.SetTradeDetails(TradeSideBuy, 1, 1, "hyUSD")
+	recordParseMetrics(time.Millisecond, &TradeParseResult{Trade: trade}, nil)
+	recordParseMetrics(time.Millisecond, &TradeParseResult{Error: "transaction failed"}, nil)
+	recordParseMetrics(time.Millisecond, nil, fmt.Errorf("transaction details cannot be nil"))
+
+	// Other tests in the package parse transactions too, so only the observations made here are compared
+	if trades := parseObservations(t, metrics.ParseOutcomeTrade) - tradesBefore; trades != 1 {
+		t.Errorf("Expected 1 trade outcome observation, got %d", trades)
+	}
+	if errors := parseObservations(t, metrics.ParseOutcomeError) - errorsBefore; errors != 2 {
+		t.Errorf("Expected 2 error outcome observations, got %d", errors)
+	}
+	if count := testutil.CollectAndCount(metrics.Registry, "hylo_parser_errors_total"); count < 2 {
+		t.Errorf("Expected at least 2 parse error series, got %d", count)
+	}
+	if count := testutil.CollectAndCount(metrics.Registry, "hylo_parser_counter_assets_total"); count < 1 {
+		t.Errorf("Expected counter asset series, got %d", count)
+	}
+}
@@ -1,6 +1,7 @@
 package hylo
 
 import (
+	"context"
 	"testing"
 
 	"hylo-wallet-tracker-api/internal/solana"
@@ -942,6 +943,7 @@ func TestDetectTokenAssetType(t *testing.T) {
 	tests := []struct {
 		name         string
 		mintAddress  string
+		programID    string
 		expectedType string
 	}{
 		{
@@ -979,13 +981,42 @@ func TestDetectTokenAssetType(t *testing.T) {
 			mintAddress:  "",
 			expectedType: "TOKEN",
 		},
+		{
+			name:         "PYUSD mint held by Token-2022",
+			mintAddress:  "2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo",
+			programID:    "TokenzQdBNbLqP5VQbDdVeeLZ9jt7G9cXN2pYMPXhN5A",
+			expectedType: "PYUSD",
+		},
+		{
+			name:         "PYUSD mint without reported program",
+			mintAddress:  "2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo",
+			expectedType: "PYUSD",
+		},
+		{
+			name:         "PYUSD mint reported under the SPL Token program",
+			mintAddress:  "2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo",
+			programID:    "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+			expectedType: "TOKEN",
+		},
+		{
+			name:         "unknown mint held by Token-2022",
+			mintAddress:  "UnknownMintAddress1234567890123456789012345",
+			programID:    "TokenzQdBNbLqP5VQbDdVeeLZ9jt7G9cXN2pYMPXhN5A",
+			expectedType: "TOKEN2022",
+		},
+		{
+			name:         "USDC mint reported with its program",
+			mintAddress:  "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+			programID:    "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA",
+			expectedType: "USDC",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := detectTokenAssetType(tt.mintAddress)
+			result := detectTokenAssetType(tt.mintAddress, tt.programID)
 			if result != tt.expectedType {
-				t.Errorf("detectTokenAssetType(%q) = %q, want %q", tt.mintAddress, result, tt.expectedType)
+				t.Errorf("detectTokenAssetType(%q, %q) = %q, want %q", tt.mintAddress, tt.programID, result, tt.expectedType)
 			}
 		})
 	}
@@ -1223,3 +1254,115 @@ func indexContains(s, substr string) int {
 	}
 	return -1
 }
+
+func TestAnalyzeCounterAssetChangesOwnerScoped(t *testing.T) {
+	owner := tokens.TestReferenceWallet
+	const (
+		poolAccount = "9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin"
+		wsolAccount = "7VHUFJHWu2CuExkJcJrzhQPJ2oygupTWkL2A2For4BmE"
+	)
+
+	xsolBalance := func(amount string) solana.TokenBalance {
+		return solana.TokenBalance{
+			AccountIndex:  2,
+			Mint:          string(tokens.XSOLMint),
+			Owner:         &owner,
+			UITokenAmount: &solana.UITokenAmount{Amount: amount, Decimals: 6},
+		}
+	}
+	wsolBalance := func(amount string) solana.TokenBalance {
+		return solana.TokenBalance{
+			AccountIndex:  3,
+			Mint:          string(tokens.WSOLMint),
+			Owner:         &owner,
+			UITokenAmount: &solana.UITokenAmount{Amount: amount, Decimals: 9},
+		}
+	}
+
+	newTx := func(pre, post []uint64, preTokens, postTokens []solana.TokenBalance) *solana.TransactionDetails {
+		return &solana.TransactionDetails{
+			Meta: &solana.TxMeta{
+				PreBalances:       pre,
+				PostBalances:      post,
+				PreTokenBalances:  preTokens,
+				PostTokenBalances: postTokens,
+			},
+			Transaction: solana.Transaction{
+				Message: solana.TxMessage{
+					AccountKeys: []string{owner, poolAccount, tokens.TestXSOLATA1, wsolAccount},
+				},
+			},
+		}
+	}
+
+	t.Run("ignores larger lamport change on a program-owned account", func(t *testing.T) {
+		// Wallet pays 1 SOL; the pool account moves 50 SOL in the same direction
+		tx := newTx(
+			[]uint64{3_000_000_000, 80_000_000_000, 2_039_280, 0},
+			[]uint64{2_000_000_000, 30_000_000_000, 2_039_280, 0},
+			[]solana.TokenBalance{xsolBalance("0")},
+			[]solana.TokenBalance{xsolBalance("5000000")},
+		)
+
+		amount, asset := analyzeCounterAssetChanges(tx, 2, TradeSideBuy)
+		if asset != "SOL" || amount != 1_000_000_000 {
+			t.Errorf("Expected the wallet's 1 SOL spend, got %d %s", amount, asset)
+		}
+	})
+
+	t.Run("counts the wallet's wrapped SOL account", func(t *testing.T) {
+		// Redeemed SOL lands in the wallet's WSOL account; the pool pays it out
+		tx := newTx(
+			[]uint64{1_000_000_000, 80_000_000_000, 2_039_280, 2_039_280},
+			[]uint64{999_995_000, 78_000_000_000, 2_039_280, 2_002_039_280},
+			[]solana.TokenBalance{xsolBalance("5000000"), wsolBalance("0")},
+			[]solana.TokenBalance{xsolBalance("0"), wsolBalance("2000000000")},
+		)
+
+		amount, asset := analyzeCounterAssetChanges(tx, 2, TradeSideSell)
+		if asset != "SOL" || amount != 2_000_000_000 {
+			t.Errorf("Expected the 2 SOL credit to the WSOL account, got %d %s", amount, asset)
+		}
+	})
+
+	t.Run("falls back to every account when the owner is unknown", func(t *testing.T) {
+		unowned := xsolBalance("0")
+		unowned.Owner = nil
+		tx := newTx(
+			[]uint64{3_000_000_000, 80_000_000_000, 2_039_280, 0},
+			[]uint64{2_000_000_000, 30_000_000_000, 2_039_280, 0},
+			[]solana.TokenBalance{unowned},
+			nil,
+		)
+
+		if amount, _ := analyzeCounterAssetChanges(tx, 2, TradeSideBuy); amount != 50_000_000_000 {
+			t.Errorf("Expected unrestricted scan to pick the largest change, got %d", amount)
+		}
+	})
+}
+
+func TestParserUsesInjectedConfig(t *testing.T) {
+	tx := cannedHistory(t, 1)[0]
+	if !NewParser(NewConfig()).IsXSOLTrade(tx) {
+		t.Fatal("expected the canned trade to invoke a Hylo program")
+	}
+
+	// A deployment with other program IDs does not recognize mainnet instructions
+	config := &Config{
+		ExchangeProgramID:      "BPFLoaderUpgradeab1e11111111111111111111111",
+		StabilityPoolProgramID: "Config1111111111111111111111111111111111111",
+	}
+	config.buildProgramRegistry()
+	parser := NewParser(config)
+	if parser.IsXSOLTrade(tx) {
+		t.Error("expected the parser to use its own program IDs")
+	}
+
+	result, err := parser.ParseTransaction(context.Background(), tx, cannedWalletXSOLATA, quietLogger())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Trade == nil || result.Trade.Steps != nil {
+		t.Errorf("expected a balance-inferred trade without Hylo steps, got %+v", result.Trade)
+	}
+}
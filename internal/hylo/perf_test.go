@@ -0,0 +1,118 @@
+package hylo
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// cannedWalletXSOLATA is the xSOL token account of the wallet in testdata/canned_trades.json
+const cannedWalletXSOLATA = solana.Address("6QGZ65aw3tFbLPqgicpahKb4VJ3Gt1EM8XxPQ2krgBem")
+
+// defaultParseBudget bounds parsing the 100-transaction canned history; PERF_PARSE_BUDGET_MS overrides it
+const defaultParseBudget = 100 * time.Millisecond
+
+// cannedHistory returns n transactions alternating the canned hyUSD-funded BUY and SELL
+func cannedHistory(tb testing.TB, n int) []*solana.TransactionDetails {
+	tb.Helper()
+
+	data, err := os.ReadFile("testdata/canned_trades.json")
+	if err != nil {
+		tb.Fatalf("failed to read canned trades: %v", err)
+	}
+
+	history := make([]*solana.TransactionDetails, n)
+	for i := range history {
+		var templates []*solana.TransactionDetails
+		if err := json.Unmarshal(data, &templates); err != nil {
+			tb.Fatalf("failed to decode canned trades: %v", err)
+		}
+		tx := templates[i%len(templates)]
+		tx.Slot += solana.Slot(i)
+		history[i] = tx
+	}
+	return history
+}
+
+// quietLogger keeps parser logging out of timings the way production runs at LOG_LEVEL=info or above
+func quietLogger() *logger.Logger {
+	return logger.New(logger.Config{Level: "error"}).WithComponent("hylo-parser")
+}
+
+func BenchmarkParseTransaction(b *testing.B) {
+	history := cannedHistory(b, 2)
+	log := quietLogger()
+	ctx := context.Background()
+
+	for _, bench := range []struct {
+		name string
+		tx   *solana.TransactionDetails
+	}{{"buy", history[0]}, {"sell", history[1]}} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseTransactionWithContext(ctx, bench.tx, cannedWalletXSOLATA, log); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAnalyzeCounterAssetChanges(b *testing.B) {
+	sell := cannedHistory(b, 2)[1]
+	xsolIndex := findAccountIndex(sell.Transaction.Message.AccountKeys, string(cannedWalletXSOLATA))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		analyzeCounterAssetChanges(sell, xsolIndex, TradeSideSell)
+	}
+}
+
+// TestParseHistoryBudget fails when parsing a 100-transaction history exceeds the time budget
+// The best of three runs is compared so a single scheduler hiccup on a shared CI runner does not fail the build
+func TestParseHistoryBudget(t *testing.T) {
+	budget := defaultParseBudget
+	if value := os.Getenv("PERF_PARSE_BUDGET_MS"); value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms <= 0 {
+			t.Fatalf("PERF_PARSE_BUDGET_MS must be a positive integer, got %q", value)
+		}
+		budget = time.Duration(ms) * time.Millisecond
+	}
+
+	history := cannedHistory(t, 100)
+	log := quietLogger()
+	ctx := context.Background()
+
+	best := time.Duration(1<<63 - 1)
+	for run := 0; run < 3; run++ {
+		start := time.Now()
+		trades := 0
+		for _, tx := range history {
+			result, err := ParseTransactionWithContext(ctx, tx, cannedWalletXSOLATA, log)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if result.Trade != nil {
+				trades++
+			}
+		}
+		best = min(best, time.Since(start))
+
+		if trades != len(history) {
+			t.Fatalf("Expected every canned transaction to parse as a trade, got %d of %d", trades, len(history))
+		}
+	}
+
+	t.Logf("parsed %d transactions in %v (budget %v)", len(history), best, budget)
+	if best > budget {
+		t.Errorf("Parsing %d transactions took %v, over the %v budget", len(history), best, budget)
+	}
+}
@@ -0,0 +1,105 @@
+package hylo
+
+import (
+	"fmt"
+	"math"
+
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// PriceAuditTolerance is the relative difference above which an audit check reports a discrepancy
+// Float rounding stays far below it; decimal or unit mistakes show up as errors of 10x or more
+const PriceAuditTolerance = 1e-6
+
+// Price audit checks, exported as the "check" label of hylo_price_audit_discrepancies_total
+const (
+	AuditCheckHyUSDDecimals     = "hyusd_decimals"
+	AuditCheckXSOLDecimals      = "xsol_decimals"
+	AuditCheckHyUSDNAV          = "hyusd_nav"
+	AuditCheckReserveBalance    = "reserve_balance"
+	AuditCheckXSOLNAV           = "xsol_nav"
+	AuditCheckCollateralRatio   = "collateral_ratio"
+	AuditCheckEffectiveLeverage = "effective_leverage"
+)
+
+// PriceDiscrepancy is an audit check whose independent result disagrees with the calculated value
+type PriceDiscrepancy struct {
+	Check         string  `json:"check"`
+	Expected      float64 `json:"expected"`       // Independently derived value
+	Actual        float64 `json:"actual"`         // Value produced by the price calculation
+	RelativeError float64 `json:"relative_error"` // |actual - expected| / |expected|
+}
+
+func (d PriceDiscrepancy) String() string {
+	return fmt.Sprintf("%s: expected %g, got %g (relative error %.2e)", d.Check, d.Expected, d.Actual, d.RelativeError)
+}
+
+// AuditProtocolState recomputes the derived protocol metrics without reusing computeDerivedMetrics
+// Supplies are scaled by the on-chain mint decimals and ratios are taken in USD rather than SOL terms,
+// so a hard-coded decimal or unit mistake in the primary path produces a discrepancy instead of agreeing with itself
+func AuditProtocolState(state *HyloProtocolState) []PriceDiscrepancy {
+	if state == nil || state.SOLPriceUSD <= 0 || state.HyUSDSupply == 0 || state.XSOLSupply == 0 {
+		return nil
+	}
+
+	var discrepancies []PriceDiscrepancy
+	check := func(name string, expected, actual float64) {
+		if d, ok := compareAudit(name, expected, actual); !ok {
+			discrepancies = append(discrepancies, d)
+		}
+	}
+
+	hyusdDecimals := auditDecimals(state.HyUSDMintInfo, tokens.HyUSDDecimals)
+	xsolDecimals := auditDecimals(state.XSOLMintInfo, tokens.XSOLDecimals)
+	check(AuditCheckHyUSDDecimals, tokens.HyUSDDecimals, float64(hyusdDecimals))
+	check(AuditCheckXSOLDecimals, tokens.XSOLDecimals, float64(xsolDecimals))
+
+	hyusdSupply := float64(state.HyUSDSupply) / math.Pow10(int(hyusdDecimals))
+	xsolSupply := float64(state.XSOLSupply) / math.Pow10(int(xsolDecimals))
+	reserveSOL := float64(state.TotalSOLReserve) / math.Pow10(tokens.SOLDecimals)
+
+	// hyUSD is pegged to $1, so its SOL NAV priced back in USD must be exactly one dollar
+	check(AuditCheckHyUSDNAV, 1, state.HyUSDNAVInSOL*state.SOLPriceUSD)
+
+	// Both tokens together claim the entire reserve: Σ NAV × supply = reserve
+	check(AuditCheckReserveBalance, reserveSOL, state.HyUSDNAVInSOL*hyusdSupply+state.XSOLNAVInSOL*xsolSupply)
+
+	// In USD, hyUSD liabilities are simply its supply and xSOL holds the remaining equity
+	reserveUSD := reserveSOL * state.SOLPriceUSD
+	check(AuditCheckXSOLNAV, (reserveUSD-hyusdSupply)/xsolSupply, state.XSOLNAVInSOL*state.SOLPriceUSD)
+
+	collateralRatio := reserveUSD / hyusdSupply
+	check(AuditCheckCollateralRatio, collateralRatio, state.CollateralRatio)
+
+	// Leverage = reserve / equity = CR / (CR - 1); undefined when the protocol has no equity
+	if collateralRatio > 1 {
+		check(AuditCheckEffectiveLeverage, collateralRatio/(collateralRatio-1), state.EffectiveLeverage)
+	}
+
+	return discrepancies
+}
+
+// auditDecimals prefers the decimals read from the mint account, falling back to the token constant
+func auditDecimals(info SPLTokenInfo, fallback uint8) uint8 {
+	if !info.IsInitialized {
+		return fallback
+	}
+	return info.Decimals
+}
+
+// compareAudit reports whether actual is within PriceAuditTolerance of expected
+func compareAudit(check string, expected, actual float64) (PriceDiscrepancy, bool) {
+	d := PriceDiscrepancy{Check: check, Expected: expected, Actual: actual}
+
+	diff := math.Abs(actual - expected)
+	if expected != 0 {
+		d.RelativeError = diff / math.Abs(expected)
+	} else {
+		d.RelativeError = diff
+	}
+
+	if math.IsNaN(d.RelativeError) || math.IsInf(d.RelativeError, 0) {
+		return d, false
+	}
+	return d, d.RelativeError <= PriceAuditTolerance
+}
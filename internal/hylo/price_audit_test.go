@@ -0,0 +1,112 @@
+package hylo
+
+import "testing"
+
+func auditState(t *testing.T) *HyloProtocolState {
+	t.Helper()
+	state := &HyloProtocolState{
+		HyUSDSupply:     1_000_000_000_000, // 1M hyUSD
+		XSOLSupply:      500_000_000_000,   // 500k xSOL
+		TotalSOLReserve: 20_000_000_000_000,
+		SOLPriceUSD:     150,
+		HyUSDMintInfo:   SPLTokenInfo{Supply: 1_000_000_000_000, Decimals: 6, IsInitialized: true},
+		XSOLMintInfo:    SPLTokenInfo{Supply: 500_000_000_000, Decimals: 6, IsInitialized: true},
+	}
+	if err := computeDerivedMetrics(state); err != nil {
+		t.Fatalf("failed to build audit state: %v", err)
+	}
+	return state
+}
+
+func auditChecks(discrepancies []PriceDiscrepancy) map[string]bool {
+	checks := make(map[string]bool, len(discrepancies))
+	for _, d := range discrepancies {
+		checks[d.Check] = true
+	}
+	return checks
+}
+
+func TestAuditProtocolState_Consistent(t *testing.T) {
+	if discrepancies := AuditProtocolState(auditState(t)); len(discrepancies) != 0 {
+		t.Errorf("expected no discrepancies, got %v", discrepancies)
+	}
+}
+
+func TestAuditProtocolState_DetectsMistakes(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*HyloProtocolState)
+		want   []string
+	}{
+		{
+			name:   "hyUSD mint decimals differ from the hard-coded scale",
+			mutate: func(s *HyloProtocolState) { s.HyUSDMintInfo.Decimals = 9 },
+			want:   []string{AuditCheckHyUSDDecimals, AuditCheckReserveBalance, AuditCheckXSOLNAV, AuditCheckCollateralRatio, AuditCheckEffectiveLeverage},
+		},
+		{
+			name:   "xSOL NAV off by a decimal place",
+			mutate: func(s *HyloProtocolState) { s.XSOLNAVInSOL *= 10 },
+			want:   []string{AuditCheckReserveBalance, AuditCheckXSOLNAV},
+		},
+		{
+			name:   "hyUSD NAV not inverted",
+			mutate: func(s *HyloProtocolState) { s.HyUSDNAVInSOL = s.SOLPriceUSD },
+			want:   []string{AuditCheckHyUSDNAV, AuditCheckReserveBalance},
+		},
+		{
+			name:   "collateral ratio in percent",
+			mutate: func(s *HyloProtocolState) { s.CollateralRatio *= 100 },
+			want:   []string{AuditCheckCollateralRatio},
+		},
+		{
+			name:   "leverage drifted",
+			mutate: func(s *HyloProtocolState) { s.EffectiveLeverage *= 1.01 },
+			want:   []string{AuditCheckEffectiveLeverage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := auditState(t)
+			tt.mutate(state)
+
+			got := auditChecks(AuditProtocolState(state))
+			for _, check := range tt.want {
+				if !got[check] {
+					t.Errorf("expected %s discrepancy, got %v", check, got)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("expected %d discrepancies, got %v", len(tt.want), got)
+			}
+		})
+	}
+}
+
+func TestAuditProtocolState_UninitializedMintFallsBack(t *testing.T) {
+	state := auditState(t)
+	state.HyUSDMintInfo = SPLTokenInfo{}
+	state.XSOLMintInfo = SPLTokenInfo{}
+
+	if discrepancies := AuditProtocolState(state); len(discrepancies) != 0 {
+		t.Errorf("expected token constants to be used for missing mint info, got %v", discrepancies)
+	}
+}
+
+func TestPriceCalculator_AuditDetails(t *testing.T) {
+	calc := NewPriceCalculator(&StateReader{config: &Config{PriceAudit: true}})
+	state := auditState(t)
+
+	details, err := calc.GetPriceCalculationDetails(state, state.SOLPriceUSD)
+	if err != nil {
+		t.Fatalf("GetPriceCalculationDetails failed: %v", err)
+	}
+
+	discrepancies, ok := details["audit_discrepancies"].([]PriceDiscrepancy)
+	if !ok {
+		t.Fatalf("expected audit_discrepancies in details, got %T", details["audit_discrepancies"])
+	}
+	if len(discrepancies) != 0 {
+		t.Errorf("expected no discrepancies, got %v", discrepancies)
+	}
+}
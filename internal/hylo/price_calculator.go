@@ -3,22 +3,41 @@ package hylo
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"log/slog"
 	"time"
 
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
 	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/utils"
 )
 
 // PriceCalculator computes xSOL prices using Hylo protocol equations
 // and integrates with SOL/USD price data from the price package
 type PriceCalculator struct {
 	stateReader *StateReader
+
+	// audit cross-checks every calculation with AuditProtocolState (Config.PriceAudit)
+	audit  bool
+	logger *logger.Logger
+
+	// recorder receives every protocol state read for pricing; nil when none is set
+	recorder StateRecorder
+}
+
+// StateRecorder receives protocol state after it was read and priced; implemented by indexer.ProtocolRecorder
+// It is called on the request path, so implementations must return quickly
+type StateRecorder interface {
+	RecordProtocolState(state *HyloProtocolState)
 }
 
 // NewPriceCalculator creates a new PriceCalculator with the provided StateReader
+// Audit mode follows the PriceAudit flag of the StateReader's config
 func NewPriceCalculator(stateReader *StateReader) *PriceCalculator {
 	return &PriceCalculator{
 		stateReader: stateReader,
+		audit:       stateReader != nil && stateReader.config.PriceAudit,
+		logger:      logger.NewFromEnv().WithComponent("price-calculator"),
 	}
 }
 
@@ -50,6 +69,10 @@ func (calc *PriceCalculator) CalculateXSOLPrice(protocolState *HyloProtocolState
 	// Formula: xSOL_Price_USD = xSOL_NAV_in_SOL × SOL_Price_USD
 	xsolPriceInUSD := xsolPriceInSOL * solPriceUSD
 
+	if calc.audit {
+		calc.auditCalculation(protocolState)
+	}
+
 	// Validate calculated prices
 	if xsolPriceInSOL <= 0 {
 		return nil, fmt.Errorf("calculated xSOL price in SOL is not positive: %f", xsolPriceInSOL)
@@ -91,6 +114,10 @@ func (calc *PriceCalculator) CalculateXSOLPriceWithSOLPrice(ctx context.Context,
 		return nil, fmt.Errorf("failed to calculate xSOL price: %w", err)
 	}
 
+	if calc.recorder != nil {
+		calc.recorder.RecordProtocolState(protocolState)
+	}
+
 	return xsolPrice, nil
 }
 
@@ -172,7 +199,7 @@ func (calc *PriceCalculator) GetPriceCalculationDetails(protocolState *HyloProto
 	// Convert raw values to human-readable formats
 	solReserveFormatted := protocolState.GetFormattedSOLReserve()
 
-	return map[string]interface{}{
+	details := map[string]interface{}{
 		// Input data
 		"sol_price_usd": solPriceUSD,
 		"hyusd_supply":  hyusdFormatted,
@@ -195,34 +222,72 @@ func (calc *PriceCalculator) GetPriceCalculationDetails(protocolState *HyloProto
 		// Calculation metadata
 		"timestamp":             protocolState.Timestamp,
 		"calculation_timestamp": time.Now(),
-	}, nil
+	}
+
+	if calc.audit {
+		discrepancies := AuditProtocolState(protocolState)
+		if discrepancies == nil {
+			discrepancies = []PriceDiscrepancy{}
+		}
+		details["audit_discrepancies"] = discrepancies
+	}
+
+	return details, nil
+}
+
+// auditCalculation logs and counts every check where the independent formulas disagree with the state
+func (calc *PriceCalculator) auditCalculation(protocolState *HyloProtocolState) {
+	for _, d := range AuditProtocolState(protocolState) {
+		metrics.RecordPriceAuditDiscrepancy(d.Check)
+		calc.logger.Warn("Price calculation audit discrepancy",
+			slog.String("check", d.Check),
+			slog.Float64("expected", d.Expected),
+			slog.Float64("actual", d.Actual),
+			slog.Float64("relative_error", d.RelativeError),
+			slog.Float64("sol_price_usd", protocolState.SOLPriceUSD),
+			slog.Uint64("hyusd_supply", protocolState.HyUSDSupply),
+			slog.Uint64("xsol_supply", protocolState.XSOLSupply),
+			slog.Uint64("total_sol_reserve", protocolState.TotalSOLReserve))
+	}
+}
+
+// PriceImpactEstimate is the xSOL price and protocol health at one SOL/USD price
+type PriceImpactEstimate struct {
+	SOLPriceMultiplier float64 `json:"sol_price_multiplier"`
+	SOLPriceUSD        float64 `json:"sol_price_usd"`
+	XSOLPriceSOL       float64 `json:"xsol_price_sol"`
+	XSOLPriceUSD       float64 `json:"xsol_price_usd"`
+	CollateralRatio    float64 `json:"collateral_ratio"`
+	EffectiveLeverage  float64 `json:"effective_leverage"`
 }
 
 // EstimateXSOLPriceImpact estimates how xSOL price would change with different SOL/USD prices
 // This is useful for stress testing and understanding price sensitivity
-func (calc *PriceCalculator) EstimateXSOLPriceImpact(protocolState *HyloProtocolState, basePriceUSD float64, priceMultipliers []float64) ([]map[string]interface{}, error) {
+// The protocol state is left untouched; every estimate reprices a copy of it
+func (calc *PriceCalculator) EstimateXSOLPriceImpact(protocolState *HyloProtocolState, basePriceUSD float64, priceMultipliers []float64) ([]PriceImpactEstimate, error) {
 	if err := calc.ValidateProtocolState(protocolState); err != nil {
 		return nil, fmt.Errorf("invalid protocol state: %w", err)
 	}
 
-	results := make([]map[string]interface{}, len(priceMultipliers))
+	results := make([]PriceImpactEstimate, len(priceMultipliers))
 
 	for i, multiplier := range priceMultipliers {
 		newSOLPrice := basePriceUSD * multiplier
 
 		// Calculate xSOL price at this SOL price
-		xsolPrice, err := calc.CalculateXSOLPrice(protocolState, newSOLPrice)
+		shocked := *protocolState
+		xsolPrice, err := calc.CalculateXSOLPrice(&shocked, newSOLPrice)
 		if err != nil {
 			return nil, fmt.Errorf("failed to calculate xSOL price for multiplier %f: %w", multiplier, err)
 		}
 
-		results[i] = map[string]interface{}{
-			"sol_price_multiplier": multiplier,
-			"sol_price_usd":        newSOLPrice,
-			"xsol_price_sol":       xsolPrice.PriceInSOL,
-			"xsol_price_usd":       xsolPrice.PriceInUSD,
-			"collateral_ratio":     xsolPrice.CollateralRatio,
-			"effective_leverage":   xsolPrice.EffectiveLeverage,
+		results[i] = PriceImpactEstimate{
+			SOLPriceMultiplier: multiplier,
+			SOLPriceUSD:        newSOLPrice,
+			XSOLPriceSOL:       xsolPrice.PriceInSOL,
+			XSOLPriceUSD:       xsolPrice.PriceInUSD,
+			CollateralRatio:    xsolPrice.CollateralRatio,
+			EffectiveLeverage:  xsolPrice.EffectiveLeverage,
 		}
 	}
 
@@ -239,49 +304,53 @@ func (calc *PriceCalculator) IsCalculationStale(protocolState *HyloProtocolState
 }
 
 // CalculateHistoricalXSOLPrice calculates historical xSOL price from trade data
-// Only calculates for stablecoin trades (hyUSD, USDC), returns nil for volatile assets
-// Formula: price = stablecoin_amount / xSOL_amount (assuming stablecoin ≈ $1 USD)
+// Only calculates for trades against a configured stablecoin (see SetStablecoins), returns nil for volatile assets
+// Formula: price = stablecoin_amount * peg_usd / xSOL_amount, with the peg read from the stablecoin's peg source
 func CalculateHistoricalXSOLPrice(trade *XSOLTrade) *string {
 	// Skip if not a stablecoin trade
-	// Both hyUSD and USDC are designed to be pegged at $1 USD
-	if trade.CounterAsset != "hyUSD" && trade.CounterAsset != "USDC" {
+	peg, ok := GetStablecoins().Lookup(trade.CounterAsset)
+	if !ok {
 		return nil
 	}
 
-	// Parse amounts (handle decimal strings)
+	// Parse amounts exactly; float64 loses precision on large raw amounts
 	xsolAmount, err := parseDecimalAmount(trade.XSOLAmount)
-	if err != nil || xsolAmount <= 0 {
+	if err != nil || xsolAmount.Sign() <= 0 {
 		return nil
 	}
 
 	stablecoinAmount, err := parseDecimalAmount(trade.CounterAmount)
-	if err != nil || stablecoinAmount <= 0 {
+	if err != nil || stablecoinAmount.Sign() <= 0 {
 		return nil
 	}
 
-	// Calculate: price = stablecoin_amount / xSOL_amount (stablecoin ≈ $1 USD)
-	price := stablecoinAmount / xsolAmount
+	// Calculate: price = stablecoin_amount * peg_usd / xSOL_amount
+	price, err := stablecoinAmount.Mul(utils.DecimalFromFloat(peg.PriceAt(trade.Timestamp))).Div(xsolAmount)
+	if err != nil {
+		return nil
+	}
 
-	// Sanity check: xSOL price should be reasonable ($1-$10,000 range)
-	if price < 1.0 || price > 10000.0 {
+	// Sanity check: xSOL price should be within the stablecoin's bounds ($1-$10,000 by default)
+	lo, hi := peg.bounds()
+	if price.Cmp(utils.DecimalFromFloat(lo)) < 0 || price.Cmp(utils.DecimalFromFloat(hi)) > 0 {
 		return nil // Skip unrealistic prices
 	}
 
 	// Format to 3 decimal places for USD price
-	formatted := fmt.Sprintf("%.3f", price)
+	formatted := price.StringFixed(3)
 	return &formatted
 }
 
-// parseDecimalAmount parses decimal amount string to float64
+// parseDecimalAmount parses a decimal amount string exactly
 // Helper function for historical price calculation
-func parseDecimalAmount(amountStr string) (float64, error) {
+func parseDecimalAmount(amountStr string) (utils.Decimal, error) {
 	if amountStr == "" {
-		return 0, fmt.Errorf("empty amount string")
+		return utils.Decimal{}, fmt.Errorf("empty amount string")
 	}
 
-	amount, err := strconv.ParseFloat(amountStr, 64)
+	amount, err := utils.ParseDecimal(amountStr)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse amount '%s': %w", amountStr, err)
+		return utils.Decimal{}, fmt.Errorf("failed to parse amount '%s': %w", amountStr, err)
 	}
 
 	return amount, nil
@@ -331,8 +331,8 @@ func TestParseDecimalAmount(t *testing.T) {
 				return
 			}
 
-			if result != tt.expected {
-				t.Errorf("Expected %f, got %f", tt.expected, result)
+			if result.Float64() != tt.expected {
+				t.Errorf("Expected %f, got %s", tt.expected, result)
 			}
 		})
 	}
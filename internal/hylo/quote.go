@@ -0,0 +1,198 @@
+package hylo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"hylo-wallet-tracker-api/internal/peg"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// ErrUnsupportedQuote is returned by GetPriceQuote for tokens it cannot price
+var ErrUnsupportedQuote = errors.New("unsupported price symbol")
+
+// QuoteSymbols lists the tokens GetPriceQuote prices
+var QuoteSymbols = []string{tokens.HyUSDSymbol, tokens.SHyUSDSymbol, tokens.XSOLSymbol, tokens.SOLSymbol, tokens.JitoSOLSymbol}
+
+// PegReader reports hyUSD's market price against its peg; implemented by peg.Monitor
+type PegReader interface {
+	Current(ctx context.Context) (*peg.Status, error)
+}
+
+// quoteProviderHylo marks prices derived from on-chain protocol state
+const quoteProviderHylo = "hylo"
+
+// SetPegReader sets the peg monitor hyUSD quotes are read from
+// Without it hyUSD is quoted at its plain market price
+func (ps *PriceService) SetPegReader(reader PegReader) {
+	ps.pegReader = reader
+}
+
+// GetPriceQuote prices one supported token by symbol (case-insensitive)
+// hyUSD is peg-monitored, sHYUSD derived from the stability pool exchange rate, xSOL from protocol
+// math, and SOL and jitoSOL read from DEX markets
+func (ps *PriceService) GetPriceQuote(ctx context.Context, symbol string) (*price.PriceQuote, error) {
+	canonical, ok := quoteSymbol(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedQuote, symbol)
+	}
+
+	solPrice, err := ps.dexScreenerClient.FetchSOLPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SOL/USD price: %w", err)
+	}
+
+	switch canonical {
+	case tokens.SOLSymbol:
+		return &price.PriceQuote{
+			Symbol:   tokens.SOLSymbol,
+			PriceUSD: solPrice.Price,
+			PriceSOL: 1,
+			Method:   price.QuoteMethodMarket,
+			Source: price.QuoteSource{
+				Provider:       solPrice.Source,
+				Pair:           solPrice.Pair,
+				LiquidityUSD:   solPrice.Liquidity,
+				SOLPriceSource: solPrice.Source,
+			},
+			Timestamp: solPrice.Timestamp,
+		}, nil
+	case tokens.XSOLSymbol:
+		prices, err := ps.combinedPrices(ctx, solPrice)
+		if err != nil {
+			return nil, err
+		}
+		return &price.PriceQuote{
+			Symbol:    tokens.XSOLSymbol,
+			Mint:      tokens.XSOLMint.String(),
+			PriceUSD:  prices.XSOLInUSD,
+			PriceSOL:  prices.XSOLInSOL,
+			Method:    price.QuoteMethodProtocol,
+			Source:    price.QuoteSource{Provider: quoteProviderHylo, SOLPriceSource: solPrice.Source},
+			Timestamp: prices.UpdatedAt,
+		}, nil
+	case tokens.SHyUSDSymbol:
+		return ps.stakedHyUSDQuote(ctx, solPrice)
+	case tokens.HyUSDSymbol:
+		return ps.hyUSDQuote(ctx, solPrice)
+	default:
+		return ps.marketQuote(ctx, tokens.JitoSOLSymbol, tokens.JitoSOLMint.String(), solPrice)
+	}
+}
+
+// combinedPrices calculates the combined prices from an already fetched SOL/USD price and records them
+func (ps *PriceService) combinedPrices(ctx context.Context, solPrice *price.SOLUSDPrice) (*price.CombinedPriceResponse, error) {
+	ctx, dataContext := solana.WithDataContext(ctx)
+	response, err := ps.priceCalculator.CalculateCombinedPriceResponse(ctx, solPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate combined price response: %w", err)
+	}
+	response.Context = dataContext.DataContext()
+	ps.history.Record(response)
+	return response, nil
+}
+
+// stakedHyUSDQuote values one sHYUSD share at the hyUSD ($1) and xSOL it redeems for
+func (ps *PriceService) stakedHyUSDQuote(ctx context.Context, solPrice *price.SOLUSDPrice) (*price.PriceQuote, error) {
+	prices, err := ps.combinedPrices(ctx, solPrice)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := ps.stateReader.ReadStabilityPool(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stability pool: %w", err)
+	}
+	backing, priceUSD, err := shareValue(pool, prices.XSOLInUSD)
+	if err != nil {
+		return nil, err
+	}
+
+	return &price.PriceQuote{
+		Symbol:    tokens.SHyUSDSymbol,
+		Mint:      tokens.SHyUSDMint.String(),
+		PriceUSD:  priceUSD,
+		PriceSOL:  priceUSD / solPrice.Price,
+		Method:    price.QuoteMethodExchangeRate,
+		Source:    price.QuoteSource{Provider: quoteProviderHylo, SOLPriceSource: solPrice.Source},
+		Backing:   backing,
+		Timestamp: prices.UpdatedAt,
+	}, nil
+}
+
+// hyUSDQuote reads hyUSD from the peg monitor, or from its market price when none is set
+func (ps *PriceService) hyUSDQuote(ctx context.Context, solPrice *price.SOLUSDPrice) (*price.PriceQuote, error) {
+	if ps.pegReader == nil {
+		return ps.marketQuote(ctx, tokens.HyUSDSymbol, tokens.HyUSDMint.String(), solPrice)
+	}
+	status, err := ps.pegReader.Current(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hyUSD peg status: %w", err)
+	}
+
+	return &price.PriceQuote{
+		Symbol:   tokens.HyUSDSymbol,
+		Mint:     tokens.HyUSDMint.String(),
+		PriceUSD: status.PriceUSD,
+		PriceSOL: status.PriceUSD / solPrice.Price,
+		Method:   price.QuoteMethodPeg,
+		Source: price.QuoteSource{
+			Provider:       status.Source,
+			LiquidityUSD:   status.LiquidityUSD,
+			Pools:          status.Pools,
+			SOLPriceSource: solPrice.Source,
+		},
+		Peg:       &price.QuotePeg{PegUSD: status.PegUSD, DeviationBps: status.DeviationBps, Depegged: status.Depegged},
+		Timestamp: status.UpdatedAt,
+	}, nil
+}
+
+// marketQuote prices a token from its liquidity-weighted DexScreener pools
+func (ps *PriceService) marketQuote(ctx context.Context, symbol, mint string, solPrice *price.SOLUSDPrice) (*price.PriceQuote, error) {
+	tokenPrice, err := ps.dexScreenerClient.FetchTokenPrice(ctx, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s price: %w", symbol, err)
+	}
+
+	return &price.PriceQuote{
+		Symbol:   symbol,
+		Mint:     mint,
+		PriceUSD: tokenPrice.PriceUSD,
+		PriceSOL: tokenPrice.PriceUSD / solPrice.Price,
+		Method:   price.QuoteMethodMarket,
+		Source: price.QuoteSource{
+			Provider:       tokenPrice.Source,
+			LiquidityUSD:   tokenPrice.LiquidityUSD,
+			Pools:          tokenPrice.Pools,
+			SOLPriceSource: solPrice.Source,
+		},
+		Timestamp: tokenPrice.Timestamp,
+	}, nil
+}
+
+// shareValue returns the assets backing one sHYUSD share and their USD value, with hyUSD at its $1 peg
+// hyUSD, xSOL and sHYUSD share 6 decimals, so raw balance ratios are per-token amounts
+func shareValue(pool *tokens.StabilityPoolSnapshot, xsolUSD float64) (*price.QuoteBacking, float64, error) {
+	if pool.SHyUSDSupply == 0 {
+		return nil, 0, fmt.Errorf("sHYUSD supply is zero: no exchange rate")
+	}
+	supply := float64(pool.SHyUSDSupply)
+	backing := &price.QuoteBacking{
+		HyUSDPerShare: float64(pool.HyUSDBalance) / supply,
+		XSOLPerShare:  float64(pool.XSOLBalance) / supply,
+	}
+	return backing, backing.HyUSDPerShare*peg.DesignPegUSD + backing.XSOLPerShare*xsolUSD, nil
+}
+
+// quoteSymbol resolves a case-insensitive symbol to its canonical spelling
+func quoteSymbol(symbol string) (string, bool) {
+	for _, supported := range QuoteSymbols {
+		if strings.EqualFold(supported, strings.TrimSpace(symbol)) {
+			return supported, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,44 @@
+package hylo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestShareValue(t *testing.T) {
+	// 1,200 hyUSD and 50 xSOL back 1,000 sHYUSD
+	pool := &tokens.StabilityPoolSnapshot{HyUSDBalance: 1_200_000_000, XSOLBalance: 50_000_000, SHyUSDSupply: 1_000_000_000}
+
+	backing, priceUSD, err := shareValue(pool, 0.4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if backing.HyUSDPerShare != 1.2 || backing.XSOLPerShare != 0.05 {
+		t.Errorf("Expected 1.2 hyUSD and 0.05 xSOL per share, got %+v", backing)
+	}
+	if math.Abs(priceUSD-1.22) > 1e-12 {
+		t.Errorf("Expected $1.22 per share, got %v", priceUSD)
+	}
+
+	if _, _, err := shareValue(&tokens.StabilityPoolSnapshot{HyUSDBalance: 1}, 0.4); err == nil {
+		t.Error("Expected an error for an empty pool")
+	}
+}
+
+func TestQuoteSymbol(t *testing.T) {
+	cases := map[string]string{"shyusd": "sHYUSD", "XSOL": "xSOL", " sol ": "SOL", "JitoSOL": "jitoSOL", "HYUSD": "hyUSD"}
+	for input, want := range cases {
+		if got, ok := quoteSymbol(input); !ok || got != want {
+			t.Errorf("quoteSymbol(%q) = %q, %v; want %q", input, got, ok, want)
+		}
+	}
+
+	ps := &PriceService{}
+	if _, err := ps.GetPriceQuote(context.Background(), "USDC"); !errors.Is(err, ErrUnsupportedQuote) {
+		t.Errorf("Expected ErrUnsupportedQuote for USDC, got %v", err)
+	}
+}
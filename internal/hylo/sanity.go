@@ -0,0 +1,29 @@
+package hylo
+
+// DefaultMaxTradeSupplyFraction is the share of the xSOL supply above which a single trade is implausible
+// No real mint or redeem moves a quarter of the supply; such amounts point at a parsing or decimals bug
+const DefaultMaxTradeSupplyFraction = 0.25
+
+// Warnings attached to implausible trades, reported in XSOLTrade.Warnings
+const (
+	TradeWarningExceedsSupply = "xsol_amount_exceeds_supply" // xSOL amount is above the configured fraction of the mint supply
+)
+
+// CheckTradeAmount flags a trade whose xSOL amount exceeds maxFraction of the xSOL supply and reports
+// whether it did. The amount is left as parsed so clients can judge it; a non-positive fraction or an
+// unknown (zero) supply skips the check
+func CheckTradeAmount(trade *XSOLTrade, supplyRaw uint64, maxFraction float64) bool {
+	if trade == nil || maxFraction <= 0 || supplyRaw == 0 {
+		return false
+	}
+	if float64(trade.XSOLAmountRaw) <= float64(supplyRaw)*maxFraction {
+		return false
+	}
+	for _, warning := range trade.Warnings {
+		if warning == TradeWarningExceedsSupply {
+			return true
+		}
+	}
+	trade.Warnings = append(trade.Warnings, TradeWarningExceedsSupply)
+	return true
+}
@@ -0,0 +1,40 @@
+package hylo
+
+import "testing"
+
+func TestCheckTradeAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   uint64
+		supply   uint64
+		fraction float64
+		flagged  bool
+	}{
+		{name: "within threshold", amount: 250, supply: 1000, fraction: 0.25},
+		{name: "above threshold", amount: 251, supply: 1000, fraction: 0.25, flagged: true},
+		{name: "above supply", amount: 5000, supply: 1000, fraction: 0.25, flagged: true},
+		{name: "unknown supply", amount: 5000, supply: 0, fraction: 0.25},
+		{name: "disabled", amount: 5000, supply: 1000, fraction: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trade := &XSOLTrade{XSOLAmountRaw: tt.amount}
+			if got := CheckTradeAmount(trade, tt.supply, tt.fraction); got != tt.flagged {
+				t.Errorf("CheckTradeAmount() = %v, want %v", got, tt.flagged)
+			}
+			if flagged := len(trade.Warnings) == 1 && trade.Warnings[0] == TradeWarningExceedsSupply; flagged != tt.flagged {
+				t.Errorf("Warnings = %v, want flagged %v", trade.Warnings, tt.flagged)
+			}
+		})
+	}
+}
+
+func TestCheckTradeAmountWarnsOnce(t *testing.T) {
+	trade := &XSOLTrade{XSOLAmountRaw: 900}
+	CheckTradeAmount(trade, 1000, 0.5)
+	CheckTradeAmount(trade, 1000, 0.5)
+	if len(trade.Warnings) != 1 {
+		t.Errorf("Expected a single warning, got %v", trade.Warnings)
+	}
+}
@@ -0,0 +1,204 @@
+package hylo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// Default collateral ratios below which the protocol enters each stability mode
+const (
+	DefaultStabilityMode1Ratio = 1.5
+	DefaultStabilityMode2Ratio = 1.3
+)
+
+// Stability modes by collateral ratio, from healthy to insolvent
+const (
+	StabilityModeNormal              = "normal"
+	StabilityMode1                   = "mode_1"              // Below the mode 1 ratio
+	StabilityMode2                   = "mode_2"              // Below the mode 2 ratio
+	StabilityModeUndercollateralized = "undercollateralized" // Below 100%: the reserve no longer covers hyUSD and xSOL is worthless
+)
+
+// MaxSOLDropPct bounds the SOL price shock a scenario can apply
+const MaxSOLDropPct = 99.0
+
+// StabilityThresholds are the collateral ratios at which the stability modes trigger
+type StabilityThresholds struct {
+	Mode1CollateralRatio float64 `json:"mode_1_collateral_ratio"`
+	Mode2CollateralRatio float64 `json:"mode_2_collateral_ratio"`
+}
+
+// ScenarioState is the protocol's health at one SOL price
+type ScenarioState struct {
+	SOLPriceUSD     float64 `json:"sol_price_usd"`
+	CollateralRatio float64 `json:"collateral_ratio"`
+
+	// EffectiveLeverage, XSOLNAVInSOL and XSOLPriceUSD are 0 once xSOL is worthless
+	EffectiveLeverage float64 `json:"effective_leverage"`
+	XSOLNAVInSOL      float64 `json:"xsol_nav_sol"`
+	XSOLPriceUSD      float64 `json:"xsol_price_usd"`
+
+	StabilityMode string `json:"stability_mode"`
+}
+
+// ProtocolScenario projects the protocol's health under a SOL price shock; supplies and the SOL
+// reserve are held at their current values, so only the SOL price moves
+type ProtocolScenario struct {
+	SOLDropPct float64       `json:"sol_drop_pct"`
+	Current    ScenarioState `json:"current"`
+	Shocked    ScenarioState `json:"shocked"`
+
+	// XSOLPriceChangePct is the change of the xSOL USD price under the shock
+	XSOLPriceChangePct float64 `json:"xsol_price_change_pct"`
+
+	Thresholds StabilityThresholds `json:"thresholds"`
+
+	// Triggered lists the stability modes the shock would enter that are not active now, mildest first
+	Triggered []string `json:"triggered"`
+
+	// DropToMode1Pct and DropToMode2Pct are the SOL price drops from now that would trigger each mode; 0 when already active
+	DropToMode1Pct float64 `json:"drop_to_mode_1_pct"`
+	DropToMode2Pct float64 `json:"drop_to_mode_2_pct"`
+
+	Context     *solana.DataContext `json:"context,omitempty"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
+
+// Thresholds returns the configured stability mode thresholds
+func (c *Config) Thresholds() StabilityThresholds {
+	return StabilityThresholds{
+		Mode1CollateralRatio: c.StabilityMode1Ratio,
+		Mode2CollateralRatio: c.StabilityMode2Ratio,
+	}
+}
+
+// Mode returns the stability mode the protocol is in at collateralRatio
+func (t StabilityThresholds) Mode(collateralRatio float64) string {
+	switch {
+	case collateralRatio < 1:
+		return StabilityModeUndercollateralized
+	case collateralRatio < t.Mode2CollateralRatio:
+		return StabilityMode2
+	case collateralRatio < t.Mode1CollateralRatio:
+		return StabilityMode1
+	}
+	return StabilityModeNormal
+}
+
+// ValidateSOLDrop checks a scenario's SOL price drop in percent
+func ValidateSOLDrop(dropPct float64) error {
+	if math.IsNaN(dropPct) || dropPct <= 0 || dropPct > MaxSOLDropPct {
+		return fmt.Errorf("invalid sol_drop %v: must be above 0 and at most %v percent", dropPct, MaxSOLDropPct)
+	}
+	return nil
+}
+
+// AnalyzeSOLShock projects the protocol state under a SOL price drop of dropPct percent
+// The collateral ratio scales with the SOL price, since the reserve is held in SOL and hyUSD is worth $1:
+// shocks taking it below 100% leave xSOL worthless and are not priced
+func (calc *PriceCalculator) AnalyzeSOLShock(state *HyloProtocolState, dropPct float64, thresholds StabilityThresholds) (*ProtocolScenario, error) {
+	if err := ValidateSOLDrop(dropPct); err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("protocol state cannot be nil")
+	}
+
+	multiplier := 1 - dropPct/100
+	multipliers := []float64{1}
+	if state.CollateralRatio*multiplier > 1 {
+		multipliers = append(multipliers, multiplier)
+	}
+	estimates, err := calc.EstimateXSOLPriceImpact(state, state.SOLPriceUSD, multipliers)
+	if err != nil {
+		return nil, err
+	}
+
+	current := scenarioState(estimates[0], thresholds)
+	shocked := ScenarioState{
+		SOLPriceUSD:     state.SOLPriceUSD * multiplier,
+		CollateralRatio: state.CollateralRatio * multiplier,
+	}
+	shocked.StabilityMode = thresholds.Mode(shocked.CollateralRatio)
+	if len(estimates) > 1 {
+		shocked = scenarioState(estimates[1], thresholds)
+	}
+
+	scenario := &ProtocolScenario{
+		SOLDropPct:     dropPct,
+		Current:        current,
+		Shocked:        shocked,
+		Thresholds:     thresholds,
+		Triggered:      []string{},
+		DropToMode1Pct: dropToRatio(current.CollateralRatio, thresholds.Mode1CollateralRatio),
+		DropToMode2Pct: dropToRatio(current.CollateralRatio, thresholds.Mode2CollateralRatio),
+		GeneratedAt:    time.Now().UTC(),
+	}
+	if current.XSOLPriceUSD > 0 {
+		scenario.XSOLPriceChangePct = (shocked.XSOLPriceUSD/current.XSOLPriceUSD - 1) * 100
+	}
+
+	for _, mode := range []struct {
+		name  string
+		ratio float64
+	}{
+		{StabilityMode1, thresholds.Mode1CollateralRatio},
+		{StabilityMode2, thresholds.Mode2CollateralRatio},
+		{StabilityModeUndercollateralized, 1},
+	} {
+		if current.CollateralRatio >= mode.ratio && shocked.CollateralRatio < mode.ratio {
+			scenario.Triggered = append(scenario.Triggered, mode.name)
+		}
+	}
+
+	return scenario, nil
+}
+
+// scenarioState converts a price impact estimate
+func scenarioState(estimate PriceImpactEstimate, thresholds StabilityThresholds) ScenarioState {
+	return ScenarioState{
+		SOLPriceUSD:       estimate.SOLPriceUSD,
+		CollateralRatio:   estimate.CollateralRatio,
+		EffectiveLeverage: estimate.EffectiveLeverage,
+		XSOLNAVInSOL:      estimate.XSOLPriceSOL,
+		XSOLPriceUSD:      estimate.XSOLPriceUSD,
+		StabilityMode:     thresholds.Mode(estimate.CollateralRatio),
+	}
+}
+
+// dropToRatio is the SOL price drop in percent that takes the collateral ratio down to target
+func dropToRatio(collateralRatio, target float64) float64 {
+	if collateralRatio <= target {
+		return 0
+	}
+	return (1 - target/collateralRatio) * 100
+}
+
+// AnalyzeSOLShock projects the current protocol state under a SOL price drop of dropPct percent
+func (ps *PriceService) AnalyzeSOLShock(ctx context.Context, dropPct float64) (*ProtocolScenario, error) {
+	if err := ValidateSOLDrop(dropPct); err != nil {
+		return nil, err
+	}
+
+	ctx, dataContext := solana.WithDataContext(ctx)
+	solPrice, err := ps.dexScreenerClient.FetchSOLPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SOL/USD price: %w", err)
+	}
+
+	protocolState, err := ps.stateReader.ReadProtocolState(ctx, solPrice.Price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol state: %w", err)
+	}
+
+	scenario, err := ps.priceCalculator.AnalyzeSOLShock(protocolState, dropPct, ps.stateReader.config.Thresholds())
+	if err != nil {
+		return nil, err
+	}
+	scenario.Context = dataContext.DataContext()
+	return scenario, nil
+}
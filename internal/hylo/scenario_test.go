@@ -0,0 +1,54 @@
+package hylo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnalyzeSOLShock(t *testing.T) {
+	state := newSimulationState(t)
+	state.HyUSDMintInfo = SPLTokenInfo{Supply: state.HyUSDSupply, IsInitialized: true}
+	state.XSOLMintInfo = SPLTokenInfo{Supply: state.XSOLSupply, IsInitialized: true}
+	thresholds := StabilityThresholds{Mode1CollateralRatio: 1.5, Mode2CollateralRatio: 1.3}
+	calc := NewPriceCalculator(nil)
+
+	// SOL at $70 leaves 20,000 SOL against $1M of hyUSD: CR 1.4 and xSOL at $0.40
+	scenario, err := calc.AnalyzeSOLShock(state, 30, thresholds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !closeTo(scenario.Shocked.CollateralRatio, 1.4) || !closeTo(scenario.Shocked.XSOLPriceUSD, 0.4) || !closeTo(scenario.XSOLPriceChangePct, -60) {
+		t.Errorf("unexpected shocked state: %+v (%v%%)", scenario.Shocked, scenario.XSOLPriceChangePct)
+	}
+	if scenario.Current.StabilityMode != StabilityModeNormal || scenario.Shocked.StabilityMode != StabilityMode1 {
+		t.Errorf("expected a move from normal to mode 1, got %s to %s", scenario.Current.StabilityMode, scenario.Shocked.StabilityMode)
+	}
+	if len(scenario.Triggered) != 1 || scenario.Triggered[0] != StabilityMode1 {
+		t.Errorf("expected mode 1 to trigger, got %v", scenario.Triggered)
+	}
+	if !closeTo(scenario.DropToMode1Pct, 25) || !closeTo(scenario.DropToMode2Pct, 35) {
+		t.Errorf("expected modes to trigger at 25%% and 35%% drops, got %v and %v", scenario.DropToMode1Pct, scenario.DropToMode2Pct)
+	}
+	if state.SOLPriceUSD != 100 || !closeTo(state.CollateralRatio, 2) {
+		t.Errorf("expected the protocol state to be left untouched, got %+v", state)
+	}
+
+	// A drop below 100% collateralization wipes xSOL out
+	scenario, err = calc.AnalyzeSOLShock(state, 60, thresholds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scenario.Shocked.StabilityMode != StabilityModeUndercollateralized || scenario.Shocked.XSOLPriceUSD != 0 || len(scenario.Triggered) != 3 {
+		t.Errorf("expected an undercollateralized protocol with worthless xSOL, got %+v triggering %v", scenario.Shocked, scenario.Triggered)
+	}
+
+	for _, drop := range []float64{0, -10, 100, math.NaN()} {
+		if _, err := calc.AnalyzeSOLShock(state, drop, thresholds); err == nil {
+			t.Errorf("expected a %v%% drop to be rejected", drop)
+		}
+	}
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
@@ -0,0 +1,225 @@
+package hylo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// ExchangeFees holds the mint and redeem fee rates applied by the Hylo exchange
+type ExchangeFees struct {
+	MintFeeRateBps   uint16 `json:"mint_fee_rate_bps"`
+	RedeemFeeRateBps uint16 `json:"redeem_fee_rate_bps"`
+	Source           string `json:"source"`
+}
+
+// DefaultExchangeFees returns the fallback fee rates used when on-chain fees are unavailable
+func DefaultExchangeFees() *ExchangeFees {
+	return &ExchangeFees{
+		MintFeeRateBps:   DefaultMintFeeRateBps,
+		RedeemFeeRateBps: DefaultRedeemFeeRateBps,
+		Source:           FeeSourceDefault,
+	}
+}
+
+// TradeSimulationRequest describes a hypothetical xSOL mint (BUY) or redeem (SELL)
+// Amount is denominated in the input asset: the counter asset for BUY, xSOL for SELL
+type TradeSimulationRequest struct {
+	Side         string `json:"side" example:"BUY"`
+	Amount       string `json:"amount" example:"10.5"`
+	CounterAsset string `json:"counter_asset" example:"SOL"`
+}
+
+// TradeSimulationResult contains the estimated outcome of a simulated trade
+type TradeSimulationResult struct {
+	Side         string `json:"side"`
+	CounterAsset string `json:"counter_asset"`
+
+	InputAsset   string `json:"input_asset"`
+	InputAmount  string `json:"input_amount"`
+	OutputAsset  string `json:"output_asset"`
+	OutputAmount string `json:"output_amount"`
+
+	FeeAsset   string `json:"fee_asset"`
+	FeeAmount  string `json:"fee_amount"`
+	FeeRateBps uint16 `json:"fee_rate_bps"`
+	FeeSource  string `json:"fee_source"`
+
+	XSOLNAVBefore  float64 `json:"xsol_nav_sol_before"`
+	XSOLNAVAfter   float64 `json:"xsol_nav_sol_after"`
+	PriceImpactPct float64 `json:"price_impact_pct"`
+
+	CollateralRatioBefore   float64 `json:"collateral_ratio_before"`
+	CollateralRatioAfter    float64 `json:"collateral_ratio_after"`
+	EffectiveLeverageBefore float64 `json:"effective_leverage_before"`
+	EffectiveLeverageAfter  float64 `json:"effective_leverage_after"`
+
+	SOLPriceUSD float64   `json:"sol_price_usd"`
+	SimulatedAt time.Time `json:"simulated_at"`
+}
+
+// Normalize canonicalizes side and counter asset casing
+func (r *TradeSimulationRequest) Normalize() {
+	r.Side = strings.ToUpper(strings.TrimSpace(r.Side))
+	r.Amount = strings.TrimSpace(r.Amount)
+
+	switch {
+	case strings.EqualFold(strings.TrimSpace(r.CounterAsset), tokens.SOLSymbol):
+		r.CounterAsset = tokens.SOLSymbol
+	case strings.EqualFold(strings.TrimSpace(r.CounterAsset), tokens.HyUSDSymbol):
+		r.CounterAsset = tokens.HyUSDSymbol
+	}
+}
+
+// Validate checks that the simulation request is well-formed
+func (r *TradeSimulationRequest) Validate() error {
+	if r.Side != TradeSideBuy && r.Side != TradeSideSell {
+		return fmt.Errorf("invalid side %q: must be %s or %s", r.Side, TradeSideBuy, TradeSideSell)
+	}
+
+	if r.CounterAsset != tokens.SOLSymbol && r.CounterAsset != tokens.HyUSDSymbol {
+		return fmt.Errorf("invalid counter_asset %q: must be %s or %s", r.CounterAsset, tokens.SOLSymbol, tokens.HyUSDSymbol)
+	}
+
+	raw, err := utils.ParseDecimalAmount(r.Amount, r.inputDecimals())
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+	if raw == 0 {
+		return fmt.Errorf("invalid amount: must be greater than zero")
+	}
+
+	return nil
+}
+
+func (r *TradeSimulationRequest) inputDecimals() uint8 {
+	if r.Side == TradeSideSell {
+		return tokens.XSOLDecimals
+	}
+	return counterAssetDecimals(r.CounterAsset)
+}
+
+func counterAssetDecimals(asset string) uint8 {
+	if asset == tokens.SOLSymbol {
+		return tokens.SOLDecimals
+	}
+	return tokens.HyUSDDecimals
+}
+
+// SimulateTrade estimates the result of minting or redeeming xSOL against the given protocol state
+// Fees are deducted from the input for BUY and from the redeemed value for SELL and go to the fee vault, not the reserve
+func SimulateTrade(state *HyloProtocolState, fees *ExchangeFees, req *TradeSimulationRequest) (*TradeSimulationResult, error) {
+	if state == nil {
+		return nil, fmt.Errorf("protocol state is required")
+	}
+	if fees == nil {
+		fees = DefaultExchangeFees()
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	inputDecimals := req.inputDecimals()
+	counterDecimals := counterAssetDecimals(req.CounterAsset)
+	inputRaw, _ := utils.ParseDecimalAmount(req.Amount, inputDecimals)
+
+	after := *state
+	result := &TradeSimulationResult{
+		Side:                    req.Side,
+		CounterAsset:            req.CounterAsset,
+		InputAmount:             utils.FormatTokenAmount(inputRaw, inputDecimals),
+		XSOLNAVBefore:           state.XSOLNAVInSOL,
+		CollateralRatioBefore:   state.CollateralRatio,
+		EffectiveLeverageBefore: state.EffectiveLeverage,
+		SOLPriceUSD:             state.SOLPriceUSD,
+		FeeSource:               fees.Source,
+		SimulatedAt:             time.Now(),
+	}
+
+	if req.Side == TradeSideBuy {
+		result.InputAsset = req.CounterAsset
+		result.OutputAsset = tokens.XSOLSymbol
+		result.FeeAsset = req.CounterAsset
+		result.FeeRateBps = fees.MintFeeRateBps
+
+		feeRaw := applyFeeRate(inputRaw, fees.MintFeeRateBps)
+		netRaw := inputRaw - feeRaw
+		netSOL := toUnits(netRaw, counterDecimals)
+		if req.CounterAsset == tokens.HyUSDSymbol {
+			// hyUSD is burned at NAV; the SOL backing it is reassigned to xSOL holders
+			if netRaw > after.HyUSDSupply {
+				return nil, fmt.Errorf("invalid amount: exceeds hyUSD supply")
+			}
+			netSOL *= state.HyUSDNAVInSOL
+			after.HyUSDSupply -= netRaw
+		} else {
+			after.TotalSOLReserve += netRaw
+		}
+
+		xsolOutRaw := fromUnits(netSOL/state.XSOLNAVInSOL, tokens.XSOLDecimals)
+		after.XSOLSupply += xsolOutRaw
+
+		result.FeeAmount = utils.FormatTokenAmount(feeRaw, counterDecimals)
+		result.OutputAmount = utils.FormatTokenAmount(xsolOutRaw, tokens.XSOLDecimals)
+	} else {
+		result.InputAsset = tokens.XSOLSymbol
+		result.OutputAsset = req.CounterAsset
+		result.FeeAsset = req.CounterAsset
+		result.FeeRateBps = fees.RedeemFeeRateBps
+
+		if inputRaw > state.XSOLSupply {
+			return nil, fmt.Errorf("invalid amount: exceeds xSOL supply")
+		}
+
+		grossSOL := toUnits(inputRaw, tokens.XSOLDecimals) * state.XSOLNAVInSOL
+		var grossRaw uint64
+		if req.CounterAsset == tokens.HyUSDSymbol {
+			// Redeemed SOL value is re-issued as hyUSD; the reserve itself is unchanged
+			grossRaw = fromUnits(grossSOL/state.HyUSDNAVInSOL, counterDecimals)
+			after.HyUSDSupply += grossRaw
+		} else {
+			grossRaw = fromUnits(grossSOL, counterDecimals)
+			if grossRaw > after.TotalSOLReserve {
+				return nil, fmt.Errorf("invalid amount: exceeds SOL reserve")
+			}
+			after.TotalSOLReserve -= grossRaw
+		}
+		after.XSOLSupply -= inputRaw
+
+		feeRaw := applyFeeRate(grossRaw, fees.RedeemFeeRateBps)
+		result.FeeAmount = utils.FormatTokenAmount(feeRaw, counterDecimals)
+		result.OutputAmount = utils.FormatTokenAmount(grossRaw-feeRaw, counterDecimals)
+	}
+
+	after.HyUSDMintInfo.Supply = after.HyUSDSupply
+	after.XSOLMintInfo.Supply = after.XSOLSupply
+	if err := computeDerivedMetrics(&after); err != nil {
+		return nil, fmt.Errorf("trade would leave protocol in an invalid state: %w", err)
+	}
+
+	result.XSOLNAVAfter = after.XSOLNAVInSOL
+	result.CollateralRatioAfter = after.CollateralRatio
+	result.EffectiveLeverageAfter = after.EffectiveLeverage
+	if state.XSOLNAVInSOL > 0 {
+		result.PriceImpactPct = (after.XSOLNAVInSOL - state.XSOLNAVInSOL) / state.XSOLNAVInSOL * 100
+	}
+
+	return result, nil
+}
+
+func applyFeeRate(raw uint64, feeRateBps uint16) uint64 {
+	return raw/BasisPointsDenominator*uint64(feeRateBps) +
+		raw%BasisPointsDenominator*uint64(feeRateBps)/BasisPointsDenominator
+}
+
+func toUnits(raw uint64, decimals uint8) float64 {
+	return float64(raw) / math.Pow10(int(decimals))
+}
+
+func fromUnits(amount float64, decimals uint8) uint64 {
+	return uint64(math.Round(amount * math.Pow10(int(decimals))))
+}
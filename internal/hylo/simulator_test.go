@@ -0,0 +1,195 @@
+package hylo
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+// newSimulationState builds a protocol state with SOL at $100, 20,000 SOL reserve,
+// 1M hyUSD and 1M xSOL outstanding (xSOL NAV 0.01 SOL, collateral ratio 2.0)
+func newSimulationState(t *testing.T) *HyloProtocolState {
+	t.Helper()
+
+	state := &HyloProtocolState{
+		HyUSDSupply:     1_000_000_000_000,
+		XSOLSupply:      1_000_000_000_000,
+		TotalSOLReserve: 20_000_000_000_000,
+		SOLPriceUSD:     100,
+	}
+	if err := computeDerivedMetrics(state); err != nil {
+		t.Fatalf("failed to build simulation state: %v", err)
+	}
+	return state
+}
+
+func zeroFees() *ExchangeFees {
+	return &ExchangeFees{Source: FeeSourceDefault}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSimulateTrade(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       TradeSimulationRequest
+		fees          *ExchangeFees
+		wantOutput    string
+		wantFee       string
+		wantCRAfter   float64
+		wantNAVAfter  float64
+		wantOutputSym string
+	}{
+		{
+			name:          "buy with SOL without fees",
+			request:       TradeSimulationRequest{Side: "BUY", Amount: "10", CounterAsset: "SOL"},
+			fees:          zeroFees(),
+			wantOutput:    "1000",
+			wantFee:       "0",
+			wantCRAfter:   2.001,
+			wantNAVAfter:  0.01,
+			wantOutputSym: "xSOL",
+		},
+		{
+			name:          "buy with SOL applies mint fee",
+			request:       TradeSimulationRequest{Side: "BUY", Amount: "10", CounterAsset: "SOL"},
+			fees:          &ExchangeFees{MintFeeRateBps: 50, RedeemFeeRateBps: 50, Source: FeeSourceEstimated},
+			wantOutput:    "995",
+			wantFee:       "0.05",
+			wantCRAfter:   2.000995,
+			wantNAVAfter:  0.01,
+			wantOutputSym: "xSOL",
+		},
+		{
+			name:          "buy with hyUSD burns stablecoin and raises collateral ratio",
+			request:       TradeSimulationRequest{Side: "BUY", Amount: "1000", CounterAsset: "hyUSD"},
+			fees:          zeroFees(),
+			wantOutput:    "1000",
+			wantFee:       "0",
+			wantCRAfter:   20000.0 / 9990.0,
+			wantNAVAfter:  0.01,
+			wantOutputSym: "xSOL",
+		},
+		{
+			name:          "sell for SOL reduces reserve",
+			request:       TradeSimulationRequest{Side: "SELL", Amount: "1000", CounterAsset: "SOL"},
+			fees:          zeroFees(),
+			wantOutput:    "10",
+			wantFee:       "0",
+			wantCRAfter:   1.999,
+			wantNAVAfter:  0.01,
+			wantOutputSym: "SOL",
+		},
+		{
+			name:          "sell for hyUSD applies redeem fee",
+			request:       TradeSimulationRequest{Side: "SELL", Amount: "1000", CounterAsset: "hyUSD"},
+			fees:          &ExchangeFees{MintFeeRateBps: 0, RedeemFeeRateBps: 100, Source: FeeSourceEstimated},
+			wantOutput:    "990",
+			wantFee:       "10",
+			wantCRAfter:   20000.0 / 10010.0,
+			wantNAVAfter:  0.01,
+			wantOutputSym: "hyUSD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newSimulationState(t)
+			originalSupply := state.XSOLSupply
+
+			result, err := SimulateTrade(state, tt.fees, &tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.OutputAmount != tt.wantOutput {
+				t.Errorf("Expected output %s, got %s", tt.wantOutput, result.OutputAmount)
+			}
+			if result.OutputAsset != tt.wantOutputSym {
+				t.Errorf("Expected output asset %s, got %s", tt.wantOutputSym, result.OutputAsset)
+			}
+			if result.FeeAmount != tt.wantFee {
+				t.Errorf("Expected fee %s, got %s", tt.wantFee, result.FeeAmount)
+			}
+			if !almostEqual(result.CollateralRatioAfter, tt.wantCRAfter) {
+				t.Errorf("Expected collateral ratio %f, got %f", tt.wantCRAfter, result.CollateralRatioAfter)
+			}
+			if !almostEqual(result.XSOLNAVAfter, tt.wantNAVAfter) {
+				t.Errorf("Expected xSOL NAV %f, got %f", tt.wantNAVAfter, result.XSOLNAVAfter)
+			}
+			if state.XSOLSupply != originalSupply {
+				t.Errorf("SimulateTrade must not mutate the input state")
+			}
+		})
+	}
+}
+
+func TestSimulateTradePriceImpact(t *testing.T) {
+	state := newSimulationState(t)
+
+	// Minting at NAV without fees leaves NAV unchanged; redeeming the whole supply is rejected
+	result, err := SimulateTrade(state, zeroFees(), &TradeSimulationRequest{Side: "BUY", Amount: "100", CounterAsset: "SOL"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !almostEqual(result.PriceImpactPct, 0) {
+		t.Errorf("Expected zero price impact, got %f", result.PriceImpactPct)
+	}
+
+	_, err = SimulateTrade(state, zeroFees(), &TradeSimulationRequest{Side: "SELL", Amount: "1000000", CounterAsset: "SOL"})
+	if err == nil {
+		t.Errorf("Expected error when redeeming the entire xSOL supply")
+	}
+}
+
+func TestTradeSimulationRequestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   TradeSimulationRequest
+		wantError string
+	}{
+		{name: "valid buy", request: TradeSimulationRequest{Side: "buy", Amount: "1.5", CounterAsset: "sol"}},
+		{name: "valid sell", request: TradeSimulationRequest{Side: "SELL", Amount: "2", CounterAsset: "HYUSD"}},
+		{name: "invalid side", request: TradeSimulationRequest{Side: "HOLD", Amount: "1", CounterAsset: "SOL"}, wantError: "invalid side"},
+		{name: "invalid counter asset", request: TradeSimulationRequest{Side: "BUY", Amount: "1", CounterAsset: "USDC"}, wantError: "invalid counter_asset"},
+		{name: "zero amount", request: TradeSimulationRequest{Side: "BUY", Amount: "0", CounterAsset: "SOL"}, wantError: "greater than zero"},
+		{name: "too many decimals for xSOL", request: TradeSimulationRequest{Side: "SELL", Amount: "1.0000001", CounterAsset: "SOL"}, wantError: "invalid amount"},
+		{name: "malformed amount", request: TradeSimulationRequest{Side: "BUY", Amount: "abc", CounterAsset: "SOL"}, wantError: "invalid amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.Normalize()
+			err := tt.request.Validate()
+
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestParseHyloExchangeStateFees(t *testing.T) {
+	data := make([]byte, 8+1+32+8+4)
+	offset := 8 + 1 + 32
+	binary.LittleEndian.PutUint64(data[offset:], 5_000_000_000)
+	binary.LittleEndian.PutUint16(data[offset+8:], 30)
+	binary.LittleEndian.PutUint16(data[offset+10:], 45)
+
+	state, err := ParseHyloExchangeState(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.MintFeeRateBps != 30 || state.RedeemFeeRateBps != 45 {
+		t.Errorf("Expected fees 30/45, got %d/%d", state.MintFeeRateBps, state.RedeemFeeRateBps)
+	}
+}
@@ -0,0 +1,103 @@
+package hylo
+
+import (
+	"regexp"
+	"strings"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// Trade sources reported in XSOLTrade.Source
+const (
+	TradeSourceHyloApp    = "hylo_app"   // Hylo exchange called as a top-level instruction, as the Hylo app and SDK build it
+	TradeSourceAggregator = "aggregator" // Routed through a known aggregator such as Jupiter
+	TradeSourceDirectCPI  = "direct_cpi" // Another program invoked the Hylo exchange through CPI
+	TradeSourceUnknown    = "unknown"    // No Hylo or router program was observed in the transaction
+)
+
+// Known aggregator router programs, keyed by program ID, with the name reported in XSOLTrade.SourceProgram
+var aggregatorPrograms = map[string]string{
+	"JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4":  "jupiter", // Jupiter Aggregator v6
+	"JUP4Fb2cqiRUcaTHdrPC8h2gNsA2ETXiEDu8C6ZkT8Kk": "jupiter", // Jupiter Aggregator v4
+}
+
+// aggregatorMemoTags attribute CPI trades to an aggregator when its frontend tags the transaction memo
+var aggregatorMemoTags = map[string]string{
+	"jupiter": "jupiter",
+	"jup.ag":  "jupiter",
+}
+
+// programInvokeLog matches "Program <id> invoke [<depth>]" runtime log lines
+var programInvokeLog = regexp.MustCompile(`^Program (\w+) invoke \[(\d+)\]$`)
+
+// memoLogPrefix starts the log line the SPL Memo program writes for each memo
+const memoLogPrefix = "Program log: Memo (len "
+
+// applySource attributes the trade to the Hylo app, an aggregator or another program's CPI
+func applySource(trade *XSOLTrade, tx *solana.TransactionDetails) {
+	trade.Source, trade.SourceProgram = attributeSource(tx)
+}
+
+// attributeSource classifies how a transaction reached the Hylo exchange
+// Top-level aggregator routers take precedence because they call Hylo through CPI themselves;
+// otherwise a top-level Hylo instruction is an app trade and a Hylo invocation found only at a nested
+// depth in the runtime logs is a CPI from the program that was executing at depth 1
+func attributeSource(tx *solana.TransactionDetails) (source, program string) {
+	accountKeys := tx.Transaction.Message.AccountKeys
+	hyloTopLevel := false
+	for _, instruction := range tx.Transaction.Message.Instructions {
+		if int(instruction.ProgramIdIndex) >= len(accountKeys) {
+			continue
+		}
+		programID := accountKeys[instruction.ProgramIdIndex]
+		if name, ok := aggregatorPrograms[programID]; ok {
+			return TradeSourceAggregator, name
+		}
+		if IsHyloProgram(solana.Address(programID)) {
+			hyloTopLevel = true
+		}
+	}
+	if hyloTopLevel {
+		return TradeSourceHyloApp, ""
+	}
+
+	if tx.Meta == nil {
+		return TradeSourceUnknown, ""
+	}
+
+	caller := ""
+	for _, message := range tx.Meta.LogMessages {
+		match := programInvokeLog.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		if match[2] == "1" {
+			caller = match[1]
+			continue
+		}
+		if IsHyloProgram(solana.Address(match[1])) {
+			if name := memoAggregator(tx.Meta.LogMessages); name != "" {
+				return TradeSourceAggregator, name
+			}
+			return TradeSourceDirectCPI, caller
+		}
+	}
+
+	return TradeSourceUnknown, ""
+}
+
+// memoAggregator returns the aggregator named by a transaction memo, or "" when none is tagged
+func memoAggregator(logMessages []string) string {
+	for _, message := range logMessages {
+		if !strings.HasPrefix(message, memoLogPrefix) {
+			continue
+		}
+		memo := strings.ToLower(message[len(memoLogPrefix):])
+		for tag, name := range aggregatorMemoTags {
+			if strings.Contains(memo, tag) {
+				return name
+			}
+		}
+	}
+	return ""
+}
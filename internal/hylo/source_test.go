@@ -0,0 +1,72 @@
+package hylo
+
+import (
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+func TestAttributeSource(t *testing.T) {
+	const (
+		jupiter     = "JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4"
+		vault       = "Vau1tProgram11111111111111111111111111111111"
+		computeBdgt = "ComputeBudget111111111111111111111111111111"
+	)
+
+	txWith := func(topLevel []string, logs ...string) *solana.TransactionDetails {
+		tx := &solana.TransactionDetails{Meta: &solana.TxMeta{LogMessages: logs}}
+		for i, program := range topLevel {
+			tx.Transaction.Message.AccountKeys = append(tx.Transaction.Message.AccountKeys, program)
+			tx.Transaction.Message.Instructions = append(tx.Transaction.Message.Instructions, solana.TxInstruction{ProgramIdIndex: uint8(i)})
+		}
+		return tx
+	}
+
+	tests := []struct {
+		name            string
+		tx              *solana.TransactionDetails
+		expectedSource  string
+		expectedProgram string
+	}{
+		{
+			name:           "hylo app",
+			tx:             txWith([]string{computeBdgt, ExchangeProgramID}),
+			expectedSource: TradeSourceHyloApp,
+		},
+		{
+			name: "jupiter route",
+			tx: txWith([]string{computeBdgt, jupiter},
+				"Program "+jupiter+" invoke [1]", "Program "+ExchangeProgramID+" invoke [2]"),
+			expectedSource:  TradeSourceAggregator,
+			expectedProgram: "jupiter",
+		},
+		{
+			name: "direct cpi",
+			tx: txWith([]string{vault},
+				"Program "+vault+" invoke [1]", "Program "+ExchangeProgramID+" invoke [2]", "Program "+ExchangeProgramID+" success"),
+			expectedSource:  TradeSourceDirectCPI,
+			expectedProgram: vault,
+		},
+		{
+			name: "cpi tagged by aggregator memo",
+			tx: txWith([]string{vault},
+				`Program log: Memo (len 6): "jup.ag"`, "Program "+vault+" invoke [1]", "Program "+ExchangeProgramID+" invoke [2]"),
+			expectedSource:  TradeSourceAggregator,
+			expectedProgram: "jupiter",
+		},
+		{
+			name:           "no hylo program",
+			tx:             txWith([]string{vault}, "Program "+vault+" invoke [1]"),
+			expectedSource: TradeSourceUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, program := attributeSource(tt.tx)
+			if source != tt.expectedSource || program != tt.expectedProgram {
+				t.Errorf("Expected %s/%q, got %s/%q", tt.expectedSource, tt.expectedProgram, source, program)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package hylo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Peg sources: where a stablecoin's USD value is read from when it prices an xSOL trade
+const (
+	PegSourceFixed   = "fixed"   // Always worth its configured peg
+	PegSourceMonitor = "monitor" // The peg monitor's market price sampled nearest the trade, else the configured peg; hyUSD only
+)
+
+// Default bounds outside which a stablecoin-derived historical xSOL price is treated as a parsing artifact
+const (
+	DefaultMinHistoricalPriceUSD = 1.0
+	DefaultMaxHistoricalPriceUSD = 10000.0
+)
+
+// EnvStablecoinsFile names a JSON file replacing the default stablecoin pegs
+const EnvStablecoinsFile = "HYLO_STABLECOINS_FILE"
+
+// StablecoinPeg describes a USD-pegged counter asset whose trades yield a historical xSOL price
+// Min and max bound the xSOL price its trades may imply; 0 uses the package defaults
+type StablecoinPeg struct {
+	Symbol          string  `json:"symbol"`
+	Decimals        uint8   `json:"decimals"`
+	PegUSD          float64 `json:"peg_usd"`
+	Source          string  `json:"source"`
+	MinXSOLPriceUSD float64 `json:"min_xsol_price_usd,omitempty"`
+	MaxXSOLPriceUSD float64 `json:"max_xsol_price_usd,omitempty"`
+}
+
+// PegHistory reports a stablecoin's sampled market price near a time; implemented by peg.Monitor
+type PegHistory interface {
+	PriceAt(symbol string, at time.Time) (float64, bool)
+}
+
+// Stablecoins is the set of USD-pegged counter assets, keyed by counter-asset symbol
+type Stablecoins struct {
+	Pegs []StablecoinPeg `json:"stablecoins"`
+
+	bySymbol map[string]StablecoinPeg
+}
+
+// DefaultStablecoins returns hyUSD, USDC and PYUSD held at $1
+func DefaultStablecoins() *Stablecoins {
+	stablecoins := &Stablecoins{
+		Pegs: []StablecoinPeg{
+			{Symbol: tokens.HyUSDSymbol, Decimals: tokens.HyUSDDecimals, PegUSD: 1, Source: PegSourceFixed},
+			{Symbol: tokens.USDCSymbol, Decimals: tokens.USDCDecimals, PegUSD: 1, Source: PegSourceFixed},
+			{Symbol: tokens.PYUSDSymbol, Decimals: tokens.PYUSDDecimals, PegUSD: 1, Source: PegSourceFixed},
+		},
+	}
+	stablecoins.buildIndex()
+	return stablecoins
+}
+
+// LoadStablecoinsFromEnvironment reads the pegs from HYLO_STABLECOINS_FILE, or returns the defaults when it is unset
+func LoadStablecoinsFromEnvironment() (*Stablecoins, error) {
+	path := strings.TrimSpace(os.Getenv(EnvStablecoinsFile))
+	if path == "" {
+		return DefaultStablecoins(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stablecoin pegs: %w", err)
+	}
+	return ParseStablecoins(data)
+}
+
+// ParseStablecoins decodes and validates JSON stablecoin pegs
+func ParseStablecoins(data []byte) (*Stablecoins, error) {
+	var stablecoins Stablecoins
+	if err := json.Unmarshal(data, &stablecoins); err != nil {
+		return nil, fmt.Errorf("invalid stablecoin pegs: %w", err)
+	}
+	if err := stablecoins.Validate(); err != nil {
+		return nil, err
+	}
+	stablecoins.buildIndex()
+	return &stablecoins, nil
+}
+
+// Validate checks for missing or duplicate symbols, non-positive pegs, unknown sources and inverted bounds
+func (s *Stablecoins) Validate() error {
+	symbols := make(map[string]bool, len(s.Pegs))
+	for _, peg := range s.Pegs {
+		if peg.Symbol == "" {
+			return fmt.Errorf("invalid stablecoin pegs: symbol is required")
+		}
+		if symbols[peg.Symbol] {
+			return fmt.Errorf("invalid stablecoin pegs: duplicate symbol %s", peg.Symbol)
+		}
+		symbols[peg.Symbol] = true
+
+		if peg.Decimals == 0 || peg.Decimals > 18 {
+			return fmt.Errorf("invalid stablecoin pegs: decimals for %s must be between 1 and 18", peg.Symbol)
+		}
+		if math.IsNaN(peg.PegUSD) || peg.PegUSD <= 0 {
+			return fmt.Errorf("invalid stablecoin pegs: peg_usd for %s must be positive", peg.Symbol)
+		}
+		switch peg.Source {
+		case PegSourceFixed:
+		case PegSourceMonitor:
+			if peg.Symbol != tokens.HyUSDSymbol {
+				return fmt.Errorf("invalid stablecoin pegs: the %s source only samples %s", PegSourceMonitor, tokens.HyUSDSymbol)
+			}
+		default:
+			return fmt.Errorf("invalid stablecoin pegs: source for %s must be %s or %s", peg.Symbol, PegSourceFixed, PegSourceMonitor)
+		}
+		if peg.MinXSOLPriceUSD < 0 || peg.MaxXSOLPriceUSD < 0 {
+			return fmt.Errorf("invalid stablecoin pegs: xSOL price bounds for %s cannot be negative", peg.Symbol)
+		}
+		if lo, hi := peg.bounds(); lo >= hi {
+			return fmt.Errorf("invalid stablecoin pegs: min_xsol_price_usd for %s must be below max_xsol_price_usd", peg.Symbol)
+		}
+	}
+	return nil
+}
+
+func (s *Stablecoins) buildIndex() {
+	s.bySymbol = make(map[string]StablecoinPeg, len(s.Pegs))
+	for _, peg := range s.Pegs {
+		s.bySymbol[peg.Symbol] = peg
+	}
+}
+
+// Lookup returns the peg of a counter-asset symbol; false when the asset is not a configured stablecoin
+func (s *Stablecoins) Lookup(symbol string) (StablecoinPeg, bool) {
+	peg, ok := s.bySymbol[symbol]
+	return peg, ok
+}
+
+// PriceAt returns the USD value of one unit of the stablecoin at a trade's time
+// Monitored pegs fall back to the configured peg without a sample near at
+func (p StablecoinPeg) PriceAt(at time.Time) float64 {
+	if p.Source == PegSourceMonitor && !at.IsZero() {
+		if history := activePegHistory.Load(); history != nil {
+			if sampled, ok := (*history).PriceAt(p.Symbol, at); ok && sampled > 0 {
+				return sampled
+			}
+		}
+	}
+	return p.PegUSD
+}
+
+// bounds returns the xSOL price range the stablecoin's trades may imply, with defaults applied
+func (p StablecoinPeg) bounds() (float64, float64) {
+	lo, hi := p.MinXSOLPriceUSD, p.MaxXSOLPriceUSD
+	if lo == 0 {
+		lo = DefaultMinHistoricalPriceUSD
+	}
+	if hi == 0 {
+		hi = DefaultMaxHistoricalPriceUSD
+	}
+	return lo, hi
+}
+
+// activeStablecoins holds the pegs used for historical prices; activePegHistory the source of monitored pegs
+var (
+	activeStablecoins atomic.Pointer[Stablecoins]
+	activePegHistory  atomic.Pointer[PegHistory]
+)
+
+func init() {
+	activeStablecoins.Store(DefaultStablecoins())
+}
+
+// SetStablecoins replaces the pegs used for historical prices; nil restores the defaults
+func SetStablecoins(stablecoins *Stablecoins) {
+	if stablecoins == nil {
+		stablecoins = DefaultStablecoins()
+	}
+	activeStablecoins.Store(stablecoins)
+}
+
+// GetStablecoins returns the pegs currently used for historical prices
+func GetStablecoins() *Stablecoins {
+	return activeStablecoins.Load()
+}
+
+// SetPegHistory sets where monitored pegs are sampled from; nil values them at their configured peg
+func SetPegHistory(history PegHistory) {
+	if history == nil {
+		activePegHistory.Store(nil)
+		return
+	}
+	activePegHistory.Store(&history)
+}
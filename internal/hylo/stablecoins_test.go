@@ -0,0 +1,124 @@
+package hylo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+type stubPegHistory map[string]float64
+
+func (h stubPegHistory) PriceAt(symbol string, at time.Time) (float64, bool) {
+	price, ok := h[symbol]
+	return price, ok
+}
+
+func TestLoadStablecoinsFromEnvironment(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv(EnvStablecoinsFile, "")
+		stablecoins, err := LoadStablecoinsFromEnvironment()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, symbol := range []string{tokens.HyUSDSymbol, tokens.USDCSymbol, tokens.PYUSDSymbol} {
+			if peg, ok := stablecoins.Lookup(symbol); !ok || peg.PegUSD != 1 || peg.Source != PegSourceFixed {
+				t.Errorf("expected %s fixed at $1, got %+v (%v)", symbol, peg, ok)
+			}
+		}
+		if _, ok := stablecoins.Lookup(tokens.SOLSymbol); ok {
+			t.Error("expected SOL not to be a stablecoin")
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "stablecoins.json")
+		data := `{"stablecoins":[{"symbol":"hyUSD","decimals":6,"peg_usd":1,"source":"monitor"},{"symbol":"USDT","decimals":6,"peg_usd":0.999,"source":"fixed","max_xsol_price_usd":500}]}`
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(EnvStablecoinsFile, path)
+
+		stablecoins, err := LoadStablecoinsFromEnvironment()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if peg, ok := stablecoins.Lookup("USDT"); !ok || peg.PegUSD != 0.999 || peg.MaxXSOLPriceUSD != 500 {
+			t.Errorf("expected USDT from the file, got %+v", peg)
+		}
+		if _, ok := stablecoins.Lookup(tokens.USDCSymbol); ok {
+			t.Error("expected the file to replace the defaults")
+		}
+	})
+}
+
+func TestParseStablecoinsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing symbol":        `{"stablecoins":[{"decimals":6,"peg_usd":1,"source":"fixed"}]}`,
+		"duplicate symbol":      `{"stablecoins":[{"symbol":"USDC","decimals":6,"peg_usd":1,"source":"fixed"},{"symbol":"USDC","decimals":6,"peg_usd":1,"source":"fixed"}]}`,
+		"missing decimals":      `{"stablecoins":[{"symbol":"USDC","peg_usd":1,"source":"fixed"}]}`,
+		"non-positive peg":      `{"stablecoins":[{"symbol":"USDC","decimals":6,"peg_usd":0,"source":"fixed"}]}`,
+		"unknown source":        `{"stablecoins":[{"symbol":"USDC","decimals":6,"peg_usd":1,"source":"oracle"}]}`,
+		"unmonitored symbol":    `{"stablecoins":[{"symbol":"USDC","decimals":6,"peg_usd":1,"source":"monitor"}]}`,
+		"inverted bounds":       `{"stablecoins":[{"symbol":"USDC","decimals":6,"peg_usd":1,"source":"fixed","min_xsol_price_usd":20,"max_xsol_price_usd":10}]}`,
+		"min above default max": `{"stablecoins":[{"symbol":"USDC","decimals":6,"peg_usd":1,"source":"fixed","min_xsol_price_usd":20000}]}`,
+		"malformed":             `{"stablecoins":`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseStablecoins([]byte(data)); err == nil || !strings.Contains(err.Error(), "invalid stablecoin pegs") {
+				t.Errorf("expected an invalid stablecoin pegs error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCalculateHistoricalXSOLPriceWithPegs(t *testing.T) {
+	stablecoins, err := ParseStablecoins([]byte(`{"stablecoins":[
+		{"symbol":"hyUSD","decimals":6,"peg_usd":1,"source":"monitor"},
+		{"symbol":"USDT","decimals":6,"peg_usd":0.998,"source":"fixed","min_xsol_price_usd":100,"max_xsol_price_usd":300}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	SetStablecoins(stablecoins)
+	defer SetStablecoins(nil)
+
+	trade := func(asset, counter string) *XSOLTrade {
+		trade := NewXSOLTrade("sig", 1, 1757360080)
+		trade.XSOLAmount, trade.CounterAmount, trade.CounterAsset = "2", counter, asset
+		return trade
+	}
+
+	// Fixed pegs scale the stablecoin amount and apply the asset's bounds
+	if price := CalculateHistoricalXSOLPrice(trade("USDT", "500")); price == nil || *price != "249.500" {
+		t.Errorf("expected 500 USDT at $0.998 for 2 xSOL to be $249.500, got %v", price)
+	}
+	if price := CalculateHistoricalXSOLPrice(trade("USDT", "1000")); price != nil {
+		t.Errorf("expected a price above the USDT bound to be dropped, got %s", *price)
+	}
+	if price := CalculateHistoricalXSOLPrice(trade(tokens.USDCSymbol, "500")); price != nil {
+		t.Errorf("expected USDC to be unpriced once it is not configured, got %s", *price)
+	}
+
+	// Monitored pegs use the sampled price, and the configured peg without a sample
+	if price := CalculateHistoricalXSOLPrice(trade(tokens.HyUSDSymbol, "500")); price == nil || *price != "250.000" {
+		t.Errorf("expected hyUSD at its $1 peg without a peg history, got %v", price)
+	}
+	SetPegHistory(stubPegHistory{tokens.HyUSDSymbol: 0.99})
+	defer SetPegHistory(nil)
+	if price := CalculateHistoricalXSOLPrice(trade(tokens.HyUSDSymbol, "500")); price == nil || *price != "247.500" {
+		t.Errorf("expected hyUSD at its sampled $0.99, got %v", price)
+	}
+}
+
+func TestFormatCounterAmountStablecoin(t *testing.T) {
+	trade := NewXSOLTrade("sig", 1, 1)
+	trade.SetTradeDetails(TradeSideBuy, 1000000, 2500000, tokens.PYUSDSymbol)
+	if trade.CounterAmount != "2.5" || !trade.IsValidTrade() {
+		t.Errorf("expected PYUSD formatted with its decimals and valid, got %q (valid %v)", trade.CounterAmount, trade.IsValidTrade())
+	}
+}
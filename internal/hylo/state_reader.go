@@ -11,12 +11,12 @@ import (
 
 // StateReader provides functionality to read Hylo protocol state from the Solana blockchain
 type StateReader struct {
-	solanaClient *solana.HTTPClient
+	solanaClient solana.ChainClient
 	config       *Config
 }
 
-// NewStateReader creates a new StateReader with the provided Solana HTTP client
-func NewStateReader(solanaClient *solana.HTTPClient, config *Config) *StateReader {
+// NewStateReader creates a new StateReader reading through the provided chain client
+func NewStateReader(solanaClient solana.ChainClient, config *Config) *StateReader {
 	if config == nil {
 		config = NewConfig() // Use default config if none provided
 	}
@@ -165,8 +165,13 @@ func (r *StateReader) readActualSOLReserveFallback(ctx context.Context, hyusdSup
 }
 
 // calculateDerivedMetrics calculates NAVs, collateral ratio, and effective leverage
-// Uses the Hylo equations documented in docs/01-hylo-documentation.md
 func (r *StateReader) calculateDerivedMetrics(state *HyloProtocolState) error {
+	return computeDerivedMetrics(state)
+}
+
+// computeDerivedMetrics calculates NAVs, collateral ratio, and effective leverage
+// Uses the Hylo equations documented in docs/01-hylo-documentation.md
+func computeDerivedMetrics(state *HyloProtocolState) error {
 	// Calculate hyUSD NAV in SOL
 	// Formula: hyUSD_NAV_in_SOL = 1 / SOL_Price_USD
 	state.HyUSDNAVInSOL = 1.0 / state.SOLPriceUSD
@@ -209,6 +214,62 @@ func (r *StateReader) calculateDerivedMetrics(state *HyloProtocolState) error {
 	return nil
 }
 
+// ReadExchangeFees reads mint/redeem fee rates from the Hylo exchange state account
+// The rates sit at offsets inferred without the program IDL, so they are reported as estimated;
+// falls back to default fee rates when the account cannot be read or parsed
+func (r *StateReader) ReadExchangeFees(ctx context.Context) *ExchangeFees {
+	hyloStateAddress := GetHyloStateAddress(r.config.GetExchangeProgramID())
+
+	account, err := r.solanaClient.GetAccount(ctx, hyloStateAddress, solana.CommitmentFinalized)
+	if err != nil {
+		return DefaultExchangeFees()
+	}
+
+	exchangeState, err := ParseHyloExchangeState(account.Data)
+	if err != nil || (exchangeState.MintFeeRateBps == 0 && exchangeState.RedeemFeeRateBps == 0) {
+		return DefaultExchangeFees()
+	}
+
+	return &ExchangeFees{
+		MintFeeRateBps:   exchangeState.MintFeeRateBps,
+		RedeemFeeRateBps: exchangeState.RedeemFeeRateBps,
+		Source:           FeeSourceEstimated,
+	}
+}
+
+// ReadStabilityPool reads stability pool balances for sHYUSD share accounting
+// Uses the sHYUSD mint supply when the pool account does not report it
+func (r *StateReader) ReadStabilityPool(ctx context.Context) (*tokens.StabilityPoolSnapshot, error) {
+	poolAddress := GetStabilityPoolConfigAddress(r.config.GetStabilityPoolProgramID())
+
+	account, err := r.solanaClient.GetAccount(ctx, poolAddress, solana.CommitmentFinalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stability pool account %s: %w", poolAddress, err)
+	}
+
+	poolState, err := ParseHyloStabilityPoolState(account.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stability pool state: %w", err)
+	}
+
+	snapshot := &tokens.StabilityPoolSnapshot{
+		HyUSDBalance: poolState.HyUSDPoolBalance,
+		XSOLBalance:  poolState.XSOLPoolBalance,
+		SHyUSDSupply: poolState.SHyUSDSupply,
+		Source:       tokens.PoolSourceEstimated,
+	}
+
+	if snapshot.SHyUSDSupply == 0 {
+		mintInfo, err := r.readTokenMintInfo(ctx, tokens.SHyUSDMint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sHYUSD mint info: %w", err)
+		}
+		snapshot.SHyUSDSupply = mintInfo.Supply
+	}
+
+	return snapshot, nil
+}
+
 // ReadTokenSupplies reads just the token supplies for both hyUSD and xSOL
 // This is a lighter weight operation compared to ReadProtocolState
 func (r *StateReader) ReadTokenSupplies(ctx context.Context) (hyusdSupply, xsolSupply uint64, err error) {
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	solanago "github.com/gagliardetto/solana-go"
+
 	"hylo-wallet-tracker-api/internal/solana"
 	"hylo-wallet-tracker-api/internal/tokens"
 	"hylo-wallet-tracker-api/internal/utils"
@@ -82,7 +84,7 @@ func ParseSPLTokenMintData(data []byte) (*SPLTokenInfo, error) {
 	offset += 4
 
 	if hasAuthority {
-		authority := solana.Address(data[offset : offset+32])
+		authority := solana.Address(solanago.PublicKeyFromBytes(data[offset : offset+32]).String())
 		info.MintAuthority = &authority
 	}
 	offset += 32
@@ -104,7 +106,7 @@ func ParseSPLTokenMintData(data []byte) (*SPLTokenInfo, error) {
 	offset += 4
 
 	if hasFreezeAuthority {
-		freezeAuthority := solana.Address(data[offset : offset+32])
+		freezeAuthority := solana.Address(solanago.PublicKeyFromBytes(data[offset : offset+32]).String())
 		info.FreezeAuthority = &freezeAuthority
 	}
 
@@ -332,6 +334,18 @@ func ParseHyloExchangeState(data []byte) (*HyloExchangeState, error) {
 
 		if possibleReserve >= minReasonableReserve && possibleReserve <= maxReasonableReserve {
 			state.TotalSOLReserve = possibleReserve
+			offset += 8
+
+			// Fee rates are assumed to follow the reserve as two u16 basis point values; the layout is not
+			// confirmed by the program IDL, so readers label them FeeSourceEstimated
+			if len(data) >= offset+4 {
+				mintFee := binary.LittleEndian.Uint16(data[offset : offset+2])
+				redeemFee := binary.LittleEndian.Uint16(data[offset+2 : offset+4])
+				if mintFee <= MaxReasonableFeeRateBps && redeemFee <= MaxReasonableFeeRateBps {
+					state.MintFeeRateBps = mintFee
+					state.RedeemFeeRateBps = redeemFee
+				}
+			}
 			return state, nil
 		}
 	}
@@ -369,9 +383,21 @@ func ParseHyloStabilityPoolState(data []byte) (*HyloStabilityPoolState, error) {
 	// For now, we'll skip parsing the authority as Address is a string type
 	// TODO: Convert bytes to base58 address string
 	state.Authority = solana.Address("") // Placeholder
+	offset += 32
+
+	// Pool balances and sHYUSD supply follow the authority as three u64 values
+	if len(data) < offset+24 {
+		return nil, fmt.Errorf("insufficient data for pool balance fields")
+	}
+	state.HyUSDPoolBalance = binary.LittleEndian.Uint64(data[offset : offset+8])
+	state.XSOLPoolBalance = binary.LittleEndian.Uint64(data[offset+8 : offset+16])
+	state.SHyUSDSupply = binary.LittleEndian.Uint64(data[offset+16 : offset+24])
+	offset += 24
+
+	if len(data) >= offset+2 {
+		state.YieldDistributionRateBps = binary.LittleEndian.Uint16(data[offset : offset+2])
+	}
 
-	// For stability pool, we don't need to parse the exact structure for SOL reserve calculation
-	// The main exchange state contains the Total SOL Reserve we need
 	return state, nil
 }
 
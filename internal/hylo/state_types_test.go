@@ -0,0 +1,53 @@
+package hylo
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestParseHyloStabilityPoolState(t *testing.T) {
+	offset := 8 + 1 + 32
+	data := make([]byte, offset+24+2)
+	binary.LittleEndian.PutUint64(data[offset:], 1_000_000_000)
+	binary.LittleEndian.PutUint64(data[offset+8:], 40_000_000)
+	binary.LittleEndian.PutUint64(data[offset+16:], 10_000_000)
+	binary.LittleEndian.PutUint16(data[offset+24:], 75)
+
+	state, err := ParseHyloStabilityPoolState(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state.HyUSDPoolBalance != 1_000_000_000 || state.XSOLPoolBalance != 40_000_000 || state.SHyUSDSupply != 10_000_000 {
+		t.Errorf("unexpected pool balances: %+v", state)
+	}
+	if state.YieldDistributionRateBps != 75 {
+		t.Errorf("Expected yield rate 75, got %d", state.YieldDistributionRateBps)
+	}
+
+	if _, err := ParseHyloStabilityPoolState(data[:offset+8]); err == nil {
+		t.Error("Expected error for truncated pool balances")
+	}
+}
+
+func TestParseSPLTokenMintData_Authorities(t *testing.T) {
+	data := make([]byte, 82)
+	binary.LittleEndian.PutUint32(data[0:], 1)
+	data[4] = 1 // Mint authority pubkey 0x01 followed by zeros
+	binary.LittleEndian.PutUint64(data[36:], 5_000_000)
+	data[44] = 6
+	data[45] = 1
+
+	info, err := ParseSPLTokenMintData(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.MintAuthority == nil || info.MintAuthority.Validate() != nil {
+		t.Errorf("Expected base58 mint authority, got %v", info.MintAuthority)
+	}
+	if info.FreezeAuthority != nil {
+		t.Errorf("Expected no freeze authority, got %v", *info.FreezeAuthority)
+	}
+	if info.Supply != 5_000_000 || info.Decimals != 6 || !info.IsInitialized {
+		t.Errorf("unexpected mint info: %+v", info)
+	}
+}
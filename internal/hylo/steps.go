@@ -0,0 +1,210 @@
+package hylo
+
+import (
+	"sort"
+	"strings"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Stability pool instructions that can share a transaction with an xSOL trade
+const (
+	StabilityPoolDepositInstruction  = "user_deposit"  // Stake hyUSD for sHYUSD
+	StabilityPoolWithdrawInstruction = "user_withdraw" // Unstake sHYUSD for hyUSD
+)
+
+// TradeStep is one leg of a transaction that composes several Hylo instructions into one logical trade,
+// such as unstaking sHYUSD and selling xSOL for hyUSD in the same transaction
+type TradeStep struct {
+	Instruction  string `json:"instruction"` // mint_levercoin, redeem_levercoin, user_deposit or user_withdraw
+	Program      string `json:"program"`     // Program ID that executed the step
+	InputAsset   string `json:"inputAsset"`
+	InputAmount  string `json:"inputAmount"`
+	OutputAsset  string `json:"outputAsset"`
+	OutputAmount string `json:"outputAmount"`
+}
+
+// stabilityPoolLeg is the wallet's movement through the stability pool within a trade transaction
+type stabilityPoolLeg struct {
+	instruction string
+	shyusd      uint64 // sHYUSD minted to or burned from the wallet
+	hyusd       uint64 // hyUSD the pool vault took in or paid out
+}
+
+// applyStabilityPoolSteps splits a trade transaction that also deposits to or withdraws from the stability pool
+// Without this, hyUSD paid out by an unstake is counted as xSOL sale proceeds and a staked or unstaked
+// sHYUSD balance can be picked as the counter asset. The pool leg is removed from the wallet's flows before
+// the counter asset is chosen again, and both legs are listed in execution order in trade.Steps
+func applyStabilityPoolSteps(trade *XSOLTrade, tx *solana.TransactionDetails, xsolIndex int, config *Config) {
+	if trade.Side != TradeSideBuy && trade.Side != TradeSideSell {
+		return
+	}
+	owner := tokenAccountOwner(
+		findTokenBalance(tx.Meta.PreTokenBalances, uint32(xsolIndex)),
+		findTokenBalance(tx.Meta.PostTokenBalances, uint32(xsolIndex)))
+	if owner == "" {
+		return
+	}
+	leg := detectStabilityPoolLeg(tx, owner, config)
+	if leg == nil {
+		return
+	}
+
+	counterAmount, counterAsset := exchangeLegCounter(tx, xsolIndex, trade.Side, owner, leg)
+	trade.SetTradeDetails(trade.Side, trade.XSOLAmountRaw, counterAmount, counterAsset)
+	trade.Steps = composeSteps(tx, trade, leg, config)
+}
+
+// detectStabilityPoolLeg finds a stability pool deposit or withdrawal by the wallet, or nil when there is none
+// The instruction is inferred from the wallet's sHYUSD balance: minted on deposit, burned on withdrawal.
+// The hyUSD side is the largest change of a hyUSD account the wallet does not own in the matching direction:
+// the pool vault transfers hyUSD, while the exchange mints and burns it and only credits a small fee vault
+func detectStabilityPoolLeg(tx *solana.TransactionDetails, owner string, config *Config) *stabilityPoolLeg {
+	if !invokesProgram(tx, config.GetStabilityPoolProgramID()) {
+		return nil
+	}
+
+	deltas := tokenAccountDeltas(tx)
+	var shyusd int64
+	for _, delta := range deltas {
+		if delta.owner == owner && delta.mint == tokens.SHyUSDMint.String() {
+			shyusd += delta.delta
+		}
+	}
+	if shyusd == 0 {
+		return nil
+	}
+
+	leg := &stabilityPoolLeg{instruction: StabilityPoolDepositInstruction, shyusd: uint64(abs64(shyusd))}
+	if shyusd < 0 {
+		leg.instruction = StabilityPoolWithdrawInstruction
+	}
+	for _, delta := range deltas {
+		if delta.owner == "" || delta.owner == owner || delta.mint != tokens.HyUSDMint.String() {
+			continue
+		}
+		// The vault gains hyUSD on deposit and pays it out on withdrawal
+		if (delta.delta > 0) != (leg.instruction == StabilityPoolDepositInstruction) {
+			continue
+		}
+		leg.hyusd = max(leg.hyusd, uint64(abs64(delta.delta)))
+	}
+	return leg
+}
+
+// exchangeLegCounter picks the counter asset of the exchange leg once the stability pool leg is taken out
+// sHYUSD only moves through the pool, and the hyUSD the pool paid or took is removed from the wallet's
+// hyUSD change; the remaining wallet token flows are matched the same way as a single-step trade
+func exchangeLegCounter(tx *solana.TransactionDetails, xsolIndex int, tradeSide, owner string, leg *stabilityPoolLeg) (uint64, string) {
+	flows := make(map[string]int64)
+	programs := make(map[string]string) // Token program per mint, when reported
+	for _, delta := range tokenAccountDeltas(tx) {
+		if delta.owner != owner || delta.mint == tokens.XSOLMint.String() || delta.mint == tokens.SHyUSDMint.String() {
+			continue
+		}
+		flows[delta.mint] += delta.delta
+		if delta.program != "" {
+			programs[delta.mint] = delta.program
+		}
+	}
+	switch leg.instruction {
+	case StabilityPoolWithdrawInstruction:
+		flows[tokens.HyUSDMint.String()] -= int64(leg.hyusd)
+	case StabilityPoolDepositInstruction:
+		flows[tokens.HyUSDMint.String()] += int64(leg.hyusd)
+	}
+
+	mints := make([]string, 0, len(flows))
+	for mint := range flows {
+		mints = append(mints, mint)
+	}
+	sort.Strings(mints)
+
+	rules := GetClassificationRules()
+	var counterAmount uint64
+	var counterAsset string
+	for _, mint := range mints {
+		flow := flows[mint]
+		if (tradeSide == TradeSideBuy && flow >= 0) || (tradeSide == TradeSideSell && flow <= 0) {
+			continue
+		}
+		candidate := detectTokenAssetType(mint, programs[mint])
+		if rules.UnknownToken.Ignore && rules.IsUnknownToken(candidate) {
+			continue
+		}
+		if change := uint64(abs64(flow)); counterAsset == "" || shouldReplaceCounterAsset(counterAsset, counterAmount, candidate, change) {
+			counterAmount, counterAsset = change, candidate
+		}
+	}
+
+	// Native SOL still wins on a larger raw change, as for single-step trades
+	if solChange, solAsset := nativeSOLChange(tx, xsolIndex, tradeSide); solChange > counterAmount {
+		counterAmount, counterAsset = solChange, solAsset
+	}
+	if counterAsset == "" {
+		counterAsset = tokens.SOLSymbol
+	}
+	return counterAmount, counterAsset
+}
+
+// composeSteps lists the pool and exchange legs in execution order
+// Order follows the runtime logs; without them an unstake is assumed to fund the trade and a deposit to follow it
+func composeSteps(tx *solana.TransactionDetails, trade *XSOLTrade, leg *stabilityPoolLeg, config *Config) []TradeStep {
+	exchange := TradeStep{Program: config.GetExchangeProgramID().String()}
+	xsolAmount := formatAmount(trade.XSOLAmountRaw, tokens.XSOLDecimals)
+	if trade.Side == TradeSideBuy {
+		exchange.Instruction = MintLeverCoinInstruction
+		exchange.InputAsset, exchange.InputAmount = trade.CounterAsset, trade.CounterAmount
+		exchange.OutputAsset, exchange.OutputAmount = tokens.XSOLSymbol, xsolAmount
+	} else {
+		exchange.Instruction = RedeemLeverCoinInstruction
+		exchange.InputAsset, exchange.InputAmount = tokens.XSOLSymbol, xsolAmount
+		exchange.OutputAsset, exchange.OutputAmount = trade.CounterAsset, trade.CounterAmount
+	}
+
+	pool := TradeStep{Instruction: leg.instruction, Program: config.GetStabilityPoolProgramID().String()}
+	shyusd := formatAmount(leg.shyusd, tokens.SHyUSDDecimals)
+	hyusd := formatAmount(leg.hyusd, tokens.HyUSDDecimals)
+	if leg.instruction == StabilityPoolWithdrawInstruction {
+		pool.InputAsset, pool.InputAmount = tokens.SHyUSDSymbol, shyusd
+		pool.OutputAsset, pool.OutputAmount = tokens.HyUSDSymbol, hyusd
+	} else {
+		pool.InputAsset, pool.InputAmount = tokens.HyUSDSymbol, hyusd
+		pool.OutputAsset, pool.OutputAmount = tokens.SHyUSDSymbol, shyusd
+	}
+
+	poolFirst := leg.instruction == StabilityPoolWithdrawInstruction
+	if poolAt, exchangeAt := firstInvocation(tx, config.GetStabilityPoolProgramID()), firstInvocation(tx, config.GetExchangeProgramID()); poolAt != -1 && exchangeAt != -1 {
+		poolFirst = poolAt < exchangeAt
+	}
+	if poolFirst {
+		return []TradeStep{pool, exchange}
+	}
+	return []TradeStep{exchange, pool}
+}
+
+// invokesProgram reports whether program runs in the transaction, as a top-level instruction or through CPI
+func invokesProgram(tx *solana.TransactionDetails, program solana.Address) bool {
+	accountKeys := tx.Transaction.Message.AccountKeys
+	for _, instruction := range tx.Transaction.Message.Instructions {
+		if int(instruction.ProgramIdIndex) < len(accountKeys) && solana.Address(accountKeys[instruction.ProgramIdIndex]) == program {
+			return true
+		}
+	}
+	return firstInvocation(tx, program) != -1
+}
+
+// firstInvocation returns the index of the first runtime log line invoking program, or -1
+func firstInvocation(tx *solana.TransactionDetails, program solana.Address) int {
+	if tx.Meta == nil {
+		return -1
+	}
+	prefix := "Program " + program.String() + " invoke ["
+	for i, message := range tx.Meta.LogMessages {
+		if strings.HasPrefix(message, prefix) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,134 @@
+package hylo
+
+import (
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// newPoolTradeTx builds a transaction where the wallet (transferSender) holds xSOL at index 1, hyUSD at 2 and
+// sHYUSD at 3; index 4 is the stability pool's hyUSD vault and 5 the exchange fee vault
+func newPoolTradeTx(pre, post [5]string, logs []string) *solana.TransactionDetails {
+	mints := []solana.Address{tokens.XSOLMint, tokens.HyUSDMint, tokens.SHyUSDMint, tokens.HyUSDMint, tokens.HyUSDMint}
+	owners := []string{transferSender, transferSender, transferSender, "poolAuthority", "hyloFeeAuthority"}
+	balances := func(amounts [5]string) []solana.TokenBalance {
+		out := make([]solana.TokenBalance, 0, len(amounts))
+		for i, amount := range amounts {
+			out = append(out, tokenBalance(uint32(i+1), mints[i], owners[i], amount))
+		}
+		return out
+	}
+
+	return &solana.TransactionDetails{
+		Slot: 365528389,
+		Meta: &solana.TxMeta{
+			Fee:               5000,
+			PreTokenBalances:  balances(pre),
+			PostTokenBalances: balances(post),
+			LogMessages:       logs,
+		},
+		Transaction: solana.Transaction{
+			Message: solana.TxMessage{
+				AccountKeys: []string{transferSender, "xsolATA", "hyusdATA", "shyusdATA", "poolVault", "feeVault", StabilityPoolProgramID, ExchangeProgramID},
+				Instructions: []solana.TxInstruction{
+					{ProgramIdIndex: 6},
+					{ProgramIdIndex: 7},
+				},
+			},
+			Signatures: []string{"poolTradeSig"},
+		},
+	}
+}
+
+func TestParseTransactionWithStabilityPoolSteps(t *testing.T) {
+	tests := []struct {
+		name          string
+		pre, post     [5]string
+		logs          []string
+		side          string
+		counterAmount string
+		steps         []TradeStep
+	}{
+		{
+			// Unstake 100 sHYUSD for 105 hyUSD, then sell 10 xSOL for 50 hyUSD: the wallet gains 155 hyUSD
+			name: "unstake then sell",
+			pre:  [5]string{"10000000", "0", "100000000", "1000000000", "0"},
+			post: [5]string{"0", "155000000", "0", "895000000", "250000"},
+			logs: []string{
+				"Program " + StabilityPoolProgramID + " invoke [1]",
+				"Program " + ExchangeProgramID + " invoke [1]",
+			},
+			side:          TradeSideSell,
+			counterAmount: "50",
+			steps: []TradeStep{
+				{Instruction: StabilityPoolWithdrawInstruction, Program: StabilityPoolProgramID, InputAsset: "sHYUSD", InputAmount: "100", OutputAsset: "hyUSD", OutputAmount: "105"},
+				{Instruction: RedeemLeverCoinInstruction, Program: ExchangeProgramID, InputAsset: "xSOL", InputAmount: "10", OutputAsset: "hyUSD", OutputAmount: "50"},
+			},
+		},
+		{
+			// Unstake 100 sHYUSD for 105 hyUSD and spend 60 of it on 12 xSOL: hyUSD rises, sHYUSD falls
+			name:          "unstake then buy",
+			pre:           [5]string{"0", "0", "100000000", "1000000000", "0"},
+			post:          [5]string{"12000000", "45000000", "0", "895000000", "300000"},
+			side:          TradeSideBuy,
+			counterAmount: "60",
+			steps: []TradeStep{
+				{Instruction: StabilityPoolWithdrawInstruction, Program: StabilityPoolProgramID, InputAsset: "sHYUSD", InputAmount: "100", OutputAsset: "hyUSD", OutputAmount: "105"},
+				{Instruction: MintLeverCoinInstruction, Program: ExchangeProgramID, InputAsset: "hyUSD", InputAmount: "60", OutputAsset: "xSOL", OutputAmount: "12"},
+			},
+		},
+		{
+			// Sell 10 xSOL for 50 hyUSD and stake all of it for 47.5 sHYUSD: the wallet's hyUSD is unchanged
+			name:          "sell then stake",
+			pre:           [5]string{"10000000", "0", "0", "1000000000", "0"},
+			post:          [5]string{"0", "0", "47500000", "1050000000", "250000"},
+			side:          TradeSideSell,
+			counterAmount: "50",
+			steps: []TradeStep{
+				{Instruction: RedeemLeverCoinInstruction, Program: ExchangeProgramID, InputAsset: "xSOL", InputAmount: "10", OutputAsset: "hyUSD", OutputAmount: "50"},
+				{Instruction: StabilityPoolDepositInstruction, Program: StabilityPoolProgramID, InputAsset: "hyUSD", InputAmount: "50", OutputAsset: "sHYUSD", OutputAmount: "47.5"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseTransaction(newPoolTradeTx(tt.pre, tt.post, tt.logs), "xsolATA")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			trade := result.Trade
+			if trade == nil {
+				t.Fatalf("expected a trade, got %+v", result)
+			}
+			if trade.Side != tt.side || trade.CounterAsset != tokens.HyUSDSymbol || trade.CounterAmount != tt.counterAmount {
+				t.Errorf("expected %s for %s hyUSD, got %s for %s %s", tt.side, tt.counterAmount, trade.Side, trade.CounterAmount, trade.CounterAsset)
+			}
+			if len(trade.Steps) != len(tt.steps) {
+				t.Fatalf("expected %d steps, got %+v", len(tt.steps), trade.Steps)
+			}
+			for i := range tt.steps {
+				if trade.Steps[i] != tt.steps[i] {
+					t.Errorf("step %d: expected %+v, got %+v", i, tt.steps[i], trade.Steps[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseTransactionWithoutStabilityPool(t *testing.T) {
+	tx := newPoolTradeTx(
+		[5]string{"10000000", "0", "100000000", "1000000000", "0"},
+		[5]string{"0", "50000000", "100000000", "1000000000", "250000"},
+		nil)
+	tx.Transaction.Message.Instructions = []solana.TxInstruction{{ProgramIdIndex: 7}}
+
+	result, err := ParseTransaction(tx, "xsolATA")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Trade == nil || len(result.Trade.Steps) != 0 || result.Trade.CounterAmount != "50" {
+		t.Errorf("expected a single-step sale for 50 hyUSD, got %+v", result.Trade)
+	}
+}
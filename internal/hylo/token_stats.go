@@ -0,0 +1,128 @@
+package hylo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// MaxTopHolders is the most accounts getTokenLargestAccounts returns
+const MaxTopHolders = 20
+
+// TokenStats summarizes a token mint's on-chain supply and authorities
+type TokenStats struct {
+	Symbol          string          `json:"symbol"`
+	Mint            string          `json:"mint"`
+	Decimals        uint8           `json:"decimals"`
+	Supply          string          `json:"supply"`     // Formatted with the mint's decimals
+	SupplyRaw       uint64          `json:"supply_raw"` // Raw units
+	MintAuthority   *solana.Address `json:"mint_authority"`
+	FreezeAuthority *solana.Address `json:"freeze_authority"`
+	TopHolders      *HolderSummary  `json:"top_holders,omitempty"` // Only when requested
+	// Metadata is the token's Metaplex name, symbol, URI and icon, once resolved at startup
+	Metadata  *tokens.TokenMetadata `json:"metadata,omitempty"`
+	FetchedAt time.Time             `json:"fetched_at"`
+	// Context is the slot, commitment and fetch time of the chain data behind the response
+	Context *solana.DataContext `json:"context,omitempty"`
+}
+
+// HolderSummary describes concentration among the largest token accounts
+type HolderSummary struct {
+	Accounts      []TokenHolder `json:"accounts"`
+	TotalShareBps uint64        `json:"total_share_bps"` // Combined share of supply held by Accounts, in basis points
+}
+
+// TokenHolder is one of the largest token accounts of a mint
+// Account is the token account, not its owner; program-owned vaults appear as-is
+type TokenHolder struct {
+	Account   string `json:"account"`
+	Amount    string `json:"amount"`
+	AmountRaw uint64 `json:"amount_raw"`
+	ShareBps  uint64 `json:"share_bps"` // Share of total supply in basis points
+}
+
+// ReadTokenStats reads supply, decimals and authorities from the SPL mint account
+// When topHolders > 0, the largest token accounts (up to MaxTopHolders) are summarized as well
+func (r *StateReader) ReadTokenStats(ctx context.Context, token tokens.TokenInfo, topHolders int) (*TokenStats, error) {
+	if topHolders < 0 || topHolders > MaxTopHolders {
+		return nil, fmt.Errorf("top holders must be between 0 and %d, got %d", MaxTopHolders, topHolders)
+	}
+	ctx, dataContext := solana.WithDataContext(ctx)
+
+	mintInfo, err := r.readTokenMintInfo(ctx, token.Mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s mint info: %w", token.Symbol, err)
+	}
+
+	stats := &TokenStats{
+		Symbol:          token.Symbol,
+		Mint:            token.Mint.String(),
+		Decimals:        mintInfo.Decimals,
+		Supply:          utils.FormatTokenAmount(mintInfo.Supply, mintInfo.Decimals),
+		SupplyRaw:       mintInfo.Supply,
+		MintAuthority:   mintInfo.MintAuthority,
+		FreezeAuthority: mintInfo.FreezeAuthority,
+		FetchedAt:       time.Now(),
+	}
+
+	if topHolders == 0 {
+		stats.Context = dataContext.DataContext()
+		return stats, nil
+	}
+
+	accounts, err := r.solanaClient.GetTokenLargestAccounts(ctx, token.Mint, solana.CommitmentFinalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s largest accounts: %w", token.Symbol, err)
+	}
+
+	summary, err := summarizeHolders(accounts, mintInfo.Supply, mintInfo.Decimals, topHolders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize %s holders: %w", token.Symbol, err)
+	}
+	stats.TopHolders = summary
+	stats.Context = dataContext.DataContext()
+
+	return stats, nil
+}
+
+// summarizeHolders converts the largest accounts into shares of supply, keeping the first limit entries
+func summarizeHolders(accounts []solana.TokenAccountBalance, supply uint64, decimals uint8, limit int) (*HolderSummary, error) {
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+	}
+
+	summary := &HolderSummary{Accounts: make([]TokenHolder, 0, len(accounts))}
+	var total uint64
+	for _, account := range accounts {
+		amount, err := strconv.ParseUint(account.Amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %q for account %s: %w", account.Amount, account.Address, err)
+		}
+		total += amount
+
+		summary.Accounts = append(summary.Accounts, TokenHolder{
+			Account:   account.Address,
+			Amount:    utils.FormatTokenAmount(amount, decimals),
+			AmountRaw: amount,
+			ShareBps:  shareBps(amount, supply),
+		})
+	}
+	summary.TotalShareBps = shareBps(total, supply)
+
+	return summary, nil
+}
+
+// shareBps returns amount/supply in basis points, using big.Int to avoid overflow on large supplies
+func shareBps(amount, supply uint64) uint64 {
+	if supply == 0 {
+		return 0
+	}
+	share := new(big.Int).Mul(new(big.Int).SetUint64(amount), big.NewInt(10000))
+	return share.Div(share, new(big.Int).SetUint64(supply)).Uint64()
+}
@@ -0,0 +1,39 @@
+package hylo
+
+import (
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+func TestSummarizeHolders(t *testing.T) {
+	accounts := []solana.TokenAccountBalance{
+		{Address: "vault", UITokenAmount: solana.UITokenAmount{Amount: "600000000"}},
+		{Address: "whale", UITokenAmount: solana.UITokenAmount{Amount: "250000000"}},
+		{Address: "small", UITokenAmount: solana.UITokenAmount{Amount: "1000"}},
+	}
+
+	summary, err := summarizeHolders(accounts, 1_000_000_000, 6, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summary.Accounts) != 2 {
+		t.Fatalf("Expected 2 accounts, got %d", len(summary.Accounts))
+	}
+	if summary.Accounts[0].ShareBps != 6000 || summary.Accounts[1].ShareBps != 2500 {
+		t.Errorf("unexpected shares: %+v", summary.Accounts)
+	}
+	if summary.TotalShareBps != 8500 {
+		t.Errorf("Expected total share 8500 bps, got %d", summary.TotalShareBps)
+	}
+	if summary.Accounts[0].Amount != "600" {
+		t.Errorf("Expected formatted amount 600, got %s", summary.Accounts[0].Amount)
+	}
+
+	if _, err := summarizeHolders([]solana.TokenAccountBalance{{Address: "bad", UITokenAmount: solana.UITokenAmount{Amount: "x"}}}, 1, 6, 1); err == nil {
+		t.Error("Expected error for malformed amount")
+	}
+	if shareBps(1, 0) != 0 {
+		t.Error("Expected zero share for zero supply")
+	}
+}
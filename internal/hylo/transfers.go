@@ -0,0 +1,242 @@
+package hylo
+
+import (
+	"sort"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Transfer directions relative to the tracked wallet
+const (
+	TransferIn  = "TRANSFER_IN"  // Wallet received Hylo tokens from another owner
+	TransferOut = "TRANSFER_OUT" // Wallet sent Hylo tokens to another owner
+)
+
+// maxTransferSOLDeltaLamports is the native SOL change (beyond the fee) tolerated for a plain transfer:
+// enough to create the recipient's associated token account, far below any real swap
+const maxTransferSOLDeltaLamports = 2_039_280
+
+// transferTokens are the mints tracked as wallet-to-wallet transfers
+var transferTokens = map[string]string{
+	tokens.XSOLMint.String():   tokens.XSOLSymbol,
+	tokens.HyUSDMint.String():  tokens.HyUSDSymbol,
+	tokens.SHyUSDMint.String(): tokens.SHyUSDSymbol,
+}
+
+// TokenTransfer is a non-trade movement of a Hylo token between two owners
+type TokenTransfer struct {
+	Signature string `json:"signature"`
+	Slot      uint64 `json:"slot"`
+	BlockTime int64  `json:"blockTime"`
+
+	Type   string `json:"type"` // TRANSFER_IN or TRANSFER_OUT
+	Token  string `json:"token"`
+	Mint   string `json:"mint"`
+	Amount string `json:"amount"`
+
+	// Owner wallets resolved from the token accounts' owners, plus the token accounts themselves
+	From             string `json:"from"`
+	To               string `json:"to"`
+	FromTokenAccount string `json:"fromTokenAccount"`
+	ToTokenAccount   string `json:"toTokenAccount"`
+
+	Timestamp   time.Time `json:"timestamp"`
+	ExplorerURL string    `json:"explorerUrl"` // Transaction URL on the configured block explorer
+
+	// Explorer pages of the source and destination owners
+	FromExplorerURL string `json:"fromExplorerUrl,omitempty"`
+	ToExplorerURL   string `json:"toExplorerUrl,omitempty"`
+
+	AmountRaw uint64 `json:"-"`
+}
+
+// tokenAccountDelta is the balance change of one token account within a transaction
+type tokenAccountDelta struct {
+	account  string
+	mint     string
+	owner    string
+	program  string // Token program reported by the RPC node; empty when omitted
+	decimals uint8
+	delta    int64
+}
+
+// ParseTokenTransfer detects a plain transfer of xSOL, hyUSD or sHYUSD into or out of wallet
+// Returns nil for failed transactions, Hylo program interactions, swaps (the wallet's balance
+// changes in more than one mint or by more than the fee and ATA rent in SOL) and self-transfers
+func ParseTokenTransfer(tx *solana.TransactionDetails, wallet solana.Address) *TokenTransfer {
+	if tx == nil || tx.Meta == nil || tx.Meta.Err != nil || invokesHyloProgram(tx) {
+		return nil
+	}
+
+	deltas := tokenAccountDeltas(tx)
+
+	// The wallet must move exactly one mint, and it must be a tracked Hylo token
+	var walletDelta *tokenAccountDelta
+	for i := range deltas {
+		if deltas[i].owner != wallet.String() {
+			continue
+		}
+		if walletDelta != nil && walletDelta.mint != deltas[i].mint {
+			return nil
+		}
+		if walletDelta == nil {
+			copied := deltas[i]
+			walletDelta = &copied
+			continue
+		}
+		walletDelta.delta += deltas[i].delta
+	}
+	if walletDelta == nil || walletDelta.delta == 0 {
+		return nil
+	}
+	symbol, tracked := transferTokens[walletDelta.mint]
+	if !tracked || !withinTransferSOLDelta(tx, wallet) {
+		return nil
+	}
+
+	// The counterparty is the other owner with the largest opposite change in the same mint
+	var counterparty *tokenAccountDelta
+	for i := range deltas {
+		d := &deltas[i]
+		if d.mint != walletDelta.mint || d.owner == wallet.String() || d.owner == "" {
+			continue
+		}
+		if (walletDelta.delta > 0) == (d.delta > 0) {
+			continue
+		}
+		if counterparty == nil || abs64(d.delta) > abs64(counterparty.delta) {
+			counterparty = d
+		}
+	}
+	if counterparty == nil {
+		return nil
+	}
+
+	signature := ""
+	if len(tx.Transaction.Signatures) > 0 {
+		signature = tx.Transaction.Signatures[0]
+	}
+	var blockTime int64
+	if tx.BlockTime != nil {
+		blockTime = *tx.BlockTime
+	}
+	base := NewXSOLTrade(signature, uint64(tx.Slot), blockTime)
+
+	amount := uint64(abs64(walletDelta.delta))
+	transfer := &TokenTransfer{
+		Signature: signature,
+		Slot:      base.Slot,
+		BlockTime: blockTime,
+		Token:     symbol,
+		Mint:      walletDelta.mint,
+		Amount:    formatAmount(amount, walletDelta.decimals),
+		Timestamp: base.Timestamp,
+		AmountRaw: amount,
+	}
+
+	if walletDelta.delta > 0 {
+		transfer.Type = TransferIn
+		transfer.From, transfer.FromTokenAccount = counterparty.owner, counterparty.account
+		transfer.To, transfer.ToTokenAccount = walletDelta.owner, walletDelta.account
+	} else {
+		transfer.Type = TransferOut
+		transfer.From, transfer.FromTokenAccount = walletDelta.owner, walletDelta.account
+		transfer.To, transfer.ToTokenAccount = counterparty.owner, counterparty.account
+	}
+
+	transfer.link(explorer.Default())
+	return transfer
+}
+
+// WithExplorer returns a copy of the transfer linking to provider's transaction and owner pages
+func (t *TokenTransfer) WithExplorer(provider explorer.Provider) *TokenTransfer {
+	linked := *t
+	linked.link(provider)
+	return &linked
+}
+
+// link sets the transfer's explorer URLs for provider
+func (t *TokenTransfer) link(provider explorer.Provider) {
+	t.ExplorerURL = provider.TransactionURL(t.Signature)
+	t.FromExplorerURL, t.ToExplorerURL = "", ""
+	if t.From != "" {
+		t.FromExplorerURL = provider.AccountURL(t.From)
+	}
+	if t.To != "" {
+		t.ToExplorerURL = provider.AccountURL(t.To)
+	}
+}
+
+// tokenAccountDeltas computes per-account balance changes from pre/post token balances
+// Accounts created or closed in the transaction are treated as having a zero balance on the missing side
+func tokenAccountDeltas(tx *solana.TransactionDetails) []tokenAccountDelta {
+	byIndex := make(map[uint32]*tokenAccountDelta)
+
+	apply := func(balances []solana.TokenBalance, sign int64) {
+		for _, balance := range balances {
+			amount, err := parseTokenAmount(balance.UITokenAmount)
+			if err != nil {
+				continue
+			}
+
+			entry, exists := byIndex[balance.AccountIndex]
+			if !exists {
+				entry = &tokenAccountDelta{mint: balance.Mint}
+				if int(balance.AccountIndex) < len(tx.Transaction.Message.AccountKeys) {
+					entry.account = tx.Transaction.Message.AccountKeys[balance.AccountIndex]
+				}
+				byIndex[balance.AccountIndex] = entry
+			}
+			if balance.Owner != nil {
+				entry.owner = *balance.Owner
+			}
+			if balance.ProgramId != nil {
+				entry.program = *balance.ProgramId
+			}
+			if balance.UITokenAmount != nil {
+				entry.decimals = balance.UITokenAmount.Decimals
+			}
+			entry.delta += sign * int64(amount)
+		}
+	}
+	apply(tx.Meta.PreTokenBalances, -1)
+	apply(tx.Meta.PostTokenBalances, 1)
+
+	indexes := make([]uint32, 0, len(byIndex))
+	for index := range byIndex {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	deltas := make([]tokenAccountDelta, 0, len(indexes))
+	for _, index := range indexes {
+		if byIndex[index].delta != 0 {
+			deltas = append(deltas, *byIndex[index])
+		}
+	}
+	return deltas
+}
+
+// withinTransferSOLDelta reports whether the wallet's native SOL change is explained by fees and ATA rent
+func withinTransferSOLDelta(tx *solana.TransactionDetails, wallet solana.Address) bool {
+	index := findAccountIndex(tx.Transaction.Message.AccountKeys, wallet.String())
+	if index == -1 || index >= len(tx.Meta.PreBalances) || index >= len(tx.Meta.PostBalances) {
+		return true // Wallet not signing or funding anything in SOL
+	}
+
+	delta := int64(tx.Meta.PostBalances[index]) - int64(tx.Meta.PreBalances[index])
+	if index == 0 {
+		delta += int64(tx.Meta.Fee) // Fee payer
+	}
+	return abs64(delta) <= maxTransferSOLDeltaLamports
+}
+
+func abs64(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,116 @@
+package hylo
+
+import (
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+const (
+	transferSender    = "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+	transferRecipient = "7VHUFJHWu2CuExkJcJrzhQPJ2oygupTWkL2A2For4BmE"
+)
+
+func tokenBalance(index uint32, mint solana.Address, owner, amount string) solana.TokenBalance {
+	return solana.TokenBalance{
+		AccountIndex:  index,
+		Mint:          mint.String(),
+		Owner:         &owner,
+		UITokenAmount: &solana.UITokenAmount{Amount: amount, Decimals: tokens.HyUSDDecimals},
+	}
+}
+
+// newTransferTx moves 25 hyUSD from sender (fee payer) to recipient
+func newTransferTx() *solana.TransactionDetails {
+	blockTime := int64(1757360080)
+	return &solana.TransactionDetails{
+		BlockTime: &blockTime,
+		Slot:      365528389,
+		Meta: &solana.TxMeta{
+			Fee:          5000,
+			PreBalances:  []uint64{1_000_000_000, 0, 0, 0},
+			PostBalances: []uint64{999_995_000, 0, 0, 0},
+			PreTokenBalances: []solana.TokenBalance{
+				tokenBalance(1, tokens.HyUSDMint, transferSender, "100000000"),
+				tokenBalance(2, tokens.HyUSDMint, transferRecipient, "0"),
+			},
+			PostTokenBalances: []solana.TokenBalance{
+				tokenBalance(1, tokens.HyUSDMint, transferSender, "75000000"),
+				tokenBalance(2, tokens.HyUSDMint, transferRecipient, "25000000"),
+			},
+		},
+		Transaction: solana.Transaction{
+			Message: solana.TxMessage{
+				AccountKeys:  []string{transferSender, "senderATA", "recipientATA", "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"},
+				Instructions: []solana.TxInstruction{{ProgramIdIndex: 3}},
+			},
+			Signatures: []string{"transferSig"},
+		},
+	}
+}
+
+func TestParseTokenTransfer(t *testing.T) {
+	out := ParseTokenTransfer(newTransferTx(), transferSender)
+	if out == nil {
+		t.Fatal("Expected outgoing transfer")
+	}
+	if out.Type != TransferOut || out.Token != tokens.HyUSDSymbol || out.Amount != "25" {
+		t.Errorf("Unexpected transfer: %+v", out)
+	}
+	if out.From != transferSender || out.To != transferRecipient || out.ToTokenAccount != "recipientATA" {
+		t.Errorf("Expected owners resolved from token accounts, got %+v", out)
+	}
+
+	in := ParseTokenTransfer(newTransferTx(), transferRecipient)
+	if in == nil || in.Type != TransferIn || in.From != transferSender || in.FromTokenAccount != "senderATA" {
+		t.Errorf("Expected incoming transfer from sender, got %+v", in)
+	}
+
+	if ParseTokenTransfer(newTransferTx(), "9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin") != nil {
+		t.Error("Expected nil for uninvolved wallet")
+	}
+}
+
+func TestParseTokenTransferRejectsNonTransfers(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(tx *solana.TransactionDetails)
+	}{
+		{
+			name: "swap spends SOL",
+			mutate: func(tx *solana.TransactionDetails) {
+				tx.Meta.PostBalances[0] = 500_000_000
+			},
+		},
+		{
+			name: "wallet changes two mints",
+			mutate: func(tx *solana.TransactionDetails) {
+				tx.Meta.PreTokenBalances = append(tx.Meta.PreTokenBalances, tokenBalance(3, tokens.USDCMint, transferSender, "0"))
+				tx.Meta.PostTokenBalances = append(tx.Meta.PostTokenBalances, tokenBalance(3, tokens.USDCMint, transferSender, "25000000"))
+			},
+		},
+		{
+			name: "hylo program interaction",
+			mutate: func(tx *solana.TransactionDetails) {
+				tx.Transaction.Message.AccountKeys[3] = ExchangeProgramID
+			},
+		},
+		{
+			name: "failed transaction",
+			mutate: func(tx *solana.TransactionDetails) {
+				tx.Meta.Err = "InstructionError"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newTransferTx()
+			tt.mutate(tx)
+			if transfer := ParseTokenTransfer(tx, transferSender); transfer != nil {
+				t.Errorf("Expected nil, got %+v", transfer)
+			}
+		})
+	}
+}
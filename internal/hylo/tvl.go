@@ -0,0 +1,245 @@
+package hylo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// ErrNoLSTVaults is returned by TVL reads when HYLO_LST_VAULTS lists no vaults
+var ErrNoLSTVaults = errors.New("no LST vaults configured: set HYLO_LST_VAULTS")
+
+// LSTRateSource returns the SOL value of one whole LST token; implemented by sanctum.Client
+type LSTRateSource interface {
+	SOLPerToken(ctx context.Context, mint solana.Address) (float64, error)
+}
+
+// Rate sources reported per LST holding
+const (
+	RateSourceSanctum = "sanctum"     // Stake pool exchange rate from the Sanctum API
+	RateSourceMarket  = "dexscreener" // LST/USD market price divided by SOL/USD
+)
+
+// LSTVault is a token account holding one LST of the exchange's SOL collateral
+type LSTVault struct {
+	Symbol string
+	Vault  solana.Address
+}
+
+// VaultBalance is the on-chain balance of an LST vault
+type VaultBalance struct {
+	Symbol     string
+	Mint       solana.Address
+	Vault      solana.Address
+	Decimals   uint8
+	BalanceRaw uint64
+}
+
+// LSTHolding is one LST's contribution to protocol TVL
+type LSTHolding struct {
+	Symbol      string  `json:"symbol"`
+	Mint        string  `json:"mint"`
+	Vault       string  `json:"vault"`
+	Balance     string  `json:"balance"`     // Formatted with the mint's decimals
+	BalanceRaw  uint64  `json:"balance_raw"` // Raw units
+	SOLPerToken float64 `json:"sol_per_token"`
+	ValueSOL    float64 `json:"value_sol"`
+	ValueUSD    float64 `json:"value_usd"`
+	Share       float64 `json:"share"`       // Fraction of total TVL, 0-1
+	RateSource  string  `json:"rate_source"` // sanctum or dexscreener
+}
+
+// ProtocolTVL is the total value locked in the exchange's LST vaults
+type ProtocolTVL struct {
+	TotalSOL       float64      `json:"total_sol"`
+	TotalUSD       float64      `json:"total_usd"`
+	SOLPriceUSD    float64      `json:"sol_price_usd"`
+	SOLPriceSource string       `json:"sol_price_source"`
+	LSTs           []LSTHolding `json:"lsts"` // Largest first
+	Timestamp      time.Time    `json:"timestamp"`
+	// Context is the slot, commitment and fetch time of the chain data behind the response
+	Context *solana.DataContext `json:"context,omitempty"`
+}
+
+// ReadLSTVaults reads the balance and mint of every configured LST vault
+func (r *StateReader) ReadLSTVaults(ctx context.Context) ([]VaultBalance, error) {
+	if len(r.config.LSTVaults) == 0 {
+		return nil, ErrNoLSTVaults
+	}
+
+	balances := make([]VaultBalance, 0, len(r.config.LSTVaults))
+	for _, vault := range r.config.LSTVaults {
+		accountInfo, err := r.solanaClient.GetAccount(ctx, vault.Vault, solana.CommitmentFinalized)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s vault %s: %w", vault.Symbol, vault.Vault, err)
+		}
+		account, err := tokens.ParseSPLTokenAccount(accountInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s vault %s: %w", vault.Symbol, vault.Vault, err)
+		}
+
+		mintInfo, err := r.readTokenMintInfo(ctx, account.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s mint info: %w", vault.Symbol, err)
+		}
+
+		balances = append(balances, VaultBalance{
+			Symbol:     vault.Symbol,
+			Mint:       account.Mint,
+			Vault:      vault.Vault,
+			Decimals:   mintInfo.Decimals,
+			BalanceRaw: account.Amount,
+		})
+	}
+
+	return balances, nil
+}
+
+// ComputeTVL values vault balances in SOL and USD
+// solPerToken maps each LST mint to its SOL conversion rate; a vault without a rate is an error
+func ComputeTVL(balances []VaultBalance, solPerToken map[solana.Address]float64, solPriceUSD float64) (*ProtocolTVL, error) {
+	if solPriceUSD <= 0 {
+		return nil, fmt.Errorf("SOL price must be positive, got %f", solPriceUSD)
+	}
+
+	tvl := &ProtocolTVL{SOLPriceUSD: solPriceUSD, LSTs: make([]LSTHolding, 0, len(balances))}
+	var totalSOL utils.Decimal
+	for _, balance := range balances {
+		rate, ok := solPerToken[balance.Mint]
+		if !ok || rate <= 0 {
+			return nil, fmt.Errorf("no SOL conversion rate for %s (%s)", balance.Symbol, balance.Mint)
+		}
+
+		valueSOL := utils.NewDecimalFromRaw(balance.BalanceRaw, balance.Decimals).Mul(utils.DecimalFromFloat(rate))
+		totalSOL = totalSOL.Add(valueSOL)
+
+		tvl.LSTs = append(tvl.LSTs, LSTHolding{
+			Symbol:      balance.Symbol,
+			Mint:        balance.Mint.String(),
+			Vault:       balance.Vault.String(),
+			Balance:     utils.FormatTokenAmount(balance.BalanceRaw, balance.Decimals),
+			BalanceRaw:  balance.BalanceRaw,
+			SOLPerToken: rate,
+			ValueSOL:    valueSOL.Float64(),
+			ValueUSD:    valueSOL.Mul(utils.DecimalFromFloat(solPriceUSD)).Float64(),
+		})
+	}
+
+	tvl.TotalSOL = totalSOL.Float64()
+	tvl.TotalUSD = totalSOL.Mul(utils.DecimalFromFloat(solPriceUSD)).Float64()
+	for i := range tvl.LSTs {
+		if tvl.TotalSOL > 0 {
+			tvl.LSTs[i].Share = tvl.LSTs[i].ValueSOL / tvl.TotalSOL
+		}
+	}
+	sort.SliceStable(tvl.LSTs, func(i, j int) bool { return tvl.LSTs[i].ValueSOL > tvl.LSTs[j].ValueSOL })
+
+	return tvl, nil
+}
+
+// GetProtocolTVL reads the LST vaults and values them in SOL and USD
+// Each LST's SOL conversion rate comes from the Sanctum stake pool rate when configured,
+// falling back to its USD market price divided by SOL/USD
+func (ps *PriceService) GetProtocolTVL(ctx context.Context) (*ProtocolTVL, error) {
+	ctx, dataContext := solana.WithDataContext(ctx)
+	balances, err := ps.stateReader.ReadLSTVaults(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	solPrice, err := ps.dexScreenerClient.FetchSOLPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SOL/USD price: %w", err)
+	}
+
+	rates := make(map[solana.Address]float64, len(balances))
+	sources := make(map[solana.Address]string, len(balances))
+	for _, balance := range balances {
+		if _, ok := rates[balance.Mint]; ok {
+			continue
+		}
+		rate, source, err := ps.lstRate(ctx, balance.Mint, solPrice.Price)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price %s: %w", balance.Symbol, err)
+		}
+		rates[balance.Mint], sources[balance.Mint] = rate, source
+	}
+
+	tvl, err := ComputeTVL(balances, rates, solPrice.Price)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tvl.LSTs {
+		tvl.LSTs[i].RateSource = sources[solana.Address(tvl.LSTs[i].Mint)]
+	}
+	tvl.SOLPriceSource = solPrice.Source
+	tvl.Timestamp = time.Now().UTC()
+	tvl.Context = dataContext.DataContext()
+	return tvl, nil
+}
+
+// LSTConversion converts an LST to SOL and on to USD
+type LSTConversion struct {
+	Mint        string    `json:"mint"`
+	SOLPerToken float64   `json:"sol_per_token"`
+	SOLPriceUSD float64   `json:"sol_price_usd"`
+	RateSource  string    `json:"rate_source"` // sanctum or dexscreener
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// GetLSTConversion returns the SOL value of one LST token and the SOL/USD price to value it with
+// The rate comes from Sanctum when configured, falling back to the market rate like TVL
+func (ps *PriceService) GetLSTConversion(ctx context.Context, mint solana.Address) (*LSTConversion, error) {
+	solPrice, err := ps.dexScreenerClient.FetchSOLPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch SOL/USD price: %w", err)
+	}
+	rate, source, err := ps.lstRate(ctx, mint, solPrice.Price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price %s: %w", mint, err)
+	}
+	return &LSTConversion{
+		Mint:        mint.String(),
+		SOLPerToken: rate,
+		SOLPriceUSD: solPrice.Price,
+		RateSource:  source,
+		FetchedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// lstRate returns the SOL value of one LST token and where it came from
+// A Sanctum failure falls back to the market rate so TVL stays available when the API is down
+func (ps *PriceService) lstRate(ctx context.Context, mint solana.Address, solPriceUSD float64) (float64, string, error) {
+	if ps.lstRates != nil {
+		rate, err := ps.lstRates.SOLPerToken(ctx, mint)
+		if err == nil {
+			return rate, RateSourceSanctum, nil
+		}
+		ps.logger.LogExternalAPIError(ctx, RateSourceSanctum, "sol-value", err, 0)
+	}
+
+	rate, err := marketRate(ctx, ps.dexScreenerClient, mint, solPriceUSD)
+	if err != nil {
+		return 0, "", err
+	}
+	return rate, RateSourceMarket, nil
+}
+
+// marketRate returns the SOL value of one LST token from its USD market price
+func marketRate(ctx context.Context, dexScreener *price.DexScreenerClient, mint solana.Address, solPriceUSD float64) (float64, error) {
+	if solPriceUSD <= 0 {
+		return 0, fmt.Errorf("SOL price must be positive, got %f", solPriceUSD)
+	}
+	tokenPrice, err := dexScreener.FetchTokenPrice(ctx, mint.String())
+	if err != nil {
+		return 0, err
+	}
+	return tokenPrice.PriceUSD / solPriceUSD, nil
+}
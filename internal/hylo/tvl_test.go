@@ -0,0 +1,94 @@
+package hylo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestComputeTVL(t *testing.T) {
+	otherMint := solana.Address("mSoLzYCxHdYgdzU16g5QSh3i5K3z3KZK7ytfqcJm7So")
+	balances := []VaultBalance{
+		{Symbol: "mSOL", Mint: otherMint, Vault: "vault2", Decimals: 9, BalanceRaw: 1_000_000_000_000}, // 1,000 mSOL
+		{Symbol: tokens.JitoSOLSymbol, Mint: tokens.JitoSOLMint, Vault: "vault1", Decimals: 9, BalanceRaw: 2_500_000_000_000},
+	}
+	rates := map[solana.Address]float64{tokens.JitoSOLMint: 1.2, otherMint: 1.3}
+
+	tvl, err := ComputeTVL(balances, rates, 200)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if math.Abs(tvl.TotalSOL-4300) > 1e-9 || math.Abs(tvl.TotalUSD-860000) > 1e-6 {
+		t.Errorf("Expected 4300 SOL / $860,000, got %v SOL / $%v", tvl.TotalSOL, tvl.TotalUSD)
+	}
+	if tvl.LSTs[0].Symbol != tokens.JitoSOLSymbol || tvl.LSTs[0].Balance != "2500" {
+		t.Errorf("Expected jitoSOL first as the largest holding, got %+v", tvl.LSTs[0])
+	}
+	if share := tvl.LSTs[0].Share + tvl.LSTs[1].Share; math.Abs(share-1) > 1e-9 || math.Abs(tvl.LSTs[1].Share-1300.0/4300) > 1e-9 {
+		t.Errorf("Expected shares to sum to 1, got %+v", tvl.LSTs)
+	}
+	if math.Abs(tvl.LSTs[1].ValueUSD-260000) > 1e-6 {
+		t.Errorf("Expected mSOL worth $260,000, got %v", tvl.LSTs[1].ValueUSD)
+	}
+}
+
+func TestComputeTVLErrors(t *testing.T) {
+	balances := []VaultBalance{{Symbol: tokens.JitoSOLSymbol, Mint: tokens.JitoSOLMint, Decimals: 9, BalanceRaw: 1}}
+
+	if _, err := ComputeTVL(balances, map[solana.Address]float64{}, 200); err == nil {
+		t.Error("Expected an error for a vault without a conversion rate")
+	}
+	if _, err := ComputeTVL(balances, map[solana.Address]float64{tokens.JitoSOLMint: 1.2}, 0); err == nil {
+		t.Error("Expected an error for a zero SOL price")
+	}
+}
+
+func TestParseLSTVaults(t *testing.T) {
+	vaults := parseLSTVaults(" jitoSOL:" + string(tokens.JitoSOLMint) + ", broken, mSOL:not-an-address, :" + string(tokens.XSOLMint))
+	if len(vaults) != 1 || vaults[0].Symbol != "jitoSOL" || vaults[0].Vault != tokens.JitoSOLMint {
+		t.Errorf("Expected only the well-formed entry, got %+v", vaults)
+	}
+}
+
+type stubLSTRates struct {
+	rate float64
+	err  error
+}
+
+func (s stubLSTRates) SOLPerToken(ctx context.Context, mint solana.Address) (float64, error) {
+	return s.rate, s.err
+}
+
+func TestLSTRateSources(t *testing.T) {
+	var marketCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		marketCalls++
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	priceConfig := price.DefaultConfig()
+	priceConfig.DexScreenerURL = server.URL
+	priceConfig.MaxRetries = 0
+	ps := NewPriceService(nil, NewConfig(), priceConfig)
+	defer ps.Close()
+
+	ps.SetLSTRates(stubLSTRates{rate: 1.21})
+	rate, source, err := ps.lstRate(context.Background(), tokens.JitoSOLMint, 200)
+	if err != nil || rate != 1.21 || source != RateSourceSanctum || marketCalls != 0 {
+		t.Errorf("Expected the Sanctum rate without a market lookup, got %v from %q (%v, %d market calls)", rate, source, err, marketCalls)
+	}
+
+	ps.SetLSTRates(stubLSTRates{err: errors.New("sanctum down")})
+	if _, _, err := ps.lstRate(context.Background(), tokens.JitoSOLMint, 200); err == nil || marketCalls != 1 {
+		t.Errorf("Expected a Sanctum failure to fall back to the market rate, got %v after %d market calls", err, marketCalls)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"time"
 
+	"hylo-wallet-tracker-api/internal/explorer"
 	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
 )
 
 // XSOLTrade represents a parsed xSOL trade transaction with all relevant details
@@ -20,29 +22,68 @@ type XSOLTrade struct {
 	CounterAmount string `json:"counterAmount"` // Formatted counter-asset amount
 	CounterAsset  string `json:"counterAsset"`  // "SOL", "hyUSD", "USDC", etc.
 
-	// Historical pricing (new field)
-	HistoricalPriceUSD *string `json:"historical_price_usd,omitempty"` // Historical xSOL price in USD, only for hyUSD trades
+	// Historical pricing, from the counter amount of trades against a configured stablecoin
+	HistoricalPriceUSD *string `json:"historical_price_usd,omitempty"` // Historical xSOL price in USD, only for stablecoin trades
+
+	// Fee breakdown: network fee from meta.fee and the Hylo mint/redeem fee in the counter asset
+	NetworkFeeSOL string `json:"network_fee_sol,omitempty"` // Transaction fee paid in SOL
+	ProtocolFee   string `json:"protocol_fee,omitempty"`    // Protocol fee denominated in counterAsset, only for Hylo trades
+
+	// Failure details, only set for reverted transactions when failed trades are requested
+	Status      string `json:"status,omitempty"`      // "failed" for reverted transactions
+	ErrorReason string `json:"errorReason,omitempty"` // Reason extracted from meta.Err or program logs
+
+	// Classification confidence (0-1): Hylo instructions score higher than balance-change inference,
+	// ambiguous counter-asset matches lower. Trades below ReviewConfidenceThreshold need manual review
+	Confidence        float64  `json:"confidence"`
+	ConfidenceReasons []string `json:"confidenceReasons,omitempty"` // Why the score is below 1
+	NeedsReview       bool     `json:"needsReview,omitempty"`
+
+	// Sanity checks the parsed amounts failed, e.g. xsol_amount_exceeds_supply; the amounts are kept as parsed
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Legs of a transaction that also staked or unstaked sHYUSD, in execution order; empty for single-step trades
+	Steps []TradeStep `json:"steps,omitempty"`
+
+	// Origin of the trade: hylo_app, aggregator, direct_cpi or unknown (see TradeSource constants)
+	Source        string `json:"source,omitempty"`
+	SourceProgram string `json:"sourceProgram,omitempty"` // Aggregator name or calling program ID, when known
+
+	// Protocol health nearest the trade's slot; set on responses, not stored with the trade
+	ProtocolState *TradeProtocolState `json:"protocolState,omitempty"`
 
 	// Display fields
 	Timestamp   time.Time `json:"timestamp"`   // Parsed timestamp
-	ExplorerURL string    `json:"explorerUrl"` // Solscan transaction URL
+	ExplorerURL string    `json:"explorerUrl"` // Transaction URL on the configured block explorer
 
 	// Raw amounts for calculations (optional, for internal use)
 	XSOLAmountRaw    uint64 `json:"-"` // Raw xSOL amount (lamports/smallest unit)
 	CounterAmountRaw uint64 `json:"-"` // Raw counter-asset amount
+	ProtocolFeeRaw   uint64 `json:"-"` // Raw protocol fee in counter-asset units
+}
+
+// TradeProtocolState is the protocol's collateral ratio and effective leverage from the state snapshot
+// recorded closest to a trade's slot, which may be shortly before or after the trade
+type TradeProtocolState struct {
+	Slot              uint64    `json:"slot"`         // Slot of the state snapshot
+	SlotDistance      uint64    `json:"slotDistance"` // Slots between the snapshot and the trade
+	CollateralRatio   float64   `json:"collateralRatio"`
+	EffectiveLeverage float64   `json:"effectiveLeverage"`
+	CapturedAt        time.Time `json:"capturedAt"`
 }
 
 // TradeParseResult contains the result of transaction parsing
 type TradeParseResult struct {
-	Trade *XSOLTrade `json:"trade,omitempty"` // Parsed trade, nil if not an xSOL trade
-	Error string     `json:"error,omitempty"` // Parse error message, if any
+	Trade    *XSOLTrade     `json:"trade,omitempty"`    // Parsed trade, nil if not an xSOL trade
+	Transfer *TokenTransfer `json:"transfer,omitempty"` // Plain xSOL transfer to/from another owner; Trade still reports the balance change
+	Error    string         `json:"error,omitempty"`    // Parse error message, if any
 }
 
 // NewXSOLTrade creates a new XSOLTrade with basic transaction info
 func NewXSOLTrade(signature string, slot uint64, blockTime int64) *XSOLTrade {
 	var timestamp time.Time
 	if blockTime > 0 {
-		timestamp = time.Unix(blockTime, 0)
+		timestamp = time.Unix(blockTime, 0).UTC()
 	}
 
 	return &XSOLTrade{
@@ -50,7 +91,7 @@ func NewXSOLTrade(signature string, slot uint64, blockTime int64) *XSOLTrade {
 		Slot:        slot,
 		BlockTime:   blockTime,
 		Timestamp:   timestamp,
-		ExplorerURL: generateSolscanURL(signature),
+		ExplorerURL: explorer.Default().TransactionURL(signature),
 	}
 }
 
@@ -63,54 +104,43 @@ func (t *XSOLTrade) SetTradeDetails(side string, xsolAmount, counterAmount uint6
 
 	// Format amounts for display using defined decimal constants
 	t.XSOLAmount = formatAmount(xsolAmount, tokens.XSOLDecimals)
+	t.CounterAmount = formatCounterAmount(counterAmount, counterAsset)
+}
 
+// formatCounterAmount formats a raw counter-asset amount using the asset's decimals
+func formatCounterAmount(amount uint64, counterAsset string) string {
 	switch counterAsset {
 	case "SOL":
-		t.CounterAmount = formatAmount(counterAmount, tokens.SOLDecimals)
+		return formatAmount(amount, tokens.SOLDecimals)
 	case "hyUSD":
-		t.CounterAmount = formatAmount(counterAmount, tokens.HyUSDDecimals)
+		return formatAmount(amount, tokens.HyUSDDecimals)
 	case "USDC":
-		t.CounterAmount = formatAmount(counterAmount, tokens.USDCDecimals)
+		return formatAmount(amount, tokens.USDCDecimals)
 	case "jitoSOL":
-		t.CounterAmount = formatAmount(counterAmount, tokens.JitoSOLDecimals)
-	default:
-		t.CounterAmount = fmt.Sprintf("%d", counterAmount) // Raw amount as fallback
+		return formatAmount(amount, tokens.JitoSOLDecimals)
 	}
+	if peg, ok := GetStablecoins().Lookup(counterAsset); ok {
+		return formatAmount(amount, peg.Decimals)
+	}
+	return fmt.Sprintf("%d", amount) // Raw amount as fallback
 }
 
-// generateSolscanURL creates a Solscan explorer URL for the transaction
-func generateSolscanURL(signature string) string {
-	return fmt.Sprintf("https://solscan.io/tx/%s", signature)
+// WithExplorer returns a copy of the trade linking to provider's transaction page
+func (t *XSOLTrade) WithExplorer(provider explorer.Provider) *XSOLTrade {
+	linked := *t
+	linked.ExplorerURL = provider.TransactionURL(t.Signature)
+	return &linked
 }
 
 // formatAmount formats raw token amount with proper decimal precision
-// This is a simplified version of the tokens package formatting logic
 func formatAmount(rawAmount uint64, decimals uint8) string {
-	if rawAmount == 0 {
-		return "0"
-	}
-
-	// Calculate divisor for decimal precision
-	divisor := uint64(1)
-	for i := uint8(0); i < decimals; i++ {
-		divisor *= 10
-	}
-
-	integerPart := rawAmount / divisor
-	fractionalPart := rawAmount % divisor
-
-	if fractionalPart == 0 {
-		return fmt.Sprintf("%d", integerPart)
-	}
-
-	// Format with decimals, removing trailing zeros
-	fracStr := fmt.Sprintf("%0*d", decimals, fractionalPart)
-	// Remove trailing zeros
-	for len(fracStr) > 1 && fracStr[len(fracStr)-1] == '0' {
-		fracStr = fracStr[:len(fracStr)-1]
-	}
+	return utils.FormatTokenAmount(rawAmount, decimals)
+}
 
-	return fmt.Sprintf("%d.%s", integerPart, fracStr)
+// isStablecoinTrade reports whether the counter asset is a configured stablecoin
+func (t *XSOLTrade) isStablecoinTrade() bool {
+	_, ok := GetStablecoins().Lookup(t.CounterAsset)
+	return ok
 }
 
 // IsValidTrade checks if the trade has valid data
@@ -118,5 +148,5 @@ func (t *XSOLTrade) IsValidTrade() bool {
 	return t.Signature != "" &&
 		(t.Side == TradeSideBuy || t.Side == TradeSideSell || t.Side == TradeSideReceive) &&
 		t.XSOLAmountRaw > 0 &&
-		(t.CounterAsset == "SOL" || t.CounterAsset == "hyUSD" || t.CounterAsset == "USDC" || t.CounterAsset == "jitoSOL" || t.CounterAsset == "")
+		(t.CounterAsset == "SOL" || t.CounterAsset == "hyUSD" || t.CounterAsset == "USDC" || t.CounterAsset == "jitoSOL" || t.CounterAsset == "" || t.isStablecoinTrade())
 }
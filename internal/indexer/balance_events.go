@@ -0,0 +1,102 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// BalanceChange is the data of a balance.changed event: the tokens whose balance differs between
+// two consecutive snapshots of a tracked wallet
+type BalanceChange struct {
+	Wallet       string        `json:"wallet"`
+	PreviousSlot uint64        `json:"previous_slot"`
+	Slot         uint64        `json:"slot"`
+	PreviousAt   time.Time     `json:"previous_at"`
+	Timestamp    time.Time     `json:"timestamp"`
+	Changes      []TokenChange `json:"changes"` // Ordered by token symbol
+	TotalUSD     float64       `json:"total_usd"`
+}
+
+// TokenChange is one token's previous and new balance; a token absent from a snapshot counts as zero
+type TokenChange struct {
+	Token       string  `json:"token"`
+	Previous    string  `json:"previous"` // Formatted with the token's decimals
+	Current     string  `json:"current"`
+	PreviousRaw uint64  `json:"previous_raw"`
+	CurrentRaw  uint64  `json:"current_raw"`
+	DeltaUSD    float64 `json:"delta_usd"` // Change in USD value, at each snapshot's prices
+}
+
+// DiffSnapshots returns the balance change from previous to current, or nil when no raw balance changed
+// A current snapshot taken at an older slot than previous (a lagging RPC node) is not a change
+func DiffSnapshots(previous, current store.BalanceSnapshot) *BalanceChange {
+	if current.Slot != 0 && current.Slot < previous.Slot {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(current.Holdings))
+	for symbol := range current.Holdings {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range previous.Holdings {
+		if _, ok := current.Holdings[symbol]; !ok {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+
+	var changes []TokenChange
+	for _, symbol := range symbols {
+		before, after := previous.Holdings[symbol], current.Holdings[symbol]
+		if before.RawAmount == after.RawAmount {
+			continue
+		}
+		changes = append(changes, TokenChange{
+			Token:       symbol,
+			Previous:    formattedOrZero(before),
+			Current:     formattedOrZero(after),
+			PreviousRaw: before.RawAmount,
+			CurrentRaw:  after.RawAmount,
+			DeltaUSD:    after.USDValue - before.USDValue,
+		})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return &BalanceChange{
+		Wallet:       current.Wallet,
+		PreviousSlot: previous.Slot,
+		Slot:         current.Slot,
+		PreviousAt:   previous.Timestamp,
+		Timestamp:    current.Timestamp,
+		Changes:      changes,
+		TotalUSD:     current.TotalUSD,
+	}
+}
+
+func formattedOrZero(holding store.Holding) string {
+	if holding.FormattedAmount == "" {
+		return "0"
+	}
+	return holding.FormattedAmount
+}
+
+// publishBalanceChange emits a balance.changed event when the wallet's balances moved since the previous snapshot
+func (ix *Indexer) publishBalanceChange(previous, current store.BalanceSnapshot) {
+	if ix.events == nil {
+		return
+	}
+	change := DiffSnapshots(previous, current)
+	if change == nil {
+		return
+	}
+	ix.events.Publish(webhook.Event{
+		Type:   webhook.EventBalanceChanged,
+		Wallet: change.Wallet,
+		Data:   change,
+	})
+}
@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// stubBalanceFetcher returns the balances set by the test
+type stubBalanceFetcher struct {
+	balances *tokens.WalletBalances
+}
+
+func (f *stubBalanceFetcher) GetWalletBalances(ctx context.Context, wallet solana.Address) (*tokens.WalletBalances, error) {
+	return f.balances, nil
+}
+
+func walletBalances(slot solana.Slot, amounts map[string]uint64) *tokens.WalletBalances {
+	balances := &tokens.WalletBalances{Wallet: testWallet, Slot: slot, Balances: make(map[string]*tokens.TokenBalance)}
+	for symbol, amount := range amounts {
+		balances.Balances[symbol] = &tokens.TokenBalance{RawAmount: amount, FormattedAmount: utils.FormatTokenAmount(amount, 6)}
+	}
+	return balances
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	previous := store.BalanceSnapshot{Wallet: testWallet.String(), Slot: 100, Holdings: map[string]store.Holding{
+		tokens.XSOLSymbol:  {RawAmount: 1_000_000, FormattedAmount: "1", USDValue: 2},
+		tokens.HyUSDSymbol: {RawAmount: 5_000_000, FormattedAmount: "5", USDValue: 5},
+	}}
+	current := store.BalanceSnapshot{Wallet: testWallet.String(), Slot: 200, Holdings: map[string]store.Holding{
+		tokens.XSOLSymbol:   {RawAmount: 1_000_000, FormattedAmount: "1", USDValue: 2.5},
+		tokens.SHyUSDSymbol: {RawAmount: 5_000_000, FormattedAmount: "5", USDValue: 5.1},
+	}}
+
+	change := DiffSnapshots(previous, current)
+	if change == nil || change.PreviousSlot != 100 || change.Slot != 200 || len(change.Changes) != 2 {
+		t.Fatalf("Expected hyUSD and sHYUSD changes between slots 100 and 200, got %+v", change)
+	}
+	hyUSD, sHyUSD := change.Changes[0], change.Changes[1]
+	if hyUSD.Token != tokens.HyUSDSymbol || hyUSD.Current != "0" || hyUSD.CurrentRaw != 0 || hyUSD.DeltaUSD != -5 {
+		t.Errorf("Expected the hyUSD balance to drop to zero, got %+v", hyUSD)
+	}
+	if sHyUSD.Token != tokens.SHyUSDSymbol || sHyUSD.Previous != "0" || sHyUSD.CurrentRaw != 5_000_000 {
+		t.Errorf("Expected a new sHYUSD balance, got %+v", sHyUSD)
+	}
+
+	// Price moves alone are not balance changes, nor are snapshots from a lagging node
+	if change := DiffSnapshots(previous, previous); change != nil {
+		t.Errorf("Expected no change, got %+v", change)
+	}
+	if change := DiffSnapshots(current, previous); change != nil {
+		t.Errorf("Expected an older snapshot to be ignored, got %+v", change)
+	}
+}
+
+func TestSyncWalletPublishesBalanceChanges(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	fetcher := &stubBalanceFetcher{balances: walletBalances(100, map[string]uint64{tokens.XSOLSymbol: 1_000_000})}
+	publisher := &recordingPublisher{}
+	ix := New(st, fetcher, &stubTradeFetcher{}, nil, DefaultConfig())
+	ix.SetEventPublisher(publisher)
+	prices := &price.CombinedPriceResponse{SOLUSD: 150, XSOLInSOL: 0.01, XSOLInUSD: 1.5}
+
+	// The first snapshot is the baseline
+	if err := ix.SyncWallet(context.Background(), testWallet.String(), prices); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.events) != 0 {
+		t.Fatalf("Expected no event for the first snapshot, got %+v", publisher.events)
+	}
+
+	// Unchanged balances publish nothing
+	fetcher.balances = walletBalances(110, map[string]uint64{tokens.XSOLSymbol: 1_000_000})
+	if err := ix.SyncWallet(context.Background(), testWallet.String(), prices); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.events) != 0 {
+		t.Fatalf("Expected no event for unchanged balances, got %+v", publisher.events)
+	}
+
+	fetcher.balances = walletBalances(120, map[string]uint64{tokens.XSOLSymbol: 3_000_000})
+	if err := ix.SyncWallet(context.Background(), testWallet.String(), prices); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != webhook.EventBalanceChanged {
+		t.Fatalf("Expected one balance.changed event, got %+v", publisher.events)
+	}
+	change := publisher.events[0].Data.(*BalanceChange)
+	if change.PreviousSlot != 110 || change.Slot != 120 || len(change.Changes) != 1 ||
+		change.Changes[0].PreviousRaw != 1_000_000 || change.Changes[0].CurrentRaw != 3_000_000 {
+		t.Errorf("Unexpected balance change %+v", change)
+	}
+}
@@ -0,0 +1,157 @@
+package indexer
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default indexer settings
+const (
+	DefaultInterval         = 60 * time.Second // Time between sync cycles
+	DefaultTradePageSize    = 50               // Trades requested per page (TradeService maximum)
+	DefaultMaxPagesPerSync  = 5                // Caps backfill work per wallet per cycle
+	DefaultReconcileDepth   = 100              // Recent trades re-scanned per wallet by a reconciliation
+	DefaultProtocolInterval = 60 * time.Second // Minimum time between protocol state snapshots
+	DefaultRetryMaxAttempts = 8                // Fetches of an unfetched transaction before it is reported as failed
+	DefaultRetryBackoff     = time.Minute      // Wait before the first refetch, doubled after each failure
+	DefaultRetryMaxFailed   = 100              // Failed transactions kept per wallet for the sync status
+	MaxRetryBackoff         = 6 * time.Hour
+	MaxReconcileDepth       = 1000
+)
+
+// Config holds indexer configuration
+type Config struct {
+	// Enabled starts the background sync loop
+	Enabled bool
+
+	// Interval is the time between sync cycles
+	Interval time.Duration
+
+	// TradePageSize is the number of trades requested per page
+	TradePageSize int
+
+	// MaxPagesPerSync limits how many trade pages are fetched per wallet per cycle
+	MaxPagesPerSync int
+
+	// ReconcileInterval is the time between background reconciliations of every indexed wallet
+	// against live RPC; 0 disables the job, leaving only on-demand runs through /admin/reconcile
+	ReconcileInterval time.Duration
+
+	// ReconcileDepth is the number of recent trades a background reconciliation re-scans per wallet
+	ReconcileDepth int
+
+	// ProtocolInterval is the minimum time between protocol state snapshots, recorded from price reads
+	// and on schedule for trade annotations and the protocol history; 0 disables recording
+	ProtocolInterval time.Duration
+
+	// RetryMaxAttempts is how many times a transaction that could not be fetched during sync is fetched
+	// before it is given up on and reported as a persistent failure; 0 disables the retry queue
+	RetryMaxAttempts int
+
+	// RetryBackoff is the wait before a queued transaction is refetched, doubled after each failure up to MaxRetryBackoff
+	// Refetches run at the end of each sync cycle, so the effective wait is rounded up to the sync interval
+	RetryBackoff time.Duration
+
+	// RetryMaxFailed caps the given-up transactions kept per wallet; the least recently attempted are dropped
+	// beyond it, so a wallet whose fetches keep failing does not grow the store without bound (0 keeps all)
+	RetryMaxFailed int
+
+	// LSTBalances records liquid staking token balances (jitoSOL) in snapshots alongside the Hylo tokens,
+	// one extra RPC read per wallet and cycle, so portfolios can value them at their SOL conversion rate
+	LSTBalances bool
+
+	// SeedWallets are tracked on startup in addition to wallets already in the store
+	SeedWallets []string
+}
+
+// DefaultConfig returns the default indexer configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:          true,
+		Interval:         DefaultInterval,
+		TradePageSize:    DefaultTradePageSize,
+		MaxPagesPerSync:  DefaultMaxPagesPerSync,
+		ReconcileDepth:   DefaultReconcileDepth,
+		ProtocolInterval: DefaultProtocolInterval,
+		RetryMaxAttempts: DefaultRetryMaxAttempts,
+		RetryBackoff:     DefaultRetryBackoff,
+		RetryMaxFailed:   DefaultRetryMaxFailed,
+		LSTBalances:      true,
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("INDEXER_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			config.Enabled = enabled
+		}
+	}
+
+	if value := os.Getenv("INDEXER_INTERVAL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.Interval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("INDEXER_MAX_PAGES_PER_SYNC"); value != "" {
+		if pages, err := strconv.Atoi(value); err == nil && pages > 0 {
+			config.MaxPagesPerSync = pages
+		}
+	}
+
+	if value := os.Getenv("INDEXER_RECONCILE_INTERVAL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			config.ReconcileInterval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("INDEXER_RECONCILE_DEPTH"); value != "" {
+		if depth, err := strconv.Atoi(value); err == nil && depth > 0 && depth <= MaxReconcileDepth {
+			config.ReconcileDepth = depth
+		}
+	}
+
+	if value := os.Getenv("PROTOCOL_SNAPSHOT_INTERVAL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			config.ProtocolInterval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("INDEXER_RETRY_MAX_ATTEMPTS"); value != "" {
+		if attempts, err := strconv.Atoi(value); err == nil && attempts >= 0 {
+			config.RetryMaxAttempts = attempts
+		}
+	}
+
+	if value := os.Getenv("INDEXER_RETRY_BACKOFF_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.RetryBackoff = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("INDEXER_RETRY_MAX_FAILED"); value != "" {
+		if failed, err := strconv.Atoi(value); err == nil && failed >= 0 {
+			config.RetryMaxFailed = failed
+		}
+	}
+
+	if value := os.Getenv("INDEXER_LST_BALANCES"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			config.LSTBalances = enabled
+		}
+	}
+
+	for _, wallet := range strings.Split(os.Getenv("TRACKED_WALLETS"), ",") {
+		if wallet = strings.TrimSpace(wallet); wallet != "" {
+			config.SeedWallets = append(config.SeedWallets, wallet)
+		}
+	}
+
+	return config
+}
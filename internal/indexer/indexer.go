@@ -0,0 +1,405 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/scheduler"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/trades"
+	"hylo-wallet-tracker-api/internal/utils"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// HyUSDPegUSD is the USD value assigned to one hyUSD in snapshots
+const HyUSDPegUSD = 1.0
+
+// hyUSDPeg is HyUSDPegUSD as a Decimal for valuation math
+var hyUSDPeg = utils.DecimalFromFloat(HyUSDPegUSD)
+
+// BalanceFetcher fetches current wallet balances
+type BalanceFetcher interface {
+	GetWalletBalances(ctx context.Context, wallet solana.Address) (*tokens.WalletBalances, error)
+}
+
+// TokenBalanceFetcher reads a single token balance of a wallet
+type TokenBalanceFetcher interface {
+	GetTokenBalance(ctx context.Context, wallet solana.Address, mint solana.Address) (*tokens.TokenBalance, error)
+}
+
+// TradeFetcher fetches wallet trade history pages
+type TradeFetcher interface {
+	GetTrades(ctx context.Context, wallet solana.Address, opts *trades.TradeRequest) (*trades.TradeResponse, error)
+}
+
+// PriceProvider supplies current prices used to value snapshots
+type PriceProvider interface {
+	GetCombinedPriceResponse(ctx context.Context) (*price.CombinedPriceResponse, error)
+}
+
+// EventPublisher receives notifications about newly indexed trades and balance changes
+type EventPublisher interface {
+	Publish(event webhook.Event)
+}
+
+// Indexer periodically snapshots balances and indexes new trades for every tracked wallet
+type Indexer struct {
+	store    store.Store
+	balances BalanceFetcher
+	trades   TradeFetcher
+	prices   PriceProvider
+	events   EventPublisher
+	config   *Config
+	logger   *logger.Logger
+
+	lstBalances TokenBalanceFetcher // Optional; reads the LST balances recorded next to the Hylo tokens
+	lstMints    []solana.Address
+
+	reportsMu sync.Mutex
+	reports   map[string]*ReconcileReport // Latest reconciliation per wallet
+}
+
+// New creates an indexer writing into st
+func New(st store.Store, balances BalanceFetcher, tradeFetcher TradeFetcher, prices PriceProvider, config *Config) *Indexer {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Indexer{
+		store:    st,
+		balances: balances,
+		trades:   tradeFetcher,
+		prices:   prices,
+		config:   config,
+		logger:   logger.NewFromEnv().WithComponent("indexer"),
+		reports:  make(map[string]*ReconcileReport),
+	}
+}
+
+// Run tracks seed wallets and syncs all tracked wallets every interval until ctx is cancelled
+// Seed wallets that were untracked stay paused across restarts
+// Sync RPC calls run at background priority so they yield to API requests
+func (ix *Indexer) Run(ctx context.Context) {
+	ctx = scheduler.WithPriority(ctx, scheduler.PriorityBackground)
+
+	for _, address := range ix.config.SeedWallets {
+		if _, err := ix.track(store.DefaultTenant, address, "", false); err != nil {
+			ix.logger.WarnContext(ctx, "Skipping invalid seed wallet",
+				slog.String("wallet", address),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	ticker := time.NewTicker(ix.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := ix.SyncAll(ctx); err != nil {
+			ix.logger.LogHandlerError(ctx, "indexer_sync", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SetEventPublisher enables trade.new notifications for trades indexed after a wallet's first sync
+// and balance.changed notifications once a wallet has a previous snapshot
+func (ix *Indexer) SetEventPublisher(events EventPublisher) {
+	ix.events = events
+}
+
+// SetLSTBalances records the wallets' balances of the given LST mints in every snapshot
+// Only non-zero balances are recorded; a failed read leaves the token out of that snapshot
+func (ix *Indexer) SetLSTBalances(fetcher TokenBalanceFetcher, mints ...solana.Address) {
+	ix.lstBalances = fetcher
+	ix.lstMints = mints
+}
+
+// TrackResult reports how a Track call changed the registry
+type TrackResult struct {
+	Wallet      store.TrackedWallet
+	Created     bool // Newly registered
+	Reactivated bool // Was paused; indexing resumes from the stored cursor
+}
+
+// Track validates and registers a wallet for indexing on behalf of tenant, reactivating it if the tenant untracked it
+// A reactivated wallet keeps its history and cursor, so the next sync only fetches newer trades
+func (ix *Indexer) Track(tenant, address, label string) (TrackResult, error) {
+	return ix.track(tenant, address, label, true)
+}
+
+func (ix *Indexer) track(tenant, address, label string, reactivate bool) (TrackResult, error) {
+	wallet, err := tokens.SanitizeAddress(address)
+	if err != nil {
+		return TrackResult{}, err
+	}
+
+	created, err := ix.store.TrackWallet(store.TrackedWallet{Tenant: tenant, Address: wallet.String(), Label: label})
+	if err != nil {
+		return TrackResult{}, err
+	}
+
+	tracked, _ := ix.store.GetWallet(tenant, wallet.String())
+	result := TrackResult{Wallet: tracked, Created: created}
+	if reactivate && tracked.Status == store.WalletStatusPaused {
+		if result.Wallet, err = ix.store.SetWalletStatus(tenant, wallet.String(), store.WalletStatusActive, ""); err != nil {
+			return TrackResult{}, err
+		}
+		result.Reactivated = true
+	}
+	return result, nil
+}
+
+// Untrack pauses the tenant's registration of a wallet while keeping its snapshots, trades and cursor
+// The wallet keeps being indexed while another tenant tracks it
+func (ix *Indexer) Untrack(tenant, address string) (store.TrackedWallet, error) {
+	wallet, err := tokens.SanitizeAddress(address)
+	if err != nil {
+		return store.TrackedWallet{}, err
+	}
+	return ix.store.SetWalletStatus(tenant, wallet.String(), store.WalletStatusPaused, "")
+}
+
+// indexedAddresses returns every wallet at least one tenant keeps indexed, once, in registration order
+func (ix *Indexer) indexedAddresses() []string {
+	return store.IndexedAddresses(ix.store.ListWallets())
+}
+
+// SyncAll syncs every tracked wallet that is not paused and flushes the store
+// A wallet tracked by several tenants is synced once; a failure for one wallet marks every tenant's
+// registration of it as errored and does not stop the others
+func (ix *Indexer) SyncAll(ctx context.Context) error {
+	wallets := ix.indexedAddresses()
+	if len(wallets) == 0 {
+		return ix.store.Flush()
+	}
+
+	prices, err := ix.prices.GetCombinedPriceResponse(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch prices for snapshots: %w", err)
+	}
+
+	startTime := time.Now()
+	failed := 0
+	for _, wallet := range wallets {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		status, lastError := store.WalletStatusActive, ""
+		if err := ix.SyncWallet(ctx, wallet, prices); err != nil {
+			failed++
+			status, lastError = store.WalletStatusError, err.Error()
+			ix.logger.WarnContext(ctx, "Wallet sync failed",
+				slog.String("wallet", wallet),
+				slog.String("error", err.Error()))
+		}
+		// Untrack may have paused a registration while the wallet was syncing
+		for _, current := range ix.store.WalletTenants(wallet) {
+			if !current.Indexed() {
+				continue
+			}
+			if _, err := ix.store.SetWalletStatus(current.Tenant, wallet, status, lastError); err != nil {
+				return fmt.Errorf("failed to update wallet status: %w", err)
+			}
+		}
+	}
+
+	ix.logger.InfoContext(ctx, "Indexer sync completed",
+		slog.Int("wallets", len(wallets)),
+		slog.Int("failed", failed),
+		slog.Duration("elapsed", time.Since(startTime)))
+
+	return ix.store.Flush()
+}
+
+// SyncWallet records a balance snapshot, indexes trades newer than the wallet's cursor and refetches
+// queued transactions that are due
+// A balance.changed event follows the cycle's trade.new events when any token balance moved since
+// the previous snapshot; the wallet's first snapshot is a baseline and publishes nothing
+func (ix *Indexer) SyncWallet(ctx context.Context, address string, prices *price.CombinedPriceResponse) error {
+	ctx = logger.WithWallet(ctx, address)
+	wallet := solana.Address(address)
+
+	balances, err := ix.balances.GetWalletBalances(ctx, wallet)
+	if err != nil {
+		return fmt.Errorf("failed to fetch balances: %w", err)
+	}
+	if ix.lstBalances != nil {
+		balances = ix.withLSTBalances(ctx, wallet, balances)
+	}
+
+	var previous *store.BalanceSnapshot
+	if latest, ok := ix.store.LatestSnapshot(address); ok {
+		copied := *latest
+		previous = &copied
+	}
+	snapshot := NewSnapshot(balances, prices)
+	if err := ix.store.AddSnapshot(snapshot); err != nil {
+		return fmt.Errorf("failed to store snapshot: %w", err)
+	}
+
+	err = ix.syncTrades(ctx, wallet)
+	if err == nil {
+		err = ix.retryTrades(ctx, wallet)
+	}
+	if previous != nil {
+		ix.publishBalanceChange(*previous, snapshot)
+	}
+	return err
+}
+
+// withLSTBalances returns a copy of balances with the wallet's non-zero LST balances added
+// The fetched balances may be shared with concurrent API requests, so they are not modified
+func (ix *Indexer) withLSTBalances(ctx context.Context, wallet solana.Address, balances *tokens.WalletBalances) *tokens.WalletBalances {
+	extended := *balances
+	extended.Balances = maps.Clone(balances.Balances)
+	for _, mint := range ix.lstMints {
+		balance, err := ix.lstBalances.GetTokenBalance(ctx, wallet, mint)
+		if err != nil {
+			ix.logger.WarnContext(ctx, "Failed to read LST balance; leaving it out of the snapshot",
+				slog.String("mint", mint.String()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if !balance.IsZero() {
+			extended.Balances[balance.TokenInfo.Symbol] = balance
+		}
+	}
+	return &extended
+}
+
+// syncTrades pages backwards from the newest trade until the stored cursor (or MaxPagesPerSync) is reached
+// If more than MaxPagesPerSync pages arrived since the last cycle the oldest of them are skipped
+// Transactions that could not be fetched are queued and refetched by retryTrades
+func (ix *Indexer) syncTrades(ctx context.Context, wallet solana.Address) error {
+	cursor, hasCursor := ix.store.Cursor(wallet.String())
+	// The trade index keeps dust so positions and reconciliation add up to the wallet's balance
+	req := &trades.TradeRequest{Limit: ix.config.TradePageSize, Until: cursor.LastSignature, IncludeDust: true}
+
+	var records []store.TradeRecord
+	var unfetched []trades.FetchFailure
+	for page := 0; page < ix.config.MaxPagesPerSync; page++ {
+		resp, err := ix.trades.GetTrades(ctx, wallet, req)
+		if err != nil {
+			return fmt.Errorf("failed to fetch trades: %w", err)
+		}
+
+		for _, trade := range resp.Trades {
+			records = append(records, store.NewTradeRecord(wallet.String(), trade))
+		}
+		unfetched = append(unfetched, resp.Unfetched...)
+
+		if !resp.Pagination.HasMore || resp.Pagination.NextCursor == "" {
+			break
+		}
+		req.Before = resp.Pagination.NextCursor
+	}
+
+	// The cursor moves past transactions that could not be fetched; the retry queue picks them up
+	if err := ix.queueRetries(ctx, wallet.String(), unfetched, !hasCursor, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to queue retries: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	added, err := ix.store.AddTrades(records)
+	if err != nil {
+		return fmt.Errorf("failed to store trades: %w", err)
+	}
+
+	// The first sync is a backfill; only trades discovered afterwards are announced
+	if hasCursor {
+		ix.publishTrades(added)
+	}
+
+	newest := records[0].Trade
+	return ix.store.SetCursor(wallet.String(), store.Cursor{
+		LastSignature: newest.Signature,
+		LastSlot:      newest.Slot,
+		UpdatedAt:     time.Now().UTC(),
+	})
+}
+
+// publishTrades emits one trade.new event per record, oldest first
+func (ix *Indexer) publishTrades(records []store.TradeRecord) {
+	if ix.events == nil {
+		return
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		trade := records[i].Trade
+		ix.events.Publish(webhook.Event{
+			Type:   webhook.EventTradeNew,
+			Wallet: records[i].Wallet,
+			Data:   &trade,
+		})
+	}
+}
+
+// NewSnapshot values wallet balances in USD
+// hyUSD is valued at its peg, xSOL at the current xSOL/USD price and sHYUSD at its stability pool claim when known
+func NewSnapshot(balances *tokens.WalletBalances, prices *price.CombinedPriceResponse) store.BalanceSnapshot {
+	snapshot := store.BalanceSnapshot{
+		Wallet:    balances.Wallet.String(),
+		Timestamp: balances.UpdatedAt.UTC(),
+		Slot:      uint64(balances.Slot),
+		Holdings:  make(map[string]store.Holding, len(balances.Balances)),
+
+		XSOLPriceUSD: prices.XSOLInUSD,
+	}
+	if snapshot.Timestamp.IsZero() {
+		snapshot.Timestamp = time.Now().UTC()
+	}
+
+	for symbol, balance := range balances.Balances {
+		if balance == nil {
+			continue
+		}
+
+		holding := store.Holding{
+			RawAmount:       balance.RawAmount,
+			FormattedAmount: balance.FormattedAmount,
+			USDValue:        valueUSD(symbol, balance, prices),
+		}
+		snapshot.Holdings[symbol] = holding
+		snapshot.TotalUSD += holding.USDValue
+	}
+
+	return snapshot
+}
+
+// valueUSD prices a balance with exact Decimal math; only the result is rounded to float64
+func valueUSD(symbol string, balance *tokens.TokenBalance, prices *price.CombinedPriceResponse) float64 {
+	xsolUSD := utils.DecimalFromFloat(prices.XSOLInUSD)
+	switch symbol {
+	case tokens.XSOLSymbol:
+		return units(balance).Mul(xsolUSD).Float64()
+	case tokens.SHyUSDSymbol:
+		if claim := balance.Underlying; claim != nil && claim.HyUSD != nil && claim.XSOL != nil {
+			return units(claim.HyUSD).Mul(hyUSDPeg).Add(units(claim.XSOL).Mul(xsolUSD)).Float64()
+		}
+		return units(balance).Mul(hyUSDPeg).Float64()
+	case tokens.HyUSDSymbol:
+		return units(balance).Mul(hyUSDPeg).Float64()
+	default:
+		return 0 // No price source for other tokens
+	}
+}
+
+func units(balance *tokens.TokenBalance) utils.Decimal {
+	return utils.NewDecimalFromRaw(balance.RawAmount, balance.Decimals)
+}
@@ -0,0 +1,229 @@
+package indexer
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/trades"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+const testWallet = solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+
+// stubTradeFetcher serves pre-built pages and records the requests it received
+type stubTradeFetcher struct {
+	pages    []*trades.TradeResponse
+	requests []trades.TradeRequest
+}
+
+func (f *stubTradeFetcher) GetTrades(ctx context.Context, wallet solana.Address, opts *trades.TradeRequest) (*trades.TradeResponse, error) {
+	f.requests = append(f.requests, *opts)
+	if len(f.requests) > len(f.pages) {
+		return &trades.TradeResponse{}, nil
+	}
+	return f.pages[len(f.requests)-1], nil
+}
+
+// recordingPublisher collects published events
+type recordingPublisher struct {
+	events []webhook.Event
+}
+
+func (p *recordingPublisher) Publish(event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func newTrade(signature string, slot uint64) *hylo.XSOLTrade {
+	trade := hylo.NewXSOLTrade(signature, slot, int64(slot))
+	trade.SetTradeDetails(hylo.TradeSideBuy, 1_000_000, 1_000_000_000, tokens.SOLSymbol)
+	return trade
+}
+
+func TestSyncTradesUsesCursor(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	fetcher := &stubTradeFetcher{pages: []*trades.TradeResponse{
+		{Trades: []*hylo.XSOLTrade{newTrade("sig3", 300), newTrade("sig2", 200)}, Pagination: trades.PaginationInfo{HasMore: true, NextCursor: "sig2"}},
+		{Trades: []*hylo.XSOLTrade{newTrade("sig1", 100)}},
+	}}
+	publisher := &recordingPublisher{}
+	ix := New(st, nil, fetcher, nil, DefaultConfig())
+	ix.SetEventPublisher(publisher)
+
+	if err := ix.syncTrades(context.Background(), testWallet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fetcher.requests) != 2 || fetcher.requests[1].Before != "sig2" {
+		t.Errorf("Expected second page requested before sig2, got %+v", fetcher.requests)
+	}
+	if cursor, _ := st.Cursor(testWallet.String()); cursor.LastSignature != "sig3" || cursor.LastSlot != 300 {
+		t.Errorf("Expected cursor at sig3, got %+v", cursor)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("Expected the initial backfill not to publish events, got %d", len(publisher.events))
+	}
+
+	// The next cycle only asks for trades newer than the cursor
+	fetcher.pages, fetcher.requests = nil, nil
+	if err := ix.syncTrades(context.Background(), testWallet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fetcher.requests) != 1 || fetcher.requests[0].Until != "sig3" {
+		t.Errorf("Expected request until sig3, got %+v", fetcher.requests)
+	}
+	if cursor, _ := st.Cursor(testWallet.String()); cursor.LastSignature != "sig3" {
+		t.Errorf("Expected cursor to stay at sig3 when no new trades, got %+v", cursor)
+	}
+
+	// Trades found after the backfill are announced oldest first
+	fetcher.pages, fetcher.requests = []*trades.TradeResponse{
+		{Trades: []*hylo.XSOLTrade{newTrade("sig5", 500), newTrade("sig4", 400)}},
+	}, nil
+	if err := ix.syncTrades(context.Background(), testWallet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.events) != 2 || publisher.events[0].Data.(*hylo.XSOLTrade).Signature != "sig4" {
+		t.Errorf("Expected trade.new events for sig4 then sig5, got %+v", publisher.events)
+	}
+	if publisher.events[0].Type != webhook.EventTradeNew || publisher.events[0].Wallet != testWallet.String() {
+		t.Errorf("Unexpected event %+v", publisher.events[0])
+	}
+}
+
+func TestUntrackAndRetrack(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	ix := New(st, nil, &stubTradeFetcher{}, nil, DefaultConfig())
+
+	if _, err := ix.Untrack(store.DefaultTenant, testWallet.String()); err != store.ErrWalletNotTracked {
+		t.Errorf("Expected ErrWalletNotTracked, got %v", err)
+	}
+
+	result, err := ix.Track(store.DefaultTenant, testWallet.String(), "")
+	if err != nil || !result.Created {
+		t.Fatalf("Expected wallet to be created, got %+v (%v)", result, err)
+	}
+	st.SetCursor(testWallet.String(), store.Cursor{LastSignature: "sig3", LastSlot: 300})
+
+	wallet, err := ix.Untrack(store.DefaultTenant, testWallet.String())
+	if err != nil || wallet.Status != store.WalletStatusPaused {
+		t.Fatalf("Expected paused wallet, got %+v (%v)", wallet, err)
+	}
+
+	// Paused wallets are skipped without fetching prices
+	if err := ix.SyncAll(context.Background()); err != nil {
+		t.Errorf("Expected paused wallets to be skipped, got %v", err)
+	}
+
+	// Seed tracking on restart must not undo an explicit untrack
+	if result, _ := ix.track(store.DefaultTenant, testWallet.String(), "", false); result.Reactivated || result.Wallet.Status != store.WalletStatusPaused {
+		t.Errorf("Expected seed tracking to leave the wallet paused, got %+v", result)
+	}
+
+	result, err = ix.Track(store.DefaultTenant, testWallet.String(), "")
+	if err != nil || result.Created || !result.Reactivated || result.Wallet.Status != store.WalletStatusActive {
+		t.Fatalf("Expected wallet to be reactivated, got %+v (%v)", result, err)
+	}
+
+	// Indexing resumes from the stored watermark
+	fetcher := &stubTradeFetcher{}
+	ix.trades = fetcher
+	if err := ix.syncTrades(context.Background(), testWallet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fetcher.requests) != 1 || fetcher.requests[0].Until != "sig3" {
+		t.Errorf("Expected sync to resume until sig3, got %+v", fetcher.requests)
+	}
+}
+
+func TestTrackPerTenant(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	ix := New(st, nil, &stubTradeFetcher{}, nil, DefaultConfig())
+
+	ix.Track("acme", testWallet.String(), "Acme")
+	if result, _ := ix.Track("globex", testWallet.String(), "Globex"); !result.Created {
+		t.Fatalf("Expected a second tenant to get its own registration, got %+v", result)
+	}
+	if addresses := ix.indexedAddresses(); len(addresses) != 1 {
+		t.Errorf("Expected the wallet to be synced once, got %v", addresses)
+	}
+
+	if _, err := ix.Untrack("acme", testWallet.String()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addresses := ix.indexedAddresses(); len(addresses) != 1 {
+		t.Errorf("Expected the wallet to stay indexed for globex, got %v", addresses)
+	}
+	if _, err := ix.Untrack(store.DefaultTenant, testWallet.String()); err != store.ErrWalletNotTracked {
+		t.Errorf("Expected the default tenant not to untrack another tenant's wallet, got %v", err)
+	}
+
+	ix.Untrack("globex", testWallet.String())
+	if addresses := ix.indexedAddresses(); len(addresses) != 0 {
+		t.Errorf("Expected no indexed wallets once every tenant untracked it, got %v", addresses)
+	}
+}
+
+func TestNewSnapshot(t *testing.T) {
+	balances := &tokens.WalletBalances{
+		Wallet: testWallet,
+		Slot:   42,
+		Balances: map[string]*tokens.TokenBalance{
+			tokens.XSOLSymbol:  {RawAmount: 200_000_000, Decimals: tokens.XSOLDecimals, FormattedAmount: "200"},
+			tokens.HyUSDSymbol: {RawAmount: 50_000_000, Decimals: tokens.HyUSDDecimals, FormattedAmount: "50"},
+			tokens.SHyUSDSymbol: {
+				RawAmount: 10_000_000, Decimals: tokens.SHyUSDDecimals, FormattedAmount: "10",
+				Underlying: &tokens.StabilityPoolClaim{
+					HyUSD: &tokens.TokenBalance{RawAmount: 8_000_000, Decimals: tokens.HyUSDDecimals},
+					XSOL:  &tokens.TokenBalance{RawAmount: 4_000_000, Decimals: tokens.XSOLDecimals},
+				},
+			},
+		},
+	}
+
+	snapshot := NewSnapshot(balances, &price.CombinedPriceResponse{XSOLInUSD: 0.5})
+
+	if snapshot.Slot != 42 || snapshot.Timestamp.IsZero() {
+		t.Errorf("Expected slot and timestamp to be set, got %+v", snapshot)
+	}
+	if got := snapshot.Holdings[tokens.XSOLSymbol].USDValue; got != 100 {
+		t.Errorf("Expected xSOL value $100, got %f", got)
+	}
+	if got := snapshot.Holdings[tokens.SHyUSDSymbol].USDValue; got != 10 {
+		t.Errorf("Expected sHYUSD claim value $10, got %f", got)
+	}
+	if math.Abs(snapshot.TotalUSD-160) > 1e-9 {
+		t.Errorf("Expected total $160, got %f", snapshot.TotalUSD)
+	}
+}
+
+// stubTokenBalances returns a fixed raw amount per mint
+type stubTokenBalances map[solana.Address]uint64
+
+func (s stubTokenBalances) GetTokenBalance(ctx context.Context, wallet solana.Address, mint solana.Address) (*tokens.TokenBalance, error) {
+	return tokens.NewConfig().NewTokenBalance(mint, s[mint]), nil
+}
+
+func TestWithLSTBalances(t *testing.T) {
+	config := tokens.NewConfig()
+	ix := New(nil, nil, nil, nil, DefaultConfig())
+	ix.SetLSTBalances(stubTokenBalances{config.JitoSOLMint: 2_000_000_000}, config.JitoSOLMint, config.USDCMint)
+
+	fetched := tokens.NewWalletBalances(testWallet, 1)
+	fetched.AddBalance(config.NewTokenBalanceBySymbol(tokens.XSOLSymbol, 1_000_000))
+
+	extended := ix.withLSTBalances(context.Background(), testWallet, fetched)
+	if balance, ok := extended.GetBalance(tokens.JitoSOLSymbol); !ok || balance.FormattedAmount != "2" {
+		t.Errorf("Expected 2 jitoSOL recorded, got %+v", extended.Balances)
+	}
+	if _, ok := extended.GetBalance(tokens.USDCSymbol); ok {
+		t.Error("Expected zero LST balances to be left out")
+	}
+	if _, ok := fetched.GetBalance(tokens.JitoSOLSymbol); ok || len(fetched.Balances) != 1 {
+		t.Errorf("Expected the fetched balances to be left untouched, got %+v", fetched.Balances)
+	}
+}
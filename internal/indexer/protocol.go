@@ -0,0 +1,142 @@
+package indexer
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/scheduler"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// protocolSlotTimeout bounds the slot read behind each protocol snapshot
+const protocolSlotTimeout = 10 * time.Second
+
+// SlotReader reads the current slot; implemented by solana.ChainClient
+type SlotReader interface {
+	GetSlot(ctx context.Context, commitment solana.Commitment) (solana.Slot, error)
+}
+
+// ProtocolStateSource reads the current protocol state; implemented by hylo.PriceService
+type ProtocolStateSource interface {
+	GetProtocolState(ctx context.Context) (*hylo.HyloProtocolState, error)
+}
+
+// ProtocolRecorder stores the protocol states read for pricing as snapshots, at most one per interval
+// Account reads do not report their slot, so the finalized slot is read alongside in the background
+type ProtocolRecorder struct {
+	store    store.Store
+	slots    SlotReader
+	interval time.Duration
+	logger   *logger.Logger
+	now      func() time.Time
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// NewProtocolRecorder creates a recorder writing into st
+func NewProtocolRecorder(st store.Store, slots SlotReader, interval time.Duration) *ProtocolRecorder {
+	return &ProtocolRecorder{
+		store:    st,
+		slots:    slots,
+		interval: interval,
+		logger:   logger.NewFromEnv().WithComponent("protocol-recorder"),
+		now:      time.Now,
+	}
+}
+
+// RecordProtocolState implements hylo.StateRecorder
+func (r *ProtocolRecorder) RecordProtocolState(state *hylo.HyloProtocolState) {
+	if state == nil {
+		return
+	}
+
+	if !r.claim(r.interval) {
+		return
+	}
+	go r.record(NewProtocolSnapshot(state))
+}
+
+// Run reads and records the protocol state every interval until ctx is cancelled, so the history
+// keeps growing while no price reads record it. A tick is skipped when a price read recorded a
+// snapshot within the last half interval
+func (r *ProtocolRecorder) Run(ctx context.Context, source ProtocolStateSource) {
+	ctx = scheduler.WithPriority(ctx, scheduler.PriorityBackground)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !r.claim(r.interval / 2) {
+			continue
+		}
+		state, err := source.GetProtocolState(ctx)
+		if err != nil {
+			r.logger.LogHandlerError(ctx, "protocol_snapshot", err)
+			continue
+		}
+		r.record(NewProtocolSnapshot(state))
+	}
+}
+
+// claim reserves the next snapshot when none was recorded within gap
+func (r *ProtocolRecorder) claim(gap time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if !r.last.IsZero() && now.Sub(r.last) < gap {
+		return false
+	}
+	r.last = now
+	return true
+}
+
+// NewProtocolSnapshot captures the health metrics of a protocol state
+func NewProtocolSnapshot(state *hylo.HyloProtocolState) store.ProtocolSnapshot {
+	timestamp := state.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	return store.ProtocolSnapshot{
+		Slot:              state.Slot,
+		Timestamp:         timestamp.UTC(),
+		CollateralRatio:   state.CollateralRatio,
+		EffectiveLeverage: state.EffectiveLeverage,
+		SOLPriceUSD:       state.SOLPriceUSD,
+		XSOLPriceUSD:      state.XSOLNAVInSOL * state.SOLPriceUSD,
+		HyUSDSupply:       state.HyUSDSupply,
+		XSOLSupply:        state.XSOLSupply,
+		SOLReserve:        state.TotalSOLReserve,
+	}
+}
+
+// record stores the snapshot, reading the finalized slot first when the state did not carry one
+func (r *ProtocolRecorder) record(snapshot store.ProtocolSnapshot) {
+	if snapshot.Slot == 0 {
+		ctx, cancel := context.WithTimeout(scheduler.WithPriority(context.Background(), scheduler.PriorityBackground), protocolSlotTimeout)
+		defer cancel()
+
+		current, err := r.slots.GetSlot(ctx, solana.CommitmentFinalized)
+		if err != nil {
+			r.logger.WarnContext(ctx, "Skipping protocol snapshot: slot read failed", slog.String("error", err.Error()))
+			return
+		}
+		snapshot.Slot = uint64(current)
+	}
+
+	if err := r.store.AddProtocolSnapshot(snapshot); err != nil {
+		r.logger.Warn("Failed to store protocol snapshot", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,120 @@
+package indexer
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// Protocol history range defaults
+const (
+	DefaultProtocolHistoryRange = "30d"
+	MaxProtocolHistoryRange     = 365 * 24 * time.Hour
+	// MaxProtocolHistoryPoints caps the points per response; longer ranges are downsampled
+	MaxProtocolHistoryPoints = 500
+)
+
+// lamportsPerSOL converts the recorded reserve to SOL
+const lamportsPerSOL = 1_000_000_000
+
+// ProtocolHistory is a time series of recorded protocol states
+type ProtocolHistory struct {
+	Range string    `json:"range"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	// IntervalSeconds is the bucket width points were sampled at; 0 when every snapshot is returned
+	IntervalSeconds int64                  `json:"interval_seconds"`
+	Points          []ProtocolHistoryPoint `json:"points"`
+}
+
+// ProtocolHistoryPoint is one recorded protocol state; supplies are in tokens and the reserve in SOL
+type ProtocolHistoryPoint struct {
+	Timestamp         time.Time `json:"timestamp"`
+	Slot              uint64    `json:"slot"`
+	CollateralRatio   float64   `json:"collateral_ratio"`
+	EffectiveLeverage float64   `json:"effective_leverage"`
+	HyUSDSupply       float64   `json:"hyusd_supply"`
+	XSOLSupply        float64   `json:"xsol_supply"`
+	SOLReserve        float64   `json:"sol_reserve"`
+	SOLPriceUSD       float64   `json:"sol_price_usd"`
+	XSOLPriceUSD      float64   `json:"xsol_price_usd"`
+}
+
+// ParseProtocolHistoryRange accepts a whole number of hours or days, e.g. 24h or 30d
+func ParseProtocolHistoryRange(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid range %q: must be a number of hours or days such as 24h or 30d, at most %dd",
+		value, int(MaxProtocolHistoryRange/(24*time.Hour)))
+	if len(value) < 2 {
+		return 0, invalid
+	}
+
+	count, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || count < 1 {
+		return 0, invalid
+	}
+	var length time.Duration
+	switch value[len(value)-1] {
+	case 'h':
+		length = time.Duration(count) * time.Hour
+	case 'd':
+		length = time.Duration(count) * 24 * time.Hour
+	default:
+		return 0, invalid
+	}
+	if length > MaxProtocolHistoryRange {
+		return 0, invalid
+	}
+	return length, nil
+}
+
+// BuildProtocolHistory returns the snapshots recorded in the length before now
+// When they outnumber MaxProtocolHistoryPoints, the range is split into that many buckets and the
+// last snapshot of each bucket is kept
+func BuildProtocolHistory(st store.Store, label string, length time.Duration, now time.Time) *ProtocolHistory {
+	now = now.UTC()
+	from := now.Add(-length)
+	history := &ProtocolHistory{Range: label, From: from, To: now, Points: []ProtocolHistoryPoint{}}
+
+	snapshots := st.ProtocolSnapshotsSince(from)
+	if len(snapshots) > MaxProtocolHistoryPoints {
+		interval := (length + MaxProtocolHistoryPoints - 1) / MaxProtocolHistoryPoints
+		history.IntervalSeconds = int64(interval.Seconds())
+		snapshots = lastPerBucket(snapshots, from, interval)
+	}
+
+	for _, snapshot := range snapshots {
+		history.Points = append(history.Points, ProtocolHistoryPoint{
+			Timestamp:         snapshot.Timestamp,
+			Slot:              snapshot.Slot,
+			CollateralRatio:   snapshot.CollateralRatio,
+			EffectiveLeverage: snapshot.EffectiveLeverage,
+			HyUSDSupply:       utils.NewDecimalFromRaw(snapshot.HyUSDSupply, tokens.HyUSDDecimals).Float64(),
+			XSOLSupply:        utils.NewDecimalFromRaw(snapshot.XSOLSupply, tokens.XSOLDecimals).Float64(),
+			SOLReserve:        float64(snapshot.SOLReserve) / lamportsPerSOL,
+			SOLPriceUSD:       snapshot.SOLPriceUSD,
+			XSOLPriceUSD:      snapshot.XSOLPriceUSD,
+		})
+	}
+	return history
+}
+
+// lastPerBucket keeps the newest of the time-ordered snapshots in each interval-wide bucket after from
+// Snapshots at the very end of the range fall into the last bucket
+func lastPerBucket(snapshots []store.ProtocolSnapshot, from time.Time, interval time.Duration) []store.ProtocolSnapshot {
+	bucketOf := func(snapshot store.ProtocolSnapshot) time.Duration {
+		return min(snapshot.Timestamp.Sub(from)/interval, MaxProtocolHistoryPoints-1)
+	}
+
+	var sampled []store.ProtocolSnapshot
+	for i, snapshot := range snapshots {
+		if i+1 < len(snapshots) && bucketOf(snapshots[i+1]) == bucketOf(snapshot) {
+			continue
+		}
+		sampled = append(sampled, snapshot)
+	}
+	return sampled
+}
@@ -0,0 +1,144 @@
+package indexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+type stubSlotReader struct {
+	slot  solana.Slot
+	reads chan struct{}
+}
+
+func (s *stubSlotReader) GetSlot(ctx context.Context, commitment solana.Commitment) (solana.Slot, error) {
+	defer func() { s.reads <- struct{}{} }()
+	return s.slot, nil
+}
+
+func TestProtocolRecorder(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	slots := &stubSlotReader{slot: 1000, reads: make(chan struct{}, 2)}
+	recorder := NewProtocolRecorder(st, slots, time.Minute)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now }
+
+	state := &hylo.HyloProtocolState{Timestamp: now, CollateralRatio: 1.6, EffectiveLeverage: 2.5, SOLPriceUSD: 150, XSOLNAVInSOL: 0.004}
+	recorder.RecordProtocolState(state)
+	<-slots.reads
+
+	// Within the interval nothing is read or stored
+	now = now.Add(30 * time.Second)
+	recorder.RecordProtocolState(state)
+	select {
+	case <-slots.reads:
+		t.Fatal("Expected states within the interval to be skipped")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The slot is read in the background; wait until the snapshot lands
+	deadline := time.Now().Add(time.Second)
+	for {
+		if snapshot, ok := st.ProtocolSnapshotNear(1000); ok {
+			if snapshot.Slot != 1000 || snapshot.CollateralRatio != 1.6 || snapshot.XSOLPriceUSD != 0.6 {
+				t.Errorf("Expected the state recorded at the finalized slot, got %+v", snapshot)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected a protocol snapshot to be stored")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+type stubStateSource struct {
+	state *hylo.HyloProtocolState
+}
+
+func (s stubStateSource) GetProtocolState(ctx context.Context) (*hylo.HyloProtocolState, error) {
+	return s.state, nil
+}
+
+func TestProtocolRecorderRun(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	recorder := NewProtocolRecorder(st, &stubSlotReader{reads: make(chan struct{}, 10)}, 10*time.Millisecond)
+	source := stubStateSource{state: &hylo.HyloProtocolState{Slot: 500, Timestamp: time.Now(), CollateralRatio: 1.5, HyUSDSupply: 2_000_000, TotalSOLReserve: 3_000_000_000}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		recorder.Run(ctx, source)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if snapshot, ok := st.ProtocolSnapshotNear(500); ok {
+			if snapshot.HyUSDSupply != 2_000_000 || snapshot.SOLReserve != 3_000_000_000 {
+				t.Errorf("Expected supplies and reserve to be recorded, got %+v", snapshot)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the scheduled read to store a protocol snapshot")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestParseProtocolHistoryRange(t *testing.T) {
+	valid := map[string]time.Duration{"24h": 24 * time.Hour, "30d": 30 * 24 * time.Hour, "365d": MaxProtocolHistoryRange}
+	for value, want := range valid {
+		if got, err := ParseProtocolHistoryRange(value); err != nil || got != want {
+			t.Errorf("ParseProtocolHistoryRange(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "d", "0d", "-1d", "30", "30m", "1.5d", "366d"} {
+		if _, err := ParseProtocolHistoryRange(value); err == nil {
+			t.Errorf("ParseProtocolHistoryRange(%q) expected an error", value)
+		}
+	}
+}
+
+func TestBuildProtocolHistory(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 48; i++ {
+		st.AddProtocolSnapshot(store.ProtocolSnapshot{
+			Slot:        uint64(i + 1),
+			Timestamp:   now.Add(time.Duration(i-48) * time.Hour),
+			HyUSDSupply: 1_500_000,
+			SOLReserve:  2_500_000_000,
+		})
+	}
+
+	history := BuildProtocolHistory(st, "24h", 24*time.Hour, now)
+	if len(history.Points) != 24 || history.IntervalSeconds != 0 {
+		t.Fatalf("Expected 24 hourly points, got %d at interval %d", len(history.Points), history.IntervalSeconds)
+	}
+	if point := history.Points[0]; point.Slot != 25 || point.HyUSDSupply != 1.5 || point.SOLReserve != 2.5 {
+		t.Errorf("Unexpected first point: %+v", point)
+	}
+
+	// Every 10 seconds for two days: 17,280 snapshots downsampled to one per bucket
+	st, _ = store.NewMemoryStore(store.DefaultConfig())
+	for i := 0; i < 2*24*360; i++ {
+		st.AddProtocolSnapshot(store.ProtocolSnapshot{Slot: uint64(i + 1), Timestamp: now.Add(time.Duration(i-2*24*360) * 10 * time.Second)})
+	}
+	history = BuildProtocolHistory(st, "2d", 48*time.Hour, now)
+	if len(history.Points) > MaxProtocolHistoryPoints || history.IntervalSeconds == 0 {
+		t.Fatalf("Expected at most %d downsampled points, got %d at interval %d", MaxProtocolHistoryPoints, len(history.Points), history.IntervalSeconds)
+	}
+	if last := history.Points[len(history.Points)-1]; last.Slot != 2*24*360 {
+		t.Errorf("Expected the newest snapshot to be kept, got slot %d", last.Slot)
+	}
+}
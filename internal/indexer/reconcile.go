@@ -0,0 +1,236 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/scheduler"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/trades"
+)
+
+// ReconcileReport is the difference between a wallet's trade index and the trades live RPC returns
+// for its most recent signatures. Only the slot range both sides cover is compared: trades newer than
+// the sync cursor are counted as pending, and when the scan stops at Depth the oldest scanned slot is
+// left out because it may hold further trades that were not scanned
+type ReconcileReport struct {
+	Wallet    string           `json:"wallet"`
+	Depth     int              `json:"depth"`     // Recent trades requested from RPC
+	Scanned   int              `json:"scanned"`   // Trades RPC returned
+	FromSlot  uint64           `json:"from_slot"` // Oldest slot compared; 0 when the whole history was scanned
+	ToSlot    uint64           `json:"to_slot"`   // Sync cursor slot; newer trades are pending
+	Matched   int              `json:"matched"`   // Trades identical on both sides
+	Pending   int              `json:"pending"`   // Live trades newer than the cursor, indexed by the next sync
+	Missing   []hylo.XSOLTrade `json:"missing"`   // Live trades absent from the index
+	Extra     []hylo.XSOLTrade `json:"extra"`     // Indexed trades RPC no longer returns
+	Changed   []TradeDiff      `json:"changed"`   // Trades whose indexed fields differ from a fresh parse
+	Drift     bool             `json:"drift"`
+	CheckedAt time.Time        `json:"checked_at"`
+}
+
+// TradeDiff lists the fields of one trade that differ between the index and live RPC
+type TradeDiff struct {
+	Signature string      `json:"signature"`
+	Fields    []FieldDiff `json:"fields"`
+}
+
+// FieldDiff is one differing trade field, named as in the trade JSON
+type FieldDiff struct {
+	Field   string `json:"field"`
+	Indexed string `json:"indexed"`
+	Live    string `json:"live"`
+}
+
+// Reconcile re-scans the wallet's depth most recent trades from RPC and diffs them against the trade index
+// The report is kept as the wallet's latest; the index itself is not modified
+func (ix *Indexer) Reconcile(ctx context.Context, address string, depth int) (*ReconcileReport, error) {
+	if len(ix.store.WalletTenants(address)) == 0 {
+		return nil, store.ErrWalletNotTracked
+	}
+	if depth <= 0 {
+		depth = ix.config.ReconcileDepth
+	}
+	ctx = logger.WithWallet(ctx, address)
+
+	// The cursor is read first so trades a concurrent sync indexes during the scan count as pending
+	cursor, hasCursor := ix.store.Cursor(address)
+	live, complete, err := ix.scanRecentTrades(ctx, solana.Address(address), depth)
+	if err != nil {
+		metrics.RecordReconciliation(metrics.ReconcileError)
+		return nil, err
+	}
+
+	report := &ReconcileReport{
+		Wallet:    address,
+		Depth:     depth,
+		Scanned:   len(live),
+		ToSlot:    cursor.LastSlot,
+		Missing:   []hylo.XSOLTrade{},
+		Extra:     []hylo.XSOLTrade{},
+		Changed:   []TradeDiff{},
+		CheckedAt: time.Now().UTC(),
+	}
+	if !complete && len(live) > 0 {
+		report.FromSlot = live[len(live)-1].Slot + 1
+	}
+
+	indexed := make(map[string]store.TradeRecord)
+	for _, record := range ix.store.TradesSince(address, time.Time{}) {
+		if record.Trade.Slot >= report.FromSlot && (!hasCursor || record.Trade.Slot <= cursor.LastSlot) {
+			indexed[record.Trade.Signature] = record
+		}
+	}
+
+	for _, trade := range live {
+		switch {
+		case !hasCursor || trade.Slot > cursor.LastSlot:
+			report.Pending++
+			continue
+		case trade.Slot < report.FromSlot:
+			continue
+		}
+
+		record, ok := indexed[trade.Signature]
+		if !ok {
+			report.Missing = append(report.Missing, *trade)
+			continue
+		}
+		delete(indexed, trade.Signature)
+
+		if fields := diffTrade(record, trade); len(fields) > 0 {
+			report.Changed = append(report.Changed, TradeDiff{Signature: trade.Signature, Fields: fields})
+		} else {
+			report.Matched++
+		}
+	}
+
+	for _, record := range indexed {
+		report.Extra = append(report.Extra, record.Trade)
+	}
+	sort.Slice(report.Extra, func(i, j int) bool { return report.Extra[i].Slot > report.Extra[j].Slot })
+	report.Drift = len(report.Missing)+len(report.Extra)+len(report.Changed) > 0
+
+	ix.recordReport(ctx, report)
+	return report, nil
+}
+
+// scanRecentTrades pages back from the newest trade until depth trades were collected
+// complete reports whether the wallet's whole history fit within depth
+func (ix *Indexer) scanRecentTrades(ctx context.Context, wallet solana.Address, depth int) ([]*hylo.XSOLTrade, bool, error) {
+	var live []*hylo.XSOLTrade
+	req := &trades.TradeRequest{IncludeDust: true} // Compared against the trade index, which keeps dust
+	for len(live) < depth {
+		req.Limit = min(ix.config.TradePageSize, depth-len(live))
+		resp, err := ix.trades.GetTrades(ctx, wallet, req)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to fetch trades: %w", err)
+		}
+		live = append(live, resp.Trades...)
+
+		if !resp.Pagination.HasMore || resp.Pagination.NextCursor == "" {
+			return live, true, nil
+		}
+		req.Before = resp.Pagination.NextCursor
+	}
+	return live[:depth], false, nil
+}
+
+// diffTrade compares the fields a trade is indexed and reported by
+func diffTrade(record store.TradeRecord, live *hylo.XSOLTrade) []FieldDiff {
+	indexed := record.Trade
+	pairs := []FieldDiff{
+		{Field: "slot", Indexed: strconv.FormatUint(indexed.Slot, 10), Live: strconv.FormatUint(live.Slot, 10)},
+		{Field: "blockTime", Indexed: strconv.FormatInt(indexed.BlockTime, 10), Live: strconv.FormatInt(live.BlockTime, 10)},
+		{Field: "side", Indexed: indexed.Side, Live: live.Side},
+		{Field: "status", Indexed: indexed.Status, Live: live.Status},
+		{Field: "xsolAmount", Indexed: indexed.XSOLAmount, Live: live.XSOLAmount},
+		{Field: "counterAsset", Indexed: indexed.CounterAsset, Live: live.CounterAsset},
+		{Field: "counterAmount", Indexed: indexed.CounterAmount, Live: live.CounterAmount},
+	}
+
+	var fields []FieldDiff
+	for _, pair := range pairs {
+		if pair.Indexed != pair.Live {
+			fields = append(fields, pair)
+		}
+	}
+	return fields
+}
+
+// recordReport keeps the report as the wallet's latest and reports drift
+func (ix *Indexer) recordReport(ctx context.Context, report *ReconcileReport) {
+	ix.reportsMu.Lock()
+	ix.reports[report.Wallet] = report
+	ix.reportsMu.Unlock()
+
+	if !report.Drift {
+		metrics.RecordReconciliation(metrics.ReconcileClean)
+		return
+	}
+	metrics.RecordReconciliation(metrics.ReconcileDrift)
+	metrics.RecordReconcileDrift(metrics.ReconcileMissing, len(report.Missing))
+	metrics.RecordReconcileDrift(metrics.ReconcileExtra, len(report.Extra))
+	metrics.RecordReconcileDrift(metrics.ReconcileChanged, len(report.Changed))
+	ix.logger.WarnContext(ctx, "Trade index drift detected",
+		slog.String("wallet", report.Wallet),
+		slog.Int("missing", len(report.Missing)),
+		slog.Int("extra", len(report.Extra)),
+		slog.Int("changed", len(report.Changed)))
+}
+
+// ReconcileReports returns the latest report of every reconciled wallet, ordered by wallet
+func (ix *Indexer) ReconcileReports() []ReconcileReport {
+	ix.reportsMu.Lock()
+	defer ix.reportsMu.Unlock()
+
+	reports := make([]ReconcileReport, 0, len(ix.reports))
+	for _, report := range ix.reports {
+		reports = append(reports, *report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Wallet < reports[j].Wallet })
+	return reports
+}
+
+// RunReconciliation reconciles every indexed wallet each ReconcileInterval until ctx is cancelled
+// The first pass waits one interval so it does not compete with the initial sync
+func (ix *Indexer) RunReconciliation(ctx context.Context) {
+	ctx = scheduler.WithPriority(ctx, scheduler.PriorityBackground)
+
+	ticker := time.NewTicker(ix.config.ReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := ix.ReconcileAll(ctx); err != nil {
+			ix.logger.LogHandlerError(ctx, "indexer_reconcile", err)
+		}
+	}
+}
+
+// ReconcileAll reconciles every indexed wallet at ReconcileDepth; a failure for one wallet does not stop the others
+func (ix *Indexer) ReconcileAll(ctx context.Context) error {
+	var errs []error
+	for _, wallet := range ix.indexedAddresses() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := ix.Reconcile(ctx, wallet, ix.config.ReconcileDepth); err != nil {
+			errs = append(errs, fmt.Errorf("wallet %s: %w", wallet, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,108 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/trades"
+)
+
+func newReconcileIndexer(t *testing.T, indexed []*hylo.XSOLTrade, cursorSlot uint64, pages ...*trades.TradeResponse) (*Indexer, *stubTradeFetcher) {
+	t.Helper()
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	if _, err := st.TrackWallet(store.TrackedWallet{Address: testWallet.String()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	records := make([]store.TradeRecord, 0, len(indexed))
+	for _, trade := range indexed {
+		records = append(records, store.NewTradeRecord(testWallet.String(), trade))
+	}
+	if _, err := st.AddTrades(records); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cursorSlot > 0 {
+		st.SetCursor(testWallet.String(), store.Cursor{LastSlot: cursorSlot, UpdatedAt: time.Now()})
+	}
+
+	fetcher := &stubTradeFetcher{pages: pages}
+	return New(st, nil, fetcher, nil, DefaultConfig()), fetcher
+}
+
+func TestReconcileReportsDrift(t *testing.T) {
+	reparsed := newTrade("sig3", 300)
+	reparsed.SetTradeDetails(hylo.TradeSideBuy, 1_000_000, 2_000_000, tokens.HyUSDSymbol)
+
+	ix, _ := newReconcileIndexer(t,
+		[]*hylo.XSOLTrade{newTrade("sig4", 400), newTrade("sig3", 300), newTrade("dropped", 250), newTrade("sig1", 100)},
+		400,
+		&trades.TradeResponse{
+			Trades:     []*hylo.XSOLTrade{newTrade("sig5", 500), newTrade("sig4", 400), reparsed},
+			Pagination: trades.PaginationInfo{HasMore: true, NextCursor: "sig3"},
+		},
+		&trades.TradeResponse{Trades: []*hylo.XSOLTrade{newTrade("sig2", 200), newTrade("sig1", 100)}},
+	)
+
+	report, err := ix.Reconcile(context.Background(), testWallet.String(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Scanned != 5 || report.FromSlot != 0 || report.ToSlot != 400 {
+		t.Errorf("Expected the whole history scanned up to the cursor, got %+v", report)
+	}
+	if report.Pending != 1 || report.Matched != 2 {
+		t.Errorf("Expected sig5 pending and sig4, sig1 matched, got pending %d matched %d", report.Pending, report.Matched)
+	}
+	if len(report.Missing) != 1 || report.Missing[0].Signature != "sig2" {
+		t.Errorf("Expected sig2 missing from the index, got %+v", report.Missing)
+	}
+	if len(report.Extra) != 1 || report.Extra[0].Signature != "dropped" {
+		t.Errorf("Expected the dropped trade reported as extra, got %+v", report.Extra)
+	}
+	if len(report.Changed) != 1 || report.Changed[0].Signature != "sig3" || len(report.Changed[0].Fields) != 2 ||
+		report.Changed[0].Fields[0].Field != "counterAsset" || report.Changed[0].Fields[1].Field != "counterAmount" {
+		t.Errorf("Expected sig3's counter asset and amount changed, got %+v", report.Changed)
+	}
+	if !report.Drift {
+		t.Error("Expected drift")
+	}
+	if reports := ix.ReconcileReports(); len(reports) != 1 || reports[0].Wallet != testWallet.String() {
+		t.Errorf("Expected the report kept as the wallet's latest, got %+v", reports)
+	}
+}
+
+func TestReconcileStopsAtDepth(t *testing.T) {
+	// The scan ends inside slot 200, which may hold more trades, so only newer slots are compared
+	ix, fetcher := newReconcileIndexer(t,
+		[]*hylo.XSOLTrade{newTrade("sig3", 300), newTrade("sig2b", 200), newTrade("sig1", 100)},
+		300,
+		&trades.TradeResponse{
+			Trades:     []*hylo.XSOLTrade{newTrade("sig3", 300), newTrade("sig2a", 200)},
+			Pagination: trades.PaginationInfo{HasMore: true, NextCursor: "sig2a"},
+		},
+	)
+
+	report, err := ix.Reconcile(context.Background(), testWallet.String(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fetcher.requests) != 1 || fetcher.requests[0].Limit != 2 {
+		t.Errorf("Expected a single page of 2 trades, got %+v", fetcher.requests)
+	}
+	if report.FromSlot != 201 || report.Matched != 1 || report.Drift {
+		t.Errorf("Expected only slots after 200 compared and no drift, got %+v", report)
+	}
+}
+
+func TestReconcileUntrackedWallet(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	ix := New(st, nil, &stubTradeFetcher{}, nil, DefaultConfig())
+	if _, err := ix.Reconcile(context.Background(), testWallet.String(), 10); !errors.Is(err, store.ErrWalletNotTracked) {
+		t.Errorf("Expected ErrWalletNotTracked, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package indexer
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/trades"
+)
+
+// maxRetriesPerSync caps the refetches made for one wallet per sync cycle
+const maxRetriesPerSync = 20
+
+// TradeRefetcher fetches a single transaction for a wallet; trades.TradeService implements it
+// Without one the retry queue still records unfetched signatures but never refetches them
+type TradeRefetcher interface {
+	FetchWalletTrade(ctx context.Context, wallet solana.Address, signature string) (*hylo.XSOLTrade, error)
+}
+
+// SyncStatus is how complete a tracked wallet's trade index is
+type SyncStatus struct {
+	Wallet store.TrackedWallet `json:"wallet"`
+	Cursor *store.Cursor       `json:"cursor,omitempty"` // Newest indexed signature; absent before the first sync
+	// Pending are transactions that could not be fetched during sync and are still being retried
+	Pending []store.RetryEntry `json:"pending"`
+	// Failed are transactions whose retries ran out; their trades, if any, are missing from the index
+	Failed []store.RetryEntry `json:"failed"`
+	// Complete is true once the wallet was synced and no transaction is pending or failed
+	Complete bool `json:"complete"`
+}
+
+// SyncStatus reports the tenant's registration of a wallet with its cursor and retry queue
+func (ix *Indexer) SyncStatus(tenant, address string) (*SyncStatus, error) {
+	wallet, ok := ix.store.GetWallet(tenant, address)
+	if !ok {
+		return nil, store.ErrWalletNotTracked
+	}
+
+	status := &SyncStatus{Wallet: wallet, Pending: []store.RetryEntry{}, Failed: []store.RetryEntry{}}
+	if cursor, ok := ix.store.Cursor(address); ok {
+		status.Cursor = &cursor
+	}
+	for _, entry := range ix.store.Retries(address) {
+		if entry.Exhausted {
+			status.Failed = append(status.Failed, entry)
+		} else {
+			status.Pending = append(status.Pending, entry)
+		}
+	}
+	status.Complete = status.Cursor != nil && len(status.Pending) == 0 && len(status.Failed) == 0
+	return status, nil
+}
+
+// queueRetries adds signatures whose transactions could not be fetched to the wallet's retry queue
+// Signatures already queued keep their entry; the next refetch updates it
+func (ix *Indexer) queueRetries(ctx context.Context, wallet string, failures []trades.FetchFailure, backfill bool, now time.Time) error {
+	if ix.config.RetryMaxAttempts <= 0 || len(failures) == 0 {
+		return nil
+	}
+
+	queued := make(map[string]bool)
+	for _, entry := range ix.store.Retries(wallet) {
+		queued[entry.Signature] = true
+	}
+	for _, failure := range failures {
+		if queued[failure.Signature] {
+			continue
+		}
+		if _, indexed := ix.store.TradeBySignature(failure.Signature); indexed {
+			continue
+		}
+		queued[failure.Signature] = true
+
+		entry := store.RetryEntry{
+			Wallet:        wallet,
+			Signature:     failure.Signature,
+			Slot:          failure.Slot,
+			LastError:     failure.Error,
+			FirstFailedAt: now,
+			LastAttemptAt: now,
+			Backfill:      backfill,
+		}
+		ix.recordAttempt(ctx, &entry, now)
+		if err := ix.saveRetry(entry); err != nil {
+			return err
+		}
+		metrics.RecordTradeRetry(metrics.TradeRetryQueued)
+	}
+	return nil
+}
+
+// saveRetry stores a retry entry, pruning the wallet's failed entries beyond RetryMaxFailed once it is given up on
+func (ix *Indexer) saveRetry(entry store.RetryEntry) error {
+	if err := ix.store.SaveRetry(entry); err != nil {
+		return err
+	}
+	if !entry.Exhausted || ix.config.RetryMaxFailed <= 0 {
+		return nil
+	}
+
+	var failed []store.RetryEntry
+	for _, queued := range ix.store.Retries(entry.Wallet) {
+		if queued.Exhausted {
+			failed = append(failed, queued)
+		}
+	}
+	if len(failed) <= ix.config.RetryMaxFailed {
+		return nil
+	}
+	sort.SliceStable(failed, func(i, j int) bool { return failed[i].LastAttemptAt.Before(failed[j].LastAttemptAt) })
+	for _, stale := range failed[:len(failed)-ix.config.RetryMaxFailed] {
+		if err := ix.store.RemoveRetry(stale.Wallet, stale.Signature); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordAttempt counts a failed fetch and schedules the next one, or gives up when the attempts ran out
+func (ix *Indexer) recordAttempt(ctx context.Context, entry *store.RetryEntry, now time.Time) {
+	entry.Attempts++
+	entry.LastAttemptAt = now
+	if entry.Attempts >= ix.config.RetryMaxAttempts {
+		entry.Exhausted, entry.NextAttemptAt = true, time.Time{}
+		ix.logger.WarnContext(ctx, "Giving up on transaction that could not be fetched",
+			slog.String("wallet", entry.Wallet),
+			slog.String("signature", entry.Signature),
+			slog.Int("attempts", entry.Attempts),
+			slog.String("error", entry.LastError))
+		return
+	}
+
+	backoff := ix.config.RetryBackoff
+	for i := 1; i < entry.Attempts && backoff < MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	entry.NextAttemptAt = now.Add(min(backoff, MaxRetryBackoff))
+}
+
+// retryTrades refetches the wallet's queued transactions that are due and indexes the trades recovered
+// A transaction that turns out not to be a trade of the wallet simply leaves the queue
+func (ix *Indexer) retryTrades(ctx context.Context, wallet solana.Address) error {
+	refetcher, ok := ix.trades.(TradeRefetcher)
+	if !ok || ix.config.RetryMaxAttempts <= 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	attempts := 0
+	for _, entry := range ix.store.Retries(wallet.String()) {
+		if entry.Exhausted || entry.NextAttemptAt.After(now) {
+			continue
+		}
+		if attempts == maxRetriesPerSync || ctx.Err() != nil {
+			break
+		}
+		attempts++
+
+		if _, indexed := ix.store.TradeBySignature(entry.Signature); indexed {
+			if err := ix.store.RemoveRetry(entry.Wallet, entry.Signature); err != nil {
+				return err
+			}
+			continue
+		}
+
+		trade, err := refetcher.FetchWalletTrade(ctx, wallet, entry.Signature)
+		if err != nil {
+			entry.LastError = err.Error()
+			ix.recordAttempt(ctx, &entry, now)
+			if err := ix.saveRetry(entry); err != nil {
+				return err
+			}
+			if entry.Exhausted {
+				metrics.RecordTradeRetry(metrics.TradeRetryExhausted)
+			} else {
+				metrics.RecordTradeRetry(metrics.TradeRetryFailed)
+			}
+			continue
+		}
+
+		if trade != nil {
+			added, err := ix.store.AddTrades([]store.TradeRecord{store.NewTradeRecord(entry.Wallet, trade)})
+			if err != nil {
+				return err
+			}
+			if !entry.Backfill {
+				ix.publishTrades(added)
+			}
+		}
+		if err := ix.store.RemoveRetry(entry.Wallet, entry.Signature); err != nil {
+			return err
+		}
+		metrics.RecordTradeRetry(metrics.TradeRetryRecovered)
+		ix.logger.InfoContext(ctx, "Recovered transaction from retry queue",
+			slog.String("wallet", entry.Wallet),
+			slog.String("signature", entry.Signature),
+			slog.Int("attempts", entry.Attempts),
+			slog.Bool("trade", trade != nil))
+	}
+	return nil
+}
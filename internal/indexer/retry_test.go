@@ -0,0 +1,113 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/trades"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// refetchingTradeFetcher serves pages and refetches single transactions from a map, failing for absent ones
+type refetchingTradeFetcher struct {
+	stubTradeFetcher
+	available map[string]*hylo.XSOLTrade
+}
+
+func (f *refetchingTradeFetcher) FetchWalletTrade(ctx context.Context, wallet solana.Address, signature string) (*hylo.XSOLTrade, error) {
+	if trade, ok := f.available[signature]; ok {
+		return trade, nil
+	}
+	return nil, errors.New("rpc timeout")
+}
+
+func TestRetryQueueRecoversUnfetchedTrades(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	fetcher := &refetchingTradeFetcher{
+		stubTradeFetcher: stubTradeFetcher{pages: []*trades.TradeResponse{
+			{Trades: []*hylo.XSOLTrade{newTrade("sig1", 100)}},
+			{
+				Trades:    []*hylo.XSOLTrade{newTrade("sig4", 400)},
+				Unfetched: []trades.FetchFailure{{Signature: "sig3", Slot: 300, Error: "rpc timeout"}, {Signature: "sig2", Slot: 200, Error: "rpc timeout"}},
+			},
+		}},
+		available: map[string]*hylo.XSOLTrade{},
+	}
+	publisher := &recordingPublisher{}
+	config := DefaultConfig()
+	config.RetryMaxAttempts = 2
+	ix := New(st, nil, fetcher, nil, config)
+	ix.SetEventPublisher(publisher)
+	ix.track(store.DefaultTenant, testWallet.String(), "", false)
+
+	// Backfill, then a cycle whose page could not fetch two transactions
+	for i := 0; i < 2; i++ {
+		if err := ix.syncTrades(context.Background(), testWallet); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	status, _ := ix.SyncStatus(store.DefaultTenant, testWallet.String())
+	if len(status.Pending) != 2 || status.Complete || status.Pending[0].Signature != "sig3" || status.Pending[0].Attempts != 1 {
+		t.Fatalf("Expected sig3 and sig2 pending after one attempt, got %+v", status)
+	}
+
+	// Entries are not refetched before their backoff elapsed
+	if err := ix.retryTrades(context.Background(), testWallet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status, _ := ix.SyncStatus(store.DefaultTenant, testWallet.String()); status.Pending[0].Attempts != 1 {
+		t.Fatalf("Expected no refetch before the backoff, got %+v", status.Pending)
+	}
+
+	for _, entry := range st.Retries(testWallet.String()) {
+		entry.NextAttemptAt = time.Now().Add(-time.Second)
+		st.SaveRetry(entry)
+	}
+	fetcher.available["sig3"] = newTrade("sig3", 300)
+	publisher.events = nil
+	if err := ix.retryTrades(context.Background(), testWallet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := st.TradeBySignature("sig3"); !ok {
+		t.Error("Expected the recovered sig3 trade to be indexed")
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != webhook.EventTradeNew {
+		t.Errorf("Expected a trade.new event for the recovered trade, got %+v", publisher.events)
+	}
+	status, _ = ix.SyncStatus(store.DefaultTenant, testWallet.String())
+	if len(status.Pending) != 0 || len(status.Failed) != 1 || status.Failed[0].Signature != "sig2" || !status.Failed[0].Exhausted {
+		t.Errorf("Expected sig2 to be reported failed after its attempts ran out, got %+v", status)
+	}
+
+	if _, err := ix.SyncStatus("acme", testWallet.String()); !errors.Is(err, store.ErrWalletNotTracked) {
+		t.Errorf("Expected another tenant's status lookup to fail, got %v", err)
+	}
+}
+
+func TestRetryQueueCapsFailedEntries(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	config := DefaultConfig()
+	config.RetryMaxAttempts, config.RetryMaxFailed = 1, 2
+	ix := New(st, nil, &stubTradeFetcher{}, nil, config)
+	ix.track(store.DefaultTenant, testWallet.String(), "", false)
+
+	// Each cycle gives up on one more transaction; only the two most recent are kept
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, signature := range []string{"sig1", "sig2", "sig3"} {
+		failure := trades.FetchFailure{Signature: signature, Slot: uint64(100 * (i + 1)), Error: "rpc timeout"}
+		if err := ix.queueRetries(context.Background(), testWallet.String(), []trades.FetchFailure{failure}, false, start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	status, _ := ix.SyncStatus(store.DefaultTenant, testWallet.String())
+	if len(status.Failed) != 2 || status.Failed[0].Signature != "sig3" || status.Failed[1].Signature != "sig2" {
+		t.Errorf("Expected only sig3 and sig2 kept as failed, got %+v", status.Failed)
+	}
+}
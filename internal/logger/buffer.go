@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultBufferSize is how many wallet-tagged records are kept in memory for GET /admin/logs
+const DefaultBufferSize = 2000
+
+// Entry is a wallet-tagged log record kept in the in-memory buffer
+type Entry struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Level     string                 `json:"level"`
+	Message   string                 `json:"message"`
+	Wallet    string                 `json:"wallet"`
+	Component string                 `json:"component,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Attrs     map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// Query filters buffered entries; zero values match everything
+type Query struct {
+	Wallet   string
+	MinLevel string // debug, info, warn or error; empty matches every level
+	Since    time.Time
+	Limit    int
+}
+
+// Buffer keeps the most recent wallet-tagged log records in a ring
+type Buffer struct {
+	mu      sync.RWMutex
+	entries []Entry
+	next    int
+	full    bool
+}
+
+// NewBuffer creates a ring buffer holding up to size entries
+func NewBuffer(size int) *Buffer {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	return &Buffer{entries: make([]Entry, size)}
+}
+
+// Record stores an entry, overwriting the oldest once the buffer is full
+func (b *Buffer) Record(entry Entry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[b.next] = entry
+	b.next = (b.next + 1) % len(b.entries)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Recent returns matching entries, newest first
+func (b *Buffer) Recent(query Query) []Entry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	count := b.next
+	if b.full {
+		count = len(b.entries)
+	}
+
+	result := make([]Entry, 0)
+	for i := 0; i < count; i++ {
+		entry := b.entries[(b.next-1-i+len(b.entries))%len(b.entries)]
+		if !query.matches(entry) {
+			continue
+		}
+		result = append(result, entry)
+		if query.Limit > 0 && len(result) >= query.Limit {
+			break
+		}
+	}
+
+	return result
+}
+
+func (q Query) matches(entry Entry) bool {
+	if q.Wallet != "" && entry.Wallet != q.Wallet {
+		return false
+	}
+	if q.MinLevel != "" && parseLogLevel(entry.Level) < parseLogLevel(q.MinLevel) {
+		return false
+	}
+	if !q.Since.IsZero() && entry.Timestamp.Before(q.Since) {
+		return false
+	}
+	return true
+}
+
+var (
+	recentOnce sync.Once
+	recent     *Buffer // nil when LOG_BUFFER_SIZE=0 disables buffering
+)
+
+// recentBuffer returns the process-wide buffer every logger records wallet-tagged entries into
+// Sized from LOG_BUFFER_SIZE on first use; 0 disables it
+func recentBuffer() *Buffer {
+	recentOnce.Do(func() {
+		size := DefaultBufferSize
+		if value := os.Getenv("LOG_BUFFER_SIZE"); value != "" {
+			if parsed, err := strconv.Atoi(value); err == nil && parsed >= 0 {
+				size = parsed
+			}
+		}
+		if size > 0 {
+			recent = NewBuffer(size)
+		}
+	})
+	return recent
+}
+
+// Recent returns buffered wallet-tagged entries from every logger in the process, newest first
+func Recent(query Query) []Entry {
+	if buffer := recentBuffer(); buffer != nil {
+		return buffer.Recent(query)
+	}
+	return []Entry{}
+}
@@ -51,8 +51,8 @@ func New(config Config) *Logger {
 		handler = slog.NewJSONHandler(os.Stdout, opts)
 	}
 
-	// Create logger with service metadata
-	logger := slog.New(handler).With(
+	// Create logger with service metadata; wallet-tagged records are also kept for GET /admin/logs
+	logger := slog.New(newWalletHandler(handler)).With(
 		slog.String("service", config.ServiceName),
 		slog.String("version", config.Version),
 	)
@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// WalletKey is the context key for the wallet an operation handles
+const WalletKey contextKey = "wallet"
+
+// walletAttrKeys are the attribute keys that identify a record's wallet
+var walletAttrKeys = map[string]bool{"wallet": true, "wallet_address": true}
+
+// WithWallet tags ctx with the wallet being handled
+// Every record logged with the context carries a "wallet" attribute and is kept for GET /admin/logs
+func WithWallet(ctx context.Context, address string) context.Context {
+	return context.WithValue(ctx, WalletKey, address)
+}
+
+// GetWallet retrieves the wallet address from context
+func GetWallet(ctx context.Context) string {
+	if wallet, ok := ctx.Value(WalletKey).(string); ok {
+		return wallet
+	}
+	return ""
+}
+
+// walletHandler tags records with the context wallet and copies wallet-tagged records to the buffer
+type walletHandler struct {
+	next   slog.Handler
+	attrs  []slog.Attr // Attributes bound with With, outside any group
+	nested bool        // WithGroup was called; later attributes are not top-level
+}
+
+func newWalletHandler(next slog.Handler) *walletHandler {
+	return &walletHandler{next: next}
+}
+
+func (h *walletHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *walletHandler) Handle(ctx context.Context, record slog.Record) error {
+	wallet := attrValue(h.attrs, walletAttrKeys)
+	if wallet == "" {
+		record.Attrs(func(a slog.Attr) bool {
+			if walletAttrKeys[a.Key] {
+				wallet = a.Value.String()
+			}
+			return wallet == ""
+		})
+	}
+	if wallet == "" {
+		if wallet = GetWallet(ctx); wallet != "" {
+			record.AddAttrs(slog.String("wallet", wallet))
+		}
+	}
+
+	if buffer := recentBuffer(); buffer != nil && wallet != "" {
+		buffer.Record(h.entry(ctx, record, wallet))
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *walletHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	if !h.nested {
+		clone.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	}
+	return &clone
+}
+
+func (h *walletHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	clone.nested = true
+	return &clone
+}
+
+// entry converts a record to a buffer entry; service metadata bound at creation is left out
+func (h *walletHandler) entry(ctx context.Context, record slog.Record, wallet string) Entry {
+	entry := Entry{
+		Timestamp: record.Time.UTC(),
+		Level:     strings.ToLower(record.Level.String()),
+		Message:   record.Message,
+		Wallet:    wallet,
+		Component: attrValue(h.attrs, map[string]bool{"component": true}),
+		RequestID: GetRequestID(ctx),
+		Attrs:     make(map[string]interface{}),
+	}
+
+	add := func(a slog.Attr) bool {
+		switch a.Key {
+		case "service", "version", "component", "request_id", "wallet", "wallet_address":
+		default:
+			entry.Attrs[a.Key] = attrAny(a.Value)
+		}
+		return true
+	}
+	for _, a := range h.attrs {
+		add(a)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		if a.Key == "component" && entry.Component == "" {
+			entry.Component = a.Value.String()
+		}
+		if a.Key == "request_id" && entry.RequestID == "" {
+			entry.RequestID = a.Value.String()
+		}
+		return add(a)
+	})
+
+	if len(entry.Attrs) == 0 {
+		entry.Attrs = nil
+	}
+	return entry
+}
+
+// attrValue returns the last top-level attribute value whose key is in keys
+func attrValue(attrs []slog.Attr, keys map[string]bool) string {
+	value := ""
+	for _, a := range attrs {
+		if keys[a.Key] {
+			value = a.Value.String()
+		}
+	}
+	return value
+}
+
+// attrAny converts an attribute value to a JSON-friendly value, expanding groups into maps
+func attrAny(value slog.Value) interface{} {
+	value = value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := make(map[string]interface{})
+		for _, a := range value.Group() {
+			group[a.Key] = attrAny(a.Value)
+		}
+		return group
+	case slog.KindDuration:
+		return value.Duration().String()
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return err.Error()
+		}
+		return value.Any()
+	default:
+		return value.Any()
+	}
+}
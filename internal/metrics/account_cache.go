@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Account cache lookup outcomes
+const (
+	AccountCacheHit  = "hit"  // Served from the cache
+	AccountCacheMiss = "miss" // Fetched with getAccountInfo
+)
+
+var accountCacheLookups = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "solana_rpc",
+	Name:      "account_cache_lookups_total",
+	Help:      "getAccountInfo cache lookups by outcome (hit, miss).",
+}, []string{"outcome"})
+
+// RecordAccountCache counts an account cache lookup
+func RecordAccountCache(outcome string) {
+	accountCacheLookups.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Archive fallback outcomes
+const (
+	ArchiveFallbackFound    = "found"     // Archive node returned the transaction
+	ArchiveFallbackNotFound = "not_found" // Archive node has no record of it either
+	ArchiveFallbackError    = "error"     // Archive request failed
+)
+
+var archiveFallbacks = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "solana_rpc",
+	Name:      "archive_fallbacks_total",
+	Help:      "Transaction fetches retried on the archival RPC endpoint by outcome (found, not_found, error).",
+}, []string{"outcome"})
+
+// RecordArchiveFallback counts a transaction fetch retried on the archive node
+func RecordArchiveFallback(outcome string) {
+	archiveFallbacks.WithLabelValues(outcome).Inc()
+}
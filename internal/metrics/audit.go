@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var auditWriteErrors = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "audit",
+	Name:      "write_errors_total",
+	Help:      "Outbound call entries that could not be appended to the audit log file.",
+})
+
+// RecordAuditWriteError counts an audit entry lost to a failed file write
+func RecordAuditWriteError() {
+	auditWriteErrors.Inc()
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Block time backfill outcomes
+const (
+	BlockTimeCached   = "cached"   // Served from the slot cache
+	BlockTimeResolved = "resolved" // Fetched with getBlockTime
+	BlockTimeMissing  = "missing"  // getBlockTime failed or returned null; the block time stays empty
+)
+
+var blockTimeBackfills = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "solana_rpc",
+	Name:      "block_time_backfills_total",
+	Help:      "Signatures and transactions returned without a blockTime, by how the block time was resolved (cached, resolved, missing).",
+}, []string{"outcome"})
+
+// RecordBlockTimeBackfill counts a missing blockTime resolved from its slot
+func RecordBlockTimeBackfill(outcome string) {
+	blockTimeBackfills.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var decimalsMismatch = promauto.With(Registry).NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: Namespace,
+	Subsystem: "tokens",
+	Name:      "decimals_mismatch",
+	Help:      "1 while a token's configured decimals differ from its on-chain mint and its amounts are withheld, 0 otherwise.",
+}, []string{"token"})
+
+// RecordDecimalsCheck sets whether a token's configured decimals mismatch its on-chain mint
+func RecordDecimalsCheck(token string, mismatch bool) {
+	if mismatch {
+		decimalsMismatch.WithLabelValues(token).Set(1)
+	} else {
+		decimalsMismatch.WithLabelValues(token).Set(0)
+	}
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Reasons a request is shed by the in-flight limiter
+const (
+	HTTPShedQueueFull      = "queue_full"       // Every slot busy and the queue at capacity
+	HTTPShedQueueTimeout   = "queue_timeout"    // Queued longer than the queue timeout
+	HTTPShedStreamsFull    = "streams_full"     // Every full trade history stream slot busy
+	HTTPShedSSEStreamsFull = "sse_streams_full" // Every wallet and supply SSE stream slot busy
+	HTTPShedStreamWallets  = "stream_wallets"   // Every upstream wallet subscription slot taken
+)
+
+var (
+	httpInFlight = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "in_flight_requests",
+		Help:      "API requests currently being handled under the in-flight limit.",
+	})
+
+	httpQueued = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "queued_requests",
+		Help:      "API requests waiting for an in-flight slot.",
+	})
+
+	httpShed = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "shed_requests_total",
+		Help:      "API requests rejected with 503 by reason (queue_full, queue_timeout).",
+	}, []string{"reason"})
+
+	httpRateLimited = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_requests_total",
+		Help:      "API requests rejected with 429 because the client exceeded its request rate.",
+	})
+
+	httpRateLimitedClients = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_clients",
+		Help:      "Clients with a partially drained request bucket.",
+	})
+)
+
+// AddHTTPInFlight adjusts the in-flight request gauge by delta
+func AddHTTPInFlight(delta float64) {
+	httpInFlight.Add(delta)
+}
+
+// AddHTTPQueued adjusts the queued request gauge by delta
+func AddHTTPQueued(delta float64) {
+	httpQueued.Add(delta)
+}
+
+// RecordHTTPShed counts a request rejected because the API is saturated
+func RecordHTTPShed(reason string) {
+	httpShed.WithLabelValues(reason).Inc()
+}
+
+// RecordHTTPRateLimited counts a request rejected by the per-client rate limiter
+func RecordHTTPRateLimited() {
+	httpRateLimited.Inc()
+}
+
+// SetHTTPRateLimitedClients sets the number of clients tracked by the per-client rate limiter
+func SetHTTPRateLimitedClients(clients int) {
+	httpRateLimitedClients.Set(float64(clients))
+}
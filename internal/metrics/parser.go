@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Parse outcomes recorded on the parse duration histogram
+const (
+	ParseOutcomeTrade   = "trade"    // Transaction classified as an xSOL trade
+	ParseOutcomeNoTrade = "no_trade" // Transaction did not touch the wallet's xSOL balance
+	ParseOutcomeError   = "error"    // Parsing failed or returned a parse error
+)
+
+// Parse durations range from ~100µs (irrelevant transactions) to a few hundred ms (large multi-hop swaps)
+const (
+	parseDurationBucketStart  = 0.0001
+	parseDurationBucketFactor = 2
+	parseDurationBucketCount  = 12
+)
+
+var (
+	parseDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "parser",
+		Name:      "parse_duration_seconds",
+		Help:      "Duration of ParseTransaction calls by outcome.",
+		Buckets:   prometheus.ExponentialBuckets(parseDurationBucketStart, parseDurationBucketFactor, parseDurationBucketCount),
+	}, []string{"outcome"})
+
+	tradesClassified = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "parser",
+		Name:      "trades_total",
+		Help:      "Parsed xSOL trades by side (BUY, SELL, RECEIVE).",
+	}, []string{"side"})
+
+	counterAssets = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "parser",
+		Name:      "counter_assets_total",
+		Help:      "Counter assets selected for parsed xSOL trades.",
+	}, []string{"asset"})
+
+	tradeSources = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "parser",
+		Name:      "trade_sources_total",
+		Help:      "Parsed xSOL trades by origin (hylo_app, aggregator, direct_cpi, unknown).",
+	}, []string{"source"})
+
+	lowConfidenceTrades = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "parser",
+		Name:      "low_confidence_trades_total",
+		Help:      "Parsed xSOL trades scored below the review threshold.",
+	})
+
+	parseErrors = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "parser",
+		Name:      "errors_total",
+		Help:      "Transaction parse errors by type.",
+	}, []string{"type"})
+)
+
+// ObserveParse records how long a parse took and its outcome
+func ObserveParse(outcome string, duration time.Duration) {
+	parseDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+// RecordTrade counts a classified trade by side and counter asset
+func RecordTrade(side, counterAsset string) {
+	tradesClassified.WithLabelValues(side).Inc()
+	if counterAsset != "" {
+		counterAssets.WithLabelValues(counterAsset).Inc()
+	}
+}
+
+// RecordTradeSource counts a parsed trade by where it originated
+func RecordTradeSource(source string) {
+	if source != "" {
+		tradeSources.WithLabelValues(source).Inc()
+	}
+}
+
+// RecordLowConfidenceTrade counts a parsed trade flagged for manual review
+func RecordLowConfidenceTrade() {
+	lowConfidenceTrades.Inc()
+}
+
+// RecordParseError counts a parse error by type
+func RecordParseError(errorType string) {
+	parseErrors.WithLabelValues(errorType).Inc()
+}
+
+var parserAnomaly = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+	Namespace: Namespace,
+	Subsystem: "parser",
+	Name:      "anomaly",
+	Help:      "1 while the parser error or low-confidence rate is above its alert threshold, 0 otherwise.",
+})
+
+// SetParserAnomaly sets whether the parser anomaly alert is raised
+func SetParserAnomaly(anomalous bool) {
+	if anomalous {
+		parserAnomaly.Set(1)
+	} else {
+		parserAnomaly.Set(0)
+	}
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pegDeviation = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "peg",
+		Name:      "hyusd_deviation_bps",
+		Help:      "Latest hyUSD market price deviation from the $1 peg in basis points (negative below the peg).",
+	})
+
+	pegDepegged = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "peg",
+		Name:      "hyusd_depegged",
+		Help:      "1 while the hyUSD depeg alert is raised, 0 otherwise.",
+	})
+)
+
+// RecordPegSample sets the latest hyUSD peg deviation and alert state
+func RecordPegSample(deviationBps float64, depegged bool) {
+	pegDeviation.Set(deviationBps)
+	if depegged {
+		pegDepegged.Set(1)
+	} else {
+		pegDepegged.Set(0)
+	}
+}
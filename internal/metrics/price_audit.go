@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var priceAuditDiscrepancies = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "price",
+	Name:      "audit_discrepancies_total",
+	Help:      "Price calculation audit checks that disagreed with the primary calculation beyond tolerance, by check.",
+}, []string{"check"})
+
+// RecordPriceAuditDiscrepancy counts a failed price audit check
+func RecordPriceAuditDiscrepancy(check string) {
+	priceAuditDiscrepancies.WithLabelValues(check).Inc()
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Trade index reconciliation outcomes
+const (
+	ReconcileClean = "clean" // Index matches live RPC over the compared range
+	ReconcileDrift = "drift" // Missing, extra or changed trades were found
+	ReconcileError = "error" // The live re-scan failed
+)
+
+// Kinds of trade index drift
+const (
+	ReconcileMissing = "missing" // Live trade absent from the index
+	ReconcileExtra   = "extra"   // Indexed trade RPC no longer returns
+	ReconcileChanged = "changed" // Indexed fields differ from a fresh parse
+)
+
+var (
+	reconciliations = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "indexer",
+		Name:      "reconciliations_total",
+		Help:      "Trade index reconciliations against live RPC, by outcome (clean, drift, error).",
+	}, []string{"outcome"})
+
+	reconcileDrift = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "indexer",
+		Name:      "reconcile_drift_trades_total",
+		Help:      "Trades found drifted from live RPC by reconciliations, by kind (missing, extra, changed).",
+	}, []string{"kind"})
+)
+
+// RecordReconciliation counts a trade index reconciliation by outcome
+func RecordReconciliation(outcome string) {
+	reconciliations.WithLabelValues(outcome).Inc()
+}
+
+// RecordReconcileDrift counts drifted trades of one kind found by a reconciliation
+func RecordReconcileDrift(kind string, count int) {
+	reconcileDrift.WithLabelValues(kind).Add(float64(count))
+}
@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var rpcErrors = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "solana_rpc",
+	Name:      "errors_total",
+	Help:      "Failed Solana RPC attempts by error class (rate_limited, node_behind, long_term_storage, slot_skipped, server, network, permanent).",
+}, []string{"class"})
+
+// RecordRPCError counts a failed Solana RPC attempt by error class
+func RecordRPCError(class string) {
+	rpcErrors.WithLabelValues(class).Inc()
+}
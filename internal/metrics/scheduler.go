@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	schedulerWait = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "rpc_scheduler",
+		Name:      "wait_seconds",
+		Help:      "Time RPC calls waited for the global request budget by priority (interactive, background).",
+		Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"priority"})
+
+	schedulerBackgroundBackoff = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "rpc_scheduler",
+		Name:      "background_backoff_seconds",
+		Help:      "Current pause applied to background RPC calls because interactive latency degraded (0 when not backing off).",
+	})
+)
+
+// ObserveSchedulerWait records how long an RPC call waited for the request budget
+func ObserveSchedulerWait(priority string, wait time.Duration) {
+	schedulerWait.WithLabelValues(priority).Observe(wait.Seconds())
+}
+
+// SetSchedulerBackgroundBackoff records the current background backoff
+func SetSchedulerBackgroundBackoff(backoff time.Duration) {
+	schedulerBackgroundBackoff.Set(backoff.Seconds())
+}
@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var tokenSupply = promauto.With(Registry).NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: Namespace,
+	Subsystem: "protocol",
+	Name:      "token_supply",
+	Help:      "Latest mint supply of each protocol token in whole tokens, updated from mint account notifications.",
+}, []string{"token"})
+
+// SetTokenSupply records a protocol token's current mint supply
+func SetTokenSupply(token string, supply float64) {
+	tokenSupply.WithLabelValues(token).Set(supply)
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Missing token account cache events
+const (
+	MissingAccountHit         = "hit"         // Balance read served as zero without an RPC call
+	MissingAccountStored      = "stored"      // getAccountInfo found no account; cached until the TTL expires
+	MissingAccountInvalidated = "invalidated" // The watched account was created and the entry dropped
+)
+
+var missingAccountEvents = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "tokens",
+	Name:      "missing_account_cache_total",
+	Help:      "Negative cache events for token accounts that do not exist (hit, stored, invalidated).",
+}, []string{"event"})
+
+// RecordMissingAccount counts a negative token account cache event
+func RecordMissingAccount(event string) {
+	missingAccountEvents.WithLabelValues(event).Inc()
+}
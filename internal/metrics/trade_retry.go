@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Outcomes of queued transaction refetches
+const (
+	TradeRetryQueued    = "queued"    // Fetch failed during sync; signature added to the queue
+	TradeRetryRecovered = "recovered" // Refetch succeeded and the signature left the queue
+	TradeRetryFailed    = "failed"    // Refetch failed again; retried after a backoff
+	TradeRetryExhausted = "exhausted" // Attempts ran out; reported as a persistent failure
+)
+
+var tradeRetries = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: Namespace,
+	Subsystem: "indexer",
+	Name:      "trade_retries_total",
+	Help:      "Transactions the indexer could not fetch while syncing and their refetches, by outcome (queued, recovered, failed, exhausted).",
+}, []string{"outcome"})
+
+// RecordTradeRetry counts a trade retry queue event by outcome
+func RecordTradeRetry(outcome string) {
+	tradeRetries.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// WebSocket disconnect reasons
+const (
+	WSDisconnectReadError = "read_error" // Connection closed or read deadline exceeded (missed pongs)
+	WSDisconnectPingError = "ping_error" // Ping could not be written
+	WSDisconnectStalled   = "stalled"    // Connection alive but slot notifications stopped advancing
+	WSDisconnectDialError = "dial_error" // Reconnect attempt failed
+)
+
+var (
+	wsConnected = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "solana_ws",
+		Name:      "connected",
+		Help:      "Whether the Solana WebSocket client is currently connected (1) or not (0).",
+	})
+
+	wsDisconnects = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "solana_ws",
+		Name:      "disconnects_total",
+		Help:      "Solana WebSocket disconnects and failed reconnects by reason.",
+	}, []string{"reason"})
+
+	wsReconnects = promauto.With(Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "solana_ws",
+		Name:      "reconnects_total",
+		Help:      "Successful Solana WebSocket reconnects.",
+	})
+
+	wsResubscriptions = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "solana_ws",
+		Name:      "resubscriptions_total",
+		Help:      "Subscriptions restored after a reconnect by outcome (ok, error).",
+	}, []string{"outcome"})
+)
+
+// SetWSConnected records the current WebSocket connection state
+func SetWSConnected(connected bool) {
+	if connected {
+		wsConnected.Set(1)
+		return
+	}
+	wsConnected.Set(0)
+}
+
+// RecordWSDisconnect counts a disconnect by reason
+func RecordWSDisconnect(reason string) {
+	wsDisconnects.WithLabelValues(reason).Inc()
+}
+
+// RecordWSReconnect counts a successful reconnect
+func RecordWSReconnect() {
+	wsReconnects.Inc()
+}
+
+// RecordWSResubscription counts a restored subscription; ok is false when the resubscribe request failed
+func RecordWSResubscription(ok bool) {
+	outcome := "ok"
+	if !ok {
+		outcome = "error"
+	}
+	wsResubscriptions.WithLabelValues(outcome).Inc()
+}
+
+var (
+	streamClients = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "stream",
+		Name:      "clients",
+		Help:      "Connected wallet stream clients.",
+	})
+
+	streamUpstreamSubscriptions = promauto.With(Registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "stream",
+		Name:      "upstream_subscriptions",
+		Help:      "Solana account subscriptions shared by wallet stream clients.",
+	})
+)
+
+// AddStreamClients adjusts the connected stream client gauge by delta
+func AddStreamClients(delta int) {
+	streamClients.Add(float64(delta))
+}
+
+// AddStreamUpstreamSubscriptions adjusts the upstream subscription gauge by delta
+func AddStreamUpstreamSubscriptions(delta int) {
+	streamUpstreamSubscriptions.Add(float64(delta))
+}
@@ -0,0 +1,66 @@
+package peg
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default peg monitor settings
+const (
+	DefaultCheckInterval     = time.Minute    // Time between hyUSD market price samples
+	DefaultWindow            = 24 * time.Hour // Window the maximum deviation is reported over
+	DefaultAlertThresholdBps = 100            // Absolute deviation from the peg, in basis points, that raises a depeg alert
+)
+
+// Config holds hyUSD peg monitor configuration
+type Config struct {
+	// Enabled starts the background sampler; GET /protocol/peg samples on demand either way
+	Enabled bool
+
+	// CheckInterval is the time between market price samples
+	CheckInterval time.Duration
+
+	// Window is how far back the maximum deviation is tracked
+	Window time.Duration
+
+	// AlertThresholdBps is the absolute deviation in basis points at which a depeg alert is published
+	// The alert clears once the deviation falls back under half the threshold
+	AlertThresholdBps float64
+}
+
+// DefaultConfig returns the default peg monitor configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:           true,
+		CheckInterval:     DefaultCheckInterval,
+		Window:            DefaultWindow,
+		AlertThresholdBps: DefaultAlertThresholdBps,
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("PEG_MONITOR_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			config.Enabled = enabled
+		}
+	}
+
+	if value := os.Getenv("PEG_CHECK_INTERVAL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.CheckInterval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("PEG_ALERT_THRESHOLD_BPS"); value != "" {
+		if bps, err := strconv.ParseFloat(value, 64); err == nil && bps > 0 {
+			config.AlertThresholdBps = bps
+		}
+	}
+
+	return config
+}
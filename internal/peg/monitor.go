@@ -0,0 +1,245 @@
+package peg
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// DesignPegUSD is the USD value hyUSD is designed to hold
+const DesignPegUSD = 1.0
+
+// PriceFetcher returns a token's market price; implemented by price.DexScreenerClient
+type PriceFetcher interface {
+	FetchTokenPrice(ctx context.Context, mint string) (*price.TokenPrice, error)
+}
+
+// EventPublisher receives depeg alerts as webhook events
+type EventPublisher interface {
+	Publish(event webhook.Event)
+}
+
+// Status is the hyUSD peg reading served by GET /protocol/peg
+// Deviations are signed fractions of the peg: -0.004 means 0.4% below $1
+type Status struct {
+	Token    string  `json:"token"`
+	Mint     string  `json:"mint"`
+	PegUSD   float64 `json:"peg_usd"`
+	PriceUSD float64 `json:"price_usd"`
+
+	Deviation    float64 `json:"deviation"`
+	DeviationBps float64 `json:"deviation_bps"`
+
+	// MaxDeviation24h is the sampled deviation furthest from the peg within the window
+	MaxDeviation24h    float64   `json:"max_deviation_24h"`
+	MaxDeviation24hBps float64   `json:"max_deviation_24h_bps"`
+	MaxDeviation24hAt  time.Time `json:"max_deviation_24h_at"`
+
+	// Depegged is true while the alert raised at AlertThresholdBps is active
+	Depegged          bool       `json:"depegged"`
+	DepeggedSince     *time.Time `json:"depegged_since,omitempty"`
+	AlertThresholdBps float64    `json:"alert_threshold_bps"`
+
+	LiquidityUSD float64   `json:"liquidity_usd"`
+	Pools        int       `json:"pools"`
+	Source       string    `json:"source"`
+	Samples      int       `json:"samples"` // Samples within the window
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// sample is one market price reading
+type sample struct {
+	deviation float64
+	at        time.Time
+}
+
+// Monitor samples hyUSD's market price, tracks its deviation from the peg and raises depeg alerts
+type Monitor struct {
+	prices PriceFetcher
+	events EventPublisher
+	config *Config
+	logger *logger.Logger
+	now    func() time.Time
+
+	mu            sync.Mutex
+	samples       []sample // Oldest first, pruned to the window
+	latest        *price.TokenPrice
+	depegged      bool
+	depeggedSince time.Time
+}
+
+// NewMonitor creates a peg monitor; events may be nil to disable alerts
+func NewMonitor(prices PriceFetcher, events EventPublisher, config *Config) *Monitor {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Monitor{
+		prices: prices,
+		events: events,
+		config: config,
+		logger: logger.NewFromEnv().WithComponent("peg"),
+		now:    time.Now,
+	}
+}
+
+// Run samples the peg every check interval until ctx is cancelled
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Check(ctx); err != nil {
+			m.logger.LogExternalAPIError(ctx, "dexscreener", "peg_check", err, 0)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check fetches hyUSD's market price, records the sample and publishes an alert when the depeg state changes
+func (m *Monitor) Check(ctx context.Context) error {
+	tokenPrice, err := m.prices.FetchTokenPrice(ctx, tokens.HyUSDMint.String())
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	deviation := (tokenPrice.PriceUSD - DesignPegUSD) / DesignPegUSD
+
+	m.mu.Lock()
+	m.latest = tokenPrice
+	m.samples = append(m.samples, sample{deviation: deviation, at: now})
+	m.prune(now)
+
+	var alert string
+	threshold := m.config.AlertThresholdBps / 10000
+	switch {
+	case !m.depegged && math.Abs(deviation) >= threshold:
+		m.depegged, m.depeggedSince, alert = true, now, webhook.EventPegDepeg
+	case m.depegged && math.Abs(deviation) < threshold/2:
+		m.depegged, m.depeggedSince, alert = false, time.Time{}, webhook.EventPegRestored
+	}
+	status := m.statusLocked()
+	m.mu.Unlock()
+
+	metrics.RecordPegSample(status.DeviationBps, status.Depegged)
+
+	if alert != "" {
+		m.logger.WarnContext(ctx, "hyUSD peg alert",
+			slog.String("event", alert),
+			slog.Float64("price_usd", status.PriceUSD),
+			slog.Float64("deviation_bps", status.DeviationBps))
+		if m.events != nil {
+			m.events.Publish(webhook.Event{Type: alert, Data: status})
+		}
+	}
+	return nil
+}
+
+// Current returns the peg status, sampling first when no sample is fresher than the check interval
+func (m *Monitor) Current(ctx context.Context) (*Status, error) {
+	m.mu.Lock()
+	fresh := len(m.samples) > 0 && m.now().Sub(m.samples[len(m.samples)-1].at) < m.config.CheckInterval
+	m.mu.Unlock()
+
+	if !fresh {
+		if err := m.Check(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.statusLocked(), nil
+}
+
+// PriceAt returns the sampled market price of symbol nearest at, if one was taken within a check interval of it
+// Only hyUSD is sampled, and only for the window
+func (m *Monitor) PriceAt(symbol string, at time.Time) (float64, bool) {
+	if symbol != tokens.HyUSDSymbol {
+		return 0, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var nearest *sample
+	for i := range m.samples {
+		if nearest == nil || absDuration(m.samples[i].at.Sub(at)) < absDuration(nearest.at.Sub(at)) {
+			nearest = &m.samples[i]
+		}
+	}
+	if nearest == nil || absDuration(nearest.at.Sub(at)) > m.config.CheckInterval {
+		return 0, false
+	}
+	return DesignPegUSD * (1 + nearest.deviation), true
+}
+
+// absDuration returns the absolute value of d
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// prune drops samples older than the window; the caller holds mu
+func (m *Monitor) prune(now time.Time) {
+	cutoff := now.Add(-m.config.Window)
+	keep := 0
+	for keep < len(m.samples) && m.samples[keep].at.Before(cutoff) {
+		keep++
+	}
+	m.samples = m.samples[keep:]
+}
+
+// statusLocked builds the status from the latest sample; the caller holds mu
+func (m *Monitor) statusLocked() *Status {
+	last := m.samples[len(m.samples)-1]
+	status := &Status{
+		Token:             tokens.HyUSDSymbol,
+		Mint:              tokens.HyUSDMint.String(),
+		PegUSD:            DesignPegUSD,
+		PriceUSD:          m.latest.PriceUSD,
+		Deviation:         last.deviation,
+		DeviationBps:      toBps(last.deviation),
+		Depegged:          m.depegged,
+		AlertThresholdBps: m.config.AlertThresholdBps,
+		LiquidityUSD:      m.latest.LiquidityUSD,
+		Pools:             m.latest.Pools,
+		Source:            m.latest.Source,
+		Samples:           len(m.samples),
+		UpdatedAt:         last.at,
+	}
+
+	for _, s := range m.samples {
+		if math.Abs(s.deviation) >= math.Abs(status.MaxDeviation24h) {
+			status.MaxDeviation24h, status.MaxDeviation24hAt = s.deviation, s.at
+		}
+	}
+	status.MaxDeviation24hBps = toBps(status.MaxDeviation24h)
+
+	if m.depegged {
+		since := m.depeggedSince
+		status.DepeggedSince = &since
+	}
+	return status
+}
+
+// toBps converts a deviation fraction to basis points rounded to 0.01
+func toBps(deviation float64) float64 {
+	return math.Round(deviation*1000000) / 100
+}
@@ -0,0 +1,148 @@
+package peg
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+type stubPrices struct {
+	prices []float64
+	calls  int
+	err    error
+}
+
+func (s *stubPrices) FetchTokenPrice(ctx context.Context, mint string) (*price.TokenPrice, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if mint != tokens.HyUSDMint.String() {
+		return nil, errors.New("unexpected mint " + mint)
+	}
+	p := s.prices[min(s.calls, len(s.prices)-1)]
+	s.calls++
+	return &price.TokenPrice{Mint: mint, PriceUSD: p, LiquidityUSD: 5e6, Pools: 3, Source: "dexscreener"}, nil
+}
+
+type recordingPublisher struct {
+	events []webhook.Event
+}
+
+func (p *recordingPublisher) Publish(event webhook.Event) {
+	p.events = append(p.events, event)
+}
+
+func newTestMonitor(prices *stubPrices, events EventPublisher, start time.Time) (*Monitor, *time.Time) {
+	clock := start
+	monitor := NewMonitor(prices, events, DefaultConfig())
+	monitor.now = func() time.Time { return clock }
+	return monitor, &clock
+}
+
+func TestMonitorDeviation(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	prices := &stubPrices{prices: []float64{0.995, 1.002, 0.9991}}
+	monitor, clock := newTestMonitor(prices, nil, start)
+
+	for i := 0; i < 3; i++ {
+		if err := monitor.Check(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		*clock = clock.Add(time.Hour)
+	}
+
+	status, err := monitor.Current(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prices.calls != 4 {
+		t.Errorf("Expected Current to resample a stale reading, got %d fetches", prices.calls)
+	}
+	if status.PriceUSD != 0.9991 || status.DeviationBps != -9 {
+		t.Errorf("Expected -9 bps at $0.9991, got %v bps at $%v", status.DeviationBps, status.PriceUSD)
+	}
+	if status.MaxDeviation24hBps != -50 || !status.MaxDeviation24hAt.Equal(start) {
+		t.Errorf("Expected the -50 bps sample as the 24h max, got %v at %v", status.MaxDeviation24hBps, status.MaxDeviation24hAt)
+	}
+	if status.Samples != 4 || status.Depegged {
+		t.Errorf("Expected 4 samples and no depeg, got %+v", status)
+	}
+
+	// The -50 and +20 bps samples age out of the window
+	*clock = start.Add(25*time.Hour + time.Minute)
+	if err := monitor.Check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status, _ = monitor.Current(context.Background())
+	if status.MaxDeviation24hBps != -9 || status.Samples != 3 {
+		t.Errorf("Expected the oldest sample to be pruned, got max %v bps over %d samples", status.MaxDeviation24hBps, status.Samples)
+	}
+}
+
+func TestMonitorDepegAlerts(t *testing.T) {
+	publisher := &recordingPublisher{}
+	// 1.5% below, still below, back within half the threshold only at 0.9996
+	prices := &stubPrices{prices: []float64{1.0, 0.985, 0.992, 0.9996}}
+	monitor, clock := newTestMonitor(prices, publisher, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC))
+
+	var depegged []bool
+	for i := 0; i < 4; i++ {
+		if err := monitor.Check(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		status, _ := monitor.Current(context.Background())
+		depegged = append(depegged, status.Depegged)
+		*clock = clock.Add(time.Minute)
+	}
+
+	if want := []bool{false, true, true, false}; depegged[0] != want[0] || depegged[1] != want[1] || depegged[2] != want[2] || depegged[3] != want[3] {
+		t.Errorf("Expected depeg states %v, got %v", want, depegged)
+	}
+	if len(publisher.events) != 2 || publisher.events[0].Type != webhook.EventPegDepeg || publisher.events[1].Type != webhook.EventPegRestored {
+		t.Fatalf("Expected one depeg and one restored event, got %+v", publisher.events)
+	}
+	if status := publisher.events[0].Data.(*Status); status.DepeggedSince == nil || status.DeviationBps != -150 || publisher.events[0].Wallet != "" {
+		t.Errorf("Unexpected depeg event payload %+v", status)
+	}
+}
+
+func TestMonitorFetchError(t *testing.T) {
+	monitor, _ := newTestMonitor(&stubPrices{err: errors.New("dexscreener down")}, nil, time.Now())
+	if _, err := monitor.Current(context.Background()); err == nil {
+		t.Error("Expected an error before any sample was taken")
+	}
+}
+
+func TestMonitorPriceAt(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	prices := &stubPrices{prices: []float64{0.995, 1.002}}
+	monitor, clock := newTestMonitor(prices, nil, start)
+
+	for i := 0; i < 2; i++ {
+		if err := monitor.Check(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		*clock = clock.Add(10 * time.Minute)
+	}
+
+	// The nearest sample within a check interval answers
+	if price, ok := monitor.PriceAt(tokens.HyUSDSymbol, start.Add(30*time.Second)); !ok || math.Abs(price-0.995) > 1e-9 {
+		t.Errorf("Expected the first sample at $0.995, got $%v (%v)", price, ok)
+	}
+	if price, ok := monitor.PriceAt(tokens.HyUSDSymbol, start.Add(10*time.Minute-time.Second)); !ok || math.Abs(price-1.002) > 1e-9 {
+		t.Errorf("Expected the second sample at $1.002, got $%v (%v)", price, ok)
+	}
+
+	if _, ok := monitor.PriceAt(tokens.HyUSDSymbol, start.Add(5*time.Minute)); ok {
+		t.Error("Expected no price between samples further apart than the check interval")
+	}
+	if _, ok := monitor.PriceAt(tokens.USDCSymbol, start); ok {
+		t.Error("Expected only hyUSD to be sampled")
+	}
+}
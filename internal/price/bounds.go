@@ -0,0 +1,171 @@
+package price
+
+import (
+	"sort"
+	"sync"
+)
+
+// Absolute SOL/USD sanity limits applied in auto-calibration mode; prices outside are never accepted
+const (
+	AbsoluteMinSOLPrice = 1.0
+	AbsoluteMaxSOLPrice = 100000.0
+)
+
+// Band source labels reported in PriceBand
+const (
+	BandSourceStatic     = "static"
+	BandSourceCalibrated = "calibrated"
+)
+
+// PriceBand is the range of SOL/USD prices currently accepted
+type PriceBand struct {
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Median  float64 `json:"median,omitempty"`
+	Samples int     `json:"samples"`
+	Source  string  `json:"source"`
+}
+
+// Contains reports whether price falls inside the band
+func (b PriceBand) Contains(price float64) bool {
+	return price > 0 && price >= b.Min && price <= b.Max
+}
+
+// PriceBounds validates fetched SOL/USD prices
+// With auto-calibration enabled the band is the rolling median of recently accepted prices ± tolerance;
+// until enough samples are collected the static SOLUSDMinPrice/SOLUSDMaxPrice band is used.
+// A sustained move outside the band is adopted once enough consecutive rejected prices agree with
+// each other, while isolated spikes keep being rejected.
+type PriceBounds struct {
+	mu       sync.Mutex
+	config   *PriceConfig
+	accepted []float64 // Rolling window, oldest first
+	rejected []float64 // Newest BoundsRecalibrateAfter consecutive rejections since the last accepted price
+}
+
+// NewPriceBounds creates price bounds from config
+func NewPriceBounds(config *PriceConfig) *PriceBounds {
+	return &PriceBounds{config: config}
+}
+
+// Band returns the currently accepted price range
+func (b *PriceBounds) Band() PriceBand {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.band()
+}
+
+// Accept validates price against the current band and records it for calibration
+// Returns the band the price was checked against and whether it was accepted
+func (b *PriceBounds) Accept(price float64) (PriceBand, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	band := b.band()
+	if !b.config.AutoCalibrateBounds {
+		return band, band.Contains(price)
+	}
+	if price < AbsoluteMinSOLPrice || price > AbsoluteMaxSOLPrice {
+		return band, false
+	}
+
+	if band.Contains(price) {
+		b.record(price)
+		return band, true
+	}
+
+	// Rejected: remember it so a sustained, consistent move can re-seed the window
+	// Only the newest BoundsRecalibrateAfter rejections are ever compared, so older ones are dropped
+	b.rejected = append(b.rejected, price)
+	if n := len(b.rejected) - b.config.BoundsRecalibrateAfter; n > 0 {
+		b.rejected = append(b.rejected[:0], b.rejected[n:]...)
+	}
+	if len(b.rejected) < b.config.BoundsRecalibrateAfter {
+		return band, false
+	}
+
+	recent := b.rejected
+	recentMedian := median(recent)
+	for _, p := range recent {
+		if !withinTolerance(p, recentMedian, b.config.BoundsTolerancePct) {
+			return band, false
+		}
+	}
+
+	b.accepted = append([]float64(nil), recent...)
+	b.rejected = nil
+	return b.band(), true
+}
+
+// Window returns a copy of the accepted prices the calibrated band is derived from, oldest first
+func (b *PriceBounds) Window() []float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]float64(nil), b.accepted...)
+}
+
+// Restore replaces the accepted window, e.g. with one saved before a restart
+// Prices outside the absolute limits are dropped and only the newest BoundsWindowSize are kept
+func (b *PriceBounds) Restore(window []float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.accepted = nil
+	b.rejected = nil
+	for _, price := range window {
+		if price >= AbsoluteMinSOLPrice && price <= AbsoluteMaxSOLPrice {
+			b.record(price)
+		}
+	}
+}
+
+func (b *PriceBounds) record(price float64) {
+	b.rejected = nil
+	b.accepted = append(b.accepted, price)
+	if len(b.accepted) > b.config.BoundsWindowSize {
+		b.accepted = b.accepted[len(b.accepted)-b.config.BoundsWindowSize:]
+	}
+}
+
+// band must be called with mu held
+func (b *PriceBounds) band() PriceBand {
+	if !b.config.AutoCalibrateBounds || len(b.accepted) < b.config.BoundsMinSamples {
+		return PriceBand{
+			Min:     b.config.SOLUSDMinPrice,
+			Max:     b.config.SOLUSDMaxPrice,
+			Samples: len(b.accepted),
+			Source:  BandSourceStatic,
+		}
+	}
+
+	mid := median(b.accepted)
+	delta := mid * b.config.BoundsTolerancePct / 100
+	return PriceBand{
+		Min:     mid - delta,
+		Max:     mid + delta,
+		Median:  mid,
+		Samples: len(b.accepted),
+		Source:  BandSourceCalibrated,
+	}
+}
+
+func withinTolerance(price, reference, tolerancePct float64) bool {
+	delta := reference * tolerancePct / 100
+	return price >= reference-delta && price <= reference+delta
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,125 @@
+package price
+
+import (
+	"testing"
+)
+
+func newCalibratedConfig() *PriceConfig {
+	config := DefaultConfig()
+	config.AutoCalibrateBounds = true
+	config.BoundsWindowSize = 10
+	config.BoundsMinSamples = 3
+	config.BoundsTolerancePct = 20
+	config.BoundsRecalibrateAfter = 3
+	return config
+}
+
+func TestPriceBoundsStatic(t *testing.T) {
+	bounds := NewPriceBounds(DefaultConfig())
+
+	if _, ok := bounds.Accept(150); !ok {
+		t.Errorf("Expected price inside static band to be accepted")
+	}
+	band, ok := bounds.Accept(1500)
+	if ok {
+		t.Errorf("Expected price above static max to be rejected")
+	}
+	if band.Source != BandSourceStatic || band.Max != 1000 {
+		t.Errorf("Expected static band, got %+v", band)
+	}
+}
+
+func TestPriceBoundsCalibration(t *testing.T) {
+	config := newCalibratedConfig()
+	config.SOLUSDMaxPrice = 1000
+	bounds := NewPriceBounds(config)
+
+	// Static band applies until enough samples are collected
+	for _, price := range []float64{900, 950, 1000} {
+		if _, ok := bounds.Accept(price); !ok {
+			t.Fatalf("Expected %v to be accepted during warm-up", price)
+		}
+	}
+
+	band := bounds.Band()
+	if band.Source != BandSourceCalibrated || band.Median != 950 || band.Min != 760 || band.Max != 1140 {
+		t.Fatalf("Expected calibrated band 760-1140 around 950, got %+v", band)
+	}
+
+	// A legitimate move above the static max is accepted once calibrated
+	if _, ok := bounds.Accept(1100); !ok {
+		t.Errorf("Expected price above static max but inside calibrated band to be accepted")
+	}
+
+	// An egregious outlier is rejected
+	if _, ok := bounds.Accept(5000); ok {
+		t.Errorf("Expected outlier to be rejected")
+	}
+}
+
+func TestPriceBoundsRecalibratesOnSustainedMove(t *testing.T) {
+	bounds := NewPriceBounds(newCalibratedConfig())
+	for _, price := range []float64{100, 100, 100} {
+		bounds.Accept(price)
+	}
+
+	// Inconsistent rejections do not move the band
+	for _, price := range []float64{200, 400, 300} {
+		if _, ok := bounds.Accept(price); ok {
+			t.Fatalf("Expected %v to be rejected", price)
+		}
+	}
+
+	// Consistent rejections re-seed the band on the last one
+	bounds.Accept(150)
+	bounds.Accept(152)
+	band, ok := bounds.Accept(151)
+	if !ok || band.Median != 151 {
+		t.Errorf("Expected sustained move to recalibrate around 151, got %+v (accepted=%v)", band, ok)
+	}
+
+	if _, ok := bounds.Accept(AbsoluteMaxSOLPrice + 1); ok {
+		t.Errorf("Expected price above absolute limit to be rejected")
+	}
+
+	// A long run of rejections only keeps the newest few
+	for i := 0; i < 100; i++ {
+		bounds.Accept(float64(1000 + 500*(i%2)))
+	}
+	if len(bounds.rejected) != bounds.config.BoundsRecalibrateAfter {
+		t.Errorf("Expected %d rejections kept, got %d", bounds.config.BoundsRecalibrateAfter, len(bounds.rejected))
+	}
+}
+
+func TestPriceConfigBoundsValidation(t *testing.T) {
+	config := newCalibratedConfig()
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config.BoundsRecalibrateAfter = config.BoundsMinSamples - 1
+	if err := config.Validate(); err == nil {
+		t.Error("Expected a recalibrate threshold below min samples to be rejected")
+	}
+}
+
+func TestPriceBoundsRestore(t *testing.T) {
+	config := newCalibratedConfig()
+	config.BoundsWindowSize = 3
+	bounds := NewPriceBounds(config)
+
+	bounds.Restore([]float64{0.5, 100, 200, 210, 220})
+
+	window := bounds.Window()
+	if len(window) != 3 || window[0] != 200 || window[2] != 220 {
+		t.Fatalf("Expected newest valid prices [200 210 220], got %v", window)
+	}
+	if band := bounds.Band(); band.Source != BandSourceCalibrated || band.Median != 210 {
+		t.Errorf("Expected calibrated band around 210 right after restore, got %+v", band)
+	}
+
+	window[0] = 1
+	if bounds.Window()[0] != 200 {
+		t.Error("Expected Window to return a copy")
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"hylo-wallet-tracker-api/internal/fixtures"
 )
 
 // DefaultConfig returns a PriceConfig with sensible defaults for production use
@@ -15,15 +17,33 @@ func DefaultConfig() *PriceConfig {
 		DexScreenerURL:     "https://api.dexscreener.com",
 		DexScreenerTimeout: 10 * time.Second,
 
+		// No pinned pair by default - the most liquid SOL pair is chosen on every fetch
+		PinnedPairMinLiquidityUSD: 100000,
+
 		// Price validation bounds - reasonable SOL price range
 		SOLUSDMinPrice: 50.0,   // Minimum reasonable SOL price in USD
 		SOLUSDMaxPrice: 1000.0, // Maximum reasonable SOL price in USD
 
+		// Auto-calibration disabled by default - static bounds apply
+		AutoCalibrateBounds:    false,
+		BoundsWindowSize:       30,   // Rolling window of accepted prices
+		BoundsMinSamples:       5,    // Use static bounds until this many prices were accepted
+		BoundsTolerancePct:     25.0, // Accept prices within ±25% of the rolling median
+		BoundsRecalibrateAfter: 5,    // Adopt a sustained move after 5 consistent rejections
+
+		// Price history for xSOL volatility and drawdown metrics
+		HistoryInterval:  DefaultHistoryInterval,
+		HistoryRetention: DefaultHistoryRetention,
+
 		// Caching disabled for fresh prices - all requests go to API
 		CacheTTL:        0, // Caching disabled
 		UpdateInterval:  0, // No scheduled updates
 		MaxStalenessSec: 0, // No staleness checks
 
+		// Stale-while-revalidate disabled by default - /price always fetches fresh prices
+		SoftTTL: 0,
+		HardTTL: 0,
+
 		// Rate limiting configuration - respect API limits
 		RequestsPerMinute: 10,              // Conservative rate limit
 		RateLimitWindow:   1 * time.Minute, // Rate limit window
@@ -58,6 +78,14 @@ func NewConfigFromEnvironment() *PriceConfig {
 		}
 	}
 
+	config.PinnedPairAddress = strings.TrimSpace(os.Getenv("DEXSCREENER_PAIR_ADDRESS"))
+
+	if minLiquidityStr := os.Getenv("DEXSCREENER_PAIR_MIN_LIQUIDITY_USD"); minLiquidityStr != "" {
+		if minLiquidity, err := strconv.ParseFloat(minLiquidityStr, 64); err == nil && minLiquidity >= 0 {
+			config.PinnedPairMinLiquidityUSD = minLiquidity
+		}
+	}
+
 	// Load price validation bounds
 	if minPriceStr := os.Getenv("SOL_USD_MIN_PRICE"); minPriceStr != "" {
 		if minPrice, err := strconv.ParseFloat(minPriceStr, 64); err == nil && minPrice > 0 {
@@ -71,6 +99,50 @@ func NewConfigFromEnvironment() *PriceConfig {
 		}
 	}
 
+	// Load price bounds auto-calibration
+	if autoStr := os.Getenv("PRICE_BOUNDS_AUTO_CALIBRATE"); autoStr != "" {
+		if auto, err := strconv.ParseBool(autoStr); err == nil {
+			config.AutoCalibrateBounds = auto
+		}
+	}
+
+	if windowStr := os.Getenv("PRICE_BOUNDS_WINDOW"); windowStr != "" {
+		if window, err := strconv.Atoi(windowStr); err == nil && window > 0 {
+			config.BoundsWindowSize = window
+		}
+	}
+
+	if minSamplesStr := os.Getenv("PRICE_BOUNDS_MIN_SAMPLES"); minSamplesStr != "" {
+		if minSamples, err := strconv.Atoi(minSamplesStr); err == nil && minSamples > 0 {
+			config.BoundsMinSamples = minSamples
+		}
+	}
+
+	if toleranceStr := os.Getenv("PRICE_BOUNDS_TOLERANCE_PCT"); toleranceStr != "" {
+		if tolerance, err := strconv.ParseFloat(toleranceStr, 64); err == nil && tolerance > 0 {
+			config.BoundsTolerancePct = tolerance
+		}
+	}
+
+	if recalibrateStr := os.Getenv("PRICE_BOUNDS_RECALIBRATE_AFTER"); recalibrateStr != "" {
+		if recalibrate, err := strconv.Atoi(recalibrateStr); err == nil && recalibrate > 0 {
+			config.BoundsRecalibrateAfter = recalibrate
+		}
+	}
+
+	// Load price history configuration
+	if intervalStr := os.Getenv("PRICE_HISTORY_INTERVAL_SEC"); intervalStr != "" {
+		if interval, err := strconv.Atoi(intervalStr); err == nil && interval >= 0 {
+			config.HistoryInterval = time.Duration(interval) * time.Second
+		}
+	}
+
+	if retentionStr := os.Getenv("PRICE_HISTORY_RETENTION_DAYS"); retentionStr != "" {
+		if retention, err := strconv.Atoi(retentionStr); err == nil && retention > 0 {
+			config.HistoryRetention = time.Duration(retention) * 24 * time.Hour
+		}
+	}
+
 	// Load caching configuration
 	if cacheTTLStr := os.Getenv("PRICE_CACHE_TTL_SEC"); cacheTTLStr != "" {
 		if cacheTTL, err := strconv.Atoi(cacheTTLStr); err == nil && cacheTTL > 0 {
@@ -90,6 +162,22 @@ func NewConfigFromEnvironment() *PriceConfig {
 		}
 	}
 
+	// Load stale-while-revalidate configuration
+	if softTTLStr := os.Getenv("PRICE_SOFT_TTL_SEC"); softTTLStr != "" {
+		if softTTL, err := strconv.Atoi(softTTLStr); err == nil && softTTL >= 0 {
+			config.SoftTTL = time.Duration(softTTL) * time.Second
+		}
+	}
+
+	if hardTTLStr := os.Getenv("PRICE_HARD_TTL_SEC"); hardTTLStr != "" {
+		if hardTTL, err := strconv.Atoi(hardTTLStr); err == nil && hardTTL >= 0 {
+			config.HardTTL = time.Duration(hardTTL) * time.Second
+		}
+	}
+	if config.SoftTTL > 0 && config.HardTTL == 0 {
+		config.HardTTL = DefaultHardTTLMultiple * config.SoftTTL
+	}
+
 	// Load rate limiting configuration
 	if rpmStr := os.Getenv("PRICE_REQUESTS_PER_MINUTE"); rpmStr != "" {
 		if rpm, err := strconv.Atoi(rpmStr); err == nil && rpm > 0 {
@@ -116,6 +204,12 @@ func NewConfigFromEnvironment() *PriceConfig {
 		}
 	}
 
+	// Load fixtures mode configuration
+	config.FixtureDir = os.Getenv(fixtures.EnvFixtureDir)
+	if record, err := strconv.ParseBool(os.Getenv(fixtures.EnvFixtureRecord)); err == nil {
+		config.FixtureRecord = record
+	}
+
 	return config
 }
 
@@ -138,11 +232,36 @@ func (c *PriceConfig) Validate() error {
 			c.SOLUSDMaxPrice, c.SOLUSDMinPrice)
 	}
 
+	// Validate auto-calibration settings
+	if c.AutoCalibrateBounds {
+		if c.BoundsWindowSize <= 0 {
+			return fmt.Errorf("bounds window size must be positive, got %v", c.BoundsWindowSize)
+		}
+		if c.BoundsMinSamples <= 0 || c.BoundsMinSamples > c.BoundsWindowSize {
+			return fmt.Errorf("bounds min samples must be between 1 and window size (%v), got %v",
+				c.BoundsWindowSize, c.BoundsMinSamples)
+		}
+		if c.BoundsTolerancePct <= 0 || c.BoundsTolerancePct >= 100 {
+			return fmt.Errorf("bounds tolerance must be between 0 and 100 percent, got %v", c.BoundsTolerancePct)
+		}
+		// An adopted move re-seeds the window with BoundsRecalibrateAfter prices; fewer than BoundsMinSamples
+		// would fall back to the static band and reject the adopted price again
+		if c.BoundsRecalibrateAfter < c.BoundsMinSamples {
+			return fmt.Errorf("bounds recalibrate threshold must be at least min samples (%v), got %v",
+				c.BoundsMinSamples, c.BoundsRecalibrateAfter)
+		}
+	}
+
 	// Caching is disabled for fresh prices - skip cache validation
 	// Cache TTL of 0 means no caching, which is the desired behavior
 
 	// No cache-related warnings needed when caching is disabled
 
+	// Validate stale-while-revalidate TTLs
+	if c.SWREnabled() && c.HardTTL < c.SoftTTL {
+		return fmt.Errorf("price hard TTL (%v) must be >= soft TTL (%v)", c.HardTTL, c.SoftTTL)
+	}
+
 	// Validate rate limiting
 	if c.RequestsPerMinute <= 0 {
 		return fmt.Errorf("requests per minute must be positive, got %v", c.RequestsPerMinute)
@@ -214,6 +333,11 @@ func (c *PriceConfig) ShouldCache() bool {
 	return false // Caching disabled for fresh prices
 }
 
+// SWREnabled reports whether GET /price serves cached prices with stale-while-revalidate semantics
+func (c *PriceConfig) SWREnabled() bool {
+	return c.SoftTTL > 0
+}
+
 // String returns a string representation of the config (without sensitive data)
 func (c *PriceConfig) String() string {
 	return fmt.Sprintf("PriceConfig{URL:%s, CacheTTL:%v, UpdateInterval:%v, RPM:%d, MaxRetries:%d}",
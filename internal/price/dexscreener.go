@@ -8,14 +8,20 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"hylo-wallet-tracker-api/internal/audit"
+	"hylo-wallet-tracker-api/internal/fixtures"
 	"hylo-wallet-tracker-api/internal/logger"
 )
 
+// solMint is the wrapped SOL mint DexScreener lists SOL pairs under
+const solMint = "So11111111111111111111111111111111111111112"
+
 // DexScreenerClient handles interactions with the DexScreener API for SOL price data
 type DexScreenerClient struct {
 	// httpClient is the underlying HTTP client with configured timeouts
@@ -30,6 +36,9 @@ type DexScreenerClient struct {
 	// baseURL is the DexScreener API base URL
 	baseURL string
 
+	// bounds validates fetched prices (static or auto-calibrated band)
+	bounds *PriceBounds
+
 	// rateLimiter handles request rate limiting
 	rateLimiter *rateLimiter
 
@@ -62,13 +71,17 @@ func NewDexScreenerClient(config *PriceConfig) *DexScreenerClient {
 		slog.Duration("rate_window", config.RateLimitWindow),
 		slog.String("base_url", config.DexScreenerURL))
 
+	httpClient := &http.Client{Timeout: config.DexScreenerTimeout}
+	if config.FixtureDir != "" {
+		httpClient.Transport = fixtures.NewTransport(config.FixtureDir, config.FixtureRecord, http.DefaultTransport)
+	}
+
 	client := &DexScreenerClient{
-		httpClient: &http.Client{
-			Timeout: config.DexScreenerTimeout,
-		},
-		config:  config,
-		logger:  serviceLogger,
-		baseURL: strings.TrimSuffix(config.DexScreenerURL, "/"),
+		httpClient: httpClient,
+		config:     config,
+		logger:     serviceLogger,
+		baseURL:    strings.TrimSuffix(config.DexScreenerURL, "/"),
+		bounds:     NewPriceBounds(config),
 		rateLimiter: &rateLimiter{
 			tokens:     config.RequestsPerMinute,
 			maxTokens:  config.RequestsPerMinute,
@@ -81,7 +94,7 @@ func NewDexScreenerClient(config *PriceConfig) *DexScreenerClient {
 }
 
 // FetchSOLPrice fetches the current SOL/USD price from DexScreener
-// Returns the best price based on liquidity and trading volume
+// Uses the pinned pair when configured and usable, otherwise the best pair based on liquidity and trading volume
 func (c *DexScreenerClient) FetchSOLPrice(ctx context.Context) (*SOLUSDPrice, error) {
 	const op = "FetchSOLPrice"
 	startTime := time.Now()
@@ -95,8 +108,114 @@ func (c *DexScreenerClient) FetchSOLPrice(ctx context.Context) (*SOLUSDPrice, er
 		return nil, NewPriceError(op, err).WithSource("rate_limit").WithRetryable(false)
 	}
 
+	solPrice := c.fetchPinnedPair(ctx)
+	if solPrice == nil {
+		var err error
+		if solPrice, err = c.scanSOLPairs(ctx, op, startTime); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate the price against the static or auto-calibrated band
+	if band, ok := c.bounds.Accept(solPrice.Price); !ok {
+		validationErr := fmt.Sprintf("price %f outside valid %s range [%f, %f]",
+			solPrice.Price, band.Source, band.Min, band.Max)
+
+		c.logger.LogValidationError(ctx, op, "price", solPrice.Price, fmt.Errorf(validationErr))
+
+		return nil, NewValidationError(op, validationErr, solPrice.Price)
+	}
+
+	// Log successful price fetch
+	c.logger.InfoContext(ctx, "SOL/USD price fetched successfully",
+		slog.Float64("price", solPrice.Price),
+		slog.String("source", solPrice.Source),
+		slog.String("pair", solPrice.Pair),
+		slog.Duration("elapsed", time.Since(startTime)))
+
+	return solPrice, nil
+}
+
+// FetchTokenPrice fetches a token's USD market price from every DexScreener pool listing it as the base token
+// Pools are weighted by USD liquidity, so pools without liquidity cannot move the price
+func (c *DexScreenerClient) FetchTokenPrice(ctx context.Context, mint string) (*TokenPrice, error) {
+	const op = "FetchTokenPrice"
+	startTime := time.Now()
+
+	if err := c.waitForRateLimit(ctx); err != nil {
+		c.logger.LogExternalAPIError(ctx, "dexscreener", "rate_limit", err, 0,
+			slog.Duration("elapsed", time.Since(startTime)))
+		return nil, NewPriceError(op, err).WithSource("rate_limit").WithRetryable(false)
+	}
+
+	requestURL := fmt.Sprintf("%s/latest/dex/tokens/%s", c.baseURL, url.PathEscape(mint))
+	response, err := c.fetchWithRetry(ctx, requestURL)
+	if err != nil {
+		c.logger.LogExternalAPIError(ctx, "dexscreener", "api_request", err, 0,
+			slog.String("mint", mint),
+			slog.Duration("total_elapsed", time.Since(startTime)))
+
+		var priceErr *PriceError
+		if errors.As(err, &priceErr) {
+			return nil, err
+		}
+		return nil, NewPriceError(op, err).WithSource("dexscreener")
+	}
+
+	tokenPrice, err := parseTokenPriceResponse(response, mint)
+	if err != nil {
+		c.logger.LogParsingError(ctx, op, "token_price_response", err,
+			slog.String("mint", mint))
+		return nil, NewPriceError(op, err).WithSource("parsing")
+	}
+
+	c.logger.DebugContext(ctx, "Token price fetched successfully",
+		slog.String("mint", mint),
+		slog.Float64("price", tokenPrice.PriceUSD),
+		slog.Int("pools", tokenPrice.Pools),
+		slog.Duration("elapsed", time.Since(startTime)))
+
+	return tokenPrice, nil
+}
+
+// fetchPinnedPair prices SOL from the configured pair, or returns nil when no pair is pinned or it is unusable
+func (c *DexScreenerClient) fetchPinnedPair(ctx context.Context) *SOLUSDPrice {
+	if c.config.PinnedPairAddress == "" {
+		return nil
+	}
+
+	requestURL := fmt.Sprintf("%s/latest/dex/pairs/solana/%s", c.baseURL, url.PathEscape(c.config.PinnedPairAddress))
+	response, err := c.fetchWithRetry(ctx, requestURL)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Pinned DexScreener pair request failed, falling back to pair scan",
+			slog.String("pair_address", c.config.PinnedPairAddress),
+			slog.String("error", err.Error()))
+		return nil
+	}
+
+	solPrice, err := c.parsePinnedPairResponse(response)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Pinned DexScreener pair unusable, falling back to pair scan",
+			slog.String("pair_address", c.config.PinnedPairAddress),
+			slog.String("reason", err.Error()))
+		return nil
+	}
+	return solPrice
+}
+
+// scanSOLPairs fetches every SOL pair and prices SOL from the best scoring one
+// A fallback after the pinned pair request waits for its own rate limit token
+func (c *DexScreenerClient) scanSOLPairs(ctx context.Context, op string, startTime time.Time) (*SOLUSDPrice, error) {
+	if c.config.PinnedPairAddress != "" {
+		if err := c.waitForRateLimit(ctx); err != nil {
+			c.logger.LogExternalAPIError(ctx, "dexscreener", "rate_limit", err, 0,
+				slog.Duration("elapsed", time.Since(startTime)))
+			return nil, NewPriceError(op, err).WithSource("rate_limit").WithRetryable(false)
+		}
+	}
+
 	// Build the request URL for SOL pairs
-	requestURL := fmt.Sprintf("%s/latest/dex/tokens/So11111111111111111111111111111111111111112", c.baseURL)
+	requestURL := fmt.Sprintf("%s/latest/dex/tokens/%s", c.baseURL, solMint)
 
 	c.logger.DebugContext(ctx, "Making DexScreener API request",
 		slog.String("url", requestURL))
@@ -123,23 +242,6 @@ func (c *DexScreenerClient) FetchSOLPrice(ctx context.Context) (*SOLUSDPrice, er
 		return nil, NewPriceError(op, err).WithSource("parsing")
 	}
 
-	// Validate the price
-	if !c.config.IsValidSOLPrice(solPrice.Price) {
-		validationErr := fmt.Sprintf("price %f outside valid range [%f, %f]",
-			solPrice.Price, c.config.SOLUSDMinPrice, c.config.SOLUSDMaxPrice)
-
-		c.logger.LogValidationError(ctx, op, "price", solPrice.Price, fmt.Errorf(validationErr))
-
-		return nil, NewValidationError(op, validationErr, solPrice.Price)
-	}
-
-	// Log successful price fetch
-	c.logger.InfoContext(ctx, "SOL/USD price fetched successfully",
-		slog.Float64("price", solPrice.Price),
-		slog.String("source", solPrice.Source),
-		slog.String("pair", solPrice.Pair),
-		slog.Duration("elapsed", time.Since(startTime)))
-
 	return solPrice, nil
 }
 
@@ -169,6 +271,14 @@ func (c *DexScreenerClient) waitForRateLimit(ctx context.Context) error {
 	return nil
 }
 
+// auditTarget labels DexScreener calls in the audit log
+const auditTarget = "dexscreener"
+
+// SetAuditLog records every outbound DexScreener call made by this client into log
+func (c *DexScreenerClient) SetAuditLog(log *audit.Log) {
+	c.httpClient.Transport = audit.NewTransport(c.httpClient.Transport, log)
+}
+
 // fetchWithRetry performs HTTP request with exponential backoff retry logic
 func (c *DexScreenerClient) fetchWithRetry(ctx context.Context, requestURL string) (*DexScreenerResponse, error) {
 	var lastErr error
@@ -189,7 +299,7 @@ func (c *DexScreenerClient) fetchWithRetry(ctx context.Context, requestURL strin
 		}
 
 		// Create HTTP request
-		req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+		req, err := http.NewRequestWithContext(audit.WithCall(ctx, auditTarget, "", attempt), "GET", requestURL, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
@@ -323,17 +433,84 @@ func (c *DexScreenerClient) parseSOLPriceResponse(response *DexScreenerResponse)
 		return nil, fmt.Errorf("failed to parse best pair price: %w", err)
 	}
 
-	// Determine the pair symbol (prefer major stablecoins)
-	pairSymbol := fmt.Sprintf("SOL/%s", bestPair.QuoteToken.Symbol)
+	return newPairPrice(bestPair, price), nil
+}
+
+// parsePinnedPairResponse prices SOL from the pinned pair in a pairs endpoint response
+// The pair must quote SOL as its base token, price it within bounds and hold the minimum liquidity
+func (c *DexScreenerClient) parsePinnedPairResponse(response *DexScreenerResponse) (*SOLUSDPrice, error) {
+	if response != nil {
+		for i := range response.Pairs {
+			pair := &response.Pairs[i]
+			if pair.PairAddr != c.config.PinnedPairAddress {
+				continue
+			}
+
+			if pair.BaseToken.Address != solMint {
+				return nil, fmt.Errorf("pinned pair base token %s is not SOL", pair.BaseToken.Symbol)
+			}
+
+			price, err := strconv.ParseFloat(pair.PriceUSD, 64)
+			if err != nil || !c.config.IsValidSOLPrice(price) {
+				return nil, fmt.Errorf("pinned pair price %q is missing or out of bounds", pair.PriceUSD)
+			}
+
+			if pair.Liquidity.USD < c.config.PinnedPairMinLiquidityUSD {
+				return nil, fmt.Errorf("pinned pair liquidity $%.0f is below the $%.0f minimum",
+					pair.Liquidity.USD, c.config.PinnedPairMinLiquidityUSD)
+			}
+
+			return newPairPrice(pair, price), nil
+		}
+	}
+
+	return nil, fmt.Errorf("pinned pair %s not found in response", c.config.PinnedPairAddress)
+}
+
+// parseTokenPriceResponse averages the USD price of the pools quoting mint as their base token, weighted by liquidity
+func parseTokenPriceResponse(response *DexScreenerResponse, mint string) (*TokenPrice, error) {
+	if response == nil || len(response.Pairs) == 0 {
+		return nil, fmt.Errorf("no trading pairs found for %s", mint)
+	}
+
+	tokenPrice := &TokenPrice{Mint: mint, Source: "dexscreener"}
+	var weighted float64
+	for i := range response.Pairs {
+		pair := &response.Pairs[i]
+		if pair.BaseToken.Address != mint || pair.Liquidity.USD <= 0 {
+			continue
+		}
+
+		price, err := strconv.ParseFloat(pair.PriceUSD, 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+
+		tokenPrice.Symbol = pair.BaseToken.Symbol
+		tokenPrice.LiquidityUSD += pair.Liquidity.USD
+		tokenPrice.Pools++
+		weighted += price * pair.Liquidity.USD
+	}
+
+	if tokenPrice.Pools == 0 {
+		return nil, fmt.Errorf("no liquid pools with a USD price found for %s", mint)
+	}
+
+	tokenPrice.PriceUSD = weighted / tokenPrice.LiquidityUSD
+	tokenPrice.Timestamp = time.Now()
+	return tokenPrice, nil
+}
 
+// newPairPrice builds the SOL/USD price reported for a DexScreener pair
+func newPairPrice(pair *DexScreenerPair, price float64) *SOLUSDPrice {
 	return &SOLUSDPrice{
 		Price:     price,
 		Timestamp: time.Now(),
 		Source:    "dexscreener",
-		Pair:      pairSymbol,
-		Liquidity: bestPair.Liquidity.USD,
-		Volume24h: bestPair.Volume.H24,
-	}, nil
+		Pair:      fmt.Sprintf("SOL/%s", pair.QuoteToken.Symbol),
+		Liquidity: pair.Liquidity.USD,
+		Volume24h: pair.Volume.H24,
+	}
 }
 
 // Rate limiter implementation
@@ -390,9 +567,7 @@ func (r *rateLimiter) timeUntilNextToken() time.Duration {
 // Close performs cleanup (currently no-op but provided for interface consistency)
 func (c *DexScreenerClient) Close() error {
 	// Close HTTP client connections if needed
-	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
-		transport.CloseIdleConnections()
-	}
+	c.httpClient.CloseIdleConnections()
 	return nil
 }
 
@@ -400,3 +575,18 @@ func (c *DexScreenerClient) Close() error {
 func (c *DexScreenerClient) GetConfig() *PriceConfig {
 	return c.config
 }
+
+// PriceBand returns the SOL/USD range currently accepted by the client
+func (c *DexScreenerClient) PriceBand() PriceBand {
+	return c.bounds.Band()
+}
+
+// PriceWindow returns the recently accepted SOL/USD prices used for bounds calibration
+func (c *DexScreenerClient) PriceWindow() []float64 {
+	return c.bounds.Window()
+}
+
+// RestorePriceWindow seeds bounds calibration with prices accepted before a restart
+func (c *DexScreenerClient) RestorePriceWindow(window []float64) {
+	c.bounds.Restore(window)
+}
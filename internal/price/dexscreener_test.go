@@ -268,6 +268,49 @@ func TestDexScreenerClient_FetchSOLPrice_BestPairSelection(t *testing.T) {
 	}
 }
 
+func TestDexScreenerClient_FetchTokenPrice(t *testing.T) {
+	const mint = "5YMkXAYccHSGnHn9nob9xEvv6Pvka9DZWH7nTbotTu9E"
+	mockResponse := DexScreenerResponse{
+		Pairs: []DexScreenerPair{
+			{PriceUSD: "0.998", BaseToken: Token{Address: mint, Symbol: "hyUSD"}, Liquidity: Liquidity{USD: 3000000}},
+			{PriceUSD: "1.002", BaseToken: Token{Address: mint, Symbol: "hyUSD"}, Liquidity: Liquidity{USD: 1000000}},
+			{PriceUSD: "0.5", BaseToken: Token{Address: mint, Symbol: "hyUSD"}},                                  // No liquidity
+			{PriceUSD: "1.0", BaseToken: Token{Address: solMint, Symbol: "SOL"}, Liquidity: Liquidity{USD: 9e6}}, // Quotes hyUSD
+		},
+	}
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.DexScreenerURL = server.URL
+	client := NewDexScreenerClient(config)
+
+	tokenPrice, err := client.FetchTokenPrice(context.Background(), mint)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if path != "/latest/dex/tokens/"+mint {
+		t.Errorf("Unexpected request path %s", path)
+	}
+	if tokenPrice.Pools != 2 || tokenPrice.LiquidityUSD != 4000000 || tokenPrice.Symbol != "hyUSD" {
+		t.Errorf("Expected two liquid hyUSD pools, got %+v", tokenPrice)
+	}
+	if diff := tokenPrice.PriceUSD - 0.999; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Expected liquidity-weighted price 0.999, got %v", tokenPrice.PriceUSD)
+	}
+
+	mockResponse.Pairs = mockResponse.Pairs[2:]
+	if _, err := client.FetchTokenPrice(context.Background(), mint); err == nil {
+		t.Error("Expected an error when no liquid pool prices the token")
+	}
+}
+
 func TestDexScreenerClient_FetchSOLPrice_HTTPErrors(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -512,3 +555,85 @@ func TestDexScreenerClient_GetConfig(t *testing.T) {
 		t.Error("Expected GetConfig() to return the same config instance")
 	}
 }
+
+func TestDexScreenerClient_FetchSOLPrice_PinnedPair(t *testing.T) {
+	const pinned = "58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2"
+
+	scanResponse := DexScreenerResponse{
+		Pairs: []DexScreenerPair{
+			{PriceUSD: "151.0", QuoteToken: Token{Symbol: "USDT"}, Liquidity: Liquidity{USD: 5000000}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		pinnedPair    DexScreenerPair
+		expectedPrice float64
+		expectScan    bool
+	}{
+		{
+			name: "liquid pinned pair",
+			pinnedPair: DexScreenerPair{PairAddr: pinned, PriceUSD: "150.0",
+				BaseToken: Token{Address: solMint, Symbol: "SOL"}, QuoteToken: Token{Symbol: "USDC"},
+				Liquidity: Liquidity{USD: 2000000}},
+			expectedPrice: 150.0,
+		},
+		{
+			name: "illiquid pinned pair falls back to scan",
+			pinnedPair: DexScreenerPair{PairAddr: pinned, PriceUSD: "150.0",
+				BaseToken: Token{Address: solMint, Symbol: "SOL"}, QuoteToken: Token{Symbol: "USDC"},
+				Liquidity: Liquidity{USD: 5000}},
+			expectedPrice: 151.0,
+			expectScan:    true,
+		},
+		{
+			name:          "missing pinned pair falls back to scan",
+			pinnedPair:    DexScreenerPair{PairAddr: "other", PriceUSD: "150.0"},
+			expectedPrice: 151.0,
+			expectScan:    true,
+		},
+		{
+			name: "pinned pair without SOL base falls back to scan",
+			pinnedPair: DexScreenerPair{PairAddr: pinned, PriceUSD: "1.0",
+				BaseToken: Token{Symbol: "USDC"}, QuoteToken: Token{Symbol: "SOL"},
+				Liquidity: Liquidity{USD: 2000000}},
+			expectedPrice: 151.0,
+			expectScan:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanned := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/latest/dex/pairs/solana/" + pinned:
+					json.NewEncoder(w).Encode(DexScreenerResponse{Pairs: []DexScreenerPair{tt.pinnedPair}})
+				case "/latest/dex/tokens/" + solMint:
+					scanned = true
+					json.NewEncoder(w).Encode(scanResponse)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			config := DefaultConfig()
+			config.DexScreenerURL = server.URL
+			config.PinnedPairAddress = pinned
+			client := NewDexScreenerClient(config)
+
+			price, err := client.FetchSOLPrice(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if price.Price != tt.expectedPrice {
+				t.Errorf("Expected price %f, got %f", tt.expectedPrice, price.Price)
+			}
+			if scanned != tt.expectScan {
+				t.Errorf("Expected scan=%v, got %v", tt.expectScan, scanned)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package price
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default price history settings; override via PRICE_HISTORY_INTERVAL_SEC and PRICE_HISTORY_RETENTION_DAYS
+const (
+	DefaultHistoryInterval  = 5 * time.Minute
+	DefaultHistoryRetention = 30 * 24 * time.Hour
+)
+
+// PricePoint is one SOL/USD and xSOL/USD observation
+type PricePoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	SOLUSD    float64   `json:"sol_usd"`
+	XSOLInUSD float64   `json:"xsol_usd"`
+}
+
+// History keeps SOL and xSOL prices sampled at most once per interval for the retention period
+// Refreshes between samples update the newest price without growing the series, so the metrics
+// computed after every refresh always include the current price
+type History struct {
+	interval  time.Duration
+	retention time.Duration
+
+	mu      sync.RWMutex
+	points  []PricePoint // At least interval apart, oldest first
+	latest  *PricePoint  // Newest refresh when it came less than interval after the last point
+	metrics XSOLMetrics
+}
+
+// NewHistory creates a price history from config; nil when PRICE_HISTORY_INTERVAL_SEC is 0
+func NewHistory(config *PriceConfig) *History {
+	if config.HistoryInterval <= 0 {
+		return nil
+	}
+	return &History{interval: config.HistoryInterval, retention: config.HistoryRetention}
+}
+
+// Record adds a refreshed price and recomputes the xSOL metrics
+// Prices without a positive SOL and xSOL value are ignored
+func (h *History) Record(prices *CombinedPriceResponse) {
+	if h == nil || prices == nil || prices.SOLUSD <= 0 || prices.XSOLInUSD <= 0 {
+		return
+	}
+	point := PricePoint{Timestamp: prices.UpdatedAt.UTC(), SOLUSD: prices.SOLUSD, XSOLInUSD: prices.XSOLInUSD}
+	if point.Timestamp.IsZero() {
+		point.Timestamp = time.Now().UTC()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n := len(h.points); n > 0 {
+		last := h.points[n-1].Timestamp
+		if !point.Timestamp.After(last) {
+			return
+		}
+		if point.Timestamp.Sub(last) < h.interval {
+			h.latest = &point
+			h.recompute()
+			return
+		}
+	}
+	h.points = append(h.points, point)
+	h.latest = nil
+	h.prune(point.Timestamp)
+	h.recompute()
+}
+
+// Points returns the sampled series including the newest refresh, oldest first
+func (h *History) Points() []PricePoint {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.series()
+}
+
+// Metrics returns the xSOL risk metrics computed after the last refresh
+func (h *History) Metrics() XSOLMetrics {
+	if h == nil {
+		return XSOLMetrics{}
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.metrics
+}
+
+// Restore replaces the series, e.g. with one saved before a restart
+// Points are re-sampled at the configured interval and those past retention dropped
+func (h *History) Restore(points []PricePoint) {
+	if h == nil || len(points) == 0 {
+		return
+	}
+	sorted := append([]PricePoint(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.points, h.latest = nil, nil
+	for _, point := range sorted {
+		if point.SOLUSD <= 0 || point.XSOLInUSD <= 0 {
+			continue
+		}
+		if n := len(h.points); n > 0 && point.Timestamp.Sub(h.points[n-1].Timestamp) < h.interval {
+			continue
+		}
+		h.points = append(h.points, point)
+	}
+	if n := len(h.points); n > 0 {
+		h.prune(h.points[n-1].Timestamp)
+	}
+	h.recompute()
+}
+
+// series returns the points plus the newest refresh; callers must hold h.mu
+func (h *History) series() []PricePoint {
+	series := make([]PricePoint, 0, len(h.points)+1)
+	series = append(series, h.points...)
+	if h.latest != nil {
+		series = append(series, *h.latest)
+	}
+	return series
+}
+
+// prune drops points older than retention before now; callers must hold h.mu
+func (h *History) prune(now time.Time) {
+	if h.retention <= 0 {
+		return
+	}
+	cutoff := now.Add(-h.retention)
+	drop := sort.Search(len(h.points), func(i int) bool { return !h.points[i].Timestamp.Before(cutoff) })
+	if drop > 0 {
+		h.points = append(h.points[:0], h.points[drop:]...)
+	}
+}
+
+// recompute refreshes the cached metrics; callers must hold h.mu for writing
+func (h *History) recompute() {
+	h.metrics = ComputeXSOLMetrics(h.series())
+}
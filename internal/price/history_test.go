@@ -0,0 +1,84 @@
+package price
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var historyStart = time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+func refresh(at time.Duration, solUSD, xsolUSD float64) *CombinedPriceResponse {
+	return &CombinedPriceResponse{SOLUSD: solUSD, XSOLInUSD: xsolUSD, UpdatedAt: historyStart.Add(at)}
+}
+
+func TestHistorySampling(t *testing.T) {
+	config := DefaultConfig()
+	config.HistoryInterval = time.Hour
+	config.HistoryRetention = 3 * time.Hour
+	history := NewHistory(config)
+
+	history.Record(refresh(0, 100, 1))
+	history.Record(refresh(10*time.Minute, 101, 1.1))
+	history.Record(refresh(20*time.Minute, 102, 1.2)) // Replaces the 10 minute refresh
+	if points := history.Points(); len(points) != 2 || points[1].SOLUSD != 102 {
+		t.Fatalf("Expected the first sample plus the newest refresh, got %+v", points)
+	}
+
+	history.Record(refresh(time.Hour, 103, 1.3))
+	history.Record(refresh(30*time.Minute, 99, 0.9)) // Out of order
+	if points := history.Points(); len(points) != 2 || points[1].SOLUSD != 103 {
+		t.Fatalf("Expected a new sample after the interval, got %+v", points)
+	}
+
+	history.Record(refresh(5*time.Hour, 104, 1.4))
+	if points := history.Points(); len(points) != 1 || points[0].SOLUSD != 104 {
+		t.Errorf("Expected samples past retention to be dropped, got %+v", points)
+	}
+	if metrics := history.Metrics(); metrics.Samples30D != 1 || metrics.UpdatedAt == nil || !metrics.UpdatedAt.Equal(historyStart.Add(5*time.Hour)) {
+		t.Errorf("Expected metrics recomputed on refresh, got %+v", metrics)
+	}
+
+	config.HistoryInterval = 0
+	if NewHistory(config) != nil {
+		t.Error("Expected a zero interval to disable the history")
+	}
+}
+
+func TestComputeXSOLMetrics(t *testing.T) {
+	// xSOL moves as SOL squared, so its log returns are exactly twice SOL's
+	var points []PricePoint
+	for i, solUSD := range []float64{100, 110, 100, 110, 100} {
+		points = append(points, PricePoint{Timestamp: historyStart.Add(time.Duration(i) * 24 * time.Hour), SOLUSD: solUSD, XSOLInUSD: solUSD * solUSD / 1000})
+	}
+
+	metrics := ComputeXSOLMetrics(points)
+	if metrics.Beta30D == nil || math.Abs(*metrics.Beta30D-2) > 1e-9 {
+		t.Errorf("Expected beta 2, got %v", metrics.Beta30D)
+	}
+	if metrics.Volatility30DPct == nil || metrics.SOLVolatility30DPct == nil ||
+		math.Abs(*metrics.Volatility30DPct-2**metrics.SOLVolatility30DPct) > 1e-9 {
+		t.Errorf("Expected xSOL volatility twice SOL's, got %v and %v", metrics.Volatility30DPct, metrics.SOLVolatility30DPct)
+	}
+
+	// Four daily returns of ln(1.1): sqrt(4 * ln(1.1)^2 / 4 days * 365 days)
+	want := math.Log(1.1) * math.Sqrt(365) * 100
+	if math.Abs(*metrics.SOLVolatility30DPct-want) > 1e-9 {
+		t.Errorf("Expected SOL volatility %.4f%%, got %.4f%%", want, *metrics.SOLVolatility30DPct)
+	}
+
+	drawdown := metrics.MaxDrawdown30D
+	if drawdown == nil || drawdown.Peak != 12.1 || drawdown.Trough != 10 || !drawdown.PeakAt.Equal(points[1].Timestamp) ||
+		math.Abs(drawdown.Pct-(12.1-10)/12.1*100) > 1e-9 {
+		t.Errorf("Expected the drawdown from 12.1 to 10, got %+v", drawdown)
+	}
+	if metrics.Samples7D != 5 || metrics.Samples30D != 5 || metrics.From == nil || !metrics.From.Equal(historyStart) {
+		t.Errorf("Unexpected sample counts %+v", metrics)
+	}
+
+	// Too few prices leave the metrics null
+	short := ComputeXSOLMetrics(points[:2])
+	if short.Volatility7DPct != nil || short.Beta30D != nil || short.MaxDrawdown30D == nil {
+		t.Errorf("Expected only the drawdown from two prices, got %+v", short)
+	}
+}
@@ -0,0 +1,74 @@
+package price
+
+import (
+	"time"
+
+	"hylo-wallet-tracker-api/internal/fx"
+)
+
+// Quote methods describe how a PriceQuote was derived
+const (
+	QuoteMethodMarket       = "market"        // Liquidity-weighted DEX price
+	QuoteMethodProtocol     = "protocol"      // Hylo protocol math over on-chain state
+	QuoteMethodExchangeRate = "exchange_rate" // Value of the pool assets backing one share
+	QuoteMethodPeg          = "peg"           // DEX price monitored against the $1 peg
+)
+
+// PriceQuote is the current price of one supported token
+// Every token shares the same shape; peg and backing are only set for hyUSD and sHYUSD respectively
+type PriceQuote struct {
+	Symbol string `json:"symbol"`
+
+	// Mint is empty for native SOL
+	Mint string `json:"mint,omitempty"`
+
+	PriceUSD float64 `json:"price_usd"`
+	PriceSOL float64 `json:"price_sol"`
+
+	// Method is market, protocol, exchange_rate or peg
+	Method string      `json:"method"`
+	Source QuoteSource `json:"source"`
+
+	Peg     *QuotePeg     `json:"peg,omitempty"`
+	Backing *QuoteBacking `json:"backing,omitempty"`
+
+	Timestamp time.Time `json:"timestamp"`
+
+	// Currency is set when price_usd was converted to another fiat currency
+	Currency *fx.Conversion `json:"currency,omitempty"`
+}
+
+// QuoteSource identifies where a quoted price came from
+type QuoteSource struct {
+	// Provider is dexscreener for market prices and hylo for prices derived from protocol state
+	Provider string `json:"provider"`
+
+	// Pair, liquidity and pool count describe the market the price was read from; liquidity stays in USD
+	Pair         string  `json:"pair,omitempty"`
+	LiquidityUSD float64 `json:"liquidity_usd,omitempty"`
+	Pools        int     `json:"pools,omitempty"`
+
+	// SOLPriceSource is the SOL/USD source used for price_sol and for protocol math
+	SOLPriceSource string `json:"sol_price_source"`
+}
+
+// QuotePeg is hyUSD's standing against its peg
+type QuotePeg struct {
+	PegUSD       float64 `json:"peg_usd"`
+	DeviationBps float64 `json:"deviation_bps"`
+	Depegged     bool    `json:"depegged"`
+}
+
+// QuoteBacking is the stability pool assets one sHYUSD share redeems for
+type QuoteBacking struct {
+	HyUSDPerShare float64 `json:"hyusd_per_share"`
+	XSOLPerShare  float64 `json:"xsol_per_share"`
+}
+
+// InCurrency returns a copy with price_usd converted by conversion; price_sol is unchanged
+func (q *PriceQuote) InCurrency(conversion *fx.Conversion) *PriceQuote {
+	converted := *q
+	converted.PriceUSD = conversion.Convert(q.PriceUSD)
+	converted.Currency = conversion
+	return &converted
+}
@@ -0,0 +1,166 @@
+package price
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+// DefaultSWRRefreshTimeout bounds a price fetch, which runs detached from the request that started it
+const DefaultSWRRefreshTimeout = 30 * time.Second
+
+// DefaultHardTTLMultiple sets the hard TTL when only the soft TTL is configured
+const DefaultHardTTLMultiple = 5
+
+// CacheStatus describes the cached prices a response was served from
+type CacheStatus struct {
+	AgeSeconds     float64 `json:"age_seconds"`
+	SoftTTLSeconds float64 `json:"soft_ttl_seconds"` // Older prices are served while a background refresh runs
+	HardTTLSeconds float64 `json:"hard_ttl_seconds"` // Older prices are never served; the request waits for a refresh
+	Stale          bool    `json:"stale"`            // Older than the soft TTL
+}
+
+// CombinedPriceFetcher fetches fresh combined prices
+type CombinedPriceFetcher func(ctx context.Context) (*CombinedPriceResponse, error)
+
+// SWRCache serves combined prices with stale-while-revalidate semantics: prices younger than the soft TTL
+// are served as they are, prices between the soft and hard TTL are served immediately while one background
+// refresh replaces them, and only prices older than the hard TTL (or none at all) make the request wait
+type SWRCache struct {
+	softTTL time.Duration
+	hardTTL time.Duration
+	fetch   CombinedPriceFetcher
+	logger  *logger.Logger
+	now     func() time.Time
+
+	// inflight coalesces blocking and background fetches so the upstream sees one at a time
+	inflight singleflight.Group
+
+	mu         sync.Mutex
+	prices     *CombinedPriceResponse
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// NewSWRCache creates a cache in front of fetch; it is only useful with SWR enabled in config
+func NewSWRCache(config *PriceConfig, fetch CombinedPriceFetcher) *SWRCache {
+	return &SWRCache{
+		softTTL: config.SoftTTL,
+		hardTTL: config.HardTTL,
+		fetch:   fetch,
+		logger:  logger.NewFromEnv().WithComponent("price-swr-cache"),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached prices with their cache status, refreshing them as their age requires
+func (c *SWRCache) Get(ctx context.Context) (*CombinedPriceResponse, error) {
+	c.mu.Lock()
+	prices, fetchedAt := c.prices, c.fetchedAt
+	age := c.now().Sub(fetchedAt)
+	if prices != nil && age >= c.softTTL && age < c.hardTTL && !c.refreshing {
+		c.refreshing = true
+		go c.refresh(ctx)
+	}
+	c.mu.Unlock()
+
+	if prices == nil || age >= c.hardTTL {
+		fresh, err := c.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return c.withStatus(fresh, 0), nil
+	}
+	return c.withStatus(prices, age), nil
+}
+
+// refresh replaces stale prices in the background; on failure the stale prices keep being served
+// until they pass the hard TTL, and the next request after the soft TTL tries again
+func (c *SWRCache) refresh(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	if _, err := c.load(ctx); err != nil {
+		c.logger.WarnContext(ctx, "Background price refresh failed, serving stale prices",
+			slog.String("error", err.Error()))
+	}
+
+	c.mu.Lock()
+	c.refreshing = false
+	c.mu.Unlock()
+}
+
+// load fetches fresh prices and caches them
+// The shared fetch is detached from ctx, so a caller that gives up does not fail the others waiting on it;
+// each caller still stops waiting when its own ctx ends
+func (c *SWRCache) load(ctx context.Context) (*CombinedPriceResponse, error) {
+	fetchCtx := context.WithoutCancel(ctx)
+	results := c.inflight.DoChan("prices", func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(fetchCtx, DefaultSWRRefreshTimeout)
+		defer cancel()
+
+		prices, err := c.fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.prices, c.fetchedAt = prices, c.now()
+		c.mu.Unlock()
+		return prices, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*CombinedPriceResponse), nil
+	}
+}
+
+// Cached returns the cached prices and when they were fetched; false when nothing is cached
+func (c *SWRCache) Cached() (*CombinedPriceResponse, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.prices, c.fetchedAt, c.prices != nil
+}
+
+// Restore seeds an empty cache with prices fetched at fetchedAt, e.g. from a snapshot saved before a restart
+// Restored prices are always stale, so the first request is served at once while a refresh replaces them;
+// prices past the hard TTL are dropped
+func (c *SWRCache) Restore(prices *CombinedPriceResponse, fetchedAt time.Time) bool {
+	now := c.now()
+	if prices == nil || fetchedAt.IsZero() || now.Sub(fetchedAt) >= c.hardTTL {
+		return false
+	}
+	if stale := now.Add(-c.softTTL); fetchedAt.After(stale) {
+		fetchedAt = stale
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.prices != nil {
+		return false
+	}
+	restored := *prices
+	restored.Cache = nil
+	c.prices, c.fetchedAt = &restored, fetchedAt
+	return true
+}
+
+// withStatus returns a copy of prices carrying their cache status
+func (c *SWRCache) withStatus(prices *CombinedPriceResponse, age time.Duration) *CombinedPriceResponse {
+	served := *prices
+	served.Cache = &CacheStatus{
+		AgeSeconds:     age.Seconds(),
+		SoftTTLSeconds: c.softTTL.Seconds(),
+		HardTTLSeconds: c.hardTTL.Seconds(),
+		Stale:          age >= c.softTTL,
+	}
+	return &served
+}
@@ -0,0 +1,191 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingFetcher returns prices numbered by the fetch that produced them
+type countingFetcher struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+	done  chan struct{}
+}
+
+func (f *countingFetcher) fetch(ctx context.Context) (*CombinedPriceResponse, error) {
+	f.mu.Lock()
+	f.calls++
+	calls, err := f.calls, f.err
+	f.mu.Unlock()
+	if f.done != nil {
+		defer func() { f.done <- struct{}{} }()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &CombinedPriceResponse{SOLUSD: float64(100 + calls)}, nil
+}
+
+func TestSWRCache(t *testing.T) {
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	fetcher := &countingFetcher{}
+	config := &PriceConfig{SoftTTL: 10 * time.Second, HardTTL: time.Minute}
+	cache := NewSWRCache(config, fetcher.fetch)
+	cache.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	// The first request waits for prices
+	prices, err := cache.Get(ctx)
+	if err != nil || prices.SOLUSD != 101 || prices.Cache.Stale || prices.Cache.AgeSeconds != 0 {
+		t.Fatalf("expected fresh prices from the first fetch, got %+v (%v)", prices, err)
+	}
+	if prices.Cache.SoftTTLSeconds != 10 || prices.Cache.HardTTLSeconds != 60 {
+		t.Errorf("expected both TTLs in the cache status, got %+v", prices.Cache)
+	}
+
+	// Within the soft TTL the cached prices are served without fetching
+	clock = clock.Add(5 * time.Second)
+	if prices, _ = cache.Get(ctx); prices.SOLUSD != 101 || prices.Cache.AgeSeconds != 5 || fetcher.calls != 1 {
+		t.Fatalf("expected cached prices aged 5s, got %+v after %d fetches", prices, fetcher.calls)
+	}
+
+	// Past the soft TTL the stale prices are served while a background refresh replaces them
+	fetcher.done = make(chan struct{}, 1)
+	clock = clock.Add(10 * time.Second)
+	if prices, _ = cache.Get(ctx); prices.SOLUSD != 101 || !prices.Cache.Stale {
+		t.Fatalf("expected stale prices to be served, got %+v", prices)
+	}
+	<-fetcher.done
+	waitForRefresh(t, cache)
+	if prices, _ = cache.Get(ctx); prices.SOLUSD != 102 || prices.Cache.Stale {
+		t.Fatalf("expected the refreshed prices, got %+v", prices)
+	}
+
+	// A failed background refresh keeps serving the stale prices
+	fetcher.err = errors.New("dexscreener unavailable")
+	clock = clock.Add(30 * time.Second)
+	if prices, _ = cache.Get(ctx); prices.SOLUSD != 102 || !prices.Cache.Stale {
+		t.Fatalf("expected stale prices to be served, got %+v", prices)
+	}
+	<-fetcher.done
+	waitForRefresh(t, cache)
+
+	// Past the hard TTL the request waits, and fails with the fetch
+	clock = clock.Add(time.Minute)
+	if _, err := cache.Get(ctx); err == nil {
+		t.Fatal("expected prices past the hard TTL not to be served")
+	}
+	<-fetcher.done
+
+	fetcher.err = nil
+	if prices, err = cache.Get(ctx); err != nil || prices.SOLUSD != 105 || prices.Cache.Stale {
+		t.Fatalf("expected a blocking fetch past the hard TTL, got %+v (%v)", prices, err)
+	}
+}
+
+func TestSWRCacheRestore(t *testing.T) {
+	clock := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	fetcher := &countingFetcher{done: make(chan struct{}, 1)}
+	config := &PriceConfig{SoftTTL: 10 * time.Second, HardTTL: time.Minute}
+	cache := NewSWRCache(config, fetcher.fetch)
+	cache.now = func() time.Time { return clock }
+
+	// Prices past the hard TTL are dropped
+	if cache.Restore(&CombinedPriceResponse{SOLUSD: 90}, clock.Add(-time.Minute)) {
+		t.Fatal("expected prices past the hard TTL not to be restored")
+	}
+
+	// Restored prices are served stale, even when saved moments ago, while a refresh replaces them
+	if !cache.Restore(&CombinedPriceResponse{SOLUSD: 95, Cache: &CacheStatus{}}, clock.Add(-time.Second)) {
+		t.Fatal("expected prices within the hard TTL to be restored")
+	}
+	if cache.Restore(&CombinedPriceResponse{SOLUSD: 96}, clock) {
+		t.Error("expected a filled cache not to be overwritten")
+	}
+	prices, err := cache.Get(context.Background())
+	if err != nil || prices.SOLUSD != 95 || !prices.Cache.Stale {
+		t.Fatalf("expected the restored prices served stale, got %+v (%v)", prices, err)
+	}
+	<-fetcher.done
+	waitForRefresh(t, cache)
+	if prices, _ = cache.Get(context.Background()); prices.SOLUSD != 101 || prices.Cache.Stale {
+		t.Fatalf("expected the refreshed prices, got %+v", prices)
+	}
+	if cached, fetchedAt, ok := cache.Cached(); !ok || cached.SOLUSD != 101 || !fetchedAt.Equal(clock) {
+		t.Errorf("expected the refreshed prices cached at %v, got %+v at %v", clock, cached, fetchedAt)
+	}
+}
+
+func TestSWRCacheLoadOutlivesCanceledCaller(t *testing.T) {
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (*CombinedPriceResponse, error) {
+		select {
+		case <-release:
+			return &CombinedPriceResponse{SOLUSD: 150}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	cache := NewSWRCache(&PriceConfig{SoftTTL: 10 * time.Second, HardTTL: time.Minute}, fetch)
+
+	// The first caller gives up while a second one waits on the same fetch
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cache.Get(first)
+		firstErr <- err
+	}()
+	second := make(chan *CombinedPriceResponse, 1)
+	go func() {
+		prices, _ := cache.Get(context.Background())
+		second <- prices
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the canceled caller to stop waiting, got %v", err)
+	}
+	close(release)
+	if prices := <-second; prices == nil || prices.SOLUSD != 150 {
+		t.Fatalf("expected the other caller to get the fetched prices, got %+v", prices)
+	}
+}
+
+func TestPriceConfigSWRValidation(t *testing.T) {
+	config := DefaultConfig()
+	if config.SWREnabled() || config.Validate() != nil {
+		t.Fatal("expected stale-while-revalidate to be disabled by default")
+	}
+
+	config.SoftTTL, config.HardTTL = 30*time.Second, 10*time.Second
+	if err := config.Validate(); err == nil {
+		t.Error("expected a hard TTL below the soft TTL to be rejected")
+	}
+
+	t.Setenv("PRICE_SOFT_TTL_SEC", "30")
+	config = NewConfigFromEnvironment()
+	if config.HardTTL != DefaultHardTTLMultiple*30*time.Second || config.Validate() != nil {
+		t.Errorf("expected the hard TTL to default to a multiple of the soft TTL, got %v", config.HardTTL)
+	}
+}
+
+// waitForRefresh waits for the background refresh to finish
+func waitForRefresh(t *testing.T, cache *SWRCache) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		cache.mu.Lock()
+		refreshing := cache.refreshing
+		cache.mu.Unlock()
+		if !refreshing {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("background refresh did not finish")
+}
@@ -2,6 +2,9 @@ package price
 
 import (
 	"time"
+
+	"hylo-wallet-tracker-api/internal/fx"
+	"hylo-wallet-tracker-api/internal/solana"
 )
 
 // SOLUSDPrice represents a SOL/USD price point with metadata
@@ -25,6 +28,30 @@ type SOLUSDPrice struct {
 	Volume24h float64 `json:"volume_24h,omitempty"`
 }
 
+// TokenPrice is a token's USD market price aggregated across its DexScreener pools
+type TokenPrice struct {
+	// Mint is the token mint the price was fetched for
+	Mint string `json:"mint"`
+
+	// Symbol is the token symbol reported by DexScreener
+	Symbol string `json:"symbol"`
+
+	// PriceUSD is the liquidity-weighted USD price across the pools used
+	PriceUSD float64 `json:"price_usd"`
+
+	// LiquidityUSD is the combined USD liquidity of the pools used
+	LiquidityUSD float64 `json:"liquidity_usd"`
+
+	// Pools is the number of pools the price was aggregated from
+	Pools int `json:"pools"`
+
+	// Timestamp indicates when this price was fetched
+	Timestamp time.Time `json:"timestamp"`
+
+	// Source identifies the price data provider
+	Source string `json:"source"`
+}
+
 // XSOLPrice represents xSOL price data in both SOL and USD terms
 type XSOLPrice struct {
 	// PriceInSOL is the xSOL price denominated in SOL (e.g., 2.7149)
@@ -57,6 +84,24 @@ type CombinedPriceResponse struct {
 
 	// UpdatedAt indicates the timestamp of the most recent price update
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Currency is set when the USD prices were converted to another fiat currency
+	Currency *fx.Conversion `json:"currency,omitempty"`
+
+	// Cache is set when the prices were served from the stale-while-revalidate cache
+	Cache *CacheStatus `json:"cache,omitempty"`
+
+	// Context is the slot, commitment and fetch time of the chain data behind the response
+	Context *solana.DataContext `json:"context,omitempty"`
+}
+
+// InCurrency returns a copy with the USD prices converted by conversion; xsol_sol is unchanged
+func (p *CombinedPriceResponse) InCurrency(conversion *fx.Conversion) *CombinedPriceResponse {
+	converted := *p
+	converted.SOLUSD = conversion.Convert(p.SOLUSD)
+	converted.XSOLInUSD = conversion.Convert(p.XSOLInUSD)
+	converted.Currency = conversion
+	return &converted
 }
 
 // PriceConfig holds configuration for price service operations
@@ -65,15 +110,35 @@ type PriceConfig struct {
 	DexScreenerURL     string        `json:"dexscreener_url"`
 	DexScreenerTimeout time.Duration `json:"dexscreener_timeout"`
 
+	// Pinned pair: fetched directly instead of scoring every SOL pair; the scan is the fallback when
+	// the pair is missing, priced out of bounds or its liquidity drops below PinnedPairMinLiquidityUSD
+	PinnedPairAddress         string  `json:"pinned_pair_address,omitempty"`
+	PinnedPairMinLiquidityUSD float64 `json:"pinned_pair_min_liquidity_usd"`
+
 	// Price validation bounds
 	SOLUSDMinPrice float64 `json:"sol_usd_min_price"`
 	SOLUSDMaxPrice float64 `json:"sol_usd_max_price"`
 
+	// Auto-calibrated price bounds: rolling median of accepted prices ± BoundsTolerancePct
+	AutoCalibrateBounds    bool    `json:"auto_calibrate_bounds"`
+	BoundsWindowSize       int     `json:"bounds_window_size"`       // Accepted prices kept for the median
+	BoundsMinSamples       int     `json:"bounds_min_samples"`       // Samples required before the static band is replaced
+	BoundsTolerancePct     float64 `json:"bounds_tolerance_pct"`     // Allowed deviation from the median
+	BoundsRecalibrateAfter int     `json:"bounds_recalibrate_after"` // Consecutive consistent rejections that re-seed the band
+
+	// Price history sampled for xSOL risk metrics; HistoryInterval 0 disables it
+	HistoryInterval  time.Duration `json:"history_interval"`
+	HistoryRetention time.Duration `json:"history_retention"`
+
 	// Caching configuration
 	CacheTTL        time.Duration `json:"cache_ttl"`
 	UpdateInterval  time.Duration `json:"update_interval"`
 	MaxStalenessSec int           `json:"max_staleness_sec"`
 
+	// Stale-while-revalidate for GET /price; SoftTTL 0 disables it and every request fetches fresh prices
+	SoftTTL time.Duration `json:"soft_ttl"` // Older prices are served while a background refresh runs
+	HardTTL time.Duration `json:"hard_ttl"` // Older prices make the request wait for fresh ones
+
 	// Rate limiting configuration
 	RequestsPerMinute int           `json:"requests_per_minute"`
 	RateLimitWindow   time.Duration `json:"rate_limit_window"`
@@ -83,6 +148,10 @@ type PriceConfig struct {
 	BaseBackoff       time.Duration `json:"base_backoff"`
 	MaxBackoff        time.Duration `json:"max_backoff"`
 	BackoffMultiplier float64       `json:"backoff_multiplier"`
+
+	// Fixtures mode: serve (or record) DexScreener responses from this directory
+	FixtureDir    string `json:"fixture_dir,omitempty"`
+	FixtureRecord bool   `json:"fixture_record,omitempty"`
 }
 
 // DexScreenerPair represents a trading pair from DexScreener API response
@@ -0,0 +1,159 @@
+package price
+
+import (
+	"math"
+	"time"
+)
+
+// Metric windows, measured back from the newest price
+const (
+	ShortMetricsWindow = 7 * 24 * time.Hour
+	LongMetricsWindow  = 30 * 24 * time.Hour
+)
+
+// year annualizes realized variance
+const year = 365 * 24 * time.Hour
+
+// XSOLMetrics are risk metrics for holding xSOL, derived from the price history
+// Percentages are in percent (35.2 means 35.2%); a metric is null until its window holds enough prices
+type XSOLMetrics struct {
+	// Annualized realized volatility of xSOL/USD log returns
+	Volatility7DPct  *float64 `json:"volatility_7d_pct"`
+	Volatility30DPct *float64 `json:"volatility_30d_pct"`
+
+	// SOLVolatility30DPct is SOL's realized volatility over the same 30 days, for comparison
+	SOLVolatility30DPct *float64 `json:"sol_volatility_30d_pct"`
+
+	// MaxDrawdown30D is the largest peak-to-trough decline of xSOL/USD within 30 days
+	MaxDrawdown30D *Drawdown `json:"max_drawdown_30d"`
+
+	// Beta30D is the sensitivity of xSOL returns to SOL returns (cov(xSOL, SOL) / var(SOL))
+	Beta30D *float64 `json:"beta_30d"`
+
+	Samples7D  int        `json:"samples_7d"`
+	Samples30D int        `json:"samples_30d"`
+	From       *time.Time `json:"from"`       // Oldest price in the 30 day window
+	UpdatedAt  *time.Time `json:"updated_at"` // Newest price
+}
+
+// Drawdown is a decline from a running peak
+type Drawdown struct {
+	Pct      float64   `json:"pct"`
+	Peak     float64   `json:"peak"`
+	Trough   float64   `json:"trough"`
+	PeakAt   time.Time `json:"peak_at"`
+	TroughAt time.Time `json:"trough_at"`
+}
+
+// ComputeXSOLMetrics derives xSOL risk metrics from a price series ordered oldest first
+// Irregular sampling is accounted for: realized variance is the sum of squared log returns divided
+// by the time they span, so gaps in the series neither inflate nor dilute volatility
+func ComputeXSOLMetrics(points []PricePoint) XSOLMetrics {
+	var metrics XSOLMetrics
+	if len(points) == 0 {
+		return metrics
+	}
+	newest := points[len(points)-1].Timestamp
+	short, long := window(points, newest.Add(-ShortMetricsWindow)), window(points, newest.Add(-LongMetricsWindow))
+
+	metrics.Samples7D, metrics.Samples30D = len(short), len(long)
+	metrics.From, metrics.UpdatedAt = &long[0].Timestamp, &newest
+
+	xsol := func(p PricePoint) float64 { return p.XSOLInUSD }
+	sol := func(p PricePoint) float64 { return p.SOLUSD }
+	metrics.Volatility7DPct = realizedVolatility(short, xsol)
+	metrics.Volatility30DPct = realizedVolatility(long, xsol)
+	metrics.SOLVolatility30DPct = realizedVolatility(long, sol)
+	metrics.MaxDrawdown30D = maxDrawdown(long)
+	metrics.Beta30D = beta(long)
+	return metrics
+}
+
+// window returns the points at or after from
+func window(points []PricePoint, from time.Time) []PricePoint {
+	for i, point := range points {
+		if !point.Timestamp.Before(from) {
+			return points[i:]
+		}
+	}
+	return nil
+}
+
+// logReturns returns the log return between each pair of consecutive points
+func logReturns(points []PricePoint, value func(PricePoint) float64) []float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	returns := make([]float64, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		returns = append(returns, math.Log(value(points[i])/value(points[i-1])))
+	}
+	return returns
+}
+
+// realizedVolatility annualizes the realized variance of the series; nil with fewer than two returns
+func realizedVolatility(points []PricePoint, value func(PricePoint) float64) *float64 {
+	returns := logReturns(points, value)
+	if len(returns) < 2 {
+		return nil
+	}
+	span := points[len(points)-1].Timestamp.Sub(points[0].Timestamp)
+	if span <= 0 {
+		return nil
+	}
+
+	var sumSquares float64
+	for _, r := range returns {
+		sumSquares += r * r
+	}
+	volatility := math.Sqrt(sumSquares/span.Seconds()*year.Seconds()) * 100
+	return &volatility
+}
+
+// maxDrawdown finds the largest decline from a running peak; nil with fewer than two points
+func maxDrawdown(points []PricePoint) *Drawdown {
+	if len(points) < 2 {
+		return nil
+	}
+
+	worst := &Drawdown{Peak: points[0].XSOLInUSD, Trough: points[0].XSOLInUSD, PeakAt: points[0].Timestamp, TroughAt: points[0].Timestamp}
+	peak := points[0]
+	for _, point := range points[1:] {
+		if point.XSOLInUSD > peak.XSOLInUSD {
+			peak = point
+			continue
+		}
+		if pct := (peak.XSOLInUSD - point.XSOLInUSD) / peak.XSOLInUSD * 100; pct > worst.Pct {
+			worst = &Drawdown{Pct: pct, Peak: peak.XSOLInUSD, Trough: point.XSOLInUSD, PeakAt: peak.Timestamp, TroughAt: point.Timestamp}
+		}
+	}
+	return worst
+}
+
+// beta regresses xSOL log returns on SOL log returns; nil with fewer than two returns or flat SOL
+func beta(points []PricePoint) *float64 {
+	xsol := logReturns(points, func(p PricePoint) float64 { return p.XSOLInUSD })
+	sol := logReturns(points, func(p PricePoint) float64 { return p.SOLUSD })
+	if len(sol) < 2 {
+		return nil
+	}
+
+	var meanX, meanS float64
+	for i := range sol {
+		meanX += xsol[i]
+		meanS += sol[i]
+	}
+	meanX /= float64(len(sol))
+	meanS /= float64(len(sol))
+
+	var covariance, variance float64
+	for i := range sol {
+		covariance += (xsol[i] - meanX) * (sol[i] - meanS)
+		variance += (sol[i] - meanS) * (sol[i] - meanS)
+	}
+	if variance == 0 {
+		return nil
+	}
+	b := covariance / variance
+	return &b
+}
@@ -0,0 +1,195 @@
+// Package sanctum reads LST to SOL exchange rates from the Sanctum API
+package sanctum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/fixtures"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// Source identifies rates served by this client
+const Source = "sanctum"
+
+// lamportsDecimals is the precision of the API's SOL values, which are lamports per whole LST token
+const lamportsDecimals = 9
+
+// ErrRateUnavailable is returned when the API has no rate for an LST mint
+var ErrRateUnavailable = errors.New("LST rate unavailable")
+
+// Rate is the SOL value of one whole LST token
+type Rate struct {
+	Mint        solana.Address `json:"mint"`
+	SOLPerToken float64        `json:"sol_per_token"`
+	FetchedAt   time.Time      `json:"fetched_at"`
+}
+
+// solValueResponse is the body of GET /v1/sol-value/current
+// SOL values are decimal strings of lamports per whole token; errs maps mints the API could not price
+type solValueResponse struct {
+	SOLValues map[string]string `json:"solValues"`
+	Errs      map[string]any    `json:"errs"`
+}
+
+// Client fetches LST rates from the Sanctum API and caches them per mint
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	cacheTTL   time.Duration
+	logger     *logger.Logger
+	now        func() time.Time
+
+	mu    sync.Mutex
+	rates map[solana.Address]Rate
+}
+
+// NewClient creates a Sanctum client from config
+func NewClient(config *Config) *Client {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	httpClient := &http.Client{Timeout: config.Timeout}
+	if config.FixtureDir != "" {
+		httpClient.Transport = fixtures.NewTransport(config.FixtureDir, config.FixtureRecord, http.DefaultTransport)
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(config.APIURL, "/"),
+		cacheTTL:   config.CacheTTL,
+		logger:     logger.NewFromEnv().WithComponent("sanctum-client"),
+		now:        time.Now,
+		rates:      make(map[solana.Address]Rate),
+	}
+}
+
+// SOLPerToken returns the SOL value of one whole token of an LST mint
+func (c *Client) SOLPerToken(ctx context.Context, mint solana.Address) (float64, error) {
+	rates, err := c.Rates(ctx, mint)
+	if err != nil {
+		return 0, err
+	}
+	return rates[mint].SOLPerToken, nil
+}
+
+// Rates returns the rate of every mint, fetching the ones missing from the cache in a single request
+func (c *Client) Rates(ctx context.Context, mints ...solana.Address) (map[solana.Address]Rate, error) {
+	result := make(map[solana.Address]Rate, len(mints))
+	var missing []solana.Address
+
+	c.mu.Lock()
+	now := c.now()
+	for _, mint := range mints {
+		if rate, ok := c.rates[mint]; ok && now.Sub(rate.FetchedAt) < c.cacheTTL {
+			result[mint] = rate
+		} else {
+			missing = append(missing, mint)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := c.fetch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	for mint, rate := range fetched {
+		c.rates[mint] = rate
+		result[mint] = rate
+	}
+	c.mu.Unlock()
+
+	return result, nil
+}
+
+// fetch requests current SOL values for mints; every mint must be priced
+func (c *Client) fetch(ctx context.Context, mints []solana.Address) (map[solana.Address]Rate, error) {
+	query := url.Values{}
+	for _, mint := range mints {
+		query.Add("lst", mint.String())
+	}
+	requestURL := c.baseURL + "/v1/sol-value/current?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	startTime := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.LogExternalAPIError(ctx, Source, "sol-value", err, 0)
+		return nil, fmt.Errorf("sanctum request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sanctum response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("sanctum API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		c.logger.LogExternalAPIError(ctx, Source, "sol-value", err, resp.StatusCode)
+		return nil, err
+	}
+
+	rates, err := parseSOLValues(body, mints, c.now())
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.DebugContext(ctx, "Fetched LST rates",
+		slog.Int("mints", len(mints)),
+		slog.Duration("elapsed", time.Since(startTime)))
+	return rates, nil
+}
+
+// parseSOLValues converts the API's lamport strings into rates for each requested mint
+func parseSOLValues(body []byte, mints []solana.Address, fetchedAt time.Time) (map[solana.Address]Rate, error) {
+	var response solValueResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("malformed sanctum response: %w", err)
+	}
+
+	rates := make(map[solana.Address]Rate, len(mints))
+	for _, mint := range mints {
+		value, ok := response.SOLValues[mint.String()]
+		if !ok {
+			if reason, failed := response.Errs[mint.String()]; failed {
+				return nil, fmt.Errorf("%w for %s: %v", ErrRateUnavailable, mint, reason)
+			}
+			return nil, fmt.Errorf("%w for %s", ErrRateUnavailable, mint)
+		}
+
+		lamports, err := utils.ParseDecimalAmount(value, 0)
+		if err != nil || lamports == 0 {
+			return nil, fmt.Errorf("malformed sanctum SOL value %q for %s", value, mint)
+		}
+
+		rates[mint] = Rate{
+			Mint:        mint,
+			SOLPerToken: utils.NewDecimalFromRaw(lamports, lamportsDecimals).Float64(),
+			FetchedAt:   fetchedAt,
+		}
+	}
+	return rates, nil
+}
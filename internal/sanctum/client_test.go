@@ -0,0 +1,88 @@
+package sanctum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *time.Time) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := DefaultConfig()
+	config.APIURL = server.URL
+	client := NewClient(config)
+	clock := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return clock }
+	return client, &clock
+}
+
+func TestClientSOLPerToken(t *testing.T) {
+	var requests int
+	client, clock := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v1/sol-value/current" || r.URL.Query().Get("lst") != tokens.JitoSOLMint.String() {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		fmt.Fprintf(w, `{"solValues":{"%s":"1213456789"},"errs":{}}`, tokens.JitoSOLMint)
+	})
+
+	for i := 0; i < 2; i++ {
+		rate, err := client.SOLPerToken(context.Background(), tokens.JitoSOLMint)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(rate-1.213456789) > 1e-12 {
+			t.Errorf("Expected 1.213456789 SOL per jitoSOL, got %v", rate)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the second lookup to be served from cache, got %d requests", requests)
+	}
+
+	*clock = clock.Add(DefaultCacheTTL)
+	if _, err := client.SOLPerToken(context.Background(), tokens.JitoSOLMint); err != nil || requests != 2 {
+		t.Errorf("Expected an expired rate to be refetched, got %d requests (%v)", requests, err)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		body   string
+		isErr  error
+	}{
+		{"mint error", http.StatusOK, `{"solValues":{},"errs":{"` + tokens.JitoSOLMint.String() + `":"unknown lst"}}`, ErrRateUnavailable},
+		{"missing mint", http.StatusOK, `{"solValues":{}}`, ErrRateUnavailable},
+		{"zero value", http.StatusOK, `{"solValues":{"` + tokens.JitoSOLMint.String() + `":"0"}}`, nil},
+		{"malformed", http.StatusOK, `not json`, nil},
+		{"server error", http.StatusBadGateway, `upstream down`, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			})
+
+			_, err := client.SOLPerToken(context.Background(), tokens.JitoSOLMint)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if tc.isErr != nil && !errors.Is(err, tc.isErr) {
+				t.Errorf("Expected %v, got %v", tc.isErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package sanctum
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/fixtures"
+)
+
+// Default Sanctum client settings
+const (
+	DefaultAPIURL   = "https://extra-api.sanctum.so"
+	DefaultTimeout  = 10 * time.Second
+	DefaultCacheTTL = 5 * time.Minute // LST rates move once per epoch, so minutes-old rates are accurate
+)
+
+// Config holds Sanctum LST rate client configuration
+type Config struct {
+	// Enabled values LST vaults at Sanctum stake pool rates; disabled, TVL uses DexScreener market rates
+	Enabled bool
+
+	// APIURL is the Sanctum extra API base URL serving /v1/sol-value/current
+	APIURL string
+
+	// Timeout bounds each API request
+	Timeout time.Duration
+
+	// CacheTTL is how long a fetched rate is served before it is refreshed
+	CacheTTL time.Duration
+
+	// FixtureDir and FixtureRecord replay or record API responses (see internal/fixtures)
+	FixtureDir    string
+	FixtureRecord bool
+}
+
+// DefaultConfig returns the default Sanctum client configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:  true,
+		APIURL:   DefaultAPIURL,
+		Timeout:  DefaultTimeout,
+		CacheTTL: DefaultCacheTTL,
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("SANCTUM_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			config.Enabled = enabled
+		}
+	}
+
+	if url := os.Getenv("SANCTUM_API_URL"); url != "" {
+		config.APIURL = strings.TrimSpace(url)
+	}
+
+	if value := os.Getenv("SANCTUM_TIMEOUT_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.Timeout = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("SANCTUM_RATE_CACHE_TTL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			config.CacheTTL = time.Duration(seconds) * time.Second
+		}
+	}
+
+	config.FixtureDir = os.Getenv(fixtures.EnvFixtureDir)
+	if record, err := strconv.ParseBool(os.Getenv(fixtures.EnvFixtureRecord)); err == nil {
+		config.FixtureRecord = record
+	}
+
+	return config
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default RPC scheduling settings
+const (
+	DefaultRPS                = 25.0             // Global RPC request budget per second
+	DefaultBurst              = 50               // Requests that may be sent back to back after an idle period
+	DefaultInteractiveReserve = DefaultBurst / 5 // Burst tokens background jobs may not consume
+	DefaultLatencyThreshold   = 2 * time.Second  // Interactive RPC latency above which background work backs off
+	DefaultInitialBackoff     = 5 * time.Second  // First background pause once latency degrades; doubles while it stays degraded
+	DefaultMaxBackoff         = 2 * time.Minute  // Upper bound for a single background pause
+)
+
+// Config holds the RPC scheduler configuration
+type Config struct {
+	// RPS is the global request budget shared by interactive and background calls; 0 disables scheduling
+	RPS float64
+
+	// Burst is the token bucket capacity
+	Burst int
+
+	// InteractiveReserve is the number of bucket tokens kept for interactive calls
+	InteractiveReserve int
+
+	// LatencyThreshold is the smoothed interactive RPC latency that pauses background calls
+	LatencyThreshold time.Duration
+
+	// InitialBackoff is the first background pause after latency degrades
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the exponential background pause
+	MaxBackoff time.Duration
+}
+
+// DefaultConfig returns the default scheduler configuration
+func DefaultConfig() *Config {
+	return &Config{
+		RPS:                DefaultRPS,
+		Burst:              DefaultBurst,
+		InteractiveReserve: DefaultInteractiveReserve,
+		LatencyThreshold:   DefaultLatencyThreshold,
+		InitialBackoff:     DefaultInitialBackoff,
+		MaxBackoff:         DefaultMaxBackoff,
+	}
+}
+
+// Enabled reports whether RPC calls are rate limited
+func (c *Config) Enabled() bool {
+	return c != nil && c.RPS > 0
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("RPC_RPS_LIMIT"); value != "" {
+		if rps, err := strconv.ParseFloat(value, 64); err == nil && rps >= 0 {
+			config.RPS = rps
+		}
+	}
+
+	// The reserve scales with an overridden burst unless set explicitly
+	if value := os.Getenv("RPC_RPS_BURST"); value != "" {
+		if burst, err := strconv.Atoi(value); err == nil && burst > 0 {
+			config.Burst = burst
+			config.InteractiveReserve = burst / 5
+		}
+	}
+
+	if value := os.Getenv("RPC_INTERACTIVE_RESERVE"); value != "" {
+		if reserve, err := strconv.Atoi(value); err == nil && reserve >= 0 && reserve < config.Burst {
+			config.InteractiveReserve = reserve
+		}
+	}
+
+	if value := os.Getenv("RPC_BACKGROUND_LATENCY_THRESHOLD_MS"); value != "" {
+		if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+			config.LatencyThreshold = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	if value := os.Getenv("RPC_BACKGROUND_MAX_BACKOFF_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.MaxBackoff = time.Duration(seconds) * time.Second
+			if config.InitialBackoff > config.MaxBackoff {
+				config.InitialBackoff = config.MaxBackoff
+			}
+		}
+	}
+
+	return config
+}
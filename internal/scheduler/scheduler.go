@@ -0,0 +1,257 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// Priority orders RPC calls competing for the shared request budget
+type Priority int
+
+const (
+	// PriorityInteractive is the default for calls made while serving an API request
+	PriorityInteractive Priority = iota
+
+	// PriorityBackground marks indexer syncs, refreshers and snapshots
+	PriorityBackground
+)
+
+// String returns the metrics label for p
+func (p Priority) String() string {
+	if p == PriorityBackground {
+		return "background"
+	}
+	return "interactive"
+}
+
+// latencySmoothing is the weight of the newest sample in the interactive latency moving average
+const latencySmoothing = 0.2
+
+// Re-check delays for blocked background calls, so they notice queued interactive calls draining
+// and a pause lifted early by recovered latency
+const (
+	yieldInterval        = 50 * time.Millisecond
+	pauseRecheckInterval = time.Second
+)
+
+type priorityKey struct{}
+
+// WithPriority marks every RPC call made with ctx as priority p
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFrom returns the priority set on ctx, defaulting to interactive
+func PriorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// Scheduler enforces a global RPC request budget with a token bucket shared by all callers
+// Interactive calls are served first: background calls yield while interactive calls are queued,
+// never consume the last InteractiveReserve tokens, and pause with exponential backoff while
+// the smoothed interactive RPC latency stays above LatencyThreshold
+// A nil *Scheduler admits every call immediately
+type Scheduler struct {
+	config *Config
+	logger *logger.Logger
+	now    func() time.Time
+
+	mu                 sync.Mutex
+	tokens             float64
+	refilledAt         time.Time
+	interactiveWaiting int
+	latency            time.Duration // Moving average of interactive call latency
+	backoff            time.Duration // Current background pause; 0 when latency is healthy
+	pausedUntil        time.Time
+}
+
+// New creates a scheduler with a full bucket, or returns nil when config disables scheduling
+func New(config *Config) *Scheduler {
+	if !config.Enabled() {
+		return nil
+	}
+
+	s := &Scheduler{
+		config: config,
+		logger: logger.NewFromEnv().WithComponent("rpc-scheduler"),
+		now:    time.Now,
+		tokens: float64(config.Burst),
+	}
+	s.refilledAt = s.now()
+	return s
+}
+
+// Wait blocks until the call made with ctx may be sent, or returns ctx.Err() when ctx ends first
+func (s *Scheduler) Wait(ctx context.Context) error {
+	return s.WaitN(ctx, 1)
+}
+
+// WaitN is Wait for a request carrying n calls, such as a JSON-RPC batch, which takes n tokens at once
+// A request larger than the bucket takes the whole bucket, so it still goes out once the bucket is full
+func (s *Scheduler) WaitN(ctx context.Context, n int) error {
+	if s == nil {
+		return nil
+	}
+
+	priority := PriorityFrom(ctx)
+	start := s.now()
+
+	s.mu.Lock()
+	if priority == PriorityInteractive {
+		s.interactiveWaiting++
+	}
+	for {
+		delay := s.take(priority, n)
+		if delay == 0 {
+			break
+		}
+		s.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.mu.Lock()
+			if priority == PriorityInteractive {
+				s.interactiveWaiting--
+			}
+			s.mu.Unlock()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		s.mu.Lock()
+	}
+	if priority == PriorityInteractive {
+		s.interactiveWaiting--
+	}
+	s.mu.Unlock()
+
+	metrics.ObserveSchedulerWait(priority.String(), s.now().Sub(start))
+	return nil
+}
+
+// take consumes n tokens for priority and returns 0, or returns how long to wait before trying again
+// Callers must hold s.mu
+func (s *Scheduler) take(priority Priority, n int) time.Duration {
+	now := s.now()
+	s.refill(now)
+
+	reserve := 0
+	if priority == PriorityBackground {
+		if now.Before(s.pausedUntil) {
+			return min(s.pausedUntil.Sub(now), pauseRecheckInterval)
+		}
+		if s.interactiveWaiting > 0 {
+			return yieldInterval
+		}
+		reserve = s.config.InteractiveReserve
+	}
+
+	cost := float64(max(min(n, s.config.Burst-reserve), 1))
+	required := cost + float64(reserve)
+	if s.tokens >= required {
+		s.tokens -= cost
+		return 0
+	}
+	return time.Duration((required - s.tokens) / s.config.RPS * float64(time.Second))
+}
+
+func (s *Scheduler) refill(now time.Time) {
+	elapsed := now.Sub(s.refilledAt).Seconds()
+	s.refilledAt = now
+	if elapsed <= 0 {
+		return
+	}
+
+	s.tokens += elapsed * s.config.RPS
+	if burst := float64(s.config.Burst); s.tokens > burst {
+		s.tokens = burst
+	}
+}
+
+// Observe records the latency of a finished call made with ctx
+// Only interactive calls count: while their moving average exceeds LatencyThreshold background
+// calls pause for a backoff that doubles on every degraded sample after the previous pause ends;
+// the pause is lifted as soon as the average recovers
+func (s *Scheduler) Observe(ctx context.Context, latency time.Duration) {
+	if s == nil || PriorityFrom(ctx) != PriorityInteractive {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.latency == 0 {
+		s.latency = latency
+	} else {
+		s.latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(s.latency))
+	}
+
+	now := s.now()
+	if s.latency <= s.config.LatencyThreshold {
+		if s.backoff > 0 {
+			s.backoff = 0
+			s.pausedUntil = time.Time{}
+			metrics.SetSchedulerBackgroundBackoff(0)
+			s.logger.InfoContext(ctx, "RPC latency recovered, resuming background calls",
+				slog.Duration("latency", s.latency))
+		}
+		return
+	}
+	if now.Before(s.pausedUntil) {
+		return
+	}
+
+	if s.backoff == 0 {
+		s.backoff = s.config.InitialBackoff
+	} else {
+		s.backoff = min(s.backoff*2, s.config.MaxBackoff)
+	}
+	s.pausedUntil = now.Add(s.backoff)
+	metrics.SetSchedulerBackgroundBackoff(s.backoff)
+
+	s.logger.WarnContext(ctx, "RPC latency degraded, pausing background calls",
+		slog.Duration("latency", s.latency),
+		slog.Duration("threshold", s.config.LatencyThreshold),
+		slog.Duration("backoff", s.backoff))
+}
+
+// Budget is a point-in-time view of the shared RPC request budget
+type Budget struct {
+	RPS                float64 `json:"rps"`
+	Burst              int     `json:"burst"`
+	InteractiveReserve int     `json:"interactive_reserve"`
+	Available          int     `json:"available"`         // Whole tokens in the bucket now
+	BackgroundPaused   bool    `json:"background_paused"` // Background calls are backing off on degraded latency
+	RefillSeconds      float64 `json:"refill_seconds"`    // Seconds until the bucket is full again
+}
+
+// Budget returns the current state of the request budget; ok is false when scheduling is disabled
+func (s *Scheduler) Budget() (budget Budget, ok bool) {
+	if s == nil {
+		return Budget{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.refill(now)
+	return Budget{
+		RPS:                s.config.RPS,
+		Burst:              s.config.Burst,
+		InteractiveReserve: s.config.InteractiveReserve,
+		Available:          int(s.tokens),
+		BackgroundPaused:   now.Before(s.pausedUntil),
+		RefillSeconds:      (float64(s.config.Burst) - s.tokens) / s.config.RPS,
+	}, true
+}
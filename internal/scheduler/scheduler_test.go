@@ -0,0 +1,176 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced time source for bucket and backoff tests
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestScheduler(config *Config) (*Scheduler, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := New(config)
+	s.now = clock.Now
+	s.refilledAt = clock.now
+	return s, clock
+}
+
+func TestNewDisabled(t *testing.T) {
+	config := DefaultConfig()
+	config.RPS = 0
+	if s := New(config); s != nil {
+		t.Fatal("Expected nil scheduler when RPS is 0")
+	}
+
+	var s *Scheduler
+	if err := s.Wait(context.Background()); err != nil {
+		t.Errorf("Expected nil scheduler to admit calls, got %v", err)
+	}
+	s.Observe(context.Background(), time.Minute)
+	if _, ok := s.Budget(); ok {
+		t.Error("Expected no budget from a nil scheduler")
+	}
+}
+
+func TestTakeReservesBurstForInteractive(t *testing.T) {
+	s, clock := newTestScheduler(&Config{RPS: 10, Burst: 5, InteractiveReserve: 2, LatencyThreshold: time.Second})
+
+	for i := 0; i < 3; i++ {
+		if delay := s.take(PriorityBackground, 1); delay != 0 {
+			t.Fatalf("Expected background call %d to be admitted, got delay %v", i, delay)
+		}
+	}
+	if delay := s.take(PriorityBackground, 1); delay == 0 {
+		t.Fatal("Expected background calls to stop at the interactive reserve")
+	}
+	for i := 0; i < 2; i++ {
+		if delay := s.take(PriorityInteractive, 1); delay != 0 {
+			t.Fatalf("Expected interactive call %d to use the reserve, got delay %v", i, delay)
+		}
+	}
+	if delay := s.take(PriorityInteractive, 1); delay != 100*time.Millisecond {
+		t.Errorf("Expected empty bucket to wait one token (100ms), got %v", delay)
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if delay := s.take(PriorityBackground, 1); delay != 0 {
+		t.Errorf("Expected background call after refill, got delay %v", delay)
+	}
+}
+
+func TestTakeBatchCostsOneTokenPerCall(t *testing.T) {
+	s, clock := newTestScheduler(&Config{RPS: 10, Burst: 10, InteractiveReserve: 2, LatencyThreshold: time.Second})
+
+	if delay := s.take(PriorityInteractive, 6); delay != 0 || s.tokens != 4 {
+		t.Fatalf("Expected a batch of 6 to take 6 tokens, got delay %v with %v tokens left", delay, s.tokens)
+	}
+	if delay := s.take(PriorityInteractive, 6); delay != 200*time.Millisecond {
+		t.Errorf("Expected the next batch to wait for 2 tokens (200ms), got %v", delay)
+	}
+
+	// Batches larger than the bucket take all of it, less the interactive reserve for background calls
+	clock.now = clock.now.Add(time.Second)
+	if delay := s.take(PriorityBackground, 50); delay != 0 || s.tokens != 2 {
+		t.Errorf("Expected an oversized background batch to leave the reserve, got delay %v with %v tokens left", delay, s.tokens)
+	}
+}
+
+func TestTakeBackgroundYieldsToQueuedInteractive(t *testing.T) {
+	s, _ := newTestScheduler(&Config{RPS: 10, Burst: 5, LatencyThreshold: time.Second})
+
+	s.interactiveWaiting = 1
+	if delay := s.take(PriorityBackground, 1); delay != yieldInterval {
+		t.Errorf("Expected background call to yield, got delay %v", delay)
+	}
+	if delay := s.take(PriorityInteractive, 1); delay != 0 {
+		t.Errorf("Expected interactive call to be admitted, got delay %v", delay)
+	}
+}
+
+func TestObserveBacksOffBackground(t *testing.T) {
+	s, clock := newTestScheduler(&Config{
+		RPS: 100, Burst: 10, LatencyThreshold: time.Second,
+		InitialBackoff: 5 * time.Second, MaxBackoff: 8 * time.Second,
+	})
+	ctx := context.Background()
+	background := WithPriority(ctx, PriorityBackground)
+
+	s.Observe(background, time.Minute)
+	if !s.pausedUntil.IsZero() {
+		t.Fatal("Expected background latency to be ignored")
+	}
+
+	s.Observe(ctx, 3*time.Second)
+	if s.backoff != 5*time.Second {
+		t.Fatalf("Expected initial 5s backoff, got %v", s.backoff)
+	}
+	if delay := s.take(PriorityBackground, 1); delay != pauseRecheckInterval {
+		t.Errorf("Expected paused background call to re-check after %v, got %v", pauseRecheckInterval, delay)
+	}
+	if delay := s.take(PriorityInteractive, 1); delay != 0 {
+		t.Errorf("Expected interactive call during backoff, got delay %v", delay)
+	}
+
+	clock.now = clock.now.Add(5 * time.Second)
+	s.Observe(ctx, 3*time.Second)
+	if s.backoff != 8*time.Second {
+		t.Errorf("Expected backoff capped at 8s, got %v", s.backoff)
+	}
+
+	for i := 0; i < 20; i++ {
+		s.Observe(ctx, 10*time.Millisecond)
+	}
+	if s.backoff != 0 || !s.pausedUntil.IsZero() {
+		t.Errorf("Expected backoff lifted after latency recovered, got %v until %v", s.backoff, s.pausedUntil)
+	}
+	if delay := s.take(PriorityBackground, 1); delay != 0 {
+		t.Errorf("Expected background call after recovery, got delay %v", delay)
+	}
+}
+
+func TestWaitHonorsContext(t *testing.T) {
+	s := New(&Config{RPS: 1, Burst: 1, LatencyThreshold: time.Second})
+
+	if err := s.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected first call to be admitted, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded while waiting for a token, got %v", err)
+	}
+	if s.interactiveWaiting != 0 {
+		t.Errorf("Expected waiting count released, got %d", s.interactiveWaiting)
+	}
+}
+
+func TestPriorityFrom(t *testing.T) {
+	if p := PriorityFrom(context.Background()); p != PriorityInteractive {
+		t.Errorf("Expected interactive default, got %v", p)
+	}
+	if p := PriorityFrom(WithPriority(context.Background(), PriorityBackground)); p != PriorityBackground {
+		t.Errorf("Expected background, got %v", p)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	s, clock := newTestScheduler(&Config{RPS: 10, Burst: 5, InteractiveReserve: 2, LatencyThreshold: time.Second})
+	for i := 0; i < 4; i++ {
+		s.take(PriorityInteractive, 1)
+	}
+
+	budget, ok := s.Budget()
+	if !ok || budget.Available != 1 || budget.Burst != 5 || budget.RefillSeconds != 0.4 {
+		t.Errorf("Expected 1 of 5 tokens left, refilled in 0.4s, got %+v", budget)
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if budget, _ := s.Budget(); budget.Available != 5 || budget.RefillSeconds != 0 {
+		t.Errorf("Expected a full bucket after a second, got %+v", budget)
+	}
+}
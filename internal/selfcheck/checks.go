@@ -0,0 +1,99 @@
+package selfcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/config"
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Run loads configuration and checks every external dependency the API needs to start
+// The network checks are skipped when configuration is invalid since no client can be built
+func Run(ctx context.Context) *Report {
+	report := NewReport()
+
+	start := time.Now()
+	cfg, err := config.Load()
+	if err != nil {
+		report.Add("configuration", "", err, time.Since(start))
+		return report
+	}
+	report.Add("configuration", fmt.Sprintf("network %s, chain %s", cfg.Network, cfg.Solana.Chain), nil, time.Since(start))
+
+	client, err := solana.NewHTTPClient(cfg.Solana, logger.NewFromEnv().WithComponent("selfcheck"))
+	if err != nil {
+		report.Add("rpc", "", fmt.Errorf("failed to create RPC client: %w", err), 0)
+		return report
+	}
+	defer client.Close()
+
+	priceService := hylo.NewPriceService(client, cfg.Hylo, cfg.Price)
+	defer priceService.Close()
+
+	report.Run(ctx, DefaultTimeout, DependencyChecks(client, cfg.Hylo, priceService.GetStateReader(), priceService.GetDexScreenerClient())...)
+	return report
+}
+
+// DependencyChecks returns the RPC, Hylo program, token mint and SOL/USD price checks in the order they run
+func DependencyChecks(client solana.ChainClient, hyloConfig *hylo.Config, stateReader *hylo.StateReader, dexScreener *price.DexScreenerClient) []Check {
+	return []Check{
+		{Name: "rpc", Run: func(ctx context.Context) (string, error) {
+			if err := client.Health(ctx); err != nil {
+				return "", err
+			}
+			slot, err := client.GetSlot(ctx, solana.CommitmentConfirmed)
+			if err != nil {
+				return "", fmt.Errorf("failed to read slot: %w", err)
+			}
+			return fmt.Sprintf("%s healthy at slot %d", client.Chain(), slot), nil
+		}},
+		{Name: "hylo_programs", Run: func(ctx context.Context) (string, error) {
+			if err := checkProgram(ctx, client, "exchange", hyloConfig.ExchangeProgramID); err != nil {
+				return "", err
+			}
+			if err := checkProgram(ctx, client, "stability pool", hyloConfig.StabilityPoolProgramID); err != nil {
+				return "", err
+			}
+			return "exchange and stability pool programs deployed", nil
+		}},
+		{Name: "token_mints", Run: func(ctx context.Context) (string, error) {
+			if err := stateReader.ValidateAllHyloTokenMints(ctx); err != nil {
+				return "", err
+			}
+			symbols := make([]string, 0, len(hylo.GetAllTokenMints()))
+			for _, mint := range hylo.GetAllTokenMints() {
+				symbols = append(symbols, tokens.GetTokenSymbol(mint))
+			}
+			return strings.Join(symbols, ", ") + " initialized", nil
+		}},
+		{Name: "sol_price", Run: func(ctx context.Context) (string, error) {
+			solPrice, err := dexScreener.FetchSOLPrice(ctx)
+			if err != nil {
+				return "", err
+			}
+			if solPrice.Price <= 0 {
+				return "", fmt.Errorf("non-positive SOL/USD price %v from %s", solPrice.Price, solPrice.Source)
+			}
+			return fmt.Sprintf("$%.2f from %s", solPrice.Price, solPrice.Source), nil
+		}},
+	}
+}
+
+// checkProgram verifies a program account exists on chain and is executable
+func checkProgram(ctx context.Context, client solana.ChainClient, name string, address solana.Address) error {
+	account, err := client.GetAccount(ctx, address, solana.CommitmentConfirmed)
+	if err != nil {
+		return fmt.Errorf("%s program %s: %w", name, address, err)
+	}
+	if !account.Executable {
+		return fmt.Errorf("%s program %s is not an executable account", name, address)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package selfcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultTimeout bounds each check so an unreachable dependency fails the report instead of hanging a deploy
+const DefaultTimeout = 15 * time.Second
+
+// Check is one named startup dependency check; Run returns a short detail on success
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) (string, error)
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Name       string `json:"name"`
+	OK         bool   `json:"ok"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report collects check results; OK is false when any check failed
+type Report struct {
+	OK        bool      `json:"ok"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    []Result  `json:"checks"`
+}
+
+// NewReport returns an empty passing report
+func NewReport() *Report {
+	return &Report{OK: true, CheckedAt: time.Now().UTC(), Checks: []Result{}}
+}
+
+// Run executes checks in order with timeout applied to each one; every check runs even after a failure
+func (r *Report) Run(ctx context.Context, timeout time.Duration, checks ...Check) {
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		detail, err := check.Run(checkCtx)
+		cancel()
+		r.Add(check.Name, detail, err, time.Since(start))
+	}
+}
+
+// Add records the result of a check that was run outside Run
+func (r *Report) Add(name, detail string, err error, elapsed time.Duration) {
+	result := Result{Name: name, OK: err == nil, Detail: detail, DurationMS: elapsed.Milliseconds()}
+	if err != nil {
+		result.Error = err.Error()
+		r.OK = false
+	}
+	r.Checks = append(r.Checks, result)
+}
+
+// ExitCode returns 0 when every check passed and 1 otherwise
+func (r *Report) ExitCode() int {
+	if r.OK {
+		return 0
+	}
+	return 1
+}
+
+// WriteText writes one line per check followed by an overall verdict
+func (r *Report) WriteText(w io.Writer) error {
+	for _, result := range r.Checks {
+		status, message := "✅", result.Detail
+		if !result.OK {
+			status, message = "❌", result.Error
+		}
+		if _, err := fmt.Fprintf(w, "%s %-14s %6dms  %s\n", status, result.Name, result.DurationMS, message); err != nil {
+			return err
+		}
+	}
+
+	verdict := "passed"
+	if !r.OK {
+		verdict = "FAILED"
+	}
+	_, err := fmt.Fprintf(w, "Self-check %s (%d checks)\n", verdict, len(r.Checks))
+	return err
+}
+
+// WriteJSON writes the report as a single JSON document
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
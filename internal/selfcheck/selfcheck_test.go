@@ -0,0 +1,70 @@
+package selfcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportRun(t *testing.T) {
+	report := NewReport()
+	report.Run(context.Background(), 20*time.Millisecond,
+		Check{Name: "ok", Run: func(ctx context.Context) (string, error) { return "fine", nil }},
+		Check{Name: "broken", Run: func(ctx context.Context) (string, error) { return "", errors.New("boom") }},
+		Check{Name: "hangs", Run: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+	)
+
+	if report.OK || report.ExitCode() != 1 {
+		t.Errorf("Expected a failed report with exit code 1, got OK=%v", report.OK)
+	}
+	if len(report.Checks) != 3 {
+		t.Fatalf("Expected every check to run after a failure, got %d results", len(report.Checks))
+	}
+	if !report.Checks[0].OK || report.Checks[0].Detail != "fine" {
+		t.Errorf("Unexpected first result %+v", report.Checks[0])
+	}
+	if report.Checks[1].OK || report.Checks[1].Error != "boom" {
+		t.Errorf("Unexpected second result %+v", report.Checks[1])
+	}
+	if !strings.Contains(report.Checks[2].Error, "deadline exceeded") {
+		t.Errorf("Expected the hanging check to time out, got %+v", report.Checks[2])
+	}
+}
+
+func TestReportOutput(t *testing.T) {
+	report := NewReport()
+	if report.ExitCode() != 0 {
+		t.Errorf("Expected an empty report to pass")
+	}
+	report.Add("configuration", "network mainnet-beta", nil, time.Millisecond)
+	report.Add("rpc", "", errors.New("connection refused"), 2*time.Second)
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"✅ configuration", "❌ rpc", "2000ms", "connection refused", "Self-check FAILED (2 checks)"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected text report to contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON report: %v", err)
+	}
+	if decoded.OK || len(decoded.Checks) != 2 || decoded.Checks[1].Error != "connection refused" {
+		t.Errorf("Unexpected JSON report %+v", decoded)
+	}
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+
+	"hylo-wallet-tracker-api/internal/config"
+)
+
+// Content codings offered by the compression middleware
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// brotliLevel trades a little ratio for speed on dynamically generated JSON
+const brotliLevel = 5
+
+// Encoders are pooled; their internal state is far larger than a typical response
+var (
+	gzipWriters   = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+	brotliWriters = sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(io.Discard, brotliLevel) }}
+)
+
+// encoder is the subset of *gzip.Writer and *brotli.Writer the middleware relies on
+type encoder interface {
+	io.Writer
+	Flush() error
+	Close() error
+}
+
+// compress negotiates br or gzip via Accept-Encoding and compresses response bodies of at least MinBytes
+// Excluded content types, responses that already carry a Content-Encoding and bodiless statuses pass through
+func (s *Server) compress(settings *config.Compression) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, encoding: encoding, settings: settings}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding picks the highest-weighted supported coding, preferring br on ties; empty means identity
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		switch coding {
+		case "*":
+			coding = encodingBrotli
+		case encodingBrotli, encodingGzip:
+		default:
+			continue
+		}
+
+		if q > bestQ || (q == bestQ && q > 0 && coding == encodingBrotli) {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressWriter buffers the start of a response until it knows whether compression is worthwhile
+// Bodies reaching MinBytes are compressed; shorter ones are written unchanged when the handler returns
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	settings *config.Compression
+
+	status  int
+	buf     []byte
+	encoder encoder // Set once compression has started
+	decided bool    // Headers have been sent, compressed or not
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status != 0 {
+		return
+	}
+	cw.status = status
+	if !cw.compressible() {
+		cw.passthrough()
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.decided {
+		if cw.encoder != nil {
+			return cw.encoder.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) >= cw.settings.MinBytes {
+		if err := cw.startEncoding(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush commits the response so far; a response flushed before compression started stays uncompressed
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		cw.passthrough()
+	}
+	if cw.encoder != nil {
+		cw.encoder.Flush()
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (e.g. SetWriteDeadline)
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// compressible checks the status and headers known when the handler commits its status
+func (cw *compressWriter) compressible() bool {
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" || cw.excluded(header.Get("Content-Type")) {
+		return false
+	}
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil && length < cw.settings.MinBytes {
+		return false
+	}
+	return true
+}
+
+func (cw *compressWriter) excluded(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	for _, excluded := range cw.settings.ExcludedTypes {
+		if mediaType == excluded {
+			return true
+		}
+	}
+	return false
+}
+
+func (cw *compressWriter) startEncoding() error {
+	header := cw.Header()
+	if header.Get("Content-Type") == "" {
+		// Sniff before encoding; net/http would otherwise sniff the compressed bytes
+		header.Set("Content-Type", http.DetectContentType(cw.buf))
+		if cw.excluded(header.Get("Content-Type")) {
+			return cw.passthrough()
+		}
+	}
+
+	header.Set("Content-Encoding", cw.encoding)
+	header.Del("Content-Length")
+	cw.decided = true
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	cw.encoder = acquireEncoder(cw.encoding, cw.ResponseWriter)
+	buf := cw.buf
+	cw.buf = nil
+	_, err := cw.encoder.Write(buf)
+	return err
+}
+
+func (cw *compressWriter) passthrough() error {
+	cw.decided = true
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	if len(cw.buf) == 0 {
+		return nil
+	}
+	buf := cw.buf
+	cw.buf = nil
+	_, err := cw.ResponseWriter.Write(buf)
+	return err
+}
+
+// close finishes the compressed stream or writes out a body that stayed below MinBytes
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if cw.status == 0 {
+			return // Handler wrote nothing; net/http sends its default response
+		}
+		cw.passthrough()
+		return
+	}
+
+	if cw.encoder != nil {
+		cw.encoder.Close()
+		releaseEncoder(cw.encoder)
+		cw.encoder = nil
+	}
+}
+
+func acquireEncoder(encoding string, w io.Writer) encoder {
+	if encoding == encodingBrotli {
+		bw := brotliWriters.Get().(*brotli.Writer)
+		bw.Reset(w)
+		return bw
+	}
+	gw := gzipWriters.Get().(*gzip.Writer)
+	gw.Reset(w)
+	return gw
+}
+
+func releaseEncoder(e encoder) {
+	switch e := e.(type) {
+	case *brotli.Writer:
+		e.Reset(io.Discard)
+		brotliWriters.Put(e)
+	case *gzip.Writer:
+		e.Reset(io.Discard)
+		gzipWriters.Put(e)
+	}
+}
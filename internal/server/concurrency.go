@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/config"
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// limitConcurrency serves at most MaxInFlight requests at once; further requests wait in a queue of
+// QueueDepth for up to QueueTimeout and are shed with 503 and Retry-After when it is full or they time out
+// Shedding early keeps traffic spikes from piling up RPC calls against the upstream providers
+func (s *Server) limitConcurrency(settings *config.Concurrency) func(http.Handler) http.Handler {
+	slots := make(chan struct{}, settings.MaxInFlight)
+	var queued atomic.Int64
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				if queued.Add(1) > int64(settings.QueueDepth) {
+					queued.Add(-1)
+					s.shed(w, r, settings, metrics.HTTPShedQueueFull,
+						fmt.Sprintf("%d requests in flight and %d queued", settings.MaxInFlight, settings.QueueDepth))
+					return
+				}
+				metrics.AddHTTPQueued(1)
+
+				timer := time.NewTimer(settings.QueueTimeout)
+				select {
+				case slots <- struct{}{}:
+					timer.Stop()
+				case <-timer.C:
+					queued.Add(-1)
+					metrics.AddHTTPQueued(-1)
+					s.shed(w, r, settings, metrics.HTTPShedQueueTimeout,
+						fmt.Sprintf("no request slot freed up within %s", settings.QueueTimeout))
+					return
+				case <-r.Context().Done():
+					timer.Stop()
+					queued.Add(-1)
+					metrics.AddHTTPQueued(-1)
+					return
+				}
+				queued.Add(-1)
+				metrics.AddHTTPQueued(-1)
+			}
+
+			metrics.AddHTTPInFlight(1)
+			defer func() {
+				<-slots
+				metrics.AddHTTPInFlight(-1)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// streamRetryAfter is the Retry-After hint when every stream slot is busy; streams run for minutes
+const streamRetryAfter = 30 * time.Second
+
+// limitStreams caps concurrent long-lived streams of kind at limit, rejecting the rest at once with 503 and
+// counting them as shed for reason; 0 disables the cap. Streams are exempt from the in-flight limiter, so this
+// keeps a burst of history walks or subscriptions from exhausting the RPC budget
+func (s *Server) limitStreams(limit int, kind, reason string) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	slots := make(chan struct{}, limit)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				metrics.RecordHTTPShed(reason)
+				s.logger.WarnContext(r.Context(), "Stream rejected: stream slots full",
+					slog.String("path", r.URL.Path),
+					slog.Int("max_streams", limit))
+				s.writeOverloadedError(w, streamRetryAfter, fmt.Sprintf("%d %s streams already running", limit, kind))
+				return
+			}
+			defer func() { <-slots }()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// shed rejects a request the limiter cannot admit, asking the client to retry once the queue could have drained
+func (s *Server) shed(w http.ResponseWriter, r *http.Request, settings *config.Concurrency, reason, details string) {
+	metrics.RecordHTTPShed(reason)
+	s.logger.WarnContext(r.Context(), "Request shed: server saturated",
+		slog.String("path", r.URL.Path),
+		slog.String("reason", reason))
+	s.writeOverloadedError(w, settings.QueueTimeout, details)
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"hylo-wallet-tracker-api/internal/casing"
+)
+
+// Response media types offered through content negotiation
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeMsgpack = "application/msgpack"
+)
+
+// msgpackMediaTypes are the Accept values selecting MessagePack; there is no single registered type
+var msgpackMediaTypes = []string{contentTypeMsgpack, "application/x-msgpack", "application/vnd.msgpack"}
+
+func init() {
+	// Projected (fields=...) responses hold json.RawMessage values, encoded as the JSON they contain
+	msgpack.Register(json.RawMessage(nil), func(enc *msgpack.Encoder, v reflect.Value) error {
+		return encodeRawJSON(enc, v.Bytes())
+	}, nil)
+	// Responses rendered under a converted field naming are ordered objects
+	msgpack.Register(casing.Object(nil), func(enc *msgpack.Encoder, v reflect.Value) error {
+		return encodeObject(enc, v.Interface().(casing.Object))
+	}, nil)
+}
+
+// responseFormat encodes response bodies in one media type
+type responseFormat interface {
+	ContentType() string
+	Encode(w io.Writer, v interface{}) error
+}
+
+// jsonFormat is the default response format
+type jsonFormat struct{}
+
+func (jsonFormat) ContentType() string { return contentTypeJSON }
+
+func (jsonFormat) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// msgpackFormat encodes MessagePack with the same field names and omitempty rules as the JSON responses
+// Timestamps use the MessagePack timestamp extension instead of RFC 3339 strings
+type msgpackFormat struct{}
+
+func (msgpackFormat) ContentType() string { return contentTypeMsgpack }
+
+func (msgpackFormat) Encode(w io.Writer, v interface{}) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	return enc.Encode(v)
+}
+
+// negotiateFormat selects the response format for every route from the Accept header
+// JSON is used unless MessagePack is weighted strictly higher, so clients sending no Accept header,
+// */* or only unsupported types keep receiving JSON
+// The Accept-Profile header likewise selects the field naming and envelope over the configured defaults,
+// echoed back in Content-Profile whenever the body differs from the declared, bare shape
+func (s *Server) negotiateFormat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+		w.Header().Add("Vary", "Accept-Profile")
+
+		format := acceptedFormat(r.Header.Get("Accept"))
+		if profile := acceptedProfile(r.Header.Get("Accept-Profile"), s.profile); !profile.isZero() {
+			w.Header().Set("Content-Profile", profile.String())
+			format = profiledFormat{responseFormat: format, profile: profile}
+		}
+		if _, ok := format.(jsonFormat); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&formatWriter{ResponseWriter: w, format: format}, r)
+	})
+}
+
+// acceptedFormat picks the highest-weighted supported format, preferring JSON on ties
+func acceptedFormat(accept string) responseFormat {
+	jsonQ, msgpackQ := 0.0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		switch {
+		case mediaType == contentTypeJSON || mediaType == "application/*" || mediaType == "*/*":
+			jsonQ = max(jsonQ, q)
+		case isMsgpackMediaType(mediaType):
+			msgpackQ = max(msgpackQ, q)
+		}
+	}
+
+	if msgpackQ > jsonQ {
+		return msgpackFormat{}
+	}
+	return jsonFormat{}
+}
+
+func isMsgpackMediaType(mediaType string) bool {
+	for _, candidate := range msgpackMediaTypes {
+		if mediaType == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeObject writes a rendered object as a MessagePack map in field order
+func encodeObject(enc *msgpack.Encoder, object casing.Object) error {
+	if object == nil {
+		return enc.EncodeNil()
+	}
+	if err := enc.EncodeMapLen(len(object)); err != nil {
+		return err
+	}
+	for _, field := range object {
+		if err := enc.EncodeString(field.Key); err != nil {
+			return err
+		}
+		if err := enc.Encode(field.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatWriter carries the negotiated format down to the response helpers
+type formatWriter struct {
+	http.ResponseWriter
+	format responseFormat
+}
+
+func (fw *formatWriter) negotiatedFormat() responseFormat {
+	return fw.format
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (e.g. Flush for SSE)
+func (fw *formatWriter) Unwrap() http.ResponseWriter {
+	return fw.ResponseWriter
+}
+
+// formatOf returns the format negotiated for w, looking through wrapping writers; JSON when none was
+func formatOf(w http.ResponseWriter) responseFormat {
+	for w != nil {
+		if negotiated, ok := w.(interface{ negotiatedFormat() responseFormat }); ok {
+			return negotiated.negotiatedFormat()
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = unwrapper.Unwrap()
+	}
+	return jsonFormat{}
+}
+
+// encodeRawJSON writes a JSON document as the equivalent MessagePack value
+// Integers stay integers; other numbers become float64
+func encodeRawJSON(enc *msgpack.Encoder, raw []byte) error {
+	if len(raw) == 0 {
+		return enc.EncodeNil()
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	return enc.Encode(fromJSONNumbers(value))
+}
+
+// fromJSONNumbers replaces json.Number values with int64, uint64 or float64
+func fromJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = fromJSONNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = fromJSONNumbers(item)
+		}
+	}
+	return value
+}
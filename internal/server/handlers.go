@@ -1,22 +1,47 @@
 package server
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"runtime"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"hylo-wallet-tracker-api/internal/audit"
+	"hylo-wallet-tracker-api/internal/digest"
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/fieldset"
+	"hylo-wallet-tracker-api/internal/fx"
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/indexer"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+	_ "hylo-wallet-tracker-api/internal/peg"   // Required for swagger type generation
 	_ "hylo-wallet-tracker-api/internal/price" // Required for swagger type generation
 	"hylo-wallet-tracker-api/internal/solana"
-	_ "hylo-wallet-tracker-api/internal/tokens" // Required for swagger type generation
-	_ "hylo-wallet-tracker-api/internal/trades" // Required for swagger type generation
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/stream"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/trades"
+	"hylo-wallet-tracker-api/internal/watchlist"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// Audit log query limits
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
 )
 
 // handleHealth returns basic liveness status
 // @Summary Health check endpoint
-// @Description Check the health and connectivity of the service and Solana RPC
+// @Description Check the health and connectivity of the service and Solana RPC. Also lists the latest check of each token's configured decimals against its on-chain mint (TOKEN_DECIMALS_CHECK_INTERVAL_SEC); while any token mismatches, its amounts are withheld from balances and the status is degraded. The parser's error and low-confidence rates over the last hour are reported too; the status is degraded while either is above its threshold (HYLO_PARSE_ERROR_RATE_THRESHOLD, HYLO_PARSE_LOW_CONFIDENCE_RATE_THRESHOLD), which usually means an upstream format changed
 // @Tags health
 // @Produce json
 // @Success 200 {object} server.HealthResponse "Service is healthy"
@@ -27,8 +52,23 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 
 	response := HealthResponse{
 		Status:    "ok",
+		Chain:     s.chain,
 		Solana:    status,
 		Timestamp: getCurrentTimestamp(),
+
+		TokenDecimals: s.tokenService.DecimalsStatuses(),
+	}
+	for _, decimals := range response.TokenDecimals {
+		if decimals.Mismatch {
+			response.Status = "degraded"
+		}
+	}
+	if s.parseMonitor != nil {
+		parser := s.parseMonitor.Status()
+		response.Parser = &parser
+		if parser.Anomalous {
+			response.Status = "degraded"
+		}
 	}
 
 	statusCode := http.StatusOK
@@ -42,31 +82,73 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	s.writeJSONSuccessWithCode(w, statusCode, response)
 }
 
+// handleReady reports readiness for traffic
+// @Summary Readiness check endpoint
+// @Description Returns 503 while the optional startup warm-up (WARMUP_ENABLED) preloads balance and trade caches for tracked wallets, and 200 once it finished or ran out of its time budget. Without a warm-up the service is ready as soon as it listens. Point load balancer readiness probes here and liveness probes at /health.
+// @Tags health
+// @Produce json
+// @Success 200 {object} server.ReadinessResponse "Ready for traffic"
+// @Success 503 {object} server.ReadinessResponse "Still warming caches"
+// @Router /ready [get]
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	response := ReadinessResponse{
+		Status:    "ready",
+		Warmup:    s.warmup.Load(),
+		Timestamp: getCurrentTimestamp(),
+	}
+
+	statusCode := http.StatusOK
+	if !s.ready.Load() {
+		response.Status = "warming"
+		statusCode = http.StatusServiceUnavailable
+	}
+	s.writeJSONSuccessWithCode(w, statusCode, response)
+}
+
 // handleWalletBalances returns token balances for a specific wallet
 // @Summary Get wallet token balances
-// @Description Fetch balances for hyUSD, sHYUSD, and xSOL tokens for a specific wallet address
+// @Description Fetch balances for hyUSD, sHYUSD, and xSOL tokens for a specific wallet address. With slot or at, balances are instead reconstructed at that past point from stored snapshots and the trade index (no archival RPC): the newest snapshot at or before the point is rolled forward by the indexed trades after it, or the latest snapshot rolled back when the point predates every snapshot. Reconstructed balances carry a reconstructed object, are valued at the base snapshot's prices, do not reflect transfers the trade index does not cover, and require the wallet to be tracked by the caller's tenant: the X-API-Key's tenant, or the operator's with the admin bearer token, when tenants are configured
 // @Tags wallet
 // @Param address path string true "Wallet address (base58 encoded)"
+// @Param slot query int false "Reconstruct balances at this slot"
+// @Param at query string false "Reconstruct balances at this RFC 3339 timestamp"
+// @Param fields query string false "Comma-separated token balance fields to return, e.g. formatted_amount,usd_value"
+// @Param currency query string false "ISO 4217 currency to convert USD values to, e.g. EUR; the applied rate is returned under currency"
+// @Param explorer query string false "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer"
 // @Produce json
 // @Success 200 {object} tokens.WalletBalances "Wallet token balances"
 // @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Historical query without a valid tenant API key or admin token when tenants are configured"
+// @Failure 404 {object} server.ErrorResponse "Historical query for a wallet the caller's tenant does not track or without a snapshot"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
 // @Failure 500 {object} server.ErrorResponse "Internal server error"
 // @Failure 502 {object} server.ErrorResponse "Network connectivity error"
 // @Router /wallet/{address}/balances [get]
 func (s *Server) handleWalletBalances(w http.ResponseWriter, r *http.Request) {
-	// Extract wallet address from URL path
-	addressStr := chi.URLParam(r, "address")
-	if addressStr == "" {
-		s.logger.LogValidationError(r.Context(), "get_wallet_balances", "address", "", fmt.Errorf("address parameter missing from URL path"))
-		s.writeValidationError(w, "Wallet address is required", "Address parameter missing from URL path")
+	wallet := walletAddress(r)
+
+	fields, ok := s.parseFields(w, r, (*tokens.WalletBalances)(nil), "balances")
+	if !ok {
 		return
 	}
 
-	// Parse and validate wallet address
-	wallet := solana.Address(addressStr)
-	if err := wallet.Validate(); err != nil {
-		s.logger.LogValidationError(r.Context(), "get_wallet_balances", "address", addressStr, err)
-		s.writeValidationError(w, "Invalid wallet address format", err.Error())
+	at, historical, ok := s.parseBalancePoint(w, r)
+	if !ok {
+		return
+	}
+	conversion, ok := s.parseCurrency(w, r)
+	if !ok {
+		return
+	}
+	provider, ok := s.parseExplorer(w, r)
+	if !ok {
+		return
+	}
+	if historical {
+		// Reconstruction reads the tenant's tracked wallet data, so it is scoped like the other store-backed routes
+		s.trackedWalletAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s.writeHistoricalBalances(w, r, wallet, at, conversion, provider, fields)
+		})).ServeHTTP(w, r)
 		return
 	}
 
@@ -91,37 +173,103 @@ func (s *Server) handleWalletBalances(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if conversion != nil {
+		balances = balances.InCurrency(conversion)
+	}
+	balances = balances.WithExplorer(provider)
+
 	// Return direct WalletBalances JSON response (maintains backward compatibility)
-	s.writeJSONSuccess(w, balances)
+	s.writeJSONFields(w, r, balances, "balances", fields)
+}
+
+// parseBalancePoint reads the slot or at parameter of a historical balance query
+// historical is false when neither is set; ok is false when a validation error was written
+func (s *Server) parseBalancePoint(w http.ResponseWriter, r *http.Request) (at watchlist.Since, historical bool, ok bool) {
+	slotStr, atStr := r.URL.Query().Get("slot"), r.URL.Query().Get("at")
+	switch {
+	case slotStr != "" && atStr != "":
+		s.writeValidationError(w, "Conflicting parameters", "slot and at cannot be combined")
+		return at, false, false
+	case slotStr != "":
+		slot, err := strconv.ParseUint(slotStr, 10, 64)
+		if err != nil || slot == 0 {
+			s.writeValidationError(w, "Invalid slot parameter", "slot must be a positive integer")
+			return at, false, false
+		}
+		return watchlist.Since{Slot: slot}, true, true
+	case atStr != "":
+		parsed, err := time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			s.writeValidationError(w, "Invalid at parameter", "at must be an RFC 3339 timestamp")
+			return at, false, false
+		}
+		return watchlist.Since{Time: parsed.UTC()}, true, true
+	}
+	return at, false, true
+}
+
+// writeHistoricalBalances serves balances reconstructed at a past point from stored snapshots and the trade index
+func (s *Server) writeHistoricalBalances(w http.ResponseWriter, r *http.Request, wallet solana.Address, at watchlist.Since, conversion *fx.Conversion, provider explorer.Provider, fields fieldset.Set) {
+	if !s.requireTrackedWallet(w, r, wallet) {
+		return
+	}
+
+	balances, err := watchlist.ReconstructBalances(s.store, s.tokenService.GetConfig(), wallet, at)
+	if errors.Is(err, watchlist.ErrNoSnapshot) {
+		s.writeNotFoundError(w, "Balance snapshot")
+		return
+	}
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "get_historical_balances", err)
+		s.writeInternalError(w, "Failed to reconstruct balances")
+		return
+	}
+	balances.Chain = s.chain
+	s.tokenService.AttachIcons(balances)
+	if conversion != nil {
+		balances = balances.InCurrency(conversion)
+	}
+	balances = balances.WithExplorer(provider)
+
+	s.writeJSONFields(w, r, balances, "balances", fields)
 }
 
 // handleWalletTrades returns xSOL trade history for a specific wallet
 // @Summary Get wallet xSOL trade history
-// @Description Fetch paginated xSOL trade history for a specific wallet address with real-time RPC data
+// @Description Fetch paginated xSOL trade history for a specific wallet address with real-time RPC data. Each trade carries protocolState, the collateral ratio and effective leverage from the protocol state snapshot recorded nearest its slot, when one lies within about an hour of it
 // @Tags wallet
 // @Param address path string true "Wallet address (base58 encoded)"
 // @Param limit query int false "Maximum number of trades to return (1-50, default 10)"
 // @Param before query string false "Cursor for pagination - signature to fetch trades before"
+// @Param until query string false "Stop at this signature (exclusive) - e.g. newest signature from a previous sync"
+// @Param min_slot query int false "Only include trades at or after this slot"
+// @Param max_slot query int false "Only include trades at or before this slot"
+// @Param include_failed query bool false "Include reverted Hylo transactions with their failure reason (default false)"
+// @Param min_confidence query number false "Only include trades with at least this classification confidence (0-1)"
+// @Param needs_review query bool false "Only include low-confidence trades flagged for manual review (default false)"
+// @Param include_dust query bool false "Include trades below the configured minimum trade size (HYLO_DUST_MIN_XSOL_RAW, HYLO_DUST_MIN_USD); default false, with the dust left out counted under dust"
+// @Param side query string false "Only include trades on this side" Enums(BUY, SELL, RECEIVE)
+// @Param counter_asset query string false "Only include trades against this counter asset" Enums(hyUSD, SOL, USDC)
+// @Param min_amount query string false "Only include trades of at least this xSOL amount (decimal)"
+// @Param max_amount query string false "Only include trades of at most this xSOL amount (decimal)"
+// @Param fields query string false "Comma-separated trade fields to return, e.g. signature,side,xsol_amount"
+// @Param explorer query string false "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer"
 // @Produce json
 // @Success 200 {object} trades.TradeResponse "Wallet xSOL trade history"
 // @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
 // @Failure 500 {object} server.ErrorResponse "Internal server error"
 // @Failure 502 {object} server.ErrorResponse "Network connectivity error"
 // @Router /wallet/{address}/trades [get]
 func (s *Server) handleWalletTrades(w http.ResponseWriter, r *http.Request) {
-	// Extract wallet address from URL path
-	addressStr := chi.URLParam(r, "address")
-	if addressStr == "" {
-		s.logger.LogValidationError(r.Context(), "get_wallet_trades", "address", "", fmt.Errorf("address parameter missing from URL path"))
-		s.writeValidationError(w, "Wallet address is required", "Address parameter missing from URL path")
+	wallet := walletAddress(r)
+
+	fields, ok := s.parseFields(w, r, (*trades.TradeResponse)(nil), "trades")
+	if !ok {
 		return
 	}
-
-	// Parse and validate wallet address
-	wallet := solana.Address(addressStr)
-	if err := wallet.Validate(); err != nil {
-		s.logger.LogValidationError(r.Context(), "get_wallet_trades", "address", addressStr, err)
-		s.writeValidationError(w, "Invalid wallet address format", err.Error())
+	provider, ok := s.parseExplorer(w, r)
+	if !ok {
 		return
 	}
 
@@ -144,11 +292,86 @@ func (s *Server) handleWalletTrades(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Extract before cursor for pagination (optional)
+	// Extract before/until cursors for pagination and incremental sync (optional)
 	before := r.URL.Query().Get("before")
+	until := r.URL.Query().Get("until")
+
+	var slotRange [2]uint64
+	for i, param := range []string{"min_slot", "max_slot"} {
+		if slotStr := r.URL.Query().Get(param); slotStr != "" {
+			parsed, err := strconv.ParseUint(slotStr, 10, 64)
+			if err != nil {
+				s.logger.LogParsingError(r.Context(), "get_wallet_trades", param+"_parameter", err, slog.String("invalid_value", slotStr))
+				s.writeValidationError(w, "Invalid "+param+" parameter", param+" must be a non-negative integer")
+				return
+			}
+			slotRange[i] = parsed
+		}
+	}
+
+	includeFailed := false
+	if includeFailedStr := r.URL.Query().Get("include_failed"); includeFailedStr != "" {
+		parsed, err := strconv.ParseBool(includeFailedStr)
+		if err != nil {
+			s.logger.LogParsingError(r.Context(), "get_wallet_trades", "include_failed_parameter", err, slog.String("invalid_value", includeFailedStr))
+			s.writeValidationError(w, "Invalid include_failed parameter", "include_failed must be true or false")
+			return
+		}
+		includeFailed = parsed
+	}
+
+	var minConfidence float64
+	if minConfidenceStr := r.URL.Query().Get("min_confidence"); minConfidenceStr != "" {
+		parsed, err := strconv.ParseFloat(minConfidenceStr, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			s.logger.LogValidationError(r.Context(), "get_wallet_trades", "min_confidence", minConfidenceStr, fmt.Errorf("min_confidence must be between 0 and 1"))
+			s.writeValidationError(w, "Invalid min_confidence parameter", "min_confidence must be a number between 0 and 1")
+			return
+		}
+		minConfidence = parsed
+	}
+
+	needsReview := false
+	if needsReviewStr := r.URL.Query().Get("needs_review"); needsReviewStr != "" {
+		parsed, err := strconv.ParseBool(needsReviewStr)
+		if err != nil {
+			s.logger.LogParsingError(r.Context(), "get_wallet_trades", "needs_review_parameter", err, slog.String("invalid_value", needsReviewStr))
+			s.writeValidationError(w, "Invalid needs_review parameter", "needs_review must be true or false")
+			return
+		}
+		needsReview = parsed
+	}
+
+	includeDust := false
+	if includeDustStr := r.URL.Query().Get("include_dust"); includeDustStr != "" {
+		parsed, err := strconv.ParseBool(includeDustStr)
+		if err != nil {
+			s.logger.LogParsingError(r.Context(), "get_wallet_trades", "include_dust_parameter", err, slog.String("invalid_value", includeDustStr))
+			s.writeValidationError(w, "Invalid include_dust parameter", "include_dust must be true or false")
+			return
+		}
+		includeDust = parsed
+	}
 
 	// Fetch wallet trades using trade service
-	trades, err := s.tradeService.GetWalletTrades(r.Context(), wallet, limit, before)
+	walletTrades, err := s.tradeService.GetTrades(r.Context(), wallet, &trades.TradeRequest{
+		Limit:         limit,
+		Before:        before,
+		Until:         until,
+		MinSlot:       slotRange[0],
+		MaxSlot:       slotRange[1],
+		IncludeFailed: includeFailed,
+		MinConfidence: minConfidence,
+		NeedsReview:   needsReview,
+		IncludeDust:   includeDust,
+		// Filter values are normalized and validated by the trade service
+		TradeFilters: trades.TradeFilters{
+			Side:         r.URL.Query().Get("side"),
+			CounterAsset: r.URL.Query().Get("counter_asset"),
+			MinAmount:    r.URL.Query().Get("min_amount"),
+			MaxAmount:    r.URL.Query().Get("max_amount"),
+		},
+	})
 	if err != nil {
 		// Log error with wallet context
 		logger := s.logger.WithWalletAddress(string(wallet))
@@ -168,50 +391,1960 @@ func (s *Server) handleWalletTrades(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return TradeResponse JSON response (follows existing patterns)
-	s.writeJSONSuccess(w, trades)
+	s.writeJSONFields(w, r, walletTrades.WithProtocolState(s.store).WithExplorer(provider), "trades", fields)
 }
 
-// handlePrice returns current price data for all supported assets
-// @Summary Get current asset prices
-// @Description Fetch current prices for SOL/USD, xSOL/SOL, and xSOL/USD with caching
-// @Tags price
+// handleWalletTradesFull streams a wallet's complete xSOL trade history as newline-delimited JSON
+// @Summary Stream complete wallet xSOL trade history
+// @Description Walk the wallet's entire signature history, newest first, writing each trade as a {"type":"trade"} line as soon as it is parsed instead of paging through /trades. The stream ends with a {"type":"done"} line whose summary reports the signatures scanned and whether the walk reached the oldest signature, or with a {"type":"error"} line when it failed after trades were sent; pass summary.cursor as before to resume. Disconnecting stops the walk and its RPC requests immediately
+// @Tags wallet
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param before query string false "Start below this signature, e.g. the cursor of an interrupted walk"
+// @Param include_failed query bool false "Include reverted Hylo transactions with their failure reason (default false)"
+// @Param explorer query string false "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer"
+// @Produce application/x-ndjson
+// @Success 200 {object} server.TradeStreamLine "One line per trade, then a done or error line"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /wallet/{address}/trades/full [get]
+func (s *Server) handleWalletTradesFull(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	provider, ok := s.parseExplorer(w, r)
+	if !ok {
+		return
+	}
+
+	includeFailed := false
+	if includeFailedStr := r.URL.Query().Get("include_failed"); includeFailedStr != "" {
+		parsed, err := strconv.ParseBool(includeFailedStr)
+		if err != nil {
+			s.logger.LogParsingError(r.Context(), "stream_wallet_trades", "include_failed_parameter", err, slog.String("invalid_value", includeFailedStr))
+			s.writeValidationError(w, "Invalid include_failed parameter", "include_failed must be true or false")
+			return
+		}
+		includeFailed = parsed
+	}
+
+	// The walk outlives the server write timeout
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	// Headers are sent with the first line, so failures before any trade still get a regular error response
+	started := false
+	writeLine := func(line TradeStreamLine) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		data, err := json.Marshal(line)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+		return controller.Flush()
+	}
+
+	summary, err := s.tradeService.StreamTrades(r.Context(), wallet, r.URL.Query().Get("before"), includeFailed,
+		func(trade *hylo.XSOLTrade) error {
+			linked := trade.WithExplorer(provider)
+			linked.ProtocolState = trades.ProtocolStateAt(s.store, linked.Slot)
+			return writeLine(TradeStreamLine{Type: "trade", Trade: linked})
+		})
+	if r.Context().Err() != nil {
+		// The client went away; nobody is left to read the outcome
+		s.logger.WithWalletAddress(string(wallet)).InfoContext(r.Context(), "Trade history stream closed by client",
+			slog.Int("signatures", summary.Signatures),
+			slog.Int("trades", summary.Trades))
+		return
+	}
+	if err == nil {
+		_ = writeLine(TradeStreamLine{Type: "done", Summary: summary})
+		return
+	}
+
+	logger := s.logger.WithWalletAddress(string(wallet))
+	status, message, code := http.StatusInternalServerError, "Failed to stream wallet trades", ErrorCodeInternal
+	if isNetworkError(err) {
+		logger.LogExternalAPIError(r.Context(), "trade-service", "StreamTrades", err, 0)
+		status, message, code = http.StatusBadGateway, "Network connectivity issue", ErrorCodeNetwork
+	} else if isValidationError(err) {
+		logger.LogValidationError(r.Context(), "stream_wallet_trades", "wallet_data", wallet, err)
+		status, code = http.StatusBadRequest, ErrorCodeValidation
+	} else {
+		logger.LogHandlerError(r.Context(), "stream_wallet_trades", err)
+	}
+	if !started {
+		s.writeJSONError(w, status, message, err.Error(), code)
+		return
+	}
+	_ = writeLine(TradeStreamLine{Type: "error", Summary: summary, Error: &ErrorResponse{
+		BaseResponse: BaseResponse{Timestamp: getCurrentTimestamp()},
+		Error:        message,
+		Details:      err.Error(),
+		Code:         code,
+	}})
+}
+
+// handleWalletEvents returns trades and token transfers for a specific wallet
+// @Summary Get wallet activity events
+// @Description Fetch paginated wallet activity: xSOL trades (BUY, SELL, RECEIVE) and plain transfers of xSOL, hyUSD and sHYUSD between owners (TRANSFER_IN, TRANSFER_OUT) with source and destination owner addresses
+// @Tags wallet
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param limit query int false "Maximum number of events to return (1-50, default 10)"
+// @Param before query string false "Cursor for pagination - signature to fetch events before"
+// @Param include_failed query bool false "Include reverted Hylo transactions as failed trade events with their failure reason (default false)"
+// @Param fields query string false "Comma-separated event fields to return, e.g. type,signature,timestamp"
+// @Param explorer query string false "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer"
 // @Produce json
-// @Success 200 {object} price.CombinedPriceResponse "Current asset prices"
+// @Success 200 {object} trades.EventsResponse "Wallet activity events"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
 // @Failure 500 {object} server.ErrorResponse "Internal server error"
 // @Failure 502 {object} server.ErrorResponse "Network connectivity error"
-// @Router /price [get]
-func (s *Server) handlePrice(w http.ResponseWriter, r *http.Request) {
-	// Always fetch fresh prices - no caching for maximum freshness
-	prices, err := s.priceService.GetCombinedPriceResponse(r.Context())
+// @Router /wallet/{address}/events [get]
+func (s *Server) handleWalletEvents(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	fields, ok := s.parseFields(w, r, (*trades.EventsResponse)(nil), "events")
+	if !ok {
+		return
+	}
+	provider, ok := s.parseExplorer(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 || parsedLimit > 50 {
+			s.writeValidationError(w, "Invalid limit parameter", "Limit must be between 1 and 50")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	includeFailed := false
+	if includeFailedStr := r.URL.Query().Get("include_failed"); includeFailedStr != "" {
+		parsed, err := strconv.ParseBool(includeFailedStr)
+		if err != nil {
+			s.logger.LogParsingError(r.Context(), "get_wallet_events", "include_failed_parameter", err, slog.String("invalid_value", includeFailedStr))
+			s.writeValidationError(w, "Invalid include_failed parameter", "include_failed must be true or false")
+			return
+		}
+		includeFailed = parsed
+	}
+
+	events, err := s.tradeService.GetEvents(r.Context(), wallet, &trades.TradeRequest{
+		Limit:         limit,
+		Before:        r.URL.Query().Get("before"),
+		IncludeFailed: includeFailed,
+	})
 	if err != nil {
-		// Log error
-		logger := s.logger.WithOperation("get_price")
+		logger := s.logger.WithWalletAddress(string(wallet))
 
-		// Return error - no fallback cache to rely on
 		if isNetworkError(err) {
-			logger.LogExternalAPIError(r.Context(), "price-service", "GetCombinedPriceResponse", err, 0)
+			logger.LogExternalAPIError(r.Context(), "trade-service", "GetEvents", err, 0)
 			s.writeNetworkError(w, err.Error())
+		} else if isValidationError(err) {
+			logger.LogValidationError(r.Context(), "get_wallet_events", "wallet_data", wallet, err)
+			s.writeValidationError(w, "Failed to fetch wallet events", err.Error())
 		} else {
-			logger.LogHandlerError(r.Context(), "get_price", err)
+			logger.LogHandlerError(r.Context(), "get_wallet_events", err)
 			s.writeInternalError(w, err.Error())
 		}
 		return
 	}
 
-	// Return fresh CombinedPriceResponse JSON (matches PRD specification)
-	s.writeJSONSuccess(w, prices)
+	s.writeJSONFields(w, r, events.WithProtocolState(s.store).WithExplorer(provider), "events", fields)
 }
 
-// handlePriceDebug returns detailed price calculation information for debugging
-// This is a temporary endpoint to help debug the xSOL price calculation
-func (s *Server) handlePriceDebug(w http.ResponseWriter, r *http.Request) {
-	// Get calculation details
-	details, err := s.priceService.GetPriceCalculationDetails(r.Context())
+// handleWalletSummary returns when a wallet first interacted with Hylo and its age in the protocol
+// @Summary Get wallet summary
+// @Description Find the wallet's earliest successful transaction on its xSOL, hyUSD or sHYUSD token accounts and report it as first_hylo_activity with account_age_days. Histories longer than a page are binary searched by slot for their tail; where the tail cannot be located the lookup walks at most 50,000 signatures per token account, reports truncated and resumes the walk on the next lookup. Complete results are cached for the 10,000 most recently found wallets
+// @Tags wallet
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param explorer query string false "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer"
+// @Produce json
+// @Success 200 {object} trades.WalletSummary "Wallet summary"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /wallet/{address}/summary [get]
+func (s *Server) handleWalletSummary(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	provider, ok := s.parseExplorer(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := s.tradeService.GetWalletSummary(r.Context(), wallet)
 	if err != nil {
-		s.logger.LogHandlerError(r.Context(), "get_price_debug", err)
-		s.writeInternalError(w, fmt.Sprintf("Failed to get calculation details: %v", err))
+		logger := s.logger.WithWalletAddress(string(wallet))
+
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "trade-service", "GetWalletSummary", err, 0)
+			s.writeNetworkError(w, err.Error())
+		} else if isValidationError(err) {
+			logger.LogValidationError(r.Context(), "get_wallet_summary", "wallet_data", wallet, err)
+			s.writeValidationError(w, "Failed to fetch wallet summary", err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "get_wallet_summary", err)
+			s.writeInternalError(w, err.Error())
+		}
 		return
 	}
 
-	s.writeJSONSuccess(w, details)
+	s.writeJSONSuccess(w, summary.WithExplorer(provider))
+}
+
+// handleTradeBySignature looks up the xSOL trade a transaction made and whether tracked wallets counted it
+// @Summary Look up a trade by signature
+// @Description Find the xSOL trade made by a transaction, regardless of wallet. Signatures in the trade index are served from it with indexed true; other signatures are fetched and parsed for every wallet whose xSOL the transaction moved, with source rpc. Each trade reports whether its wallet is tracked and whether the trade was indexed, answering whether a transaction was counted in tracked history. Tracked wallets are those of the X-API-Key's tenant when tenants are configured; without a key no wallet is reported as tracked. With include_raw the response also carries the transaction's undecoded instructions: base64 data, the resolved account key list including lookup table accounts, and the tree of inner instructions each one invoked, for decoding programs the API does not
+// @Tags trades
+// @Param signature path string true "Transaction signature (base58 encoded)"
+// @Param include_raw query bool false "Include raw instruction data, account keys and inner instruction trees (default false)"
+// @Param explorer query string false "Block explorer for links: solscan (default EXPLORER_PROVIDER), solanafm, xray or solana_explorer"
+// @Produce json
+// @Success 200 {object} trades.SignatureLookup "Trade and affected wallets"
+// @Security TenantAPIKey
+// @Failure 400 {object} server.ErrorResponse "Invalid signature"
+// @Failure 401 {object} server.ErrorResponse "Invalid X-API-Key"
+// @Failure 404 {object} server.ErrorResponse "Unknown transaction or no xSOL trade"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /trades/{signature} [get]
+func (s *Server) handleTradeBySignature(w http.ResponseWriter, r *http.Request) {
+	signature := strings.TrimSpace(chi.URLParam(r, "signature"))
+	if err := solana.Signature(signature).Validate(); err != nil {
+		s.writeValidationError(w, "Invalid signature", err.Error())
+		return
+	}
+
+	provider, ok := s.parseExplorer(w, r)
+	if !ok {
+		return
+	}
+
+	includeRaw := false
+	if includeRawStr := r.URL.Query().Get("include_raw"); includeRawStr != "" {
+		parsed, err := strconv.ParseBool(includeRawStr)
+		if err != nil {
+			s.logger.LogParsingError(r.Context(), "lookup_signature", "include_raw_parameter", err, slog.String("invalid_value", includeRawStr))
+			s.writeValidationError(w, "Invalid include_raw parameter", "include_raw must be true or false")
+			return
+		}
+		includeRaw = parsed
+	}
+
+	lookup, err := s.tradeService.LookupSignature(r.Context(), s.store, requestTenant(r), signature, includeRaw)
+	if err != nil {
+		if errors.Is(err, trades.ErrTradeNotFound) {
+			s.writeNotFoundError(w, "Trade for signature "+signature)
+			return
+		}
+
+		logger := s.logger.WithOperation("lookup_signature")
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "trade-service", "LookupSignature", err, 0,
+				slog.String("signature", signature))
+			s.writeNetworkError(w, err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "lookup_signature", err,
+				slog.String("signature", signature))
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	if !s.tenantScoped(r) {
+		lookup = lookup.WithoutTracking()
+	}
+	s.writeJSONSuccess(w, lookup.WithExplorer(provider))
+}
+
+// handleWalletTradeAggregate buckets a tracked wallet's indexed trades by day or week
+// @Summary Get aggregated wallet trades
+// @Description Per-bucket totals of the wallet's indexed trades for charting: trade counts, buy and sell volume, net xSOL flow and the volume-weighted USD price of trades against stablecoins. Buckets start at midnight in the tz time zone, so daily volumes match the calendar day of users outside UTC. Computed from the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant. Every bucket in the window is returned, oldest first, including empty ones
+// @Tags wallet
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param bucket query string false "Bucket size: day or week (ISO weeks starting Monday), default day"
+// @Param days query int false "Lookback window in days (1-365, default 30)"
+// @Param tz query string false "Time zone buckets start at midnight in: IANA name (Asia/Singapore) or UTC offset (+08:00), default BUCKET_TIMEZONE"
+// @Produce json
+// @Success 200 {object} watchlist.TradeAggregate "Aggregated trades"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key or admin token when tenants are configured"
+// @Failure 404 {object} server.ErrorResponse "Wallet is not tracked by the caller's tenant"
+// @Router /wallet/{address}/trades/aggregate [get]
+func (s *Server) handleWalletTradeAggregate(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	bucket := watchlist.BucketDay
+	if value := r.URL.Query().Get("bucket"); value != "" {
+		if !watchlist.ValidBucket(value) {
+			s.writeValidationError(w, "Invalid bucket parameter", "bucket must be day or week")
+			return
+		}
+		bucket = value
+	}
+
+	days := watchlist.DefaultAggregateDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > watchlist.MaxAggregateDays {
+			s.writeValidationError(w, "Invalid days parameter", fmt.Sprintf("days must be between 1 and %d", watchlist.MaxAggregateDays))
+			return
+		}
+		days = parsed
+	}
+
+	loc, ok := s.parseTimezone(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.requireTrackedWallet(w, r, wallet) {
+		return
+	}
+
+	aggregate, err := watchlist.AggregateTrades(s.store, wallet.String(), bucket, days, loc, time.Now())
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "get_trade_aggregate", err)
+		s.writeInternalError(w, "Failed to aggregate trades")
+		return
+	}
+
+	s.writeJSONSuccess(w, aggregate)
+}
+
+// handleWalletActivity counts a tracked wallet's indexed Hylo interactions by weekday and hour
+// @Summary Get wallet activity heatmap
+// @Description Counts of the wallet's indexed Hylo interactions (trades, failed attempts included) bucketed by day-of-week and hour in the tz time zone over the last days days, for rendering activity heatmaps. heatmap[weekday][hour] uses weekday 0 = Sunday. Computed from the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant
+// @Tags wallet
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param days query int false "Lookback window in days (1-365, default 90)"
+// @Param tz query string false "Time zone of the weekdays and hours: IANA name (Asia/Singapore) or UTC offset (+08:00), default BUCKET_TIMEZONE"
+// @Produce json
+// @Success 200 {object} watchlist.WalletActivity "Activity heatmap"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key or admin token when tenants are configured"
+// @Failure 404 {object} server.ErrorResponse "Wallet is not tracked by the caller's tenant"
+// @Router /wallet/{address}/activity [get]
+func (s *Server) handleWalletActivity(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	days := watchlist.DefaultActivityDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > watchlist.MaxActivityDays {
+			s.writeValidationError(w, "Invalid days parameter", fmt.Sprintf("days must be between 1 and %d", watchlist.MaxActivityDays))
+			return
+		}
+		days = parsed
+	}
+
+	loc, ok := s.parseTimezone(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.requireTrackedWallet(w, r, wallet) {
+		return
+	}
+
+	activity, err := watchlist.SummarizeActivity(s.store, wallet.String(), days, loc, time.Now())
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "get_wallet_activity", err)
+		s.writeInternalError(w, "Failed to summarize wallet activity")
+		return
+	}
+
+	s.writeJSONSuccess(w, activity)
+}
+
+// handleWalletRisk scores a tracked wallet's exposure with the breakdown of each component
+// @Summary Get wallet risk score
+// @Description Risk score from 0 to 100 with its components: leverage (xSOL share of the wallet's USD value), concentration (Herfindahl index of the holdings), frequency (executed trades per day over the last days days) and drawdown (unrealized loss of the open xSOL position against its average cost). Each component scores 0-100 and contributes by its weight; the total maps to low, moderate, high or severe. Computed from the latest stored snapshot and the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant
+// @Tags wallet
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param days query int false "Trading frequency window in days (1-365, default 30)"
+// @Produce json
+// @Success 200 {object} watchlist.WalletRisk "Risk score and components"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key or admin token when tenants are configured"
+// @Failure 404 {object} server.ErrorResponse "Wallet is not tracked by the caller's tenant or has no snapshot"
+// @Router /wallet/{address}/risk [get]
+func (s *Server) handleWalletRisk(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	days := watchlist.DefaultRiskDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > watchlist.MaxRiskDays {
+			s.writeValidationError(w, "Invalid days parameter", fmt.Sprintf("days must be between 1 and %d", watchlist.MaxRiskDays))
+			return
+		}
+		days = parsed
+	}
+
+	if !s.requireTrackedWallet(w, r, wallet) {
+		return
+	}
+
+	risk, err := watchlist.ScoreRisk(s.store, wallet.String(), days, time.Now())
+	if errors.Is(err, watchlist.ErrNoSnapshot) {
+		s.writeNotFoundError(w, "Balance snapshot")
+		return
+	}
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "get_wallet_risk", err)
+		s.writeInternalError(w, "Failed to score wallet risk")
+		return
+	}
+
+	s.writeJSONSuccess(w, risk)
+}
+
+// handleWalletApprovals audits the delegates approved on a wallet's Hylo token accounts
+// @Summary Get wallet token approvals
+// @Description Lists every active delegate on the wallet's hyUSD, sHYUSD and xSOL token accounts. A delegate can transfer up to its delegated amount without the owner signing again, so outstanding approvals left behind by dApps are a common drain vector. Each approval is graded unlimited (approved for the maximum u64 amount), full_balance (the delegated amount covers the account balance) or partial; at_risk is set when any approval is unlimited or full_balance. Read live from the chain: the associated token accounts, or every token account of the wallet when token account discovery is enabled
+// @Tags wallet
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Produce json
+// @Success 200 {object} tokens.WalletApprovals "Active token approvals"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /wallet/{address}/approvals [get]
+func (s *Server) handleWalletApprovals(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	approvals, err := s.tokenService.GetWalletApprovals(r.Context(), wallet)
+	if err != nil {
+		logger := s.logger.WithWalletAddress(string(wallet))
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "token-service", "GetWalletApprovals", err, 0)
+			s.writeNetworkError(w, err.Error())
+		} else if isValidationError(err) {
+			logger.LogValidationError(r.Context(), "get_wallet_approvals", "wallet_data", wallet, err)
+			s.writeValidationError(w, "Failed to audit wallet approvals", err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "get_wallet_approvals", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	s.writeJSONSuccess(w, approvals)
+}
+
+// handleWalletBalanceDelta reports how a tracked wallet's holdings changed since a slot or timestamp
+// @Summary Get wallet balance changes
+// @Description Per-token balance changes between the newest stored snapshot at or before since and the latest snapshot. Each change is split into the part explained by indexed trades (from_trades) and the remainder (other: transfers and activity the trade index does not cover). Tokens that did not change are omitted. Computed from stored snapshots and the trade index (no live RPC calls), so the wallet must be tracked by the caller's tenant
+// @Tags wallet
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param since query string true "Slot number or RFC 3339 timestamp"
+// @Produce json
+// @Success 200 {object} watchlist.BalanceDelta "Balance changes"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key or admin token when tenants are configured"
+// @Failure 404 {object} server.ErrorResponse "Wallet is not tracked by the caller's tenant or has no snapshot at or before since"
+// @Router /wallet/{address}/balances/delta [get]
+func (s *Server) handleWalletBalanceDelta(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	since, err := watchlist.ParseSince(r.URL.Query().Get("since"))
+	if err != nil {
+		s.writeValidationError(w, "Invalid since parameter", err.Error())
+		return
+	}
+
+	if !s.requireTrackedWallet(w, r, wallet) {
+		return
+	}
+
+	delta, err := watchlist.ComputeBalanceDelta(s.store, wallet.String(), since, time.Now())
+	if errors.Is(err, watchlist.ErrNoSnapshot) {
+		s.writeNotFoundError(w, "Balance snapshot at or before since")
+		return
+	}
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "get_wallet_balance_delta", err)
+		s.writeInternalError(w, "Failed to compute balance changes")
+		return
+	}
+
+	s.writeJSONSuccess(w, delta)
+}
+
+// streamKeepAlive is the interval between SSE comment lines that keep idle connections open
+const streamKeepAlive = 15 * time.Second
+
+// handleWalletStream streams account updates for a wallet's token accounts as Server-Sent Events
+// @Summary Stream wallet account updates
+// @Description Server-Sent Events stream of xSOL, hyUSD and sHYUSD token account changes for a wallet. Each "account" event carries a stream.Update JSON payload. For wallets tracked by the indexer, a "balance.changed" event carrying an indexer.BalanceChange payload follows each sync that moved a token balance; its id is the webhook event ID. Upstream Solana subscriptions are shared between all clients watching the same wallet. At most HTTP_MAX_SSE_STREAMS wallet and supply streams run at once and at most STREAM_MAX_WALLETS distinct wallets are streamed; beyond either cap the stream is rejected with 503 and Retry-After
+// @Tags wallet
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Produce text/event-stream
+// @Success 200 {object} stream.Update "Stream of account update events"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 429 {object} server.ErrorResponse "Client rate limit exceeded"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Failure 503 {object} server.ErrorResponse "Stream slots or streamed wallets at capacity"
+// @Router /wallet/{address}/stream [get]
+func (s *Server) handleWalletStream(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	client, err := s.streams.Subscribe(r.Context(), wallet)
+	if err != nil {
+		logger := s.logger.WithWalletAddress(string(wallet))
+		if errors.Is(err, stream.ErrTooManyWallets) {
+			metrics.RecordHTTPShed(metrics.HTTPShedStreamWallets)
+			logger.WarnContext(r.Context(), "Stream rejected: streamed wallets at capacity", slog.String("error", err.Error()))
+			s.writeOverloadedError(w, streamRetryAfter, err.Error())
+		} else if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "solana-ws", "AccountSubscribe", err, 0)
+			s.writeNetworkError(w, err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "stream_wallet", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+	defer client.Close()
+
+	// Streams outlive the server write timeout
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case update, ok := <-client.Updates:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(update)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: account\nid: %d\ndata: %s\n\n", update.Slot, data)
+		case event, ok := <-client.Events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event.Data)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\nid: %s\ndata: %s\n\n", event.Type, event.ID, data)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// handleLimits documents the quotas that apply to the caller
+// @Summary Get current rate limits and quotas
+// @Description Report the caller's per-client request bucket, the shared Solana RPC budget, the server-wide in-flight limits and per-request deadlines, so integrators can pace requests instead of running into 429 or 503 responses. Limited endpoints also return X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers for the client bucket and X-RPC-Budget-Remaining and X-RPC-Budget-Reset for the RPC budget. Calling this endpoint does not consume the client's quota
+// @Tags health
+// @Produce json
+// @Success 200 {object} server.LimitsResponse "Current limits"
+// @Router /limits [get]
+func (s *Server) handleLimits(w http.ResponseWriter, r *http.Request) {
+	response := LimitsResponse{
+		Requests: RequestLimits{
+			DefaultTimeoutMS:  s.routeLimits.DefaultTimeout.Milliseconds(),
+			BalancesTimeoutMS: s.routeLimits.BalancesTimeout.Milliseconds(),
+			TradesTimeoutMS:   s.routeLimits.TradesTimeout.Milliseconds(),
+			MaxBodyBytes:      s.routeLimits.MaxBodyBytes,
+		},
+	}
+
+	if s.clientLimiter != nil {
+		quota := s.clientLimiter.peek(s.clientLimiter.clientKey(r))
+		setClientQuotaHeaders(w, quota)
+		response.Client = ClientLimits{
+			Enabled:      true,
+			RPS:          s.clientLimiter.settings.RPS,
+			Burst:        quota.Limit,
+			Remaining:    quota.Remaining,
+			ResetSeconds: ceilSeconds(quota.Reset),
+		}
+	}
+	if budget, ok := s.rpcScheduler.Budget(); ok {
+		setRPCBudgetHeaders(w, budget)
+		response.RPCBudget = &budget
+	}
+	if s.concurrency.Enabled() {
+		response.Concurrency = ConcurrencyLimits{
+			Enabled:        true,
+			MaxInFlight:    s.concurrency.MaxInFlight,
+			QueueDepth:     s.concurrency.QueueDepth,
+			QueueTimeoutMS: s.concurrency.QueueTimeout.Milliseconds(),
+		}
+	}
+	if s.concurrency != nil {
+		response.Concurrency.MaxTradeStreams = s.concurrency.MaxTradeStreams
+		response.Concurrency.MaxSSEStreams = s.concurrency.MaxSSEStreams
+	}
+
+	s.writeJSONSuccess(w, response)
+}
+
+// handlePrice returns current price data for all supported assets
+// @Summary Get current asset prices
+// @Description Fetch current prices for SOL/USD, xSOL/SOL, and xSOL/USD. With stale-while-revalidate configured (PRICE_SOFT_TTL_SEC), cached prices are returned immediately with their age and both TTLs under cache: past the soft TTL a background refresh replaces them, and only past the hard TTL does the request wait for fresh prices. With currency, sol_usd and xsol_usd are converted to that fiat currency at the daily reference rate returned under currency
+// @Tags price
+// @Param currency query string false "ISO 4217 currency to convert USD prices to, e.g. EUR"
+// @Produce json
+// @Success 200 {object} price.CombinedPriceResponse "Current asset prices"
+// @Failure 400 {object} server.ErrorResponse "Unknown currency"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /price [get]
+func (s *Server) handlePrice(w http.ResponseWriter, r *http.Request) {
+	conversion, ok := s.parseCurrency(w, r)
+	if !ok {
+		return
+	}
+
+	// Fresh prices unless stale-while-revalidate is configured
+	prices, err := s.priceService.GetServedPriceResponse(r.Context())
+	if err != nil {
+		// Log error
+		logger := s.logger.WithOperation("get_price")
+
+		// Return error - no fallback cache to rely on
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "price-service", "GetCombinedPriceResponse", err, 0)
+			s.writeNetworkError(w, err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "get_price", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	if conversion != nil {
+		prices = prices.InCurrency(conversion)
+	}
+
+	// Return fresh CombinedPriceResponse JSON (matches PRD specification)
+	s.writeJSONSuccess(w, prices)
+}
+
+// handlePriceQuote returns the price of one supported token
+// @Summary Get a token price quote
+// @Description Price one token in a shape shared by every token, with how the price was derived under method and where it came from under source. hyUSD is read from the peg monitor (method peg, with its deviation under peg), sHYUSD from the stability pool assets backing one share (method exchange_rate, with the per-share assets under backing), xSOL from Hylo protocol math (method protocol), and SOL and jitoSOL from DexScreener markets (method market). With currency, price_usd is converted to that fiat currency
+// @Tags price
+// @Param symbol path string true "Token symbol (hyUSD, sHYUSD, xSOL, SOL, jitoSOL; case-insensitive)"
+// @Param currency query string false "ISO 4217 currency to convert price_usd to, e.g. EUR"
+// @Produce json
+// @Success 200 {object} price.PriceQuote "Token price quote"
+// @Failure 400 {object} server.ErrorResponse "Unknown currency"
+// @Failure 404 {object} server.ErrorResponse "Token not supported"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /price/{symbol} [get]
+func (s *Server) handlePriceQuote(w http.ResponseWriter, r *http.Request) {
+	symbol := chi.URLParam(r, "symbol")
+	conversion, ok := s.parseCurrency(w, r)
+	if !ok {
+		return
+	}
+
+	quote, err := s.priceService.GetPriceQuote(r.Context(), symbol)
+	if err != nil {
+		if errors.Is(err, hylo.ErrUnsupportedQuote) {
+			s.writeNotFoundError(w, "Price for "+symbol)
+			return
+		}
+
+		logger := s.logger.WithOperation("get_price_quote")
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "price-service", "GetPriceQuote", err, 0,
+				slog.String("symbol", symbol))
+			s.writeNetworkError(w, err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "get_price_quote", err,
+				slog.String("symbol", symbol))
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	if conversion != nil {
+		quote = quote.InCurrency(conversion)
+	}
+	s.writeJSONSuccess(w, quote)
+}
+
+// handleXSOLMetrics returns xSOL risk metrics computed from the sampled price history
+// @Summary Get xSOL volatility and drawdown metrics
+// @Description Annualized realized volatility of xSOL/USD over 7 and 30 days, its maximum drawdown and beta against SOL over 30 days, recomputed on every price refresh from prices sampled every PRICE_HISTORY_INTERVAL_SEC. Metrics are null until their window holds enough prices; the history survives restarts through the cache snapshot
+// @Tags price
+// @Produce json
+// @Success 200 {object} price.XSOLMetrics "xSOL risk metrics"
+// @Failure 503 {object} server.ErrorResponse "Price history disabled"
+// @Router /price/xsol/metrics [get]
+func (s *Server) handleXSOLMetrics(w http.ResponseWriter, r *http.Request) {
+	history := s.priceService.GetPriceHistory()
+	if history == nil {
+		s.writeNotConfiguredError(w, "price history is disabled: PRICE_HISTORY_INTERVAL_SEC is 0")
+		return
+	}
+
+	s.writeJSONSuccess(w, history.Metrics())
+}
+
+// handlePeg returns hyUSD's market price and its deviation from the $1 peg
+// @Summary Get hyUSD peg status
+// @Description Compare hyUSD's liquidity-weighted DexScreener market price with its $1 design peg. Returns the current deviation, the largest deviation sampled in the last 24 hours, and whether the depeg alert is raised. Crossing PEG_ALERT_THRESHOLD_BPS publishes a peg.depeg webhook event, and peg.restored once the deviation falls back under half the threshold
+// @Tags protocol
+// @Produce json
+// @Success 200 {object} peg.Status "hyUSD peg status"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /protocol/peg [get]
+func (s *Server) handlePeg(w http.ResponseWriter, r *http.Request) {
+	status, err := s.pegMonitor.Current(r.Context())
+	if err != nil {
+		logger := s.logger.WithOperation("get_peg")
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "peg-monitor", "Current", err, 0)
+			s.writeNetworkError(w, err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "get_peg", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	s.writeJSONSuccess(w, status)
+}
+
+// handleProtocolTVL returns the value locked in the exchange's LST vaults
+// @Summary Get protocol TVL
+// @Description Total value locked in USD and SOL terms with a per-LST breakdown: vault balance, SOL conversion rate, value and share of total. Rates are Sanctum stake pool rates, falling back to the LST's DexScreener USD price over SOL/USD; rate_source reports which was used. Vaults are the token accounts listed in HYLO_LST_VAULTS
+// @Tags protocol
+// @Produce json
+// @Success 200 {object} hylo.ProtocolTVL "Protocol TVL"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Failure 503 {object} server.ErrorResponse "No LST vaults configured"
+// @Router /protocol/tvl [get]
+func (s *Server) handleProtocolTVL(w http.ResponseWriter, r *http.Request) {
+	tvl, err := s.priceService.GetProtocolTVL(r.Context())
+	if err != nil {
+		logger := s.logger.WithOperation("get_protocol_tvl")
+		switch {
+		case errors.Is(err, hylo.ErrNoLSTVaults):
+			s.writeNotConfiguredError(w, err.Error())
+		case isNetworkError(err):
+			logger.LogExternalAPIError(r.Context(), "price-service", "GetProtocolTVL", err, 0)
+			s.writeNetworkError(w, err.Error())
+		default:
+			logger.LogHandlerError(r.Context(), "get_protocol_tvl", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	s.writeJSONSuccess(w, tvl)
+}
+
+// handleProtocolScenarios projects protocol health and tracked wallet values under a SOL price drop
+// @Summary Analyze a SOL price shock
+// @Description Project the collateral ratio, effective leverage and xSOL NAV if SOL fell by sol_drop percent with supplies and the SOL reserve unchanged, and report which stability modes would trigger. Mode 1 and mode 2 start below the collateral ratios HYLO_STABILITY_MODE1_CR and HYLO_STABILITY_MODE2_CR; below 100% the protocol is undercollateralized and xSOL is worthless. drop_to_mode_1_pct and drop_to_mode_2_pct give the drops at which each mode starts. Each of the caller's tracked wallets is repriced from its latest snapshot with xSOL at the shocked price, hardest hit first; wallets without a snapshot are left out. With tenants configured the wallets are only listed for requests carrying a valid X-API-Key header, and wallets is empty otherwise
+// @Tags protocol
+// @Security TenantAPIKey
+// @Produce json
+// @Param sol_drop query number true "SOL price drop in percent (above 0, at most 99)"
+// @Success 200 {object} watchlist.Scenario "Projected protocol state and wallet impact"
+// @Failure 400 {object} server.ErrorResponse "Invalid sol_drop"
+// @Failure 401 {object} server.ErrorResponse "Invalid API key"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /protocol/scenarios [get]
+func (s *Server) handleProtocolScenarios(w http.ResponseWriter, r *http.Request) {
+	dropStr := r.URL.Query().Get("sol_drop")
+	if dropStr == "" {
+		s.writeValidationError(w, "Missing sol_drop parameter", "sol_drop is required")
+		return
+	}
+	drop, err := strconv.ParseFloat(dropStr, 64)
+	if err == nil {
+		err = hylo.ValidateSOLDrop(drop)
+	}
+	if err != nil {
+		s.logger.LogParsingError(r.Context(), "get_protocol_scenarios", "sol_drop_parameter", err, slog.String("invalid_value", dropStr))
+		s.writeValidationError(w, "Invalid sol_drop parameter", fmt.Sprintf("sol_drop must be a percentage above 0 and at most %v", hylo.MaxSOLDropPct))
+		return
+	}
+
+	scenario, err := s.priceService.AnalyzeSOLShock(r.Context(), drop)
+	if err != nil {
+		logger := s.logger.WithOperation("get_protocol_scenarios")
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "price-service", "AnalyzeSOLShock", err, 0)
+			s.writeNetworkError(w, err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "get_protocol_scenarios", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	if !s.tenantScoped(r) {
+		s.writeJSONSuccess(w, &watchlist.Scenario{ProtocolScenario: *scenario, Wallets: []watchlist.WalletShock{}})
+		return
+	}
+	s.writeJSONSuccess(w, watchlist.ApplyScenario(s.store, requestTenant(r), scenario))
+}
+
+// handleProtocolHistory returns recorded protocol state snapshots for charting protocol health
+// @Summary Get protocol state history
+// @Description Time series of protocol health: collateral ratio, effective leverage, hyUSD and xSOL supply, SOL reserve and prices. Snapshots are recorded from price reads and at least every PROTOCOL_SNAPSHOT_INTERVAL_SEC, and kept for PROTOCOL_SNAPSHOT_RETENTION_HOURS. Ranges holding more than 500 snapshots are split into 500 buckets and the last snapshot of each is returned
+// @Tags protocol
+// @Produce json
+// @Param range query string false "Window ending now, in hours or days such as 24h or 30d (default 30d, max 365d)"
+// @Success 200 {object} indexer.ProtocolHistory "Protocol state history"
+// @Failure 400 {object} server.ErrorResponse "Invalid range"
+// @Failure 503 {object} server.ErrorResponse "Protocol snapshots disabled"
+// @Router /protocol/stats/history [get]
+func (s *Server) handleProtocolHistory(w http.ResponseWriter, r *http.Request) {
+	label := indexer.DefaultProtocolHistoryRange
+	if value := r.URL.Query().Get("range"); value != "" {
+		label = value
+	}
+	length, err := indexer.ParseProtocolHistoryRange(label)
+	if err != nil {
+		s.writeValidationError(w, "Invalid range parameter", err.Error())
+		return
+	}
+
+	if s.protocol == nil {
+		s.writeNotConfiguredError(w, "protocol snapshots are disabled: PROTOCOL_SNAPSHOT_INTERVAL_SEC is 0")
+		return
+	}
+
+	s.writeJSONSuccess(w, indexer.BuildProtocolHistory(s.store, label, length, time.Now()))
+}
+
+// handleSupplyStream streams protocol token supply changes as Server-Sent Events
+// @Summary Stream protocol token supply
+// @Description Server-Sent Events stream of hyUSD, xSOL and sHYUSD mint supply, fed by WebSocket subscriptions to the mint accounts rather than polling. A "supply" event carrying a []stream.TokenSupply payload with the latest known supplies is sent on connect; a "supply.changed" event carrying a stream.SupplyChange payload follows each mint or burn, with the slot as its id. Each change is also published as a protocol.supply_changed webhook event. Counts against the HTTP_MAX_SSE_STREAMS slots shared with wallet streams
+// @Tags protocol
+// @Produce text/event-stream
+// @Success 200 {object} stream.SupplyChange "Stream of supply change events"
+// @Failure 429 {object} server.ErrorResponse "Client rate limit exceeded"
+// @Failure 503 {object} server.ErrorResponse "Supply watcher disabled or stream slots at capacity"
+// @Router /protocol/supply/stream [get]
+func (s *Server) handleSupplyStream(w http.ResponseWriter, r *http.Request) {
+	if s.supply == nil {
+		s.writeNotConfiguredError(w, "supply watcher is disabled: SUPPLY_WATCHER_ENABLED is false")
+		return
+	}
+
+	client := s.supply.Subscribe()
+	defer client.Close()
+
+	// Streams outlive the server write timeout
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if data, err := json.Marshal(s.supply.Supplies()); err == nil {
+		fmt.Fprintf(w, "event: supply\ndata: %s\n\n", data)
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case change, ok := <-client.Changes:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(change)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: supply.changed\nid: %d\ndata: %s\n\n", change.Slot, data)
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// handleTokenStats returns supply, decimals and authorities for a supported token
+// @Summary Get token supply and holder stats
+// @Description Read total supply, decimals and mint/freeze authorities from the SPL mint account. Set holders to include the largest token accounts (via getTokenLargestAccounts) with their share of supply. metadata carries the name, symbol, URI and icon from the Metaplex metadata account once resolved at startup (TOKEN_METADATA_ENABLED)
+// @Tags tokens
+// @Param symbol path string true "Token symbol (hyUSD, sHYUSD, xSOL, USDC, jitoSOL; case-insensitive)"
+// @Param holders query int false "Number of largest token accounts to include (0-20, default 0)"
+// @Produce json
+// @Success 200 {object} hylo.TokenStats "Token stats"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 404 {object} server.ErrorResponse "Token not supported"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /tokens/{symbol}/stats [get]
+func (s *Server) handleTokenStats(w http.ResponseWriter, r *http.Request) {
+	symbol := chi.URLParam(r, "symbol")
+
+	var token *tokens.TokenInfo
+	for _, supported := range s.tokenService.GetSupportedTokens() {
+		if strings.EqualFold(supported.Symbol, symbol) {
+			token = supported
+			break
+		}
+	}
+	if token == nil {
+		s.writeNotFoundError(w, "Token "+symbol)
+		return
+	}
+
+	holders := 0
+	if holdersStr := r.URL.Query().Get("holders"); holdersStr != "" {
+		parsed, err := strconv.Atoi(holdersStr)
+		if err != nil || parsed < 0 || parsed > hylo.MaxTopHolders {
+			s.writeValidationError(w, "Invalid holders parameter", fmt.Sprintf("holders must be between 0 and %d", hylo.MaxTopHolders))
+			return
+		}
+		holders = parsed
+	}
+
+	stats, err := s.priceService.GetStateReader().ReadTokenStats(r.Context(), *token, holders)
+	if err != nil {
+		logger := s.logger.WithOperation("get_token_stats")
+
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "solana-rpc", "ReadTokenStats", err, 0,
+				slog.String("symbol", token.Symbol))
+			s.writeNetworkError(w, err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "get_token_stats", err,
+				slog.String("symbol", token.Symbol))
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+	stats.Metadata = s.tokenService.TokenMetadata(token.Mint)
+
+	s.writeJSONSuccess(w, stats)
+}
+
+// handlePriceDebug returns detailed price calculation information for debugging
+// This is a temporary endpoint to help debug the xSOL price calculation
+func (s *Server) handlePriceDebug(w http.ResponseWriter, r *http.Request) {
+	// Get calculation details
+	details, err := s.priceService.GetPriceCalculationDetails(r.Context())
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "get_price_debug", err)
+		s.writeInternalError(w, fmt.Sprintf("Failed to get calculation details: %v", err))
+		return
+	}
+
+	s.writeJSONSuccess(w, details)
+}
+
+// handleSimulateTrade estimates the outcome of a hypothetical xSOL mint or redeem
+// @Summary Simulate an xSOL trade
+// @Description Estimate output amount, fees, xSOL NAV price impact and resulting collateral ratio for a hypothetical BUY (mint) or SELL (redeem) using current protocol state. Read-only, no transaction is built. fee_source is estimated when fee rates were read from the exchange state account, whose layout is inferred without the program IDL, and default when the protocol's default rates were used.
+// @Tags simulate
+// @Accept json
+// @Param request body hylo.TradeSimulationRequest true "Trade to simulate (amount is in the input asset: counter asset for BUY, xSOL for SELL)"
+// @Produce json
+// @Success 200 {object} hylo.TradeSimulationResult "Simulated trade outcome"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 413 {object} server.ErrorResponse "Request body too large"
+// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 502 {object} server.ErrorResponse "Network connectivity error"
+// @Router /simulate/trade [post]
+func (s *Server) handleSimulateTrade(w http.ResponseWriter, r *http.Request) {
+	var req hylo.TradeSimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.LogParsingError(r.Context(), "simulate_trade", "request_body", err)
+		s.writeBodyDecodeError(w, err)
+		return
+	}
+
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		s.logger.LogValidationError(r.Context(), "simulate_trade", "request", req, err)
+		s.writeValidationError(w, "Invalid simulation request", err.Error())
+		return
+	}
+
+	result, err := s.priceService.SimulateTrade(r.Context(), &req)
+	if err != nil {
+		logger := s.logger.WithOperation("simulate_trade")
+
+		if isNetworkError(err) {
+			logger.LogExternalAPIError(r.Context(), "price-service", "SimulateTrade", err, 0)
+			s.writeNetworkError(w, err.Error())
+		} else if isValidationError(err) {
+			logger.LogValidationError(r.Context(), "simulate_trade", "request", req, err)
+			s.writeValidationError(w, "Trade cannot be simulated", err.Error())
+		} else {
+			logger.LogHandlerError(r.Context(), "simulate_trade", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	s.writeJSONSuccess(w, result)
+}
+
+// handleValidateAddress checks a wallet address before it is used for balance or trade queries
+// @Summary Validate a wallet address
+// @Description Check whether an address is a valid Solana wallet address. Returns the normalized form, derived associated token accounts for hyUSD, sHYUSD and xSOL, and suggestions for common input mistakes. No RPC calls are made.
+// @Tags validate
+// @Accept json
+// @Param request body tokens.AddressValidationRequest true "Address to validate"
+// @Produce json
+// @Success 200 {object} tokens.AddressValidationResult "Validation result (invalid addresses return valid=false with suggestions)"
+// @Failure 400 {object} server.ErrorResponse "Malformed request body"
+// @Failure 408 {object} server.ErrorResponse "Request timed out"
+// @Failure 413 {object} server.ErrorResponse "Request body too large"
+// @Router /validate/address [post]
+func (s *Server) handleValidateAddress(w http.ResponseWriter, r *http.Request) {
+	var req tokens.AddressValidationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.LogParsingError(r.Context(), "validate_address", "request_body", err)
+		s.writeBodyDecodeError(w, err)
+		return
+	}
+
+	s.writeJSONSuccess(w, s.tokenService.InspectAddress(req.Address))
+}
+
+// handleGetMaintenance reports whether the API is in read-only maintenance mode
+// @Summary Get maintenance mode
+// @Description Report whether maintenance mode is on. While it is, POST, PUT and DELETE requests to /wallets, /portfolios and /webhooks return 503 with a MAINTENANCE error code; read endpoints keep serving. Requires an admin bearer token.
+// @Tags admin
+// @Security AdminBearer
+// @Produce json
+// @Success 200 {object} server.MaintenanceStatus "Current maintenance mode"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or signature"
+// @Router /admin/maintenance [get]
+func (s *Server) handleGetMaintenance(w http.ResponseWriter, r *http.Request) {
+	s.writeJSONSuccess(w, s.maintenanceStatus())
+}
+
+// handleSetMaintenance switches read-only maintenance mode on or off
+// @Summary Switch maintenance mode
+// @Description Turn maintenance mode on or off, e.g. around a store migration. The message is returned by rejected writes and defaults to MAINTENANCE_MESSAGE. The switch lasts until the next one or a restart, which starts in MAINTENANCE_MODE. Requires an admin bearer token.
+// @Tags admin
+// @Security AdminBearer
+// @Accept json
+// @Param request body server.MaintenanceRequest true "Maintenance mode"
+// @Produce json
+// @Success 200 {object} server.MaintenanceStatus "Updated maintenance mode"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or signature"
+// @Failure 413 {object} server.ErrorResponse "Request body too large"
+// @Router /admin/maintenance [put]
+func (s *Server) handleSetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req MaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.LogParsingError(r.Context(), "set_maintenance", "request_body", err)
+		s.writeBodyDecodeError(w, err)
+		return
+	}
+
+	s.setMaintenance(req.Enabled, strings.TrimSpace(req.Message))
+	s.writeJSONSuccess(w, s.maintenanceStatus())
+}
+
+// handleAuditLog returns recent outbound calls recorded in the audit log
+// @Summary Query recent outbound calls
+// @Description List recent outbound Solana RPC and DexScreener calls (newest first) with duration, status and retry count. Requires an admin bearer token, and when ADMIN_SIGNING_SECRET or ADMIN_SIGNING_PUBLIC_KEY is set, X-Hylo-Timestamp (unix seconds) and X-Hylo-Signature (hex HMAC-SHA256 or Ed25519 signature of "<timestamp>.<METHOD>.<request URI>.<body>").
+// @Tags admin
+// @Security AdminBearer
+// @Param limit query int false "Maximum number of entries to return (1-1000, default 100)"
+// @Param target query string false "Filter by target (solana-rpc, dexscreener)"
+// @Param method query string false "Filter by RPC method, e.g. getAccountInfo"
+// @Param status query int false "Filter by HTTP status code"
+// @Produce json
+// @Success 200 {object} server.AuditEntriesResponse "Recent audit entries"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or signature"
+// @Router /admin/audit [get]
+func (s *Server) handleAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := audit.Query{
+		Target: r.URL.Query().Get("target"),
+		Method: r.URL.Query().Get("method"),
+		Limit:  defaultAuditLimit,
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			s.writeValidationError(w, "Invalid limit parameter", fmt.Sprintf("limit must be between 1 and %d", maxAuditLimit))
+			return
+		}
+		query.Limit = limit
+	}
+
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		status, err := strconv.Atoi(statusStr)
+		if err != nil {
+			s.writeValidationError(w, "Invalid status parameter", "status must be an HTTP status code")
+			return
+		}
+		query.StatusCode = status
+	}
+
+	entries := s.auditLog.Recent(query)
+	s.writeJSONSuccess(w, AuditEntriesResponse{
+		Entries:   entries,
+		Count:     len(entries),
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// handleWalletLogs returns recent log records tagged with a wallet
+// @Summary Query recent logs for a wallet
+// @Description List recent in-memory log records (newest first) emitted while handling a wallet: its API requests, background indexing and digests. Records are kept per process in a ring of LOG_BUFFER_SIZE entries and only at the configured LOG_LEVEL. Requires an admin bearer token, and when ADMIN_SIGNING_SECRET or ADMIN_SIGNING_PUBLIC_KEY is set, X-Hylo-Timestamp and X-Hylo-Signature as for /admin/audit.
+// @Tags admin
+// @Security AdminBearer
+// @Param wallet query string true "Wallet address"
+// @Param level query string false "Minimum level (debug, info, warn, error)"
+// @Param limit query int false "Maximum number of entries to return (1-1000, default 100)"
+// @Produce json
+// @Success 200 {object} server.WalletLogsResponse "Recent log records for the wallet"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or signature"
+// @Router /admin/logs [get]
+func (s *Server) handleWalletLogs(w http.ResponseWriter, r *http.Request) {
+	wallet, err := tokens.SanitizeAddress(r.URL.Query().Get("wallet"))
+	if err != nil {
+		s.writeValidationError(w, "Invalid wallet parameter", err.Error())
+		return
+	}
+
+	query := logger.Query{Wallet: string(wallet), Limit: defaultAuditLimit}
+
+	switch level := r.URL.Query().Get("level"); level {
+	case "", "debug", "info", "warn", "error":
+		query.MinLevel = level
+	default:
+		s.writeValidationError(w, "Invalid level parameter", "level must be debug, info, warn or error")
+		return
+	}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			s.writeValidationError(w, "Invalid limit parameter", fmt.Sprintf("limit must be between 1 and %d", maxAuditLimit))
+			return
+		}
+		query.Limit = limit
+	}
+
+	entries := logger.Recent(query)
+	s.writeJSONSuccess(w, WalletLogsResponse{
+		Wallet:    query.Wallet,
+		Entries:   entries,
+		Count:     len(entries),
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// handleReconcileWallet diffs a tracked wallet's trade index against a fresh RPC scan
+// @Summary Reconcile a wallet's trade index
+// @Description Re-scan the wallet's most recent trades from Solana RPC and diff them against the stored trade index: trades missing from the index, indexed trades RPC no longer returns, and trades whose side, status, amounts, counter asset, slot or block time changed. Only the slot range both sides cover is compared; trades newer than the sync cursor are counted as pending. The index is not modified. Requires an admin bearer token, and a request signature as for /admin/audit when a signing key is set.
+// @Tags admin
+// @Security AdminBearer
+// @Param address path string true "Tracked wallet address (base58 encoded)"
+// @Param depth query int false "Recent trades to re-scan (1-1000, default INDEXER_RECONCILE_DEPTH)"
+// @Produce json
+// @Success 200 {object} indexer.ReconcileReport "Reconciliation report"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or signature"
+// @Failure 404 {object} server.ErrorResponse "Wallet not tracked"
+// @Failure 502 {object} server.ErrorResponse "Solana RPC error"
+// @Router /admin/reconcile/{address} [get]
+func (s *Server) handleReconcileWallet(w http.ResponseWriter, r *http.Request) {
+	wallet := walletAddress(r)
+
+	depth := 0
+	if depthStr := r.URL.Query().Get("depth"); depthStr != "" {
+		parsed, err := strconv.Atoi(depthStr)
+		if err != nil || parsed < 1 || parsed > indexer.MaxReconcileDepth {
+			s.writeValidationError(w, "Invalid depth parameter", fmt.Sprintf("depth must be between 1 and %d", indexer.MaxReconcileDepth))
+			return
+		}
+		depth = parsed
+	}
+
+	report, err := s.indexer.Reconcile(r.Context(), wallet.String(), depth)
+	if err != nil {
+		logger := s.logger.WithWalletAddress(string(wallet))
+		switch {
+		case errors.Is(err, store.ErrWalletNotTracked):
+			s.writeNotFoundError(w, "Tracked wallet")
+		case isNetworkError(err):
+			logger.LogExternalAPIError(r.Context(), "trade-service", "ReconcileWallet", err, 0)
+			s.writeNetworkError(w, err.Error())
+		default:
+			logger.LogHandlerError(r.Context(), "reconcile_wallet", err)
+			s.writeInternalError(w, err.Error())
+		}
+		return
+	}
+
+	s.writeJSONSuccess(w, report)
+}
+
+// handleReconcileReports lists the latest reconciliation of every wallet
+// @Summary Latest trade index reconciliations
+// @Description List the latest reconciliation report of every wallet, from the background job (INDEXER_RECONCILE_INTERVAL_SEC) or on-demand runs, kept in memory since startup. Requires an admin bearer token, and a request signature as for /admin/audit when a signing key is set.
+// @Tags admin
+// @Security AdminBearer
+// @Produce json
+// @Success 200 {object} server.ReconcileReportsResponse "Latest reports"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or signature"
+// @Router /admin/reconcile [get]
+func (s *Server) handleReconcileReports(w http.ResponseWriter, r *http.Request) {
+	reports := s.indexer.ReconcileReports()
+	drifted := 0
+	for _, report := range reports {
+		if report.Drift {
+			drifted++
+		}
+	}
+
+	s.writeJSONSuccess(w, ReconcileReportsResponse{
+		Reports:   reports,
+		Count:     len(reports),
+		Drifted:   drifted,
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// handleDebugStats returns a runtime snapshot for performance debugging
+// @Summary Runtime diagnostics
+// @Description Goroutine count, heap and GC statistics, in-memory cache sizes, tracked wallets, and WebSocket and SSE stream subscription counts. Only served when DEBUG_ENDPOINTS_ENABLED is true, and requires an admin bearer token. pprof profiles are served under /debug/pprof/ and expvar under /debug/vars with the same guard; keep profile durations under the 30 second write timeout.
+// @Tags admin
+// @Security AdminBearer
+// @Produce json
+// @Success 200 {object} server.DebugStatsResponse "Runtime diagnostics"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token"
+// @Router /debug/stats [get]
+func (s *Server) handleDebugStats(w http.ResponseWriter, r *http.Request) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	response := DebugStatsResponse{
+		GoVersion:       runtime.Version(),
+		Goroutines:      runtime.NumGoroutine(),
+		CPUs:            runtime.NumCPU(),
+		HeapAllocBytes:  memStats.HeapAlloc,
+		HeapInuseBytes:  memStats.HeapInuse,
+		HeapObjects:     memStats.HeapObjects,
+		SysBytes:        memStats.Sys,
+		NumGC:           memStats.NumGC,
+		GCPauseTotalMs:  float64(memStats.PauseTotalNs) / float64(time.Millisecond),
+		GCCPUFraction:   memStats.GCCPUFraction,
+		TotalAllocBytes: memStats.TotalAlloc,
+		TrackedWallets:  len(s.store.ListWallets()),
+		Caches: DebugCacheSizes{
+			BlockTimes:           s.solanaService.GetHTTPClient().BlockTimeCacheSize(),
+			Accounts:             s.solanaService.GetHTTPClient().AccountCacheSize(),
+			PriceWindow:          len(s.priceService.GetDexScreenerClient().PriceWindow()),
+			FirstActivityWallets: len(s.tradeService.FirstActivities()),
+			FXRates:              s.fx.CacheSize(),
+		},
+		Streams:   s.streams.Stats(),
+		Timestamp: getCurrentTimestamp(),
+	}
+	if wsClient := s.solanaService.GetWSClient(); wsClient != nil {
+		stats := wsClient.Stats()
+		response.WebSocket = &stats
+	}
+
+	s.writeJSONSuccess(w, response)
+}
+
+// handleListWallets returns the tenant's wallets registered for background indexing
+// @Summary List tracked wallets
+// @Description List wallets registered for background balance snapshots and trade indexing with their status (active, paused or error). When tenants are configured only the wallets of the X-API-Key's tenant are listed.
+// @Tags watchlist
+// @Security TenantAPIKey
+// @Param status query string false "Only return wallets with this status (active, paused, error)"
+// @Produce json
+// @Success 200 {object} server.TrackedWalletsResponse "Tracked wallets"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Router /wallets [get]
+func (s *Server) handleListWallets(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", store.WalletStatusActive, store.WalletStatusPaused, store.WalletStatusError:
+	default:
+		s.writeValidationError(w, "Invalid status parameter", "status must be active, paused or error")
+		return
+	}
+
+	wallets := make([]store.TrackedWallet, 0)
+	for _, wallet := range s.store.ListTenantWallets(requestTenant(r)) {
+		if status == "" || wallet.Status == status {
+			wallets = append(wallets, wallet)
+		}
+	}
+
+	s.writeJSONSuccess(w, TrackedWalletsResponse{
+		Wallets:   wallets,
+		Count:     len(wallets),
+		Timestamp: getCurrentTimestamp(),
+	})
+}
+
+// handleTrackWallet registers a wallet for background indexing
+// @Summary Track a wallet
+// @Description Register a wallet for periodic balance snapshots and trade indexing. Returns 201 when newly tracked and 200 when it was already tracked. Re-tracking a paused wallet reactivates it and resumes indexing from its stored cursor instead of backfilling again. Each tenant keeps its own registration and label; a tenant may keep at most TENANT_MAX_WALLETS wallets active.
+// @Tags watchlist
+// @Security TenantAPIKey
+// @Accept json
+// @Param request body server.TrackWalletRequest true "Wallet to track"
+// @Produce json
+// @Success 200 {object} server.TrackWalletResponse "Wallet already tracked or reactivated"
+// @Success 201 {object} server.TrackWalletResponse "Wallet tracked"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 403 {object} server.ErrorResponse "Tenant wallet quota exceeded"
+// @Failure 413 {object} server.ErrorResponse "Request body too large"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /wallets [post]
+func (s *Server) handleTrackWallet(w http.ResponseWriter, r *http.Request) {
+	var req TrackWalletRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.LogParsingError(r.Context(), "track_wallet", "request_body", err)
+		s.writeBodyDecodeError(w, err)
+		return
+	}
+
+	tenant := requestTenant(r)
+	if address, err := tokens.SanitizeAddress(req.Address); err == nil && s.walletQuotaExceeded(tenant, address.String()) {
+		s.writeQuotaExceededError(w, "tracked wallets", s.tenants.MaxWallets)
+		return
+	}
+
+	result, err := s.indexer.Track(tenant, req.Address, req.Label)
+	if err != nil {
+		if isValidationError(err) {
+			s.logger.LogValidationError(r.Context(), "track_wallet", "address", req.Address, err)
+			s.writeValidationError(w, "Invalid wallet address", err.Error())
+			return
+		}
+		s.logger.LogHandlerError(r.Context(), "track_wallet", err)
+		s.writeInternalError(w, "Failed to track wallet")
+		return
+	}
+
+	statusCode := http.StatusOK
+	if result.Created {
+		statusCode = http.StatusCreated
+	}
+	s.writeJSONSuccessWithCode(w, statusCode, TrackWalletResponse{
+		Wallet:      result.Wallet,
+		Created:     result.Created,
+		Reactivated: result.Reactivated,
+	})
+}
+
+// handleUntrackWallet stops indexing a wallet without deleting its history
+// @Summary Untrack a wallet
+// @Description Pause background indexing for a wallet. Snapshots, indexed trades and the sync cursor are kept; tracking the wallet again resumes from the cursor. Only the tenant's own registration is paused; the wallet keeps being indexed while another tenant tracks it.
+// @Tags watchlist
+// @Security TenantAPIKey
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Produce json
+// @Success 200 {object} store.TrackedWallet "Paused wallet"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Wallet not tracked"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /wallets/{address} [delete]
+func (s *Server) handleUntrackWallet(w http.ResponseWriter, r *http.Request) {
+	address := walletAddress(r).String()
+
+	wallet, err := s.indexer.Untrack(requestTenant(r), address)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrWalletNotTracked):
+			s.writeNotFoundError(w, "Tracked wallet")
+		case isValidationError(err):
+			s.logger.LogValidationError(r.Context(), "untrack_wallet", "address", address, err)
+			s.writeValidationError(w, "Invalid wallet address", err.Error())
+		default:
+			s.logger.LogHandlerError(r.Context(), "untrack_wallet", err)
+			s.writeInternalError(w, "Failed to untrack wallet")
+		}
+		return
+	}
+
+	s.writeJSONSuccess(w, wallet)
+}
+
+// handleWalletSyncStatus reports how complete a tracked wallet's trade index is
+// @Summary Wallet sync status
+// @Description Report the wallet's tracking status, its sync cursor and the transactions that could not be fetched while its trades were indexed. Pending transactions are refetched with exponential backoff at the end of sync cycles (INDEXER_RETRY_BACKOFF_SEC, doubling); after INDEXER_RETRY_MAX_ATTEMPTS failed fetches they are listed under failed, and their trades, if any, are missing from the index. Only the most recent INDEXER_RETRY_MAX_FAILED failed transactions are kept. complete is true once the wallet was synced with nothing pending or failed.
+// @Tags watchlist
+// @Security TenantAPIKey
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Produce json
+// @Success 200 {object} indexer.SyncStatus "Sync status"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Wallet not tracked"
+// @Router /wallets/{address}/sync [get]
+func (s *Server) handleWalletSyncStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.indexer.SyncStatus(requestTenant(r), walletAddress(r).String())
+	if err != nil {
+		if errors.Is(err, store.ErrWalletNotTracked) {
+			s.writeNotFoundError(w, "Tracked wallet")
+			return
+		}
+		s.logger.LogHandlerError(r.Context(), "wallet_sync_status", err)
+		s.writeInternalError(w, "Failed to read sync status")
+		return
+	}
+
+	s.writeJSONSuccess(w, status)
+}
+
+// handleSetWalletDigest opts a tracked wallet in to periodic change digests
+// @Summary Subscribe a wallet to digests
+// @Description Send a daily or weekly digest of the wallet's net worth change, executed trades and sHYUSD yield. Digests go out at the configured hour in the wallet's time zone as wallet.digest webhook events and to the configured Telegram chat and email recipients; digests of a tenant's wallets only go to that tenant's webhooks. Updating an existing subscription keeps its last reported period.
+// @Tags watchlist
+// @Security TenantAPIKey
+// @Accept json
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Param request body server.DigestRequest true "Digest schedule"
+// @Produce json
+// @Success 200 {object} store.TrackedWallet "Wallet with its digest preference"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Wallet not tracked"
+// @Failure 413 {object} server.ErrorResponse "Request body too large"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /wallets/{address}/digest [put]
+func (s *Server) handleSetWalletDigest(w http.ResponseWriter, r *http.Request) {
+	address := walletAddress(r).String()
+
+	var req DigestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.LogParsingError(r.Context(), "set_wallet_digest", "request_body", err)
+		s.writeBodyDecodeError(w, err)
+		return
+	}
+
+	wallet, err := digest.OptIn(s.store, requestTenant(r), address, req.Frequency, req.Timezone, time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrWalletNotTracked):
+			s.writeNotFoundError(w, "Tracked wallet")
+		case isValidationError(err):
+			s.logger.LogValidationError(r.Context(), "set_wallet_digest", "request", req, err)
+			s.writeValidationError(w, "Invalid digest preference", err.Error())
+		default:
+			s.logger.LogHandlerError(r.Context(), "set_wallet_digest", err)
+			s.writeInternalError(w, "Failed to update digest preference")
+		}
+		return
+	}
+
+	s.writeJSONSuccess(w, wallet)
+}
+
+// handleDeleteWalletDigest opts a tracked wallet out of digests
+// @Summary Unsubscribe a wallet from digests
+// @Description Stop sending change digests for the wallet. Tracking and indexing are unaffected.
+// @Tags watchlist
+// @Security TenantAPIKey
+// @Param address path string true "Wallet address (base58 encoded)"
+// @Produce json
+// @Success 200 {object} store.TrackedWallet "Wallet without a digest preference"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Wallet not tracked"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /wallets/{address}/digest [delete]
+func (s *Server) handleDeleteWalletDigest(w http.ResponseWriter, r *http.Request) {
+	address := walletAddress(r).String()
+
+	wallet, err := digest.OptOut(s.store, requestTenant(r), address)
+	if err != nil {
+		if errors.Is(err, store.ErrWalletNotTracked) {
+			s.writeNotFoundError(w, "Tracked wallet")
+			return
+		}
+		s.logger.LogHandlerError(r.Context(), "delete_wallet_digest", err)
+		s.writeInternalError(w, "Failed to remove digest preference")
+		return
+	}
+
+	s.writeJSONSuccess(w, wallet)
+}
+
+// handleWatchlistSummary aggregates holdings and activity across the tenant's tracked wallets
+// @Summary Watchlist summary
+// @Description Totals across the tenant's tracked wallets (all of them when tenants are not configured) computed from stored snapshots and the trade index (no live RPC calls): combined holdings and USD value per token, trade count and volume within the window, and the wallets with the largest USD change.
+// @Tags watchlist
+// @Param top query int false "Number of top movers to return (1-50, default 5)"
+// @Param window_hours query int false "Lookback window in hours for trades and movers (default 24)"
+// @Security TenantAPIKey
+// @Produce json
+// @Success 200 {object} watchlist.Summary "Watchlist summary"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Router /watchlist/summary [get]
+func (s *Server) handleWatchlistSummary(w http.ResponseWriter, r *http.Request) {
+	topN := watchlist.DefaultTopN
+	if topStr := r.URL.Query().Get("top"); topStr != "" {
+		parsed, err := strconv.Atoi(topStr)
+		if err != nil || parsed < 1 || parsed > watchlist.MaxTopN {
+			s.writeValidationError(w, "Invalid top parameter", fmt.Sprintf("top must be between 1 and %d", watchlist.MaxTopN))
+			return
+		}
+		topN = parsed
+	}
+
+	window := watchlist.DefaultWindow
+	if windowStr := r.URL.Query().Get("window_hours"); windowStr != "" {
+		hours, err := strconv.Atoi(windowStr)
+		if err != nil || hours < 1 {
+			s.writeValidationError(w, "Invalid window_hours parameter", "window_hours must be a positive integer")
+			return
+		}
+		window = time.Duration(hours) * time.Hour
+	}
+
+	s.writeJSONSuccess(w, watchlist.Summarize(s.store, requestTenant(r), time.Now().UTC(), window, topN))
+}
+
+// handleLeaderboard ranks tracked wallets by realized P&L, volume or xSOL balance
+// @Summary Tracked wallet leaderboard
+// @Description Top wallets tracked by the tenant by the chosen metric, computed from stored snapshots and the trade index (no live RPC calls). realized_pnl is the USD realized by xSOL sells in the window against each wallet's average buy cost over its whole trade index; volume is the USD value of xSOL bought and sold in the window; xsol_balance is the xSOL held in the latest snapshot regardless of window. Trades are valued at their historical price when known, otherwise at the xSOL price of the nearest earlier snapshot. anonymize truncates addresses and hides labels for public display.
+// @Tags watchlist
+// @Param metric query string false "Ranking metric: realized_pnl, volume or xsol_balance (default realized_pnl)"
+// @Param window_hours query int false "Lookback window in hours for P&L and volume; 0 for all indexed history (default 168)"
+// @Param top query int false "Number of wallets to return (1-100, default 10)"
+// @Param anonymize query bool false "Truncate wallet addresses and omit labels (default false)"
+// @Produce json
+// @Security TenantAPIKey
+// @Success 200 {object} watchlist.Leaderboard "Leaderboard"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Router /leaderboard [get]
+func (s *Server) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	metric := watchlist.MetricRealizedPnL
+	if value := query.Get("metric"); value != "" {
+		metric = value
+	}
+	if err := watchlist.ValidateMetric(metric); err != nil {
+		s.writeValidationError(w, "Invalid metric parameter", err.Error())
+		return
+	}
+
+	window := watchlist.DefaultLeaderboardWindow
+	if windowStr := query.Get("window_hours"); windowStr != "" {
+		hours, err := strconv.Atoi(windowStr)
+		if err != nil || hours < 0 {
+			s.writeValidationError(w, "Invalid window_hours parameter", "window_hours must be a non-negative integer")
+			return
+		}
+		window = time.Duration(hours) * time.Hour
+	}
+
+	topN := watchlist.DefaultLeaderboardTopN
+	if topStr := query.Get("top"); topStr != "" {
+		parsed, err := strconv.Atoi(topStr)
+		if err != nil || parsed < 1 || parsed > watchlist.MaxLeaderboardTopN {
+			s.writeValidationError(w, "Invalid top parameter", fmt.Sprintf("top must be between 1 and %d", watchlist.MaxLeaderboardTopN))
+			return
+		}
+		topN = parsed
+	}
+
+	anonymize := false
+	if anonymizeStr := query.Get("anonymize"); anonymizeStr != "" {
+		parsed, err := strconv.ParseBool(anonymizeStr)
+		if err != nil {
+			s.writeValidationError(w, "Invalid anonymize parameter", "anonymize must be true or false")
+			return
+		}
+		anonymize = parsed
+	}
+
+	board, err := watchlist.Rank(s.store, requestTenant(r), metric, time.Now().UTC(), window, topN, anonymize)
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "leaderboard", err)
+		s.writeInternalError(w, "Failed to compute leaderboard")
+		return
+	}
+	s.writeJSONSuccess(w, board)
+}
+
+// handleListPortfolios lists the tenant's wallet portfolios
+// @Summary List portfolios
+// @Description List the tenant's named wallet groups, oldest first.
+// @Tags portfolios
+// @Security TenantAPIKey
+// @Produce json
+// @Success 200 {object} server.PortfoliosResponse "Portfolios"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Router /portfolios [get]
+func (s *Server) handleListPortfolios(w http.ResponseWriter, r *http.Request) {
+	portfolios := s.store.ListPortfolios(requestTenant(r))
+	s.writeJSONSuccess(w, PortfoliosResponse{
+		Portfolios: portfolios,
+		Count:      len(portfolios),
+		Timestamp:  getCurrentTimestamp(),
+	})
+}
+
+// handleCreatePortfolio defines a named group of wallets
+// @Summary Create a portfolio
+// @Description Define a named group of wallets whose balances, trades and P&L are reported together under /portfolios/{id}/summary. Addresses are normalized and de-duplicated. Members that are not tracked yet are registered for background indexing under the tenant; paused members are reactivated. Counts against the TENANT_MAX_PORTFOLIOS and TENANT_MAX_WALLETS quotas.
+// @Tags portfolios
+// @Security TenantAPIKey
+// @Accept json
+// @Param request body server.CreatePortfolioRequest true "Portfolio definition"
+// @Produce json
+// @Success 201 {object} server.PortfolioCreatedResponse "Portfolio created"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 403 {object} server.ErrorResponse "Tenant portfolio or wallet quota exceeded"
+// @Failure 413 {object} server.ErrorResponse "Request body too large"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /portfolios [post]
+func (s *Server) handleCreatePortfolio(w http.ResponseWriter, r *http.Request) {
+	var req CreatePortfolioRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.LogParsingError(r.Context(), "create_portfolio", "request_body", err)
+		s.writeBodyDecodeError(w, err)
+		return
+	}
+
+	tenant := requestTenant(r)
+	portfolio, err := watchlist.NewPortfolio(tenant, req.Name, req.Wallets, time.Now())
+	if err != nil {
+		if isValidationError(err) {
+			s.writeValidationError(w, "Invalid portfolio", err.Error())
+			return
+		}
+		s.logger.LogHandlerError(r.Context(), "create_portfolio", err)
+		s.writeInternalError(w, "Failed to create portfolio")
+		return
+	}
+	if s.portfolioQuotaExceeded(tenant) {
+		s.writeQuotaExceededError(w, "portfolios", s.tenants.MaxPortfolios)
+		return
+	}
+	if s.walletQuotaExceeded(tenant, portfolio.Wallets...) {
+		s.writeQuotaExceededError(w, "tracked wallets", s.tenants.MaxWallets)
+		return
+	}
+
+	// Members are indexed like any tracked wallet so the summary has snapshots and trades to aggregate
+	tracked := make([]string, 0)
+	for _, address := range portfolio.Wallets {
+		result, err := s.indexer.Track(tenant, address, "")
+		if err != nil {
+			s.logger.LogHandlerError(r.Context(), "create_portfolio", err)
+			s.writeInternalError(w, "Failed to track portfolio wallets")
+			return
+		}
+		if result.Created || result.Reactivated {
+			tracked = append(tracked, address)
+		}
+	}
+
+	if err := s.store.SavePortfolio(portfolio); err != nil {
+		s.logger.LogHandlerError(r.Context(), "create_portfolio", err)
+		s.writeInternalError(w, "Failed to create portfolio")
+		return
+	}
+
+	s.writeJSONSuccessWithCode(w, http.StatusCreated, PortfolioCreatedResponse{
+		Portfolio: portfolio,
+		Tracked:   tracked,
+	})
+}
+
+// handleGetPortfolio returns a portfolio definition
+// @Summary Get a portfolio
+// @Tags portfolios
+// @Security TenantAPIKey
+// @Param id path string true "Portfolio ID"
+// @Produce json
+// @Success 200 {object} store.Portfolio "Portfolio"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Portfolio not found"
+// @Router /portfolios/{id} [get]
+func (s *Server) handleGetPortfolio(w http.ResponseWriter, r *http.Request) {
+	portfolio, ok := s.store.GetPortfolio(requestTenant(r), chi.URLParam(r, "id"))
+	if !ok {
+		s.writeNotFoundError(w, "Portfolio")
+		return
+	}
+	s.writeJSONSuccess(w, portfolio)
+}
+
+// handleDeletePortfolio removes a portfolio definition
+// @Summary Delete a portfolio
+// @Description Remove a wallet group. Member wallets stay tracked; untrack them under /wallets if they are no longer needed.
+// @Tags portfolios
+// @Security TenantAPIKey
+// @Param id path string true "Portfolio ID"
+// @Success 204 "Portfolio deleted"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Portfolio not found"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /portfolios/{id} [delete]
+func (s *Server) handleDeletePortfolio(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.DeletePortfolio(requestTenant(r), chi.URLParam(r, "id")); err != nil {
+		if errors.Is(err, store.ErrPortfolioNotFound) {
+			s.writeNotFoundError(w, "Portfolio")
+			return
+		}
+		s.logger.LogHandlerError(r.Context(), "delete_portfolio", err)
+		s.writeInternalError(w, "Failed to delete portfolio")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePortfolioSummary aggregates balances, trades and P&L across a portfolio's wallets
+// @Summary Portfolio summary
+// @Description Totals across the portfolio's wallets computed from stored snapshots and the trade index (no live RPC calls): combined holdings and USD value per token, trade count and volume within the window, the wallets with the largest USD change, and realized P&L per wallet and in total. P&L uses the same average-cost method as /leaderboard. jitoSOL held by the wallets is converted to SOL at the Sanctum stake pool rate (or its market rate when Sanctum is unavailable) and valued in USD; lst_exposure reports the rate applied, and the value is included in the holding, wallet and portfolio totals.
+// @Tags portfolios
+// @Security TenantAPIKey
+// @Param id path string true "Portfolio ID"
+// @Param top query int false "Number of top movers to return (1-50, default 5)"
+// @Param window_hours query int false "Lookback window in hours for trades, movers and P&L (default 24)"
+// @Produce json
+// @Success 200 {object} watchlist.PortfolioSummary "Portfolio summary"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Portfolio not found"
+// @Router /portfolios/{id}/summary [get]
+func (s *Server) handlePortfolioSummary(w http.ResponseWriter, r *http.Request) {
+	portfolio, ok := s.store.GetPortfolio(requestTenant(r), chi.URLParam(r, "id"))
+	if !ok {
+		s.writeNotFoundError(w, "Portfolio")
+		return
+	}
+
+	topN := watchlist.DefaultTopN
+	if topStr := r.URL.Query().Get("top"); topStr != "" {
+		parsed, err := strconv.Atoi(topStr)
+		if err != nil || parsed < 1 || parsed > watchlist.MaxTopN {
+			s.writeValidationError(w, "Invalid top parameter", fmt.Sprintf("top must be between 1 and %d", watchlist.MaxTopN))
+			return
+		}
+		topN = parsed
+	}
+
+	window := watchlist.DefaultWindow
+	if windowStr := r.URL.Query().Get("window_hours"); windowStr != "" {
+		hours, err := strconv.Atoi(windowStr)
+		if err != nil || hours < 1 {
+			s.writeValidationError(w, "Invalid window_hours parameter", "window_hours must be a positive integer")
+			return
+		}
+		window = time.Duration(hours) * time.Hour
+	}
+
+	summary := watchlist.SummarizePortfolio(s.store, portfolio, time.Now().UTC(), window, topN)
+	if summary.Holdings[tokens.JitoSOLSymbol].Amount > 0 {
+		// Snapshots carry no jitoSOL price; without a conversion it is listed at its raw amount only
+		conversion, err := s.priceService.GetLSTConversion(r.Context(), s.tokenService.GetConfig().JitoSOLMint)
+		if err != nil {
+			s.logger.LogExternalAPIError(r.Context(), "lst-rates", "GetLSTConversion", err, 0,
+				slog.String("portfolio", portfolio.ID))
+		} else {
+			summary.ApplyLSTConversion(s.store, tokens.JitoSOLSymbol, conversion)
+		}
+	}
+
+	s.writeJSONSuccess(w, summary)
+}
+
+// handleCreateWebhook registers a webhook subscription
+// @Summary Create webhook subscription
+// @Description Register an endpoint for event notifications. Each delivery is POSTed with X-Hylo-Delivery-Id (stable across retries), X-Hylo-Event and X-Hylo-Signature (t=<unix>,v1=<hex HMAC-SHA256 of "<t>.<body>">). Failed deliveries are retried with exponential backoff and then listed under /webhooks/{id}/failures. The signing secret is only returned in this response. Requires a tenant API key, whose subscriptions receive protocol events and events of the tenant's tracked wallets, or an admin bearer token, whose subscriptions receive every event. A tenant may hold at most TENANT_MAX_WEBHOOKS subscriptions.
+// @Tags webhooks
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Accept json
+// @Param request body webhook.SubscriptionRequest true "Subscription parameters"
+// @Produce json
+// @Success 201 {object} server.WebhookCreatedResponse "Subscription created"
+// @Failure 400 {object} server.ErrorResponse "Validation error"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or tenant API key"
+// @Failure 403 {object} server.ErrorResponse "Tenant webhook quota exceeded"
+// @Failure 413 {object} server.ErrorResponse "Request body too large"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /webhooks [post]
+func (s *Server) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhook.SubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.LogParsingError(r.Context(), "create_webhook", "request_body", err)
+		s.writeBodyDecodeError(w, err)
+		return
+	}
+
+	tenant := requestTenant(r)
+	if s.webhookQuotaExceeded(tenant) {
+		s.writeQuotaExceededError(w, "webhook subscriptions", s.tenants.MaxWebhooks)
+		return
+	}
+
+	sub, err := s.webhooks.Subscribe(tenant, req)
+	if err != nil {
+		if isValidationError(err) {
+			s.logger.LogValidationError(r.Context(), "create_webhook", "request", req, err)
+			s.writeValidationError(w, "Invalid webhook subscription", err.Error())
+			return
+		}
+		s.logger.LogHandlerError(r.Context(), "create_webhook", err)
+		s.writeInternalError(w, "Failed to create webhook subscription")
+		return
+	}
+
+	s.writeJSONSuccessWithCode(w, http.StatusCreated, WebhookCreatedResponse{Subscription: *sub, Secret: sub.Secret})
+}
+
+// handleListWebhooks lists webhook subscriptions
+// @Summary List webhook subscriptions
+// @Description List registered webhook subscriptions (secrets are not included). A tenant API key lists the tenant's subscriptions; an admin bearer token lists the operator's.
+// @Tags webhooks
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Produce json
+// @Success 200 {object} server.WebhooksResponse "Subscriptions"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or tenant API key"
+// @Router /webhooks [get]
+func (s *Server) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs := s.webhooks.Subscriptions(requestTenant(r))
+	s.writeJSONSuccess(w, WebhooksResponse{
+		Subscriptions: subs,
+		Count:         len(subs),
+		Timestamp:     getCurrentTimestamp(),
+	})
+}
+
+// handleDeleteWebhook removes a webhook subscription
+// @Summary Delete webhook subscription
+// @Description Remove a subscription and its dead-letter list. Requires the owning tenant's API key, or an admin bearer token for operator subscriptions.
+// @Tags webhooks
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Param id path string true "Subscription ID"
+// @Success 204 "Subscription deleted"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Subscription not found"
+// @Failure 503 {object} server.ErrorResponse "Maintenance mode: writes are disabled"
+// @Router /webhooks/{id} [delete]
+func (s *Server) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if !s.webhooks.Unsubscribe(requestTenant(r), chi.URLParam(r, "id")) {
+		s.writeNotFoundError(w, "Webhook subscription")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleWebhookFailures returns dead-lettered deliveries for a subscription
+// @Summary List failed webhook deliveries
+// @Description List deliveries that exhausted their retries or were rejected by the subscriber (newest first), so consumers can reconcile missed events. Requires the owning tenant's API key, or an admin bearer token for operator subscriptions.
+// @Tags webhooks
+// @Security AdminBearer
+// @Security TenantAPIKey
+// @Param id path string true "Subscription ID"
+// @Produce json
+// @Success 200 {object} server.WebhookFailuresResponse "Dead-lettered deliveries"
+// @Failure 401 {object} server.ErrorResponse "Missing or invalid admin token or tenant API key"
+// @Failure 404 {object} server.ErrorResponse "Subscription not found"
+// @Router /webhooks/{id}/failures [get]
+func (s *Server) handleWebhookFailures(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	failures, ok := s.webhooks.Failures(requestTenant(r), id)
+	if !ok {
+		s.writeNotFoundError(w, "Webhook subscription")
+		return
+	}
+
+	s.writeJSONSuccess(w, WebhookFailuresResponse{
+		SubscriptionID: id,
+		Failures:       failures,
+		Count:          len(failures),
+		Timestamp:      getCurrentTimestamp(),
+	})
 }
@@ -1,18 +1,36 @@
 package server
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/casing"
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/fieldset"
+	"hylo-wallet-tracker-api/internal/fx"
+	"hylo-wallet-tracker-api/internal/watchlist"
 )
 
 // Enhanced Helper Functions for consistent response handling
 
-// writeJSONError writes a structured error response with categorization
+// writeJSONError writes a structured error response with categorization, in the negotiated format
 func (s *Server) writeJSONError(w http.ResponseWriter, statusCode int, message string, details string, code string) {
-	w.Header().Set("Content-Type", "application/json")
+	format := formatOf(w)
+	w.Header().Set("Content-Type", format.ContentType())
 	w.WriteHeader(statusCode)
 
+	if profileOf(format).envelope {
+		format.Encode(w, Envelope{
+			Meta:  envelopeMeta(w, statusCode),
+			Error: &EnvelopeError{Message: message, Details: details, Code: code},
+		})
+		return
+	}
+
 	response := ErrorResponse{
 		BaseResponse: BaseResponse{
 			Timestamp: getCurrentTimestamp(),
@@ -23,7 +41,7 @@ func (s *Server) writeJSONError(w http.ResponseWriter, statusCode int, message s
 		Code:    code,
 	}
 
-	json.NewEncoder(w).Encode(response)
+	format.Encode(w, response)
 }
 
 // writeJSONSuccess writes a structured success response
@@ -32,13 +50,113 @@ func (s *Server) writeJSONSuccess(w http.ResponseWriter, data interface{}) {
 }
 
 // writeJSONSuccessWithCode writes a success response with custom status code
+// The body is JSON unless the client negotiated MessagePack through the Accept header, and is wrapped
+// in an Envelope when the envelope profile was negotiated
 func (s *Server) writeJSONSuccessWithCode(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
+	format := formatOf(w)
+	w.Header().Set("Content-Type", format.ContentType())
 	w.WriteHeader(statusCode)
 
+	if profileOf(format).envelope {
+		format.Encode(w, Envelope{Data: data, Meta: envelopeMeta(w, statusCode)})
+		return
+	}
+
 	// For backward compatibility, return data directly (not wrapped in SuccessResponse)
 	// This maintains current API response formats for /wallet/{address}/balances
-	json.NewEncoder(w).Encode(data)
+	format.Encode(w, data)
+}
+
+// parseFields reads the fields query parameter for an endpoint whose response lists items in collection
+// response is a typed nil of the response type. A 400 is written and false returned for unknown fields
+func (s *Server) parseFields(w http.ResponseWriter, r *http.Request, response interface{}, collection string) (fieldset.Set, bool) {
+	fields := fieldset.Parse(r.URL.Query().Get("fields"))
+	if err := fieldset.Validate(response, collection, fields); err != nil {
+		s.writeValidationError(w, "Invalid fields parameter", err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// writeJSONFields writes a success response with each item of collection trimmed to fields
+// Under a converted field naming the data is rendered first, so the trimmed items keep the converted names
+func (s *Server) writeJSONFields(w http.ResponseWriter, r *http.Request, data interface{}, collection string, fields fieldset.Set) {
+	if naming := profileOf(formatOf(w)).naming; naming != casing.StyleDeclared && fields != nil {
+		selected, err := fieldset.Select(data, collection, fields)
+		if err != nil {
+			s.logger.LogHandlerError(r.Context(), "project_fields", err)
+			s.writeInternalError(w, err.Error())
+			return
+		}
+		if rendered, ok := casing.Render(data, naming).(casing.Object); ok {
+			s.writeJSONSuccess(w, rendered.Project(collection, selected))
+			return
+		}
+	}
+
+	projected, err := fieldset.Project(data, collection, fields)
+	if err != nil {
+		s.logger.LogHandlerError(r.Context(), "project_fields", err)
+		s.writeInternalError(w, err.Error())
+		return
+	}
+	s.writeJSONSuccess(w, projected)
+}
+
+// parseCurrency resolves the optional currency parameter to a USD conversion; nil keeps amounts in USD
+// A 400 is written for unknown currencies and a 502 when the rate cannot be fetched; false is returned either way
+func (s *Server) parseCurrency(w http.ResponseWriter, r *http.Request) (*fx.Conversion, bool) {
+	currency := r.URL.Query().Get("currency")
+	if currency == "" {
+		return nil, true
+	}
+
+	conversion, err := s.fx.Conversion(r.Context(), currency)
+	if err != nil {
+		if errors.Is(err, fx.ErrInvalidCurrency) || errors.Is(err, fx.ErrUnsupportedCurrency) {
+			s.writeValidationError(w, "Invalid currency parameter", err.Error())
+			return nil, false
+		}
+		s.logger.LogExternalAPIError(r.Context(), fx.Source, "conversion", err, 0)
+		s.writeNetworkError(w, fmt.Sprintf("failed to fetch %s exchange rate: %v", currency, err))
+		return nil, false
+	}
+	return conversion, true
+}
+
+// parseExplorer resolves the optional explorer parameter, defaulting to the configured EXPLORER_PROVIDER
+// A 400 is written for unknown explorers and false returned
+func (s *Server) parseExplorer(w http.ResponseWriter, r *http.Request) (explorer.Provider, bool) {
+	name := r.URL.Query().Get("explorer")
+	if name == "" {
+		return explorer.Default(), true
+	}
+
+	provider, err := explorer.ParseProvider(name)
+	if err != nil {
+		s.writeValidationError(w, "Invalid explorer parameter", err.Error())
+		return "", false
+	}
+	return provider, true
+}
+
+// parseTimezone resolves the optional tz parameter, defaulting to the configured BUCKET_TIMEZONE
+// A 400 is written for unknown zones and malformed offsets and false returned
+func (s *Server) parseTimezone(w http.ResponseWriter, r *http.Request) (*time.Location, bool) {
+	value := r.URL.Query().Get("tz")
+	if value == "" {
+		if s.timezone == nil {
+			return time.UTC, true
+		}
+		return s.timezone, true
+	}
+
+	loc, err := watchlist.ParseTimezone(value)
+	if err != nil {
+		s.writeValidationError(w, "Invalid tz parameter", err.Error())
+		return nil, false
+	}
+	return loc, true
 }
 
 // Specialized error helper functions with predefined categories
@@ -54,6 +172,60 @@ func (s *Server) writeNotFoundError(w http.ResponseWriter, resource string) {
 	s.writeJSONError(w, http.StatusNotFound, message, "", ErrorCodeNotFound)
 }
 
+// writeUnauthorizedError writes an unauthorized error (401) with UNAUTHORIZED code
+func (s *Server) writeUnauthorizedError(w http.ResponseWriter, details string) {
+	s.writeJSONError(w, http.StatusUnauthorized, "Unauthorized", details, ErrorCodeUnauthorized)
+}
+
+// writeQuotaExceededError writes a forbidden error (403) with QUOTA_EXCEEDED code for writes beyond a tenant quota
+func (s *Server) writeQuotaExceededError(w http.ResponseWriter, resource string, limit int) {
+	s.writeJSONError(w, http.StatusForbidden, "Tenant quota exceeded",
+		fmt.Sprintf("tenant may have at most %d %s", limit, resource), ErrorCodeQuotaExceeded)
+}
+
+// writeTimeoutError writes a request timeout error (408) with TIMEOUT code
+func (s *Server) writeTimeoutError(w http.ResponseWriter, details string) {
+	s.writeJSONError(w, http.StatusRequestTimeout, "Request timed out", details, ErrorCodeTimeout)
+}
+
+// writePayloadTooLargeError writes a payload too large error (413) with PAYLOAD_TOO_LARGE code
+func (s *Server) writePayloadTooLargeError(w http.ResponseWriter, maxBytes int64) {
+	s.writeJSONError(w, http.StatusRequestEntityTooLarge, "Request body too large",
+		fmt.Sprintf("request body must not exceed %d bytes", maxBytes), ErrorCodeTooLarge)
+}
+
+// writeOverloadedError writes a service unavailable error (503) with OVERLOADED code and a Retry-After hint
+func (s *Server) writeOverloadedError(w http.ResponseWriter, retryAfter time.Duration, details string) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(retryAfter), 1)))
+	s.writeJSONError(w, http.StatusServiceUnavailable, "Server is overloaded", details, ErrorCodeOverloaded)
+}
+
+// writeRateLimitError writes a too many requests error (429) with RATE_LIMIT code and a Retry-After hint
+func (s *Server) writeRateLimitError(w http.ResponseWriter, retryAfter time.Duration, details string) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(ceilSeconds(retryAfter), 1)))
+	s.writeJSONError(w, http.StatusTooManyRequests, "Rate limit exceeded", details, ErrorCodeRateLimit)
+}
+
+// writeNotConfiguredError writes a service unavailable error (503) for an endpoint missing required configuration
+func (s *Server) writeNotConfiguredError(w http.ResponseWriter, details string) {
+	s.writeJSONError(w, http.StatusServiceUnavailable, "Endpoint is not configured", details, ErrorCodeNotConfigured)
+}
+
+// writeMaintenanceError writes a service unavailable error (503) with MAINTENANCE code for writes rejected in maintenance mode
+func (s *Server) writeMaintenanceError(w http.ResponseWriter, message string) {
+	s.writeJSONError(w, http.StatusServiceUnavailable, "Service is in maintenance mode", message, ErrorCodeMaintenance)
+}
+
+// writeBodyDecodeError writes 413 for bodies cut off by limitBody and 400 for malformed JSON
+func (s *Server) writeBodyDecodeError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		s.writePayloadTooLargeError(w, maxBytesErr.Limit)
+		return
+	}
+	s.writeValidationError(w, "Invalid request body", err.Error())
+}
+
 // writeInternalError writes an internal server error (500) with INTERNAL_ERROR code
 func (s *Server) writeInternalError(w http.ResponseWriter, details string) {
 	s.writeJSONError(w, http.StatusInternalServerError, "Internal server error", details, ErrorCodeInternal)
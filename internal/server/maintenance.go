@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/config"
+)
+
+// maintenanceState is the current read-only mode; replaced as a whole on every switch
+type maintenanceState struct {
+	enabled bool
+	message string
+	since   time.Time
+}
+
+// initMaintenance sets the mode the server starts in
+func (s *Server) initMaintenance(maintenance *config.Maintenance) {
+	if maintenance == nil {
+		maintenance = config.NewMaintenanceFromEnvironment()
+	}
+	s.maintenanceMessage = maintenance.Message
+	s.setMaintenance(maintenance.Enabled, "")
+}
+
+// setMaintenance switches read-only mode; an empty message falls back to the configured one
+func (s *Server) setMaintenance(enabled bool, message string) maintenanceState {
+	if message == "" {
+		message = s.maintenanceMessage
+	}
+	state := maintenanceState{enabled: enabled, message: message}
+	if enabled {
+		state.since = time.Now().UTC()
+		if current := s.maintenance.Load(); current != nil && current.enabled {
+			state.since = current.since
+		}
+	}
+	s.maintenance.Store(&state)
+
+	s.logger.InfoContext(context.Background(), "Maintenance mode updated",
+		slog.Bool("enabled", enabled),
+		slog.String("message", message))
+	return state
+}
+
+// maintenanceStatus returns the current mode as a response
+func (s *Server) maintenanceStatus() MaintenanceStatus {
+	status := MaintenanceStatus{Timestamp: getCurrentTimestamp()}
+	if state := s.maintenance.Load(); state != nil && state.enabled {
+		since := state.since
+		status.Enabled = true
+		status.Message = state.message
+		status.Since = &since
+	}
+	return status
+}
+
+// readOnlyDuringMaintenance rejects every request but GET, HEAD and OPTIONS with 503 while maintenance mode is on
+func (s *Server) readOnlyDuringMaintenance(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if state := s.maintenance.Load(); state != nil && state.enabled {
+				s.writeMaintenanceError(w, state.message)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"hylo-wallet-tracker-api/internal/config"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// bearerPrefix is the Authorization header scheme expected by admin endpoints
+const bearerPrefix = "Bearer "
+
+// adminAuth restricts access to requests bearing ADMIN_API_KEY; admin endpoints are disabled when it is unset
+func (s *Server) adminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.adminAPIKey == "" {
+			s.writeUnauthorizedError(w, "admin endpoints are disabled: ADMIN_API_KEY is not configured")
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.adminAPIKey)) != 1 {
+			s.writeUnauthorizedError(w, "missing or invalid admin bearer token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminSignature verifies the timestamped request signature configured by ADMIN_SIGNING_SECRET or ADMIN_SIGNING_PUBLIC_KEY
+// The body is read (up to maxBytes) to check the signature and then replayed to the handler; it is a no-op when signing is disabled
+func (s *Server) adminSignature(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !s.adminSigning.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+			if err != nil {
+				s.writeBodyDecodeError(w, err)
+				return
+			}
+
+			err = s.adminSigning.Verify(r.Method, r.URL.RequestURI(),
+				r.Header.Get(config.HeaderAdminTimestamp), r.Header.Get(config.HeaderAdminSignature), body, time.Now())
+			if err != nil {
+				s.logger.WarnContext(r.Context(), "Rejected admin request signature",
+					slog.String("path", r.URL.Path),
+					slog.String("error", err.Error()))
+				s.writeUnauthorizedError(w, err.Error())
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routeTimeout bounds a handler with a context deadline and answers 408 if it has not finished in time
+// Responses are buffered so a late handler cannot write after the timeout response
+func (s *Server) routeTimeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutWriter{header: make(http.Header), format: formatOf(w)}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+				tw.flushTo(w)
+			case <-ctx.Done():
+				tw.mu.Lock()
+				tw.timedOut = true
+				tw.mu.Unlock()
+
+				s.logger.WarnContext(r.Context(), "Request timed out",
+					slog.String("path", r.URL.Path),
+					slog.Duration("timeout", timeout))
+				s.writeTimeoutError(w, fmt.Sprintf("request did not complete within %s", timeout))
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers a handler's response until it completes
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	status   int
+	timedOut bool
+	format   responseFormat // Negotiated by the outer writer, which the buffer hides from the helpers
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) negotiatedFormat() responseFormat {
+	return tw.format
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.body.Write(p)
+}
+
+func (tw *timeoutWriter) WriteHeader(statusCode int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.status != 0 {
+		return
+	}
+	tw.status = statusCode
+}
+
+func (tw *timeoutWriter) flushTo(w http.ResponseWriter) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	for key, values := range tw.header {
+		w.Header()[key] = values
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	w.WriteHeader(tw.status)
+	w.Write(tw.body.Bytes())
+}
+
+// limitBody rejects request bodies larger than maxBytes with 413
+// Declared oversized bodies are rejected up front; chunked bodies fail on read with *http.MaxBytesError
+func (s *Server) limitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				s.writePayloadTooLargeError(w, maxBytes)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// addressContextKey is the request context key for the sanitized {address} route parameter
+type addressContextKey struct{}
+
+// sanitizeAddress validates the {address} route parameter with tokens.SanitizeAddress and rejects bad input
+// with the structured 400 before any handler runs, so no unchecked address reaches RPC or the store
+// The normalized address is stored in the request context (see walletAddress) and tags every record logged
+// while serving the request so it can be found with GET /admin/logs
+func (s *Server) sanitizeAddress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := chi.URLParam(r, "address")
+		address, err := tokens.SanitizeAddress(raw)
+		if err != nil {
+			s.logger.LogValidationError(r.Context(), "path_params", "address", raw, err)
+			s.writeValidationError(w, "Invalid wallet address format", err.Error())
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), addressContextKey{}, address)
+		next.ServeHTTP(w, r.WithContext(logger.WithWallet(ctx, address.String())))
+	})
+}
+
+// walletAddress returns the {address} route parameter normalized by sanitizeAddress
+func walletAddress(r *http.Request) solana.Address {
+	address, _ := r.Context().Value(addressContextKey{}).(solana.Address)
+	return address
+}
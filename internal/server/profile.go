@@ -0,0 +1,96 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"hylo-wallet-tracker-api/internal/casing"
+	"hylo-wallet-tracker-api/internal/config"
+)
+
+// Accept-Profile tokens besides the casing styles; a request may combine one naming and one envelope
+// token, e.g. "Accept-Profile: camelCase, envelope"
+const (
+	profileEnvelope = "envelope"
+	profileBare     = "bare"
+	profileDeclared = "declared"
+)
+
+// responseProfile is the field naming and envelope applied to response bodies
+type responseProfile struct {
+	naming   casing.Style
+	envelope bool
+}
+
+// newResponseProfile converts the configured defaults; nil keeps declared names without an envelope
+func newResponseProfile(cfg *config.ResponseProfile) responseProfile {
+	if cfg == nil {
+		return responseProfile{}
+	}
+	return responseProfile{naming: cfg.Naming, envelope: cfg.Envelope}
+}
+
+func (p responseProfile) isZero() bool {
+	return p == responseProfile{}
+}
+
+// String lists the profile as Accept-Profile tokens, as sent back in Content-Profile
+func (p responseProfile) String() string {
+	naming := string(p.naming)
+	if p.naming == casing.StyleDeclared {
+		naming = profileDeclared
+	}
+	if p.envelope {
+		return naming + ", " + profileEnvelope
+	}
+	return naming + ", " + profileBare
+}
+
+// acceptedProfile applies the tokens of an Accept-Profile header over the defaults
+// Unknown tokens are ignored, so clients asking for a profile the server lacks get the defaults
+func acceptedProfile(header string, defaults responseProfile) responseProfile {
+	profile := defaults
+	for _, token := range strings.Split(header, ",") {
+		token, _, _ = strings.Cut(token, ";")
+		token = strings.TrimSpace(token)
+		switch strings.ToLower(token) {
+		case profileEnvelope:
+			profile.envelope = true
+		case profileBare:
+			profile.envelope = false
+		default:
+			if style, ok := casing.ParseStyle(token); ok {
+				profile.naming = style
+			}
+		}
+	}
+	return profile
+}
+
+// profiledFormat renders bodies in the negotiated profile before encoding them in the underlying format
+type profiledFormat struct {
+	responseFormat
+	profile responseProfile
+}
+
+func (f profiledFormat) Encode(w io.Writer, v interface{}) error {
+	return f.responseFormat.Encode(w, casing.Render(v, f.profile.naming))
+}
+
+// profileOf returns the profile negotiated into format; zero when none was
+func profileOf(format responseFormat) responseProfile {
+	if profiled, ok := format.(profiledFormat); ok {
+		return profiled.profile
+	}
+	return responseProfile{}
+}
+
+// envelopeMeta describes a response written with statusCode
+func envelopeMeta(w http.ResponseWriter, statusCode int) EnvelopeMeta {
+	return EnvelopeMeta{
+		BaseResponse: BaseResponse{Timestamp: getCurrentTimestamp(), RequestID: extractRequestID(w)},
+		Status:       statusCode,
+		RPCUsage:     rpcUsageOf(w),
+	}
+}
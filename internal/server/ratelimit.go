@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/config"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/scheduler"
+)
+
+// Rate limit hint headers sent on every limited API response
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"     // Client bucket capacity
+	headerRateLimitRemaining = "X-RateLimit-Remaining" // Requests the client may still send back to back
+	headerRateLimitReset     = "X-RateLimit-Reset"     // Seconds until the client bucket is full again
+	headerRPCBudgetRemaining = "X-RPC-Budget-Remaining"
+	headerRPCBudgetReset     = "X-RPC-Budget-Reset"
+)
+
+// rateLimitHeaders are exposed to browser clients through CORS
+var rateLimitHeaders = []string{
+	headerRateLimitLimit, headerRateLimitRemaining, headerRateLimitReset,
+	headerRPCBudgetRemaining, headerRPCBudgetReset, "Retry-After",
+}
+
+// clientSweepInterval is how often buckets that refilled completely are dropped
+const clientSweepInterval = time.Minute
+
+// clientLimiter keeps one token bucket per client key
+type clientLimiter struct {
+	settings *config.ClientRateLimit
+	now      func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*clientBucket
+	sweptAt time.Time
+}
+
+type clientBucket struct {
+	tokens     float64
+	refilledAt time.Time
+}
+
+// clientQuota is a client's standing after a request was admitted or rejected
+type clientQuota struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // Until the bucket is full
+	RetryAfter time.Duration // Until the next request is admitted; 0 when one is available now
+}
+
+// newClientLimiter returns nil when per-client limits are disabled
+func newClientLimiter(settings *config.ClientRateLimit) *clientLimiter {
+	if !settings.Enabled() {
+		return nil
+	}
+	return &clientLimiter{
+		settings: settings,
+		now:      time.Now,
+		buckets:  make(map[string]*clientBucket),
+	}
+}
+
+// take consumes a request from the client's bucket, reporting false when it is empty
+func (l *clientLimiter) take(key string) (clientQuota, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	bucket := l.bucket(key, now)
+	if bucket.tokens < 1 {
+		return l.quota(bucket), false
+	}
+	bucket.tokens--
+	return l.quota(bucket), true
+}
+
+// peek reports the client's quota without consuming a request
+func (l *clientLimiter) peek(key string) clientQuota {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		return l.quota(&clientBucket{tokens: float64(l.settings.Burst)})
+	}
+	l.refill(bucket, l.now())
+	return l.quota(bucket)
+}
+
+// bucket returns the client's refilled bucket, creating a full one for new clients; callers must hold l.mu
+func (l *clientLimiter) bucket(key string, now time.Time) *clientBucket {
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &clientBucket{tokens: float64(l.settings.Burst), refilledAt: now}
+		l.buckets[key] = bucket
+		metrics.SetHTTPRateLimitedClients(len(l.buckets))
+		return bucket
+	}
+	l.refill(bucket, now)
+	return bucket
+}
+
+func (l *clientLimiter) refill(bucket *clientBucket, now time.Time) {
+	elapsed := now.Sub(bucket.refilledAt).Seconds()
+	bucket.refilledAt = now
+	if elapsed > 0 {
+		bucket.tokens = math.Min(bucket.tokens+elapsed*l.settings.RPS, float64(l.settings.Burst))
+	}
+}
+
+// sweep drops buckets that refilled completely, which are equivalent to a new client's; callers must hold l.mu
+func (l *clientLimiter) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < clientSweepInterval {
+		return
+	}
+	l.sweptAt = now
+
+	for key, bucket := range l.buckets {
+		l.refill(bucket, now)
+		if bucket.tokens >= float64(l.settings.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	metrics.SetHTTPRateLimitedClients(len(l.buckets))
+}
+
+func (l *clientLimiter) quota(bucket *clientBucket) clientQuota {
+	quota := clientQuota{
+		Limit:     l.settings.Burst,
+		Remaining: int(bucket.tokens),
+		Reset:     l.secondsToTokens(float64(l.settings.Burst) - bucket.tokens),
+	}
+	if bucket.tokens < 1 {
+		quota.RetryAfter = l.secondsToTokens(1 - bucket.tokens)
+	}
+	return quota
+}
+
+func (l *clientLimiter) secondsToTokens(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / l.settings.RPS * float64(time.Second))
+}
+
+// clientKey identifies the client a request is counted against
+func (l *clientLimiter) clientKey(r *http.Request) string {
+	return l.settings.ClientKey(r)
+}
+
+// limitClientRate rejects requests beyond the client's token bucket with 429 and Retry-After
+// Every response carries the client's remaining quota and the shared RPC budget, each when its
+// limiter is enabled, so integrators can slow down before they are rejected
+func (s *Server) limitClientRate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if budget, scheduled := s.rpcScheduler.Budget(); scheduled {
+			setRPCBudgetHeaders(w, budget)
+		}
+		if s.clientLimiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		quota, admitted := s.clientLimiter.take(s.clientLimiter.clientKey(r))
+		setClientQuotaHeaders(w, quota)
+		if !admitted {
+			metrics.RecordHTTPRateLimited()
+			s.writeRateLimitError(w, quota.RetryAfter,
+				fmt.Sprintf("client request rate exceeds %g per second (burst %d)", s.clientLimiter.settings.RPS, quota.Limit))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func setClientQuotaHeaders(w http.ResponseWriter, quota clientQuota) {
+	w.Header().Set(headerRateLimitLimit, strconv.Itoa(quota.Limit))
+	w.Header().Set(headerRateLimitRemaining, strconv.Itoa(quota.Remaining))
+	w.Header().Set(headerRateLimitReset, strconv.Itoa(ceilSeconds(quota.Reset)))
+}
+
+// setRPCBudgetHeaders reports the shared RPC budget; it is a soft hint because background jobs and
+// other clients draw from the same budget
+func setRPCBudgetHeaders(w http.ResponseWriter, budget scheduler.Budget) {
+	w.Header().Set(headerRPCBudgetRemaining, strconv.Itoa(budget.Available))
+	w.Header().Set(headerRPCBudgetReset, strconv.Itoa(ceilSeconds(time.Duration(budget.RefillSeconds*float64(time.Second)))))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
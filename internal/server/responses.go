@@ -3,6 +3,19 @@ package server
 import (
 	"net/http"
 	"time"
+
+	"hylo-wallet-tracker-api/internal/audit"
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/indexer"
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/scheduler"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/stream"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/trades"
+	"hylo-wallet-tracker-api/internal/warmup"
+	"hylo-wallet-tracker-api/internal/webhook"
 )
 
 // Base response structures for consistent API responses
@@ -19,6 +32,29 @@ type ErrorResponse struct {
 	Code    string `json:"code,omitempty"` // For categorizing errors
 }
 
+// Envelope wraps every response body when the envelope profile is negotiated (Accept-Profile: envelope
+// or RESPONSE_ENVELOPE); data is null on errors and error is null on success
+type Envelope struct {
+	Data  interface{}    `json:"data"`
+	Meta  EnvelopeMeta   `json:"meta"`
+	Error *EnvelopeError `json:"error"`
+}
+
+// EnvelopeMeta describes an enveloped response
+type EnvelopeMeta struct {
+	BaseResponse
+	Status int `json:"status"`
+	// RPCUsage is the Solana RPC work the request cost; only reported for requests sent with debug=true
+	RPCUsage *solana.RPCUsageReport `json:"rpc_usage,omitempty"`
+}
+
+// EnvelopeError is the error of an enveloped response, with the fields of ErrorResponse
+type EnvelopeError struct {
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
 // SuccessResponse represents a structured success response wrapper (optional - for future use)
 type SuccessResponse struct {
 	BaseResponse
@@ -27,19 +63,224 @@ type SuccessResponse struct {
 
 // HealthResponse represents the health check response (matches current format)
 type HealthResponse struct {
-	Status    string      `json:"status"`
-	Solana    interface{} `json:"solana"`
-	Timestamp string      `json:"timestamp"`
+	Status    string         `json:"status"`
+	Chain     solana.ChainID `json:"chain"`
+	Solana    interface{}    `json:"solana"`
+	Timestamp string         `json:"timestamp"`
+
+	// TokenDecimals is the latest check of the registry's decimals against the on-chain mints
+	// Omitted until the first check ran
+	TokenDecimals []tokens.DecimalsStatus `json:"token_decimals,omitempty"`
+
+	// Parser is the transaction parser's error and low-confidence rates over the last hour
+	Parser *hylo.ParseAnomalyStatus `json:"parser,omitempty"`
+}
+
+// ReadinessResponse reports whether the startup warm-up has finished
+type ReadinessResponse struct {
+	Status    string         `json:"status"`           // ready or warming
+	Warmup    *warmup.Result `json:"warmup,omitempty"` // Omitted when no warm-up ran
+	Timestamp string         `json:"timestamp"`
+}
+
+// LimitsResponse documents the quotas applied to the calling client
+type LimitsResponse struct {
+	Client      ClientLimits      `json:"client"`
+	RPCBudget   *scheduler.Budget `json:"rpc_budget"` // Shared by all clients and background jobs; null when unlimited
+	Concurrency ConcurrencyLimits `json:"concurrency"`
+	Requests    RequestLimits     `json:"requests"`
+}
+
+// ClientLimits is the calling client's token bucket
+type ClientLimits struct {
+	Enabled      bool    `json:"enabled"`
+	RPS          float64 `json:"rps,omitempty"`
+	Burst        int     `json:"burst,omitempty"`
+	Remaining    int     `json:"remaining,omitempty"`
+	ResetSeconds int     `json:"reset_seconds,omitempty"` // Until the bucket is full again
+}
+
+// ConcurrencyLimits are the server-wide in-flight request limits
+type ConcurrencyLimits struct {
+	Enabled        bool  `json:"enabled"`
+	MaxInFlight    int   `json:"max_in_flight,omitempty"`
+	QueueDepth     int   `json:"queue_depth,omitempty"`
+	QueueTimeoutMS int64 `json:"queue_timeout_ms,omitempty"`
+
+	// MaxTradeStreams caps concurrent full trade history streams; 0 when uncapped
+	MaxTradeStreams int `json:"max_trade_streams,omitempty"`
+
+	// MaxSSEStreams caps concurrent wallet and supply SSE streams; 0 when uncapped
+	MaxSSEStreams int `json:"max_sse_streams,omitempty"`
+}
+
+// RequestLimits are the per-request deadlines and body size limit
+type RequestLimits struct {
+	DefaultTimeoutMS  int64 `json:"default_timeout_ms"`
+	BalancesTimeoutMS int64 `json:"balances_timeout_ms"`
+	TradesTimeoutMS   int64 `json:"trades_timeout_ms"`
+	MaxBodyBytes      int64 `json:"max_body_bytes"`
+}
+
+// DebugStatsResponse is a runtime snapshot for performance debugging
+type DebugStatsResponse struct {
+	GoVersion  string `json:"go_version"`
+	Goroutines int    `json:"goroutines"`
+	CPUs       int    `json:"cpus"`
+
+	HeapAllocBytes  uint64  `json:"heap_alloc_bytes"`
+	HeapInuseBytes  uint64  `json:"heap_inuse_bytes"`
+	HeapObjects     uint64  `json:"heap_objects"`
+	SysBytes        uint64  `json:"sys_bytes"`
+	NumGC           uint32  `json:"num_gc"`
+	GCPauseTotalMs  float64 `json:"gc_pause_total_ms"`
+	GCCPUFraction   float64 `json:"gc_cpu_fraction"`
+	TotalAllocBytes uint64  `json:"total_alloc_bytes"`
+	TrackedWallets  int     `json:"tracked_wallets"`
+
+	Caches    DebugCacheSizes `json:"caches"`
+	WebSocket *solana.WSStats `json:"websocket"` // Null when the WebSocket client is closed
+	Streams   stream.Stats    `json:"streams"`
+	Timestamp string          `json:"timestamp"`
+}
+
+// DebugCacheSizes counts the entries held by each in-memory cache
+type DebugCacheSizes struct {
+	BlockTimes           int `json:"block_times"`            // Slot -> block time
+	Accounts             int `json:"accounts"`               // Address -> account info, invalidated by account notifications
+	PriceWindow          int `json:"price_window"`           // Accepted SOL/USD samples kept for price bounds
+	FirstActivityWallets int `json:"first_activity_wallets"` // Wallets with a cached first trade
+	FXRates              int `json:"fx_rates"`               // Currency conversion rates
+}
+
+// MaintenanceStatus reports whether the API is in read-only maintenance mode
+type MaintenanceStatus struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"` // Returned by rejected writes
+	Since     *time.Time `json:"since,omitempty"`   // When maintenance mode was switched on
+	Timestamp string     `json:"timestamp"`
+}
+
+// MaintenanceRequest is the body accepted by PUT /admin/maintenance
+type MaintenanceRequest struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty" example:"Migrating the wallet store, back by 14:00 UTC"` // Defaults to MAINTENANCE_MESSAGE
+}
+
+// AuditEntriesResponse represents recent outbound calls recorded in the audit log
+type AuditEntriesResponse struct {
+	Entries   []audit.Entry `json:"entries"`
+	Count     int           `json:"count"`
+	Timestamp string        `json:"timestamp"`
+}
+
+// WalletLogsResponse represents recent log records tagged with a wallet
+type WalletLogsResponse struct {
+	Wallet    string         `json:"wallet"`
+	Entries   []logger.Entry `json:"entries"`
+	Count     int            `json:"count"`
+	Timestamp string         `json:"timestamp"`
+}
+
+// ReconcileReportsResponse lists the latest trade index reconciliation of every wallet
+type ReconcileReportsResponse struct {
+	Reports   []indexer.ReconcileReport `json:"reports"`
+	Count     int                       `json:"count"`
+	Drifted   int                       `json:"drifted"` // Reports with missing, extra or changed trades
+	Timestamp string                    `json:"timestamp"`
+}
+
+// TrackWalletRequest is the body accepted by POST /wallets
+type TrackWalletRequest struct {
+	Address string `json:"address" example:"A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"`
+	Label   string `json:"label,omitempty" example:"treasury"`
+}
+
+// DigestRequest is the body accepted by PUT /wallets/{address}/digest
+type DigestRequest struct {
+	Frequency string `json:"frequency" example:"daily" enums:"daily,weekly"`
+	Timezone  string `json:"timezone,omitempty" example:"Europe/Berlin"` // IANA time zone; defaults to UTC
+}
+
+// TrackedWalletsResponse lists wallets registered for background indexing
+type TrackedWalletsResponse struct {
+	Wallets   []store.TrackedWallet `json:"wallets"`
+	Count     int                   `json:"count"`
+	Timestamp string                `json:"timestamp"`
+}
+
+// TrackWalletResponse reports the outcome of registering a wallet
+type TrackWalletResponse struct {
+	Wallet      store.TrackedWallet `json:"wallet"`
+	Created     bool                `json:"created"`
+	Reactivated bool                `json:"reactivated"` // Was paused; indexing resumes from the stored cursor
+}
+
+// CreatePortfolioRequest is the body accepted by POST /portfolios
+type CreatePortfolioRequest struct {
+	Name    string   `json:"name" example:"Treasury"`
+	Wallets []string `json:"wallets" example:"A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"`
+}
+
+// PortfolioCreatedResponse returns a new portfolio and the members it started tracking
+type PortfolioCreatedResponse struct {
+	Portfolio store.Portfolio `json:"portfolio"`
+	Tracked   []string        `json:"tracked"` // Members newly tracked or reactivated for indexing
+}
+
+// PortfoliosResponse lists wallet portfolios
+type PortfoliosResponse struct {
+	Portfolios []store.Portfolio `json:"portfolios"`
+	Count      int               `json:"count"`
+	Timestamp  string            `json:"timestamp"`
+}
+
+// WebhookCreatedResponse returns a new subscription together with its signing secret
+// The secret is not retrievable afterwards
+type WebhookCreatedResponse struct {
+	Subscription webhook.Subscription `json:"subscription"`
+	Secret       string               `json:"secret"`
+}
+
+// WebhooksResponse lists webhook subscriptions
+type WebhooksResponse struct {
+	Subscriptions []webhook.Subscription `json:"subscriptions"`
+	Count         int                    `json:"count"`
+	Timestamp     string                 `json:"timestamp"`
+}
+
+// WebhookFailuresResponse lists dead-lettered deliveries for a subscription, newest first
+type WebhookFailuresResponse struct {
+	SubscriptionID string             `json:"subscription_id"`
+	Failures       []webhook.Delivery `json:"failures"`
+	Count          int                `json:"count"`
+	Timestamp      string             `json:"timestamp"`
+}
+
+// TradeStreamLine is one line of the full trade history NDJSON stream
+// Trade lines carry a trade; the stream ends with a done line carrying the walk summary, or an error line
+// when the walk failed after trades were sent
+type TradeStreamLine struct {
+	Type    string                 `json:"type"` // trade, done or error
+	Trade   *hylo.XSOLTrade        `json:"trade,omitempty"`
+	Summary *trades.HistorySummary `json:"summary,omitempty"`
+	Error   *ErrorResponse         `json:"error,omitempty"`
 }
 
 // Error codes for categorization - helps with monitoring and debugging
 const (
-	ErrorCodeValidation   = "VALIDATION_ERROR"
-	ErrorCodeNotFound     = "NOT_FOUND"
-	ErrorCodeNetwork      = "NETWORK_ERROR"
-	ErrorCodeInternal     = "INTERNAL_ERROR"
-	ErrorCodeRateLimit    = "RATE_LIMIT"
-	ErrorCodeUnauthorized = "UNAUTHORIZED"
+	ErrorCodeValidation    = "VALIDATION_ERROR"
+	ErrorCodeNotFound      = "NOT_FOUND"
+	ErrorCodeNetwork       = "NETWORK_ERROR"
+	ErrorCodeInternal      = "INTERNAL_ERROR"
+	ErrorCodeRateLimit     = "RATE_LIMIT"
+	ErrorCodeUnauthorized  = "UNAUTHORIZED"
+	ErrorCodeTimeout       = "TIMEOUT"
+	ErrorCodeTooLarge      = "PAYLOAD_TOO_LARGE"
+	ErrorCodeOverloaded    = "OVERLOADED"
+	ErrorCodeNotConfigured = "NOT_CONFIGURED"
+	ErrorCodeMaintenance   = "MAINTENANCE"
+	ErrorCodeQuotaExceeded = "QUOTA_EXCEEDED"
 )
 
 // Helper function to create timestamp in consistent format
@@ -9,7 +9,9 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "hylo-wallet-tracker-api/docs/api" // This line is important for swagger to work
+	"hylo-wallet-tracker-api/internal/config"
 	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
 	_ "hylo-wallet-tracker-api/internal/tokens" // Required for swagger type generation
 	_ "hylo-wallet-tracker-api/internal/trades" // Required for swagger type generation
 )
@@ -24,28 +26,162 @@ func (s *Server) RegisterRoutes() http.Handler {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", config.HeaderTenantAPIKey},
+		ExposedHeaders:   rateLimitHeaders,
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
+	r.Use(s.negotiateFormat) // JSON or MessagePack bodies, chosen from the Accept header
+	r.Use(s.traceRPCUsage)   // debug=true reports the request's RPC usage in meta.rpc_usage
 
-	// API Routes
+	compression := s.compression
+	if compression == nil {
+		compression = config.NewCompressionFromEnvironment()
+	}
+	if compression.Enabled {
+		r.Use(s.compress(compression))
+	}
+
+	// Health, metrics and docs bypass the in-flight limiter so operators can still see a saturated server
 	r.Get("/health", s.handleHealth)
+	r.Get("/ready", s.handleReady)
 
-	// Price endpoint
-	r.Get("/price", s.handlePrice)
-	r.Get("/price/debug", s.handlePriceDebug)
+	// Current quotas; not counted against the client's own rate limit so throttled clients can check it
+	r.Get("/limits", s.handleLimits)
 
-	// Wallet endpoints
-	r.Route("/wallet", func(r chi.Router) {
-		r.Get("/{address}/balances", s.handleWalletBalances)
-		r.Get("/{address}/trades", s.handleWalletTrades)
-	})
+	// Prometheus metrics endpoint
+	r.Handle("/metrics", metrics.Handler())
 
 	// Documentation endpoint
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
 	))
 
+	// Profiling and runtime diagnostics, opt-in and admin-only; outside the limiter and route timeouts
+	// so CPU profiles and traces can run for their requested duration
+	if s.debug != nil && s.debug.Enabled {
+		r.Route("/debug", func(r chi.Router) {
+			r.Use(s.adminAuth)
+			r.Get("/stats", s.handleDebugStats)
+			r.Mount("/", middleware.Profiler()) // /debug/pprof/* and /debug/vars
+		})
+	}
+
+	// Fallbacks are kept on the server so /limits reports the limits actually applied
+	if s.routeLimits == nil {
+		s.routeLimits = config.NewRouteLimitsFromEnvironment()
+	}
+	limits := s.routeLimits
+	defaultTimeout := s.routeTimeout(limits.DefaultTimeout)
+	bodyLimit := s.limitBody(limits.MaxBodyBytes)
+
+	if s.concurrency == nil {
+		s.concurrency = config.NewConcurrencyFromEnvironment()
+	}
+	concurrency := s.concurrency
+
+	// Long-lived SSE and NDJSON streams; no route timeout and not counted against the in-flight limit
+	// Instead they are rate limited per client and capped by their own stream slots: SSE streams share one
+	// pool, and full history walks, which make more RPC calls than any other endpoint, have another
+	sseStreams := s.limitStreams(concurrency.MaxSSEStreams, "SSE", metrics.HTTPShedSSEStreamsFull)
+	r.With(s.limitClientRate, sseStreams, s.sanitizeAddress).Get("/wallet/{address}/stream", s.handleWalletStream)
+	r.With(s.limitClientRate, sseStreams).Get("/protocol/supply/stream", s.handleSupplyStream)
+	r.With(s.limitClientRate, s.limitStreams(concurrency.MaxTradeStreams, "trade history", metrics.HTTPShedStreamsFull), s.sanitizeAddress).
+		Get("/wallet/{address}/trades/full", s.handleWalletTradesFull)
+
+	r.Group(func(r chi.Router) {
+		// Per-client limits run first so a throttled client never occupies an in-flight slot
+		r.Use(s.limitClientRate)
+		if concurrency.Enabled() {
+			r.Use(s.limitConcurrency(concurrency))
+		}
+
+		// Price endpoint
+		r.With(defaultTimeout).Get("/price", s.handlePrice)
+		r.With(defaultTimeout).Get("/price/debug", s.handlePriceDebug)
+		r.With(defaultTimeout).Get("/price/xsol/metrics", s.handleXSOLMetrics) // Served from the price history
+		r.With(defaultTimeout).Get("/price/{symbol}", s.handlePriceQuote)
+
+		// Token analytics endpoints
+		r.With(defaultTimeout).Get("/tokens/{symbol}/stats", s.handleTokenStats)
+
+		// Protocol monitoring: hyUSD peg, LST vault TVL and health history
+		r.With(defaultTimeout).Get("/protocol/peg", s.handlePeg)
+		r.With(defaultTimeout).Get("/protocol/tvl", s.handleProtocolTVL)
+		r.With(defaultTimeout).Get("/protocol/stats/history", s.handleProtocolHistory) // Served from recorded protocol snapshots
+		// Public protocol analysis; the per-wallet impact is only listed for a resolved tenant
+		r.With(s.optionalTenant, defaultTimeout).Get("/protocol/scenarios", s.handleProtocolScenarios)
+
+		// Simulation endpoints
+		r.With(defaultTimeout, bodyLimit).Post("/simulate/trade", s.handleSimulateTrade)
+
+		// Validation endpoints
+		r.With(defaultTimeout, bodyLimit).Post("/validate/address", s.handleValidateAddress)
+
+		// Wallet endpoints
+		r.Route("/wallet/{address}", func(r chi.Router) {
+			r.Use(s.sanitizeAddress)
+			r.With(s.routeTimeout(limits.BalancesTimeout)).Get("/balances", s.handleWalletBalances)
+			r.With(s.trackedWalletAuth, defaultTimeout).Get("/balances/delta", s.handleWalletBalanceDelta) // Served from stored snapshots
+			r.With(s.routeTimeout(limits.BalancesTimeout)).Get("/approvals", s.handleWalletApprovals)
+			r.With(s.routeTimeout(limits.TradesTimeout)).Get("/trades", s.handleWalletTrades)
+			r.With(s.trackedWalletAuth, defaultTimeout).Get("/trades/aggregate", s.handleWalletTradeAggregate) // Served from the trade index
+			r.With(s.trackedWalletAuth, defaultTimeout).Get("/activity", s.handleWalletActivity)               // Served from the trade index
+			r.With(s.trackedWalletAuth, defaultTimeout).Get("/risk", s.handleWalletRisk)                       // Served from stored snapshots and the trade index
+			r.With(s.routeTimeout(limits.TradesTimeout)).Get("/events", s.handleWalletEvents)
+			r.With(s.routeTimeout(limits.TradesTimeout)).Get("/summary", s.handleWalletSummary)
+		})
+
+		// Trade lookup across wallets: the trade index first, then the transaction itself; tracked wallets are
+		// reported for the X-API-Key's tenant only
+		r.With(s.optionalTenant, s.routeTimeout(limits.TradesTimeout)).Get("/trades/{signature}", s.handleTradeBySignature)
+
+		// Tracked wallet registry and watchlist (served from the store, no live RPC calls), scoped to the
+		// tenant of the X-API-Key when tenants are configured
+		// Writes to the registry, portfolios and webhooks are rejected while maintenance mode is on
+		r.Route("/wallets", func(r chi.Router) {
+			r.Use(s.requireTenant, defaultTimeout, s.readOnlyDuringMaintenance)
+			r.Get("/", s.handleListWallets)
+			r.With(bodyLimit).Post("/", s.handleTrackWallet)
+			r.With(s.sanitizeAddress).Delete("/{address}", s.handleUntrackWallet)
+			r.With(s.sanitizeAddress).Get("/{address}/sync", s.handleWalletSyncStatus)
+			r.With(s.sanitizeAddress, bodyLimit).Put("/{address}/digest", s.handleSetWalletDigest)
+			r.With(s.sanitizeAddress).Delete("/{address}/digest", s.handleDeleteWalletDigest)
+		})
+		r.With(s.requireTenant, defaultTimeout).Get("/watchlist/summary", s.handleWatchlistSummary)
+		r.With(s.requireTenant, defaultTimeout).Get("/leaderboard", s.handleLeaderboard)
+
+		// Named wallet groups, summarized from the store
+		r.Route("/portfolios", func(r chi.Router) {
+			r.Use(s.requireTenant, defaultTimeout, s.readOnlyDuringMaintenance)
+			r.Get("/", s.handleListPortfolios)
+			r.With(bodyLimit).Post("/", s.handleCreatePortfolio)
+			r.Get("/{id}", s.handleGetPortfolio)
+			r.Delete("/{id}", s.handleDeletePortfolio)
+			r.Get("/{id}/summary", s.handlePortfolioSummary)
+		})
+
+		// Webhook subscriptions: a tenant manages its own with its API key, the operator all of them with the
+		// admin token (responses include signing secrets)
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(s.tenantOrAdminAuth, defaultTimeout, s.readOnlyDuringMaintenance)
+			r.Get("/", s.handleListWebhooks)
+			r.With(bodyLimit).Post("/", s.handleCreateWebhook)
+			r.Delete("/{id}", s.handleDeleteWebhook)
+			r.Get("/{id}/failures", s.handleWebhookFailures)
+		})
+
+		// Admin endpoints (require ADMIN_API_KEY bearer token, plus a request signature when a signing key is set)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(s.adminAuth, s.adminSignature(limits.MaxBodyBytes), defaultTimeout)
+			r.Get("/audit", s.handleAuditLog)
+			r.Get("/maintenance", s.handleGetMaintenance)
+			r.With(bodyLimit).Put("/maintenance", s.handleSetMaintenance)
+			r.Get("/logs", s.handleWalletLogs)
+			r.Get("/reconcile", s.handleReconcileReports)
+			r.With(s.sanitizeAddress).Get("/reconcile/{address}", s.handleReconcileWallet) // Re-scans RPC
+		})
+	})
+
 	return r
 }
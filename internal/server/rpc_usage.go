@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// traceRPCUsage accounts the Solana RPC requests made for requests sent with debug=true
+// Their response is enveloped so meta.rpc_usage can report the calls, retries and upstream latency it cost
+func (s *Server) traceRPCUsage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if debug, _ := strconv.ParseBool(r.URL.Query().Get("debug")); !debug {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, usage := solana.WithRPCUsage(r.Context())
+		format := formatOf(w)
+		profile := profileOf(format)
+		if !profile.envelope {
+			if profiled, ok := format.(profiledFormat); ok {
+				format = profiled.responseFormat
+			}
+			profile.envelope = true
+			w.Header().Set("Content-Profile", profile.String())
+			w = &formatWriter{ResponseWriter: w, format: profiledFormat{responseFormat: format, profile: profile}}
+		}
+		next.ServeHTTP(&usageWriter{ResponseWriter: w, usage: usage}, r.WithContext(ctx))
+	})
+}
+
+// usageWriter carries a request's RPC usage down to the envelope
+type usageWriter struct {
+	http.ResponseWriter
+	usage *solana.RPCUsage
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController and formatOf
+func (uw *usageWriter) Unwrap() http.ResponseWriter {
+	return uw.ResponseWriter
+}
+
+// rpcUsageOf returns the RPC usage report for w, looking through wrapping writers; nil unless debug=true
+func rpcUsageOf(w http.ResponseWriter) *solana.RPCUsageReport {
+	for w != nil {
+		if uw, ok := w.(*usageWriter); ok {
+			report := uw.usage.Report()
+			return &report
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = unwrapper.Unwrap()
+	}
+	return nil
+}
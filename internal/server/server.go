@@ -1,67 +1,131 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"math"
 	"net/http"
-	"os"
-	"strconv"
+	"sync/atomic"
 	"time"
 
+	"hylo-wallet-tracker-api/internal/audit"
+	"hylo-wallet-tracker-api/internal/config"
+	"hylo-wallet-tracker-api/internal/digest"
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/fx"
 	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/indexer"
 	"hylo-wallet-tracker-api/internal/logger"
-	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/peg"
+	"hylo-wallet-tracker-api/internal/sanctum"
+	"hylo-wallet-tracker-api/internal/scheduler"
 	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/stream"
 	"hylo-wallet-tracker-api/internal/tokens"
 	"hylo-wallet-tracker-api/internal/trades"
+	"hylo-wallet-tracker-api/internal/warmstart"
+	"hylo-wallet-tracker-api/internal/warmup"
+	"hylo-wallet-tracker-api/internal/webhook"
 
 	_ "github.com/joho/godotenv/autoload"
 )
 
 type Server struct {
 	port          int
+	chain         solana.ChainID
 	logger        *logger.Logger
 	solanaService *solana.Service
 	tokenService  *tokens.TokenService
 	tradeService  *trades.TradeService
 	priceService  *hylo.PriceService
+	fx            *fx.Client
+	auditLog      *audit.Log
+	adminAPIKey   string
+	adminSigning  *config.AdminSignature
+	routeLimits   *config.RouteLimits
+	compression   *config.Compression
+	profile       responseProfile
+	timezone      *time.Location
+	concurrency   *config.Concurrency
+	clientLimiter *clientLimiter
+	rpcScheduler  *scheduler.Scheduler
+	debug         *config.Debug
+	tenants       *config.Tenants
+	store         store.Store
+	indexer       *indexer.Indexer
+	protocol      *indexer.ProtocolRecorder
+	webhooks      *webhook.Dispatcher
+	pegMonitor    *peg.Monitor
+	parseMonitor  *hylo.ParseAnomalyMonitor
+	streams       *stream.Manager
+	supply        *stream.SupplyWatcher
+	warmstart     *warmstart.Config
+	httpServer    *http.Server
+
+	// ready is set once the startup warm-up finished; warmup holds its result when one ran
+	ready  atomic.Bool
+	warmup atomic.Pointer[warmup.Result]
+
+	// maintenance is the read-only mode, switchable through /admin/maintenance; maintenanceMessage is
+	// the configured message used when a switch gives none
+	maintenance        atomic.Pointer[maintenanceState]
+	maintenanceMessage string
 	// Note: Price caching removed for fresh prices - all requests fetch live data
 }
 
+// NewServer builds the API and returns its HTTP server
+// Use New instead when in-memory state must be persisted on shutdown
 func NewServer() *http.Server {
-	port, _ := strconv.Atoi(os.Getenv("PORT"))
+	return New().HTTPServer()
+}
 
-	// Bootstrap Solana service with environment configuration
-	solanaConfig := &solana.Config{
-		HttpURL:           os.Getenv("RPC_HTTP_URL"),
-		WebSocketURL:      os.Getenv("RPC_WS_URL"),
-		RequestTimeout:    30 * time.Second,
-		MaxRetries:        3,
-		BaseBackoff:       1 * time.Second,
-		MaxBackoff:        10 * time.Second,
-		HeartbeatInterval: 15 * time.Second,
-		ReconnectTimeout:  30 * time.Second,
+// New loads the configuration, bootstraps every service and restores the cache snapshot if one is configured
+func New() *Server {
+	// Load and validate all settings once; services receive typed sub-configs
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
 	}
 
-	solanaService, err := solana.NewService(solanaConfig)
+	fmt.Printf("✅ Configuration loaded (network: %s)\n", cfg.Network)
+
+	// Bootstrap Solana service
+	solanaService, err := solana.NewService(cfg.Solana)
 	if err != nil {
 		log.Fatalf("Failed to create Solana service: %v", err)
 	}
 
-	fmt.Println("✅ Solana service created successfully")
+	// Share the RPC request budget between API requests and background jobs, API requests first
+	rpcScheduler := scheduler.New(cfg.Scheduler)
+	solanaService.GetHTTPClient().SetScheduler(rpcScheduler)
+
+	fmt.Printf("✅ Solana service created successfully (chain: %s)\n", cfg.Solana.Chain)
 
-	// Bootstrap Token service with Solana HTTP client and environment configuration
-	tokenConfig := tokens.NewConfig()
-	tokenService, err := tokens.NewTokenService(solanaService.GetHTTPClient(), tokenConfig)
+	// Services read on-chain state through the chain-agnostic client interface
+	chainClient := solanaService.GetChainClient()
+
+	// Bootstrap Token service with the chain client and token configuration
+	tokenService, err := tokens.NewTokenService(chainClient, cfg.Tokens)
 	if err != nil {
 		log.Fatalf("Failed to create Token service: %v", err)
 	}
+	// Wallets holding tokens outside their ATAs need every token account listed
+	if cfg.Tokens.AccountDiscovery {
+		tokenService.SetTokenAccountLister(chainClient)
+	}
 
 	fmt.Println("✅ Token service created successfully")
 
-	// Bootstrap Trade service with Solana HTTP client, token config, and hylo config
-	hyloConfig := hylo.NewConfig()
-	tradeService, err := trades.NewTradeService(solanaService.GetHTTPClient(), tokenConfig, hyloConfig)
+	// Bootstrap Trade service with the chain client, token config, and hylo config
+	hylo.SetClassificationRules(cfg.ClassificationRules)
+	hylo.SetStablecoins(cfg.Stablecoins)
+	explorer.SetDefault(cfg.Explorer.Provider)
+
+	tradeService, err := trades.NewTradeService(chainClient, cfg.Tokens, cfg.Hylo)
 	if err != nil {
 		log.Fatalf("Failed to create Trade service: %v", err)
 	}
@@ -69,27 +133,159 @@ func NewServer() *http.Server {
 	fmt.Println("✅ Trade service created successfully")
 
 	// Bootstrap Price service with all required dependencies
-	priceConfig := price.NewConfig()
-	priceService := hylo.NewPriceService(solanaService.GetHTTPClient(), hyloConfig, priceConfig)
+	priceService := hylo.NewPriceService(chainClient, cfg.Hylo, cfg.Price)
+	tokenService.SetStabilityPoolReader(priceService.GetStateReader())
+	tradeService.SetPriceSource(priceService)
+	if cfg.Sanctum.Enabled {
+		priceService.SetLSTRates(sanctum.NewClient(cfg.Sanctum))
+	}
+
+	if priceService.GetPriceHistory() != nil {
+		go priceService.RunPriceHistory(context.Background(), cfg.Price.HistoryInterval)
+	}
 
 	fmt.Println("✅ Price service created successfully")
 
-	// Bootstrap Logger from environment
-	appLogger := logger.NewFromEnv()
+	// Bootstrap audit log and record every outbound RPC and DexScreener call
+	auditLog, err := audit.NewLog(cfg.Audit)
+	if err != nil {
+		log.Fatalf("Failed to create audit log: %v", err)
+	}
+	solanaService.GetHTTPClient().SetAuditLog(auditLog)
+	priceService.GetDexScreenerClient().SetAuditLog(auditLog)
+
+	fmt.Println("✅ Audit log created successfully")
+
+	// Bootstrap wallet streaming over the shared Solana WebSocket connection
+	wsClient := solanaService.GetWSClient()
+	wsClient.Start(context.Background())
+	tokenService.SetAccountSubscriber(wsClient)
+	streams := stream.NewManager(wsClient, cfg.Tokens, cfg.Stream)
+
+	fmt.Println("✅ Stream manager created successfully")
+
+	// Bootstrap store and background indexer for tracked wallets
+	walletStore, err := store.NewMemoryStore(cfg.Store)
+	if err != nil {
+		log.Fatalf("Failed to create store: %v", err)
+	}
+	walletIndexer := indexer.New(walletStore, tokenService, tradeService, priceService, cfg.Indexer)
+	webhooks := webhook.NewDispatcher(cfg.Webhook)
+	// Tenant subscriptions receive events of the wallets their tenant tracks
+	webhooks.SetTenantResolver(func(tenant, wallet string) bool {
+		tracked, ok := walletStore.GetWallet(tenant, wallet)
+		return ok && tracked.Indexed()
+	})
+	// Indexer events go to webhook subscribers and to clients streaming the wallet
+	walletIndexer.SetEventPublisher(webhook.Fanout{webhooks, streams})
+	// jitoSOL is recorded in snapshots so portfolios can value it at its SOL conversion rate
+	if cfg.Indexer.LSTBalances {
+		walletIndexer.SetLSTBalances(tokenService, cfg.Tokens.JitoSOLMint)
+	}
+	if cfg.Indexer.Enabled {
+		go walletIndexer.Run(context.Background())
+	}
+	// Protocol state read for pricing is kept so trades can be annotated with protocol health; a scheduled
+	// read fills the gaps between price reads so the history can be charted
+	var protocolRecorder *indexer.ProtocolRecorder
+	if cfg.Indexer.ProtocolInterval > 0 {
+		protocolRecorder = indexer.NewProtocolRecorder(walletStore, chainClient, cfg.Indexer.ProtocolInterval)
+		priceService.SetStateRecorder(protocolRecorder)
+		go protocolRecorder.Run(context.Background(), priceService)
+	}
+	if cfg.Indexer.ReconcileInterval > 0 {
+		go walletIndexer.RunReconciliation(context.Background())
+	}
+
+	fmt.Println("✅ Store, indexer and webhook dispatcher created successfully")
+
+	// Bootstrap the digest job for wallets opted in to daily or weekly change digests
+	if cfg.Digest.Enabled {
+		digestJob := digest.NewJob(walletStore, cfg.Digest, digest.NewNotifiers(cfg.Digest, webhooks))
+		go digestJob.Run(context.Background())
+	}
+
+	// Bootstrap the hyUSD peg monitor; depeg alerts go out as webhook events
+	pegMonitor := peg.NewMonitor(priceService.GetDexScreenerClient(), webhooks, cfg.Peg)
+	if cfg.Peg.Enabled {
+		go pegMonitor.Run(context.Background())
+	}
+	priceService.SetPegReader(pegMonitor)
+	hylo.SetPegHistory(pegMonitor)
+
+	// Check the registry's decimals against the on-chain mints; mismatched tokens are withheld and alerted
+	tokenService.SetDecimalsAlerter(func(status tokens.DecimalsStatus) {
+		webhooks.Publish(webhook.Event{Type: webhook.EventDecimalsMismatch, Data: status})
+	})
+	if cfg.Tokens.DecimalsCheckInterval > 0 {
+		go tokenService.RunDecimalsReconciliation(context.Background(), cfg.Tokens.DecimalsCheckInterval)
+	}
+
+	// Watch the parser's hourly error and low-confidence rates; a spike usually means an upstream format changed
+	parseMonitor := hylo.NewParseAnomalyMonitor(cfg.Hylo)
+	parseMonitor.SetAlerter(func(status hylo.ParseAnomalyStatus) {
+		eventType := webhook.EventParserRecovered
+		if status.Anomalous {
+			eventType = webhook.EventParserAnomaly
+		}
+		webhooks.Publish(webhook.Event{Type: eventType, Data: status})
+	})
+	hylo.SetParseAnomalyMonitor(parseMonitor)
+
+	// Resolve token names and icons from their Metaplex metadata so frontends need no separate token list
+	if cfg.Tokens.MetadataEnabled {
+		go tokenService.ResolveMetadata(context.Background())
+	}
+
+	// Follow the protocol mints so supply changes are streamed and published without polling
+	var supplyWatcher *stream.SupplyWatcher
+	if cfg.Supply.Enabled {
+		supplyWatcher = stream.NewSupplyWatcher(wsClient, solanaService.GetHTTPClient(), webhooks, cfg.Tokens)
+		go supplyWatcher.Run(context.Background())
+	}
+
+	// Bootstrap Logger
+	appLogger := logger.New(cfg.Logger)
 	fmt.Println("✅ Logger service created successfully")
 
 	newServer := &Server{
-		port:          port,
+		port:          cfg.HTTP.Port,
+		chain:         chainClient.Chain(),
 		logger:        appLogger,
 		solanaService: solanaService,
 		tokenService:  tokenService,
 		tradeService:  tradeService,
 		priceService:  priceService,
+		fx:            fx.NewClient(cfg.FX),
+		auditLog:      auditLog,
+		adminAPIKey:   cfg.HTTP.AdminAPIKey,
+		adminSigning:  cfg.HTTP.AdminSignature,
+		routeLimits:   cfg.HTTP.RouteLimits,
+		compression:   cfg.HTTP.Compression,
+		profile:       newResponseProfile(cfg.HTTP.ResponseProfile),
+		timezone:      cfg.HTTP.BucketTimezone,
+		concurrency:   cfg.HTTP.Concurrency,
+		clientLimiter: newClientLimiter(cfg.HTTP.ClientRateLimit),
+		rpcScheduler:  rpcScheduler,
+		debug:         cfg.HTTP.Debug,
+		tenants:       cfg.HTTP.Tenants,
+		store:         walletStore,
+		indexer:       walletIndexer,
+		protocol:      protocolRecorder,
+		webhooks:      webhooks,
+		pegMonitor:    pegMonitor,
+		parseMonitor:  parseMonitor,
+		streams:       streams,
+		supply:        supplyWatcher,
+		warmstart:     cfg.Warmstart,
 		// Cache TTL removed - fresh prices always fetched
 	}
+	newServer.initMaintenance(cfg.HTTP.Maintenance)
+	newServer.restoreCaches()
+	newServer.startWarmup(cfg.Warmup)
 
 	// Declare Server config
-	server := &http.Server{
+	newServer.httpServer = &http.Server{
 		Addr:         fmt.Sprintf(":%d", newServer.port),
 		Handler:      newServer.RegisterRoutes(),
 		IdleTimeout:  time.Minute,
@@ -97,5 +293,91 @@ func NewServer() *http.Server {
 		WriteTimeout: 30 * time.Second,
 	}
 
-	return server
+	return newServer
+}
+
+// HTTPServer returns the HTTP server serving the API routes
+func (s *Server) HTTPServer() *http.Server {
+	return s.httpServer
+}
+
+// Close persists in-memory state after the HTTP server has shut down: the wallet store with its
+// trade cursors, and the cache snapshot when CACHE_SNAPSHOT_PATH is set
+func (s *Server) Close(ctx context.Context) error {
+	var errs []error
+	if err := s.store.Flush(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to flush store: %w", err))
+	}
+	if err := s.saveCaches(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// restoreCaches seeds in-memory caches from the snapshot saved at the last shutdown
+// A missing, stale or unreadable snapshot only costs a cold start, so errors are logged and ignored
+func (s *Server) restoreCaches() {
+	if s.warmstart.Path == "" {
+		return
+	}
+
+	ctx := context.Background()
+	snapshot, err := warmstart.Load(s.warmstart.Path, s.warmstart.MaxAge, time.Now())
+	if err != nil {
+		s.logger.WarnContext(ctx, "Ignoring cache snapshot", slog.String("error", err.Error()))
+		return
+	}
+	if snapshot == nil {
+		return
+	}
+
+	s.priceService.GetDexScreenerClient().RestorePriceWindow(snapshot.PriceWindow)
+	s.tradeService.RestoreFirstActivities(snapshot.FirstActivity)
+	s.priceService.GetPriceHistory().Restore(snapshot.PriceHistory)
+	pricesRestored := s.priceService.RestoreCachedPrices(snapshot.Prices, snapshot.PricesFetchedAt)
+	if snapshot.ProtocolState != nil {
+		// Ignored when the store already holds a snapshot at or after its slot
+		if err := s.store.AddProtocolSnapshot(*snapshot.ProtocolState); err != nil {
+			s.logger.WarnContext(ctx, "Failed to restore protocol state", slog.String("error", err.Error()))
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Restored cache snapshot",
+		slog.String("path", s.warmstart.Path),
+		slog.Time("saved_at", snapshot.SavedAt),
+		slog.Int("price_samples", len(snapshot.PriceWindow)),
+		slog.Int("price_history_points", len(snapshot.PriceHistory)),
+		slog.Int("first_activity_wallets", len(snapshot.FirstActivity)),
+		slog.Bool("stale_prices", pricesRestored),
+		slog.Bool("protocol_state", snapshot.ProtocolState != nil))
+}
+
+func (s *Server) saveCaches(ctx context.Context) error {
+	if s.warmstart.Path == "" {
+		return nil
+	}
+
+	snapshot := &warmstart.Snapshot{
+		PriceWindow:   s.priceService.GetDexScreenerClient().PriceWindow(),
+		PriceHistory:  s.priceService.GetPriceHistory().Points(),
+		FirstActivity: s.tradeService.FirstActivities(),
+	}
+	if prices, fetchedAt, ok := s.priceService.CachedPrices(); ok {
+		snapshot.Prices, snapshot.PricesFetchedAt = prices, fetchedAt
+	}
+	if state, ok := s.store.ProtocolSnapshotNear(math.MaxUint64); ok {
+		snapshot.ProtocolState = state
+	}
+	if err := warmstart.Save(s.warmstart.Path, snapshot, time.Now()); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Saved cache snapshot",
+		slog.String("path", s.warmstart.Path),
+		slog.Int("price_samples", len(snapshot.PriceWindow)),
+		slog.Int("price_history_points", len(snapshot.PriceHistory)),
+		slog.Int("first_activity_wallets", len(snapshot.FirstActivity)),
+		slog.Bool("prices", snapshot.Prices != nil),
+		slog.Bool("protocol_state", snapshot.ProtocolState != nil))
+	return nil
 }
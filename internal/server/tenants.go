@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"hylo-wallet-tracker-api/internal/config"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// tenantContextKey is the request context key for the tenant resolved by requireTenant, optionalTenant or tenantOrAdminAuth
+type tenantContextKey struct{}
+
+// requireTenant resolves the tenant of the request from its X-API-Key header and rejects unknown keys
+// Without tenants configured every request belongs to the default tenant and no key is needed
+func (s *Server) requireTenant(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.tenants.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tenant, ok := s.tenants.Resolve(r.Header.Get(config.HeaderTenantAPIKey))
+		if !ok {
+			s.writeUnauthorizedError(w, "missing or invalid "+config.HeaderTenantAPIKey+" header")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantContextKey{}, tenant)))
+	})
+}
+
+// optionalTenant scopes requests carrying an X-API-Key header to their tenant and rejects unknown keys
+// Requests without a key pass through anonymously; tenantScoped tells the two apart
+func (s *Server) optionalTenant(next http.Handler) http.Handler {
+	scoped := s.requireTenant(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(config.HeaderTenantAPIKey) == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		scoped.ServeHTTP(w, r)
+	})
+}
+
+// tenantScoped reports whether the request may see a tenant's wallets: always without tenants configured,
+// otherwise only once requireTenant or optionalTenant resolved its API key
+func (s *Server) tenantScoped(r *http.Request) bool {
+	if !s.tenants.Enabled() {
+		return true
+	}
+	_, ok := r.Context().Value(tenantContextKey{}).(string)
+	return ok
+}
+
+// tenantOrAdminAuth accepts a tenant API key, scoping the request to that tenant, or otherwise the admin
+// bearer token, which acts as the operator on the default tenant
+func (s *Server) tenantOrAdminAuth(next http.Handler) http.Handler {
+	admin := s.adminAuth(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.tenants.Enabled() || r.Header.Get(config.HeaderTenantAPIKey) == "" {
+			admin.ServeHTTP(w, r)
+			return
+		}
+		s.requireTenant(next).ServeHTTP(w, r)
+	})
+}
+
+// trackedWalletAuth guards reads served from a tenant's tracked wallet data: public on the default tenant without
+// tenants configured, otherwise scoped like tenantOrAdminAuth to the X-API-Key's tenant or the admin's default tenant
+func (s *Server) trackedWalletAuth(next http.Handler) http.Handler {
+	scoped := s.tenantOrAdminAuth(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.tenants.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		scoped.ServeHTTP(w, r)
+	})
+}
+
+// requireTrackedWallet reports whether the request's tenant tracks wallet, writing 404 when it does not
+// Other tenants' registrations are not consulted, so the response never reveals who else tracks the wallet
+func (s *Server) requireTrackedWallet(w http.ResponseWriter, r *http.Request, wallet solana.Address) bool {
+	if _, ok := s.store.GetWallet(requestTenant(r), wallet.String()); !ok {
+		s.writeNotFoundError(w, "Tracked wallet")
+		return false
+	}
+	return true
+}
+
+// requestTenant returns the tenant a request was scoped to, or the default tenant
+func requestTenant(r *http.Request) string {
+	tenant, _ := r.Context().Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// quotaApplies reports whether TENANT_MAX_* quotas limit the tenant; the operator's default tenant is never limited
+func (s *Server) quotaApplies(tenant string) bool {
+	return s.tenants.Enabled() && tenant != store.DefaultTenant
+}
+
+// walletQuotaExceeded reports whether keeping addresses indexed would take the tenant past TENANT_MAX_WALLETS
+// Addresses the tenant already keeps indexed do not count again, so re-tracking an active wallet always succeeds
+func (s *Server) walletQuotaExceeded(tenant string, addresses ...string) bool {
+	if !s.quotaApplies(tenant) || s.tenants.MaxWallets == 0 {
+		return false
+	}
+
+	indexed := make(map[string]bool)
+	for _, wallet := range s.store.ListTenantWallets(tenant) {
+		if wallet.Indexed() {
+			indexed[wallet.Address] = true
+		}
+	}
+	count := len(indexed)
+	for _, address := range addresses {
+		if !indexed[address] {
+			indexed[address] = true
+			count++
+		}
+	}
+	return count > s.tenants.MaxWallets
+}
+
+// portfolioQuotaExceeded reports whether the tenant already holds TENANT_MAX_PORTFOLIOS portfolios
+func (s *Server) portfolioQuotaExceeded(tenant string) bool {
+	return s.quotaApplies(tenant) && s.tenants.MaxPortfolios > 0 &&
+		len(s.store.ListPortfolios(tenant)) >= s.tenants.MaxPortfolios
+}
+
+// webhookQuotaExceeded reports whether the tenant already holds TENANT_MAX_WEBHOOKS subscriptions
+func (s *Server) webhookQuotaExceeded(tenant string) bool {
+	return s.quotaApplies(tenant) && s.tenants.MaxWebhooks > 0 &&
+		len(s.webhooks.Subscriptions(tenant)) >= s.tenants.MaxWebhooks
+}
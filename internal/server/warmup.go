@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"hylo-wallet-tracker-api/internal/scheduler"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/warmup"
+)
+
+// startWarmup preloads balance and trade caches for every indexed wallet in the background and marks
+// the server ready when it finishes or runs out of budget; without a warm-up the server is ready at once
+func (s *Server) startWarmup(config *warmup.Config) {
+	if config == nil || !config.Enabled {
+		s.ready.Store(true)
+		return
+	}
+
+	// A wallet tracked by several tenants is warmed once
+	wallets := store.IndexedAddresses(s.store.ListWallets())
+
+	go func() {
+		ctx := context.Background()
+		result := warmup.Run(ctx, config, wallets, s.warmWallet)
+		s.warmup.Store(&result)
+		s.ready.Store(true)
+
+		s.logger.InfoContext(ctx, "Startup warm-up finished",
+			slog.Int("wallets", result.Wallets),
+			slog.Int("warmed", result.Warmed),
+			slog.Int("failed", result.Failed),
+			slog.Int("skipped", result.Skipped),
+			slog.Bool("budget_exceeded", result.BudgetExceeded),
+			slog.Int64("duration_ms", result.DurationMS))
+	}()
+}
+
+// warmWallet fetches a wallet's balances and first trade page, filling the missing token account,
+// block time and connection caches the same way the first user request would
+func (s *Server) warmWallet(ctx context.Context, address string) error {
+	ctx = scheduler.WithPriority(ctx, scheduler.PriorityBackground)
+
+	wallet, err := tokens.SanitizeAddress(address)
+	if err != nil {
+		return err
+	}
+	if _, err := s.tokenService.GetWalletBalances(ctx, wallet); err != nil {
+		return fmt.Errorf("failed to warm balances: %w", err)
+	}
+	if _, err := s.tradeService.GetWalletTrades(ctx, wallet, s.tradeService.GetOptions().DefaultLimit, ""); err != nil {
+		return fmt.Errorf("failed to warm trades: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package solana
+
+import (
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// accountCacheSize bounds the address -> account cache; the oldest entry is evicted when full
+const accountCacheSize = 1024
+
+// accountEntry is an account as read at slot
+type accountEntry struct {
+	info       *AccountInfo
+	slot       Slot
+	commitment Commitment
+	fetchedAt  time.Time // When the read was answered
+	storedAt   time.Time // Restarts the TTL when a subscription ends
+}
+
+// accountCache remembers getAccountInfo results by address along with the slot they were read at.
+// Accounts with an active WebSocket subscription stay cached until a notification reports a change;
+// every other entry expires after the TTL. A read older than the last notification for its address
+// is never stored, so an in-flight fetch cannot reinstate data a notification already invalidated
+type accountCache struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	entries  map[Address]accountEntry
+	watched  map[Address]int  // Active account subscriptions per address
+	notified map[Address]Slot // Slot of the last notification per address
+}
+
+func newAccountCache(ttl time.Duration) *accountCache {
+	return &accountCache{
+		ttl:      ttl,
+		entries:  make(map[Address]accountEntry),
+		watched:  make(map[Address]int),
+		notified: make(map[Address]Slot),
+	}
+}
+
+// get returns the cached entry when it was read at commitment and is still fresh
+func (c *accountCache) get(address Address, commitment Commitment) (accountEntry, bool) {
+	if c == nil || c.ttl <= 0 {
+		return accountEntry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[address]
+	if ok && c.watched[address] == 0 && time.Since(entry.storedAt) > c.ttl {
+		delete(c.entries, address)
+		ok = false
+	}
+	if !ok || entry.commitment != commitment {
+		metrics.RecordAccountCache(metrics.AccountCacheMiss)
+		return accountEntry{}, false
+	}
+	metrics.RecordAccountCache(metrics.AccountCacheHit)
+	return entry, true
+}
+
+// add stores an account read at slot unless a notification has since reported a newer change
+func (c *accountCache) add(address Address, commitment Commitment, slot Slot, info *AccountInfo) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if notified, ok := c.notified[address]; ok && slot < notified {
+		return
+	}
+	if current, ok := c.entries[address]; ok && current.commitment == commitment && current.slot > slot {
+		return
+	}
+	if _, ok := c.entries[address]; !ok && len(c.entries) >= accountCacheSize {
+		c.evictOldest()
+	}
+	now := time.Now()
+	c.entries[address] = accountEntry{info: info, slot: slot, commitment: commitment, fetchedAt: now, storedAt: now}
+}
+
+// evictOldest drops the entry stored longest ago; called with c.mu held
+func (c *accountCache) evictOldest() {
+	var oldest Address
+	var oldestAt time.Time
+	for address, entry := range c.entries {
+		if oldestAt.IsZero() || entry.storedAt.Before(oldestAt) {
+			oldest, oldestAt = address, entry.storedAt
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+// invalidate drops the cached account after a notification reported a change at slot
+func (c *accountCache) invalidate(address Address, slot Slot) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, address)
+	if slot > c.notified[address] {
+		c.notified[address] = slot
+	}
+}
+
+// watch marks address as covered by an active subscription
+func (c *accountCache) watch(address Address) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.watched[address]++
+}
+
+// unwatch removes one subscription for address; its cached account falls back to the TTL
+func (c *accountCache) unwatch(address Address) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.watched[address] <= 1 {
+		delete(c.watched, address)
+		delete(c.notified, address)
+		if entry, ok := c.entries[address]; ok {
+			entry.storedAt = time.Now()
+			c.entries[address] = entry
+		}
+		return
+	}
+	c.watched[address]--
+}
+
+// unwatchAll forgets every subscription when the connection drops, since notifications sent before
+// the resubscribe completes are lost. Entries that relied on a subscription are dropped
+func (c *accountCache) unwatchAll() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for address := range c.watched {
+		delete(c.entries, address)
+	}
+	c.watched = make(map[Address]int)
+	c.notified = make(map[Address]Slot)
+}
+
+// size returns the number of cached accounts
+func (c *accountCache) size() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// AccountCacheSize returns the number of accounts held in the getAccountInfo cache
+func (c *HTTPClient) AccountCacheSize() int {
+	return c.accounts.size()
+}
+
+// accountAddress returns the address of an account subscription
+func (s *Subscription) accountAddress() (Address, bool) {
+	if s.Method != wsMethodAccountSubscribe || len(s.params) == 0 {
+		return "", false
+	}
+	address, ok := s.params[0].(string)
+	return Address(address), ok
+}
@@ -0,0 +1,119 @@
+package solana
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+func TestHTTPClient_AccountCache(t *testing.T) {
+	response := loadTestData(t, "get_account_response.json")
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(NewConfig(server.URL, "ws://unused"), logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetAccount(ctx, testAccount, CommitmentConfirmed); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected repeated reads to be cached, got %d calls", calls.Load())
+	}
+
+	if _, err := client.GetAccount(ctx, testAccount, CommitmentFinalized); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected a read at another commitment to be fetched, got %d calls", calls.Load())
+	}
+
+	client.accounts.invalidate(testAccount, 294112233)
+	if _, err := client.GetAccount(ctx, testAccount, CommitmentFinalized); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 || client.AccountCacheSize() != 1 {
+		t.Errorf("expected an invalidated account to be refetched and cached, got %d calls and %d entries",
+			calls.Load(), client.AccountCacheSize())
+	}
+}
+
+func TestAccountCacheExpiry(t *testing.T) {
+	cache := newAccountCache(time.Minute)
+	info := &AccountInfo{Lamports: 1}
+	backdate := func() {
+		entry := cache.entries[testAccount]
+		entry.storedAt = time.Now().Add(-2 * time.Minute)
+		cache.entries[testAccount] = entry
+	}
+
+	cache.add(testAccount, CommitmentConfirmed, 100, info)
+	backdate()
+	if _, ok := cache.get(testAccount, CommitmentConfirmed); ok {
+		t.Error("expected an unwatched entry to expire after the TTL")
+	}
+
+	cache.watch(testAccount)
+	cache.add(testAccount, CommitmentConfirmed, 100, info)
+	backdate()
+	if _, ok := cache.get(testAccount, CommitmentConfirmed); !ok {
+		t.Error("expected a watched entry to outlive the TTL")
+	}
+
+	cache.invalidate(testAccount, 105)
+	cache.add(testAccount, CommitmentConfirmed, 104, info)
+	if _, ok := cache.get(testAccount, CommitmentConfirmed); ok {
+		t.Error("expected a read older than the last notification not to be cached")
+	}
+	cache.add(testAccount, CommitmentConfirmed, 105, info)
+	if _, ok := cache.get(testAccount, CommitmentConfirmed); !ok {
+		t.Error("expected a read at the notified slot to be cached")
+	}
+
+	cache.unwatchAll()
+	if _, ok := cache.get(testAccount, CommitmentConfirmed); ok {
+		t.Error("expected watched entries to be dropped when the connection is lost")
+	}
+}
+
+func TestWSClientInvalidatesCachedAccounts(t *testing.T) {
+	server := newFakeWSServer(t, true)
+	client := newTestWSClient(t, server, 0)
+	cache := newAccountCache(time.Minute)
+	client.accounts = cache
+
+	ctx := context.Background()
+	if _, err := client.AccountSubscribe(ctx, testAccount, CommitmentConfirmed); err != nil {
+		t.Fatalf("AccountSubscribe() error = %v", err)
+	}
+	client.Start(ctx)
+	waitFor(t, "account subscription", func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		return cache.watched[testAccount] == 1
+	})
+
+	cache.add(testAccount, CommitmentConfirmed, 200, &AccountInfo{Lamports: 1})
+	server.notifyAccount(t, `{"context":{"slot":201},"value":{"lamports":2}}`)
+	waitFor(t, "invalidation", func() bool { return cache.size() == 0 })
+
+	cache.add(testAccount, CommitmentConfirmed, 200, &AccountInfo{Lamports: 1})
+	if cache.size() != 0 {
+		t.Error("expected a read from before the notification not to be cached")
+	}
+}
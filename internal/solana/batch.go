@@ -0,0 +1,162 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// rpcInternalErrorCode is the JSON-RPC 2.0 code reported for a call missing from a batch reply
+const rpcInternalErrorCode = -32603
+
+// batchResponse is one entry of a JSON-RPC batch reply; Result stays raw until matched to its call
+type batchResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// TransactionBatchSize returns how many getTransaction calls GetTransactions sends per round trip
+// Values below 2 mean the endpoint is not batched and every transaction costs its own request
+func (c *HTTPClient) TransactionBatchSize() int {
+	return c.config.BatchSize
+}
+
+// GetTransactions fetches transactions for several signatures, sending up to BatchSize
+// getTransaction calls in each JSON-RPC batch request
+// Results line up with signatures: errs[i] is ErrTransactionNotFound when the node does not have
+// signatures[i], the call's RPC error when only that call failed, or the batch error for every
+// entry of a batch that could not be sent. Archive fallback is not attempted for batched calls
+func (c *HTTPClient) GetTransactions(ctx context.Context, signatures []Signature) ([]*TransactionDetails, []error) {
+	txs := make([]*TransactionDetails, len(signatures))
+	errs := make([]error, len(signatures))
+
+	size := max(c.config.BatchSize, 1)
+	for start := 0; start < len(signatures); start += size {
+		end := min(start+size, len(signatures))
+		c.getTransactionBatch(ctx, signatures[start:end], txs[start:end], errs[start:end])
+	}
+
+	return txs, errs
+}
+
+// getTransactionBatch fills txs and errs for one batch of signatures
+func (c *HTTPClient) getTransactionBatch(ctx context.Context, signatures []Signature, txs []*TransactionDetails, errs []error) {
+	var params []interface{}
+	var positions []int // Index into signatures of each call in params
+	for i, signature := range signatures {
+		if err := signature.Validate(); err != nil {
+			errs[i] = WrapValidationError("signature", signature, err.Error())
+			continue
+		}
+		params = append(params, []interface{}{
+			signature.String(),
+			map[string]interface{}{
+				"encoding":                       "json",
+				"commitment":                     "confirmed",
+				"maxSupportedTransactionVersion": 0,
+				"rewards":                        false,
+			},
+		})
+		positions = append(positions, i)
+	}
+	if len(params) == 0 {
+		return
+	}
+
+	var responses []batchResponse
+	err := c.withRetry(ctx, "getTransaction", len(params), func(ctx context.Context) error {
+		var err error
+		responses, err = c.doBatchRequest(ctx, "getTransaction", params)
+		return err
+	})
+	if err == nil {
+		dataContextFrom(ctx).observe(0, CommitmentConfirmed, time.Now())
+	}
+
+	for call, i := range positions {
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to get transaction: %w", err)
+			continue
+		}
+
+		response := responses[call]
+		switch {
+		case response.Error != nil:
+			errs[i] = fmt.Errorf("failed to get transaction: %w", response.Error)
+		case len(response.Result) == 0 || bytes.Equal(response.Result, []byte("null")):
+			errs[i] = ErrTransactionNotFound
+		default:
+			if err := decodeResult(response.Result, &txs[i]); err != nil {
+				errs[i] = err
+				continue
+			}
+			c.resolveBlockTime(ctx, txs[i].Slot, &txs[i].BlockTime)
+		}
+	}
+}
+
+// doBatchRequest sends one call per params entry in a single JSON-RPC batch without retry
+// The reply is reordered to match params; providers that reject batching answer with a single
+// error object, which is returned as the error for the whole batch
+func (c *HTTPClient) doBatchRequest(ctx context.Context, method string, params []interface{}) ([]batchResponse, error) {
+	requests := make([]JSONRPCRequest, len(params))
+	calls := make(map[int]int, len(params)) // Request ID -> index in params
+	for i, p := range params {
+		id := int(c.rpcID.Add(1))
+		requests[i] = JSONRPCRequest{JSONRPC: "2.0", ID: id, Method: method, Params: p}
+		calls[id] = i
+	}
+
+	reqBody, err := json.Marshal(requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal batch request: %w", err)
+	}
+
+	var body json.RawMessage
+	err = c.post(ctx, reqBody, func(r io.Reader) error {
+		if err := json.NewDecoder(r).Decode(&body); err != nil {
+			return fmt.Errorf("failed to parse RPC batch response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		var single batchResponse
+		if err := json.Unmarshal(trimmed, &single); err != nil {
+			return nil, fmt.Errorf("failed to parse RPC response: %w", err)
+		}
+		if single.Error != nil {
+			return nil, single.Error
+		}
+		return nil, errors.New("batch request answered with a single response")
+	}
+
+	var replies []batchResponse
+	if err := json.Unmarshal(body, &replies); err != nil {
+		return nil, fmt.Errorf("failed to parse RPC batch response: %w", err)
+	}
+
+	responses := make([]batchResponse, len(params))
+	received := make([]bool, len(params))
+	for _, reply := range replies {
+		if i, ok := calls[reply.ID]; ok {
+			responses[i] = reply
+			received[i] = true
+		}
+	}
+	for i, ok := range received {
+		if !ok {
+			responses[i].Error = &RPCError{Code: rpcInternalErrorCode, Message: "missing response in RPC batch"}
+		}
+	}
+
+	return responses, nil
+}
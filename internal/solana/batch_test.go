@@ -0,0 +1,96 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+func testSignature(suffix string) Signature {
+	return Signature(strings.Repeat("5", 64) + suffix)
+}
+
+func TestHTTPClient_GetTransactions(t *testing.T) {
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			t.Errorf("expected a batch request: %v", err)
+			return
+		}
+		batchSizes = append(batchSizes, len(requests))
+
+		// Reply in reverse order; the client must match responses by ID
+		var replies []map[string]interface{}
+		for i := len(requests) - 1; i >= 0; i-- {
+			signature := requests[i].Params.([]interface{})[0].(string)
+			reply := map[string]interface{}{"jsonrpc": "2.0", "id": requests[i].ID}
+			switch {
+			case strings.HasSuffix(signature, "missing"):
+				reply["result"] = nil
+			case strings.HasSuffix(signature, "error"):
+				reply["error"] = map[string]interface{}{"code": -32009, "message": "slot skipped"}
+			default:
+				reply["result"] = map[string]interface{}{"slot": 42, "blockTime": 1700000000, "transaction": map[string]interface{}{"signatures": []string{signature}}}
+			}
+			replies = append(replies, reply)
+		}
+		json.NewEncoder(w).Encode(replies)
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "ws://unused")
+	config.BatchSize = 2
+	client, err := NewHTTPClient(config, logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	signatures := []Signature{testSignature("a"), testSignature("missing"), testSignature("error"), "short"}
+	txs, errs := client.GetTransactions(context.Background(), signatures)
+
+	if len(batchSizes) != 2 || batchSizes[0] != 2 || batchSizes[1] != 1 {
+		t.Errorf("expected batches of 2 and 1 valid signatures, got %v", batchSizes)
+	}
+	if errs[0] != nil || txs[0] == nil || txs[0].Transaction.Signatures[0] != string(signatures[0]) {
+		t.Errorf("expected the first transaction, got %+v (%v)", txs[0], errs[0])
+	}
+	if !errors.Is(errs[1], ErrTransactionNotFound) {
+		t.Errorf("expected ErrTransactionNotFound for a null result, got %v", errs[1])
+	}
+	var rpcErr *RPCError
+	if !errors.As(errs[2], &rpcErr) || rpcErr.Code != -32009 {
+		t.Errorf("expected the per-call RPC error, got %v", errs[2])
+	}
+	var validationErr *ValidationError
+	if !errors.As(errs[3], &validationErr) {
+		t.Errorf("expected a validation error without sending the signature, got %v", errs[3])
+	}
+}
+
+func TestHTTPClient_GetTransactionsBatchRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch requests are not supported"}}`))
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "ws://unused")
+	config.BatchSize = 10
+	client, err := NewHTTPClient(config, logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, errs := client.GetTransactions(context.Background(), []Signature{testSignature("a"), testSignature("b")})
+	for i, err := range errs {
+		if err == nil || !strings.Contains(err.Error(), "batch requests are not supported") {
+			t.Errorf("signature %d: expected the batch rejection, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// blockSignatures is the getBlock result with transactionDetails set to signatures
+type blockSignatures struct {
+	Signatures []string `json:"signatures"`
+}
+
+// GetBlockSignature returns the first transaction signature of the block at a slot
+// Any signature works as a getSignaturesForAddress before cursor, so this anchors a listing at a slot.
+// The result is false when the slot was skipped or its block holds no transactions
+func (c *HTTPClient) GetBlockSignature(ctx context.Context, slot Slot) (string, bool, error) {
+	params := []interface{}{
+		uint64(slot),
+		map[string]interface{}{
+			"commitment":                     "finalized",
+			"transactionDetails":             "signatures",
+			"rewards":                        false,
+			"maxSupportedTransactionVersion": 0,
+		},
+	}
+
+	var block *blockSignatures
+	if err := c.request(ctx, "getBlock", params, &block); err != nil {
+		if errors.Is(err, ErrSlotSkipped) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get block: %w", err)
+	}
+	if block == nil || len(block.Signatures) == 0 {
+		return "", false, nil
+	}
+	return block.Signatures[0], true, nil
+}
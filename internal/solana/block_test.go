@@ -0,0 +1,55 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+func TestHTTPClient_GetBlockSignature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+
+		reply := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch slot := uint64(req.Params.([]interface{})[0].(float64)); slot {
+		case 100:
+			reply["result"] = map[string]interface{}{"signatures": []string{strings.Repeat("5", 88), strings.Repeat("4", 88)}}
+		case 101:
+			reply["error"] = map[string]interface{}{"code": -32007, "message": "Slot 101 was skipped"}
+		case 102:
+			reply["result"] = map[string]interface{}{"signatures": []string{}}
+		default:
+			reply["error"] = map[string]interface{}{"code": -32602, "message": "invalid params"}
+		}
+		json.NewEncoder(w).Encode(reply)
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(NewConfig(server.URL, "ws://unused"), logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	signature, found, err := client.GetBlockSignature(ctx, 100)
+	if err != nil || !found || signature != strings.Repeat("5", 88) {
+		t.Errorf("expected the block's first signature, got %q, %v, %v", signature, found, err)
+	}
+	for _, slot := range []Slot{101, 102} {
+		if _, found, err := client.GetBlockSignature(ctx, slot); err != nil || found {
+			t.Errorf("slot %d: expected no anchor and no error, got %v, %v", slot, found, err)
+		}
+	}
+	if _, _, err := client.GetBlockSignature(ctx, 103); err == nil {
+		t.Error("expected other RPC errors to be returned")
+	}
+}
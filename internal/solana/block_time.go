@@ -0,0 +1,100 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// blockTimeCacheSize bounds the slot -> block time cache; one entry per distinct slot looked up
+const blockTimeCacheSize = 10000
+
+// blockTimeCache remembers block times by slot. A slot's block time never changes once the
+// block is confirmed, so entries are never invalidated, only evicted oldest first when full
+type blockTimeCache struct {
+	mu    sync.Mutex
+	times map[Slot]int64
+	order []Slot // Insertion order for eviction
+}
+
+func newBlockTimeCache() *blockTimeCache {
+	return &blockTimeCache{times: make(map[Slot]int64)}
+}
+
+func (c *blockTimeCache) get(slot Slot) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	blockTime, ok := c.times[slot]
+	return blockTime, ok
+}
+
+func (c *blockTimeCache) add(slot Slot, blockTime int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.times[slot]; ok {
+		return
+	}
+	if len(c.order) >= blockTimeCacheSize {
+		delete(c.times, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.times[slot] = blockTime
+	c.order = append(c.order, slot)
+}
+
+// BlockTimeCacheSize returns the number of slots with a cached block time
+func (c *HTTPClient) BlockTimeCacheSize() int {
+	c.blockTimes.mu.Lock()
+	defer c.blockTimes.mu.Unlock()
+	return len(c.blockTimes.times)
+}
+
+// GetBlockTime returns the estimated production time of a slot as a Unix timestamp
+// Results are cached per slot; ErrBlockTimeUnavailable is returned when the node has none
+func (c *HTTPClient) GetBlockTime(ctx context.Context, slot Slot) (int64, error) {
+	if blockTime, ok := c.blockTimes.get(slot); ok {
+		return blockTime, nil
+	}
+
+	var response *int64
+	if err := c.request(ctx, "getBlockTime", []interface{}{uint64(slot)}, &response); err != nil {
+		return 0, fmt.Errorf("failed to get block time: %w", err)
+	}
+	if response == nil {
+		return 0, ErrBlockTimeUnavailable
+	}
+
+	c.blockTimes.add(slot, *response)
+	return *response, nil
+}
+
+// resolveBlockTime fills a nil blockTime from the slot cache or getBlockTime
+// Known block times are cached on the way through so later lookups for the slot are free.
+// Failures are logged and leave blockTime nil; a missing timestamp never fails the caller
+func (c *HTTPClient) resolveBlockTime(ctx context.Context, slot Slot, blockTime **int64) {
+	if *blockTime != nil {
+		c.blockTimes.add(slot, **blockTime)
+		return
+	}
+
+	if cached, ok := c.blockTimes.get(slot); ok {
+		metrics.RecordBlockTimeBackfill(metrics.BlockTimeCached)
+		*blockTime = &cached
+		return
+	}
+
+	resolved, err := c.GetBlockTime(ctx, slot)
+	if err != nil {
+		metrics.RecordBlockTimeBackfill(metrics.BlockTimeMissing)
+		c.logger.DebugContext(ctx, "Failed to backfill block time",
+			slog.Uint64("slot", uint64(slot)),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	metrics.RecordBlockTimeBackfill(metrics.BlockTimeResolved)
+	*blockTime = &resolved
+}
@@ -0,0 +1,97 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+func TestHTTPClient_BlockTimeBackfill(t *testing.T) {
+	blockTimeCalls := map[uint64]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+			return
+		}
+
+		var result interface{}
+		switch req.Method {
+		case "getSignaturesForAddress":
+			result = []map[string]interface{}{
+				{"signature": strings.Repeat("5", 88), "slot": 100, "blockTime": 1700000100},
+				{"signature": strings.Repeat("4", 88), "slot": 100, "blockTime": nil},
+				{"signature": strings.Repeat("3", 88), "slot": 99, "blockTime": nil},
+				{"signature": strings.Repeat("2", 88), "slot": 98, "blockTime": nil},
+			}
+		case "getTransaction":
+			result = map[string]interface{}{"slot": 99, "blockTime": nil}
+		case "getBlockTime":
+			slot := uint64(req.Params.([]interface{})[0].(float64))
+			blockTimeCalls[slot]++
+			if slot != 98 {
+				result = 1700000000 + slot
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(NewConfig(server.URL, "ws://unused"), logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	signatures, err := client.GetSignaturesForAddress(ctx, Address("11111111111111111111111111111112"), "", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signatures[1].BlockTime == nil || *signatures[1].BlockTime != 1700000100 {
+		t.Errorf("expected the slot's listed block time to be reused, got %v", signatures[1].BlockTime)
+	}
+	if signatures[2].BlockTime == nil || *signatures[2].BlockTime != 1700000099 {
+		t.Errorf("expected getBlockTime to backfill slot 99, got %v", signatures[2].BlockTime)
+	}
+	if signatures[3].BlockTime != nil {
+		t.Errorf("expected no block time when the node has none, got %v", *signatures[3].BlockTime)
+	}
+
+	tx, err := client.GetTransaction(ctx, Signature(strings.Repeat("3", 88)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.BlockTime == nil || *tx.BlockTime != 1700000099 {
+		t.Errorf("expected the cached block time for slot 99, got %v", tx.BlockTime)
+	}
+
+	if blockTimeCalls[100] != 0 || blockTimeCalls[99] != 1 {
+		t.Errorf("expected one getBlockTime call for slot 99 only, got %v", blockTimeCalls)
+	}
+	if _, err := client.GetBlockTime(ctx, 98); !errors.Is(err, ErrBlockTimeUnavailable) {
+		t.Errorf("expected ErrBlockTimeUnavailable for a null result, got %v", err)
+	}
+}
+
+func TestBlockTimeCacheEviction(t *testing.T) {
+	cache := newBlockTimeCache()
+	for slot := Slot(0); slot <= blockTimeCacheSize; slot++ {
+		cache.add(slot, int64(slot))
+	}
+
+	if _, ok := cache.get(0); ok {
+		t.Error("expected the oldest slot to be evicted")
+	}
+	if blockTime, ok := cache.get(blockTimeCacheSize); !ok || blockTime != blockTimeCacheSize {
+		t.Errorf("expected the newest slot to be cached, got %v (%v)", blockTime, ok)
+	}
+	if len(cache.times) != blockTimeCacheSize {
+		t.Errorf("expected the cache to stay bounded, got %d entries", len(cache.times))
+	}
+}
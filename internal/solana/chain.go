@@ -0,0 +1,58 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChainID identifies the SVM chain a client reads from
+// Every supported chain speaks the Solana JSON-RPC API, so the types in this package are shared between them
+type ChainID string
+
+// Supported chains
+const (
+	ChainSolana ChainID = "solana"
+)
+
+// supportedChains lists the chains with a ChainClient implementation
+var supportedChains = map[ChainID]bool{
+	ChainSolana: true,
+}
+
+// Validate checks that a client implementation exists for the chain
+func (c ChainID) Validate() error {
+	if supportedChains[c] {
+		return nil
+	}
+
+	names := make([]string, 0, len(supportedChains))
+	for chain := range supportedChains {
+		names = append(names, string(chain))
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unsupported chain %q: must be one of %s", c, strings.Join(names, ", "))
+}
+
+// ChainClient is the RPC surface services use to read on-chain state
+// HTTPClient implements it for Solana; Hylo deployments on other SVM chains (e.g. Eclipse)
+// plug in by providing another implementation and registering its ChainID
+type ChainClient interface {
+	// Chain identifies the chain this client reads from
+	Chain() ChainID
+
+	GetAccount(ctx context.Context, address Address, commitment Commitment) (*AccountInfo, error)
+	GetTransaction(ctx context.Context, signature Signature) (*TransactionDetails, error)
+	GetTransactionForSignature(ctx context.Context, info SignatureInfo) (*TransactionDetails, error)
+	GetSlot(ctx context.Context, commitment Commitment) (Slot, error)
+	GetTokenLargestAccounts(ctx context.Context, mint Address, commitment Commitment) ([]TokenAccountBalance, error)
+	GetTokenAccountsByOwner(ctx context.Context, owner, mint Address, commitment Commitment) ([]KeyedAccount, error)
+	GetSignaturesForAddress(ctx context.Context, address Address, before string, limit int) ([]SignatureInfo, error)
+	GetSignaturesForAddressWithOptions(ctx context.Context, address Address, query SignatureQuery) ([]SignatureInfo, error)
+
+	Health(ctx context.Context) error
+	Close() error
+}
+
+var _ ChainClient = (*HTTPClient)(nil)
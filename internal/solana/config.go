@@ -2,11 +2,40 @@ package solana
 
 import (
 	"errors"
+	"os"
+	"strconv"
 	"time"
+
+	"hylo-wallet-tracker-api/internal/fixtures"
+)
+
+// Default HTTP transport pool settings for RPC connections
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultKeepAlive           = 30 * time.Second
 )
 
+// DefaultStallTimeout is how long the WebSocket may go without slot progress before reconnecting
+const DefaultStallTimeout = 30 * time.Second
+
+// DefaultArchiveMinSlotAge is roughly one epoch; younger transactions are expected on the primary node
+const DefaultArchiveMinSlotAge Slot = 432000
+
+// DefaultMaxResponseBytes bounds a single RPC response body; large getTransaction replies with deep CPI
+// trees stay well below it
+const DefaultMaxResponseBytes int64 = 64 << 20
+
+// DefaultAccountCacheTTL is how long an unsubscribed account read stays cached; protocol state and mints
+// change far less often than they are read
+const DefaultAccountCacheTTL = 5 * time.Second
+
 // Config holds configuration for Solana RPC client
 type Config struct {
+	// Chain is the SVM chain the endpoints serve; empty means Solana
+	Chain ChainID
+
 	// HTTP RPC endpoint URL
 	HttpURL string
 
@@ -29,13 +58,57 @@ type Config struct {
 	// WebSocket heartbeat interval for keepalive pings
 	HeartbeatInterval time.Duration
 
-	// WebSocket reconnect timeout
+	// WebSocket reconnect timeout (bounds each dial and handshake attempt)
 	ReconnectTimeout time.Duration
+
+	// Force a WebSocket reconnect when slot notifications stop advancing for this long (0 disables)
+	StallTimeout time.Duration
+
+	// Maximum idle connections kept across all hosts (0 uses net/http default)
+	MaxIdleConns int
+
+	// Maximum idle connections kept per RPC host (0 uses net/http default of 2)
+	MaxIdleConnsPerHost int
+
+	// How long an idle connection stays in the pool before closing
+	IdleConnTimeout time.Duration
+
+	// TCP keep-alive probe interval for RPC connections
+	KeepAlive time.Duration
+
+	// Disable HTTP keep-alives so every request opens a new connection
+	DisableKeepAlives bool
+
+	// Negotiate HTTP/2 with the RPC endpoint when supported
+	EnableHTTP2 bool
+
+	// Maximum getTransaction calls sent in one JSON-RPC batch request (0 or 1 disables batching)
+	// Only enable for providers that accept batch requests
+	BatchSize int
+
+	// Maximum size of one RPC response body in bytes; larger responses fail without retry (0 disables)
+	MaxResponseBytes int64
+
+	// How long getAccountInfo results stay cached when the account has no WebSocket subscription (0 disables)
+	AccountCacheTTL time.Duration
+
+	// Serve RPC responses from recorded fixtures in this directory instead of the network
+	FixtureDir string
+
+	// Record live RPC responses into FixtureDir
+	FixtureRecord bool
+
+	// Archival HTTP RPC endpoint queried when the primary node no longer has an old transaction (empty disables)
+	ArchiveHttpURL string
+
+	// Minimum age in slots of a finalized signature before a missing transaction is retried on the archive node
+	ArchiveMinSlotAge Slot
 }
 
 // NewConfig creates a new Config with sensible defaults
 func NewConfig(httpURL, wsURL string) *Config {
 	return &Config{
+		Chain:             ChainSolana,
 		HttpURL:           httpURL,
 		WebSocketURL:      wsURL,
 		RequestTimeout:    30 * time.Second,
@@ -44,11 +117,26 @@ func NewConfig(httpURL, wsURL string) *Config {
 		MaxBackoff:        30 * time.Second,
 		HeartbeatInterval: 15 * time.Second,
 		ReconnectTimeout:  60 * time.Second,
+		StallTimeout:      DefaultStallTimeout,
+		ArchiveMinSlotAge: DefaultArchiveMinSlotAge,
+		MaxResponseBytes:  DefaultMaxResponseBytes,
+		AccountCacheTTL:   DefaultAccountCacheTTL,
+
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+		KeepAlive:           DefaultKeepAlive,
 	}
 }
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
+	if c.Chain != "" {
+		if err := c.Chain.Validate(); err != nil {
+			return err
+		}
+	}
+
 	if c.HttpURL == "" {
 		return errors.New("HttpURL is required")
 	}
@@ -81,6 +169,30 @@ func (c *Config) Validate() error {
 		return errors.New("ReconnectTimeout must be positive")
 	}
 
+	if c.StallTimeout < 0 {
+		return errors.New("StallTimeout cannot be negative")
+	}
+
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 {
+		return errors.New("idle connection limits cannot be negative")
+	}
+
+	if c.IdleConnTimeout < 0 {
+		return errors.New("IdleConnTimeout cannot be negative")
+	}
+
+	if c.BatchSize < 0 {
+		return errors.New("BatchSize cannot be negative")
+	}
+
+	if c.MaxResponseBytes < 0 {
+		return errors.New("MaxResponseBytes cannot be negative")
+	}
+
+	if c.AccountCacheTTL < 0 {
+		return errors.New("AccountCacheTTL cannot be negative")
+	}
+
 	return nil
 }
 
@@ -97,3 +209,91 @@ func (c *Config) WithRetries(retries int) *Config {
 	newConfig.MaxRetries = retries
 	return &newConfig
 }
+
+// LoadTransportFromEnvironment overrides connection pool settings from environment variables
+func (c *Config) LoadTransportFromEnvironment() {
+	if value := os.Getenv("SOLANA_RPC_MAX_IDLE_CONNS"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			c.MaxIdleConns = n
+		}
+	}
+
+	if value := os.Getenv("SOLANA_RPC_MAX_IDLE_CONNS_PER_HOST"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			c.MaxIdleConnsPerHost = n
+		}
+	}
+
+	if value := os.Getenv("SOLANA_RPC_IDLE_CONN_TIMEOUT_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			c.IdleConnTimeout = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("SOLANA_RPC_KEEPALIVE_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			c.KeepAlive = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("SOLANA_RPC_DISABLE_KEEPALIVES"); value != "" {
+		if disabled, err := strconv.ParseBool(value); err == nil {
+			c.DisableKeepAlives = disabled
+		}
+	}
+
+	if value := os.Getenv("SOLANA_RPC_HTTP2"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			c.EnableHTTP2 = enabled
+		}
+	}
+
+	if value := os.Getenv("SOLANA_RPC_BATCH_SIZE"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			c.BatchSize = n
+		}
+	}
+
+	if value := os.Getenv("SOLANA_RPC_MAX_RESPONSE_BYTES"); value != "" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil && n >= 0 {
+			c.MaxResponseBytes = n
+		}
+	}
+
+	if value := os.Getenv("SOLANA_ACCOUNT_CACHE_TTL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			c.AccountCacheTTL = time.Duration(seconds) * time.Second
+		}
+	}
+
+	c.FixtureDir = os.Getenv(fixtures.EnvFixtureDir)
+	if record, err := strconv.ParseBool(os.Getenv(fixtures.EnvFixtureRecord)); err == nil {
+		c.FixtureRecord = record
+	}
+}
+
+// LoadWebSocketFromEnvironment overrides WebSocket keepalive and stall detection settings from environment variables
+func (c *Config) LoadWebSocketFromEnvironment() {
+	if value := os.Getenv("SOLANA_WS_HEARTBEAT_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			c.HeartbeatInterval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("SOLANA_WS_STALL_TIMEOUT_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			c.StallTimeout = time.Duration(seconds) * time.Second
+		}
+	}
+}
+
+// LoadArchiveFromEnvironment configures the archival RPC fallback from environment variables
+func (c *Config) LoadArchiveFromEnvironment() {
+	c.ArchiveHttpURL = os.Getenv("SOLANA_ARCHIVE_RPC_URL")
+
+	if value := os.Getenv("SOLANA_ARCHIVE_MIN_SLOT_AGE"); value != "" {
+		if slots, err := strconv.ParseUint(value, 10, 64); err == nil {
+			c.ArchiveMinSlotAge = Slot(slots)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package solana
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DataContext tells clients how fresh the chain data behind a response is, so responses of different
+// endpoints can be ordered. A response assembled from several reads reports the oldest of them:
+// every read reflected the chain at Slot or later, at Commitment or stronger, as of FetchedAt or later
+type DataContext struct {
+	Slot       Slot       `json:"slot"`       // Lowest slot of the reads; 0 when no read reported one
+	Commitment Commitment `json:"commitment"` // Weakest commitment of the reads
+	FetchedAt  time.Time  `json:"fetched_at"` // When the oldest read was answered, including reads served from cache
+}
+
+// DataContextRecorder accumulates the context of the RPC responses one result is built from; safe for concurrent use
+type DataContextRecorder struct {
+	mu       sync.Mutex
+	observed bool
+	context  DataContext
+}
+
+type dataContextKey struct{}
+
+// WithDataContext returns a context whose RPC responses are recorded in the returned recorder
+func WithDataContext(ctx context.Context) (context.Context, *DataContextRecorder) {
+	recorder := &DataContextRecorder{}
+	return context.WithValue(ctx, dataContextKey{}, recorder), recorder
+}
+
+// dataContextFrom returns the recorder for ctx, or nil when none is
+func dataContextFrom(ctx context.Context) *DataContextRecorder {
+	recorder, _ := ctx.Value(dataContextKey{}).(*DataContextRecorder)
+	return recorder
+}
+
+// observe records a response read at commitment and answered at fetchedAt; slot is the response context
+// slot, or the newest slot a response without one is known to reflect, and 0 when neither is known
+func (r *DataContextRecorder) observe(slot Slot, commitment Commitment, fetchedAt time.Time) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.observed {
+		r.observed = true
+		r.context = DataContext{Slot: slot, Commitment: commitment, FetchedAt: fetchedAt.UTC()}
+		return
+	}
+	if slot != 0 && (r.context.Slot == 0 || slot < r.context.Slot) {
+		r.context.Slot = slot
+	}
+	if commitmentRank(commitment) < commitmentRank(r.context.Commitment) {
+		r.context.Commitment = commitment
+	}
+	if fetchedAt.Before(r.context.FetchedAt) {
+		r.context.FetchedAt = fetchedAt.UTC()
+	}
+}
+
+// DataContext returns the context recorded so far, or nil when no RPC response was recorded
+func (r *DataContextRecorder) DataContext() *DataContext {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.observed {
+		return nil
+	}
+	context := r.context
+	return &context
+}
+
+// commitmentRank orders commitments from weakest to strongest
+func commitmentRank(commitment Commitment) int {
+	switch commitment {
+	case CommitmentProcessed:
+		return 0
+	case CommitmentConfirmed:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,62 @@
+package solana
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+func TestDataContextRecorder(t *testing.T) {
+	_, recorder := WithDataContext(context.Background())
+	if recorder.DataContext() != nil {
+		t.Fatal("expected no context before any response")
+	}
+
+	oldest := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	recorder.observe(0, CommitmentFinalized, oldest.Add(time.Second))
+	recorder.observe(200, CommitmentFinalized, oldest)
+	recorder.observe(150, CommitmentConfirmed, oldest.Add(2*time.Second))
+
+	got := recorder.DataContext()
+	if got.Slot != 150 || got.Commitment != CommitmentConfirmed || !got.FetchedAt.Equal(oldest) {
+		t.Errorf("expected the lowest slot, weakest commitment and oldest fetch, got %+v", got)
+	}
+}
+
+func TestHTTPClient_GetAccountDataContext(t *testing.T) {
+	response := loadTestData(t, "get_account_response.json")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(NewConfig(server.URL, "ws://unused"), logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, recorder := WithDataContext(context.Background())
+	if _, err := client.GetAccount(ctx, testAccount, CommitmentConfirmed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fetched := recorder.DataContext()
+	if fetched == nil || fetched.Slot != 294112233 || fetched.Commitment != CommitmentConfirmed || fetched.FetchedAt.IsZero() {
+		t.Fatalf("expected the response context slot to be recorded, got %+v", fetched)
+	}
+
+	// A cached read reports when the account was actually fetched
+	time.Sleep(5 * time.Millisecond)
+	ctx, recorder = WithDataContext(context.Background())
+	if _, err := client.GetAccount(ctx, testAccount, CommitmentConfirmed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cached := recorder.DataContext()
+	if cached == nil || cached.Slot != fetched.Slot || cached.FetchedAt.Sub(fetched.FetchedAt) > time.Millisecond {
+		t.Errorf("expected the cached read to report the original context %+v, got %+v", fetched, cached)
+	}
+}
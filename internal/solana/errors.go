@@ -3,6 +3,8 @@ package solana
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 )
 
 // Error types for Solana RPC operations
@@ -27,13 +29,94 @@ var (
 
 	// ErrAccountNotFound indicates the account doesn't exist
 	ErrAccountNotFound = errors.New("account not found")
+
+	// ErrTransactionNotFound indicates the node has no record of the transaction
+	ErrTransactionNotFound = errors.New("transaction not found")
+
+	// ErrBlockTimeUnavailable indicates the node has no block time for the slot
+	ErrBlockTimeUnavailable = errors.New("block time unavailable")
+
+	// ErrResponseTooLarge indicates an RPC response body exceeded Config.MaxResponseBytes
+	ErrResponseTooLarge = errors.New("rpc response too large")
+)
+
+// Provider failure classes; an RPCError matches one of these with errors.Is
+var (
+	// ErrRateLimited indicates the provider rejected the request for exceeding its rate limit
+	ErrRateLimited = errors.New("rpc rate limited")
+
+	// ErrNodeBehind indicates the node is unhealthy or has not caught up to the requested slot
+	ErrNodeBehind = errors.New("rpc node behind")
+
+	// ErrLongTermStorage indicates the node no longer has the data locally and could not query long-term storage
+	ErrLongTermStorage = errors.New("rpc long-term storage unavailable")
+
+	// ErrSlotSkipped indicates the requested slot was skipped and has no block
+	ErrSlotSkipped = errors.New("slot skipped")
 )
 
+// Solana JSON-RPC server error codes
+const (
+	rpcCodeBlockCleanedUp             = -32001
+	rpcCodeNodeUnhealthy              = -32005
+	rpcCodeSlotSkipped                = -32007
+	rpcCodeLongTermStorageSlotSkipped = -32009
+	rpcCodeTransactionHistoryMissing  = -32011
+	rpcCodeBlockStatusNotAvailableYet = -32014
+	rpcCodeMinContextSlotNotReached   = -32016
+	rpcCodeLongTermStorageUnreachable = -32019
+	rpcCodeRateLimited                = -32429 // Not part of the Solana spec; returned by several providers
+)
+
+// ErrorClass labels an RPC failure for retry decisions and metrics
+type ErrorClass string
+
+// Error classes
+const (
+	ClassRateLimited     ErrorClass = "rate_limited"      // HTTP 429 or -32429
+	ClassNodeBehind      ErrorClass = "node_behind"       // -32005, -32014, -32016
+	ClassLongTermStorage ErrorClass = "long_term_storage" // -32001, -32011, -32019
+	ClassSlotSkipped     ErrorClass = "slot_skipped"      // -32007, -32009
+	ClassServer          ErrorClass = "server"            // HTTP 5xx
+	ClassNetwork         ErrorClass = "network"           // Transport failure before a reply
+	ClassPermanent       ErrorClass = "permanent"         // Everything else; retrying returns the same error
+)
+
+// RetryStrategy is how the retry loop treats one error class
+type RetryStrategy struct {
+	// Retry is false when another attempt against the same node would fail the same way
+	Retry bool
+
+	// Exponential grows the delay with each attempt; otherwise every retry waits the base delay
+	Exponential bool
+
+	// Multiplier scales the backoff delay
+	Multiplier float64
+}
+
+// retryStrategies maps each error class to its retry strategy
+// Rate limits back off twice as hard as server errors so the provider's window can reset;
+// a node behind usually catches up within a slot or two, so it is retried quickly without growth.
+// Long-term storage and skipped slots are not retried: the former is served by the archive node, the latter never will be
+var retryStrategies = map[ErrorClass]RetryStrategy{
+	ClassRateLimited:     {Retry: true, Exponential: true, Multiplier: 2},
+	ClassNodeBehind:      {Retry: true, Multiplier: 1},
+	ClassLongTermStorage: {},
+	ClassSlotSkipped:     {},
+	ClassServer:          {Retry: true, Exponential: true, Multiplier: 1},
+	ClassNetwork:         {Retry: true, Exponential: true, Multiplier: 1},
+	ClassPermanent:       {},
+}
+
 // RPCError represents an error returned by the Solana RPC
+// Code is the JSON-RPC error code, or the HTTP status when the reply was not 200
 type RPCError struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+
+	// RetryAfter is the delay requested by the provider's Retry-After header, if any
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error implements the error interface
@@ -44,10 +127,42 @@ func (e *RPCError) Error() string {
 	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
 }
 
+// Class classifies the error by provider-specific cause
+func (e *RPCError) Class() ErrorClass {
+	switch e.Code {
+	case http.StatusTooManyRequests, rpcCodeRateLimited:
+		return ClassRateLimited
+	case rpcCodeNodeUnhealthy, rpcCodeBlockStatusNotAvailableYet, rpcCodeMinContextSlotNotReached:
+		return ClassNodeBehind
+	case rpcCodeBlockCleanedUp, rpcCodeTransactionHistoryMissing, rpcCodeLongTermStorageUnreachable:
+		return ClassLongTermStorage
+	case rpcCodeSlotSkipped, rpcCodeLongTermStorageSlotSkipped:
+		return ClassSlotSkipped
+	}
+	if e.Code >= 500 {
+		return ClassServer
+	}
+	return ClassPermanent
+}
+
+// Is matches the error against the provider failure classes
+func (e *RPCError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.Class() == ClassRateLimited
+	case ErrNodeBehind:
+		return e.Class() == ClassNodeBehind
+	case ErrLongTermStorage:
+		return e.Class() == ClassLongTermStorage
+	case ErrSlotSkipped:
+		return e.Class() == ClassSlotSkipped
+	}
+	return false
+}
+
 // IsRetryable returns true if this RPC error should be retried
 func (e *RPCError) IsRetryable() bool {
-	// Retry on server errors and rate limiting
-	return e.Code >= 500 || e.Code == 429
+	return retryStrategies[e.Class()].Retry
 }
 
 // NetworkError wraps network-related errors with retry information
@@ -114,6 +229,36 @@ func WrapValidationError(field string, value interface{}, reason string) error {
 	}
 }
 
+// Classify returns the error class of a failed RPC call
+func Classify(err error) ErrorClass {
+	var rpcErr *RPCError
+	var netErr *NetworkError
+
+	if errors.As(err, &rpcErr) {
+		return rpcErr.Class()
+	}
+
+	if errors.As(err, &netErr) && !netErr.Final {
+		return ClassNetwork
+	}
+
+	return ClassPermanent
+}
+
+// StrategyFor returns the retry strategy for an error
+func StrategyFor(err error) RetryStrategy {
+	return retryStrategies[Classify(err)]
+}
+
+// retryAfter returns the provider-requested delay carried by err, or 0
+func retryAfter(err error) time.Duration {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr.RetryAfter
+	}
+	return 0
+}
+
 // IsRetryable checks if an error should be retried
 func IsRetryable(err error) bool {
 	var rpcErr *RPCError
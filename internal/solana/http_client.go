@@ -10,18 +10,39 @@ import (
 	"log/slog"
 	"math"
 	"math/rand"
+	"net"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	"hylo-wallet-tracker-api/internal/audit"
+	"hylo-wallet-tracker-api/internal/fixtures"
 	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/scheduler"
 )
 
 // HTTPClient provides HTTP-based Solana RPC functionality
+// It is safe for concurrent use and meant to be shared: one client reuses a single connection pool.
+// SetAuditLog and SetScheduler configure the client and must be called before it is shared
 type HTTPClient struct {
 	config     *Config
 	logger     *logger.Logger
 	httpClient *http.Client
-	rpcID      int
+	rpcID      atomic.Int64 // Last JSON-RPC request ID handed out
+
+	// archive serves finalized transactions the primary node has pruned; nil when not configured
+	archive *HTTPClient
+
+	// scheduler rations the primary endpoint's request budget; nil sends every call immediately
+	scheduler *scheduler.Scheduler
+
+	// blockTimes backfills blockTime for signatures and transactions the node returned without one
+	blockTimes *blockTimeCache
+
+	// accounts caches getAccountInfo results; shared with the WebSocket client for invalidation
+	accounts *accountCache
 }
 
 // NewHTTPClient creates a new HTTP client for Solana RPC
@@ -36,18 +57,88 @@ func NewHTTPClient(config *Config, serviceLogger *logger.Logger) (*HTTPClient, e
 		config: config,
 		logger: clientLogger,
 		httpClient: &http.Client{
-			Timeout: config.RequestTimeout,
+			Timeout:   config.RequestTimeout,
+			Transport: newRoundTripper(config),
 		},
-		rpcID: 1,
+		blockTimes: newBlockTimeCache(),
+		accounts:   newAccountCache(config.AccountCacheTTL),
+	}
+
+	if config.ArchiveHttpURL != "" {
+		archiveConfig := *config
+		archiveConfig.HttpURL = config.ArchiveHttpURL
+		archiveConfig.ArchiveHttpURL = ""
+
+		archive, err := NewHTTPClient(&archiveConfig, serviceLogger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create archive client: %w", err)
+		}
+		client.archive = archive
 	}
 
 	clientLogger.InfoContext(context.Background(), "Solana HTTP client created",
 		slog.String("rpc_url", config.HttpURL),
-		slog.Duration("timeout", config.RequestTimeout))
+		slog.Duration("timeout", config.RequestTimeout),
+		slog.Int("max_idle_conns_per_host", config.MaxIdleConnsPerHost),
+		slog.Bool("http2", config.EnableHTTP2),
+		slog.String("fixture_dir", config.FixtureDir),
+		slog.Bool("archive_fallback", client.archive != nil))
 
 	return client, nil
 }
 
+// Transport dial/handshake timeouts match net/http defaults
+const (
+	transportDialTimeout           = 30 * time.Second
+	transportTLSHandshakeTimeout   = 10 * time.Second
+	transportExpectContinueTimeout = 1 * time.Second
+)
+
+// auditTarget labels Solana RPC calls in the audit log
+const auditTarget = "solana-rpc"
+
+// SetAuditLog records every outbound RPC call made by this client into log
+func (c *HTTPClient) SetAuditLog(log *audit.Log) {
+	c.httpClient.Transport = audit.NewTransport(c.httpClient.Transport, log)
+	if c.archive != nil {
+		c.archive.SetAuditLog(log)
+	}
+}
+
+// SetScheduler makes every attempt against the primary endpoint wait for budget from s
+// The archive endpoint has its own quota and is not scheduled
+func (c *HTTPClient) SetScheduler(s *scheduler.Scheduler) {
+	c.scheduler = s
+}
+
+// newRoundTripper returns the fixture transport when fixtures mode is enabled, otherwise the pooled transport
+func newRoundTripper(config *Config) http.RoundTripper {
+	if config.FixtureDir != "" {
+		return fixtures.NewTransport(config.FixtureDir, config.FixtureRecord, newTransport(config))
+	}
+	return newTransport(config)
+}
+
+// newTransport builds a pooled HTTP transport so concurrent RPC calls reuse connections
+func newTransport(config *Config) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   transportDialTimeout,
+		KeepAlive: config.KeepAlive,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		DisableKeepAlives:     config.DisableKeepAlives,
+		ForceAttemptHTTP2:     config.EnableHTTP2,
+		TLSHandshakeTimeout:   transportTLSHandshakeTimeout,
+		ExpectContinueTimeout: transportExpectContinueTimeout,
+	}
+}
+
 // JSONRPCRequest represents a JSON-RPC 2.0 request
 type JSONRPCRequest struct {
 	JSONRPC string      `json:"jsonrpc"`
@@ -58,13 +149,23 @@ type JSONRPCRequest struct {
 
 // JSONRPCResponse represents a JSON-RPC 2.0 response
 type JSONRPCResponse struct {
-	JSONRPC string      `json:"jsonrpc"`
-	ID      int         `json:"id"`
-	Result  interface{} `json:"result"`
-	Error   *RPCError   `json:"error"`
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *RPCError       `json:"error"`
+}
+
+// Chain returns the chain this client's endpoint serves; configs that leave it unset read from Solana
+func (c *HTTPClient) Chain() ChainID {
+	if c.config.Chain == "" {
+		return ChainSolana
+	}
+	return c.config.Chain
 }
 
 // GetAccount fetches account information for the given address
+// Results are cached per address for AccountCacheTTL, or until an account notification when the
+// address is subscribed over the WebSocket; the returned account must not be modified
 func (c *HTTPClient) GetAccount(ctx context.Context, address Address, commitment Commitment) (*AccountInfo, error) {
 	// Validate inputs
 	if err := address.Validate(); err != nil {
@@ -75,6 +176,11 @@ func (c *HTTPClient) GetAccount(ctx context.Context, address Address, commitment
 		return nil, WrapValidationError("commitment", commitment, err.Error())
 	}
 
+	if cached, ok := c.accounts.get(address, commitment); ok {
+		dataContextFrom(ctx).observe(cached.slot, commitment, cached.fetchedAt)
+		return cached.info, nil
+	}
+
 	params := []interface{}{
 		address.String(),
 		map[string]interface{}{
@@ -93,12 +199,14 @@ func (c *HTTPClient) GetAccount(ctx context.Context, address Address, commitment
 	if err := c.request(ctx, "getAccountInfo", params, &response); err != nil {
 		return nil, fmt.Errorf("failed to get account info: %w", err)
 	}
+	dataContextFrom(ctx).observe(response.Context.Slot, commitment, time.Now())
 
 	// Account not found
 	if response.Value == nil {
 		return nil, ErrAccountNotFound
 	}
 
+	c.accounts.add(address, commitment, response.Context.Slot, response.Value)
 	return response.Value, nil
 }
 
@@ -119,51 +227,242 @@ func (c *HTTPClient) GetTransaction(ctx context.Context, signature Signature) (*
 		},
 	}
 
-	var response TransactionDetails
+	var response *TransactionDetails
 
 	if err := c.request(ctx, "getTransaction", params, &response); err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
+	dataContextFrom(ctx).observe(0, CommitmentConfirmed, time.Now())
+
+	// Transaction not found (unknown signature or pruned from the node's ledger)
+	if response == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	c.resolveBlockTime(ctx, response.Slot, &response.BlockTime)
+	return response, nil
+}
+
+// GetTransactionForSignature fetches the transaction for a signature returned by getSignaturesForAddress
+// Finalized signatures at least ArchiveMinSlotAge behind the finalized tip that the primary node
+// no longer has, or cannot fetch from long-term storage, are retried on the archive node,
+// so deep history backfills don't drop trades
+func (c *HTTPClient) GetTransactionForSignature(ctx context.Context, info SignatureInfo) (*TransactionDetails, error) {
+	signature := Signature(info.Signature)
+
+	// Seed the cache with the listing's block time so a transaction missing one needs no extra call
+	if info.BlockTime != nil {
+		c.blockTimes.add(info.Slot, *info.BlockTime)
+	}
+
+	tx, err := c.GetTransaction(ctx, signature)
+	pruned := errors.Is(err, ErrTransactionNotFound) || errors.Is(err, ErrLongTermStorage)
+	if !pruned || !c.archiveEligible(ctx, info) {
+		return tx, err
+	}
+
+	archiveTx, archiveErr := c.archive.GetTransaction(ctx, signature)
+	switch {
+	case archiveErr == nil:
+		metrics.RecordArchiveFallback(metrics.ArchiveFallbackFound)
+		c.logger.InfoContext(ctx, "Fetched pruned transaction from archive node",
+			slog.String("signature", info.Signature),
+			slog.Uint64("slot", uint64(info.Slot)))
+		return archiveTx, nil
+	case errors.Is(archiveErr, ErrTransactionNotFound):
+		metrics.RecordArchiveFallback(metrics.ArchiveFallbackNotFound)
+		return nil, archiveErr
+	default:
+		metrics.RecordArchiveFallback(metrics.ArchiveFallbackError)
+		c.logger.LogExternalAPIError(ctx, "solana-archive-rpc", "getTransaction", archiveErr, 0,
+			slog.String("signature", info.Signature),
+			slog.Uint64("slot", uint64(info.Slot)))
+		return nil, fmt.Errorf("archive fallback failed: %w", archiveErr)
+	}
+}
+
+// archiveEligible reports whether a missing transaction is finalized and old enough to have been pruned
+func (c *HTTPClient) archiveEligible(ctx context.Context, info SignatureInfo) bool {
+	if c.archive == nil || info.ConfirmationStatus == nil || *info.ConfirmationStatus != string(CommitmentFinalized) {
+		return false
+	}
+
+	tip, err := c.GetSlot(ctx, CommitmentFinalized)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to get finalized slot, skipping archive fallback",
+			slog.String("signature", info.Signature),
+			slog.String("error", err.Error()))
+		return false
+	}
+
+	return tip >= info.Slot && tip-info.Slot >= c.config.ArchiveMinSlotAge
+}
+
+// GetSlot returns the current slot at the given commitment
+func (c *HTTPClient) GetSlot(ctx context.Context, commitment Commitment) (Slot, error) {
+	if err := commitment.Validate(); err != nil {
+		return 0, WrapValidationError("commitment", commitment, err.Error())
+	}
+
+	params := []interface{}{
+		map[string]interface{}{"commitment": string(commitment)},
+	}
+
+	var slot Slot
+	if err := c.request(ctx, "getSlot", params, &slot); err != nil {
+		return 0, fmt.Errorf("failed to get slot: %w", err)
+	}
+
+	return slot, nil
+}
+
+// GetTokenLargestAccounts returns the 20 largest token accounts of a mint, largest first
+func (c *HTTPClient) GetTokenLargestAccounts(ctx context.Context, mint Address, commitment Commitment) ([]TokenAccountBalance, error) {
+	if err := mint.Validate(); err != nil {
+		return nil, WrapValidationError("mint", mint, err.Error())
+	}
+
+	if err := commitment.Validate(); err != nil {
+		return nil, WrapValidationError("commitment", commitment, err.Error())
+	}
+
+	params := []interface{}{
+		mint.String(),
+		map[string]interface{}{"commitment": string(commitment)},
+	}
+
+	var response struct {
+		Context struct {
+			Slot Slot `json:"slot"`
+		} `json:"context"`
+		Value []TokenAccountBalance `json:"value"`
+	}
+
+	if err := c.request(ctx, "getTokenLargestAccounts", params, &response); err != nil {
+		return nil, fmt.Errorf("failed to get largest token accounts: %w", err)
+	}
+	dataContextFrom(ctx).observe(response.Context.Slot, commitment, time.Now())
+
+	return response.Value, nil
+}
+
+// GetTokenAccountsByOwner returns every token account owner holds for mint, associated or not
+func (c *HTTPClient) GetTokenAccountsByOwner(ctx context.Context, owner, mint Address, commitment Commitment) ([]KeyedAccount, error) {
+	if err := owner.Validate(); err != nil {
+		return nil, WrapValidationError("owner", owner, err.Error())
+	}
+
+	if err := mint.Validate(); err != nil {
+		return nil, WrapValidationError("mint", mint, err.Error())
+	}
+
+	if err := commitment.Validate(); err != nil {
+		return nil, WrapValidationError("commitment", commitment, err.Error())
+	}
+
+	params := []interface{}{
+		owner.String(),
+		map[string]interface{}{"mint": mint.String()},
+		map[string]interface{}{
+			"encoding":   "base64",
+			"commitment": string(commitment),
+		},
+	}
+
+	var response struct {
+		Context struct {
+			Slot Slot `json:"slot"`
+		} `json:"context"`
+		Value []KeyedAccount `json:"value"`
+	}
+
+	if err := c.request(ctx, "getTokenAccountsByOwner", params, &response); err != nil {
+		return nil, fmt.Errorf("failed to get token accounts by owner: %w", err)
+	}
+	dataContextFrom(ctx).observe(response.Context.Slot, commitment, time.Now())
 
-	return &response, nil
+	return response.Value, nil
 }
 
 // GetSignaturesForAddress fetches signatures for the given address
 func (c *HTTPClient) GetSignaturesForAddress(ctx context.Context, address Address, before string, limit int) ([]SignatureInfo, error) {
+	return c.GetSignaturesForAddressWithOptions(ctx, address, SignatureQuery{Before: before, Limit: limit})
+}
+
+// GetSignaturesForAddressWithOptions fetches signatures (newest first) between the query's before/until cursors
+// Signatures outside MinSlot/MaxSlot are dropped from the page after fetching, so a page of out-of-range
+// signatures comes back empty: callers paging through a slot range should page the unfiltered listing. Signatures listed
+// without a blockTime are backfilled from their slot with getBlockTime
+func (c *HTTPClient) GetSignaturesForAddressWithOptions(ctx context.Context, address Address, query SignatureQuery) ([]SignatureInfo, error) {
 	// Validate address
 	if err := address.Validate(); err != nil {
 		return nil, WrapValidationError("address", address, err.Error())
 	}
 
 	// Validate limit
-	if limit <= 0 || limit > 1000 {
-		return nil, WrapValidationError("limit", limit, "must be between 1 and 1000")
+	if query.Limit <= 0 || query.Limit > 1000 {
+		return nil, WrapValidationError("limit", query.Limit, "must be between 1 and 1000")
 	}
 
-	params := []interface{}{
-		address.String(),
-		map[string]interface{}{
-			"limit":      limit,
-			"commitment": "finalized", // Use finalized for historical data
-		},
+	// Validate slot range
+	if query.MaxSlot != 0 && query.MinSlot > query.MaxSlot {
+		return nil, WrapValidationError("slot_range", query.MinSlot, "min slot must not exceed max slot")
 	}
 
-	// Add before parameter if provided
-	if before != "" {
-		params[1].(map[string]interface{})["before"] = before
+	options := map[string]interface{}{
+		"limit":      query.Limit,
+		"commitment": "finalized", // Use finalized for historical data
+	}
+
+	// Add cursor parameters if provided
+	if query.Before != "" {
+		options["before"] = query.Before
+	}
+	if query.Until != "" {
+		options["until"] = query.Until
 	}
 
 	var response []SignatureInfo
 
-	if err := c.request(ctx, "getSignaturesForAddress", params, &response); err != nil {
+	if err := c.request(ctx, "getSignaturesForAddress", []interface{}{address.String(), options}, &response); err != nil {
 		return nil, fmt.Errorf("failed to get signatures: %w", err)
 	}
+	// The listing carries no context slot, but the node had finalized at least its newest signature
+	var newest Slot
+	for _, sig := range response {
+		newest = max(newest, sig.Slot)
+	}
+	dataContextFrom(ctx).observe(newest, CommitmentFinalized, time.Now())
 
-	return response, nil
+	for i := range response {
+		c.resolveBlockTime(ctx, response[i].Slot, &response[i].BlockTime)
+	}
+
+	if query.MinSlot == 0 && query.MaxSlot == 0 {
+		return response, nil
+	}
+
+	filtered := make([]SignatureInfo, 0, len(response))
+	for _, sig := range response {
+		if query.InSlotRange(sig.Slot) {
+			filtered = append(filtered, sig)
+		}
+	}
+
+	return filtered, nil
 }
 
 // request performs a JSON-RPC request with retry logic
 func (c *HTTPClient) request(ctx context.Context, method string, params interface{}, result interface{}) error {
+	return c.withRetry(ctx, method, 1, func(ctx context.Context) error {
+		return c.doRequest(ctx, method, params, result)
+	})
+}
+
+// withRetry runs each RPC attempt once the scheduler admits its calls, retrying retryable failures with
+// exponential backoff. method labels logs and the audit log; batches pass the method shared by their calls
+// and how many calls they carry
+func (c *HTTPClient) withRetry(ctx context.Context, method string, calls int, do func(ctx context.Context) error) error {
 	startTime := time.Now()
 	var lastErr error
 
@@ -173,9 +472,9 @@ func (c *HTTPClient) request(ctx context.Context, method string, params interfac
 		slog.Int("max_retries", c.config.MaxRetries))
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		// Calculate backoff delay (exponential with jitter)
+		// Back off according to the previous failure's class
 		if attempt > 0 {
-			delay := c.calculateBackoff(attempt - 1)
+			delay := c.retryDelay(lastErr, attempt-1)
 
 			// Log retry attempt
 			c.logger.WarnContext(ctx, "Retrying Solana RPC request",
@@ -183,6 +482,7 @@ func (c *HTTPClient) request(ctx context.Context, method string, params interfac
 				slog.Int("attempt", attempt+1),
 				slog.Int("max_retries", c.config.MaxRetries+1),
 				slog.Duration("backoff_delay", delay),
+				slog.String("error_class", string(Classify(lastErr))),
 				slog.String("previous_error", lastErr.Error()))
 
 			select {
@@ -192,7 +492,14 @@ func (c *HTTPClient) request(ctx context.Context, method string, params interfac
 			}
 		}
 
-		err := c.doRequest(ctx, method, params, result)
+		if err := c.scheduler.WaitN(ctx, calls); err != nil {
+			return err
+		}
+		attemptStart := time.Now()
+		err := do(audit.WithCall(ctx, auditTarget, method, attempt))
+		latency := time.Since(attemptStart)
+		c.scheduler.Observe(ctx, latency)
+		rpcUsageFrom(ctx).record(method, attempt, latency)
 		if err == nil {
 			totalTime := time.Since(startTime)
 
@@ -213,13 +520,16 @@ func (c *HTTPClient) request(ctx context.Context, method string, params interfac
 		}
 
 		lastErr = err
+		class := Classify(err)
+		metrics.RecordRPCError(string(class))
 
 		// Don't retry on validation errors or non-retryable errors
-		if !IsRetryable(err) {
+		if !retryStrategies[class].Retry {
 			c.logger.LogExternalAPIError(ctx, "solana-rpc", method, err, 0,
 				slog.Duration("total_time", time.Since(startTime)),
 				slog.Int("attempts", attempt+1),
-				slog.String("error_type", "non_retryable"))
+				slog.String("error_type", "non_retryable"),
+				slog.String("error_class", string(class)))
 			return err
 		}
 
@@ -241,10 +551,9 @@ func (c *HTTPClient) request(ctx context.Context, method string, params interfac
 // doRequest performs a single JSON-RPC request without retry
 func (c *HTTPClient) doRequest(ctx context.Context, method string, params interface{}, result interface{}) error {
 	// Create JSON-RPC request
-	c.rpcID++
 	req := JSONRPCRequest{
 		JSONRPC: "2.0",
-		ID:      c.rpcID,
+		ID:      int(c.rpcID.Add(1)),
 		Method:  method,
 		Params:  params,
 	}
@@ -255,6 +564,43 @@ func (c *HTTPClient) doRequest(ctx context.Context, method string, params interf
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	// Parse the JSON-RPC envelope straight off the body; the result is kept raw until its type is known
+	var rpcResp JSONRPCResponse
+	err = c.post(ctx, reqBody, func(body io.Reader) error {
+		if err := json.NewDecoder(body).Decode(&rpcResp); err != nil {
+			return fmt.Errorf("failed to parse RPC response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Check for RPC error
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	return decodeResult(rpcResp.Result, result)
+}
+
+// decodeResult decodes a raw JSON-RPC result into the caller's target; a missing result decodes like null
+func decodeResult(raw json.RawMessage, result interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(result); err != nil {
+		return fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return nil
+}
+
+// maxErrorBodyBytes caps how much of a non-200 reply is kept as the error message
+const maxErrorBodyBytes = 4 << 10
+
+// post sends a JSON-RPC payload and hands the body of a 200 reply to decode
+// The body is capped at MaxResponseBytes; exceeding it fails with ErrResponseTooLarge, which is not retried
+func (c *HTTPClient) post(ctx context.Context, reqBody []byte, decode func(body io.Reader) error) error {
 	// Create HTTP request
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.config.HttpURL, bytes.NewReader(reqBody))
 	if err != nil {
@@ -272,50 +618,109 @@ func (c *HTTPClient) doRequest(ctx context.Context, method string, params interf
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return WrapNetworkError(err, 1, false)
-	}
-
 	// Check HTTP status
 	if resp.StatusCode != http.StatusOK {
-		return WrapRPCError(resp.StatusCode, string(body), nil)
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		if err != nil {
+			return WrapNetworkError(err, 1, false)
+		}
+		return &RPCError{
+			Code:       resp.StatusCode,
+			Message:    string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
-	// Parse JSON-RPC response
-	var rpcResp JSONRPCResponse
-	if err := json.Unmarshal(body, &rpcResp); err != nil {
-		return fmt.Errorf("failed to parse RPC response: %w", err)
+	limit := c.config.MaxResponseBytes
+	if limit > 0 && resp.ContentLength > limit {
+		return responseTooLarge(limit)
 	}
 
-	// Check for RPC error
-	if rpcResp.Error != nil {
-		return rpcResp.Error
+	body := &responseReader{body: resp.Body, limit: limit}
+	if err := decode(body); err != nil {
+		// Oversized and interrupted bodies surface as decode errors; report their cause instead
+		if body.err != nil {
+			return body.err
+		}
+		return err
 	}
+	return nil
+}
 
-	// Marshal result back to JSON and unmarshal to target type
-	// This handles type conversion properly
-	resultBytes, err := json.Marshal(rpcResp.Result)
-	if err != nil {
-		return fmt.Errorf("failed to marshal result: %w", err)
+// responseReader enforces the response size limit while a decoder reads the body and remembers
+// why reading stopped, so post can tell transport failures from malformed JSON
+type responseReader struct {
+	body  io.Reader
+	limit int64 // 0 means unlimited
+	read  int64
+	err   error
+}
+
+func (r *responseReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	// Read at most one byte past the limit, enough to notice the body is too large
+	if r.limit > 0 && int64(len(p)) > r.limit-r.read+1 {
+		p = p[:r.limit-r.read+1]
 	}
 
-	if err := json.Unmarshal(resultBytes, result); err != nil {
-		return fmt.Errorf("failed to unmarshal result: %w", err)
+	n, err := r.body.Read(p)
+	r.read += int64(n)
+	switch {
+	case r.limit > 0 && r.read > r.limit:
+		r.err = responseTooLarge(r.limit)
+		return 0, r.err
+	case err != nil && err != io.EOF:
+		r.err = WrapNetworkError(err, 1, false)
+		return n, r.err
 	}
+	return n, err
+}
 
-	return nil
+func responseTooLarge(limit int64) error {
+	return fmt.Errorf("%w: body exceeds %d bytes", ErrResponseTooLarge, limit)
 }
 
 // calculateBackoff returns the backoff delay for the given attempt with jitter
 func (c *HTTPClient) calculateBackoff(attempt int) time.Duration {
+	return backoffDelay(c.config, attempt)
+}
+
+// retryDelay returns the wait before retrying after err on the given attempt
+// The error class's strategy shapes the backoff; a provider's Retry-After is honoured when longer
+func (c *HTTPClient) retryDelay(err error, attempt int) time.Duration {
+	strategy := StrategyFor(err)
+	if !strategy.Exponential {
+		attempt = 0
+	}
+	delay := time.Duration(float64(c.calculateBackoff(attempt)) * max(strategy.Multiplier, 1))
+	return max(delay, retryAfter(err))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date; 0 when absent or invalid
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// backoffDelay computes BaseBackoff * 2^attempt with ±50% jitter, capped at MaxBackoff
+// Shared by HTTP retries and WebSocket reconnects
+func backoffDelay(config *Config, attempt int) time.Duration {
 	// Exponential backoff: BaseBackoff * 2^attempt
-	backoff := time.Duration(float64(c.config.BaseBackoff) * math.Pow(2, float64(attempt)))
+	backoff := time.Duration(float64(config.BaseBackoff) * math.Pow(2, float64(attempt)))
 
 	// Cap at MaxBackoff
-	if backoff > c.config.MaxBackoff {
-		backoff = c.config.MaxBackoff
+	if backoff > config.MaxBackoff {
+		backoff = config.MaxBackoff
 	}
 
 	// Add jitter (±25% random variance)
@@ -327,8 +732,8 @@ func (c *HTTPClient) calculateBackoff(attempt int) time.Duration {
 	}
 
 	// Ensure backoff doesn't exceed MaxBackoff after jitter
-	if backoff > c.config.MaxBackoff {
-		backoff = c.config.MaxBackoff
+	if backoff > config.MaxBackoff {
+		backoff = config.MaxBackoff
 	}
 
 	// Ensure minimum 100ms backoff
@@ -342,8 +747,10 @@ func (c *HTTPClient) calculateBackoff(attempt int) time.Duration {
 // Close closes the HTTP client
 func (c *HTTPClient) Close() error {
 	c.logger.InfoContext(context.Background(), "Closing Solana HTTP client")
-	// HTTP client doesn't need explicit closing, but this provides
-	// a consistent interface with WebSocket client
+	c.httpClient.CloseIdleConnections()
+	if c.archive != nil {
+		return c.archive.Close()
+	}
 	return nil
 }
 
@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -164,6 +168,85 @@ func TestHTTPClient_GetAccount(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_GetSignaturesForAddressWithOptions(t *testing.T) {
+	successResp := loadTestData(t, "get_signatures_response.json")
+
+	var params []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		params = req.Params
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(successResp))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(NewConfig(server.URL, "ws://unused"), logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	address := Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	signatures, err := client.GetSignaturesForAddressWithOptions(context.Background(), address, SignatureQuery{
+		Until:   "watermarkSig",
+		MinSlot: 294112233,
+		Limit:   10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	options, _ := params[1].(map[string]interface{})
+	if options["until"] != "watermarkSig" {
+		t.Errorf("expected until to be forwarded, got %v", options)
+	}
+	if len(signatures) != 1 || signatures[0].Slot != 294112233 {
+		t.Errorf("expected signatures below min slot to be filtered, got %+v", signatures)
+	}
+
+	_, err = client.GetSignaturesForAddressWithOptions(context.Background(), address, SignatureQuery{MinSlot: 10, MaxSlot: 5, Limit: 10})
+	if err == nil {
+		t.Error("expected error for inverted slot range")
+	}
+}
+
+func TestHTTPClient_GetTokenAccountsByOwner(t *testing.T) {
+	var params []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		params = req.Params
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"context":{"slot":294112233},"value":[` +
+			`{"pubkey":"Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ","account":{"lamports":2039280,"owner":"TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA","data":["AQID","base64"],"executable":false,"rentEpoch":0}}]}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewHTTPClient(NewConfig(server.URL, "ws://unused"), logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	owner := Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	mint := Address("5YMkXAYccHSGnHn9nob9xEvv6Pvka9DZWH7nTbotTu9E")
+	accounts, err := client.GetTokenAccountsByOwner(context.Background(), owner, mint, CommitmentConfirmed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	filter, _ := params[1].(map[string]interface{})
+	if params[0] != owner.String() || filter["mint"] != mint.String() {
+		t.Errorf("expected owner and mint filter to be forwarded, got %v", params)
+	}
+	if len(accounts) != 1 || accounts[0].Pubkey != "Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ" || len(accounts[0].Account.Data) != 3 {
+		t.Errorf("unexpected accounts: %+v", accounts)
+	}
+}
+
 func TestHTTPClient_GetSignaturesForAddress(t *testing.T) {
 	successResp := loadTestData(t, "get_signatures_response.json")
 
@@ -292,7 +375,7 @@ func TestHTTPClient_RetryLogic(t *testing.T) {
 	}
 
 	// Test that retry logic works
-	ctx := context.Background()
+	ctx, usage := WithRPCUsage(context.Background())
 	account, err := client.GetAccount(ctx, "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g", CommitmentConfirmed)
 
 	if err != nil {
@@ -306,6 +389,10 @@ func TestHTTPClient_RetryLogic(t *testing.T) {
 	if attempts != 3 {
 		t.Errorf("expected 3 attempts, got %d", attempts)
 	}
+
+	if report := usage.Report(); report.Calls != 3 || report.Retries != 2 || report.Methods["getAccountInfo"] != 3 || report.UpstreamMS <= 0 {
+		t.Errorf("expected the attempts to be accounted to the request, got %+v", report)
+	}
 }
 
 func TestHTTPClient_GetTransaction(t *testing.T) {
@@ -378,6 +465,184 @@ func TestHTTPClient_GetTransaction(t *testing.T) {
 	}
 }
 
+// TestHTTPClient_ConcurrentUse shares one client across goroutines; run with -race to catch unsynchronized state
+func TestHTTPClient_ConcurrentUse(t *testing.T) {
+	accountResp := loadTestData(t, "get_account_response.json")
+	txResp := loadTestData(t, "get_transaction_response.json")
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	duplicates := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req JSONRPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		if seen[req.ID] {
+			duplicates++
+		}
+		seen[req.ID] = true
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == "getTransaction" {
+			w.Write([]byte(txResp))
+			return
+		}
+		w.Write([]byte(accountResp))
+	}))
+	defer server.Close()
+
+	config := NewConfig(server.URL, "ws://unused")
+	config.AccountCacheTTL = 0 // Every call must reach the server
+	client, err := NewHTTPClient(config, logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	const workers = 16
+	const callsPerWorker = 10
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	errs := make(chan error, workers*callsPerWorker)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < callsPerWorker; j++ {
+				var err error
+				if (worker+j)%2 == 0 {
+					_, err = client.GetAccount(ctx, "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g", CommitmentConfirmed)
+				} else {
+					_, err = client.GetTransaction(ctx, "5VWF2BTGZGS9c8uJ8ZmKGZwxAAaG5Wnr4drcmA8zbHEKkArDhYBm2HjRN1QAK1EzQ8sKvQw9GsJJ6sJ4x7q3LQg9")
+				}
+				if err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if duplicates != 0 {
+		t.Errorf("expected unique JSON-RPC request IDs, got %d duplicates", duplicates)
+	}
+	if len(seen) != workers*callsPerWorker {
+		t.Errorf("expected %d requests, got %d", workers*callsPerWorker, len(seen))
+	}
+}
+
+func TestHTTPClient_GetTransactionForSignature_ArchiveFallback(t *testing.T) {
+	successResp := loadTestData(t, "get_transaction_response.json")
+	const signature = "5VWF2BTGZGS9c8uJ8ZmKGZwxAAaG5Wnr4drcmA8zbHEKkArDhYBm2HjRN1QAK1EzQ8sKvQw9GsJJ6sJ4x7q3LQg9"
+	finalized := string(CommitmentFinalized)
+	confirmed := string(CommitmentConfirmed)
+
+	// Primary node has pruned every transaction and reports a finalized tip of slot 1,000,000
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req JSONRPCRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == "getSlot" {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":1000000}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer primary.Close()
+
+	var archiveCalls int
+	var mu sync.Mutex
+	archive := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		archiveCalls++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(successResp))
+	}))
+	defer archive.Close()
+
+	tests := []struct {
+		name         string
+		archiveURL   string
+		info         SignatureInfo
+		expectTx     bool
+		expectCalled bool
+	}{
+		{
+			name:         "old finalized signature uses archive",
+			archiveURL:   archive.URL,
+			info:         SignatureInfo{Signature: signature, Slot: 500000, ConfirmationStatus: &finalized},
+			expectTx:     true,
+			expectCalled: true,
+		},
+		{
+			name:       "recent signature is not retried",
+			archiveURL: archive.URL,
+			info:       SignatureInfo{Signature: signature, Slot: 999000, ConfirmationStatus: &finalized},
+		},
+		{
+			name:       "unfinalized signature is not retried",
+			archiveURL: archive.URL,
+			info:       SignatureInfo{Signature: signature, Slot: 500000, ConfirmationStatus: &confirmed},
+		},
+		{
+			name: "no archive configured",
+			info: SignatureInfo{Signature: signature, Slot: 500000, ConfirmationStatus: &finalized},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			archiveCalls = 0
+			mu.Unlock()
+
+			config := NewConfig(primary.URL, "ws://unused")
+			config.ArchiveHttpURL = tt.archiveURL
+			config.ArchiveMinSlotAge = 100000
+			client, err := NewHTTPClient(config, logger.NewFromEnv())
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			tx, err := client.GetTransactionForSignature(context.Background(), tt.info)
+			if tt.expectTx {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if tx == nil || tx.Slot != 294112233 {
+					t.Errorf("expected archived transaction, got %+v", tx)
+				}
+			} else if !errors.Is(err, ErrTransactionNotFound) {
+				t.Errorf("expected ErrTransactionNotFound, got %v", err)
+			}
+
+			mu.Lock()
+			called := archiveCalls > 0
+			mu.Unlock()
+			if called != tt.expectCalled {
+				t.Errorf("archive called = %v, want %v", called, tt.expectCalled)
+			}
+		})
+	}
+}
+
 func TestHTTPClient_MaxRetriesExceeded(t *testing.T) {
 	// Server that always fails
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -411,6 +676,85 @@ func TestHTTPClient_MaxRetriesExceeded(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_MaxResponseBytes(t *testing.T) {
+	successResp := loadTestData(t, "get_transaction_response.json")
+	signature := Signature("5VWF2BTGZGS9c8uJ8ZmKGZwxAAaG5Wnr4drcmA8zbHEKkArDhYBm2HjRN1QAK1EzQ8sKvQw9GsJJ6sJ4x7q3LQg9")
+
+	tests := []struct {
+		name     string
+		limit    int64
+		chunked  bool
+		expected error
+	}{
+		{name: "within limit", limit: int64(len(successResp))},
+		{name: "chunked body within limit", limit: int64(len(successResp)), chunked: true},
+		{name: "unlimited", limit: 0},
+		{name: "content length over limit", limit: int64(len(successResp)) - 1, expected: ErrResponseTooLarge},
+		{name: "chunked body over limit", limit: 64, chunked: true, expected: ErrResponseTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if tt.chunked {
+					// Flushing before the body is written leaves the reply without a Content-Length
+					w.(http.Flusher).Flush()
+				} else {
+					w.Header().Set("Content-Length", fmt.Sprint(len(successResp)))
+				}
+				w.Write([]byte(successResp))
+			}))
+			defer server.Close()
+
+			config := NewConfig(server.URL, "ws://unused")
+			config.BaseBackoff = time.Millisecond
+			config.MaxBackoff = 5 * time.Millisecond
+			config.MaxResponseBytes = tt.limit
+			client, err := NewHTTPClient(config, logger.NewFromEnv())
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			tx, err := client.GetTransaction(context.Background(), signature)
+			if tt.expected == nil {
+				if err != nil || tx == nil || tx.Slot != 294112233 {
+					t.Fatalf("expected the transaction to decode, got %+v, %v", tx, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			if n := calls.Load(); n != 1 {
+				t.Errorf("expected an oversized response not to be retried, got %d calls", n)
+			}
+		})
+	}
+}
+
+func TestDecodeResult(t *testing.T) {
+	// Integers beyond float64 precision survive because the result is never decoded into interface{}
+	var amount struct {
+		Amount uint64 `json:"amount"`
+	}
+	if err := decodeResult(json.RawMessage(`{"amount":18446744073709551615}`), &amount); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if amount.Amount != 18446744073709551615 {
+		t.Errorf("expected the full uint64, got %d", amount.Amount)
+	}
+
+	var missing *AccountInfo
+	if err := decodeResult(nil, &missing); err != nil || missing != nil {
+		t.Errorf("expected a missing result to decode like null, got %+v, %v", missing, err)
+	}
+	if err := decodeResult(json.RawMessage(`"text"`), &amount); err == nil {
+		t.Error("expected a type mismatch to fail")
+	}
+}
+
 func TestHTTPClient_ContextTimeout(t *testing.T) {
 	// Server with artificial delay
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -743,6 +1087,9 @@ func TestSignatureInfo_GetTime(t *testing.T) {
 	if !actualTime.Equal(expectedTime) {
 		t.Errorf("expected time %v, got %v", expectedTime, actualTime)
 	}
+	if actualTime.Location() != time.UTC {
+		t.Errorf("expected a UTC time, got %v", actualTime.Location())
+	}
 
 	// Test with nil block time
 	sigInfoNil := &SignatureInfo{BlockTime: nil}
@@ -796,8 +1143,153 @@ func TestAccountInfo_UnmarshalJSON_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestNewTransport(t *testing.T) {
+	config := NewConfig("http://localhost:8899", "ws://localhost:8900")
+	config.MaxIdleConnsPerHost = 64
+	config.EnableHTTP2 = true
+
+	transport := newTransport(config)
+	if transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("expected MaxIdleConnsPerHost 64, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxIdleConns != DefaultMaxIdleConns {
+		t.Errorf("expected MaxIdleConns %d, got %d", DefaultMaxIdleConns, transport.MaxIdleConns)
+	}
+	if transport.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("expected IdleConnTimeout %v, got %v", DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be enabled")
+	}
+	if transport.DisableKeepAlives {
+		t.Error("expected keep-alives to be enabled by default")
+	}
+}
+
+func TestConfig_LoadTransportFromEnvironment(t *testing.T) {
+	t.Setenv("SOLANA_RPC_MAX_IDLE_CONNS", "200")
+	t.Setenv("SOLANA_RPC_MAX_IDLE_CONNS_PER_HOST", "16")
+	t.Setenv("SOLANA_RPC_IDLE_CONN_TIMEOUT_SEC", "45")
+	t.Setenv("SOLANA_RPC_KEEPALIVE_SEC", "not-a-number")
+	t.Setenv("SOLANA_RPC_DISABLE_KEEPALIVES", "true")
+	t.Setenv("SOLANA_RPC_HTTP2", "true")
+	t.Setenv("SOLANA_RPC_MAX_RESPONSE_BYTES", "1048576")
+
+	config := NewConfig("http://localhost:8899", "ws://localhost:8900")
+	config.LoadTransportFromEnvironment()
+
+	if config.MaxIdleConns != 200 {
+		t.Errorf("expected MaxIdleConns 200, got %d", config.MaxIdleConns)
+	}
+	if config.MaxIdleConnsPerHost != 16 {
+		t.Errorf("expected MaxIdleConnsPerHost 16, got %d", config.MaxIdleConnsPerHost)
+	}
+	if config.IdleConnTimeout != 45*time.Second {
+		t.Errorf("expected IdleConnTimeout 45s, got %v", config.IdleConnTimeout)
+	}
+	if config.KeepAlive != DefaultKeepAlive {
+		t.Errorf("expected invalid keep-alive to be ignored, got %v", config.KeepAlive)
+	}
+	if !config.DisableKeepAlives || !config.EnableHTTP2 {
+		t.Error("expected boolean transport flags to be applied")
+	}
+	if config.MaxResponseBytes != 1<<20 {
+		t.Errorf("expected MaxResponseBytes 1048576, got %d", config.MaxResponseBytes)
+	}
+}
+
+func TestHTTPClient_FixtureMode(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "getAccountInfo.json"), []byte(loadTestData(t, "get_account_response.json")), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	// No server is listening on this URL; responses must come from fixtures
+	config := NewConfig("http://127.0.0.1:1", "ws://127.0.0.1:1")
+	config.FixtureDir = dir
+	config.MaxRetries = 0
+
+	client, err := NewHTTPClient(config, logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	account, err := client.GetAccount(context.Background(), "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g", CommitmentFinalized)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account == nil || len(account.Data) == 0 {
+		t.Error("expected account data from fixture")
+	}
+
+	if _, err := client.GetTransaction(context.Background(), "5VERv8NMvzbJMEkV8xnrLkEaWRtSz9CosKDYjCJjBRnbJLgp8uirBgmQpjKhoR4tjF3ZpRzrFmBV6UjKdiSZkQUW"); err == nil {
+		t.Error("expected error for missing fixture")
+	}
+}
+
+// BenchmarkHTTPClient_ConcurrentGetAccount compares p95 latency of concurrent
+// RPC calls with a tuned connection pool against per-request connections
+func BenchmarkHTTPClient_ConcurrentGetAccount(b *testing.B) {
+	response := []byte(loadTestData(b, "get_account_response.json"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		name  string
+		tweak func(*Config)
+	}{
+		{name: "no_keepalive", tweak: func(c *Config) { c.DisableKeepAlives = true }},
+		{name: "default_pool", tweak: func(c *Config) { c.MaxIdleConns, c.MaxIdleConnsPerHost = 0, 0 }},
+		{name: "tuned_pool", tweak: func(c *Config) {}},
+	}
+
+	for _, bc := range cases {
+		b.Run(bc.name, func(b *testing.B) {
+			config := NewConfig(server.URL, "ws://localhost:8900")
+			config.AccountCacheTTL = 0
+			bc.tweak(config)
+			client, err := NewHTTPClient(config, logger.NewFromEnv())
+			if err != nil {
+				b.Fatalf("failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			var mu sync.Mutex
+			latencies := make([]time.Duration, 0, b.N)
+
+			b.SetParallelism(8)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					start := time.Now()
+					if _, err := client.GetAccount(context.Background(), "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g", CommitmentFinalized); err != nil {
+						b.Errorf("GetAccount failed: %v", err)
+						return
+					}
+					elapsed := time.Since(start)
+
+					mu.Lock()
+					latencies = append(latencies, elapsed)
+					mu.Unlock()
+				}
+			})
+			b.StopTimer()
+
+			if len(latencies) == 0 {
+				return
+			}
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+			p95 := latencies[len(latencies)*95/100]
+			b.ReportMetric(float64(p95.Microseconds()), "p95-µs")
+		})
+	}
+}
+
 // Helper function to load test data from files
-func loadTestData(t *testing.T, filename string) string {
+func loadTestData(t testing.TB, filename string) string {
 	t.Helper()
 
 	path := filepath.Join("testdata", filename)
@@ -808,3 +1300,149 @@ func loadTestData(t *testing.T, filename string) string {
 
 	return string(data)
 }
+
+func TestErrors_Classify(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		class     ErrorClass
+		sentinel  error
+		retryable bool
+	}{
+		{"http 429", &RPCError{Code: http.StatusTooManyRequests}, ClassRateLimited, ErrRateLimited, true},
+		{"provider rate limit", &RPCError{Code: -32429}, ClassRateLimited, ErrRateLimited, true},
+		{"node unhealthy", &RPCError{Code: -32005, Message: "Node is behind by 42 slots"}, ClassNodeBehind, ErrNodeBehind, true},
+		{"min context slot", &RPCError{Code: -32016}, ClassNodeBehind, ErrNodeBehind, true},
+		{"long-term storage", fmt.Errorf("failed to get transaction: %w", &RPCError{Code: -32019}), ClassLongTermStorage, ErrLongTermStorage, false},
+		{"slot skipped", &RPCError{Code: -32007}, ClassSlotSkipped, ErrSlotSkipped, false},
+		{"long-term storage slot skipped", &RPCError{Code: -32009}, ClassSlotSkipped, ErrSlotSkipped, false},
+		{"server error", &RPCError{Code: http.StatusBadGateway}, ClassServer, nil, true},
+		{"invalid params", &RPCError{Code: -32602}, ClassPermanent, nil, false},
+		{"network", &NetworkError{Err: errors.New("reset"), Attempt: 1}, ClassNetwork, nil, true},
+		{"retries exhausted", &NetworkError{Err: errors.New("reset"), Attempt: 3, Final: true}, ClassPermanent, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if class := Classify(tt.err); class != tt.class {
+				t.Errorf("expected class %s, got %s", tt.class, class)
+			}
+			if tt.sentinel != nil && !errors.Is(tt.err, tt.sentinel) {
+				t.Errorf("expected errors.Is(%v, %v)", tt.err, tt.sentinel)
+			}
+			if IsRetryable(tt.err) != tt.retryable {
+				t.Errorf("expected retryable %v", tt.retryable)
+			}
+		})
+	}
+
+	if errors.Is(&RPCError{Code: -32005}, ErrSlotSkipped) {
+		t.Error("node behind must not match ErrSlotSkipped")
+	}
+}
+
+func TestHTTPClient_RetryDelay(t *testing.T) {
+	config := NewConfig("http://localhost", "ws://localhost")
+	config.BaseBackoff = 200 * time.Millisecond
+	config.MaxBackoff = 10 * time.Second
+	client, err := NewHTTPClient(config, logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	// Node behind retries at the base delay (±50% jitter) however many attempts have failed
+	if delay := client.retryDelay(&RPCError{Code: -32005}, 4); delay > 300*time.Millisecond {
+		t.Errorf("expected node behind to retry quickly, got %v", delay)
+	}
+	// Rate limits back off twice as hard: 800ms ±50% doubled at the third attempt
+	if delay := client.retryDelay(&RPCError{Code: 429}, 2); delay < 800*time.Millisecond {
+		t.Errorf("expected rate limit to back off harder, got %v", delay)
+	}
+	// A longer Retry-After wins
+	if delay := client.retryDelay(&RPCError{Code: 429, RetryAfter: 30 * time.Second}, 0); delay != 30*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %v", delay)
+	}
+
+	now := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	if d := parseRetryAfter("7", now); d != 7*time.Second {
+		t.Errorf("expected 7s, got %v", d)
+	}
+	if d := parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now); d != time.Minute {
+		t.Errorf("expected 1m, got %v", d)
+	}
+	if d := parseRetryAfter("soon", now); d != 0 {
+		t.Errorf("expected 0 for an invalid header, got %v", d)
+	}
+}
+
+func TestHTTPClient_ErrorClassRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    func(w http.ResponseWriter)
+		attempts int
+		sentinel error
+	}{
+		{
+			name: "rate limit is retried",
+			reply: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			attempts: 3,
+			sentinel: ErrRateLimited,
+		},
+		{
+			name: "node behind is retried",
+			reply: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"Node is unhealthy"}}`))
+			},
+			attempts: 3,
+			sentinel: ErrNodeBehind,
+		},
+		{
+			name: "slot skipped is not retried",
+			reply: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32007,"message":"Slot 5 was skipped"}}`))
+			},
+			attempts: 1,
+			sentinel: ErrSlotSkipped,
+		},
+		{
+			name: "long-term storage is not retried",
+			reply: func(w http.ResponseWriter) {
+				w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32019,"message":"Failed to query long-term storage"}}`))
+			},
+			attempts: 1,
+			sentinel: ErrLongTermStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.Header().Set("Content-Type", "application/json")
+				tt.reply(w)
+			}))
+			defer server.Close()
+
+			config := NewConfig(server.URL, "ws://unused")
+			config.BaseBackoff = time.Millisecond
+			config.MaxBackoff = 5 * time.Millisecond
+			config.MaxRetries = 2
+			client, err := NewHTTPClient(config, logger.NewFromEnv())
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.GetSlot(context.Background(), CommitmentFinalized)
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("expected %v, got %v", tt.sentinel, err)
+			}
+			if attempts != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, attempts)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ type Service struct {
 	config        *Config
 	logger        *logger.Logger
 	httpClient    *HTTPClient
+	wsClient      *WSClient
 	healthTracker *HealthTracker
 	mu            sync.RWMutex
 	closed        bool
@@ -52,6 +53,16 @@ func NewService(config *Config) (*Service, error) {
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
+	// Create WebSocket client; it connects only once a consumer calls Start
+	wsClient, err := NewWSClient(config, serviceLogger)
+	if err != nil {
+		serviceLogger.LogHandlerError(context.Background(), "service_initialization", err,
+			slog.String("error_type", "ws_client_creation"))
+		return nil, fmt.Errorf("failed to create WebSocket client: %w", err)
+	}
+	// Account notifications invalidate the HTTP client's cached accounts
+	wsClient.accounts = httpClient.accounts
+
 	// Create health tracker
 	healthTracker := NewHealthTracker()
 
@@ -59,6 +70,7 @@ func NewService(config *Config) (*Service, error) {
 		config:        config,
 		logger:        serviceLogger,
 		httpClient:    httpClient,
+		wsClient:      wsClient,
 		healthTracker: healthTracker,
 	}
 
@@ -82,6 +94,28 @@ func (s *Service) GetHTTPClient() *HTTPClient {
 	return s.httpClient
 }
 
+// GetChainClient returns the managed HTTP client as a ChainClient for services that read on-chain state
+// Returns a nil interface once the service is closed
+func (s *Service) GetChainClient() ChainClient {
+	if client := s.GetHTTPClient(); client != nil {
+		return client
+	}
+	return nil
+}
+
+// GetWSClient returns the managed WebSocket client for account and log subscriptions
+// Callers must Start it; reconnects and resubscriptions are handled by the client
+func (s *Service) GetWSClient() *WSClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil
+	}
+
+	return s.wsClient
+}
+
 // Health returns the current health status of the Solana connection
 func (s *Service) Health(ctx context.Context) *HealthStatus {
 	// Get current status from health tracker
@@ -172,6 +206,14 @@ func (s *Service) Close() error {
 	s.logger.InfoContext(context.Background(), "Closing Solana service")
 	s.closed = true
 
+	// Close WebSocket client
+	if s.wsClient != nil {
+		if err := s.wsClient.Close(); err != nil {
+			s.logger.LogHandlerError(context.Background(), "service_close", err,
+				slog.String("error_type", "ws_client_close"))
+		}
+	}
+
 	// Close HTTP client
 	if s.httpClient != nil {
 		if err := s.httpClient.Close(); err != nil {
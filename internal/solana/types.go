@@ -156,6 +156,25 @@ type TxMeta struct {
 	PostBalances      []uint64       `json:"postBalances"`
 	PreTokenBalances  []TokenBalance `json:"preTokenBalances"`
 	PostTokenBalances []TokenBalance `json:"postTokenBalances"`
+
+	// InnerInstructions lists the instructions invoked through CPI, grouped by top-level instruction
+	InnerInstructions []InnerInstructions `json:"innerInstructions"`
+
+	// LoadedAddresses are the accounts a versioned transaction loaded from address lookup tables;
+	// they follow AccountKeys in account index order, writable first
+	LoadedAddresses *LoadedAddresses `json:"loadedAddresses,omitempty"`
+}
+
+// InnerInstructions are the instructions invoked while the top-level instruction at Index ran
+type InnerInstructions struct {
+	Index        uint8           `json:"index"`
+	Instructions []TxInstruction `json:"instructions"`
+}
+
+// LoadedAddresses are the accounts loaded from address lookup tables
+type LoadedAddresses struct {
+	Writable []string `json:"writable"`
+	Readonly []string `json:"readonly"`
 }
 
 // TokenBalance represents a token balance in transaction metadata
@@ -175,6 +194,18 @@ type UITokenAmount struct {
 	UIAmountString string   `json:"uiAmountString"` // Human readable as string
 }
 
+// TokenAccountBalance is a token account entry returned by getTokenLargestAccounts
+type TokenAccountBalance struct {
+	Address string `json:"address"`
+	UITokenAmount
+}
+
+// KeyedAccount is an account returned with its address by getTokenAccountsByOwner
+type KeyedAccount struct {
+	Pubkey  Address     `json:"pubkey"`
+	Account AccountInfo `json:"account"`
+}
+
 // Transaction contains the actual transaction data
 type Transaction struct {
 	Message    TxMessage `json:"message"`
@@ -183,6 +214,7 @@ type Transaction struct {
 
 // TxMessage contains the transaction message
 type TxMessage struct {
+	Header          MessageHeader   `json:"header"`
 	AccountKeys     []string        `json:"accountKeys"`
 	Instructions    []TxInstruction `json:"instructions"`
 	RecentBlockhash string          `json:"recentBlockhash"`
@@ -192,7 +224,16 @@ type TxMessage struct {
 type TxInstruction struct {
 	ProgramIdIndex uint8   `json:"programIdIndex"`
 	Accounts       []uint8 `json:"accounts"`
-	Data           string  `json:"data"`
+	Data           string  `json:"data"`        // Base58 encoded
+	StackHeight    *int    `json:"stackHeight"` // Invocation depth, 1 for top-level instructions; reported by newer nodes
+}
+
+// MessageHeader tells which of a message's account keys sign and which are read-only:
+// signers come first, and the read-only accounts are the last ones of each group
+type MessageHeader struct {
+	NumRequiredSignatures       int `json:"numRequiredSignatures"`
+	NumReadonlySignedAccounts   int `json:"numReadonlySignedAccounts"`
+	NumReadonlyUnsignedAccounts int `json:"numReadonlyUnsignedAccounts"`
 }
 
 // SignatureInfo represents signature information from getSignaturesForAddress
@@ -205,10 +246,25 @@ type SignatureInfo struct {
 	Memo               *string     `json:"memo"`
 }
 
-// GetTime returns the block time as a time.Time, or zero time if nil
+// SignatureQuery selects a window of signatures for getSignaturesForAddress
+// Before and Until are exclusive signature bounds; MinSlot and MaxSlot are inclusive and 0 means unbounded
+type SignatureQuery struct {
+	Before  string
+	Until   string
+	MinSlot Slot
+	MaxSlot Slot
+	Limit   int
+}
+
+// InSlotRange reports whether slot falls within the query's slot bounds
+func (q *SignatureQuery) InSlotRange(slot Slot) bool {
+	return (q.MinSlot == 0 || slot >= q.MinSlot) && (q.MaxSlot == 0 || slot <= q.MaxSlot)
+}
+
+// GetTime returns the block time as a UTC time.Time, or zero time if nil
 func (si *SignatureInfo) GetTime() time.Time {
 	if si.BlockTime == nil {
 		return time.Time{}
 	}
-	return time.Unix(*si.BlockTime, 0)
+	return time.Unix(*si.BlockTime, 0).UTC()
 }
@@ -0,0 +1,72 @@
+package solana
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RPCUsage accumulates the RPC requests made on behalf of one API request; safe for concurrent use
+// Reads served from caches make no request and are not counted
+type RPCUsage struct {
+	mu      sync.Mutex
+	calls   int
+	retries int
+	latency time.Duration
+	methods map[string]int
+}
+
+// RPCUsageReport is a snapshot of an RPCUsage
+type RPCUsageReport struct {
+	Calls      int            `json:"calls"`       // Upstream requests sent, retries included; a batch is one request
+	Retries    int            `json:"retries"`     // Requests that repeated a failed attempt
+	UpstreamMS float64        `json:"upstream_ms"` // Time spent waiting on upstream responses, excluding backoff and queueing
+	Methods    map[string]int `json:"methods"`     // Requests per JSON-RPC method
+}
+
+type rpcUsageKey struct{}
+
+// WithRPCUsage returns a context whose RPC requests are recorded in the returned usage
+func WithRPCUsage(ctx context.Context) (context.Context, *RPCUsage) {
+	usage := &RPCUsage{methods: make(map[string]int)}
+	return context.WithValue(ctx, rpcUsageKey{}, usage), usage
+}
+
+// rpcUsageFrom returns the usage recorded for ctx, or nil when none is
+func rpcUsageFrom(ctx context.Context) *RPCUsage {
+	usage, _ := ctx.Value(rpcUsageKey{}).(*RPCUsage)
+	return usage
+}
+
+// record counts one upstream attempt of method that took latency
+func (u *RPCUsage) record(method string, attempt int, latency time.Duration) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.calls++
+	if attempt > 0 {
+		u.retries++
+	}
+	u.latency += latency
+	u.methods[method]++
+}
+
+// Report returns the usage recorded so far
+func (u *RPCUsage) Report() RPCUsageReport {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	methods := make(map[string]int, len(u.methods))
+	for method, calls := range u.methods {
+		methods[method] = calls
+	}
+	return RPCUsageReport{
+		Calls:      u.calls,
+		Retries:    u.retries,
+		UpstreamMS: float64(u.latency.Microseconds()) / 1000,
+		Methods:    methods,
+	}
+}
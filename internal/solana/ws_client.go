@@ -0,0 +1,615 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+)
+
+// WebSocket subscription methods supported by the client
+const (
+	wsMethodAccountSubscribe = "accountSubscribe"
+	wsMethodLogsSubscribe    = "logsSubscribe"
+	wsMethodSlotSubscribe    = "slotSubscribe"
+
+	// wsNotificationBuffer is the per-subscription notification channel capacity
+	wsNotificationBuffer = 64
+
+	// wsWriteTimeout bounds a single frame write, including pings
+	wsWriteTimeout = 10 * time.Second
+)
+
+// ErrWSNotConnected is returned when a request is made while the WebSocket is down
+var ErrWSNotConnected = errors.New("websocket not connected")
+
+// Subscription is an account or logs subscription that survives reconnects
+// Notifications receives the raw "result" of each notification; it is closed on Unsubscribe or client Close
+type Subscription struct {
+	ID            uint64
+	Method        string
+	Notifications <-chan json.RawMessage
+
+	params   []interface{}
+	notify   chan json.RawMessage
+	handle   func(json.RawMessage)
+	serverID uint64
+	active   bool
+}
+
+// WSStats reports connection and subscription health for the WebSocket client
+type WSStats struct {
+	Connected     bool      `json:"connected"`
+	Disconnects   uint64    `json:"disconnects"`
+	Reconnects    uint64    `json:"reconnects"`
+	Resubscribed  uint64    `json:"resubscribed"`
+	Dropped       uint64    `json:"dropped_notifications"`
+	LastSlot      uint64    `json:"last_slot"`
+	LastSlotAt    time.Time `json:"last_slot_at"`
+	Subscriptions int       `json:"subscriptions"`
+}
+
+// WSClient maintains a Solana RPC WebSocket connection with automatic reconnect
+// Active subscriptions are restored after every reconnect; silent stalls are detected
+// through ping/pong read deadlines and an internal slot subscription watchdog
+type WSClient struct {
+	config *Config
+	logger *logger.Logger
+	dialer *websocket.Dialer
+
+	mu         sync.Mutex
+	conn       *websocket.Conn
+	nextID     uint64
+	pending    map[uint64]chan wsResponse
+	subs       map[uint64]*Subscription
+	serverSubs map[uint64]uint64
+	slotSub    *Subscription
+	stats      WSStats
+	started    bool
+	closed     bool
+
+	// accounts is the HTTP client's account cache, kept current by account subscriptions; nil when unshared
+	accounts *accountCache
+
+	writeMu sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+type wsRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params,omitempty"`
+}
+
+type wsResponse struct {
+	Result json.RawMessage
+	Err    error
+}
+
+type wsMessage struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+	Method string          `json:"method"`
+	Params *struct {
+		Result       json.RawMessage `json:"result"`
+		Subscription uint64          `json:"subscription"`
+	} `json:"params"`
+}
+
+// NewWSClient creates a WebSocket client; no connection is made until Start
+func NewWSClient(config *Config, serviceLogger *logger.Logger) (*WSClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	client := &WSClient{
+		config:     config,
+		logger:     serviceLogger.WithComponent("solana-ws-client"),
+		dialer:     &websocket.Dialer{HandshakeTimeout: config.ReconnectTimeout},
+		pending:    make(map[uint64]chan wsResponse),
+		subs:       make(map[uint64]*Subscription),
+		serverSubs: make(map[uint64]uint64),
+		done:       make(chan struct{}),
+	}
+
+	// The slot subscription is the liveness signal for stall detection
+	client.slotSub = client.register(wsMethodSlotSubscribe, nil, client.handleSlot)
+
+	return client, nil
+}
+
+// Start launches the connection loop in the background; it runs until ctx is cancelled or Close is called
+func (c *WSClient) Start(ctx context.Context) {
+	c.mu.Lock()
+	if c.started || c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.started = true
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.mu.Unlock()
+
+	go c.run(ctx)
+}
+
+// AccountSubscribe subscribes to account data changes for address
+func (c *WSClient) AccountSubscribe(ctx context.Context, address Address, commitment Commitment) (*Subscription, error) {
+	if err := address.Validate(); err != nil {
+		return nil, err
+	}
+	params := []interface{}{string(address), map[string]interface{}{
+		"encoding":   "base64",
+		"commitment": string(commitment),
+	}}
+	return c.subscribe(ctx, wsMethodAccountSubscribe, params)
+}
+
+// LogsSubscribe subscribes to transaction logs mentioning address
+func (c *WSClient) LogsSubscribe(ctx context.Context, mentions Address, commitment Commitment) (*Subscription, error) {
+	if err := mentions.Validate(); err != nil {
+		return nil, err
+	}
+	params := []interface{}{
+		map[string]interface{}{"mentions": []string{string(mentions)}},
+		map[string]interface{}{"commitment": string(commitment)},
+	}
+	return c.subscribe(ctx, wsMethodLogsSubscribe, params)
+}
+
+// Unsubscribe removes a subscription and closes its notification channel
+func (c *WSClient) Unsubscribe(ctx context.Context, sub *Subscription) error {
+	c.mu.Lock()
+	if _, ok := c.subs[sub.ID]; !ok {
+		c.mu.Unlock()
+		return nil
+	}
+	delete(c.subs, sub.ID)
+	serverID, active := sub.serverID, sub.active
+	if active {
+		delete(c.serverSubs, serverID)
+		if address, ok := sub.accountAddress(); ok {
+			c.accounts.unwatch(address)
+		}
+	}
+	close(sub.notify)
+	c.mu.Unlock()
+
+	if !active {
+		return nil
+	}
+
+	method := strings.TrimSuffix(sub.Method, "Subscribe") + "Unsubscribe"
+	if _, err := c.call(ctx, method, []interface{}{serverID}); err != nil && !errors.Is(err, ErrWSNotConnected) {
+		return fmt.Errorf("failed to unsubscribe %d: %w", sub.ID, err)
+	}
+	return nil
+}
+
+// Stats returns a snapshot of connection counters and the last observed slot
+func (c *WSClient) Stats() WSStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Connected = c.conn != nil
+	stats.Subscriptions = len(c.subs) - 1 // exclude the internal slot subscription
+	return stats
+}
+
+// Close stops the connection loop and closes all notification channels
+func (c *WSClient) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	started := c.started
+	cancel := c.cancel
+	c.mu.Unlock()
+
+	if started {
+		cancel()
+		<-c.done
+	}
+
+	c.mu.Lock()
+	for id, sub := range c.subs {
+		if sub.notify != nil {
+			close(sub.notify)
+		}
+		delete(c.subs, id)
+	}
+	c.mu.Unlock()
+
+	c.logger.InfoContext(context.Background(), "Solana WebSocket client closed")
+	return nil
+}
+
+// register adds a subscription to the local table; it is sent to the server on the next (re)connect
+func (c *WSClient) register(method string, params []interface{}, handle func(json.RawMessage)) *Subscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	sub := &Subscription{ID: c.nextID, Method: method, params: params, handle: handle}
+	if handle == nil {
+		sub.notify = make(chan json.RawMessage, wsNotificationBuffer)
+		sub.Notifications = sub.notify
+	}
+	c.subs[sub.ID] = sub
+	return sub
+}
+
+// subscribe registers a subscription and activates it immediately when connected
+// While disconnected the subscription is queued and activated by the reconnect loop
+func (c *WSClient) subscribe(ctx context.Context, method string, params []interface{}) (*Subscription, error) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	if closed {
+		return nil, fmt.Errorf("websocket client is closed")
+	}
+
+	sub := c.register(method, params, nil)
+	if err := c.activate(ctx, sub); err != nil && !errors.Is(err, ErrWSNotConnected) {
+		_ = c.Unsubscribe(ctx, sub)
+		return nil, err
+	}
+	return sub, nil
+}
+
+// activate sends the subscribe request and maps the server subscription id to the local one
+func (c *WSClient) activate(ctx context.Context, sub *Subscription) error {
+	result, err := c.call(ctx, sub.Method, sub.params)
+	if err != nil {
+		return err
+	}
+
+	var serverID uint64
+	if err := json.Unmarshal(result, &serverID); err != nil {
+		return fmt.Errorf("invalid %s response: %w", sub.Method, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.subs[sub.ID]; !ok {
+		return nil // unsubscribed while the request was in flight
+	}
+	sub.serverID = serverID
+	sub.active = true
+	c.serverSubs[serverID] = sub.ID
+	if address, ok := sub.accountAddress(); ok {
+		c.accounts.watch(address)
+	}
+	return nil
+}
+
+// call sends a JSON-RPC request over the current connection and waits for its response
+func (c *WSClient) call(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	c.mu.Lock()
+	conn := c.conn
+	if conn == nil {
+		c.mu.Unlock()
+		return nil, ErrWSNotConnected
+	}
+	c.nextID++
+	id := c.nextID
+	responseCh := make(chan wsResponse, 1)
+	c.pending[id] = responseCh
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.write(conn, wsRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWSNotConnected, err)
+	}
+
+	timer := time.NewTimer(c.config.RequestTimeout)
+	defer timer.Stop()
+
+	select {
+	case response, ok := <-responseCh:
+		if !ok {
+			return nil, ErrWSNotConnected
+		}
+		return response.Result, response.Err
+	case <-timer.C:
+		return nil, fmt.Errorf("%s timed out after %v", method, c.config.RequestTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *WSClient) write(conn *websocket.Conn, request wsRequest) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return conn.WriteJSON(request)
+}
+
+// run dials, serves and reconnects with exponential backoff until ctx is cancelled
+func (c *WSClient) run(ctx context.Context) {
+	defer close(c.done)
+
+	attempt := 0
+	connectedBefore := false
+	for ctx.Err() == nil {
+		conn, _, err := c.dialer.DialContext(ctx, c.config.WebSocketURL, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			metrics.RecordWSDisconnect(metrics.WSDisconnectDialError)
+			delay := backoffDelay(c.config, attempt)
+			c.logger.WarnContext(ctx, "Solana WebSocket dial failed",
+				slog.String("error", err.Error()),
+				slog.Int("attempt", attempt+1),
+				slog.Duration("backoff_delay", delay))
+			attempt++
+			if !sleepContext(ctx, delay) {
+				return
+			}
+			continue
+		}
+
+		reconnected := connectedBefore
+		if reconnected {
+			c.mu.Lock()
+			c.stats.Reconnects++
+			c.mu.Unlock()
+			metrics.RecordWSReconnect()
+		}
+		connectedBefore = true
+		attempt = 0
+
+		reason := c.serve(ctx, conn, reconnected)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.mu.Lock()
+		c.stats.Disconnects++
+		c.mu.Unlock()
+		metrics.RecordWSDisconnect(reason)
+
+		delay := backoffDelay(c.config, 0)
+		c.logger.WarnContext(ctx, "Solana WebSocket disconnected, reconnecting",
+			slog.String("reason", reason),
+			slog.Duration("backoff_delay", delay))
+		if !sleepContext(ctx, delay) {
+			return
+		}
+	}
+}
+
+// serve owns a single connection: it (re)activates subscriptions, sends pings and watches for stalls
+// Returns the disconnect reason once the connection is unusable
+func (c *WSClient) serve(ctx context.Context, conn *websocket.Conn, reconnected bool) string {
+	readDeadline := 2 * c.config.HeartbeatInterval
+	_ = conn.SetReadDeadline(time.Now().Add(readDeadline))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readDeadline))
+	})
+
+	c.mu.Lock()
+	c.conn = conn
+	c.serverSubs = make(map[uint64]uint64)
+	c.stats.LastSlotAt = time.Now()
+	subs := make([]*Subscription, 0, len(c.subs))
+	for _, sub := range c.subs {
+		sub.active = false
+		subs = append(subs, sub)
+	}
+	c.mu.Unlock()
+	metrics.SetWSConnected(true)
+
+	readErr := make(chan error, 1)
+	go func() { readErr <- c.readLoop(conn, readDeadline) }()
+
+	defer func() {
+		conn.Close()
+		c.mu.Lock()
+		c.conn = nil
+		for id, ch := range c.pending {
+			close(ch)
+			delete(c.pending, id)
+		}
+		c.accounts.unwatchAll()
+		c.mu.Unlock()
+		metrics.SetWSConnected(false)
+	}()
+
+	go c.resubscribe(ctx, subs, reconnected)
+
+	ticker := time.NewTicker(c.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	watchdogInterval := c.config.HeartbeatInterval
+	if c.config.StallTimeout > 0 && c.config.StallTimeout/2 < watchdogInterval {
+		watchdogInterval = c.config.StallTimeout / 2
+	}
+	watchdog := time.NewTicker(watchdogInterval)
+	defer watchdog.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.writeMu.Lock()
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			c.writeMu.Unlock()
+			return ""
+		case err := <-readErr:
+			c.logger.DebugContext(ctx, "Solana WebSocket read failed", slog.String("error", err.Error()))
+			return metrics.WSDisconnectReadError
+		case <-ticker.C:
+			c.writeMu.Lock()
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+			c.writeMu.Unlock()
+			if err != nil {
+				return metrics.WSDisconnectPingError
+			}
+		case <-watchdog.C:
+			if c.stalled() {
+				c.logger.WarnContext(ctx, "Solana WebSocket stalled: no slot progress",
+					slog.Duration("stall_timeout", c.config.StallTimeout))
+				return metrics.WSDisconnectStalled
+			}
+		}
+	}
+}
+
+// resubscribe activates every registered subscription on a fresh connection
+// Only restores after a reconnect are counted; the first connection activates queued subscriptions
+func (c *WSClient) resubscribe(ctx context.Context, subs []*Subscription, reconnected bool) {
+	for _, sub := range subs {
+		err := c.activate(ctx, sub)
+		if errors.Is(err, ErrWSNotConnected) || ctx.Err() != nil {
+			return // connection dropped again; the next reconnect retries
+		}
+		if reconnected && sub != c.slotSub {
+			metrics.RecordWSResubscription(err == nil)
+			if err == nil {
+				c.mu.Lock()
+				c.stats.Resubscribed++
+				c.mu.Unlock()
+			}
+		}
+		if err != nil {
+			c.logger.WarnContext(ctx, "Solana WebSocket resubscribe failed",
+				slog.String("method", sub.Method),
+				slog.Uint64("subscription_id", sub.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// stalled reports whether slot notifications have stopped advancing for longer than StallTimeout
+func (c *WSClient) stalled() bool {
+	if c.config.StallTimeout <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Since(c.stats.LastSlotAt) > c.config.StallTimeout
+}
+
+// readLoop dispatches responses and notifications until the connection fails
+func (c *WSClient) readLoop(conn *websocket.Conn, readDeadline time.Duration) error {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(readDeadline))
+
+		var message wsMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+
+		if message.ID != nil {
+			c.resolve(*message.ID, message)
+			continue
+		}
+		if message.Params != nil {
+			c.dispatch(message.Params.Subscription, message.Params.Result)
+		}
+	}
+}
+
+func (c *WSClient) resolve(id uint64, message wsMessage) {
+	c.mu.Lock()
+	responseCh, ok := c.pending[id]
+	delete(c.pending, id)
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	response := wsResponse{Result: message.Result}
+	if message.Error != nil {
+		response.Err = message.Error
+	}
+	responseCh <- response
+}
+
+// dispatch routes a notification to its subscription; slow consumers drop notifications rather than block reads
+func (c *WSClient) dispatch(serverID uint64, result json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sub, ok := c.subs[c.serverSubs[serverID]]
+	if !ok {
+		return
+	}
+	if sub.handle != nil {
+		sub.handle(result)
+		return
+	}
+	if address, ok := sub.accountAddress(); ok {
+		c.invalidateAccount(address, result)
+	}
+
+	select {
+	case sub.notify <- result:
+	default:
+		c.stats.Dropped++
+	}
+}
+
+// invalidateAccount drops the cached account a notification reports as changed; called with c.mu held
+func (c *WSClient) invalidateAccount(address Address, result json.RawMessage) {
+	var notification struct {
+		Context struct {
+			Slot Slot `json:"slot"`
+		} `json:"context"`
+	}
+	_ = json.Unmarshal(result, &notification)
+	c.accounts.invalidate(address, notification.Context.Slot)
+}
+
+// handleSlot records slot progress; called with c.mu held
+func (c *WSClient) handleSlot(result json.RawMessage) {
+	var notification struct {
+		Slot uint64 `json:"slot"`
+	}
+	if err := json.Unmarshal(result, &notification); err != nil {
+		return
+	}
+	if notification.Slot > c.stats.LastSlot {
+		c.stats.LastSlot = notification.Slot
+		c.stats.LastSlotAt = time.Now()
+	}
+}
+
+// sleepContext waits for d or until ctx is cancelled; returns false when cancelled
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,246 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"hylo-wallet-tracker-api/internal/logger"
+)
+
+const testAccount = Address("So11111111111111111111111111111111111111112")
+
+// fakeConn serializes writes from the request loop, slot ticker and test notifications
+type fakeConn struct {
+	*websocket.Conn
+	writeMu sync.Mutex
+}
+
+func (c *fakeConn) send(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.WriteJSON(v)
+}
+
+// fakeWSServer answers subscribe requests and optionally streams slot notifications
+type fakeWSServer struct {
+	*httptest.Server
+
+	sendSlots bool
+
+	mu         sync.Mutex
+	subscribes map[string]int
+	conns      []*fakeConn
+	nextSubID  uint64
+	accountSub uint64
+}
+
+func newFakeWSServer(t *testing.T, sendSlots bool) *fakeWSServer {
+	t.Helper()
+
+	fake := &fakeWSServer{sendSlots: sendSlots, subscribes: make(map[string]int)}
+	upgrader := websocket.Upgrader{}
+	fake.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn := &fakeConn{Conn: wsConn}
+		fake.mu.Lock()
+		fake.conns = append(fake.conns, conn)
+		fake.mu.Unlock()
+		fake.serve(conn)
+	}))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func (f *fakeWSServer) serve(conn *fakeConn) {
+	done := make(chan struct{})
+	defer close(done)
+
+	for {
+		var request wsRequest
+		if err := conn.ReadJSON(&request); err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.subscribes[request.Method]++
+		f.nextSubID++
+		subID := f.nextSubID
+		if request.Method == wsMethodAccountSubscribe {
+			f.accountSub = subID
+		}
+		f.mu.Unlock()
+
+		_ = conn.send(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": subID})
+
+		if request.Method == wsMethodSlotSubscribe && f.sendSlots {
+			go func() {
+				ticker := time.NewTicker(20 * time.Millisecond)
+				defer ticker.Stop()
+				for slot := uint64(100); ; slot++ {
+					select {
+					case <-done:
+						return
+					case <-ticker.C:
+						_ = conn.send(map[string]interface{}{
+							"jsonrpc": "2.0",
+							"method":  "slotNotification",
+							"params":  map[string]interface{}{"subscription": subID, "result": map[string]uint64{"slot": slot}},
+						})
+					}
+				}
+			}()
+		}
+	}
+}
+
+func (f *fakeWSServer) subscribeCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.subscribes[method]
+}
+
+// dropConnections closes every open server-side connection without a close frame
+func (f *fakeWSServer) dropConnections() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, conn := range f.conns {
+		conn.UnderlyingConn().Close()
+	}
+	f.conns = nil
+}
+
+func (f *fakeWSServer) notifyAccount(t *testing.T, payload string) {
+	t.Helper()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.conns) == 0 {
+		t.Fatal("no open connection to notify")
+	}
+	conn := f.conns[len(f.conns)-1]
+	message := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "accountNotification",
+		"params":  map[string]interface{}{"subscription": f.accountSub, "result": json.RawMessage(payload)},
+	}
+	if err := conn.send(message); err != nil {
+		t.Fatalf("failed to send notification: %v", err)
+	}
+}
+
+func newTestWSClient(t *testing.T, server *fakeWSServer, stallTimeout time.Duration) *WSClient {
+	t.Helper()
+
+	config := NewConfig("http://unused", "ws"+strings.TrimPrefix(server.URL, "http"))
+	config.BaseBackoff = 10 * time.Millisecond
+	config.MaxBackoff = 50 * time.Millisecond
+	config.RequestTimeout = time.Second
+	config.HeartbeatInterval = time.Second
+	config.StallTimeout = stallTimeout
+
+	client, err := NewWSClient(config, logger.NewFromEnv())
+	if err != nil {
+		t.Fatalf("NewWSClient() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestWSClientResubscribesAfterReconnect(t *testing.T) {
+	server := newFakeWSServer(t, true)
+	client := newTestWSClient(t, server, 0)
+
+	ctx := context.Background()
+	sub, err := client.AccountSubscribe(ctx, testAccount, CommitmentConfirmed)
+	if err != nil {
+		t.Fatalf("AccountSubscribe() error = %v", err)
+	}
+
+	client.Start(ctx)
+	waitFor(t, "initial subscription", func() bool { return server.subscribeCount(wsMethodAccountSubscribe) == 1 })
+	waitFor(t, "slot progress", func() bool { return client.Stats().LastSlot > 0 })
+
+	server.dropConnections()
+	waitFor(t, "resubscription", func() bool { return server.subscribeCount(wsMethodAccountSubscribe) == 2 })
+	waitFor(t, "subscription activation", func() bool { return client.Stats().Resubscribed == 1 })
+
+	server.notifyAccount(t, `{"lamports":42}`)
+	select {
+	case notification := <-sub.Notifications:
+		if !strings.Contains(string(notification), `"lamports":42`) {
+			t.Errorf("unexpected notification %s", notification)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("notification was not routed after reconnect")
+	}
+
+	stats := client.Stats()
+	if stats.Disconnects != 1 || stats.Reconnects != 1 {
+		t.Errorf("Expected 1 disconnect and 1 reconnect, got %d/%d", stats.Disconnects, stats.Reconnects)
+	}
+	if stats.Subscriptions != 1 {
+		t.Errorf("Expected 1 subscription, got %d", stats.Subscriptions)
+	}
+	if server.subscribeCount(wsMethodSlotSubscribe) != 2 {
+		t.Errorf("Expected slot subscription to be restored, got %d", server.subscribeCount(wsMethodSlotSubscribe))
+	}
+}
+
+func TestWSClientReconnectsOnStall(t *testing.T) {
+	server := newFakeWSServer(t, false)
+	client := newTestWSClient(t, server, 100*time.Millisecond)
+
+	client.Start(context.Background())
+	waitFor(t, "stall reconnect", func() bool {
+		stats := client.Stats()
+		return stats.Disconnects >= 1 && stats.Reconnects >= 1
+	})
+}
+
+func TestWSClientUnsubscribeClosesChannel(t *testing.T) {
+	server := newFakeWSServer(t, true)
+	client := newTestWSClient(t, server, 0)
+
+	ctx := context.Background()
+	client.Start(ctx)
+	waitFor(t, "connection", func() bool { return server.subscribeCount(wsMethodSlotSubscribe) == 1 })
+
+	sub, err := client.LogsSubscribe(ctx, testAccount, CommitmentConfirmed)
+	if err != nil {
+		t.Fatalf("LogsSubscribe() error = %v", err)
+	}
+	if err := client.Unsubscribe(ctx, sub); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+
+	if _, ok := <-sub.Notifications; ok {
+		t.Error("Expected notification channel to be closed")
+	}
+	if got := client.Stats().Subscriptions; got != 0 {
+		t.Errorf("Expected no subscriptions, got %d", got)
+	}
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSnapshotRetention keeps enough history for 24h comparisons with headroom
+const DefaultSnapshotRetention = 48 * time.Hour
+
+// DefaultProtocolSnapshotRetention keeps protocol state long enough to annotate a month of trades
+const DefaultProtocolSnapshotRetention = 30 * 24 * time.Hour
+
+// Config holds store configuration
+type Config struct {
+	// Path is the JSON file the store is persisted to; empty keeps data in memory only
+	Path string
+
+	// SnapshotRetention is how long balance snapshots are kept per wallet
+	SnapshotRetention time.Duration
+
+	// ProtocolSnapshotRetention is how long protocol state snapshots are kept
+	ProtocolSnapshotRetention time.Duration
+}
+
+// DefaultConfig returns an in-memory store configuration
+func DefaultConfig() *Config {
+	return &Config{SnapshotRetention: DefaultSnapshotRetention, ProtocolSnapshotRetention: DefaultProtocolSnapshotRetention}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	config.Path = strings.TrimSpace(os.Getenv("STORE_PATH"))
+
+	if value := os.Getenv("SNAPSHOT_RETENTION_HOURS"); value != "" {
+		if hours, err := strconv.Atoi(value); err == nil && hours > 0 {
+			config.SnapshotRetention = time.Duration(hours) * time.Hour
+		}
+	}
+
+	if value := os.Getenv("PROTOCOL_SNAPSHOT_RETENTION_HOURS"); value != "" {
+		if hours, err := strconv.Atoi(value); err == nil && hours > 0 {
+			config.ProtocolSnapshotRetention = time.Duration(hours) * time.Hour
+		}
+	}
+
+	return config
+}
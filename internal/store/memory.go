@@ -0,0 +1,591 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a Store kept in memory and optionally persisted to a JSON file on Flush
+type MemoryStore struct {
+	mu         sync.RWMutex
+	config     *Config
+	dirty      bool
+	wallets    map[walletKey]TrackedWallet
+	snapshots  map[string][]BalanceSnapshot // Oldest first
+	trades     map[string][]TradeRecord     // Newest first
+	seen       map[string]string            // Indexed signature -> wallet it was indexed for
+	cursors    map[string]Cursor
+	retries    map[string][]RetryEntry // Newest slot first
+	portfolios map[string]Portfolio
+	protocol   []ProtocolSnapshot // Ascending slots
+}
+
+// walletKey identifies one tenant's registration of a wallet
+type walletKey struct {
+	tenant  string
+	address string
+}
+
+func keyOf(wallet TrackedWallet) walletKey {
+	return walletKey{tenant: wallet.Tenant, address: wallet.Address}
+}
+
+// memoryStoreFile is the on-disk layout of a MemoryStore
+type memoryStoreFile struct {
+	Wallets    []TrackedWallet              `json:"wallets"`
+	Snapshots  map[string][]BalanceSnapshot `json:"snapshots"`
+	Trades     map[string][]TradeRecord     `json:"trades"`
+	Cursors    map[string]Cursor            `json:"cursors"`
+	Retries    map[string][]RetryEntry      `json:"retries,omitempty"`
+	Portfolios []Portfolio                  `json:"portfolios,omitempty"`
+	Protocol   []ProtocolSnapshot           `json:"protocol_snapshots,omitempty"`
+}
+
+// NewMemoryStore creates a store, loading existing data from config.Path when present
+func NewMemoryStore(config *Config) (*MemoryStore, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	s := &MemoryStore{
+		config:     config,
+		wallets:    make(map[walletKey]TrackedWallet),
+		snapshots:  make(map[string][]BalanceSnapshot),
+		trades:     make(map[string][]TradeRecord),
+		seen:       make(map[string]string),
+		cursors:    make(map[string]Cursor),
+		retries:    make(map[string][]RetryEntry),
+		portfolios: make(map[string]Portfolio),
+	}
+
+	if config.Path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(config.Path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store: %w", err)
+	}
+
+	var file memoryStoreFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode store: %w", err)
+	}
+
+	for _, wallet := range file.Wallets {
+		// Stores written before wallet statuses existed only held active wallets
+		if wallet.Status == "" {
+			wallet.Status = WalletStatusActive
+			wallet.StatusChangedAt = wallet.AddedAt
+		}
+		s.wallets[keyOf(wallet)] = wallet
+	}
+	for wallet, snapshots := range file.Snapshots {
+		s.snapshots[wallet] = snapshots
+	}
+	for wallet, records := range file.Trades {
+		for i := range records {
+			records[i].Trade.XSOLAmountRaw = records[i].XSOLAmountRaw
+			records[i].Trade.CounterAmountRaw = records[i].CounterAmountRaw
+			s.seen[records[i].Trade.Signature] = wallet
+		}
+		s.trades[wallet] = records
+	}
+	for wallet, cursor := range file.Cursors {
+		s.cursors[wallet] = cursor
+	}
+	for wallet, entries := range file.Retries {
+		s.retries[wallet] = entries
+	}
+	for _, portfolio := range file.Portfolios {
+		s.portfolios[portfolio.ID] = portfolio
+	}
+	s.protocol = file.Protocol
+
+	return s, nil
+}
+
+// TrackWallet implements Store
+func (s *MemoryStore) TrackWallet(wallet TrackedWallet) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.wallets[keyOf(wallet)]; exists {
+		return false, nil
+	}
+	if wallet.AddedAt.IsZero() {
+		wallet.AddedAt = time.Now().UTC()
+	}
+	wallet.Status = WalletStatusActive
+	wallet.LastError = ""
+	wallet.StatusChangedAt = wallet.AddedAt
+
+	s.wallets[keyOf(wallet)] = wallet
+	s.dirty = true
+	return true, nil
+}
+
+// ListWallets implements Store, returning registrations in registration order
+func (s *MemoryStore) ListWallets() []TrackedWallet {
+	return s.filterWallets(func(TrackedWallet) bool { return true })
+}
+
+// ListTenantWallets implements Store, returning registrations in registration order
+func (s *MemoryStore) ListTenantWallets(tenant string) []TrackedWallet {
+	return s.filterWallets(func(wallet TrackedWallet) bool { return wallet.Tenant == tenant })
+}
+
+// WalletTenants implements Store
+func (s *MemoryStore) WalletTenants(address string) []TrackedWallet {
+	return s.filterWallets(func(wallet TrackedWallet) bool { return wallet.Address == address })
+}
+
+// filterWallets returns the registrations matching keep in registration order
+func (s *MemoryStore) filterWallets(keep func(TrackedWallet) bool) []TrackedWallet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wallets := make([]TrackedWallet, 0)
+	for _, wallet := range s.wallets {
+		if keep(wallet) {
+			wallets = append(wallets, wallet)
+		}
+	}
+	sort.Slice(wallets, func(i, j int) bool {
+		if !wallets[i].AddedAt.Equal(wallets[j].AddedAt) {
+			return wallets[i].AddedAt.Before(wallets[j].AddedAt)
+		}
+		if wallets[i].Address != wallets[j].Address {
+			return wallets[i].Address < wallets[j].Address
+		}
+		return wallets[i].Tenant < wallets[j].Tenant
+	})
+	return wallets
+}
+
+// GetWallet implements Store
+func (s *MemoryStore) GetWallet(tenant, address string) (TrackedWallet, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wallet, exists := s.wallets[walletKey{tenant: tenant, address: address}]
+	return wallet, exists
+}
+
+// SetWalletStatus implements Store; unchanged statuses leave the store clean
+func (s *MemoryStore) SetWalletStatus(tenant, address, status, lastError string) (TrackedWallet, error) {
+	switch status {
+	case WalletStatusActive, WalletStatusPaused:
+		lastError = ""
+	case WalletStatusError:
+	default:
+		return TrackedWallet{}, fmt.Errorf("invalid wallet status %q", status)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := walletKey{tenant: tenant, address: address}
+	wallet, exists := s.wallets[key]
+	if !exists {
+		return TrackedWallet{}, ErrWalletNotTracked
+	}
+	if wallet.Status == status && wallet.LastError == lastError {
+		return wallet, nil
+	}
+
+	if wallet.Status != status {
+		wallet.StatusChangedAt = time.Now().UTC()
+	}
+	wallet.Status = status
+	wallet.LastError = lastError
+	s.wallets[key] = wallet
+	s.dirty = true
+	return wallet, nil
+}
+
+// SetWalletDigest implements Store; the preference is copied so callers cannot mutate stored state
+func (s *MemoryStore) SetWalletDigest(tenant, address string, digest *DigestPreference) (TrackedWallet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := walletKey{tenant: tenant, address: address}
+	wallet, exists := s.wallets[key]
+	if !exists {
+		return TrackedWallet{}, ErrWalletNotTracked
+	}
+
+	wallet.Digest = nil
+	if digest != nil {
+		preference := *digest
+		wallet.Digest = &preference
+	}
+	s.wallets[key] = wallet
+	s.dirty = true
+	return wallet, nil
+}
+
+// AddSnapshot implements Store, pruning snapshots older than the retention window
+// The newest snapshot outside the window is kept so SnapshotAt can still answer for the window start
+func (s *MemoryStore) AddSnapshot(snapshot BalanceSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots := append(s.snapshots[snapshot.Wallet], snapshot)
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Timestamp.Before(snapshots[j].Timestamp)
+	})
+
+	cutoff := snapshot.Timestamp.Add(-s.config.SnapshotRetention)
+	keepFrom := 0
+	for i := range snapshots {
+		if snapshots[i].Timestamp.Before(cutoff) {
+			keepFrom = i
+		}
+	}
+
+	s.snapshots[snapshot.Wallet] = snapshots[keepFrom:]
+	s.dirty = true
+	return nil
+}
+
+// LatestSnapshot implements Store
+func (s *MemoryStore) LatestSnapshot(wallet string) (*BalanceSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.snapshots[wallet]
+	if len(snapshots) == 0 {
+		return nil, false
+	}
+	latest := snapshots[len(snapshots)-1]
+	return &latest, true
+}
+
+// SnapshotAt implements Store
+func (s *MemoryStore) SnapshotAt(wallet string, t time.Time) (*BalanceSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.snapshots[wallet]
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].Timestamp.After(t) {
+			snapshot := snapshots[i]
+			return &snapshot, true
+		}
+	}
+	return nil, false
+}
+
+// SnapshotAtSlot implements Store
+func (s *MemoryStore) SnapshotAtSlot(wallet string, slot uint64) (*BalanceSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.snapshots[wallet]
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Slot <= slot {
+			snapshot := snapshots[i]
+			return &snapshot, true
+		}
+	}
+	return nil, false
+}
+
+// AddTrades implements Store
+func (s *MemoryStore) AddTrades(records []TradeRecord) ([]TradeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := make([]TradeRecord, 0, len(records))
+	touched := make(map[string]bool)
+	for _, record := range records {
+		if _, indexed := s.seen[record.Trade.Signature]; indexed || record.Trade.Signature == "" {
+			continue
+		}
+		s.seen[record.Trade.Signature] = record.Wallet
+		s.trades[record.Wallet] = append(s.trades[record.Wallet], record)
+		touched[record.Wallet] = true
+		added = append(added, record)
+	}
+
+	for wallet := range touched {
+		trades := s.trades[wallet]
+		sort.SliceStable(trades, func(i, j int) bool {
+			return trades[i].Trade.Slot > trades[j].Trade.Slot
+		})
+	}
+
+	if len(added) > 0 {
+		s.dirty = true
+	}
+	return added, nil
+}
+
+// TradesSince implements Store
+func (s *MemoryStore) TradesSince(wallet string, since time.Time) []TradeRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]TradeRecord, 0)
+	for _, record := range s.trades[wallet] {
+		if record.Trade.Timestamp.Before(since) {
+			break
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+// TradeBySignature implements Store
+func (s *MemoryStore) TradeBySignature(signature string) (TradeRecord, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wallet, indexed := s.seen[signature]
+	if !indexed {
+		return TradeRecord{}, false
+	}
+	for _, record := range s.trades[wallet] {
+		if record.Trade.Signature == signature {
+			return record, true
+		}
+	}
+	return TradeRecord{}, false
+}
+
+// Cursor implements Store
+func (s *MemoryStore) Cursor(wallet string) (Cursor, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cursor, exists := s.cursors[wallet]
+	return cursor, exists
+}
+
+// SetCursor implements Store
+func (s *MemoryStore) SetCursor(wallet string, cursor Cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cursors[wallet] = cursor
+	s.dirty = true
+	return nil
+}
+
+// SaveRetry implements Store
+func (s *MemoryStore) SaveRetry(entry RetryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.retries[entry.Wallet]
+	for i := range entries {
+		if entries[i].Signature == entry.Signature {
+			entries[i] = entry
+			s.dirty = true
+			return nil
+		}
+	}
+
+	entries = append(entries, entry)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Slot > entries[j].Slot })
+	s.retries[entry.Wallet] = entries
+	s.dirty = true
+	return nil
+}
+
+// Retries implements Store
+func (s *MemoryStore) Retries(wallet string) []RetryEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]RetryEntry(nil), s.retries[wallet]...)
+}
+
+// RemoveRetry implements Store
+func (s *MemoryStore) RemoveRetry(wallet, signature string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.retries[wallet]
+	for i := range entries {
+		if entries[i].Signature != signature {
+			continue
+		}
+		entries = append(entries[:i], entries[i+1:]...)
+		if len(entries) == 0 {
+			delete(s.retries, wallet)
+		} else {
+			s.retries[wallet] = entries
+		}
+		s.dirty = true
+		return nil
+	}
+	return nil
+}
+
+// AddProtocolSnapshot implements Store
+func (s *MemoryStore) AddProtocolSnapshot(snapshot ProtocolSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n := len(s.protocol); n > 0 && snapshot.Slot <= s.protocol[n-1].Slot {
+		return nil
+	}
+	s.protocol = append(s.protocol, snapshot)
+
+	if s.config.ProtocolSnapshotRetention > 0 {
+		cutoff := snapshot.Timestamp.Add(-s.config.ProtocolSnapshotRetention)
+		drop := sort.Search(len(s.protocol), func(i int) bool { return !s.protocol[i].Timestamp.Before(cutoff) })
+		if drop > 0 {
+			s.protocol = append(s.protocol[:0], s.protocol[drop:]...)
+		}
+	}
+	s.dirty = true
+	return nil
+}
+
+// ProtocolSnapshotNear implements Store
+func (s *MemoryStore) ProtocolSnapshotNear(slot uint64) (*ProtocolSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.protocol) == 0 {
+		return nil, false
+	}
+	i := sort.Search(len(s.protocol), func(i int) bool { return s.protocol[i].Slot >= slot })
+	if i == len(s.protocol) || (i > 0 && slot-s.protocol[i-1].Slot <= s.protocol[i].Slot-slot) {
+		i--
+	}
+	snapshot := s.protocol[i]
+	return &snapshot, true
+}
+
+// ProtocolSnapshotsSince implements Store
+func (s *MemoryStore) ProtocolSnapshotsSince(since time.Time) []ProtocolSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := sort.Search(len(s.protocol), func(i int) bool { return !s.protocol[i].Timestamp.Before(since) })
+	return append([]ProtocolSnapshot(nil), s.protocol[i:]...)
+}
+
+// SavePortfolio implements Store; the wallet list is copied so callers cannot mutate stored state
+func (s *MemoryStore) SavePortfolio(portfolio Portfolio) error {
+	if portfolio.ID == "" {
+		return fmt.Errorf("portfolio ID is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.portfolios[portfolio.ID]; exists && existing.Tenant != portfolio.Tenant {
+		return fmt.Errorf("portfolio %s belongs to another tenant", portfolio.ID)
+	}
+	portfolio.Wallets = append([]string(nil), portfolio.Wallets...)
+	s.portfolios[portfolio.ID] = portfolio
+	s.dirty = true
+	return nil
+}
+
+// ListPortfolios implements Store
+func (s *MemoryStore) ListPortfolios(tenant string) []Portfolio {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	portfolios := make([]Portfolio, 0)
+	for _, portfolio := range s.portfolios {
+		if portfolio.Tenant == tenant {
+			portfolios = append(portfolios, portfolio)
+		}
+	}
+	sort.Slice(portfolios, func(i, j int) bool {
+		if portfolios[i].CreatedAt.Equal(portfolios[j].CreatedAt) {
+			return portfolios[i].ID < portfolios[j].ID
+		}
+		return portfolios[i].CreatedAt.Before(portfolios[j].CreatedAt)
+	})
+	return portfolios
+}
+
+// GetPortfolio implements Store
+func (s *MemoryStore) GetPortfolio(tenant, id string) (Portfolio, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	portfolio, exists := s.portfolios[id]
+	if !exists || portfolio.Tenant != tenant {
+		return Portfolio{}, false
+	}
+	return portfolio, true
+}
+
+// DeletePortfolio implements Store
+func (s *MemoryStore) DeletePortfolio(tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if portfolio, exists := s.portfolios[id]; !exists || portfolio.Tenant != tenant {
+		return ErrPortfolioNotFound
+	}
+	delete(s.portfolios, id)
+	s.dirty = true
+	return nil
+}
+
+// Flush writes the store to config.Path atomically when there are unsaved changes
+func (s *MemoryStore) Flush() error {
+	if s.config.Path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+
+	file := memoryStoreFile{
+		Wallets:   make([]TrackedWallet, 0, len(s.wallets)),
+		Snapshots: s.snapshots,
+		Trades:    s.trades,
+		Cursors:   s.cursors,
+		Retries:   s.retries,
+		Protocol:  s.protocol,
+	}
+	for _, wallet := range s.wallets {
+		file.Wallets = append(file.Wallets, wallet)
+	}
+	for _, portfolio := range s.portfolios {
+		file.Portfolios = append(file.Portfolios, portfolio)
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to encode store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.config.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	tmpPath := s.config.Path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.config.Path); err != nil {
+		return fmt.Errorf("failed to replace store: %w", err)
+	}
+
+	s.dirty = false
+	return nil
+}
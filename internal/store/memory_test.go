@@ -0,0 +1,323 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+)
+
+const testWallet = "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+
+func newTestRecord(signature string, slot uint64, blockTime int64) TradeRecord {
+	trade := hylo.NewXSOLTrade(signature, slot, blockTime)
+	trade.SetTradeDetails(hylo.TradeSideBuy, 1_500_000, 1_000_000_000, "SOL")
+	return NewTradeRecord(testWallet, trade)
+}
+
+func TestMemoryStoreAddTradesDedup(t *testing.T) {
+	s, err := NewMemoryStore(DefaultConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	added, _ := s.AddTrades([]TradeRecord{newTestRecord("sig1", 100, 1000), newTestRecord("sig2", 200, 2000)})
+	if len(added) != 2 {
+		t.Fatalf("Expected 2 trades added, got %d", len(added))
+	}
+
+	added, _ = s.AddTrades([]TradeRecord{newTestRecord("sig2", 200, 2000), newTestRecord("sig3", 150, 1500)})
+	if len(added) != 1 || added[0].Trade.Signature != "sig3" {
+		t.Errorf("Expected duplicate signature to be skipped, got %+v", added)
+	}
+
+	trades := s.TradesSince(testWallet, time.Unix(1200, 0))
+	if len(trades) != 2 || trades[0].Trade.Signature != "sig2" || trades[1].Trade.Signature != "sig3" {
+		t.Errorf("Expected [sig2 sig3] newest first, got %+v", trades)
+	}
+}
+
+func TestMemoryStoreTradeBySignature(t *testing.T) {
+	s, _ := NewMemoryStore(DefaultConfig())
+	other := newTestRecord("sig2", 200, 2000)
+	other.Wallet = "B4wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6h"
+	s.AddTrades([]TradeRecord{newTestRecord("sig1", 100, 1000), other})
+
+	if record, ok := s.TradeBySignature("sig2"); !ok || record.Wallet != other.Wallet || record.Trade.Slot != 200 {
+		t.Errorf("Expected sig2 indexed for the other wallet, got %+v (%v)", record, ok)
+	}
+	if _, ok := s.TradeBySignature("sig3"); ok {
+		t.Error("Expected an unknown signature not to be found")
+	}
+}
+
+func TestMemoryStoreSnapshotRetention(t *testing.T) {
+	s, _ := NewMemoryStore(&Config{SnapshotRetention: 2 * time.Hour})
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for hour := 0; hour <= 4; hour++ {
+		s.AddSnapshot(BalanceSnapshot{Wallet: testWallet, Timestamp: base.Add(time.Duration(hour) * time.Hour), TotalUSD: float64(hour)})
+	}
+
+	// Retention keeps hours 2-4 plus hour 1 as the anchor for the window start
+	if len(s.snapshots[testWallet]) != 4 {
+		t.Errorf("Expected 4 retained snapshots, got %d", len(s.snapshots[testWallet]))
+	}
+
+	snapshot, ok := s.SnapshotAt(testWallet, base.Add(150*time.Minute))
+	if !ok || snapshot.TotalUSD != 2 {
+		t.Errorf("Expected snapshot from hour 2, got %+v", snapshot)
+	}
+	if _, ok := s.SnapshotAt(testWallet, base.Add(30*time.Minute)); ok {
+		t.Errorf("Expected no snapshot before the oldest retained one")
+	}
+
+	latest, ok := s.LatestSnapshot(testWallet)
+	if !ok || latest.TotalUSD != 4 {
+		t.Errorf("Expected latest snapshot from hour 4, got %+v", latest)
+	}
+}
+
+func TestMemoryStoreSnapshotAtSlot(t *testing.T) {
+	s, _ := NewMemoryStore(&Config{SnapshotRetention: DefaultSnapshotRetention})
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= 3; i++ {
+		s.AddSnapshot(BalanceSnapshot{Wallet: testWallet, Timestamp: base.Add(time.Duration(i) * time.Hour), Slot: uint64(i * 100), TotalUSD: float64(i)})
+	}
+
+	snapshot, ok := s.SnapshotAtSlot(testWallet, 250)
+	if !ok || snapshot.Slot != 200 {
+		t.Errorf("Expected snapshot at slot 200, got %+v", snapshot)
+	}
+	if snapshot, ok := s.SnapshotAtSlot(testWallet, 300); !ok || snapshot.Slot != 300 {
+		t.Errorf("Expected snapshot taken exactly at slot 300, got %+v", snapshot)
+	}
+	if _, ok := s.SnapshotAtSlot(testWallet, 99); ok {
+		t.Error("Expected no snapshot before the oldest one")
+	}
+}
+
+func TestMemoryStoreProtocolSnapshotNear(t *testing.T) {
+	s, _ := NewMemoryStore(&Config{ProtocolSnapshotRetention: 2 * time.Hour})
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, ok := s.ProtocolSnapshotNear(100); ok {
+		t.Error("Expected no snapshot in an empty store")
+	}
+	for i := 1; i <= 4; i++ {
+		s.AddProtocolSnapshot(ProtocolSnapshot{Slot: uint64(i * 100), Timestamp: base.Add(time.Duration(i) * time.Hour), CollateralRatio: float64(i)})
+	}
+	s.AddProtocolSnapshot(ProtocolSnapshot{Slot: 350, Timestamp: base.Add(5 * time.Hour)})
+
+	cases := map[uint64]uint64{0: 200, 240: 200, 260: 300, 250: 200, 1000: 400}
+	for slot, want := range cases {
+		if snapshot, ok := s.ProtocolSnapshotNear(slot); !ok || snapshot.Slot != want {
+			t.Errorf("ProtocolSnapshotNear(%d) = %+v, want slot %d", slot, snapshot, want)
+		}
+	}
+
+	since := s.ProtocolSnapshotsSince(base.Add(3 * time.Hour))
+	if len(since) != 2 || since[0].Slot != 300 || since[1].Slot != 400 {
+		t.Errorf("ProtocolSnapshotsSince() = %+v, want slots 300 and 400", since)
+	}
+}
+
+func TestMemoryStoreFlushAndReload(t *testing.T) {
+	config := &Config{Path: filepath.Join(t.TempDir(), "store.json"), SnapshotRetention: DefaultSnapshotRetention}
+
+	s, err := NewMemoryStore(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.TrackWallet(TrackedWallet{Address: testWallet, Label: "treasury"})
+	s.AddTrades([]TradeRecord{newTestRecord("sig1", 100, 1000)})
+	s.SetCursor(testWallet, Cursor{LastSignature: "sig1", LastSlot: 100})
+	s.SaveRetry(RetryEntry{Wallet: testWallet, Signature: "sig0", Slot: 90, Attempts: 1})
+	s.SavePortfolio(Portfolio{ID: "pf_1", Name: "Treasury", Wallets: []string{testWallet}})
+	s.AddProtocolSnapshot(ProtocolSnapshot{Slot: 90, Timestamp: time.Now(), CollateralRatio: 1.8})
+	if err := s.Flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	reloaded, err := NewMemoryStore(config)
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+
+	wallet, ok := reloaded.GetWallet(DefaultTenant, testWallet)
+	if !ok || wallet.Label != "treasury" {
+		t.Errorf("Expected tracked wallet to survive reload, got %+v", wallet)
+	}
+	if cursor, ok := reloaded.Cursor(testWallet); !ok || cursor.LastSignature != "sig1" {
+		t.Errorf("Expected cursor sig1, got %+v", cursor)
+	}
+	if retries := reloaded.Retries(testWallet); len(retries) != 1 || retries[0].Signature != "sig0" {
+		t.Errorf("Expected retry entry to survive reload, got %+v", retries)
+	}
+	if portfolio, ok := reloaded.GetPortfolio(DefaultTenant, "pf_1"); !ok || portfolio.Name != "Treasury" || len(portfolio.Wallets) != 1 {
+		t.Errorf("Expected portfolio to survive reload, got %+v", portfolio)
+	}
+	if snapshot, ok := reloaded.ProtocolSnapshotNear(100); !ok || snapshot.CollateralRatio != 1.8 {
+		t.Errorf("Expected protocol snapshot to survive reload, got %+v", snapshot)
+	}
+
+	trades := reloaded.TradesSince(testWallet, time.Time{})
+	if len(trades) != 1 || trades[0].Trade.XSOLAmountRaw != 1_500_000 {
+		t.Errorf("Expected raw amounts to be restored, got %+v", trades)
+	}
+	if added, _ := reloaded.AddTrades([]TradeRecord{newTestRecord("sig1", 100, 1000)}); len(added) != 0 {
+		t.Errorf("Expected reloaded signatures to be deduplicated")
+	}
+	if record, ok := reloaded.TradeBySignature("sig1"); !ok || record.Wallet != testWallet {
+		t.Errorf("Expected reloaded signatures to be looked up, got %+v", record)
+	}
+}
+
+func TestMemoryStoreRetries(t *testing.T) {
+	s, _ := NewMemoryStore(nil)
+
+	s.SaveRetry(RetryEntry{Wallet: testWallet, Signature: "sig1", Slot: 100, Attempts: 1})
+	s.SaveRetry(RetryEntry{Wallet: testWallet, Signature: "sig2", Slot: 200, Attempts: 1})
+	s.SaveRetry(RetryEntry{Wallet: testWallet, Signature: "sig1", Slot: 100, Attempts: 2})
+
+	retries := s.Retries(testWallet)
+	if len(retries) != 2 || retries[0].Signature != "sig2" || retries[1].Attempts != 2 {
+		t.Fatalf("Expected two entries newest first with sig1 replaced, got %+v", retries)
+	}
+
+	s.RemoveRetry(testWallet, "sig2")
+	s.RemoveRetry(testWallet, "unknown")
+	if retries := s.Retries(testWallet); len(retries) != 1 || retries[0].Signature != "sig1" {
+		t.Errorf("Expected only sig1 left, got %+v", retries)
+	}
+}
+
+func TestMemoryStoreSetWalletStatus(t *testing.T) {
+	s, _ := NewMemoryStore(DefaultConfig())
+
+	if _, err := s.SetWalletStatus(DefaultTenant, testWallet, WalletStatusPaused, ""); err != ErrWalletNotTracked {
+		t.Errorf("Expected ErrWalletNotTracked, got %v", err)
+	}
+
+	s.TrackWallet(TrackedWallet{Address: testWallet})
+	if wallet, _ := s.GetWallet(DefaultTenant, testWallet); wallet.Status != WalletStatusActive {
+		t.Errorf("Expected new wallet to be active, got %q", wallet.Status)
+	}
+
+	wallet, err := s.SetWalletStatus(DefaultTenant, testWallet, WalletStatusError, "rpc timeout")
+	if err != nil || wallet.Status != WalletStatusError || wallet.LastError != "rpc timeout" {
+		t.Errorf("Expected error status with message, got %+v (%v)", wallet, err)
+	}
+
+	wallet, _ = s.SetWalletStatus(DefaultTenant, testWallet, WalletStatusActive, "ignored")
+	if wallet.LastError != "" {
+		t.Errorf("Expected last error to be cleared, got %q", wallet.LastError)
+	}
+
+	if _, err := s.SetWalletStatus(DefaultTenant, testWallet, "deleted", ""); err == nil {
+		t.Error("Expected error for unknown status")
+	}
+
+	// Re-registering keeps the existing wallet and its status
+	s.SetWalletStatus(DefaultTenant, testWallet, WalletStatusPaused, "")
+	if created, _ := s.TrackWallet(TrackedWallet{Address: testWallet}); created {
+		t.Error("Expected paused wallet to count as already tracked")
+	}
+	if wallet, _ := s.GetWallet(DefaultTenant, testWallet); wallet.Status != WalletStatusPaused {
+		t.Errorf("Expected wallet to stay paused, got %q", wallet.Status)
+	}
+}
+
+func TestMemoryStoreSetWalletDigest(t *testing.T) {
+	s, _ := NewMemoryStore(DefaultConfig())
+
+	preference := &DigestPreference{Frequency: DigestDaily, Timezone: "Europe/Berlin"}
+	if _, err := s.SetWalletDigest(DefaultTenant, testWallet, preference); err != ErrWalletNotTracked {
+		t.Errorf("Expected ErrWalletNotTracked, got %v", err)
+	}
+
+	s.TrackWallet(TrackedWallet{Address: testWallet})
+	if _, err := s.SetWalletDigest(DefaultTenant, testWallet, preference); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	preference.Frequency = DigestWeekly
+	if wallet, _ := s.GetWallet(DefaultTenant, testWallet); wallet.Digest == nil || wallet.Digest.Frequency != DigestDaily {
+		t.Errorf("Expected stored daily preference unaffected by caller changes, got %+v", wallet.Digest)
+	}
+
+	if wallet, _ := s.SetWalletDigest(DefaultTenant, testWallet, nil); wallet.Digest != nil {
+		t.Errorf("Expected opt-out to clear the preference, got %+v", wallet.Digest)
+	}
+}
+
+func TestMemoryStorePortfolios(t *testing.T) {
+	s, _ := NewMemoryStore(DefaultConfig())
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	wallets := []string{testWallet}
+	s.SavePortfolio(Portfolio{ID: "pf_b", Name: "Second", Wallets: wallets, CreatedAt: base.Add(time.Hour)})
+	s.SavePortfolio(Portfolio{ID: "pf_a", Name: "First", Wallets: wallets, CreatedAt: base})
+	wallets[0] = "mutated"
+
+	portfolios := s.ListPortfolios(DefaultTenant)
+	if len(portfolios) != 2 || portfolios[0].ID != "pf_a" || portfolios[1].ID != "pf_b" {
+		t.Fatalf("Expected portfolios oldest first, got %+v", portfolios)
+	}
+	if portfolios[1].Wallets[0] != testWallet {
+		t.Errorf("Expected the stored wallet list to be a copy, got %v", portfolios[1].Wallets)
+	}
+
+	if err := s.DeletePortfolio(DefaultTenant, "pf_a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := s.GetPortfolio(DefaultTenant, "pf_a"); ok {
+		t.Error("Expected deleted portfolio to be gone")
+	}
+	if err := s.DeletePortfolio(DefaultTenant, "pf_a"); !errors.Is(err, ErrPortfolioNotFound) {
+		t.Errorf("Expected ErrPortfolioNotFound, got %v", err)
+	}
+	if err := s.SavePortfolio(Portfolio{Name: "No ID"}); err == nil {
+		t.Error("Expected an error for a portfolio without an ID")
+	}
+}
+
+func TestMemoryStoreTenantIsolation(t *testing.T) {
+	s, _ := NewMemoryStore(DefaultConfig())
+
+	s.TrackWallet(TrackedWallet{Tenant: "acme", Address: testWallet, Label: "Acme treasury"})
+	if created, _ := s.TrackWallet(TrackedWallet{Tenant: "globex", Address: testWallet, Label: "Globex"}); !created {
+		t.Fatal("Expected a second tenant to get its own registration")
+	}
+	if _, ok := s.GetWallet(DefaultTenant, testWallet); ok {
+		t.Error("Expected the default tenant not to see other tenants' wallets")
+	}
+
+	s.SetWalletStatus("acme", testWallet, WalletStatusPaused, "")
+	if wallet, _ := s.GetWallet("globex", testWallet); wallet.Status != WalletStatusActive || wallet.Label != "Globex" {
+		t.Errorf("Expected globex's registration to be unaffected, got %+v", wallet)
+	}
+	if wallets := s.ListTenantWallets("acme"); len(wallets) != 1 || wallets[0].Label != "Acme treasury" {
+		t.Errorf("Expected acme's own registration, got %+v", wallets)
+	}
+	if registrations := s.WalletTenants(testWallet); len(registrations) != 2 || !AnyIndexed(registrations) {
+		t.Errorf("Expected two registrations, one indexed, got %+v", registrations)
+	}
+
+	s.SavePortfolio(Portfolio{Tenant: "acme", ID: "pf_acme", Name: "Acme", Wallets: []string{testWallet}})
+	if _, ok := s.GetPortfolio("globex", "pf_acme"); ok {
+		t.Error("Expected another tenant's portfolio to be hidden")
+	}
+	if err := s.DeletePortfolio("globex", "pf_acme"); !errors.Is(err, ErrPortfolioNotFound) {
+		t.Errorf("Expected ErrPortfolioNotFound deleting another tenant's portfolio, got %v", err)
+	}
+	if err := s.SavePortfolio(Portfolio{Tenant: "globex", ID: "pf_acme", Name: "Hijack"}); err == nil {
+		t.Error("Expected an error overwriting another tenant's portfolio")
+	}
+	if len(s.ListPortfolios("acme")) != 1 || len(s.ListPortfolios("globex")) != 0 {
+		t.Error("Expected portfolios to be listed per tenant")
+	}
+}
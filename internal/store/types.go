@@ -0,0 +1,232 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+)
+
+// Tracked wallet lifecycle states
+const (
+	WalletStatusActive = "active" // Synced every indexer cycle
+	WalletStatusPaused = "paused" // Untracked; history and cursor are kept so re-tracking resumes from the watermark
+	WalletStatusError  = "error"  // Last sync failed; retried every cycle
+)
+
+// Digest frequencies a tracked wallet can opt in to
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DefaultTenant owns registrations made without a tenant API key, and every registration stored before tenants existed
+const DefaultTenant = ""
+
+// ErrWalletNotTracked is returned when changing the status of a wallet that was never registered
+var ErrWalletNotTracked = errors.New("wallet is not tracked")
+
+// ErrPortfolioNotFound is returned when deleting a portfolio that does not exist
+var ErrPortfolioNotFound = errors.New("portfolio not found")
+
+// TrackedWallet is a wallet registered for background indexing by one tenant
+// Each tenant holds its own registration, label, status and digest preference for an address; the
+// snapshots, trade index and cursor are kept once per address and shared by every tenant tracking it
+type TrackedWallet struct {
+	Tenant          string    `json:"tenant,omitempty"`
+	Address         string    `json:"address"`
+	Label           string    `json:"label,omitempty"`
+	Status          string    `json:"status"`               // active, paused or error
+	LastError       string    `json:"last_error,omitempty"` // Set while Status is error
+	AddedAt         time.Time `json:"added_at"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+
+	// Digest is the wallet's opt-in to periodic change digests; nil when not subscribed
+	Digest *DigestPreference `json:"digest,omitempty"`
+}
+
+// DigestPreference schedules change digests for a tracked wallet
+type DigestPreference struct {
+	Frequency string `json:"frequency"` // daily or weekly
+	Timezone  string `json:"timezone"`  // IANA time zone the send time is evaluated in
+	// LastSentAt is the end of the last reported period; the opt-in time until the first digest is sent
+	LastSentAt time.Time `json:"last_sent_at"`
+}
+
+// Indexed reports whether the indexer should sync the wallet
+func (w TrackedWallet) Indexed() bool {
+	return w.Status != WalletStatusPaused
+}
+
+// AnyIndexed reports whether any of the registrations keeps the wallet indexed
+func AnyIndexed(registrations []TrackedWallet) bool {
+	for _, wallet := range registrations {
+		if wallet.Indexed() {
+			return true
+		}
+	}
+	return false
+}
+
+// IndexedAddresses returns each address kept indexed by at least one registration, once, in list order
+func IndexedAddresses(registrations []TrackedWallet) []string {
+	var addresses []string
+	seen := make(map[string]bool)
+	for _, wallet := range registrations {
+		if wallet.Indexed() && !seen[wallet.Address] {
+			seen[wallet.Address] = true
+			addresses = append(addresses, wallet.Address)
+		}
+	}
+	return addresses
+}
+
+// Portfolio is a named group of wallets whose balances, trades and P&L are reported together
+type Portfolio struct {
+	Tenant    string    `json:"tenant,omitempty"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Wallets   []string  `json:"wallets"` // Addresses in the order they were given
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Holding is a single token position inside a balance snapshot
+type Holding struct {
+	RawAmount       uint64  `json:"raw_amount"`
+	FormattedAmount string  `json:"formatted_amount"`
+	USDValue        float64 `json:"usd_value"`
+}
+
+// BalanceSnapshot records a wallet's holdings and their USD value at a point in time
+type BalanceSnapshot struct {
+	Wallet    string             `json:"wallet"`
+	Timestamp time.Time          `json:"timestamp"`
+	Slot      uint64             `json:"slot"`
+	Holdings  map[string]Holding `json:"holdings"` // Keyed by token symbol
+	TotalUSD  float64            `json:"total_usd"`
+
+	// XSOLPriceUSD is the xSOL price used to value the snapshot
+	XSOLPriceUSD float64 `json:"xsol_price_usd"`
+}
+
+// ProtocolSnapshot records the protocol's health at a slot, used to annotate trades made near it
+// and to chart protocol health over time
+type ProtocolSnapshot struct {
+	Slot              uint64    `json:"slot"`
+	Timestamp         time.Time `json:"timestamp"`
+	CollateralRatio   float64   `json:"collateral_ratio"`
+	EffectiveLeverage float64   `json:"effective_leverage"`
+	SOLPriceUSD       float64   `json:"sol_price_usd"`
+	XSOLPriceUSD      float64   `json:"xsol_price_usd"`
+
+	// Supplies are raw token units; the reserve is in lamports
+	HyUSDSupply uint64 `json:"hyusd_supply"`
+	XSOLSupply  uint64 `json:"xsol_supply"`
+	SOLReserve  uint64 `json:"sol_reserve"`
+}
+
+// TradeRecord is an indexed xSOL trade for a tracked wallet
+// Raw amounts are stored separately because XSOLTrade does not serialize them
+type TradeRecord struct {
+	Wallet           string         `json:"wallet"`
+	Trade            hylo.XSOLTrade `json:"trade"`
+	XSOLAmountRaw    uint64         `json:"xsol_amount_raw"`
+	CounterAmountRaw uint64         `json:"counter_amount_raw"`
+	IndexedAt        time.Time      `json:"indexed_at"`
+}
+
+// NewTradeRecord captures a parsed trade for storage
+func NewTradeRecord(wallet string, trade *hylo.XSOLTrade) TradeRecord {
+	return TradeRecord{
+		Wallet:           wallet,
+		Trade:            *trade,
+		XSOLAmountRaw:    trade.XSOLAmountRaw,
+		CounterAmountRaw: trade.CounterAmountRaw,
+		IndexedAt:        time.Now().UTC(),
+	}
+}
+
+// Cursor is the per-wallet sync watermark: the newest indexed signature
+type Cursor struct {
+	LastSignature string    `json:"last_signature"`
+	LastSlot      uint64    `json:"last_slot"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// RetryEntry is a signature whose transaction could not be fetched while a wallet's trades were indexed
+// The sync cursor moves past it, so it is refetched on a backoff until its trade is indexed or the
+// attempts run out, after which it stays listed as a persistent failure
+type RetryEntry struct {
+	Wallet        string    `json:"wallet"`
+	Signature     string    `json:"signature"`
+	Slot          uint64    `json:"slot"`
+	Attempts      int       `json:"attempts"` // Fetches that failed, including the one during sync
+	LastError     string    `json:"last_error"`
+	FirstFailedAt time.Time `json:"first_failed_at"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	Exhausted     bool      `json:"exhausted"`          // Attempts ran out; no longer retried
+	Backfill      bool      `json:"backfill,omitempty"` // Failed during the wallet's first sync, so a recovered trade is not announced
+}
+
+// Store persists tracked wallets, balance snapshots, the trade index, sync cursors and the retry queue
+// Wallet registrations and portfolios are namespaced by tenant: a tenant only reads and changes its own
+type Store interface {
+	// TrackWallet registers a wallet for wallet.Tenant; returns false if the tenant already tracked it, whatever its status
+	TrackWallet(wallet TrackedWallet) (bool, error)
+	// ListWallets returns every registration of every tenant, including paused ones
+	ListWallets() []TrackedWallet
+	// ListTenantWallets returns the tenant's registrations, including paused ones
+	ListTenantWallets(tenant string) []TrackedWallet
+	GetWallet(tenant, address string) (TrackedWallet, bool)
+	// WalletTenants returns every tenant's registration of address, oldest first
+	WalletTenants(address string) []TrackedWallet
+	// SetWalletStatus changes a registration's lifecycle status; returns ErrWalletNotTracked when the tenant does not track the wallet
+	SetWalletStatus(tenant, address, status, lastError string) (TrackedWallet, error)
+	// SetWalletDigest replaces a registration's digest preference (nil opts out); returns ErrWalletNotTracked when the tenant does not track the wallet
+	SetWalletDigest(tenant, address string, digest *DigestPreference) (TrackedWallet, error)
+
+	AddSnapshot(snapshot BalanceSnapshot) error
+	LatestSnapshot(wallet string) (*BalanceSnapshot, bool)
+	// SnapshotAt returns the newest snapshot taken at or before t
+	SnapshotAt(wallet string, t time.Time) (*BalanceSnapshot, bool)
+	// SnapshotAtSlot returns the newest snapshot taken at or before slot
+	SnapshotAtSlot(wallet string, slot uint64) (*BalanceSnapshot, bool)
+
+	// AddTrades indexes trades, ignoring signatures already stored; returns the newly added records
+	AddTrades(records []TradeRecord) ([]TradeRecord, error)
+	// TradesSince returns a wallet's trades with block time at or after since, newest first
+	TradesSince(wallet string, since time.Time) []TradeRecord
+	// TradeBySignature returns the indexed trade with signature, whichever wallet it was indexed for
+	// A signature is indexed once, for the first tracked wallet synced with it
+	TradeBySignature(signature string) (TradeRecord, bool)
+
+	Cursor(wallet string) (Cursor, bool)
+	SetCursor(wallet string, cursor Cursor) error
+
+	// SaveRetry adds a retry entry or replaces the one with the same wallet and signature
+	SaveRetry(entry RetryEntry) error
+	// Retries returns a wallet's retry entries, newest slot first
+	Retries(wallet string) []RetryEntry
+	// RemoveRetry drops a retry entry; unknown entries are ignored
+	RemoveRetry(wallet, signature string) error
+
+	// AddProtocolSnapshot records protocol state; snapshots at or before the newest stored slot are ignored
+	AddProtocolSnapshot(snapshot ProtocolSnapshot) error
+	// ProtocolSnapshotNear returns the snapshot whose slot is closest to slot, preferring the earlier one on ties
+	ProtocolSnapshotNear(slot uint64) (*ProtocolSnapshot, bool)
+	// ProtocolSnapshotsSince returns the snapshots taken at or after since, oldest first
+	ProtocolSnapshotsSince(since time.Time) []ProtocolSnapshot
+
+	// SavePortfolio stores a portfolio for portfolio.Tenant, replacing any with the same ID
+	SavePortfolio(portfolio Portfolio) error
+	// ListPortfolios returns the tenant's portfolios, oldest first
+	ListPortfolios(tenant string) []Portfolio
+	// GetPortfolio returns the portfolio when it belongs to tenant
+	GetPortfolio(tenant, id string) (Portfolio, bool)
+	// DeletePortfolio removes a portfolio; returns ErrPortfolioNotFound for unknown IDs and other tenants' portfolios
+	DeletePortfolio(tenant, id string) error
+
+	// Flush persists pending changes; a no-op for purely in-memory stores
+	Flush() error
+}
@@ -0,0 +1,363 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// Default streaming settings
+const (
+	DefaultClientBuffer       = 32              // Updates buffered per client before dropping
+	DefaultUnsubscribeTimeout = 5 * time.Second // Bound for tearing down upstream subscriptions
+	DefaultMaxWallets         = 50              // Wallets with upstream subscriptions open at once
+)
+
+// ErrTooManyWallets is returned by Subscribe when a new wallet would exceed the upstream wallet cap
+var ErrTooManyWallets = errors.New("too many streamed wallets")
+
+// Config holds wallet stream configuration
+type Config struct {
+	// MaxWallets caps the wallets with upstream subscriptions open at once, each taking one per streamed token;
+	// clients of a wallet already streamed always join. 0 disables the cap
+	MaxWallets int
+}
+
+// NewConfigFromEnvironment loads STREAM_MAX_WALLETS, falling back to the default for missing or invalid values
+func NewConfigFromEnvironment() *Config {
+	config := &Config{MaxWallets: DefaultMaxWallets}
+	if value := os.Getenv("STREAM_MAX_WALLETS"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			config.MaxWallets = n
+		}
+	}
+	return config
+}
+
+// StreamedTokens are the tokens whose ATAs are watched for each streamed wallet
+var StreamedTokens = []string{tokens.XSOLSymbol, tokens.HyUSDSymbol, tokens.SHyUSDSymbol}
+
+// Upstream opens account subscriptions against Solana; satisfied by *solana.WSClient
+type Upstream interface {
+	AccountSubscribe(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.Subscription, error)
+	Unsubscribe(ctx context.Context, sub *solana.Subscription) error
+}
+
+// Update is an account change for one of a wallet's token accounts
+type Update struct {
+	Wallet     string          `json:"wallet"`
+	Token      string          `json:"token"`
+	Account    string          `json:"account"`
+	Slot       uint64          `json:"slot"`
+	Data       json.RawMessage `json:"data"`
+	ReceivedAt time.Time       `json:"received_at"`
+}
+
+// Stats reports the multiplexing ratio between clients and upstream subscriptions
+type Stats struct {
+	Wallets               int `json:"wallets"`
+	Clients               int `json:"clients"`
+	UpstreamSubscriptions int `json:"upstream_subscriptions"`
+}
+
+// Manager multiplexes client streams onto one upstream subscription per wallet ATA
+// Upstream subscriptions are reference counted per wallet and torn down when the last client leaves
+type Manager struct {
+	upstream    Upstream
+	tokenConfig *tokens.Config
+	commitment  solana.Commitment
+	maxWallets  int
+	logger      *logger.Logger
+
+	mu      sync.Mutex
+	wallets map[solana.Address]*walletStream
+	nextID  atomic.Uint64
+}
+
+// walletStream holds the upstream subscriptions and connected clients for a single wallet
+type walletStream struct {
+	wallet solana.Address
+	refs   int // Guarded by Manager.mu
+
+	mu       sync.RWMutex
+	started  bool
+	upstream []*solana.Subscription
+	clients  map[uint64]*Client
+}
+
+// StreamedEvents are the indexer events forwarded to the clients of the event's wallet
+var StreamedEvents = []string{webhook.EventBalanceChanged}
+
+// Client is a single consumer of a wallet's updates and events
+// Both channels are closed when the client is closed; slow consumers drop updates and events instead of blocking others
+type Client struct {
+	ID      uint64
+	Wallet  solana.Address
+	Updates <-chan Update
+	Events  <-chan webhook.Event
+
+	updates chan Update
+	events  chan webhook.Event
+	dropped atomic.Uint64
+	stream  *walletStream
+	manager *Manager
+	once    sync.Once
+}
+
+// NewManager creates a subscription manager on top of an upstream account subscriber
+// A nil config uses the defaults
+func NewManager(upstream Upstream, tokenConfig *tokens.Config, config *Config) *Manager {
+	if config == nil {
+		config = &Config{MaxWallets: DefaultMaxWallets}
+	}
+	return &Manager{
+		upstream:    upstream,
+		tokenConfig: tokenConfig,
+		commitment:  solana.CommitmentConfirmed,
+		maxWallets:  config.MaxWallets,
+		logger:      logger.NewFromEnv().WithComponent("stream-manager"),
+		wallets:     make(map[solana.Address]*walletStream),
+	}
+}
+
+// Subscribe registers a client for wallet updates, opening upstream subscriptions for the first client
+// Returns ErrTooManyWallets when the wallet is not streamed yet and the wallet cap is reached
+// Callers must Close the client when the connection ends
+func (m *Manager) Subscribe(ctx context.Context, wallet solana.Address) (*Client, error) {
+	if err := wallet.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid wallet address: %w", err)
+	}
+
+	m.mu.Lock()
+	stream, ok := m.wallets[wallet]
+	if !ok && m.maxWallets > 0 && len(m.wallets) >= m.maxWallets {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w: %d wallets already streamed", ErrTooManyWallets, m.maxWallets)
+	}
+	if !ok {
+		stream = &walletStream{wallet: wallet, clients: make(map[uint64]*Client)}
+		m.wallets[wallet] = stream
+	}
+	stream.refs++
+	m.mu.Unlock()
+
+	stream.mu.Lock()
+	if !stream.started {
+		if err := m.start(ctx, stream); err != nil {
+			stream.mu.Unlock()
+			m.release(stream)
+			return nil, err
+		}
+	}
+
+	updates := make(chan Update, DefaultClientBuffer)
+	events := make(chan webhook.Event, DefaultClientBuffer)
+	client := &Client{
+		ID:      m.nextID.Add(1),
+		Wallet:  wallet,
+		Updates: updates,
+		Events:  events,
+		updates: updates,
+		events:  events,
+		stream:  stream,
+		manager: m,
+	}
+	stream.clients[client.ID] = client
+	stream.mu.Unlock()
+
+	metrics.AddStreamClients(1)
+	return client, nil
+}
+
+// Stats returns the current number of streamed wallets, clients and upstream subscriptions
+func (m *Manager) Stats() Stats {
+	m.mu.Lock()
+	streams := make([]*walletStream, 0, len(m.wallets))
+	for _, stream := range m.wallets {
+		streams = append(streams, stream)
+	}
+	m.mu.Unlock()
+
+	stats := Stats{Wallets: len(streams)}
+	for _, stream := range streams {
+		stream.mu.RLock()
+		stats.Clients += len(stream.clients)
+		stats.UpstreamSubscriptions += len(stream.upstream)
+		stream.mu.RUnlock()
+	}
+	return stats
+}
+
+// Close disconnects the client and releases its reference on the wallet's upstream subscriptions
+func (c *Client) Close() {
+	c.once.Do(func() {
+		c.stream.mu.Lock()
+		delete(c.stream.clients, c.ID)
+		close(c.updates)
+		close(c.events)
+		c.stream.mu.Unlock()
+
+		metrics.AddStreamClients(-1)
+		c.manager.release(c.stream)
+	})
+}
+
+// Publish forwards a streamed indexer event to the connected clients of its wallet
+// Events for wallets nobody is streaming are discarded; the indexer never blocks on a slow client
+func (m *Manager) Publish(event webhook.Event) {
+	if !isStreamedEvent(event.Type) {
+		return
+	}
+
+	m.mu.Lock()
+	stream, ok := m.wallets[solana.Address(event.Wallet)]
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	stream.mu.RLock()
+	for _, client := range stream.clients {
+		select {
+		case client.events <- event:
+		default:
+			client.dropped.Add(1)
+		}
+	}
+	stream.mu.RUnlock()
+}
+
+func isStreamedEvent(eventType string) bool {
+	for _, streamed := range StreamedEvents {
+		if eventType == streamed {
+			return true
+		}
+	}
+	return false
+}
+
+// Dropped returns the number of updates and events discarded because the client fell behind
+func (c *Client) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// start opens one upstream subscription per streamed ATA; called with stream.mu held
+func (m *Manager) start(ctx context.Context, stream *walletStream) error {
+	atas, err := tokens.GetWalletATAs(stream.wallet, m.tokenConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, symbol := range StreamedTokens {
+		ata, ok := atas[symbol]
+		if !ok {
+			continue
+		}
+
+		sub, err := m.upstream.AccountSubscribe(ctx, ata, m.commitment)
+		if err != nil {
+			m.unsubscribeAll(stream.upstream)
+			stream.upstream = nil
+			return fmt.Errorf("failed to subscribe to %s account %s: %w", symbol, ata, err)
+		}
+		stream.upstream = append(stream.upstream, sub)
+		go m.fanOut(stream, sub, symbol, ata)
+	}
+
+	stream.started = true
+	metrics.AddStreamUpstreamSubscriptions(len(stream.upstream))
+	m.logger.InfoContext(ctx, "Opened upstream subscriptions for wallet stream",
+		slog.String("wallet", stream.wallet.String()),
+		slog.Int("subscriptions", len(stream.upstream)))
+	return nil
+}
+
+// release drops a reference and tears down upstream subscriptions once no clients remain
+func (m *Manager) release(stream *walletStream) {
+	m.mu.Lock()
+	stream.refs--
+	last := stream.refs == 0
+	if last && m.wallets[stream.wallet] == stream {
+		delete(m.wallets, stream.wallet)
+	}
+	m.mu.Unlock()
+
+	if !last {
+		return
+	}
+
+	stream.mu.Lock()
+	upstream := stream.upstream
+	stream.upstream = nil
+	stream.started = false
+	stream.mu.Unlock()
+
+	if len(upstream) == 0 {
+		return
+	}
+	m.unsubscribeAll(upstream)
+	metrics.AddStreamUpstreamSubscriptions(-len(upstream))
+	m.logger.InfoContext(context.Background(), "Closed upstream subscriptions for wallet stream",
+		slog.String("wallet", stream.wallet.String()),
+		slog.Int("subscriptions", len(upstream)))
+}
+
+func (m *Manager) unsubscribeAll(subs []*solana.Subscription) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultUnsubscribeTimeout)
+	defer cancel()
+
+	for _, sub := range subs {
+		if err := m.upstream.Unsubscribe(ctx, sub); err != nil {
+			m.logger.WarnContext(ctx, "Failed to close upstream subscription",
+				slog.Uint64("subscription_id", sub.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// fanOut forwards upstream notifications to every client until the subscription is closed
+func (m *Manager) fanOut(stream *walletStream, sub *solana.Subscription, symbol string, ata solana.Address) {
+	for raw := range sub.Notifications {
+		var notification struct {
+			Context struct {
+				Slot uint64 `json:"slot"`
+			} `json:"context"`
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(raw, &notification); err != nil {
+			continue
+		}
+
+		update := Update{
+			Wallet:     stream.wallet.String(),
+			Token:      symbol,
+			Account:    ata.String(),
+			Slot:       notification.Context.Slot,
+			Data:       notification.Value,
+			ReceivedAt: time.Now(),
+		}
+
+		stream.mu.RLock()
+		for _, client := range stream.clients {
+			select {
+			case client.updates <- update:
+			default:
+				client.dropped.Add(1)
+			}
+		}
+		stream.mu.RUnlock()
+	}
+}
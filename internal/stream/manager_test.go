@@ -0,0 +1,214 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+const testWallet = solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+
+// fakeUpstream records account subscriptions and lets tests push notifications
+type fakeUpstream struct {
+	mu     sync.Mutex
+	nextID uint64
+	active map[solana.Address]chan json.RawMessage
+	subs   map[uint64]solana.Address
+	opened int
+}
+
+func newFakeUpstream() *fakeUpstream {
+	return &fakeUpstream{
+		active: make(map[solana.Address]chan json.RawMessage),
+		subs:   make(map[uint64]solana.Address),
+	}
+}
+
+func (f *fakeUpstream) AccountSubscribe(_ context.Context, address solana.Address, _ solana.Commitment) (*solana.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextID++
+	f.opened++
+	notifications := make(chan json.RawMessage, 4)
+	f.active[address] = notifications
+	f.subs[f.nextID] = address
+	return &solana.Subscription{ID: f.nextID, Method: "accountSubscribe", Notifications: notifications}, nil
+}
+
+func (f *fakeUpstream) Unsubscribe(_ context.Context, sub *solana.Subscription) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	address := f.subs[sub.ID]
+	delete(f.subs, sub.ID)
+	close(f.active[address])
+	delete(f.active, address)
+	return nil
+}
+
+func (f *fakeUpstream) counts() (active, opened int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.active), f.opened
+}
+
+func (f *fakeUpstream) notify(t *testing.T, address solana.Address, payload string) {
+	t.Helper()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	notifications, ok := f.active[address]
+	if !ok {
+		t.Fatalf("no active subscription for %s", address)
+	}
+	notifications <- json.RawMessage(payload)
+}
+
+func TestManagerSharesUpstreamSubscriptions(t *testing.T) {
+	upstream := newFakeUpstream()
+	manager := NewManager(upstream, tokens.NewConfig(), nil)
+	ctx := context.Background()
+
+	first, err := manager.Subscribe(ctx, testWallet)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	second, err := manager.Subscribe(ctx, testWallet)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if active, opened := upstream.counts(); active != len(StreamedTokens) || opened != len(StreamedTokens) {
+		t.Fatalf("Expected %d upstream subscriptions shared by both clients, got %d active/%d opened",
+			len(StreamedTokens), active, opened)
+	}
+	if stats := manager.Stats(); stats.Wallets != 1 || stats.Clients != 2 || stats.UpstreamSubscriptions != len(StreamedTokens) {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	xsolATA, err := tokens.GetWalletATAForToken(testWallet, tokens.XSOLSymbol, tokens.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to derive xSOL ATA: %v", err)
+	}
+	upstream.notify(t, xsolATA, `{"context":{"slot":42},"value":{"lamports":2039280}}`)
+
+	for _, client := range []*Client{first, second} {
+		select {
+		case update := <-client.Updates:
+			if update.Token != tokens.XSOLSymbol || update.Slot != 42 || update.Account != xsolATA.String() {
+				t.Errorf("Unexpected update: %+v", update)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("client %d did not receive the update", client.ID)
+		}
+	}
+
+	first.Close()
+	if active, _ := upstream.counts(); active != len(StreamedTokens) {
+		t.Errorf("Expected upstream subscriptions to stay open for the remaining client, got %d", active)
+	}
+
+	second.Close()
+	second.Close() // Closing twice must not release twice
+	if active, _ := upstream.counts(); active != 0 {
+		t.Errorf("Expected upstream subscriptions to be torn down, got %d active", active)
+	}
+	if stats := manager.Stats(); stats.Wallets != 0 || stats.Clients != 0 {
+		t.Errorf("Expected empty manager, got %+v", stats)
+	}
+	if _, ok := <-first.Updates; ok {
+		t.Error("Expected closed client channel")
+	}
+}
+
+func TestManagerConcurrentSubscribers(t *testing.T) {
+	upstream := newFakeUpstream()
+	manager := NewManager(upstream, tokens.NewConfig(), nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := manager.Subscribe(context.Background(), testWallet)
+			if err != nil {
+				t.Errorf("Subscribe() error = %v", err)
+				return
+			}
+			client.Close()
+		}()
+	}
+	wg.Wait()
+
+	if active, _ := upstream.counts(); active != 0 {
+		t.Errorf("Expected all upstream subscriptions to be released, got %d active", active)
+	}
+}
+
+func TestManagerCapsStreamedWallets(t *testing.T) {
+	const otherWallet = solana.Address("7VHUFJHWu2CuExkJcJrzhQPJ2oygupTWkL2A2For4BmE")
+	upstream := newFakeUpstream()
+	manager := NewManager(upstream, tokens.NewConfig(), &Config{MaxWallets: 1})
+	ctx := context.Background()
+
+	first, err := manager.Subscribe(ctx, testWallet)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	// Another client of a streamed wallet joins without opening upstream subscriptions
+	second, err := manager.Subscribe(ctx, testWallet)
+	if err != nil {
+		t.Fatalf("Subscribe() for a streamed wallet error = %v", err)
+	}
+	second.Close()
+
+	if _, err := manager.Subscribe(ctx, otherWallet); !errors.Is(err, ErrTooManyWallets) {
+		t.Fatalf("Expected ErrTooManyWallets for a second wallet, got %v", err)
+	}
+	if _, opened := upstream.counts(); opened != len(StreamedTokens) {
+		t.Errorf("Expected no upstream subscriptions for the rejected wallet, got %d opened", opened)
+	}
+
+	first.Close()
+	client, err := manager.Subscribe(ctx, otherWallet)
+	if err != nil {
+		t.Fatalf("Expected the freed slot to admit the wallet, got %v", err)
+	}
+	client.Close()
+}
+
+func TestManagerPublishesWalletEvents(t *testing.T) {
+	manager := NewManager(newFakeUpstream(), tokens.NewConfig(), nil)
+	client, err := manager.Subscribe(context.Background(), testWallet)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer client.Close()
+
+	manager.Publish(webhook.Event{ID: "evt_trade", Type: webhook.EventTradeNew, Wallet: testWallet.String()})
+	manager.Publish(webhook.Event{ID: "evt_other", Type: webhook.EventBalanceChanged, Wallet: "other"})
+	manager.Publish(webhook.Event{ID: "evt_balance", Type: webhook.EventBalanceChanged, Wallet: testWallet.String()})
+
+	select {
+	case event := <-client.Events:
+		if event.ID != "evt_balance" {
+			t.Errorf("Expected only the wallet's balance.changed event, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("client did not receive the event")
+	}
+	select {
+	case event := <-client.Events:
+		t.Errorf("Unexpected event %+v", event)
+	default:
+	}
+}
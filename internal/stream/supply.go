@@ -0,0 +1,317 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// SuppliedTokens are the protocol tokens whose mint supply the SupplyWatcher follows
+var SuppliedTokens = []string{tokens.HyUSDSymbol, tokens.XSOLSymbol, tokens.SHyUSDSymbol}
+
+// SupplyConfig holds protocol supply watcher configuration
+type SupplyConfig struct {
+	// Enabled subscribes to the protocol mints at startup; GET /protocol/supply/stream requires it
+	Enabled bool
+}
+
+// NewSupplyConfigFromEnvironment loads SUPPLY_WATCHER_ENABLED, defaulting to enabled
+func NewSupplyConfigFromEnvironment() *SupplyConfig {
+	config := &SupplyConfig{Enabled: true}
+	if value := os.Getenv("SUPPLY_WATCHER_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			config.Enabled = enabled
+		}
+	}
+	return config
+}
+
+// MintReader reads the mint accounts supplies are seeded from; satisfied by *solana.HTTPClient
+type MintReader interface {
+	GetAccount(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error)
+}
+
+// EventPublisher receives supply changes as webhook events
+type EventPublisher interface {
+	Publish(event webhook.Event)
+}
+
+// TokenSupply is the latest known supply of one protocol token
+type TokenSupply struct {
+	Token     string    `json:"token"`
+	Mint      string    `json:"mint"`
+	Supply    string    `json:"supply"` // Formatted with the token's decimals
+	SupplyRaw uint64    `json:"supply_raw"`
+	Slot      uint64    `json:"slot"` // 0 when read from the mint before the first notification
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SupplyChange is a mint supply change seen over the account subscription
+type SupplyChange struct {
+	Token        string    `json:"token"`
+	Mint         string    `json:"mint"`
+	PreviousSlot uint64    `json:"previous_slot"`
+	Slot         uint64    `json:"slot"`
+	Previous     string    `json:"previous"` // Formatted with the token's decimals
+	Current      string    `json:"current"`
+	PreviousRaw  uint64    `json:"previous_raw"`
+	CurrentRaw   uint64    `json:"current_raw"`
+	Delta        string    `json:"delta"` // Signed: negative when tokens were burned
+	ReceivedAt   time.Time `json:"received_at"`
+}
+
+// SupplyWatcher follows the hyUSD, xSOL and sHYUSD mint accounts over WebSocket and publishes every supply
+// change to webhook subscribers and stream clients, so protocol supply is tracked without polling the mints
+type SupplyWatcher struct {
+	upstream   Upstream
+	reader     MintReader
+	events     EventPublisher
+	tokens     []*tokens.TokenInfo
+	commitment solana.Commitment
+	logger     *logger.Logger
+
+	mu       sync.RWMutex
+	supplies map[string]TokenSupply // Keyed by token symbol
+	clients  map[uint64]*SupplyClient
+	nextID   atomic.Uint64
+}
+
+// SupplyClient is a single consumer of supply changes
+// Changes is closed when the client is closed; a slow consumer drops changes instead of blocking others
+type SupplyClient struct {
+	ID      uint64
+	Changes <-chan SupplyChange
+
+	changes chan SupplyChange
+	dropped atomic.Uint64
+	watcher *SupplyWatcher
+	once    sync.Once
+}
+
+// NewSupplyWatcher creates a watcher for the configured protocol mints; events may be nil
+func NewSupplyWatcher(upstream Upstream, reader MintReader, events EventPublisher, tokenConfig *tokens.Config) *SupplyWatcher {
+	watcher := &SupplyWatcher{
+		upstream:   upstream,
+		reader:     reader,
+		events:     events,
+		commitment: solana.CommitmentConfirmed,
+		logger:     logger.NewFromEnv().WithComponent("supply-watcher"),
+		supplies:   make(map[string]TokenSupply),
+		clients:    make(map[uint64]*SupplyClient),
+	}
+	for _, symbol := range SuppliedTokens {
+		if token := tokenConfig.GetTokenBySymbol(symbol); token != nil {
+			watcher.tokens = append(watcher.tokens, token)
+		}
+	}
+	return watcher
+}
+
+// Run seeds each supply from its mint, then applies mint notifications until ctx is done
+// Subscriptions survive WebSocket reconnects; a seed that fails is filled in by the first notification
+func (w *SupplyWatcher) Run(ctx context.Context) {
+	var subs []*solana.Subscription
+	var wg sync.WaitGroup
+	for _, token := range w.tokens {
+		w.seed(ctx, token)
+
+		sub, err := w.upstream.AccountSubscribe(ctx, token.Mint, w.commitment)
+		if err != nil {
+			w.logger.ErrorContext(ctx, "Failed to subscribe to mint account",
+				slog.String("token", token.Symbol),
+				slog.String("mint", token.Mint.String()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		subs = append(subs, sub)
+
+		wg.Add(1)
+		go func(token *tokens.TokenInfo) {
+			defer wg.Done()
+			w.consume(sub, token)
+		}(token)
+	}
+
+	<-ctx.Done()
+
+	unsubscribeCtx, cancel := context.WithTimeout(context.Background(), DefaultUnsubscribeTimeout)
+	defer cancel()
+	for _, sub := range subs {
+		if err := w.upstream.Unsubscribe(unsubscribeCtx, sub); err != nil {
+			w.logger.WarnContext(unsubscribeCtx, "Failed to close mint subscription",
+				slog.Uint64("subscription_id", sub.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+	wg.Wait()
+
+	w.mu.Lock()
+	for _, client := range w.clients {
+		client.closeLocked()
+	}
+	w.mu.Unlock()
+}
+
+// Supplies returns the latest known supply of every followed token in SuppliedTokens order
+func (w *SupplyWatcher) Supplies() []TokenSupply {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	supplies := make([]TokenSupply, 0, len(w.supplies))
+	for _, token := range w.tokens {
+		if supply, ok := w.supplies[token.Symbol]; ok {
+			supplies = append(supplies, supply)
+		}
+	}
+	return supplies
+}
+
+// Subscribe registers a client for supply changes; callers must Close it when the connection ends
+func (w *SupplyWatcher) Subscribe() *SupplyClient {
+	changes := make(chan SupplyChange, DefaultClientBuffer)
+	client := &SupplyClient{
+		ID:      w.nextID.Add(1),
+		Changes: changes,
+		changes: changes,
+		watcher: w,
+	}
+
+	w.mu.Lock()
+	w.clients[client.ID] = client
+	w.mu.Unlock()
+
+	return client
+}
+
+// Close disconnects the client
+func (c *SupplyClient) Close() {
+	c.watcher.mu.Lock()
+	c.closeLocked()
+	c.watcher.mu.Unlock()
+}
+
+// closeLocked closes the client once; called with watcher.mu held
+func (c *SupplyClient) closeLocked() {
+	c.once.Do(func() {
+		delete(c.watcher.clients, c.ID)
+		close(c.changes)
+	})
+}
+
+// Dropped returns the number of changes discarded because the client fell behind
+func (c *SupplyClient) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// seed reads the mint so the first notification can be reported as a change
+func (w *SupplyWatcher) seed(ctx context.Context, token *tokens.TokenInfo) {
+	account, err := w.reader.GetAccount(ctx, token.Mint, w.commitment)
+	if err == nil {
+		err = w.observe(token, 0, account)
+	}
+	if err != nil {
+		w.logger.WarnContext(ctx, "Failed to read mint supply",
+			slog.String("token", token.Symbol),
+			slog.String("error", err.Error()))
+	}
+}
+
+// consume applies mint notifications until the subscription is closed
+func (w *SupplyWatcher) consume(sub *solana.Subscription, token *tokens.TokenInfo) {
+	for raw := range sub.Notifications {
+		var notification struct {
+			Context struct {
+				Slot uint64 `json:"slot"`
+			} `json:"context"`
+			Value solana.AccountInfo `json:"value"`
+		}
+		if err := json.Unmarshal(raw, &notification); err != nil {
+			continue
+		}
+		if err := w.observe(token, notification.Context.Slot, &notification.Value); err != nil {
+			w.logger.Warn("Ignored mint notification",
+				slog.String("token", token.Symbol),
+				slog.Uint64("slot", notification.Context.Slot),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// observe records the supply in a mint account read at slot, publishing a change when it moved
+// Notifications older than the recorded slot and mint updates that left the supply alone are not changes
+func (w *SupplyWatcher) observe(token *tokens.TokenInfo, slot uint64, account *solana.AccountInfo) error {
+	raw, err := tokens.ParseMintSupply(account)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	current := TokenSupply{
+		Token:     token.Symbol,
+		Mint:      token.Mint.String(),
+		Supply:    utils.FormatTokenAmount(raw, token.Decimals),
+		SupplyRaw: raw,
+		Slot:      slot,
+		UpdatedAt: now,
+	}
+
+	w.mu.Lock()
+	previous, known := w.supplies[token.Symbol]
+	if known && slot != 0 && slot < previous.Slot {
+		w.mu.Unlock()
+		return nil
+	}
+	w.supplies[token.Symbol] = current
+	if !known || previous.SupplyRaw == raw {
+		w.mu.Unlock()
+		metrics.SetTokenSupply(token.Symbol, utils.NewDecimalFromRaw(raw, token.Decimals).Float64())
+		return nil
+	}
+
+	change := SupplyChange{
+		Token:        token.Symbol,
+		Mint:         current.Mint,
+		PreviousSlot: previous.Slot,
+		Slot:         slot,
+		Previous:     previous.Supply,
+		Current:      current.Supply,
+		PreviousRaw:  previous.SupplyRaw,
+		CurrentRaw:   raw,
+		Delta:        signedAmount(previous.SupplyRaw, raw, token.Decimals),
+		ReceivedAt:   now,
+	}
+	for _, client := range w.clients {
+		select {
+		case client.changes <- change:
+		default:
+			client.dropped.Add(1)
+		}
+	}
+	w.mu.Unlock()
+
+	metrics.SetTokenSupply(token.Symbol, utils.NewDecimalFromRaw(raw, token.Decimals).Float64())
+	if w.events != nil {
+		w.events.Publish(webhook.Event{Type: webhook.EventSupplyChanged, Data: change})
+	}
+	return nil
+}
+
+// signedAmount formats current minus previous with the token's decimals
+func signedAmount(previous, current uint64, decimals uint8) string {
+	if current >= previous {
+		return utils.FormatTokenAmount(current-previous, decimals)
+	}
+	return fmt.Sprintf("-%s", utils.FormatTokenAmount(previous-current, decimals))
+}
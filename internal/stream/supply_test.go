@@ -0,0 +1,162 @@
+package stream
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/webhook"
+)
+
+// fakeMints serves mint accounts with a fixed supply per mint
+type fakeMints map[solana.Address]uint64
+
+func (f fakeMints) GetAccount(_ context.Context, address solana.Address, _ solana.Commitment) (*solana.AccountInfo, error) {
+	supply, ok := f[address]
+	if !ok {
+		return nil, fmt.Errorf("account %s not found", address)
+	}
+	return &solana.AccountInfo{Owner: tokens.SPLTokenProgramID, Data: mintData(supply)}, nil
+}
+
+// recordingPublisher collects published events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []webhook.Event
+}
+
+func (p *recordingPublisher) Publish(event webhook.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) published() []webhook.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]webhook.Event(nil), p.events...)
+}
+
+func mintData(supply uint64) []byte {
+	data := make([]byte, tokens.MintAccountSize)
+	binary.LittleEndian.PutUint64(data[tokens.MintSupplyOffset:], supply)
+	data[tokens.MintDecimalsOffset] = 6
+	return data
+}
+
+func mintNotification(slot, supply uint64) string {
+	return fmt.Sprintf(`{"context":{"slot":%d},"value":{"lamports":1461600,"owner":%q,"data":[%q,"base64"]}}`,
+		slot, tokens.SPLTokenProgramID, base64.StdEncoding.EncodeToString(mintData(supply)))
+}
+
+// startSupplyWatcher runs a watcher seeded with 1,000 hyUSD and waits for its mint subscriptions
+func startSupplyWatcher(t *testing.T) (*SupplyWatcher, *fakeUpstream, *recordingPublisher) {
+	t.Helper()
+
+	upstream := newFakeUpstream()
+	events := &recordingPublisher{}
+	mints := fakeMints{tokens.HyUSDMint: 1_000_000_000, tokens.XSOLMint: 5_000_000, tokens.SHyUSDMint: 0}
+	watcher := NewSupplyWatcher(upstream, mints, events, tokens.NewConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watcher.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if active, _ := upstream.counts(); active == len(SuppliedTokens) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for mint subscriptions")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return watcher, upstream, events
+}
+
+func receiveChange(t *testing.T, client *SupplyClient) SupplyChange {
+	t.Helper()
+
+	select {
+	case change := <-client.Changes:
+		return change
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for supply change")
+	}
+	return SupplyChange{}
+}
+
+func TestSupplyWatcherSeedsFromMints(t *testing.T) {
+	watcher, _, _ := startSupplyWatcher(t)
+
+	supplies := watcher.Supplies()
+	if len(supplies) != len(SuppliedTokens) {
+		t.Fatalf("Expected %d supplies, got %d", len(SuppliedTokens), len(supplies))
+	}
+	if supplies[0].Token != tokens.HyUSDSymbol || supplies[0].Supply != "1000" || supplies[0].Slot != 0 {
+		t.Errorf("Unexpected hyUSD supply: %+v", supplies[0])
+	}
+}
+
+func TestSupplyWatcherPublishesChanges(t *testing.T) {
+	watcher, upstream, events := startSupplyWatcher(t)
+	client := watcher.Subscribe()
+	defer client.Close()
+
+	upstream.notify(t, tokens.HyUSDMint, mintNotification(100, 1_250_000_000))
+	change := receiveChange(t, client)
+	if change.Token != tokens.HyUSDSymbol || change.Slot != 100 || change.Previous != "1000" || change.Current != "1250" || change.Delta != "250" {
+		t.Errorf("Unexpected mint change: %+v", change)
+	}
+
+	upstream.notify(t, tokens.HyUSDMint, mintNotification(101, 1_250_000_000)) // Unchanged supply
+	upstream.notify(t, tokens.HyUSDMint, mintNotification(99, 900_000_000))    // Stale slot
+	upstream.notify(t, tokens.HyUSDMint, mintNotification(102, 1_200_000_000))
+	change = receiveChange(t, client)
+	if change.PreviousSlot != 101 || change.Slot != 102 || change.Delta != "-50" {
+		t.Errorf("Unexpected burn change: %+v", change)
+	}
+
+	published := events.published()
+	if len(published) != 2 {
+		t.Fatalf("Expected 2 published events, got %d", len(published))
+	}
+	if published[1].Type != webhook.EventSupplyChanged || published[1].Wallet != "" {
+		t.Errorf("Unexpected event: %+v", published[1])
+	}
+	if supply := watcher.Supplies()[0]; supply.SupplyRaw != 1_200_000_000 || supply.Slot != 102 {
+		t.Errorf("Unexpected latest supply: %+v", supply)
+	}
+}
+
+func TestSupplyClientClose(t *testing.T) {
+	watcher, upstream, _ := startSupplyWatcher(t)
+	closed := watcher.Subscribe()
+	open := watcher.Subscribe()
+	defer open.Close()
+
+	closed.Close()
+	closed.Close()
+	if _, ok := <-closed.Changes; ok {
+		t.Error("Expected closed client's channel to be closed")
+	}
+
+	upstream.notify(t, tokens.XSOLMint, mintNotification(7, 6_000_000))
+	if change := receiveChange(t, open); change.Token != tokens.XSOLSymbol || change.Delta != "1" {
+		t.Errorf("Unexpected change: %+v", change)
+	}
+}
@@ -0,0 +1,158 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// Approval risk levels, from most to least severe
+const (
+	ApprovalRiskUnlimited   = "unlimited"    // The delegate was approved for the maximum u64 amount
+	ApprovalRiskFullBalance = "full_balance" // The delegate may transfer the account's whole balance
+	ApprovalRiskPartial     = "partial"      // The delegate may transfer part of the balance
+)
+
+// TokenApproval is an active delegate on one of a wallet's Hylo token accounts
+// A delegate can transfer up to the delegated amount without the owner signing again
+type TokenApproval struct {
+	Token           string         `json:"token"`
+	Mint            solana.Address `json:"mint"`
+	TokenAccount    solana.Address `json:"token_account"`
+	Delegate        solana.Address `json:"delegate"`
+	DelegatedRaw    uint64         `json:"delegated_raw_amount"`
+	DelegatedAmount string         `json:"delegated_amount"`
+	BalanceRaw      uint64         `json:"balance_raw_amount"`
+	Balance         string         `json:"balance"`
+	Risk            string         `json:"risk"` // unlimited, full_balance or partial
+}
+
+// WalletApprovals lists the active delegates on a wallet's Hylo token accounts
+type WalletApprovals struct {
+	Wallet    solana.Address  `json:"wallet"`
+	Approvals []TokenApproval `json:"approvals"`
+	// AtRisk is set when any approval is unlimited or covers the account's whole balance
+	AtRisk    bool      `json:"at_risk"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// GetWalletApprovals reads the wallet's hyUSD, sHYUSD and xSOL token accounts and lists their active delegates
+// Every account discovery would count is audited when token account discovery is enabled, otherwise only the ATAs;
+// any failed read fails the audit so an outstanding approval is never hidden behind a partial result
+func (s *TokenService) GetWalletApprovals(ctx context.Context, wallet solana.Address) (*WalletApprovals, error) {
+	if err := wallet.Validate(); err != nil {
+		s.logger.LogValidationError(ctx, "get_wallet_approvals", "wallet", wallet, err)
+		return nil, fmt.Errorf("invalid wallet address: %w", err)
+	}
+
+	result := &WalletApprovals{
+		Wallet:    wallet,
+		Approvals: []TokenApproval{},
+		CheckedAt: time.Now().UTC(),
+	}
+
+	for _, mint := range []solana.Address{s.config.HyUSDMint, s.config.SHyUSDMint, s.config.XSOLMint} {
+		tokenInfo := s.config.GetTokenInfo(mint)
+		if tokenInfo == nil {
+			continue
+		}
+
+		accounts, err := s.walletTokenAccounts(ctx, wallet, *tokenInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, keyed := range accounts {
+			tokenAccount, err := ParseSPLTokenAccountWithContext(ctx, &keyed.Account, s.logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse token account %s: %w", keyed.Pubkey, err)
+			}
+			if tokenAccount.Mint != mint || tokenAccount.Owner != wallet || !tokenAccount.HasActiveDelegate() {
+				continue
+			}
+
+			approval := newTokenApproval(*tokenInfo, keyed.Pubkey, tokenAccount)
+			if approval.Risk != ApprovalRiskPartial {
+				result.AtRisk = true
+			}
+			result.Approvals = append(result.Approvals, approval)
+		}
+	}
+
+	if len(result.Approvals) > 0 {
+		s.logger.WarnContext(ctx, "Wallet has active token approvals",
+			slog.String("wallet", wallet.String()),
+			slog.Int("approvals", len(result.Approvals)),
+			slog.Bool("at_risk", result.AtRisk))
+	}
+
+	return result, nil
+}
+
+// walletTokenAccounts returns the wallet's token accounts for a token: every account the lister finds,
+// or the associated token account alone, which is absent when it does not exist
+func (s *TokenService) walletTokenAccounts(ctx context.Context, wallet solana.Address, tokenInfo TokenInfo) ([]solana.KeyedAccount, error) {
+	if s.accountLister != nil {
+		accounts, err := s.accountLister.GetTokenAccountsByOwner(ctx, wallet, tokenInfo.Mint, solana.CommitmentConfirmed)
+		if err != nil {
+			s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetTokenAccountsByOwner", err, 0,
+				slog.String("wallet", wallet.String()),
+				slog.String("token", tokenInfo.Symbol))
+			return nil, fmt.Errorf("failed to list token accounts: %w", err)
+		}
+		return accounts, nil
+	}
+
+	ataAddress, err := DeriveAssociatedTokenAddress(wallet, tokenInfo.Mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive ATA address: %w", err)
+	}
+	if s.missing.contains(ataAddress) {
+		metrics.RecordMissingAccount(metrics.MissingAccountHit)
+		return nil, nil
+	}
+
+	accountInfo, err := s.httpClient.GetAccount(ctx, ataAddress, solana.CommitmentConfirmed)
+	if errors.Is(err, solana.ErrAccountNotFound) {
+		s.missing.add(ataAddress)
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetAccount", err, 0,
+			slog.String("ata_address", ataAddress.String()),
+			slog.String("token", tokenInfo.Symbol))
+		return nil, fmt.Errorf("failed to fetch token account: %w", err)
+	}
+	return []solana.KeyedAccount{{Pubkey: ataAddress, Account: *accountInfo}}, nil
+}
+
+// newTokenApproval describes a token account's delegate and grades how much of the balance it exposes
+func newTokenApproval(tokenInfo TokenInfo, tokenAccount solana.Address, account *SPLTokenAccount) TokenApproval {
+	approval := TokenApproval{
+		Token:           tokenInfo.Symbol,
+		Mint:            tokenInfo.Mint,
+		TokenAccount:    tokenAccount,
+		Delegate:        account.Delegate,
+		DelegatedRaw:    account.DelegatedAmount,
+		DelegatedAmount: utils.FormatTokenAmount(account.DelegatedAmount, tokenInfo.Decimals),
+		BalanceRaw:      account.Amount,
+		Balance:         utils.FormatTokenAmount(account.Amount, tokenInfo.Decimals),
+	}
+
+	switch {
+	case account.DelegatedAmount == math.MaxUint64:
+		approval.Risk = ApprovalRiskUnlimited
+	case account.DelegatedAmount >= account.Amount:
+		approval.Risk = ApprovalRiskFullBalance
+	default:
+		approval.Risk = ApprovalRiskPartial
+	}
+	return approval
+}
@@ -0,0 +1,112 @@
+package tokens
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// withDelegate approves delegate for amount on a keyed token account
+func withDelegate(t *testing.T, keyed solana.KeyedAccount, delegate solana.Address, amount uint64) solana.KeyedAccount {
+	t.Helper()
+
+	decoded, err := decodeBase58(string(delegate))
+	if err != nil || len(decoded) != 32 {
+		t.Fatalf("failed to decode %s: %v", delegate, err)
+	}
+	data := append([]byte(nil), keyed.Account.Data...)
+	binary.LittleEndian.PutUint32(data[DelegateOptionOffset:], 1)
+	copy(data[DelegateOffset:], decoded)
+	binary.LittleEndian.PutUint64(data[DelegatedAmountOffset:], amount)
+	keyed.Account.Data = data
+	return keyed
+}
+
+func TestParseSPLTokenAccount_Delegate(t *testing.T) {
+	wallet := solana.Address(TestReferenceWallet)
+	keyed := keyedTokenAccount(t, TestHyUSDATA, NewConfig().HyUSDMint, wallet, 1_000_000, TokenStateInitialized)
+
+	account, err := ParseSPLTokenAccount(&keyed.Account)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account.Delegate != "" || account.HasActiveDelegate() {
+		t.Errorf("expected no delegate, got %q", account.Delegate)
+	}
+
+	keyed = withDelegate(t, keyed, solana.Address(TestSystemWallet), 400_000)
+	account, err = ParseSPLTokenAccount(&keyed.Account)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account.Delegate != solana.Address(TestSystemWallet) || account.DelegatedAmount != 400_000 || !account.HasActiveDelegate() {
+		t.Errorf("expected a delegate approved for 400000, got %q for %d", account.Delegate, account.DelegatedAmount)
+	}
+}
+
+func TestGetWalletApprovals(t *testing.T) {
+	config := NewConfig()
+	service, err := NewTokenService(NewMockHTTPClient(), config)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	wallet := solana.Address(TestReferenceWallet)
+	delegate := solana.Address(TestSystemWallet)
+	lister := &mintAccountLister{accounts: map[solana.Address][]solana.KeyedAccount{
+		config.HyUSDMint: {
+			withDelegate(t, keyedTokenAccount(t, TestHyUSDATA, config.HyUSDMint, wallet, 1_000_000, TokenStateInitialized), delegate, 250_000),
+			withDelegate(t, keyedTokenAccount(t, TestXSOLATA1, config.HyUSDMint, wallet, 2_000_000, TokenStateInitialized), delegate, 0),
+		},
+		config.SHyUSDMint: {
+			keyedTokenAccount(t, TestSHyUSDATA, config.SHyUSDMint, wallet, 5_000_000, TokenStateInitialized),
+		},
+		config.XSOLMint: {
+			withDelegate(t, keyedTokenAccount(t, TestSOLATA, config.XSOLMint, wallet, 3_000_000, TokenStateInitialized), delegate, math.MaxUint64),
+		},
+	}}
+	service.SetTokenAccountLister(lister)
+
+	approvals, err := service.GetWalletApprovals(context.Background(), wallet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(approvals.Approvals) != 2 || !approvals.AtRisk {
+		t.Fatalf("expected 2 active approvals flagged at risk, got %+v", approvals)
+	}
+
+	hyusd, xsol := approvals.Approvals[0], approvals.Approvals[1]
+	if hyusd.Token != "hyUSD" || hyusd.Risk != ApprovalRiskPartial || hyusd.DelegatedAmount != "0.25" || hyusd.Delegate != delegate {
+		t.Errorf("unexpected hyUSD approval: %+v", hyusd)
+	}
+	if xsol.Token != "xSOL" || xsol.Risk != ApprovalRiskUnlimited || xsol.TokenAccount != solana.Address(TestSOLATA) {
+		t.Errorf("unexpected xSOL approval: %+v", xsol)
+	}
+}
+
+func TestGetWalletApprovals_NoAccounts(t *testing.T) {
+	service, err := NewTokenService(NewMockHTTPClient(), NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	approvals, err := service.GetWalletApprovals(context.Background(), solana.Address(TestReferenceWallet))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(approvals.Approvals) != 0 || approvals.AtRisk {
+		t.Errorf("expected no approvals for a wallet without token accounts, got %+v", approvals)
+	}
+}
+
+// mintAccountLister returns the fixed token accounts of each mint
+type mintAccountLister struct {
+	accounts map[solana.Address][]solana.KeyedAccount
+}
+
+func (l *mintAccountLister) GetTokenAccountsByOwner(ctx context.Context, owner, mint solana.Address, commitment solana.Commitment) ([]solana.KeyedAccount, error) {
+	return l.accounts[mint], nil
+}
@@ -280,6 +280,17 @@ func TestATADeterminism(t *testing.T) {
 	})
 }
 
+func BenchmarkDeriveAssociatedTokenAddress(b *testing.B) {
+	wallet := solana.Address(TestReferenceWallet)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := DeriveAssociatedTokenAddress(wallet, XSOLMint); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestCryptoUtilityFunctions(t *testing.T) {
 	t.Run("base58 encode/decode round trip", func(t *testing.T) {
 		// Test with known Solana address
@@ -3,7 +3,9 @@ package tokens
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"hylo-wallet-tracker-api/internal/solana"
 	"hylo-wallet-tracker-api/internal/utils"
@@ -26,6 +28,26 @@ type Config struct {
 	// JitoSOLMint can be overridden via JITOSOL_MINT environment variable
 	JitoSOLMint solana.Address
 
+	// MissingAccountTTL is how long a token account found not to exist is served as a zero balance
+	// without another RPC call; 0 disables the cache (TOKEN_MISSING_ACCOUNT_TTL_SEC)
+	MissingAccountTTL time.Duration
+
+	// MaxWatchedMissingAccounts bounds the WebSocket subscriptions that drop a cached missing account
+	// as soon as it is created; beyond it entries only expire (TOKEN_MISSING_ACCOUNT_WATCH_MAX)
+	MaxWatchedMissingAccounts int
+
+	// DecimalsCheckInterval is the time between checks of the registry's decimals against the on-chain mints,
+	// starting at startup; 0 disables the check (TOKEN_DECIMALS_CHECK_INTERVAL_SEC)
+	DecimalsCheckInterval time.Duration
+
+	// AccountDiscovery sums every token account a wallet holds for a mint, found with getTokenAccountsByOwner,
+	// instead of reading only its associated token account (TOKEN_ACCOUNT_DISCOVERY)
+	AccountDiscovery bool
+
+	// MetadataEnabled resolves the registry tokens' Metaplex metadata at startup so balances and token stats
+	// carry icon URLs (TOKEN_METADATA_ENABLED)
+	MetadataEnabled bool
+
 	// tokenRegistry is an internal map for fast token lookups
 	tokenRegistry map[solana.Address]*TokenInfo
 }
@@ -40,6 +62,11 @@ func NewConfig() *Config {
 		XSOLMint:    XSOLMint,
 		USDCMint:    USDCMint,
 		JitoSOLMint: JitoSOLMint,
+
+		MissingAccountTTL:         DefaultMissingAccountTTL,
+		MaxWatchedMissingAccounts: DefaultMaxWatchedMissingAccounts,
+		DecimalsCheckInterval:     DefaultDecimalsCheckInterval,
+		MetadataEnabled:           true,
 	}
 
 	// Load configuration from environment variables
@@ -78,6 +105,36 @@ func (c *Config) loadFromEnvironment() {
 	if jitosolMint := os.Getenv("JITOSOL_MINT"); jitosolMint != "" {
 		c.JitoSOLMint = solana.Address(strings.TrimSpace(jitosolMint))
 	}
+
+	if value := os.Getenv("TOKEN_MISSING_ACCOUNT_TTL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			c.MissingAccountTTL = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("TOKEN_MISSING_ACCOUNT_WATCH_MAX"); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit >= 0 {
+			c.MaxWatchedMissingAccounts = limit
+		}
+	}
+
+	if value := os.Getenv("TOKEN_DECIMALS_CHECK_INTERVAL_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			c.DecimalsCheckInterval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("TOKEN_ACCOUNT_DISCOVERY"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			c.AccountDiscovery = enabled
+		}
+	}
+
+	if value := os.Getenv("TOKEN_METADATA_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			c.MetadataEnabled = enabled
+		}
+	}
 }
 
 // buildTokenRegistry constructs the internal token registry for fast lookups
@@ -15,6 +15,10 @@ const (
 	// This program manages Associated Token Account (ATA) creation and management
 	AssociatedTokenProgramID = "ATokenGqhhm39XWKyoU9QkZJhbT5gTcfA5q3eHpDG7d"
 
+	// Token2022ProgramID is the Token Extensions (Token-2022) program
+	// Mints created under it hold their token accounts there instead of the SPL Token Program
+	Token2022ProgramID = "TokenzQdBNbLqP5VQbDdVeeLZ9jt7G9cXN2pYMPXhN5A"
+
 	// SystemProgramID is the system program that manages account creation and transfers
 	SystemProgramID = "11111111111111111111111111111111"
 
@@ -24,6 +28,7 @@ const (
 	SHyUSDDecimals  = 6 // Staked hyUSD shares (same as hyUSD)
 	XSOLDecimals    = 6 // xSOL token precision (6 decimals, same as other Hylo tokens)
 	USDCDecimals    = 6 // USDC standard precision (6 decimals)
+	PYUSDDecimals   = 6 // PayPal USD precision (6 decimals)
 	JitoSOLDecimals = 9 // jitoSOL liquid staking token precision (9 decimals, same as SOL)
 	SOLDecimals     = 9 // SOL native token precision (9 decimals - lamports)
 
@@ -33,6 +38,8 @@ const (
 	XSOLSymbol    = "xSOL"
 	USDCSymbol    = "USDC"
 	JitoSOLSymbol = "jitoSOL"
+	SOLSymbol     = "SOL"
+	PYUSDSymbol   = "PYUSD"
 
 	// Token Display Names for user interfaces
 	HyUSDName   = "Hylo USD Stablecoin"
@@ -68,6 +75,15 @@ var (
 	// Liquid staking derivative that includes MEV rewards
 	// Source: https://solscan.io/token/J1toso1uCk3RLmjorhTtrVwY9HJ7X8V9yYac6Y7kGCPn
 	JitoSOLMint = solana.Address("J1toso1uCk3RLmjorhTtrVwY9HJ7X8V9yYac6Y7kGCPn")
+
+	// PYUSDMint is the mint address for PayPal USD, a Token-2022 stablecoin
+	// Only recognized as a trade counter asset; it is not a supported balance token
+	// Source: https://solscan.io/token/2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo
+	PYUSDMint = solana.Address("2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo")
+
+	// WSOLMint is the native mint used by wrapped SOL token accounts
+	// Their lamport balance tracks the wrapped amount, so they count as native SOL holdings
+	WSOLMint = solana.Address("So11111111111111111111111111111111111111112")
 )
 
 // GetSupportedTokenMints returns all supported token mint addresses
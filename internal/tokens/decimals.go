@@ -0,0 +1,191 @@
+package tokens
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// DefaultDecimalsCheckInterval is the time between checks of the registry's decimals against the on-chain mints
+const DefaultDecimalsCheckInterval = time.Hour
+
+// SPL Token mint layout; Token-2022 mints share it and append extensions
+const (
+	MintAccountSize    = 82
+	MintSupplyOffset   = 36 // supply: u64, after the mint authority option (36 bytes)
+	MintDecimalsOffset = 44 // decimals: u8, after the mint authority option (36 bytes) and supply (8 bytes)
+)
+
+// ErrDecimalsMismatch is returned for a token whose configured decimals differ from its on-chain mint
+// Its amounts would be formatted off by a power of ten, so they are not served at all
+var ErrDecimalsMismatch = errors.New("configured decimals do not match the on-chain mint")
+
+// DecimalsStatus is the latest comparison of one registry token with its on-chain mint
+type DecimalsStatus struct {
+	Symbol     string `json:"symbol"`
+	Mint       string `json:"mint"`
+	Configured uint8  `json:"configured_decimals"`
+	// OnChain is omitted while the mint could not be read; the token is then served as configured
+	OnChain   *uint8    `json:"onchain_decimals,omitempty"`
+	Mismatch  bool      `json:"mismatch"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// DecimalsAlerter is called when a token's configured decimals are first found to differ from its mint
+type DecimalsAlerter func(status DecimalsStatus)
+
+// decimalsGuard holds the latest decimals check of every registry token
+type decimalsGuard struct {
+	alerter DecimalsAlerter
+
+	mu       sync.RWMutex
+	statuses map[solana.Address]DecimalsStatus
+}
+
+func newDecimalsGuard() *decimalsGuard {
+	return &decimalsGuard{statuses: make(map[solana.Address]DecimalsStatus)}
+}
+
+// check returns ErrDecimalsMismatch, wrapped with both values, for a mint found to mismatch
+func (g *decimalsGuard) check(mint solana.Address) error {
+	g.mu.RLock()
+	status, ok := g.statuses[mint]
+	g.mu.RUnlock()
+	if !ok || !status.Mismatch {
+		return nil
+	}
+	return fmt.Errorf("%w: %s has %d decimals configured, %d on-chain",
+		ErrDecimalsMismatch, status.Symbol, status.Configured, *status.OnChain)
+}
+
+// update stores status and reports whether the token just started mismatching
+// A failed read keeps the previous verdict, so an RPC outage neither raises nor clears a mismatch
+func (g *decimalsGuard) update(mint solana.Address, status DecimalsStatus) (DecimalsStatus, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	previous, seen := g.statuses[mint]
+	if status.OnChain == nil && seen {
+		status.OnChain, status.Mismatch = previous.OnChain, previous.Mismatch
+	}
+	g.statuses[mint] = status
+	return status, status.Mismatch && !previous.Mismatch
+}
+
+// SetDecimalsAlerter sets the function told about newly detected decimals mismatches
+func (s *TokenService) SetDecimalsAlerter(alerter DecimalsAlerter) {
+	s.decimals.alerter = alerter
+}
+
+// ReconcileDecimals reads every registry token's mint and compares its decimals with the configured ones
+// Mismatched tokens stop being served until a later check matches; each new mismatch is logged and alerted
+func (s *TokenService) ReconcileDecimals(ctx context.Context) []DecimalsStatus {
+	supported := s.config.GetSupportedTokens()
+	statuses := make([]DecimalsStatus, 0, len(supported))
+	for _, token := range supported {
+		status := DecimalsStatus{
+			Symbol:     token.Symbol,
+			Mint:       token.Mint.String(),
+			Configured: token.Decimals,
+			CheckedAt:  time.Now().UTC(),
+		}
+		decimals, err := s.readMintDecimals(ctx, token.Mint)
+		if err != nil {
+			status.Error = err.Error()
+			s.logger.LogExternalAPIError(ctx, "solana-rpc", "check_mint_decimals", err, 0,
+				slog.String("token", token.Symbol))
+		} else {
+			status.OnChain = &decimals
+			status.Mismatch = decimals != token.Decimals
+		}
+
+		status, raised := s.decimals.update(token.Mint, status)
+		metrics.RecordDecimalsCheck(token.Symbol, status.Mismatch)
+		if raised {
+			s.logger.ErrorContext(ctx, "Token decimals do not match the on-chain mint; withholding its amounts",
+				slog.String("token", token.Symbol),
+				slog.String("mint", token.Mint.String()),
+				slog.Int("configured_decimals", int(token.Decimals)),
+				slog.Int("onchain_decimals", int(*status.OnChain)))
+			if s.decimals.alerter != nil {
+				s.decimals.alerter(status)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// RunDecimalsReconciliation checks decimals immediately and then every interval until ctx is cancelled
+func (s *TokenService) RunDecimalsReconciliation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.ReconcileDecimals(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DecimalsStatuses returns the latest check of each registry token, in registry order
+// Empty until the first check ran
+func (s *TokenService) DecimalsStatuses() []DecimalsStatus {
+	s.decimals.mu.RLock()
+	defer s.decimals.mu.RUnlock()
+
+	var statuses []DecimalsStatus
+	for _, mint := range s.config.GetSupportedMints() {
+		if status, ok := s.decimals.statuses[mint]; ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// readMintDecimals reads the decimals of a mint account
+func (s *TokenService) readMintDecimals(ctx context.Context, mint solana.Address) (uint8, error) {
+	account, err := s.httpClient.GetAccount(ctx, mint, solana.CommitmentFinalized)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read mint %s: %w", mint, err)
+	}
+	return ParseMintDecimals(account)
+}
+
+// ParseMintDecimals reads the decimals of an SPL Token or Token-2022 mint account
+func ParseMintDecimals(account *solana.AccountInfo) (uint8, error) {
+	if err := validateMintAccount(account); err != nil {
+		return 0, err
+	}
+	return account.Data[MintDecimalsOffset], nil
+}
+
+// ParseMintSupply reads the raw supply of an SPL Token or Token-2022 mint account
+func ParseMintSupply(account *solana.AccountInfo) (uint64, error) {
+	if err := validateMintAccount(account); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(account.Data[MintSupplyOffset:MintDecimalsOffset]), nil
+}
+
+func validateMintAccount(account *solana.AccountInfo) error {
+	if account.Owner != SPLTokenProgramID && account.Owner != Token2022ProgramID {
+		return fmt.Errorf("account is not a token mint: owned by %s", account.Owner)
+	}
+	if len(account.Data) < MintAccountSize {
+		return fmt.Errorf("invalid mint data length: expected at least %d bytes, got %d", MintAccountSize, len(account.Data))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package tokens
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// mintAccount returns a mint account with the given decimals
+func mintAccount(decimals uint8) *solana.AccountInfo {
+	data := make([]byte, MintAccountSize)
+	data[MintDecimalsOffset] = decimals
+	return &solana.AccountInfo{Owner: SPLTokenProgramID, Data: data}
+}
+
+func TestReconcileDecimals(t *testing.T) {
+	client := NewMockHTTPClient()
+	config := NewConfig()
+	service, err := NewTokenService(client, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var alerts []DecimalsStatus
+	service.SetDecimalsAlerter(func(status DecimalsStatus) { alerts = append(alerts, status) })
+
+	for _, token := range config.GetSupportedTokens() {
+		client.SetAccount(token.Mint, mintAccount(token.Decimals))
+	}
+	client.SetAccount(config.XSOLMint, mintAccount(9))
+
+	statuses := service.ReconcileDecimals(context.Background())
+	if len(statuses) != len(config.GetSupportedTokens()) {
+		t.Fatalf("Expected a status per registry token, got %+v", statuses)
+	}
+	for _, status := range statuses {
+		if status.Mismatch != (status.Symbol == XSOLSymbol) {
+			t.Errorf("Expected only xSOL to mismatch, got %+v", status)
+		}
+	}
+	if len(alerts) != 1 || alerts[0].Symbol != XSOLSymbol || *alerts[0].OnChain != 9 {
+		t.Fatalf("Expected one xSOL alert, got %+v", alerts)
+	}
+
+	// Mismatched amounts are refused and the token is withheld rather than reported as zero
+	wallet := solana.Address(TestReferenceWallet)
+	if _, err := service.GetTokenBalance(context.Background(), wallet, config.XSOLMint); !errors.Is(err, ErrDecimalsMismatch) {
+		t.Errorf("Expected ErrDecimalsMismatch for xSOL, got %v", err)
+	}
+	balances, err := service.GetWalletBalances(context.Background(), wallet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := balances.Balances[XSOLSymbol]; ok || len(balances.Withheld) != 1 || balances.Withheld[0] != XSOLSymbol {
+		t.Errorf("Expected xSOL withheld, got balances %v withheld %v", balances.Balances, balances.Withheld)
+	}
+	if _, ok := balances.Balances[HyUSDSymbol]; !ok {
+		t.Errorf("Expected matching tokens to still be served, got %v", balances.Balances)
+	}
+
+	// A failed read keeps the verdict without alerting again
+	client.SetError(config.XSOLMint, errors.New("rpc error: timeout"))
+	service.ReconcileDecimals(context.Background())
+	if _, err := service.GetTokenBalance(context.Background(), wallet, config.XSOLMint); !errors.Is(err, ErrDecimalsMismatch) {
+		t.Errorf("Expected xSOL to stay withheld after a failed read, got %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("Expected no repeated alert, got %d", len(alerts))
+	}
+
+	// A matching read serves the token again
+	client.Reset()
+	for _, token := range config.GetSupportedTokens() {
+		client.SetAccount(token.Mint, mintAccount(token.Decimals))
+	}
+	service.ReconcileDecimals(context.Background())
+	if _, err := service.GetTokenBalance(context.Background(), wallet, config.XSOLMint); err != nil {
+		t.Errorf("Expected xSOL served after a matching check, got %v", err)
+	}
+	for _, status := range service.DecimalsStatuses() {
+		if status.Mismatch || status.OnChain == nil {
+			t.Errorf("Expected every token to match, got %+v", status)
+		}
+	}
+}
+
+func TestParseMintDecimals(t *testing.T) {
+	token2022 := mintAccount(2)
+	token2022.Owner = Token2022ProgramID
+	token2022.Data = append(token2022.Data, make([]byte, 100)...) // Extensions
+
+	tests := []struct {
+		name    string
+		account *solana.AccountInfo
+		want    uint8
+		wantErr bool
+	}{
+		{name: "SPL Token mint", account: mintAccount(6), want: 6},
+		{name: "Token-2022 mint with extensions", account: token2022, want: 2},
+		{name: "not a token program account", account: &solana.AccountInfo{Owner: "11111111111111111111111111111111", Data: make([]byte, MintAccountSize)}, wantErr: true},
+		{name: "truncated data", account: &solana.AccountInfo{Owner: SPLTokenProgramID, Data: make([]byte, 40)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMintDecimals(tt.account)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseMintDecimals() = %d, %v; want %d, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseMintSupply(t *testing.T) {
+	account := mintAccount(6)
+	binary.LittleEndian.PutUint64(account.Data[MintSupplyOffset:], 1_234_567_890)
+
+	supply, err := ParseMintSupply(account)
+	if err != nil || supply != 1_234_567_890 {
+		t.Errorf("ParseMintSupply() = %d, %v; want 1234567890", supply, err)
+	}
+	if _, err := ParseMintSupply(&solana.AccountInfo{Owner: SPLTokenProgramID, Data: make([]byte, 40)}); err == nil {
+		t.Error("Expected truncated mint data to fail")
+	}
+}
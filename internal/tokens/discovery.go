@@ -0,0 +1,64 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// TokenAccountLister lists every token account a wallet holds for a mint; implemented by *solana.HTTPClient
+type TokenAccountLister interface {
+	GetTokenAccountsByOwner(ctx context.Context, owner, mint solana.Address, commitment solana.Commitment) ([]solana.KeyedAccount, error)
+}
+
+// SetTokenAccountLister makes balances sum every token account the wallet holds for a mint instead of
+// reading only its associated token account, so tokens kept in accounts created by older wallets or
+// programs are counted
+func (s *TokenService) SetTokenAccountLister(lister TokenAccountLister) {
+	s.accountLister = lister
+}
+
+// discoverTokenBalance sums the wallet's token accounts for the token's mint
+// Frozen accounts cannot be spent and are left out; a wallet without accounts has a zero balance
+func (s *TokenService) discoverTokenBalance(ctx context.Context, wallet solana.Address, tokenInfo TokenInfo) (*TokenBalance, error) {
+	accounts, err := s.accountLister.GetTokenAccountsByOwner(ctx, wallet, tokenInfo.Mint, solana.CommitmentConfirmed)
+	if err != nil {
+		s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetTokenAccountsByOwner", err, 0,
+			slog.String("wallet", wallet.String()),
+			slog.String("token", tokenInfo.Symbol))
+		return nil, fmt.Errorf("failed to list token accounts: %w", err)
+	}
+
+	var total uint64
+	counted := 0
+	for _, keyed := range accounts {
+		tokenAccount, err := ParseSPLTokenAccountWithContext(ctx, &keyed.Account, s.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse token account %s: %w", keyed.Pubkey, err)
+		}
+		if tokenAccount.Mint != tokenInfo.Mint || tokenAccount.Owner != wallet {
+			continue
+		}
+		if tokenAccount.IsFrozen {
+			s.logger.WarnContext(ctx, "Skipping frozen token account",
+				slog.String("wallet", wallet.String()),
+				slog.String("token", tokenInfo.Symbol),
+				slog.String("token_account", keyed.Pubkey.String()))
+			continue
+		}
+		total += tokenAccount.Amount
+		counted++
+	}
+
+	s.logger.DebugContext(ctx, "Token balance summed across discovered accounts",
+		slog.String("wallet", wallet.String()),
+		slog.String("token", tokenInfo.Symbol),
+		slog.Int("accounts", counted),
+		slog.Uint64("raw_amount", total))
+
+	balance := s.newTokenBalance(tokenInfo, total)
+	balance.Accounts = counted
+	return balance, nil
+}
@@ -0,0 +1,75 @@
+package tokens
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// stubAccountLister returns fixed token accounts for every owner and mint
+type stubAccountLister struct {
+	accounts []solana.KeyedAccount
+	calls    int
+}
+
+func (s *stubAccountLister) GetTokenAccountsByOwner(ctx context.Context, owner, mint solana.Address, commitment solana.Commitment) ([]solana.KeyedAccount, error) {
+	s.calls++
+	return s.accounts, nil
+}
+
+// keyedTokenAccount builds a token account holding amount of mint for owner in state
+func keyedTokenAccount(t *testing.T, pubkey string, mint, owner solana.Address, amount uint64, state byte) solana.KeyedAccount {
+	t.Helper()
+
+	data := make([]byte, SPLTokenAccountSize)
+	for offset, address := range map[int]solana.Address{MintOffset: mint, OwnerOffset: owner} {
+		decoded, err := decodeBase58(string(address))
+		if err != nil || len(decoded) != 32 {
+			t.Fatalf("failed to decode %s: %v", address, err)
+		}
+		copy(data[offset:], decoded)
+	}
+	binary.LittleEndian.PutUint64(data[AmountOffset:], amount)
+	data[StateOffset] = state
+
+	return solana.KeyedAccount{
+		Pubkey:  solana.Address(pubkey),
+		Account: solana.AccountInfo{Owner: SPLTokenProgramID, Data: data},
+	}
+}
+
+func TestGetTokenBalance_AccountDiscovery(t *testing.T) {
+	config := NewConfig()
+	service, err := NewTokenService(NewMockHTTPClient(), config)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	wallet := solana.Address(TestReferenceWallet)
+	lister := &stubAccountLister{accounts: []solana.KeyedAccount{
+		keyedTokenAccount(t, TestHyUSDATA, config.HyUSDMint, wallet, 1_000_000, TokenStateInitialized),
+		keyedTokenAccount(t, TestXSOLATA1, config.HyUSDMint, wallet, 2_500_000, TokenStateInitialized),
+		keyedTokenAccount(t, TestSHyUSDATA, config.HyUSDMint, wallet, 9_000_000, TokenStateFrozen),
+		keyedTokenAccount(t, TestSOLATA, config.HyUSDMint, solana.Address(TestSystemWallet), 7_000_000, TokenStateInitialized),
+	}}
+	service.SetTokenAccountLister(lister)
+
+	balance, err := service.GetTokenBalance(context.Background(), wallet, config.HyUSDMint)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if balance.RawAmount != 3_500_000 || balance.FormattedAmount != "3.5" || balance.Accounts != 2 {
+		t.Errorf("expected 3.5 hyUSD summed across 2 accounts, got %s across %d", balance.FormattedAmount, balance.Accounts)
+	}
+
+	lister.accounts = nil
+	balance, err = service.GetTokenBalance(context.Background(), wallet, config.HyUSDMint)
+	if err != nil || !balance.IsZero() {
+		t.Errorf("expected a zero balance without token accounts, got %+v, %v", balance, err)
+	}
+	if lister.calls != 2 {
+		t.Errorf("expected every read to list token accounts, got %d calls", lister.calls)
+	}
+}
@@ -0,0 +1,200 @@
+package tokens
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	solanainternal "hylo-wallet-tracker-api/internal/solana"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// MetaplexMetadataProgramID owns the Metaplex token metadata accounts
+const MetaplexMetadataProgramID = "metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s"
+
+// DefaultMetadataFetchTimeout bounds the request for the off-chain JSON a metadata URI points at
+const DefaultMetadataFetchTimeout = 5 * time.Second
+
+// maxMetadataJSONBytes caps the off-chain metadata read; token JSON is a few hundred bytes
+const maxMetadataJSONBytes = 64 << 10
+
+// metadataKeyV1 is the account discriminator of a Metaplex metadata account
+const metadataKeyV1 = 4
+
+// TokenMetadata is a token's Metaplex metadata
+// IconURL is the image of the off-chain JSON at URI, or URI itself when it serves an image
+type TokenMetadata struct {
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol"`
+	URI     string `json:"uri,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+// tokenMetadataStore holds the metadata resolved for each mint
+type tokenMetadataStore struct {
+	mu    sync.RWMutex
+	mints map[solanainternal.Address]*TokenMetadata
+}
+
+func newTokenMetadataStore() *tokenMetadataStore {
+	return &tokenMetadataStore{mints: make(map[solanainternal.Address]*TokenMetadata)}
+}
+
+// DeriveMetadataAddress returns the Metaplex metadata account of a mint
+func DeriveMetadataAddress(mint solanainternal.Address) (solanainternal.Address, error) {
+	mintPubkey, err := solana.PublicKeyFromBase58(string(mint))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse mint address: %w", err)
+	}
+	address, _, err := solana.FindTokenMetadataAddress(mintPubkey)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive metadata address: %w", err)
+	}
+	return solanainternal.Address(address.String()), nil
+}
+
+// ParseMetadataAccount reads the name, symbol and URI of a Metaplex metadata account
+// Layout: key (1), update authority (32), mint (32), then name, symbol and uri as Borsh strings
+// (u32 length prefix), zero-padded to their maximum length
+func ParseMetadataAccount(account *solanainternal.AccountInfo) (*TokenMetadata, error) {
+	if account.Owner != MetaplexMetadataProgramID {
+		return nil, fmt.Errorf("account is not Metaplex metadata: owned by %s", account.Owner)
+	}
+	if len(account.Data) == 0 || account.Data[0] != metadataKeyV1 {
+		return nil, fmt.Errorf("account is not a metadata account")
+	}
+
+	offset := 1 + 32 + 32
+	fields := make([]string, 3)
+	for i := range fields {
+		if len(account.Data) < offset+4 {
+			return nil, fmt.Errorf("metadata truncated at byte %d", offset)
+		}
+		length := int(binary.LittleEndian.Uint32(account.Data[offset:]))
+		offset += 4
+		if length > len(account.Data)-offset {
+			return nil, fmt.Errorf("metadata string of %d bytes overruns the account", length)
+		}
+		fields[i] = strings.TrimSpace(strings.TrimRight(string(account.Data[offset:offset+length]), "\x00"))
+		offset += length
+	}
+
+	return &TokenMetadata{Name: fields[0], Symbol: fields[1], URI: fields[2]}, nil
+}
+
+// ResolveMetadata reads the Metaplex metadata of every registry token and the icon its URI points at
+// Tokens whose metadata cannot be read keep their previous metadata, if any; failures are logged
+func (s *TokenService) ResolveMetadata(ctx context.Context) map[string]*TokenMetadata {
+	resolved := make(map[string]*TokenMetadata)
+	for _, token := range s.config.GetSupportedTokens() {
+		metadata, err := s.readTokenMetadata(ctx, token.Mint)
+		if err != nil {
+			s.logger.LogExternalAPIError(ctx, "solana-rpc", "read_token_metadata", err, 0,
+				slog.String("token", token.Symbol))
+			continue
+		}
+
+		if metadata.URI != "" {
+			icon, err := s.fetchMetadataIcon(ctx, metadata.URI)
+			if err != nil {
+				s.logger.WarnContext(ctx, "Failed to resolve token icon",
+					slog.String("token", token.Symbol),
+					slog.String("uri", metadata.URI),
+					slog.String("error", err.Error()))
+			}
+			metadata.IconURL = icon
+		}
+
+		s.metadata.mu.Lock()
+		s.metadata.mints[token.Mint] = metadata
+		s.metadata.mu.Unlock()
+		resolved[token.Symbol] = metadata
+	}
+
+	s.logger.InfoContext(ctx, "Token metadata resolved",
+		slog.Int("tokens", len(resolved)),
+		slog.Int("supported", len(s.config.GetSupportedTokens())))
+	return resolved
+}
+
+// TokenMetadata returns the Metaplex metadata resolved for mint, or nil before it was resolved
+func (s *TokenService) TokenMetadata(mint solanainternal.Address) *TokenMetadata {
+	s.metadata.mu.RLock()
+	defer s.metadata.mu.RUnlock()
+	return s.metadata.mints[mint]
+}
+
+// newTokenBalance creates a balance carrying the token's icon when its metadata was resolved
+func (s *TokenService) newTokenBalance(tokenInfo TokenInfo, rawAmount uint64) *TokenBalance {
+	balance := NewTokenBalance(tokenInfo, rawAmount)
+	if metadata := s.TokenMetadata(tokenInfo.Mint); metadata != nil {
+		balance.IconURL = metadata.IconURL
+	}
+	return balance
+}
+
+// AttachIcons sets the icons of balances built outside the service, such as balances reconstructed from snapshots
+func (s *TokenService) AttachIcons(balances *WalletBalances) {
+	for _, balance := range balances.Balances {
+		if metadata := s.TokenMetadata(balance.TokenInfo.Mint); metadata != nil {
+			balance.IconURL = metadata.IconURL
+		}
+	}
+}
+
+// readTokenMetadata reads and parses the metadata account of a mint
+func (s *TokenService) readTokenMetadata(ctx context.Context, mint solanainternal.Address) (*TokenMetadata, error) {
+	address, err := DeriveMetadataAddress(mint)
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.httpClient.GetAccount(ctx, address, solanainternal.CommitmentFinalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata account %s: %w", address, err)
+	}
+	return ParseMetadataAccount(account)
+}
+
+// fetchMetadataIcon returns the image of the off-chain metadata JSON at uri
+// URIs that serve an image directly are icons themselves; non-HTTP URIs are not fetched
+func (s *TokenService) fetchMetadataIcon(ctx context.Context, uri string) (string, error) {
+	if !strings.HasPrefix(uri, "https://") && !strings.HasPrefix(uri, "http://") {
+		return "", fmt.Errorf("unsupported metadata URI scheme")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.metadataClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); strings.HasPrefix(mediaType, "image/") {
+		return uri, nil
+	}
+
+	var document struct {
+		Image string `json:"image"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxMetadataJSONBytes)).Decode(&document); err != nil {
+		return "", fmt.Errorf("failed to decode metadata JSON: %w", err)
+	}
+	return strings.TrimSpace(document.Image), nil
+}
@@ -0,0 +1,103 @@
+package tokens
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// metadataAccount builds a Metaplex metadata account with zero-padded name, symbol and uri
+func metadataAccount(name, symbol, uri string) *solana.AccountInfo {
+	data := []byte{metadataKeyV1}
+	data = append(data, make([]byte, 64)...) // update authority and mint
+	for _, field := range []struct {
+		value string
+		size  int
+	}{{name, 32}, {symbol, 10}, {uri, 200}} {
+		padded := make([]byte, field.size)
+		copy(padded, field.value)
+		data = binary.LittleEndian.AppendUint32(data, uint32(field.size))
+		data = append(data, padded...)
+	}
+	return &solana.AccountInfo{Owner: MetaplexMetadataProgramID, Data: data}
+}
+
+func TestParseMetadataAccount(t *testing.T) {
+	metadata, err := ParseMetadataAccount(metadataAccount("Hylo xSOL", "xSOL", "https://example.com/xsol.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metadata.Name != "Hylo xSOL" || metadata.Symbol != "xSOL" || metadata.URI != "https://example.com/xsol.json" {
+		t.Errorf("expected padding to be trimmed, got %+v", metadata)
+	}
+
+	truncated := metadataAccount("Hylo xSOL", "xSOL", "")
+	truncated.Data = truncated.Data[:80]
+	if _, err := ParseMetadataAccount(truncated); err == nil {
+		t.Error("expected an error for truncated metadata")
+	}
+	if _, err := ParseMetadataAccount(&solana.AccountInfo{Owner: SPLTokenProgramID, Data: truncated.Data}); err == nil {
+		t.Error("expected an error for an account not owned by the metadata program")
+	}
+}
+
+func TestResolveMetadata(t *testing.T) {
+	icons := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/xsol.json":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"name":"xSOL","image":"https://cdn.example.com/xsol.png"}`)
+		case "/hyusd.png":
+			w.Header().Set("Content-Type", "image/png")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer icons.Close()
+
+	config := NewConfig()
+	client := NewMockHTTPClient()
+	for mint, account := range map[solana.Address]*solana.AccountInfo{
+		config.XSOLMint:   metadataAccount("Hylo xSOL", "xSOL", icons.URL+"/xsol.json"),
+		config.HyUSDMint:  metadataAccount("Hylo USD", "hyUSD", icons.URL+"/hyusd.png"),
+		config.SHyUSDMint: metadataAccount("Staked hyUSD", "sHYUSD", icons.URL+"/missing.json"),
+	} {
+		address, err := DeriveMetadataAddress(mint)
+		if err != nil {
+			t.Fatalf("failed to derive metadata address: %v", err)
+		}
+		client.SetAccount(address, account)
+	}
+
+	service, err := NewTokenService(client, config)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	resolved := service.ResolveMetadata(context.Background())
+
+	if len(resolved) != 3 {
+		t.Fatalf("expected metadata for the 3 tokens with accounts, got %d", len(resolved))
+	}
+	if got := resolved[XSOLSymbol].IconURL; got != "https://cdn.example.com/xsol.png" {
+		t.Errorf("expected the JSON image as xSOL icon, got %q", got)
+	}
+	if got := resolved[HyUSDSymbol].IconURL; got != icons.URL+"/hyusd.png" {
+		t.Errorf("expected an image URI to be its own icon, got %q", got)
+	}
+	if got := resolved[SHyUSDSymbol]; got.IconURL != "" || got.Name != "Staked hyUSD" {
+		t.Errorf("expected metadata without icon when the URI fails, got %+v", got)
+	}
+
+	balance, err := service.GetTokenBalance(context.Background(), solana.Address(TestReferenceWallet), config.XSOLMint)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if balance.IconURL != "https://cdn.example.com/xsol.png" {
+		t.Errorf("expected balances to carry the icon, got %q", balance.IconURL)
+	}
+}
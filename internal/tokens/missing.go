@@ -0,0 +1,183 @@
+package tokens
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// Missing token account cache defaults
+const (
+	DefaultMissingAccountTTL         = 30 * time.Second
+	DefaultMaxWatchedMissingAccounts = 256
+
+	// unsubscribeTimeout bounds the accountUnsubscribe call made when a watch ends
+	unsubscribeTimeout = 5 * time.Second
+)
+
+// AccountSubscriber delivers account change notifications; implemented by solana.WSClient
+type AccountSubscriber interface {
+	AccountSubscribe(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.Subscription, error)
+	Unsubscribe(ctx context.Context, sub *solana.Subscription) error
+}
+
+// missingEntry is a token account known not to exist until expires
+type missingEntry struct {
+	expires time.Time
+	stop    context.CancelFunc // Ends the account watch; nil when the entry is not watched
+}
+
+// missingAccounts is a short-lived negative cache of token accounts that do not exist
+// Most wallets hold only some Hylo tokens, so batch and portfolio reads spend many getAccountInfo calls
+// on accounts that were never created. An entry expires after the TTL, and while a subscriber is set
+// it is also watched over WebSocket so creating the account invalidates it straight away
+type missingAccounts struct {
+	ttl        time.Duration
+	maxWatched int
+	now        func() time.Time
+	logger     *logger.Logger
+
+	mu         sync.Mutex
+	entries    map[solana.Address]*missingEntry
+	watched    int
+	nextSweep  time.Time
+	subscriber AccountSubscriber
+}
+
+// newMissingAccounts creates the cache; a zero TTL disables it
+func newMissingAccounts(ttl time.Duration, maxWatched int, log *logger.Logger) *missingAccounts {
+	return &missingAccounts{
+		ttl:        ttl,
+		maxWatched: maxWatched,
+		now:        time.Now,
+		logger:     log,
+		entries:    make(map[solana.Address]*missingEntry),
+	}
+}
+
+// setSubscriber enables invalidating entries when the watched account is created
+func (m *missingAccounts) setSubscriber(subscriber AccountSubscriber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriber = subscriber
+}
+
+// contains reports whether address is cached as missing and not yet expired
+func (m *missingAccounts) contains(address solana.Address) bool {
+	if m.ttl <= 0 {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[address]
+	if !ok {
+		return false
+	}
+	if !m.now().Before(entry.expires) {
+		m.deleteLocked(address, entry)
+		return false
+	}
+	return true
+}
+
+// add caches address as missing for the TTL and starts watching it when a subscriber is set
+func (m *missingAccounts) add(address solana.Address) {
+	if m.ttl <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if !now.Before(m.nextSweep) {
+		m.sweepLocked(now)
+		m.nextSweep = now.Add(m.ttl)
+	}
+	if previous, ok := m.entries[address]; ok {
+		m.deleteLocked(address, previous)
+	}
+
+	entry := &missingEntry{expires: now.Add(m.ttl)}
+	m.entries[address] = entry
+	metrics.RecordMissingAccount(metrics.MissingAccountStored)
+
+	if m.subscriber == nil || m.watched >= m.maxWatched {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.ttl)
+	entry.stop = cancel
+	m.watched++
+	go m.watch(ctx, m.subscriber, address, entry)
+}
+
+// remove drops address from the cache
+func (m *missingAccounts) remove(address solana.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.entries[address]; ok {
+		m.deleteLocked(address, entry)
+	}
+}
+
+// watch drops entry on the first change notification for address, until ctx ends with the TTL or removal
+func (m *missingAccounts) watch(ctx context.Context, subscriber AccountSubscriber, address solana.Address, entry *missingEntry) {
+	sub, err := subscriber.AccountSubscribe(ctx, address, solana.CommitmentConfirmed)
+	if err != nil {
+		m.logger.DebugContext(ctx, "Missing token account not watched",
+			slog.String("ata_address", address.String()),
+			slog.String("error", err.Error()))
+		m.release(address, entry, false)
+		return
+	}
+	defer func() {
+		unsubscribeCtx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+		defer cancel()
+		_ = subscriber.Unsubscribe(unsubscribeCtx, sub)
+	}()
+
+	select {
+	case <-ctx.Done():
+		m.release(address, entry, false)
+	case _, ok := <-sub.Notifications:
+		m.release(address, entry, ok)
+	}
+}
+
+// release ends the watch of entry, also dropping it from the cache when the account changed
+// Only the same entry is dropped, so a watch outliving its entry never evicts a newer one
+func (m *missingAccounts) release(address solana.Address, entry *missingEntry, changed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry.stop != nil {
+		entry.stop()
+		entry.stop = nil
+		m.watched--
+	}
+	if changed && m.entries[address] == entry {
+		delete(m.entries, address)
+		metrics.RecordMissingAccount(metrics.MissingAccountInvalidated)
+	}
+}
+
+// deleteLocked removes entry and cancels its watch; the caller holds mu
+func (m *missingAccounts) deleteLocked(address solana.Address, entry *missingEntry) {
+	delete(m.entries, address)
+	if entry.stop != nil {
+		entry.stop()
+	}
+}
+
+// sweepLocked drops every expired entry; the caller holds mu
+func (m *missingAccounts) sweepLocked(now time.Time) {
+	for address, entry := range m.entries {
+		if !now.Before(entry.expires) {
+			m.deleteLocked(address, entry)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package tokens
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// countingClient counts getAccountInfo calls made through MockHTTPClient
+type countingClient struct {
+	*MockHTTPClient
+	calls atomic.Int32
+}
+
+func (c *countingClient) GetAccount(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error) {
+	c.calls.Add(1)
+	return c.MockHTTPClient.GetAccount(ctx, address, commitment)
+}
+
+// fakeSubscriber hands out subscriptions whose notifications the test sends
+type fakeSubscriber struct {
+	mu           sync.Mutex
+	subscribed   chan solana.Address
+	notify       map[solana.Address]chan json.RawMessage
+	unsubscribed atomic.Int32
+}
+
+func newFakeSubscriber() *fakeSubscriber {
+	return &fakeSubscriber{subscribed: make(chan solana.Address, 8), notify: make(map[solana.Address]chan json.RawMessage)}
+}
+
+func (f *fakeSubscriber) AccountSubscribe(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.Subscription, error) {
+	ch := make(chan json.RawMessage, 1)
+	f.mu.Lock()
+	f.notify[address] = ch
+	f.mu.Unlock()
+	f.subscribed <- address
+	return &solana.Subscription{Method: "accountSubscribe", Notifications: ch}, nil
+}
+
+func (f *fakeSubscriber) Unsubscribe(ctx context.Context, sub *solana.Subscription) error {
+	f.unsubscribed.Add(1)
+	return nil
+}
+
+func (f *fakeSubscriber) send(address solana.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notify[address] <- json.RawMessage(`{}`)
+}
+
+func newCachingService(t *testing.T) (*TokenService, *countingClient, *time.Time) {
+	t.Helper()
+	client := &countingClient{MockHTTPClient: NewMockHTTPClient()}
+	config := NewConfig()
+	config.MissingAccountTTL = 30 * time.Second
+	service, err := NewTokenService(client, config)
+	if err != nil {
+		t.Fatalf("Failed to create token service: %v", err)
+	}
+	clock := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	service.missing.now = func() time.Time { return clock }
+	return service, client, &clock
+}
+
+func TestGetTokenBalance_MissingAccountCache(t *testing.T) {
+	service, client, clock := newCachingService(t)
+	wallet := solana.Address(TestReferenceWallet)
+	mint := service.config.HyUSDMint
+	ata, _ := DeriveAssociatedTokenAddress(wallet, mint)
+
+	for i := 0; i < 3; i++ {
+		balance, err := service.GetTokenBalance(context.Background(), wallet, mint)
+		if err != nil || balance.RawAmount != 0 {
+			t.Fatalf("Expected a zero balance, got %+v (%v)", balance, err)
+		}
+	}
+	if calls := client.calls.Load(); calls != 1 {
+		t.Errorf("Expected one RPC call for a missing account within the TTL, got %d", calls)
+	}
+
+	// The account is created after the entry expires
+	client.SetAccount(ata, &solana.AccountInfo{Owner: SPLTokenProgramID, Data: createTokenAccountDataWithAmount(mint, wallet, 2500000)})
+	*clock = clock.Add(31 * time.Second)
+	balance, err := service.GetTokenBalance(context.Background(), wallet, mint)
+	if err != nil || balance.RawAmount != 2500000 {
+		t.Fatalf("Expected the created account after expiry, got %+v (%v)", balance, err)
+	}
+	if calls := client.calls.Load(); calls != 2 {
+		t.Errorf("Expected an RPC call after expiry, got %d", calls)
+	}
+
+	// Disabled cache
+	service.missing.ttl = 0
+	client.Reset()
+	service.GetTokenBalance(context.Background(), wallet, mint)
+	service.GetTokenBalance(context.Background(), wallet, mint)
+	if calls := client.calls.Load(); calls != 4 {
+		t.Errorf("Expected every read to reach RPC with the cache disabled, got %d calls", calls)
+	}
+}
+
+func TestGetTokenBalance_MissingAccountInvalidation(t *testing.T) {
+	service, client, _ := newCachingService(t)
+	subscriber := newFakeSubscriber()
+	service.SetAccountSubscriber(subscriber)
+
+	wallet := solana.Address(TestReferenceWallet)
+	mint := service.config.XSOLMint
+	ata, _ := DeriveAssociatedTokenAddress(wallet, mint)
+
+	if _, err := service.GetTokenBalance(context.Background(), wallet, mint); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	select {
+	case address := <-subscriber.subscribed:
+		if address != ata {
+			t.Fatalf("Expected the ATA %s to be watched, got %s", ata, address)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the missing account to be watched")
+	}
+
+	// The account is created: the notification drops the cached entry
+	client.SetAccount(ata, &solana.AccountInfo{Owner: SPLTokenProgramID, Data: createTokenAccountDataWithAmount(mint, wallet, 7000000)})
+	subscriber.send(ata)
+	deadline := time.Now().Add(time.Second)
+	for service.missing.contains(ata) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	balance, err := service.GetTokenBalance(context.Background(), wallet, mint)
+	if err != nil || balance.RawAmount != 7000000 {
+		t.Fatalf("Expected the created account after invalidation, got %+v (%v)", balance, err)
+	}
+	for subscriber.unsubscribed.Load() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if subscriber.unsubscribed.Load() != 1 {
+		t.Error("Expected the watch to unsubscribe after the notification")
+	}
+}
+
+func TestMissingAccounts_WatchLimitAndRemoval(t *testing.T) {
+	service, _, _ := newCachingService(t)
+	subscriber := newFakeSubscriber()
+	service.missing.maxWatched = 1
+	service.SetAccountSubscriber(subscriber)
+
+	first, second := solana.Address(TestReferenceWallet), solana.Address(TestSystemWallet)
+	service.missing.add(first)
+	service.missing.add(second)
+	<-subscriber.subscribed
+	select {
+	case address := <-subscriber.subscribed:
+		t.Fatalf("Expected only one watch under the limit, also got %s", address)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if !service.missing.contains(second) {
+		t.Error("Expected an unwatched entry to stay cached until it expires")
+	}
+
+	// Removing the watched entry ends its watch and frees the slot
+	service.InvalidateAccount(first)
+	if service.missing.contains(first) {
+		t.Error("Expected InvalidateAccount to drop the entry")
+	}
+	deadline := time.Now().Add(time.Second)
+	for subscriber.unsubscribed.Load() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	service.missing.add(first)
+	select {
+	case <-subscriber.subscribed:
+	case <-time.After(time.Second):
+		t.Error("Expected the freed watch slot to be reused")
+	}
+}
@@ -24,6 +24,11 @@ const (
 	OwnerOffset  = 32  // owner: Pubkey (32 bytes)
 	AmountOffset = 64  // amount: u64 (8 bytes)
 	StateOffset  = 108 // state: u8 (1 byte)
+
+	// Delegate fields: delegate is a COption<Pubkey>, a u32 tag (1 when set) followed by the pubkey
+	DelegateOptionOffset  = 72  // delegate tag: u32 (4 bytes)
+	DelegateOffset        = 76  // delegate: Pubkey (32 bytes)
+	DelegatedAmountOffset = 121 // delegated_amount: u64 (8 bytes)
 )
 
 // Token Account State values
@@ -47,6 +52,10 @@ type SPLTokenAccount struct {
 	IsInitialized bool `json:"isInitialized"`
 	// IsFrozen indicates if the account is frozen
 	IsFrozen bool `json:"isFrozen"`
+	// Delegate is the address approved to transfer tokens out of the account, empty when none is set
+	Delegate solanainternal.Address `json:"delegate,omitempty"`
+	// DelegatedAmount is the raw amount the delegate may still transfer (8 bytes, u64)
+	DelegatedAmount uint64 `json:"delegatedAmount"`
 }
 
 // ParseSPLTokenAccount parses SPL token account data from Solana AccountInfo
@@ -110,6 +119,17 @@ func ParseSPLTokenAccountWithContext(ctx context.Context, accountInfo *solanaint
 	// Extract state (byte 108)
 	state := accountInfo.Data[StateOffset]
 
+	// Extract delegate (tag at bytes 72-75, pubkey at bytes 76-107) and delegated amount (bytes 121-128)
+	var delegate solanainternal.Address
+	if binary.LittleEndian.Uint32(accountInfo.Data[DelegateOptionOffset:DelegateOptionOffset+4]) == 1 {
+		delegate, err = bytesToAddressWithLogging(ctx, accountInfo.Data[DelegateOffset:DelegateOffset+32], log, "delegate")
+		if err != nil {
+			log.LogParsingError(ctx, "parse_spl_token_account", "delegate_address", err)
+			return nil, fmt.Errorf("failed to parse delegate address: %w", err)
+		}
+	}
+	delegatedAmount := binary.LittleEndian.Uint64(accountInfo.Data[DelegatedAmountOffset : DelegatedAmountOffset+8])
+
 	// Determine account status flags
 	isInitialized := state == TokenStateInitialized || state == TokenStateFrozen
 	isFrozen := state == TokenStateFrozen
@@ -121,6 +141,9 @@ func ParseSPLTokenAccountWithContext(ctx context.Context, accountInfo *solanaint
 		State:         state,
 		IsInitialized: isInitialized,
 		IsFrozen:      isFrozen,
+
+		Delegate:        delegate,
+		DelegatedAmount: delegatedAmount,
 	}
 
 	// Log successful parsing
@@ -201,6 +224,11 @@ func (account *SPLTokenAccount) IsZeroBalance() bool {
 	return account.Amount == 0
 }
 
+// HasActiveDelegate returns true if a delegate may still transfer tokens out of the account
+func (account *SPLTokenAccount) HasActiveDelegate() bool {
+	return account.Delegate != "" && account.DelegatedAmount > 0
+}
+
 // String returns a string representation of the token account
 func (account *SPLTokenAccount) String() string {
 	return fmt.Sprintf("SPLTokenAccount{Mint: %s, Owner: %s, Amount: %d, State: %d}",
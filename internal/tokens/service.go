@@ -5,8 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+
+	"golang.org/x/sync/singleflight"
 
 	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/metrics"
 	"hylo-wallet-tracker-api/internal/solana"
 )
 
@@ -19,14 +23,34 @@ type TokenService struct {
 	config *Config
 	// logger for structured logging
 	logger *logger.Logger
+	// inflight coalesces concurrent balance requests for the same wallet
+	inflight singleflight.Group
+	// poolReader provides stability pool state for sHYUSD share accounting (optional)
+	poolReader StabilityPoolReader
+	// missing caches token accounts that do not exist so repeated reads skip the RPC call
+	missing *missingAccounts
+	// decimals withholds tokens whose configured decimals differ from their on-chain mint
+	decimals *decimalsGuard
+	// accountLister discovers every token account of a wallet instead of deriving its ATA (optional)
+	accountLister TokenAccountLister
+	// metadata holds the Metaplex metadata resolved for registry tokens
+	metadata *tokenMetadataStore
+	// metadataClient fetches the off-chain JSON metadata URIs point at
+	metadataClient *http.Client
 }
 
 // HTTPClientInterface defines the contract for Solana HTTP client interaction
 // Allows for easy mocking and testing of the balance service
 type HTTPClientInterface interface {
+	Chain() solana.ChainID
 	GetAccount(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error)
 }
 
+// StabilityPoolReader reads stability pool balances used to resolve sHYUSD into underlying assets
+type StabilityPoolReader interface {
+	ReadStabilityPool(ctx context.Context) (*StabilityPoolSnapshot, error)
+}
+
 // NewTokenService creates a new token service with dependency injection
 // Parameters:
 //   - httpClient: Solana HTTP client for RPC calls
@@ -59,12 +83,33 @@ func NewTokenService(httpClient HTTPClientInterface, config *Config) (*TokenServ
 		httpClient: httpClient,
 		config:     config,
 		logger:     serviceLogger,
+		missing:    newMissingAccounts(config.MissingAccountTTL, config.MaxWatchedMissingAccounts, serviceLogger),
+		decimals:   newDecimalsGuard(),
+		metadata:   newTokenMetadataStore(),
+
+		metadataClient: &http.Client{Timeout: DefaultMetadataFetchTimeout},
 	}
 
 	serviceLogger.InfoContext(context.Background(), "Token service initialized successfully")
 	return service, nil
 }
 
+// SetStabilityPoolReader enables resolving sHYUSD balances into underlying hyUSD and xSOL claims
+func (s *TokenService) SetStabilityPoolReader(reader StabilityPoolReader) {
+	s.poolReader = reader
+}
+
+// SetAccountSubscriber enables dropping a cached missing token account as soon as it is created
+// Without a subscriber cached entries only expire after MissingAccountTTL
+func (s *TokenService) SetAccountSubscriber(subscriber AccountSubscriber) {
+	s.missing.setSubscriber(subscriber)
+}
+
+// InvalidateAccount drops a token account from the missing account cache, e.g. after seeing it created
+func (s *TokenService) InvalidateAccount(ataAddress solana.Address) {
+	s.missing.remove(ataAddress)
+}
+
 // GetTokenBalance fetches the balance for a specific token in a wallet
 // Returns TokenBalance with formatted amount or zero balance if account doesn't exist
 func (s *TokenService) GetTokenBalance(ctx context.Context, wallet solana.Address, mint solana.Address) (*TokenBalance, error) {
@@ -92,6 +137,16 @@ func (s *TokenService) GetTokenBalance(ctx context.Context, wallet solana.Addres
 		return nil, fmt.Errorf("unsupported token mint: %s", mint)
 	}
 
+	// Refuse to format amounts with decimals known to be wrong
+	if err := s.decimals.check(mint); err != nil {
+		return nil, err
+	}
+
+	// Sum every token account the wallet holds when discovery is enabled
+	if s.accountLister != nil {
+		return s.discoverTokenBalance(ctx, wallet, *tokenInfo)
+	}
+
 	// Derive Associated Token Account address
 	ataAddress, err := DeriveAssociatedTokenAddress(wallet, mint)
 	if err != nil {
@@ -106,15 +161,25 @@ func (s *TokenService) GetTokenBalance(ctx context.Context, wallet solana.Addres
 		slog.String("ata_address", ataAddress.String()),
 		slog.String("token", tokenInfo.Symbol))
 
+	// Skip the RPC call for an account recently found not to exist
+	if s.missing.contains(ataAddress) {
+		metrics.RecordMissingAccount(metrics.MissingAccountHit)
+		s.logger.DebugContext(ctx, "Token account cached as missing, returning zero balance",
+			slog.String("wallet", wallet.String()),
+			slog.String("token", tokenInfo.Symbol))
+		return s.newTokenBalance(*tokenInfo, 0), nil
+	}
+
 	// Fetch account info from Solana
 	accountInfo, err := s.httpClient.GetAccount(ctx, ataAddress, solana.CommitmentConfirmed)
 	if err != nil {
 		// Handle account not found (zero balance)
 		if errors.Is(err, solana.ErrAccountNotFound) {
+			s.missing.add(ataAddress)
 			s.logger.InfoContext(ctx, "Token account not found, returning zero balance",
 				slog.String("wallet", wallet.String()),
 				slog.String("token", tokenInfo.Symbol))
-			return NewTokenBalance(*tokenInfo, 0), nil
+			return s.newTokenBalance(*tokenInfo, 0), nil
 		}
 		s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetAccount", err, 0,
 			slog.String("ata_address", ataAddress.String()),
@@ -151,12 +216,31 @@ func (s *TokenService) GetTokenBalance(ctx context.Context, wallet solana.Addres
 		slog.String("formatted_amount", fmt.Sprintf("%.6f", float64(tokenAccount.Amount)/1e6)))
 
 	// Create TokenBalance with proper formatting
-	return NewTokenBalance(*tokenInfo, tokenAccount.Amount), nil
+	return s.newTokenBalance(*tokenInfo, tokenAccount.Amount), nil
 }
 
 // GetWalletBalances fetches balances for all supported Hylo tokens in a wallet
-// Returns WalletBalances with all token balances, including zero balances
+// Concurrent requests for the same wallet share a single upstream fetch
 func (s *TokenService) GetWalletBalances(ctx context.Context, wallet solana.Address) (*WalletBalances, error) {
+	result := s.inflight.DoChan(wallet.String(), func() (interface{}, error) {
+		// Detach from the first caller's cancellation so other waiters still get a result
+		return s.fetchWalletBalances(context.WithoutCancel(ctx), wallet)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*WalletBalances), nil
+	}
+}
+
+// fetchWalletBalances fetches balances for all supported Hylo tokens in a wallet
+// Returns WalletBalances with all token balances, including zero balances
+func (s *TokenService) fetchWalletBalances(ctx context.Context, wallet solana.Address) (*WalletBalances, error) {
 	// Log operation start
 	s.logger.InfoContext(ctx, "Getting wallet balances for all supported tokens",
 		slog.String("wallet", wallet.String()))
@@ -167,6 +251,9 @@ func (s *TokenService) GetWalletBalances(ctx context.Context, wallet solana.Addr
 		return nil, fmt.Errorf("invalid wallet address: %w", err)
 	}
 
+	// Record the context of every token account read
+	ctx, dataContext := solana.WithDataContext(ctx)
+
 	// Get all supported token mints
 	tokenMints := []solana.Address{
 		s.config.HyUSDMint,
@@ -176,6 +263,7 @@ func (s *TokenService) GetWalletBalances(ctx context.Context, wallet solana.Addr
 
 	// Initialize result structure
 	balances := NewWalletBalances(wallet, 0) // Slot will be updated if we get successful responses
+	balances.Chain = s.httpClient.Chain()
 	successCount := 0
 	failCount := 0
 
@@ -211,12 +299,31 @@ func (s *TokenService) GetWalletBalances(ctx context.Context, wallet solana.Addr
 			continue // Skip unsupported tokens
 		}
 
+		// Withheld tokens are listed instead of being reported as zero
+		if s.decimals.check(mint) != nil {
+			balances.Withheld = append(balances.Withheld, tokenInfo.Symbol)
+			continue
+		}
+
 		if _, exists := balances.Balances[tokenInfo.Symbol]; !exists {
 			// Add zero balance for missing tokens
-			balances.AddBalance(NewTokenBalance(*tokenInfo, 0))
+			balances.AddBalance(s.newTokenBalance(*tokenInfo, 0))
 		}
 	}
 
+	// Resolve sHYUSD into its underlying stability pool claim
+	if shyusd, ok := balances.GetSHyUSDBalance(); ok && !shyusd.IsZero() && s.poolReader != nil {
+		if err := s.attachStabilityPoolClaim(ctx, shyusd); err != nil {
+			s.logger.WarnContext(ctx, "Failed to resolve sHYUSD stability pool claim",
+				slog.String("wallet", wallet.String()),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	if balances.Context = dataContext.DataContext(); balances.Context != nil {
+		balances.Slot = balances.Context.Slot
+	}
+
 	// Log operation completion
 	s.logger.InfoContext(ctx, "Wallet balances retrieval completed",
 		slog.String("wallet", wallet.String()),
@@ -232,6 +339,16 @@ func (s *TokenService) GetSupportedTokens() []*TokenInfo {
 	return s.config.GetSupportedTokens()
 }
 
+// GetConfig returns the token registry the service resolves mints with
+func (s *TokenService) GetConfig() *Config {
+	return s.config
+}
+
+// InspectAddress validates an address and derives its ATAs for all supported tokens
+func (s *TokenService) InspectAddress(address string) *AddressValidationResult {
+	return InspectAddress(address, s.config)
+}
+
 // ValidateWalletForBalances performs comprehensive wallet validation before balance fetching
 func (s *TokenService) ValidateWalletForBalances(wallet solana.Address) error {
 	// Basic address validation
@@ -254,6 +371,11 @@ func (s *TokenService) Health(ctx context.Context) error {
 	// This should either succeed or return ErrAccountNotFound (both are healthy)
 	testWallet := solana.Address(TestReferenceWallet) // Test address from PRD
 
+	// A cached missing account would answer without reaching the RPC node
+	if ataAddress, err := DeriveAssociatedTokenAddress(testWallet, s.config.HyUSDMint); err == nil {
+		s.missing.remove(ataAddress)
+	}
+
 	// Test single token balance fetch (hyUSD)
 	_, err := s.GetTokenBalance(ctx, testWallet, s.config.HyUSDMint)
 
@@ -270,3 +392,30 @@ func (s *TokenService) Health(ctx context.Context) error {
 	// Successful fetch is also healthy
 	return nil
 }
+
+// attachStabilityPoolClaim sets the pro-rata hyUSD and xSOL claim on an sHYUSD balance
+func (s *TokenService) attachStabilityPoolClaim(ctx context.Context, shyusd *TokenBalance) error {
+	pool, err := s.poolReader.ReadStabilityPool(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read stability pool: %w", err)
+	}
+
+	hyusdInfo := s.config.GetTokenInfo(s.config.HyUSDMint)
+	xsolInfo := s.config.GetTokenInfo(s.config.XSOLMint)
+	if hyusdInfo == nil || xsolInfo == nil {
+		return fmt.Errorf("hyUSD and xSOL must be registered for share accounting")
+	}
+	for _, mint := range []solana.Address{s.config.HyUSDMint, s.config.XSOLMint} {
+		if err := s.decimals.check(mint); err != nil {
+			return err
+		}
+	}
+
+	claim, err := pool.ClaimFor(shyusd.RawAmount, *hyusdInfo, *xsolInfo)
+	if err != nil {
+		return err
+	}
+
+	shyusd.Underlying = claim
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/binary"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"hylo-wallet-tracker-api/internal/solana"
 )
@@ -23,6 +26,11 @@ func NewMockHTTPClient() *MockHTTPClient {
 	}
 }
 
+// Chain implements HTTPClientInterface
+func (m *MockHTTPClient) Chain() solana.ChainID {
+	return solana.ChainSolana
+}
+
 // GetAccount implements HTTPClientInterface
 func (m *MockHTTPClient) GetAccount(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error) {
 	// Check for specific error first
@@ -111,6 +119,7 @@ func TestNewTokenService(t *testing.T) {
 
 func TestBalanceService_GetTokenBalance(t *testing.T) {
 	config := NewConfig()
+	config.MissingAccountTTL = 0 // Subtests reset the mock chain, so missing accounts must not be cached
 	mockClient := NewMockHTTPClient()
 	service, err := NewTokenService(mockClient, config)
 	if err != nil {
@@ -424,6 +433,180 @@ func TestBalanceService_GetBalances(t *testing.T) {
 	}
 }
 
+// blockingHTTPClient counts GetAccount calls and holds them until released
+type blockingHTTPClient struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (m *blockingHTTPClient) Chain() solana.ChainID {
+	return solana.ChainSolana
+}
+
+func (m *blockingHTTPClient) GetAccount(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error) {
+	m.calls.Add(1)
+	m.once.Do(func() { close(m.started) })
+	<-m.release
+	return nil, solana.ErrAccountNotFound
+}
+
+func TestBalanceService_GetBalancesCoalescesConcurrentRequests(t *testing.T) {
+	client := &blockingHTTPClient{started: make(chan struct{}), release: make(chan struct{})}
+	service, err := NewTokenService(client, NewConfig())
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	const concurrentRequests = 5
+	wallet := solana.Address(TestReferenceWallet)
+
+	var wg sync.WaitGroup
+	results := make([]*WalletBalances, concurrentRequests)
+	errs := make([]error, concurrentRequests)
+	for i := 0; i < concurrentRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = service.GetWalletBalances(context.Background(), wallet)
+		}(i)
+	}
+
+	<-client.started
+	time.Sleep(50 * time.Millisecond) // let the remaining callers join the in-flight fetch
+	close(client.release)
+	wg.Wait()
+
+	for i := 0; i < concurrentRequests; i++ {
+		if errs[i] != nil {
+			t.Fatalf("request %d failed: %v", i, errs[i])
+		}
+		if results[i] != results[0] {
+			t.Errorf("request %d did not share the coalesced result", i)
+		}
+	}
+
+	// One fetch covers hyUSD, sHYUSD and xSOL
+	if calls := client.calls.Load(); calls != 3 {
+		t.Errorf("Expected 3 upstream GetAccount calls, got %d", calls)
+	}
+}
+
+func TestBalanceService_GetBalancesCallerCancellation(t *testing.T) {
+	client := &blockingHTTPClient{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(client.release)
+
+	service, err := NewTokenService(client, NewConfig())
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-client.started
+		cancel()
+	}()
+
+	if _, err := service.GetWalletBalances(ctx, solana.Address(TestReferenceWallet)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// stubPoolReader implements StabilityPoolReader for testing
+type stubPoolReader struct {
+	snapshot *StabilityPoolSnapshot
+	err      error
+}
+
+func (r *stubPoolReader) ReadStabilityPool(ctx context.Context) (*StabilityPoolSnapshot, error) {
+	return r.snapshot, r.err
+}
+
+func TestBalanceService_GetBalancesStabilityPoolClaim(t *testing.T) {
+	config := NewConfig()
+	validWallet := solana.Address(TestReferenceWallet)
+	sHyUSDATA, _ := DeriveAssociatedTokenAddress(validWallet, config.SHyUSDMint)
+
+	tests := []struct {
+		name      string
+		reader    *stubPoolReader
+		wantClaim bool
+		wantShare float64
+		wantHyUSD string
+		wantXSOL  string
+	}{
+		{
+			name: "sHYUSD resolved into pro-rata pool assets",
+			reader: &stubPoolReader{snapshot: &StabilityPoolSnapshot{
+				HyUSDBalance: 1_000_000_000, // 1000 hyUSD
+				XSOLBalance:  40_000_000,    // 40 xSOL
+				SHyUSDSupply: 10_000_000,    // 10 sHYUSD
+				Source:       PoolSourceEstimated,
+			}},
+			wantClaim: true,
+			wantShare: 0.25,
+			wantHyUSD: "250",
+			wantXSOL:  "10",
+		},
+		{
+			name:      "pool read failure keeps plain sHYUSD balance",
+			reader:    &stubPoolReader{err: errors.New("rpc unavailable")},
+			wantClaim: false,
+		},
+		{
+			name:      "zero pool supply is rejected",
+			reader:    &stubPoolReader{snapshot: &StabilityPoolSnapshot{HyUSDBalance: 1}},
+			wantClaim: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := NewMockHTTPClient()
+			mockClient.SetAccount(sHyUSDATA, &solana.AccountInfo{
+				Owner: SPLTokenProgramID,
+				Data:  createTokenAccountDataWithAmount(config.SHyUSDMint, validWallet, 2_500_000),
+			})
+
+			service, err := NewTokenService(mockClient, config)
+			if err != nil {
+				t.Fatalf("Failed to create balance service: %v", err)
+			}
+			service.SetStabilityPoolReader(tt.reader)
+
+			balances, err := service.GetWalletBalances(context.Background(), validWallet)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			shyusd, _ := balances.GetSHyUSDBalance()
+			if !tt.wantClaim {
+				if shyusd.Underlying != nil {
+					t.Errorf("Expected no underlying claim, got %+v", shyusd.Underlying)
+				}
+				return
+			}
+
+			if shyusd.Underlying == nil {
+				t.Fatal("Expected underlying claim on sHYUSD balance")
+			}
+			if shyusd.Underlying.Source != PoolSourceEstimated {
+				t.Errorf("Expected the claim labeled %s, got %q", PoolSourceEstimated, shyusd.Underlying.Source)
+			}
+			if shyusd.Underlying.PoolShare != tt.wantShare {
+				t.Errorf("Expected pool share %f, got %f", tt.wantShare, shyusd.Underlying.PoolShare)
+			}
+			if shyusd.Underlying.HyUSD.FormattedAmount != tt.wantHyUSD {
+				t.Errorf("Expected hyUSD claim %s, got %s", tt.wantHyUSD, shyusd.Underlying.HyUSD.FormattedAmount)
+			}
+			if shyusd.Underlying.XSOL.FormattedAmount != tt.wantXSOL {
+				t.Errorf("Expected xSOL claim %s, got %s", tt.wantXSOL, shyusd.Underlying.XSOL.FormattedAmount)
+			}
+		})
+	}
+}
+
 func TestBalanceService_GetSupportedTokens(t *testing.T) {
 	config := NewConfig()
 	mockClient := NewMockHTTPClient()
@@ -2,8 +2,11 @@ package tokens
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/fx"
 	"hylo-wallet-tracker-api/internal/solana"
 	"hylo-wallet-tracker-api/internal/utils"
 )
@@ -60,6 +63,15 @@ type TokenBalance struct {
 
 	// USDValue is the USD value of this token balance (optional, for display)
 	USDValue *float64 `json:"usd_value,omitempty"`
+
+	// Underlying is the pro-rata stability pool claim backing an sHYUSD balance (optional)
+	Underlying *StabilityPoolClaim `json:"underlying,omitempty"`
+
+	// Accounts is the number of token accounts summed when token account discovery is enabled
+	Accounts int `json:"accounts,omitempty"`
+
+	// IconURL is the token's logo from its Metaplex metadata, once resolved
+	IconURL string `json:"icon_url,omitempty"`
 }
 
 // NewTokenBalance creates a new TokenBalance from raw amount and token info
@@ -86,12 +98,81 @@ func (tb *TokenBalance) SetUSDValue(usdValue float64) {
 	tb.USDValue = &usdValue
 }
 
+// PoolSourceEstimated marks stability pool balances read at assumed offsets of the pool account rather than
+// decoded with the program IDL; claims computed from them carry the same source
+const PoolSourceEstimated = "estimated"
+
+// StabilityPoolSnapshot holds the stability pool balances needed for sHYUSD share accounting
+type StabilityPoolSnapshot struct {
+	// HyUSDBalance is the raw hyUSD held by the pool
+	HyUSDBalance uint64 `json:"hyusd_balance"`
+
+	// XSOLBalance is the raw xSOL held by the pool after rebalancing
+	XSOLBalance uint64 `json:"xsol_balance"`
+
+	// SHyUSDSupply is the raw sHYUSD supply representing pool shares
+	SHyUSDSupply uint64 `json:"shyusd_supply"`
+
+	// Source tells how the balances were read, e.g. PoolSourceEstimated
+	Source string `json:"source,omitempty"`
+}
+
+// StabilityPoolClaim is a wallet's pro-rata claim on stability pool assets
+type StabilityPoolClaim struct {
+	// PoolShare is the fraction of total sHYUSD supply held by the wallet (0-1)
+	PoolShare float64 `json:"pool_share"`
+
+	// HyUSD is the wallet's share of pool hyUSD
+	HyUSD *TokenBalance `json:"hyusd"`
+
+	// XSOL is the wallet's share of pool xSOL
+	XSOL *TokenBalance `json:"xsol"`
+
+	// Source is how the pool balances behind the claim were read, e.g. PoolSourceEstimated
+	Source string `json:"source,omitempty"`
+}
+
+// ClaimFor computes the pro-rata pool assets owned by the given raw sHYUSD amount
+func (p *StabilityPoolSnapshot) ClaimFor(shyusdAmount uint64, hyusdInfo, xsolInfo TokenInfo) (*StabilityPoolClaim, error) {
+	if p.SHyUSDSupply == 0 {
+		return nil, fmt.Errorf("sHYUSD supply cannot be zero for share accounting")
+	}
+	if shyusdAmount > p.SHyUSDSupply {
+		return nil, fmt.Errorf("sHYUSD amount %d exceeds supply %d", shyusdAmount, p.SHyUSDSupply)
+	}
+
+	return &StabilityPoolClaim{
+		PoolShare: poolShare(shyusdAmount, p.SHyUSDSupply),
+		HyUSD:     NewTokenBalance(hyusdInfo, proRata(p.HyUSDBalance, shyusdAmount, p.SHyUSDSupply)),
+		XSOL:      NewTokenBalance(xsolInfo, proRata(p.XSOLBalance, shyusdAmount, p.SHyUSDSupply)),
+		Source:    p.Source,
+	}, nil
+}
+
+// poolShare returns share/supply computed exactly and rounded to float64 only at the end
+func poolShare(share, supply uint64) float64 {
+	ratio, err := utils.NewDecimalFromRaw(share, 0).Div(utils.NewDecimalFromRaw(supply, 0))
+	if err != nil {
+		return 0
+	}
+	return ratio.Float64()
+}
+
+// proRata returns total * share / supply without intermediate overflow
+func proRata(total, share, supply uint64) uint64 {
+	result := new(big.Int).Mul(new(big.Int).SetUint64(total), new(big.Int).SetUint64(share))
+	return result.Div(result, new(big.Int).SetUint64(supply)).Uint64()
+}
+
 // WalletBalances represents all token balances for a specific wallet
 type WalletBalances struct {
 	// Wallet is the wallet address these balances belong to
 	Wallet solana.Address `json:"wallet"`
 
-	// Slot is the Solana slot when these balances were fetched
+	// Chain is the SVM chain these balances were read from
+	Chain solana.ChainID `json:"chain"`
+
+	// Slot is the Solana slot when these balances were fetched, the slot of Context for live reads
 	Slot solana.Slot `json:"slot"`
 
 	// UpdatedAt is the timestamp when these balances were last updated
@@ -100,8 +181,37 @@ type WalletBalances struct {
 	// Balances is a map of token symbol to token balance
 	Balances map[string]*TokenBalance `json:"balances"`
 
+	// Withheld lists tokens left out of balances because their configured decimals do not match the on-chain mint
+	Withheld []string `json:"withheld,omitempty"`
+
 	// TotalUSDValue is the sum of all token balances in USD (optional)
 	TotalUSDValue *float64 `json:"total_usd_value,omitempty"`
+
+	// Currency is set when the USD values were converted to another fiat currency
+	Currency *fx.Conversion `json:"currency,omitempty"`
+
+	// ExplorerURL is the wallet's page on the requested block explorer
+	ExplorerURL string `json:"explorer_url,omitempty"`
+
+	// Reconstructed is set when the balances were rebuilt for a past slot or time from stored data
+	Reconstructed *Reconstruction `json:"reconstructed,omitempty"`
+
+	// Context is the slot, commitment and fetch time of the chain data behind the response
+	Context *solana.DataContext `json:"context,omitempty"`
+}
+
+// Reconstruction describes how historical balances were rebuilt from a stored snapshot and the trade index
+type Reconstruction struct {
+	// BaseSlot and BaseTimestamp identify the snapshot the balances were rebuilt from
+	BaseSlot      uint64    `json:"base_slot"`
+	BaseTimestamp time.Time `json:"base_timestamp"`
+
+	// Direction is forward when trades after an earlier snapshot were replayed,
+	// backward when trades before a later snapshot were unwound
+	Direction string `json:"direction"`
+
+	// ReplayedTrades is the number of indexed trades applied to the base snapshot
+	ReplayedTrades int `json:"replayed_trades"`
 }
 
 // NewWalletBalances creates a new WalletBalances instance
@@ -153,6 +263,42 @@ func (wb *WalletBalances) CalculateTotalUSDValue() {
 	}
 }
 
+// InCurrency returns a copy with every USD value converted by conversion
+// Balances are copied so responses shared between concurrent requests are never modified
+func (wb *WalletBalances) InCurrency(conversion *fx.Conversion) *WalletBalances {
+	converted := *wb
+	converted.Balances = make(map[string]*TokenBalance, len(wb.Balances))
+	for symbol, balance := range wb.Balances {
+		converted.Balances[symbol] = balance.inCurrency(conversion)
+	}
+	converted.TotalUSDValue = conversion.ConvertPtr(wb.TotalUSDValue)
+	converted.Currency = conversion
+	return &converted
+}
+
+// WithExplorer returns a copy of the balances linking to the wallet's page on provider
+func (wb *WalletBalances) WithExplorer(provider explorer.Provider) *WalletBalances {
+	linked := *wb
+	linked.ExplorerURL = provider.AccountURL(wb.Wallet.String())
+	return &linked
+}
+
+// inCurrency returns a copy of the balance with its USD values converted
+func (tb *TokenBalance) inCurrency(conversion *fx.Conversion) *TokenBalance {
+	if tb == nil {
+		return nil
+	}
+	converted := *tb
+	converted.USDValue = conversion.ConvertPtr(tb.USDValue)
+	if tb.Underlying != nil {
+		claim := *tb.Underlying
+		claim.HyUSD = tb.Underlying.HyUSD.inCurrency(conversion)
+		claim.XSOL = tb.Underlying.XSOL.inCurrency(conversion)
+		converted.Underlying = &claim
+	}
+	return &converted
+}
+
 // GetHyUSDBalance is a convenience method to get hyUSD balance
 func (wb *WalletBalances) GetHyUSDBalance() (*TokenBalance, bool) {
 	return wb.GetBalance(HyUSDSymbol)
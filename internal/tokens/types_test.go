@@ -0,0 +1,37 @@
+package tokens
+
+import (
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/fx"
+)
+
+func TestWalletBalancesInCurrency(t *testing.T) {
+	config := NewConfig()
+	balances := NewWalletBalances(XSOLMint, 1)
+	xsol := config.NewTokenBalanceBySymbol(XSOLSymbol, 2_000_000)
+	xsol.SetUSDValue(50)
+	shyusd := config.NewTokenBalanceBySymbol(SHyUSDSymbol, 1_000_000)
+	shyusd.SetUSDValue(10)
+	shyusd.Underlying = &StabilityPoolClaim{PoolShare: 0.1, HyUSD: config.NewTokenBalanceBySymbol(HyUSDSymbol, 1)}
+	shyusd.Underlying.HyUSD.SetUSDValue(10)
+	balances.AddBalance(xsol)
+	balances.AddBalance(shyusd)
+	balances.CalculateTotalUSDValue()
+
+	conversion := &fx.Conversion{Currency: "EUR", Rate: 0.9}
+	converted := balances.InCurrency(conversion)
+
+	if *converted.TotalUSDValue != 54 || *converted.Balances[XSOLSymbol].USDValue != 45 {
+		t.Errorf("Expected values converted at 0.9, got total %v and xSOL %v", *converted.TotalUSDValue, *converted.Balances[XSOLSymbol].USDValue)
+	}
+	if *converted.Balances[SHyUSDSymbol].Underlying.HyUSD.USDValue != 9 || converted.Balances[SHyUSDSymbol].Underlying.XSOL != nil {
+		t.Errorf("Expected the pool claim converted, got %+v", converted.Balances[SHyUSDSymbol].Underlying)
+	}
+	if converted.Currency != conversion {
+		t.Error("Expected the conversion to be attached")
+	}
+	if *balances.TotalUSDValue != 60 || *xsol.USDValue != 50 || *shyusd.Underlying.HyUSD.USDValue != 10 || balances.Currency != nil {
+		t.Error("Expected the original balances to be left unchanged")
+	}
+}
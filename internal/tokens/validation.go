@@ -1,6 +1,7 @@
 package tokens
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -196,6 +197,62 @@ func SuggestCorrection(address string) []string {
 	return suggestions
 }
 
+// AddressValidationRequest is the body accepted by the address validation endpoint
+type AddressValidationRequest struct {
+	Address string `json:"address" example:"A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"`
+}
+
+// AddressValidationResult describes whether an address can be used as a wallet and what it resolves to
+type AddressValidationResult struct {
+	Address     string                    `json:"address"`
+	Valid       bool                      `json:"valid"`
+	Normalized  string                    `json:"normalized,omitempty"`
+	Reason      string                    `json:"reason,omitempty"`
+	TokenMint   string                    `json:"token_mint,omitempty"` // Symbol when the address is a supported token mint
+	ATAs        map[string]solana.Address `json:"atas,omitempty"`
+	Suggestions []string                  `json:"suggestions,omitempty"`
+}
+
+// InspectAddress validates a wallet address and returns its normalized form, derived ATAs
+// for all supported tokens and human-readable suggestions when the input is malformed
+func InspectAddress(address string, config *Config) *AddressValidationResult {
+	result := &AddressValidationResult{Address: address}
+
+	sanitized, err := SanitizeAddress(address)
+	if err != nil {
+		var validationErr *AddressValidationError
+		if errors.As(err, &validationErr) {
+			result.Reason = validationErr.Reason
+		} else {
+			result.Reason = err.Error()
+		}
+		result.Suggestions = SuggestCorrection(address)
+		return result
+	}
+
+	result.Valid = true
+	result.Normalized = sanitized.String()
+
+	if config == nil {
+		return result
+	}
+
+	if tokenInfo := config.GetTokenInfo(sanitized); tokenInfo != nil {
+		result.TokenMint = tokenInfo.Symbol
+		result.Suggestions = append(result.Suggestions,
+			fmt.Sprintf("This is the %s token mint, not a wallet address", tokenInfo.Symbol))
+	}
+
+	atas, err := GetWalletATAs(sanitized, config)
+	if err != nil {
+		result.Suggestions = append(result.Suggestions, fmt.Sprintf("Could not derive token accounts: %s", err.Error()))
+		return result
+	}
+	result.ATAs = atas
+
+	return result
+}
+
 // ValidateTokenConfiguration validates that all token mints in the configuration
 // are valid Solana addresses and don't conflict with each other
 func ValidateTokenConfiguration(config *Config) error {
@@ -461,3 +461,44 @@ func TestAddressValidationError(t *testing.T) {
 		}
 	})
 }
+
+func TestInspectAddress(t *testing.T) {
+	config := NewConfig()
+
+	t.Run("valid wallet is normalized with ATAs", func(t *testing.T) {
+		result := InspectAddress("  "+TestReferenceWallet+"\n", config)
+		if !result.Valid {
+			t.Fatalf("Expected valid address, got reason %q", result.Reason)
+		}
+		if result.Normalized != TestReferenceWallet {
+			t.Errorf("Expected normalized %s, got %s", TestReferenceWallet, result.Normalized)
+		}
+		if len(result.ATAs) != len(config.GetSupportedTokens()) {
+			t.Errorf("Expected ATAs for all supported tokens, got %d", len(result.ATAs))
+		}
+		expected, _ := DeriveAssociatedTokenAddress(solana.Address(TestReferenceWallet), config.XSOLMint)
+		if result.ATAs[XSOLSymbol] != expected {
+			t.Errorf("Expected xSOL ATA %s, got %s", expected, result.ATAs[XSOLSymbol])
+		}
+	})
+
+	t.Run("invalid address returns reason and suggestions", func(t *testing.T) {
+		result := InspectAddress("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyIII", config)
+		if result.Valid {
+			t.Fatal("Expected invalid address")
+		}
+		if !strings.Contains(result.Reason, "invalid characters") {
+			t.Errorf("Expected invalid character reason, got %q", result.Reason)
+		}
+		if len(result.Suggestions) == 0 || len(result.ATAs) != 0 {
+			t.Errorf("Expected suggestions and no ATAs, got %+v", result)
+		}
+	})
+
+	t.Run("token mint is flagged", func(t *testing.T) {
+		result := InspectAddress(config.HyUSDMint.String(), config)
+		if !result.Valid || result.TokenMint != HyUSDSymbol {
+			t.Errorf("Expected hyUSD mint to be flagged, got %+v", result)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package trades
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/price"
+)
+
+// PriceSource supplies the xSOL price trades are valued at for the USD dust threshold; implemented by hylo.PriceService
+// Served prices come from the stale-while-revalidate cache when it is enabled, like GET /price
+type PriceSource interface {
+	GetServedPriceResponse(ctx context.Context) (*price.CombinedPriceResponse, error)
+}
+
+// DustSummary reports the dust filter applied to a trade history page
+type DustSummary struct {
+	MinXSOLRaw uint64  `json:"minXsolRaw,omitempty"` // Trades moving fewer raw xSOL units are dust
+	MinUSD     float64 `json:"minUsd,omitempty"`     // Trades worth less are dust
+	Filtered   int     `json:"filtered"`             // Dust trades left out; request include_dust=true to see them
+}
+
+// SetPriceSource sets the source of the xSOL price used by the USD dust threshold
+// Without one, only trades against stablecoins can be valued and other trades never count as dust by value
+func (s *TradeService) SetPriceSource(source PriceSource) {
+	s.prices = source
+}
+
+// dustCheck drops trades below the configured minimum trade size and counts them
+// The xSOL price is read once, on the first trade that needs it
+type dustCheck struct {
+	service  *TradeService
+	minRaw   uint64
+	minUSD   float64
+	xsolUSD  float64
+	loaded   bool
+	filtered int
+}
+
+// newDustCheck returns a check for one request's trades, or nil when no dust threshold is configured
+func (s *TradeService) newDustCheck() *dustCheck {
+	minRaw, minUSD := s.hyloConfig.DustMinXSOLRaw, s.hyloConfig.DustMinUSD
+	if minRaw == 0 && minUSD <= 0 {
+		return nil
+	}
+	return &dustCheck{service: s, minRaw: minRaw, minUSD: minUSD}
+}
+
+// drop reports whether trade is dust, counting it when it is
+// Failed transactions move no xSOL and are never dust; a trade that cannot be valued passes the USD threshold
+func (c *dustCheck) drop(ctx context.Context, trade *hylo.XSOLTrade) bool {
+	if c == nil || trade == nil || trade.Status == hylo.TradeStatusFailed {
+		return false
+	}
+
+	dust := c.minRaw > 0 && trade.XSOLAmountRaw < c.minRaw
+	if !dust && c.minUSD > 0 {
+		if value, ok := c.valueUSD(ctx, trade); ok && value < c.minUSD {
+			dust = true
+		}
+	}
+	if dust {
+		c.filtered++
+		c.service.logger.DebugContext(ctx, "Trade filtered as dust",
+			slog.String("signature", trade.Signature),
+			slog.String("xsol_amount", trade.XSOLAmount))
+	}
+	return dust
+}
+
+// valueUSD values a trade at its historical xSOL price, else its stablecoin counter amount,
+// else the current xSOL price
+func (c *dustCheck) valueUSD(ctx context.Context, trade *hylo.XSOLTrade) (float64, bool) {
+	amount, err := strconv.ParseFloat(trade.XSOLAmount, 64)
+	if trade.HistoricalPriceUSD != nil && err == nil {
+		if historical, err := strconv.ParseFloat(*trade.HistoricalPriceUSD, 64); err == nil && historical > 0 {
+			return amount * historical, true
+		}
+	}
+	if peg, ok := hylo.GetStablecoins().Lookup(trade.CounterAsset); ok {
+		value, err := strconv.ParseFloat(trade.CounterAmount, 64)
+		return value * peg.PriceAt(trade.Timestamp), err == nil
+	}
+
+	if !c.loaded {
+		c.loaded = true
+		c.xsolUSD = c.service.xsolPriceUSD(ctx)
+	}
+	if err != nil || c.xsolUSD <= 0 {
+		return 0, false
+	}
+	return amount * c.xsolUSD, true
+}
+
+// summary returns what the check dropped, or nil when there is no check
+func (c *dustCheck) summary() *DustSummary {
+	if c == nil {
+		return nil
+	}
+	return &DustSummary{MinXSOLRaw: c.minRaw, MinUSD: c.minUSD, Filtered: c.filtered}
+}
+
+// xsolPriceUSD reads the current xSOL price; 0 when no price source is set or the price cannot be read
+func (s *TradeService) xsolPriceUSD(ctx context.Context) float64 {
+	if s.prices == nil {
+		return 0
+	}
+	prices, err := s.prices.GetServedPriceResponse(ctx)
+	if err != nil {
+		s.logger.DebugContext(ctx, "Skipping USD dust threshold: xSOL price unavailable",
+			slog.String("error", err.Error()))
+		return 0
+	}
+	return prices.XSOLInUSD
+}
@@ -0,0 +1,135 @@
+package trades
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestGetTradesDustFilter(t *testing.T) {
+	wallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	testXSOLATA, err := tokens.DeriveAssociatedTokenAddress(wallet, tokens.XSOLMint)
+	if err != nil {
+		t.Fatalf("failed to derive ATA: %v", err)
+	}
+
+	// sig2 moves 50 raw units of xSOL, below the 1000 unit minimum
+	history := []solana.SignatureInfo{
+		{Signature: "sig1", Slot: 300, BlockTime: int64Ptr(1757360080)},
+		{Signature: "sig2", Slot: 299, BlockTime: int64Ptr(1757360079)},
+		{Signature: "sig3", Slot: 298, BlockTime: int64Ptr(1757360078)},
+	}
+	transactions := map[string]*solana.TransactionDetails{
+		"sig1": createMockTradeTransaction("sig1", 300, 1757360080, testXSOLATA, "1000000", "2000000", ""),
+		"sig2": createMockTradeTransaction("sig2", 299, 1757360079, testXSOLATA, "2000000", "2000050", ""),
+		"sig3": createMockTradeTransaction("sig3", 298, 1757360078, testXSOLATA, "3000000", "1500000", ""),
+	}
+	client := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			return append([]solana.SignatureInfo(nil), history...), nil
+		},
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			return transactions[string(signature)], nil
+		},
+	}
+
+	hyloConfig := hylo.NewConfig()
+	hyloConfig.DustMinXSOLRaw = 1000
+	service, err := NewTradeService(client, tokens.NewConfig(), hyloConfig)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	response, err := service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Trades) != 2 || response.Trades[0].Signature != "sig1" || response.Trades[1].Signature != "sig3" {
+		t.Fatalf("expected the dust trade to be left out, got %+v", response.Trades)
+	}
+	if response.Dust == nil || response.Dust.Filtered != 1 || response.Dust.MinXSOLRaw != 1000 {
+		t.Errorf("expected one dust trade to be counted, got %+v", response.Dust)
+	}
+
+	response, err = service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 10, IncludeDust: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Trades) != 3 || response.Dust != nil {
+		t.Errorf("expected every trade without a dust summary, got %d trades and %+v", len(response.Trades), response.Dust)
+	}
+}
+
+func TestDustCheckUSD(t *testing.T) {
+	hyloConfig := hylo.NewConfig()
+	hyloConfig.DustMinUSD = 1
+	service, err := NewTradeService(&mockHTTPClient{}, tokens.NewConfig(), hyloConfig)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	trade := func(xsol, counter uint64, asset string) *hylo.XSOLTrade {
+		trade := hylo.NewXSOLTrade("sig", 1, 1)
+		trade.SetTradeDetails(hylo.TradeSideBuy, xsol, counter, asset)
+		return trade
+	}
+
+	// Stablecoin trades are valued at their counter amount, without a price source
+	dust := service.newDustCheck()
+	if !dust.drop(context.Background(), trade(10_000_000, 500_000, "hyUSD")) || dust.drop(context.Background(), trade(1_000, 2_000_000, "hyUSD")) {
+		t.Error("expected hyUSD trades to be judged by their hyUSD amount")
+	}
+	if dust.drop(context.Background(), trade(1_000, 10, "SOL")) {
+		t.Error("expected trades that cannot be valued to pass")
+	}
+
+	// Other trades are valued at the current xSOL price, read once
+	source := &fixedPriceSource{xsolUSD: 0.5}
+	service.SetPriceSource(source)
+	dust = service.newDustCheck()
+	if !dust.drop(context.Background(), trade(1_000_000, 10, "SOL")) || dust.drop(context.Background(), trade(3_000_000, 10, "SOL")) {
+		t.Error("expected SOL trades to be valued at $0.50 per xSOL")
+	}
+	if source.calls != 1 || dust.summary().Filtered != 1 {
+		t.Errorf("expected one price read and one dust trade, got %d reads and %+v", source.calls, dust.summary())
+	}
+
+	// A trade's historical xSOL price is preferred over the current one
+	historical := trade(1_000_000, 10, "SOL")
+	historicalPrice := "2.00"
+	historical.HistoricalPriceUSD = &historicalPrice
+	if dust.drop(context.Background(), historical) {
+		t.Error("expected the trade to be valued at its $2.00 historical xSOL price")
+	}
+
+	failed := trade(0, 0, "")
+	failed.Status = hylo.TradeStatusFailed
+	if dust.drop(context.Background(), failed) {
+		t.Error("expected failed transactions never to be dust")
+	}
+
+	source.err = errors.New("price unavailable")
+	if dust = service.newDustCheck(); dust.drop(context.Background(), trade(1_000_000, 10, "SOL")) {
+		t.Error("expected an unavailable price to skip the USD threshold")
+	}
+}
+
+// fixedPriceSource returns a fixed xSOL price
+type fixedPriceSource struct {
+	xsolUSD float64
+	err     error
+	calls   int
+}
+
+func (s *fixedPriceSource) GetServedPriceResponse(ctx context.Context) (*price.CombinedPriceResponse, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &price.CombinedPriceResponse{XSOLInUSD: s.xsolUSD}, nil
+}
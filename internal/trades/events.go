@@ -0,0 +1,248 @@
+package trades
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Wallet event kinds
+const (
+	EventKindTrade    = "trade"    // xSOL BUY, SELL or RECEIVE
+	EventKindTransfer = "transfer" // TRANSFER_IN or TRANSFER_OUT of xSOL, hyUSD or sHYUSD
+)
+
+// eventTokenMints are the mints whose token accounts are scanned for wallet events
+var eventTokenMints = []solana.Address{tokens.XSOLMint, tokens.HyUSDMint, tokens.SHyUSDMint}
+
+// WalletEvent is a single entry in a wallet's activity feed
+// Exactly one of Trade or Transfer is set, depending on Kind
+type WalletEvent struct {
+	Kind      string    `json:"kind"`
+	Type      string    `json:"type"` // BUY, SELL, RECEIVE, TRANSFER_IN or TRANSFER_OUT; empty for a failed trade of unknown side
+	Signature string    `json:"signature"`
+	Slot      uint64    `json:"slot"`
+	Timestamp time.Time `json:"timestamp"`
+
+	Trade    *hylo.XSOLTrade     `json:"trade,omitempty"`
+	Transfer *hylo.TokenTransfer `json:"transfer,omitempty"`
+}
+
+// EventsResponse represents the response structure for wallet events
+type EventsResponse struct {
+	Events        []*WalletEvent `json:"events"`
+	Pagination    PaginationInfo `json:"pagination"`
+	WalletAddress string         `json:"walletAddress"`
+	WalletURL     string         `json:"walletExplorerUrl,omitempty"` // Wallet page on the requested block explorer
+	RequestedAt   time.Time      `json:"requestedAt"`
+	Count         int            `json:"count"`
+}
+
+// GetEvents returns xSOL trades and Hylo token transfers for a wallet, newest first
+// Signatures are collected from the wallet's xSOL, hyUSD and sHYUSD token accounts; reverted transactions
+// are skipped unless opts.IncludeFailed is set, in which case reverted Hylo interactions are reported as failed trades
+func (s *TradeService) GetEvents(ctx context.Context, walletAddr solana.Address, opts *TradeRequest) (*EventsResponse, error) {
+	startTime := time.Now()
+
+	if err := walletAddr.Validate(); err != nil {
+		s.logger.LogValidationError(ctx, "get_wallet_events", "wallet", walletAddr, err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWalletAddress, err)
+	}
+
+	req := *opts
+	req.WalletAddress = walletAddr.String()
+	if err := ValidateTradeRequest(&req, s.options); err != nil {
+		s.logger.LogValidationError(ctx, "get_wallet_events", "request", req, err)
+		return nil, err
+	}
+
+	xsolATA, err := tokens.DeriveAssociatedTokenAddress(walletAddr, tokens.XSOLMint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrXSOLATADerivation, err)
+	}
+
+	signatures, truncated, err := s.collectEventSignatures(ctx, walletAddr, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip failed transactions unless explicitly requested
+	pending := make([]solana.SignatureInfo, 0, len(signatures))
+	for _, sigInfo := range signatures {
+		if sigInfo.Err == nil || req.IncludeFailed {
+			pending = append(pending, sigInfo)
+		}
+	}
+
+	// Fetch transactions a batch at a time, the same way trade history does, until the page is full
+	events := make([]*WalletEvent, 0)
+	sanity := s.newSupplyCheck()
+	batchSize := s.transactionBatchSize()
+	for start := 0; start < len(pending) && len(events) < req.Limit; start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		batch := pending[start:min(start+batchSize, len(pending))]
+		txs, errs := s.fetchTransactions(ctx, batch)
+
+		for i, sigInfo := range batch {
+			if len(events) >= req.Limit {
+				break
+			}
+			tx, err := txs[i], errs[i]
+			if err != nil {
+				s.logger.WarnContext(ctx, "Failed to fetch transaction details, continuing with others",
+					slog.String("signature", sigInfo.Signature),
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			if req.IncludeFailed && tx.Meta != nil && tx.Meta.Err != nil {
+				if failed := hylo.ParseFailedTransaction(tx); failed != nil {
+					events = append(events, tradeEvent(failed))
+				}
+				continue
+			}
+
+			if event := s.parseEvent(ctx, tx, walletAddr, xsolATA); event != nil {
+				sanity.apply(ctx, event.Trade)
+				events = append(events, event)
+			}
+		}
+	}
+
+	// A full page of events continues after the last one; a shorter page cut at the merge frontier
+	// continues after the oldest signature it covered
+	hasMore := len(events) == req.Limit && len(signatures) > 0
+	var nextCursor string
+	if hasMore {
+		nextCursor = events[len(events)-1].Signature
+	} else if truncated && len(signatures) > 0 {
+		hasMore, nextCursor = true, signatures[len(signatures)-1].Signature
+	}
+
+	s.logger.InfoContext(ctx, "Wallet events retrieval completed",
+		slog.String("wallet", walletAddr.String()),
+		slog.Int("events_found", len(events)),
+		slog.Bool("has_more", hasMore),
+		slog.Duration("elapsed", time.Since(startTime)))
+
+	return &EventsResponse{
+		Events:        events,
+		WalletAddress: walletAddr.String(),
+		RequestedAt:   time.Now().UTC(),
+		Count:         len(events),
+		Pagination: PaginationInfo{
+			HasMore:    hasMore,
+			NextCursor: nextCursor,
+			Limit:      req.Limit,
+			Count:      len(events),
+		},
+	}, nil
+}
+
+// collectEventSignatures merges signatures from the wallet's Hylo token accounts, newest first
+// Each account is listed one page deep, so an account whose page filled may have more signatures older than
+// its page that the other accounts' pages reach past. Only signatures newer than the oldest slot of any full
+// page (the merge frontier) are returned, and truncated reports that older history was held back
+func (s *TradeService) collectEventSignatures(ctx context.Context, walletAddr solana.Address, req TradeRequest) ([]solana.SignatureInfo, bool, error) {
+	seen := make(map[string]bool)
+	var signatures []solana.SignatureInfo
+	var frontier solana.Slot
+	truncated := false
+	pageSize := req.Limit * 2 // Fetch extra to account for filtering
+	slotRange := solana.SignatureQuery{MinSlot: solana.Slot(req.MinSlot), MaxSlot: solana.Slot(req.MaxSlot)}
+
+	for _, mint := range eventTokenMints {
+		ata, err := tokens.DeriveAssociatedTokenAddress(walletAddr, mint)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to derive token account for %s: %w", mint, err)
+		}
+
+		// Pages are listed unfiltered so a full page's oldest slot sets the frontier even outside the slot range
+		page, err := s.httpClient.GetSignaturesForAddressWithOptions(ctx, ata, solana.SignatureQuery{
+			Before: req.Before,
+			Until:  req.Until,
+			Limit:  pageSize,
+		})
+		if err != nil {
+			s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetSignaturesForAddress", err, 0,
+				slog.String("ata_address", ata.String()))
+			return nil, false, fmt.Errorf("%w: %v", ErrSignatureFetch, err)
+		}
+
+		if len(page) >= pageSize {
+			oldest := page[0].Slot
+			for _, sigInfo := range page {
+				oldest = min(oldest, sigInfo.Slot)
+			}
+			if slotRange.MinSlot == 0 || oldest >= slotRange.MinSlot {
+				frontier = max(frontier, oldest)
+				truncated = true
+			}
+		}
+
+		for _, sigInfo := range page {
+			if !slotRange.InSlotRange(sigInfo.Slot) {
+				continue
+			}
+			if !seen[sigInfo.Signature] {
+				seen[sigInfo.Signature] = true
+				signatures = append(signatures, sigInfo)
+			}
+		}
+	}
+
+	sort.SliceStable(signatures, func(i, j int) bool {
+		return signatures[i].Slot > signatures[j].Slot
+	})
+
+	// Signatures in the frontier slot itself may be split across pages, so they wait for the next page,
+	// unless nothing newer is left and holding them back would stall the cursor
+	if truncated {
+		cut := sort.Search(len(signatures), func(i int) bool { return signatures[i].Slot <= frontier })
+		if cut == 0 {
+			cut = sort.Search(len(signatures), func(i int) bool { return signatures[i].Slot < frontier })
+		}
+		signatures = signatures[:cut]
+	}
+	return signatures, truncated, nil
+}
+
+// parseEvent classifies a transaction as a transfer or an xSOL trade; nil when it is neither
+func (s *TradeService) parseEvent(ctx context.Context, tx *solana.TransactionDetails, walletAddr, xsolATA solana.Address) *WalletEvent {
+	if transfer := hylo.ParseTokenTransfer(tx, walletAddr); transfer != nil {
+		return &WalletEvent{
+			Kind:      EventKindTransfer,
+			Type:      transfer.Type,
+			Signature: transfer.Signature,
+			Slot:      transfer.Slot,
+			Timestamp: transfer.Timestamp,
+			Transfer:  transfer,
+		}
+	}
+
+	result, err := s.parser.ParseTransaction(ctx, tx, xsolATA, s.logger)
+	if err != nil || result == nil || result.Trade == nil {
+		return nil
+	}
+	return tradeEvent(result.Trade)
+}
+
+// tradeEvent wraps an xSOL trade, successful or failed, as a wallet event
+func tradeEvent(trade *hylo.XSOLTrade) *WalletEvent {
+	return &WalletEvent{
+		Kind:      EventKindTrade,
+		Type:      trade.Side,
+		Signature: trade.Signature,
+		Slot:      trade.Slot,
+		Timestamp: trade.Timestamp,
+		Trade:     trade,
+	}
+}
@@ -0,0 +1,196 @@
+package trades
+
+import (
+	"context"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func createMockTransferTransaction(signature string, slot uint64, blockTime int64, from, to string) *solana.TransactionDetails {
+	balance := func(index uint32, owner, amount string) solana.TokenBalance {
+		return solana.TokenBalance{
+			AccountIndex:  index,
+			Mint:          tokens.HyUSDMint.String(),
+			Owner:         &owner,
+			UITokenAmount: &solana.UITokenAmount{Amount: amount, Decimals: tokens.HyUSDDecimals},
+		}
+	}
+
+	return &solana.TransactionDetails{
+		BlockTime: &blockTime,
+		Slot:      solana.Slot(slot),
+		Meta: &solana.TxMeta{
+			Fee:               5000,
+			PreBalances:       []uint64{1_000_000_000, 0, 0},
+			PostBalances:      []uint64{999_995_000, 0, 0},
+			PreTokenBalances:  []solana.TokenBalance{balance(1, from, "10000000"), balance(2, to, "0")},
+			PostTokenBalances: []solana.TokenBalance{balance(1, from, "0"), balance(2, to, "10000000")},
+		},
+		Transaction: solana.Transaction{
+			Message:    solana.TxMessage{AccountKeys: []string{from, "fromATA", "toATA"}},
+			Signatures: []string{signature},
+		},
+	}
+}
+
+func TestGetEvents(t *testing.T) {
+	testWallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	otherWallet := "7VHUFJHWu2CuExkJcJrzhQPJ2oygupTWkL2A2For4BmE"
+	xsolATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.XSOLMint)
+	hyusdATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.HyUSDMint)
+
+	transactions := map[string]*solana.TransactionDetails{
+		"tradeSig":    createMockTradeTransaction("tradeSig", 200, 1757360079, xsolATA, "1000000", "2000000", hylo.TradeSideBuy),
+		"transferSig": createMockTransferTransaction("transferSig", 300, 1757360080, otherWallet, testWallet.String()),
+	}
+
+	queried := make(map[solana.Address]int)
+	mockClient := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			queried[address]++
+			switch address {
+			case xsolATA:
+				return []solana.SignatureInfo{{Signature: "tradeSig", Slot: 200}}, nil
+			case hyusdATA:
+				return []solana.SignatureInfo{{Signature: "transferSig", Slot: 300}, {Signature: "tradeSig", Slot: 200}}, nil
+			}
+			return nil, nil
+		},
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			return transactions[string(signature)], nil
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	resp, err := service.GetEvents(context.Background(), testWallet, &TradeRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(queried) != len(eventTokenMints) {
+		t.Errorf("Expected signatures for %d token accounts, got %d", len(eventTokenMints), len(queried))
+	}
+	if resp.Count != 2 {
+		t.Fatalf("Expected 2 deduplicated events, got %d", resp.Count)
+	}
+
+	transfer := resp.Events[0]
+	if transfer.Kind != EventKindTransfer || transfer.Type != hylo.TransferIn || transfer.Transfer.From != otherWallet {
+		t.Errorf("Expected newest event to be a transfer in from %s, got %+v", otherWallet, transfer)
+	}
+	if transfer.Transfer.Amount != "10" || transfer.Transfer.Token != tokens.HyUSDSymbol {
+		t.Errorf("Unexpected transfer amount %+v", transfer.Transfer)
+	}
+
+	trade := resp.Events[1]
+	if trade.Kind != EventKindTrade || trade.Type != hylo.TradeSideBuy || trade.Trade == nil {
+		t.Errorf("Expected BUY trade event, got %+v", trade)
+	}
+}
+
+func TestGetEventsStopsAtMergeFrontier(t *testing.T) {
+	testWallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	otherWallet := "7VHUFJHWu2CuExkJcJrzhQPJ2oygupTWkL2A2For4BmE"
+	xsolATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.XSOLMint)
+	hyusdATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.HyUSDMint)
+
+	transactions := map[string]*solana.TransactionDetails{
+		"transferSig": createMockTransferTransaction("transferSig", 450, 1757360080, otherWallet, testWallet.String()),
+		"tradeSig":    createMockTradeTransaction("tradeSig", 100, 1757360079, xsolATA, "1000000", "2000000", hylo.TradeSideBuy),
+	}
+
+	// The xSOL account's page fills at slot 300, so it may hold more signatures older than that
+	failed := map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}
+	mockClient := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			switch address {
+			case xsolATA:
+				return []solana.SignatureInfo{
+					{Signature: "a", Slot: 500, Err: failed},
+					{Signature: "b", Slot: 400, Err: failed},
+					{Signature: "c", Slot: 350, Err: failed},
+					{Signature: "d", Slot: 300, Err: failed},
+				}, nil
+			case hyusdATA:
+				return []solana.SignatureInfo{{Signature: "transferSig", Slot: 450}, {Signature: "tradeSig", Slot: 100}}, nil
+			}
+			return nil, nil
+		},
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			return transactions[string(signature)], nil
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	resp, err := service.GetEvents(context.Background(), testWallet, &TradeRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The trade at slot 100 is behind the frontier and waits for the next page
+	if resp.Count != 1 || resp.Events[0].Signature != "transferSig" {
+		t.Fatalf("Expected only the transfer above the frontier, got %+v", resp.Events)
+	}
+	if !resp.Pagination.HasMore || resp.Pagination.NextCursor != "c" {
+		t.Errorf("Expected to continue after the oldest signature above the frontier, got %+v", resp.Pagination)
+	}
+}
+
+func TestGetEventsBatchesTransactions(t *testing.T) {
+	testWallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	xsolATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.XSOLMint)
+
+	client := &batchingHTTPClient{batchSize: 2, transactions: map[string]*solana.TransactionDetails{}}
+	var signatures []solana.SignatureInfo
+	for i, sig := range []string{"sig1", "sig2", "sig3", "sig4", "sig5"} {
+		slot := uint64(500 - i)
+		signatures = append(signatures, solana.SignatureInfo{Signature: sig, Slot: solana.Slot(slot)})
+		client.transactions[sig] = createMockTradeTransaction(sig, slot, 1757360080, xsolATA, "1000000", "2000000", hylo.TradeSideBuy)
+	}
+	client.getSignaturesQueryFunc = func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+		if address == xsolATA {
+			return signatures, nil
+		}
+		return nil, nil
+	}
+
+	// sig2 is missing from its batch and only found by the single-call path
+	pruned := client.transactions["sig2"]
+	delete(client.transactions, "sig2")
+	var singleFetches []string
+	client.getTransactionFunc = func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+		singleFetches = append(singleFetches, string(signature))
+		return pruned, nil
+	}
+
+	service, err := NewTradeService(client, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	resp, err := service.GetEvents(context.Background(), testWallet, &TradeRequest{Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Count != 3 || resp.Events[1].Signature != "sig2" || resp.Events[2].Signature != "sig3" {
+		t.Fatalf("Expected sig1, sig2 and sig3 in slot order, got %+v", resp.Events)
+	}
+	if len(client.batches) != 2 {
+		t.Errorf("Expected two batches of two before the page filled, got %v", client.batches)
+	}
+	if len(singleFetches) != 1 || singleFetches[0] != "sig2" {
+		t.Errorf("Expected only sig2 to be refetched on its own, got %v", singleFetches)
+	}
+}
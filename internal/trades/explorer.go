@@ -0,0 +1,48 @@
+package trades
+
+import (
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/hylo"
+)
+
+// WithExplorer returns a copy of the response with trade and wallet links pointing at provider
+// Trades are copied, so cached and stored trades keep the links they were parsed with
+func (r *TradeResponse) WithExplorer(provider explorer.Provider) *TradeResponse {
+	linked := *r
+	linked.WalletURL = provider.AccountURL(r.WalletAddress)
+	linked.Trades = make([]*hylo.XSOLTrade, len(r.Trades))
+	for i, trade := range r.Trades {
+		linked.Trades[i] = trade.WithExplorer(provider)
+	}
+	return &linked
+}
+
+// WithExplorer returns a copy of the response with event and wallet links pointing at provider
+func (r *EventsResponse) WithExplorer(provider explorer.Provider) *EventsResponse {
+	linked := *r
+	linked.WalletURL = provider.AccountURL(r.WalletAddress)
+	linked.Events = make([]*WalletEvent, len(r.Events))
+	for i, event := range r.Events {
+		copied := *event
+		if event.Trade != nil {
+			copied.Trade = event.Trade.WithExplorer(provider)
+		}
+		if event.Transfer != nil {
+			copied.Transfer = event.Transfer.WithExplorer(provider)
+		}
+		linked.Events[i] = &copied
+	}
+	return &linked
+}
+
+// WithExplorer returns a copy of the summary with wallet and first activity links pointing at provider
+func (s *WalletSummary) WithExplorer(provider explorer.Provider) *WalletSummary {
+	linked := *s
+	linked.ExplorerURL = provider.AccountURL(s.WalletAddress)
+	if s.FirstHyloActivity != nil {
+		first := *s.FirstHyloActivity
+		first.ExplorerURL = provider.TransactionURL(first.Signature)
+		linked.FirstHyloActivity = &first
+	}
+	return &linked
+}
@@ -0,0 +1,71 @@
+package trades
+
+import (
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// MaxProtocolStateDistance is how many slots a state snapshot may be from a trade to annotate it (about an hour)
+const MaxProtocolStateDistance = 9000
+
+// ProtocolStates looks up recorded protocol state by slot; implemented by store.Store
+type ProtocolStates interface {
+	ProtocolSnapshotNear(slot uint64) (*store.ProtocolSnapshot, bool)
+}
+
+// ProtocolStateAt returns the protocol state recorded nearest slot, or nil when none is within MaxProtocolStateDistance
+func ProtocolStateAt(states ProtocolStates, slot uint64) *hylo.TradeProtocolState {
+	if slot == 0 {
+		return nil
+	}
+	snapshot, ok := states.ProtocolSnapshotNear(slot)
+	if !ok {
+		return nil
+	}
+
+	distance := snapshot.Slot - slot
+	if slot > snapshot.Slot {
+		distance = slot - snapshot.Slot
+	}
+	if distance > MaxProtocolStateDistance {
+		return nil
+	}
+	return &hylo.TradeProtocolState{
+		Slot:              snapshot.Slot,
+		SlotDistance:      distance,
+		CollateralRatio:   snapshot.CollateralRatio,
+		EffectiveLeverage: snapshot.EffectiveLeverage,
+		CapturedAt:        snapshot.Timestamp,
+	}
+}
+
+// WithProtocolState returns a copy of the response with each trade annotated with the protocol state near its slot
+// Trades are copied, so cached trades are not annotated in place
+func (r *TradeResponse) WithProtocolState(states ProtocolStates) *TradeResponse {
+	annotated := *r
+	annotated.Trades = make([]*hylo.XSOLTrade, len(r.Trades))
+	for i, trade := range r.Trades {
+		annotated.Trades[i] = withProtocolState(trade, states)
+	}
+	return &annotated
+}
+
+// WithProtocolState returns a copy of the response with each trade event annotated with the protocol state near its slot
+func (r *EventsResponse) WithProtocolState(states ProtocolStates) *EventsResponse {
+	annotated := *r
+	annotated.Events = make([]*WalletEvent, len(r.Events))
+	for i, event := range r.Events {
+		copied := *event
+		if event.Trade != nil {
+			copied.Trade = withProtocolState(event.Trade, states)
+		}
+		annotated.Events[i] = &copied
+	}
+	return &annotated
+}
+
+func withProtocolState(trade *hylo.XSOLTrade, states ProtocolStates) *hylo.XSOLTrade {
+	copied := *trade
+	copied.ProtocolState = ProtocolStateAt(states, trade.Slot)
+	return &copied
+}
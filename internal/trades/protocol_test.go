@@ -0,0 +1,32 @@
+package trades
+
+import (
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func TestWithProtocolState(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	capturedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	st.AddProtocolSnapshot(store.ProtocolSnapshot{Slot: 100_000, Timestamp: capturedAt, CollateralRatio: 1.7, EffectiveLeverage: 2.4})
+
+	near := hylo.NewXSOLTrade("near", 100_120, 0)
+	far := hylo.NewXSOLTrade("far", 100_000+MaxProtocolStateDistance+1, 0)
+	response := &TradeResponse{Trades: []*hylo.XSOLTrade{near, far}}
+
+	annotated := response.WithProtocolState(st)
+
+	state := annotated.Trades[0].ProtocolState
+	if state == nil || state.Slot != 100_000 || state.SlotDistance != 120 || state.CollateralRatio != 1.7 || !state.CapturedAt.Equal(capturedAt) {
+		t.Errorf("Expected the nearby snapshot on the first trade, got %+v", state)
+	}
+	if annotated.Trades[1].ProtocolState != nil {
+		t.Errorf("Expected no annotation beyond %d slots, got %+v", MaxProtocolStateDistance, annotated.Trades[1].ProtocolState)
+	}
+	if near.ProtocolState != nil {
+		t.Error("Expected the original trade to be left untouched")
+	}
+}
@@ -0,0 +1,174 @@
+package trades
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// Where a transaction account key came from
+const (
+	AccountKeySourceTransaction = "transaction"  // Listed in the transaction message
+	AccountKeySourceLookupTable = "lookup_table" // Loaded from an address lookup table by a versioned transaction
+)
+
+// RawTransaction is a transaction's undecoded instructions, for integrators decoding programs the API does not
+type RawTransaction struct {
+	Slot            solana.Slot `json:"slot"`
+	RecentBlockhash string      `json:"recentBlockhash"`
+
+	// AccountKeys lists every account the transaction referenced, in account index order
+	AccountKeys []RawAccountKey `json:"accountKeys"`
+
+	// Instructions are the top-level instructions, each with the instructions it invoked nested below it
+	Instructions []*RawInstruction `json:"instructions"`
+}
+
+// RawAccountKey is one account of a transaction
+type RawAccountKey struct {
+	Pubkey   string `json:"pubkey"`
+	Signer   bool   `json:"signer"`
+	Writable bool   `json:"writable"`
+	Source   string `json:"source"`
+}
+
+// RawInstruction is one instruction with its accounts resolved and its data base64 encoded
+type RawInstruction struct {
+	ProgramID      string   `json:"programId"`
+	Accounts       []string `json:"accounts"`
+	AccountIndexes []int    `json:"accountIndexes"`
+	Data           string   `json:"data"`        // Base64 encoded
+	StackHeight    int      `json:"stackHeight"` // 1 for top-level instructions
+
+	// InnerInstructions are the instructions this one invoked through CPI
+	InnerInstructions []*RawInstruction `json:"innerInstructions"`
+}
+
+// newRawTransaction builds the raw view of a fetched transaction
+func newRawTransaction(tx *solana.TransactionDetails) (*RawTransaction, error) {
+	message := tx.Transaction.Message
+	raw := &RawTransaction{
+		Slot:            tx.Slot,
+		RecentBlockhash: message.RecentBlockhash,
+		AccountKeys:     rawAccountKeys(tx),
+		Instructions:    make([]*RawInstruction, 0, len(message.Instructions)),
+	}
+
+	for _, instruction := range message.Instructions {
+		decoded, err := raw.instruction(instruction, 1)
+		if err != nil {
+			return nil, err
+		}
+		raw.Instructions = append(raw.Instructions, decoded)
+	}
+
+	if tx.Meta == nil {
+		return raw, nil
+	}
+	for _, set := range tx.Meta.InnerInstructions {
+		if int(set.Index) >= len(raw.Instructions) {
+			return nil, fmt.Errorf("inner instructions reference missing instruction %d", set.Index)
+		}
+		if err := raw.nest(raw.Instructions[set.Index], set.Instructions); err != nil {
+			return nil, err
+		}
+	}
+	return raw, nil
+}
+
+// nest builds the invocation tree below a top-level instruction from its inner instructions in execution
+// order: each one was invoked by the closest preceding instruction one stack level above it. Nodes that do
+// not report a stack height are treated as invoked directly by the top-level instruction
+func (r *RawTransaction) nest(root *RawInstruction, inner []solana.TxInstruction) error {
+	stack := []*RawInstruction{root}
+	for _, instruction := range inner {
+		height := root.StackHeight + 1
+		if instruction.StackHeight != nil && *instruction.StackHeight > root.StackHeight {
+			height = *instruction.StackHeight
+		}
+		decoded, err := r.instruction(instruction, height)
+		if err != nil {
+			return err
+		}
+
+		for len(stack) > 1 && stack[len(stack)-1].StackHeight >= height {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		parent.InnerInstructions = append(parent.InnerInstructions, decoded)
+		stack = append(stack, decoded)
+	}
+	return nil
+}
+
+// instruction resolves an instruction's accounts and re-encodes its data
+func (r *RawTransaction) instruction(instruction solana.TxInstruction, height int) (*RawInstruction, error) {
+	var data []byte
+	if instruction.Data != "" {
+		decoded, err := base58.Decode(instruction.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode instruction data: %w", err)
+		}
+		data = decoded
+	}
+
+	programID, err := r.accountKey(instruction.ProgramIdIndex)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]string, len(instruction.Accounts))
+	indexes := make([]int, len(instruction.Accounts))
+	for i, index := range instruction.Accounts {
+		if accounts[i], err = r.accountKey(index); err != nil {
+			return nil, err
+		}
+		indexes[i] = int(index)
+	}
+
+	return &RawInstruction{
+		ProgramID:         programID,
+		Accounts:          accounts,
+		AccountIndexes:    indexes,
+		Data:              base64.StdEncoding.EncodeToString(data),
+		StackHeight:       height,
+		InnerInstructions: []*RawInstruction{},
+	}, nil
+}
+
+// accountKey returns the account at index
+func (r *RawTransaction) accountKey(index uint8) (string, error) {
+	if int(index) >= len(r.AccountKeys) {
+		return "", fmt.Errorf("instruction references missing account %d", index)
+	}
+	return r.AccountKeys[index].Pubkey, nil
+}
+
+// rawAccountKeys lists a transaction's accounts in account index order: the message keys, whose signer and
+// writable flags follow from the message header, then the writable and read-only lookup table accounts
+func rawAccountKeys(tx *solana.TransactionDetails) []RawAccountKey {
+	message := tx.Transaction.Message
+	header := message.Header
+	keys := make([]RawAccountKey, 0, len(message.AccountKeys))
+	for i, pubkey := range message.AccountKeys {
+		signer := i < header.NumRequiredSignatures
+		writable := i < len(message.AccountKeys)-header.NumReadonlyUnsignedAccounts
+		if signer {
+			writable = i < header.NumRequiredSignatures-header.NumReadonlySignedAccounts
+		}
+		keys = append(keys, RawAccountKey{Pubkey: pubkey, Signer: signer, Writable: writable, Source: AccountKeySourceTransaction})
+	}
+
+	if tx.Meta == nil || tx.Meta.LoadedAddresses == nil {
+		return keys
+	}
+	for _, pubkey := range tx.Meta.LoadedAddresses.Writable {
+		keys = append(keys, RawAccountKey{Pubkey: pubkey, Writable: true, Source: AccountKeySourceLookupTable})
+	}
+	for _, pubkey := range tx.Meta.LoadedAddresses.Readonly {
+		keys = append(keys, RawAccountKey{Pubkey: pubkey, Source: AccountKeySourceLookupTable})
+	}
+	return keys
+}
@@ -0,0 +1,86 @@
+package trades
+
+import (
+	"testing"
+
+	"github.com/mr-tron/base58"
+
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+func TestNewRawTransaction(t *testing.T) {
+	height := func(h int) *int { return &h }
+	data := base58.Encode([]byte{1, 2, 3})
+	tx := &solana.TransactionDetails{
+		Slot: 42,
+		Meta: &solana.TxMeta{
+			InnerInstructions: []solana.InnerInstructions{{
+				Index: 0,
+				Instructions: []solana.TxInstruction{
+					{ProgramIdIndex: 3, Accounts: []uint8{1, 4}, Data: data, StackHeight: height(2)},
+					{ProgramIdIndex: 2, Accounts: []uint8{4}, Data: data, StackHeight: height(3)},
+					{ProgramIdIndex: 3, Data: data, StackHeight: height(2)},
+				},
+			}},
+			LoadedAddresses: &solana.LoadedAddresses{Writable: []string{"pool"}, Readonly: []string{"oracle"}},
+		},
+		Transaction: solana.Transaction{Message: solana.TxMessage{
+			Header:      solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 2},
+			AccountKeys: []string{"payer", "vault", "token", "hylo"},
+			Instructions: []solana.TxInstruction{
+				{ProgramIdIndex: 3, Accounts: []uint8{0, 1, 5}, Data: data},
+				{ProgramIdIndex: 2, Accounts: []uint8{1}, Data: ""},
+			},
+		}},
+	}
+
+	raw, err := newRawTransaction(tx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Message keys follow the header, then the lookup table accounts
+	want := []RawAccountKey{
+		{Pubkey: "payer", Signer: true, Writable: true, Source: AccountKeySourceTransaction},
+		{Pubkey: "vault", Writable: true, Source: AccountKeySourceTransaction},
+		{Pubkey: "token", Source: AccountKeySourceTransaction},
+		{Pubkey: "hylo", Source: AccountKeySourceTransaction},
+		{Pubkey: "pool", Writable: true, Source: AccountKeySourceLookupTable},
+		{Pubkey: "oracle", Source: AccountKeySourceLookupTable},
+	}
+	if len(raw.AccountKeys) != len(want) {
+		t.Fatalf("expected %d account keys, got %+v", len(want), raw.AccountKeys)
+	}
+	for i := range want {
+		if raw.AccountKeys[i] != want[i] {
+			t.Errorf("account %d: expected %+v, got %+v", i, want[i], raw.AccountKeys[i])
+		}
+	}
+
+	swap := raw.Instructions[0]
+	if swap.ProgramID != "hylo" || swap.Data != "AQID" || swap.StackHeight != 1 || swap.Accounts[2] != "oracle" {
+		t.Errorf("unexpected top-level instruction: %+v", swap)
+	}
+
+	// The second inner instruction was invoked by the first, the third by the top-level instruction
+	if len(swap.InnerInstructions) != 2 {
+		t.Fatalf("expected 2 directly invoked instructions, got %d", len(swap.InnerInstructions))
+	}
+	cpi := swap.InnerInstructions[0]
+	if len(cpi.InnerInstructions) != 1 || cpi.InnerInstructions[0].ProgramID != "token" || cpi.InnerInstructions[0].StackHeight != 3 {
+		t.Errorf("expected a nested token instruction, got %+v", cpi.InnerInstructions)
+	}
+	if len(raw.Instructions[1].InnerInstructions) != 0 || raw.Instructions[1].Data != "" {
+		t.Errorf("expected an empty instruction without inner instructions, got %+v", raw.Instructions[1])
+	}
+}
+
+func TestNewRawTransaction_MissingAccount(t *testing.T) {
+	tx := &solana.TransactionDetails{Transaction: solana.Transaction{Message: solana.TxMessage{
+		AccountKeys:  []string{"payer"},
+		Instructions: []solana.TxInstruction{{ProgramIdIndex: 3}},
+	}}}
+	if _, err := newRawTransaction(tx); err == nil {
+		t.Error("expected an instruction referencing a missing account to be rejected")
+	}
+}
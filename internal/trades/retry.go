@@ -0,0 +1,49 @@
+package trades
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// FetchFailure is a signature whose transaction could not be fetched while scanning a wallet's history
+type FetchFailure struct {
+	Signature string
+	Slot      uint64
+	Error     string
+}
+
+// FetchWalletTrade fetches one transaction and parses it for the wallet's xSOL account
+// Returns nil without an error when the transaction reverted, cannot be parsed or is not an xSOL trade of
+// the wallet; an error means the transaction could not be fetched and the caller may try again later
+func (s *TradeService) FetchWalletTrade(ctx context.Context, wallet solana.Address, signature string) (*hylo.XSOLTrade, error) {
+	xsolATA, err := tokens.DeriveAssociatedTokenAddress(wallet, tokens.XSOLMint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrXSOLATADerivation, err)
+	}
+
+	tx, err := s.httpClient.GetTransactionForSignature(ctx, solana.SignatureInfo{Signature: signature})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+	if tx.Meta != nil && tx.Meta.Err != nil {
+		return nil, nil
+	}
+
+	result, err := s.parser.ParseTransaction(ctx, tx, xsolATA, s.logger)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to parse refetched transaction",
+			slog.String("signature", signature),
+			slog.String("error", err.Error()))
+		return nil, nil
+	}
+	if result == nil || result.Trade == nil {
+		return nil, nil
+	}
+	s.newSupplyCheck().apply(ctx, result.Trade)
+	return result.Trade, nil
+}
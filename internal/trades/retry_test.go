@@ -0,0 +1,53 @@
+package trades
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestUnfetchedSignaturesAndRefetch(t *testing.T) {
+	wallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	xsolATA, err := tokens.DeriveAssociatedTokenAddress(wallet, tokens.XSOLMint)
+	if err != nil {
+		t.Fatalf("failed to derive xSOL ATA: %v", err)
+	}
+
+	available := map[string]*solana.TransactionDetails{
+		"sig1": createMockTradeTransaction("sig1", 300, 1757360079, xsolATA, "1000000", "2000000", hylo.TradeSideBuy),
+	}
+	mockClient := &mockHTTPClient{
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			if tx, ok := available[string(signature)]; ok {
+				return tx, nil
+			}
+			return nil, errors.New("rpc timeout")
+		},
+	}
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	signatures := []solana.SignatureInfo{{Signature: "sig1", Slot: 300}, {Signature: "sig2", Slot: 200}}
+	trades, unfetched, err := service.processSignatures(context.Background(), signatures, xsolATA, &TradeRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trades) != 1 || len(unfetched) != 1 || unfetched[0].Signature != "sig2" || unfetched[0].Slot != 200 {
+		t.Fatalf("expected sig2 to be reported unfetched, got %d trades and %+v", len(trades), unfetched)
+	}
+
+	if _, err := service.FetchWalletTrade(context.Background(), wallet, "sig2"); err == nil {
+		t.Error("expected a fetch error while the transaction is unavailable")
+	}
+	available["sig2"] = createMockTradeTransaction("sig2", 200, 1757360078, xsolATA, "3000000", "1500000", hylo.TradeSideSell)
+	trade, err := service.FetchWalletTrade(context.Background(), wallet, "sig2")
+	if err != nil || trade == nil || trade.Side != hylo.TradeSideSell {
+		t.Errorf("expected the refetched sell trade, got %+v, %v", trade, err)
+	}
+}
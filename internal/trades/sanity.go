@@ -0,0 +1,56 @@
+package trades
+
+import (
+	"context"
+	"log/slog"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// supplyCheck flags parsed trades whose xSOL amount is implausible against the mint supply
+// The supply is read once, on the first trade checked, through the client's account cache
+type supplyCheck struct {
+	service *TradeService
+	supply  uint64
+	loaded  bool
+}
+
+// newSupplyCheck returns a check for one request's trades
+func (s *TradeService) newSupplyCheck() *supplyCheck {
+	return &supplyCheck{service: s}
+}
+
+// apply adds hylo.TradeWarningExceedsSupply to trade when needed; an unreadable supply skips the check
+func (c *supplyCheck) apply(ctx context.Context, trade *hylo.XSOLTrade) {
+	fraction := c.service.hyloConfig.MaxTradeSupplyFraction
+	if trade == nil || fraction <= 0 {
+		return
+	}
+	if !c.loaded {
+		c.loaded = true
+		c.supply = c.service.xsolSupply(ctx)
+	}
+	if hylo.CheckTradeAmount(trade, c.supply, fraction) {
+		c.service.logger.WarnContext(ctx, "Trade amount exceeds the xSOL supply threshold",
+			slog.String("signature", trade.Signature),
+			slog.String("xsol_amount", trade.XSOLAmount),
+			slog.Uint64("xsol_supply_raw", c.supply),
+			slog.Float64("max_supply_fraction", fraction))
+	}
+}
+
+// xsolSupply reads the raw xSOL mint supply; 0 when the mint cannot be read
+func (s *TradeService) xsolSupply(ctx context.Context) uint64 {
+	account, err := s.httpClient.GetAccount(ctx, tokens.XSOLMint, solana.CommitmentConfirmed)
+	if err == nil {
+		var supply uint64
+		if supply, err = tokens.ParseMintSupply(account); err == nil {
+			return supply
+		}
+	}
+	s.logger.DebugContext(ctx, "Skipping trade amount sanity check: xSOL supply unavailable",
+		slog.String("error", err.Error()))
+	return 0
+}
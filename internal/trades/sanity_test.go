@@ -0,0 +1,70 @@
+package trades
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestProcessSignaturesFlagsAmountsAboveSupplyFraction(t *testing.T) {
+	testXSOLATA := solana.Address("Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ")
+
+	signatures := []solana.SignatureInfo{
+		{Signature: "sig1", Slot: 365528388, BlockTime: int64Ptr(1757360079)},
+		{Signature: "sig2", Slot: 365528387, BlockTime: int64Ptr(1757360078)},
+	}
+	mockTransactions := map[string]*solana.TransactionDetails{
+		"sig1": createMockTradeTransaction("sig1", 365528388, 1757360079, testXSOLATA, "1000000", "2000000", hylo.TradeSideBuy),
+		"sig2": createMockTradeTransaction("sig2", 365528387, 1757360078, testXSOLATA, "3000000", "1500000", hylo.TradeSideSell),
+	}
+
+	// 5 xSOL in supply: the 1 xSOL buy is within a quarter of it, the 1.5 xSOL sell is not
+	mint := make([]byte, tokens.MintAccountSize)
+	binary.LittleEndian.PutUint64(mint[tokens.MintSupplyOffset:], 5_000_000)
+	mintReads := 0
+	mockClient := &mockHTTPClient{
+		getAccountFunc: func(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error) {
+			if address != tokens.XSOLMint {
+				return nil, solana.ErrAccountNotFound
+			}
+			mintReads++
+			return &solana.AccountInfo{Owner: tokens.SPLTokenProgramID, Data: mint}, nil
+		},
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			if tx, ok := mockTransactions[string(signature)]; ok {
+				return tx, nil
+			}
+			return nil, errors.New("transaction not found")
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	trades, _, err := service.processSignatures(context.Background(), signatures, testXSOLATA, &TradeRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trades) != 2 {
+		t.Fatalf("expected 2 trades, got %d", len(trades))
+	}
+	if len(trades[0].Warnings) != 0 {
+		t.Errorf("expected no warning for %s, got %v", trades[0].Signature, trades[0].Warnings)
+	}
+	if len(trades[1].Warnings) != 1 || trades[1].Warnings[0] != hylo.TradeWarningExceedsSupply {
+		t.Errorf("expected a supply warning for %s, got %v", trades[1].Signature, trades[1].Warnings)
+	}
+	if trades[1].XSOLAmount != "1.5" {
+		t.Errorf("expected the flagged amount to be kept as parsed, got %s", trades[1].XSOLAmount)
+	}
+	if mintReads != 1 {
+		t.Errorf("expected the mint to be read once per request, got %d reads", mintReads)
+	}
+}
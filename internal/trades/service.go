@@ -2,6 +2,7 @@ package trades
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"hylo-wallet-tracker-api/internal/hylo"
 	"hylo-wallet-tracker-api/internal/logger"
@@ -10,16 +11,29 @@ import (
 	"log/slog"
 	"sort"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // HTTPClientInterface defines the contract for Solana HTTP client interaction
 // Matches the interface from tokens service for consistency
 type HTTPClientInterface interface {
+	Chain() solana.ChainID
 	GetAccount(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error)
-	GetSignaturesForAddress(ctx context.Context, address solana.Address, before string, limit int) ([]solana.SignatureInfo, error)
-	GetTransaction(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error)
+	GetSignaturesForAddressWithOptions(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error)
+	GetTransactionForSignature(ctx context.Context, info solana.SignatureInfo) (*solana.TransactionDetails, error)
+}
+
+// TransactionBatcher is implemented by clients that can fetch several transactions in one round trip
+// TradeService uses it when the client reports a batch size above 1
+type TransactionBatcher interface {
+	TransactionBatchSize() int
+	GetTransactions(ctx context.Context, signatures []solana.Signature) ([]*solana.TransactionDetails, []error)
 }
 
+// maxFilterScanPages bounds how many signature pages a filtered trade request scans before returning a partial page
+const maxFilterScanPages = 5
+
 // TradeService provides xSOL trade history functionality with real-time fetching
 // Integrates with Solana HTTP client, token configuration, and transaction parsing
 type TradeService struct {
@@ -32,11 +46,25 @@ type TradeService struct {
 	// hyloConfig provides Hylo program configuration
 	hyloConfig *hylo.Config
 
+	// parser classifies transactions against hyloConfig
+	parser *hylo.Parser
+
 	// logger for structured logging
 	logger *logger.Logger
 
 	// options provides service configuration options
 	options *TradeServiceOptions
+
+	// inflight coalesces concurrent identical trade history requests
+	inflight singleflight.Group
+
+	// firstSeen caches each wallet's earliest Hylo activity, and firstSeenScans the token account
+	// walks a truncated lookup resumes from
+	firstSeen      *boundedMap[solana.Address, *FirstActivity]
+	firstSeenScans *boundedMap[solana.Address, firstSeenScan]
+
+	// prices values trades for the USD dust threshold; nil when unset
+	prices PriceSource
 }
 
 // NewTradeService creates a new trade service with dependency injection
@@ -76,8 +104,12 @@ func NewTradeService(httpClient HTTPClientInterface, tokenConfig *tokens.Config,
 		httpClient:  httpClient,
 		tokenConfig: tokenConfig,
 		hyloConfig:  hyloConfig,
+		parser:      hylo.NewParser(hyloConfig),
 		logger:      serviceLogger,
 		options:     DefaultTradeServiceOptions(),
+
+		firstSeen:      newBoundedMap[solana.Address, *FirstActivity](firstSeenCacheSize),
+		firstSeenScans: newBoundedMap[solana.Address, firstSeenScan](firstSeenCacheSize),
 	}
 
 	serviceLogger.InfoContext(context.Background(), "Trade service initialized successfully")
@@ -85,14 +117,48 @@ func NewTradeService(httpClient HTTPClientInterface, tokenConfig *tokens.Config,
 }
 
 // GetWalletTrades fetches xSOL trade history for a wallet using real-time RPC calls
-// Returns paginated trade results with cursor-based navigation
 func (s *TradeService) GetWalletTrades(ctx context.Context, walletAddr solana.Address, limit int, before string) (*TradeResponse, error) {
+	return s.GetTrades(ctx, walletAddr, &TradeRequest{Limit: limit, Before: before})
+}
+
+// GetTrades fetches xSOL trade history for a wallet with the given request options
+// Concurrent requests with the same wallet and options share a single upstream fetch
+func (s *TradeService) GetTrades(ctx context.Context, walletAddr solana.Address, opts *TradeRequest) (*TradeResponse, error) {
+	key := fmt.Sprintf("%s:%d:%s:%s:%d:%d:%t:%g:%t:%t:%+v", walletAddr, opts.Limit, opts.Before, opts.Until, opts.MinSlot, opts.MaxSlot,
+		opts.IncludeFailed, opts.MinConfidence, opts.NeedsReview, opts.IncludeDust, opts.TradeFilters)
+	result := s.inflight.DoChan(key, func() (interface{}, error) {
+		// Detach from the first caller's cancellation so other waiters still get a result
+		return s.fetchWalletTrades(context.WithoutCancel(ctx), walletAddr, *opts)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*TradeResponse), nil
+	}
+}
+
+// fetchWalletTrades fetches xSOL trade history for a wallet using real-time RPC calls
+// Returns paginated trade results with cursor-based navigation
+func (s *TradeService) fetchWalletTrades(ctx context.Context, walletAddr solana.Address, opts TradeRequest) (*TradeResponse, error) {
 	startTime := time.Now()
 
 	s.logger.InfoContext(ctx, "Getting wallet trades",
 		slog.String("wallet", walletAddr.String()),
-		slog.Int("limit", limit),
-		slog.String("before", before))
+		slog.Int("limit", opts.Limit),
+		slog.String("before", opts.Before),
+		slog.String("until", opts.Until),
+		slog.Uint64("min_slot", opts.MinSlot),
+		slog.Uint64("max_slot", opts.MaxSlot),
+		slog.Bool("include_failed", opts.IncludeFailed),
+		slog.Float64("min_confidence", opts.MinConfidence),
+		slog.Bool("needs_review", opts.NeedsReview),
+		slog.Bool("include_dust", opts.IncludeDust),
+		slog.Any("filters", opts.TradeFilters))
 
 	// Validate wallet address
 	if err := walletAddr.Validate(); err != nil {
@@ -101,11 +167,8 @@ func (s *TradeService) GetWalletTrades(ctx context.Context, walletAddr solana.Ad
 	}
 
 	// Create and validate request
-	req := &TradeRequest{
-		WalletAddress: walletAddr.String(),
-		Limit:         limit,
-		Before:        before,
-	}
+	req := &opts
+	req.WalletAddress = walletAddr.String()
 
 	if err := ValidateTradeRequest(req, s.options); err != nil {
 		s.logger.LogValidationError(ctx, "get_wallet_trades", "request", req, err)
@@ -124,32 +187,84 @@ func (s *TradeService) GetWalletTrades(ctx context.Context, walletAddr solana.Ad
 	s.logger.DebugContext(ctx, "Derived xSOL ATA address",
 		slog.String("ata_address", xsolATA.String()))
 
-	// Step 2: Fetch transaction signatures for the xSOL ATA
-	signatures, err := s.httpClient.GetSignaturesForAddress(ctx, xsolATA, before, req.Limit*2) // Fetch extra to account for filtering
-	if err != nil {
-		s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetSignaturesForAddress", err, 0,
-			slog.String("ata_address", xsolATA.String()))
-		return nil, fmt.Errorf("%w: %v", ErrSignatureFetch, err)
+	// Dust is counted over every page scanned
+	if !req.IncludeDust {
+		req.dust = s.newDustCheck()
 	}
 
-	s.logger.InfoContext(ctx, "Fetched signatures for xSOL ATA",
-		slog.Int("signature_count", len(signatures)),
-		slog.String("ata_address", xsolATA.String()))
+	// Step 2: Fetch transaction signatures for the xSOL ATA and process them into trades
+	// Filtered and slot-ranged requests keep scanning older signature pages until the page fills. Pages are
+	// fetched unfiltered and cut to the slot range here, so the cursor and end of history follow the listing
+	ctx, dataContext := solana.WithDataContext(ctx)
+	pageSize := req.Limit * 2 // Fetch extra to account for filtering
+	slotRange := solana.SignatureQuery{MinSlot: solana.Slot(req.MinSlot), MaxSlot: solana.Slot(req.MaxSlot)}
+	ranged := req.MinSlot != 0 || req.MaxSlot != 0
+	scanPages := 1
+	if req.TradeFilters.Active() || ranged {
+		scanPages = maxFilterScanPages
+	}
 
-	// Step 3: Process signatures to extract xSOL trades
-	trades, err := s.processSignatures(ctx, signatures, xsolATA, req.Limit)
-	if err != nil {
-		s.logger.LogHandlerError(ctx, "get_wallet_trades", err,
-			slog.String("error_type", "signature_processing"))
-		return nil, err
+	trades := make([]*hylo.XSOLTrade, 0)
+	var unfetched []FetchFailure
+	cursor, exhausted := req.Before, false
+	for page := 0; page < scanPages && len(trades) < req.Limit && !exhausted; page++ {
+		listed, err := s.httpClient.GetSignaturesForAddressWithOptions(ctx, xsolATA, solana.SignatureQuery{
+			Before: cursor,
+			Until:  req.Until,
+			Limit:  pageSize,
+		})
+		if err != nil {
+			s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetSignaturesForAddress", err, 0,
+				slog.String("ata_address", xsolATA.String()))
+			return nil, fmt.Errorf("%w: %v", ErrSignatureFetch, err)
+		}
+
+		s.logger.InfoContext(ctx, "Fetched signatures for xSOL ATA",
+			slog.Int("signature_count", len(listed)),
+			slog.String("ata_address", xsolATA.String()))
+
+		// The listing is newest first: past its oldest signature lies older history, and once that is
+		// below the slot range nothing further can match
+		exhausted = len(listed) < pageSize
+		if len(listed) > 0 {
+			oldest := listed[len(listed)-1]
+			cursor = oldest.Signature
+			if slotRange.MinSlot != 0 && oldest.Slot < slotRange.MinSlot {
+				exhausted = true
+			}
+		}
+		signatures := listed
+		if ranged {
+			signatures = make([]solana.SignatureInfo, 0, len(listed))
+			for _, sig := range listed {
+				if slotRange.InSlotRange(sig.Slot) {
+					signatures = append(signatures, sig)
+				}
+			}
+		}
+
+		// Step 3: Process signatures to extract xSOL trades, counting only towards what the page still needs
+		pageReq := *req
+		pageReq.Limit = req.Limit - len(trades)
+		pageTrades, pageUnfetched, err := s.processSignatures(ctx, signatures, xsolATA, &pageReq)
+		if err != nil {
+			s.logger.LogHandlerError(ctx, "get_wallet_trades", err,
+				slog.String("error_type", "signature_processing"))
+			return nil, err
+		}
+		trades = append(trades, pageTrades...)
+		unfetched = append(unfetched, pageUnfetched...)
 	}
 
 	// Step 4: Determine pagination information
-	hasMore := len(trades) == req.Limit && len(signatures) > 0
+	hasMore := len(trades) == req.Limit && len(trades) > 0
 	var nextCursor string
-	if hasMore && len(trades) > 0 {
+	if hasMore {
 		// Use the last trade's signature as the next cursor
 		nextCursor = trades[len(trades)-1].Signature
+	} else if !exhausted && cursor != req.Before {
+		// The scan budget ran out before the page filled; resume after the oldest signature scanned
+		hasMore, nextCursor = true, cursor
 	}
 
 	// Log operation completion
@@ -159,68 +274,186 @@ func (s *TradeService) GetWalletTrades(ctx context.Context, walletAddr solana.Ad
 		slog.Bool("has_more", hasMore),
 		slog.Duration("elapsed", time.Since(startTime)))
 
-	return NewTradeResponse(walletAddr.String(), trades, hasMore, nextCursor, req.Limit), nil
+	response := NewTradeResponse(walletAddr.String(), trades, hasMore, nextCursor, req.Limit)
+	response.Chain = s.httpClient.Chain()
+	response.Unfetched = unfetched
+	response.Context = dataContext.DataContext()
+	response.Dust = req.dust.summary()
+	if req.TradeFilters.Active() {
+		filters := req.TradeFilters
+		response.Pagination.Filters = &filters
+	}
+	return response, nil
 }
 
 // processSignatures fetches transaction details and parses them for xSOL trades
-func (s *TradeService) processSignatures(ctx context.Context, signatures []solana.SignatureInfo, xsolATA solana.Address, maxTrades int) ([]*hylo.XSOLTrade, error) {
+// Failed transactions are skipped unless includeFailed is set, in which case reverted Hylo interactions are reported
+// Signatures scanned before the limit was reached whose transactions could not be fetched are returned alongside
+func (s *TradeService) processSignatures(ctx context.Context, signatures []solana.SignatureInfo, xsolATA solana.Address, req *TradeRequest) ([]*hylo.XSOLTrade, []FetchFailure, error) {
+	maxTrades := req.Limit
+
 	// Initialize as empty slice to ensure JSON serialization returns [] instead of null
 	trades := make([]*hylo.XSOLTrade, 0)
+	if maxTrades <= 0 {
+		return trades, nil, nil
+	}
+
+	var unfetched []FetchFailure
+	recordFailure := func(failure FetchFailure) { unfetched = append(unfetched, failure) }
+	err := s.scanSignatures(ctx, signatures, xsolATA, req, s.newSupplyCheck(), recordFailure, func(trade *hylo.XSOLTrade) error {
+		trades = append(trades, trade)
+		if len(trades) >= maxTrades {
+			s.logger.DebugContext(ctx, "Reached trade limit, stopping processing",
+				slog.Int("trades_found", len(trades)),
+				slog.Int("limit", maxTrades))
+			return errStopScan
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopScan) {
+		return nil, nil, err
+	}
+	return trades, unfetched, nil
+}
+
+// errStopScan is returned by a scanSignatures callback to end the scan without an error
+var errStopScan = errors.New("stop scan")
+
+// scanSignatures fetches and parses the transactions of signatures newest first, passing each trade
+// matching req to emit as soon as it is parsed. Transactions that cannot be fetched or parsed are
+// skipped, the unfetched ones reported to failed when it is set; the scan ends with the first error
+// from emit, or when ctx is done
+func (s *TradeService) scanSignatures(ctx context.Context, signatures []solana.SignatureInfo, xsolATA solana.Address, req *TradeRequest,
+	sanity *supplyCheck, failed func(FetchFailure), emit func(trade *hylo.XSOLTrade) error) error {
+	includeFailed := req.IncludeFailed
 
 	// Sort signatures by slot (newest first) to ensure consistent ordering
 	sort.Slice(signatures, func(i, j int) bool {
 		return signatures[i].Slot > signatures[j].Slot
 	})
 
-	// Process each signature until we have enough trades or run out of signatures
+	// Skip failed transactions unless explicitly requested
+	pending := make([]solana.SignatureInfo, 0, len(signatures))
 	for _, sigInfo := range signatures {
-		// Skip failed transactions
-		if sigInfo.Err != nil {
-			continue
+		if sigInfo.Err == nil || includeFailed {
+			pending = append(pending, sigInfo)
 		}
+	}
 
-		// Fetch transaction details
-		tx, err := s.httpClient.GetTransaction(ctx, solana.Signature(sigInfo.Signature))
-		if err != nil {
-			s.logger.WarnContext(ctx, "Failed to fetch transaction details, continuing with others",
-				slog.String("signature", sigInfo.Signature),
-				slog.String("error", err.Error()))
-			continue
+	// Fetch transactions a batch at a time and process them until emit stops the scan or we run out of signatures
+	batchSize := s.transactionBatchSize()
+	for start := 0; start < len(pending); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
+		batch := pending[start:min(start+batchSize, len(pending))]
+		txs, errs := s.fetchTransactions(ctx, batch)
+
+		for i, sigInfo := range batch {
+			tx, err := txs[i], errs[i]
+			if err != nil {
+				s.logger.WarnContext(ctx, "Failed to fetch transaction details, continuing with others",
+					slog.String("signature", sigInfo.Signature),
+					slog.String("error", err.Error()))
+				if failed != nil {
+					failed(FetchFailure{Signature: sigInfo.Signature, Slot: uint64(sigInfo.Slot), Error: err.Error()})
+				}
+				continue
+			}
 
-		// Parse the transaction for xSOL trades with logging context
-		parseResult, err := hylo.ParseTransactionWithContext(ctx, tx, xsolATA, s.logger)
-		if err != nil {
-			s.logger.WarnContext(ctx, "Failed to parse transaction, continuing with others",
-				slog.String("signature", sigInfo.Signature),
-				slog.String("error", err.Error()))
-			continue
-		}
+			if includeFailed && tx.Meta != nil && tx.Meta.Err != nil {
+				if failed := hylo.ParseFailedTransaction(tx); failed != nil && req.TradeFilters.matches(failed) {
+					if err := emit(failed); err != nil {
+						return err
+					}
+				}
+				continue
+			}
 
-		// If we found a valid trade, add it to our results
-		if parseResult != nil && parseResult.Trade != nil {
-			trades = append(trades, parseResult.Trade)
-
-			s.logger.DebugContext(ctx, "Successfully parsed and added trade",
-				slog.String("signature", sigInfo.Signature),
-				slog.String("side", parseResult.Trade.Side),
-				slog.String("xsol_amount", parseResult.Trade.XSOLAmount))
-
-			// Stop if we've reached the requested limit
-			if len(trades) >= maxTrades {
-				s.logger.DebugContext(ctx, "Reached trade limit, stopping processing",
-					slog.Int("trades_found", len(trades)),
-					slog.Int("limit", maxTrades))
-				break
+			// Parse the transaction for xSOL trades with logging context
+			parseResult, err := s.parser.ParseTransaction(ctx, tx, xsolATA, s.logger)
+			if err != nil {
+				s.logger.WarnContext(ctx, "Failed to parse transaction, continuing with others",
+					slog.String("signature", sigInfo.Signature),
+					slog.String("error", err.Error()))
+				continue
 			}
-		} else if parseResult != nil && parseResult.Error != "" {
-			s.logger.DebugContext(ctx, "Transaction parsing returned error",
-				slog.String("signature", sigInfo.Signature),
-				slog.String("parse_error", parseResult.Error))
+
+			// Trades outside the requested confidence range are skipped without counting towards the limit
+			if parseResult != nil && parseResult.Trade != nil && !req.matchesConfidence(parseResult.Trade) {
+				s.logger.DebugContext(ctx, "Trade filtered by confidence",
+					slog.String("signature", sigInfo.Signature),
+					slog.Float64("confidence", parseResult.Trade.Confidence))
+				continue
+			}
+
+			// Trades outside the requested side, counter asset or amount range are skipped the same way
+			if parseResult != nil && parseResult.Trade != nil && !req.TradeFilters.matches(parseResult.Trade) {
+				s.logger.DebugContext(ctx, "Trade filtered by query",
+					slog.String("signature", sigInfo.Signature),
+					slog.String("side", parseResult.Trade.Side),
+					slog.String("counter_asset", parseResult.Trade.CounterAsset))
+				continue
+			}
+
+			// Dust below the configured minimum trade size is skipped the same way, and counted
+			if parseResult != nil && req.dust.drop(ctx, parseResult.Trade) {
+				continue
+			}
+
+			// If we found a valid trade, pass it on
+			if parseResult != nil && parseResult.Trade != nil {
+				sanity.apply(ctx, parseResult.Trade)
+
+				s.logger.DebugContext(ctx, "Successfully parsed trade",
+					slog.String("signature", sigInfo.Signature),
+					slog.String("side", parseResult.Trade.Side),
+					slog.String("xsol_amount", parseResult.Trade.XSOLAmount))
+
+				if err := emit(parseResult.Trade); err != nil {
+					return err
+				}
+			} else if parseResult != nil && parseResult.Error != "" {
+				s.logger.DebugContext(ctx, "Transaction parsing returned error",
+					slog.String("signature", sigInfo.Signature),
+					slog.String("parse_error", parseResult.Error))
+			}
+		}
+	}
+
+	return nil
+}
+
+// transactionBatchSize returns how many transactions processSignatures requests per round trip
+func (s *TradeService) transactionBatchSize() int {
+	if batcher, ok := s.httpClient.(TransactionBatcher); ok {
+		return max(batcher.TransactionBatchSize(), 1)
+	}
+	return 1
+}
+
+// fetchTransactions returns the transactions for batch, indexed like batch
+// Batches go out as one JSON-RPC batch request when the client supports it; any transaction the batch
+// did not return is refetched on its own so per-call retries and the archive fallback still apply
+func (s *TradeService) fetchTransactions(ctx context.Context, batch []solana.SignatureInfo) ([]*solana.TransactionDetails, []error) {
+	txs := make([]*solana.TransactionDetails, len(batch))
+	errs := make([]error, len(batch))
+
+	if batcher, ok := s.httpClient.(TransactionBatcher); ok && len(batch) > 1 {
+		signatures := make([]solana.Signature, len(batch))
+		for i, sigInfo := range batch {
+			signatures[i] = solana.Signature(sigInfo.Signature)
+		}
+		txs, errs = batcher.GetTransactions(ctx, signatures)
+	}
+
+	for i, sigInfo := range batch {
+		if txs[i] == nil {
+			txs[i], errs[i] = s.httpClient.GetTransactionForSignature(ctx, sigInfo)
 		}
 	}
 
-	return trades, nil
+	return txs, errs
 }
 
 // GetServiceHealth returns health information for the trade service
@@ -3,7 +3,11 @@ package trades
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"hylo-wallet-tracker-api/internal/hylo"
 	"hylo-wallet-tracker-api/internal/solana"
@@ -14,9 +18,14 @@ import (
 type mockHTTPClient struct {
 	getAccountFunc              func(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error)
 	getSignaturesForAddressFunc func(ctx context.Context, address solana.Address, before string, limit int) ([]solana.SignatureInfo, error)
+	getSignaturesQueryFunc      func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error)
 	getTransactionFunc          func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error)
 }
 
+func (m *mockHTTPClient) Chain() solana.ChainID {
+	return solana.ChainSolana
+}
+
 func (m *mockHTTPClient) GetAccount(ctx context.Context, address solana.Address, commitment solana.Commitment) (*solana.AccountInfo, error) {
 	if m.getAccountFunc != nil {
 		return m.getAccountFunc(ctx, address, commitment)
@@ -24,16 +33,19 @@ func (m *mockHTTPClient) GetAccount(ctx context.Context, address solana.Address,
 	return &solana.AccountInfo{}, nil
 }
 
-func (m *mockHTTPClient) GetSignaturesForAddress(ctx context.Context, address solana.Address, before string, limit int) ([]solana.SignatureInfo, error) {
+func (m *mockHTTPClient) GetSignaturesForAddressWithOptions(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+	if m.getSignaturesQueryFunc != nil {
+		return m.getSignaturesQueryFunc(ctx, address, query)
+	}
 	if m.getSignaturesForAddressFunc != nil {
-		return m.getSignaturesForAddressFunc(ctx, address, before, limit)
+		return m.getSignaturesForAddressFunc(ctx, address, query.Before, query.Limit)
 	}
 	return []solana.SignatureInfo{}, nil
 }
 
-func (m *mockHTTPClient) GetTransaction(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+func (m *mockHTTPClient) GetTransactionForSignature(ctx context.Context, info solana.SignatureInfo) (*solana.TransactionDetails, error) {
 	if m.getTransactionFunc != nil {
-		return m.getTransactionFunc(ctx, signature)
+		return m.getTransactionFunc(ctx, solana.Signature(info.Signature))
 	}
 	return &solana.TransactionDetails{}, nil
 }
@@ -335,6 +347,231 @@ func TestGetWalletTrades(t *testing.T) {
 	}
 }
 
+func TestGetWalletTradesCoalescesConcurrentRequests(t *testing.T) {
+	var signatureCalls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+
+	mockClient := &mockHTTPClient{
+		getSignaturesForAddressFunc: func(ctx context.Context, address solana.Address, before string, limit int) ([]solana.SignatureInfo, error) {
+			signatureCalls.Add(1)
+			once.Do(func() { close(started) })
+			<-release
+			return []solana.SignatureInfo{}, nil
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("Failed to create trade service: %v", err)
+	}
+
+	const concurrentRequests = 5
+	wallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+
+	var wg sync.WaitGroup
+	errs := make([]error, concurrentRequests)
+	for i := 0; i < concurrentRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = service.GetWalletTrades(context.Background(), wallet, 10, "")
+		}(i)
+	}
+
+	<-started
+	time.Sleep(50 * time.Millisecond) // let the remaining callers join the in-flight fetch
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+	if calls := signatureCalls.Load(); calls != 1 {
+		t.Errorf("Expected 1 upstream GetSignaturesForAddress call, got %d", calls)
+	}
+
+	// Different pagination parameters must not be coalesced
+	if _, err := service.GetWalletTrades(context.Background(), wallet, 5, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls := signatureCalls.Load(); calls != 2 {
+		t.Errorf("Expected 2 upstream GetSignaturesForAddress calls, got %d", calls)
+	}
+}
+
+func TestGetTradesPassesSignatureWindow(t *testing.T) {
+	var captured solana.SignatureQuery
+	mockClient := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			captured = query
+			return []solana.SignatureInfo{}, nil
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("Failed to create trade service: %v", err)
+	}
+
+	wallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	_, err = service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 5, Until: "watermarkSig", MinSlot: 100, MaxSlot: 200})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The slot range is applied to the listing here, so pages past it can be followed
+	if captured.Until != "watermarkSig" || captured.MinSlot != 0 || captured.MaxSlot != 0 || captured.Limit != 10 {
+		t.Errorf("Expected the until cursor without the slot range to be forwarded, got %+v", captured)
+	}
+
+	_, err = service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 5, MinSlot: 300, MaxSlot: 200})
+	if !errors.Is(err, ErrInvalidSlotRange) {
+		t.Errorf("Expected ErrInvalidSlotRange, got %v", err)
+	}
+}
+
+func TestGetTradesSlotRangePagesPastNewerHistory(t *testing.T) {
+	testXSOLATA, err := tokens.DeriveAssociatedTokenAddress(solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"), tokens.XSOLMint)
+	if err != nil {
+		t.Fatalf("failed to derive ATA: %v", err)
+	}
+
+	// Twelve trades, newest first, one slot apart
+	transactions := make(map[string]*solana.TransactionDetails)
+	var history []solana.SignatureInfo
+	slotOf := func(i int) uint64 { return uint64(365528400 - i) }
+	for i := 1; i <= 12; i++ {
+		sig := fmt.Sprintf("sig%d", i)
+		history = append(history, solana.SignatureInfo{Signature: sig, Slot: solana.Slot(slotOf(i)), BlockTime: int64Ptr(1757360080)})
+		transactions[sig] = createMockTradeTransaction(sig, slotOf(i), 1757360080, testXSOLATA, "1000000", "2000000", "")
+	}
+
+	var queries []solana.SignatureQuery
+	mockClient := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			queries = append(queries, query)
+			start := 0
+			for i, info := range history {
+				if info.Signature == query.Before {
+					start = i + 1
+				}
+			}
+			return append([]solana.SignatureInfo(nil), history[start:min(start+query.Limit, len(history))]...), nil
+		},
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			return transactions[string(signature)], nil
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("Failed to create trade service: %v", err)
+	}
+	wallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+
+	// max_slot is older than the first two pages, which are paged past rather than ending the history
+	response, err := service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 2, MaxSlot: slotOf(9)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Trades) != 2 || response.Trades[0].Signature != "sig9" || response.Trades[1].Signature != "sig10" {
+		t.Fatalf("expected sig9 and sig10, got %+v", response.Trades)
+	}
+	if len(queries) != 3 || queries[1].Before != "sig4" || queries[2].Before != "sig8" {
+		t.Errorf("expected pages before sig4 and sig8, got %+v", queries)
+	}
+	if !response.Pagination.HasMore || response.Pagination.NextCursor != "sig10" {
+		t.Errorf("expected next cursor sig10, got %+v", response.Pagination)
+	}
+
+	// The scan ends once the listing drops below min_slot
+	queries = nil
+	response, err = service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 5, MinSlot: slotOf(6), MaxSlot: slotOf(3)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Trades) != 4 || response.Trades[0].Signature != "sig3" || response.Trades[3].Signature != "sig6" {
+		t.Fatalf("expected sig3 to sig6, got %+v", response.Trades)
+	}
+	if len(queries) != 1 || response.Pagination.HasMore || response.Pagination.NextCursor != "" {
+		t.Errorf("expected one page and no more results, got %d pages and %+v", len(queries), response.Pagination)
+	}
+}
+
+func TestGetTradesFilteredScansUntilPageFills(t *testing.T) {
+	testXSOLATA, err := tokens.DeriveAssociatedTokenAddress(solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"), tokens.XSOLMint)
+	if err != nil {
+		t.Fatalf("failed to derive ATA: %v", err)
+	}
+
+	// Twelve signatures, newest first; only sig6, sig8 and sig9 are sells
+	transactions := make(map[string]*solana.TransactionDetails)
+	var history []solana.SignatureInfo
+	for i := 1; i <= 12; i++ {
+		sig := fmt.Sprintf("sig%d", i)
+		slot := uint64(365528400 - i)
+		pre, post := "1000000", "2000000"
+		if i == 6 || i == 8 || i == 9 {
+			pre, post = "3000000", "1500000"
+		}
+		history = append(history, solana.SignatureInfo{Signature: sig, Slot: solana.Slot(slot), BlockTime: int64Ptr(1757360080)})
+		transactions[sig] = createMockTradeTransaction(sig, slot, 1757360080, testXSOLATA, pre, post, "")
+	}
+
+	var queries []solana.SignatureQuery
+	mockClient := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			queries = append(queries, query)
+			start := 0
+			for i, info := range history {
+				if info.Signature == query.Before {
+					start = i + 1
+				}
+			}
+			return append([]solana.SignatureInfo(nil), history[start:min(start+query.Limit, len(history))]...), nil
+		},
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			return transactions[string(signature)], nil
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("Failed to create trade service: %v", err)
+	}
+
+	wallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	response, err := service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 2, TradeFilters: TradeFilters{Side: "sell", MaxAmount: "10"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(response.Trades) != 2 || response.Trades[0].Signature != "sig6" || response.Trades[1].Signature != "sig8" {
+		t.Fatalf("expected sig6 and sig8, got %+v", response.Trades)
+	}
+	if len(queries) != 2 || queries[1].Before != "sig4" {
+		t.Errorf("expected a second signature page before sig4, got %+v", queries)
+	}
+	if !response.Pagination.HasMore || response.Pagination.NextCursor != "sig8" {
+		t.Errorf("expected next cursor sig8, got %+v", response.Pagination)
+	}
+	if filters := response.Pagination.Filters; filters == nil || filters.Side != hylo.TradeSideSell || filters.MaxAmount != "10" {
+		t.Errorf("expected normalized filters to be echoed, got %+v", filters)
+	}
+
+	// No trade is below 1 xSOL, so the scan runs to the end of history without filling the page
+	response, err = service.GetTrades(context.Background(), wallet, &TradeRequest{Limit: 2, TradeFilters: TradeFilters{MaxAmount: "0.5"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Trades) != 0 || response.Pagination.HasMore {
+		t.Errorf("expected an empty final page, got %d trades and %+v", len(response.Trades), response.Pagination)
+	}
+}
+
 func TestProcessSignatures(t *testing.T) {
 	testXSOLATA := solana.Address("Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ")
 
@@ -382,7 +619,7 @@ func TestProcessSignatures(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	trades, err := service.processSignatures(ctx, signatures, testXSOLATA, 10)
+	trades, _, err := service.processSignatures(ctx, signatures, testXSOLATA, &TradeRequest{Limit: 10})
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 		return
@@ -411,6 +648,181 @@ func TestProcessSignatures(t *testing.T) {
 	}
 }
 
+// batchingHTTPClient adds TransactionBatcher to mockHTTPClient and records each batch
+type batchingHTTPClient struct {
+	mockHTTPClient
+	batchSize    int
+	transactions map[string]*solana.TransactionDetails
+	batches      [][]solana.Signature
+}
+
+func (m *batchingHTTPClient) TransactionBatchSize() int {
+	return m.batchSize
+}
+
+func (m *batchingHTTPClient) GetTransactions(ctx context.Context, signatures []solana.Signature) ([]*solana.TransactionDetails, []error) {
+	m.batches = append(m.batches, signatures)
+	txs := make([]*solana.TransactionDetails, len(signatures))
+	errs := make([]error, len(signatures))
+	for i, signature := range signatures {
+		if txs[i] = m.transactions[string(signature)]; txs[i] == nil {
+			errs[i] = solana.ErrTransactionNotFound
+		}
+	}
+	return txs, errs
+}
+
+func TestProcessSignaturesBatched(t *testing.T) {
+	testXSOLATA := solana.Address("Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ")
+
+	client := &batchingHTTPClient{batchSize: 2, transactions: map[string]*solana.TransactionDetails{}}
+	var signatures []solana.SignatureInfo
+	for i, sig := range []string{"sig1", "sig2", "sig3", "sig4", "sig5"} {
+		slot := uint64(365528390 - i)
+		signatures = append(signatures, solana.SignatureInfo{Signature: sig, Slot: solana.Slot(slot), BlockTime: int64Ptr(1757360080)})
+		client.transactions[sig] = createMockTradeTransaction(sig, slot, 1757360080, testXSOLATA, "1000000", "2000000", hylo.TradeSideBuy)
+	}
+
+	// sig2 is missing from its batch and only found by the single-call path (e.g. on the archive node)
+	pruned := client.transactions["sig2"]
+	delete(client.transactions, "sig2")
+	var singleFetches []string
+	client.getTransactionFunc = func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+		singleFetches = append(singleFetches, string(signature))
+		return pruned, nil
+	}
+
+	service, err := NewTradeService(client, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	trades, _, err := service.processSignatures(context.Background(), signatures, testXSOLATA, &TradeRequest{Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(trades) != 3 || trades[1].Signature != "sig2" || trades[2].Signature != "sig3" {
+		t.Fatalf("expected sig1, sig2 and sig3 in slot order, got %d trades", len(trades))
+	}
+	if len(client.batches) != 2 {
+		t.Errorf("expected two batches of two before reaching the limit, got %v", client.batches)
+	}
+	if len(singleFetches) != 1 || singleFetches[0] != "sig2" {
+		t.Errorf("expected only sig2 to be refetched on its own, got %v", singleFetches)
+	}
+}
+
+func TestProcessSignaturesConfidenceFilter(t *testing.T) {
+	testXSOLATA := solana.Address("Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ")
+
+	signatures := []solana.SignatureInfo{
+		{Signature: "sig1", Slot: 365528388, BlockTime: int64Ptr(1757360079)},
+		{Signature: "sig2", Slot: 365528387, BlockTime: int64Ptr(1757360078)},
+	}
+
+	// Neither transaction carries a Hylo instruction or a counter-asset leg, so both score low
+	mockTransactions := map[string]*solana.TransactionDetails{
+		"sig1": createMockTradeTransaction("sig1", 365528388, 1757360079, testXSOLATA, "1000000", "2000000", hylo.TradeSideBuy),
+		"sig2": createMockTradeTransaction("sig2", 365528387, 1757360078, testXSOLATA, "3000000", "1500000", hylo.TradeSideSell),
+	}
+
+	mockClient := &mockHTTPClient{
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			if tx, ok := mockTransactions[string(signature)]; ok {
+				return tx, nil
+			}
+			return nil, errors.New("transaction not found")
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	trades, _, err := service.processSignatures(context.Background(), signatures, testXSOLATA, &TradeRequest{Limit: 10, MinConfidence: 0.8})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trades) != 0 {
+		t.Errorf("expected low-confidence trades to be filtered, got %d", len(trades))
+	}
+
+	trades, _, err = service.processSignatures(context.Background(), signatures, testXSOLATA, &TradeRequest{Limit: 10, NeedsReview: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trades) != 2 {
+		t.Fatalf("expected 2 trades flagged for review, got %d", len(trades))
+	}
+	for _, trade := range trades {
+		if !trade.NeedsReview || trade.Confidence >= hylo.ReviewConfidenceThreshold {
+			t.Errorf("expected %s to need review, got confidence %v", trade.Signature, trade.Confidence)
+		}
+	}
+}
+
+func TestProcessSignaturesIncludeFailed(t *testing.T) {
+	testXSOLATA := solana.Address("Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ")
+
+	signatures := []solana.SignatureInfo{
+		{Signature: "sig1", Slot: 365528388, BlockTime: int64Ptr(1757360079)},
+		{Signature: "sigFailed", Slot: 365528389, BlockTime: int64Ptr(1757360080), Err: "InstructionError"},
+	}
+
+	failedTx := createMockTradeTransaction("sigFailed", 365528389, 1757360080, testXSOLATA, "1000000", "1000000", hylo.TradeSideBuy)
+	failedTx.Meta.Err = map[string]interface{}{"InstructionError": []interface{}{float64(0), map[string]interface{}{"Custom": float64(6001)}}}
+	failedTx.Meta.LogMessages = []string{
+		"Program log: Instruction: MintLevercoin",
+		"Program log: AnchorError occurred. Error Code: SlippageExceeded. Error Number: 6001. Error Message: Slippage tolerance exceeded.",
+	}
+	failedTx.Transaction.Message.AccountKeys = append(failedTx.Transaction.Message.AccountKeys, hylo.ExchangeProgramID)
+	failedTx.Transaction.Message.Instructions = []solana.TxInstruction{{ProgramIdIndex: 4}}
+
+	mockTransactions := map[string]*solana.TransactionDetails{
+		"sig1":      createMockTradeTransaction("sig1", 365528388, 1757360079, testXSOLATA, "1000000", "2000000", hylo.TradeSideBuy),
+		"sigFailed": failedTx,
+	}
+
+	mockClient := &mockHTTPClient{
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			if tx, ok := mockTransactions[string(signature)]; ok {
+				return tx, nil
+			}
+			return nil, errors.New("transaction not found")
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	trades, _, err := service.processSignatures(context.Background(), signatures, testXSOLATA, &TradeRequest{Limit: 10, IncludeFailed: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(trades) != 2 {
+		t.Fatalf("expected 2 trades including the failed one, got %d", len(trades))
+	}
+
+	failed := trades[0]
+	if failed.Status != hylo.TradeStatusFailed {
+		t.Errorf("expected failed status, got %q", failed.Status)
+	}
+	if failed.Side != hylo.TradeSideBuy {
+		t.Errorf("expected BUY side from instruction logs, got %q", failed.Side)
+	}
+	if failed.ErrorReason != "Slippage tolerance exceeded" {
+		t.Errorf("unexpected error reason: %q", failed.ErrorReason)
+	}
+	if trades[1].Status != "" {
+		t.Errorf("expected successful trade to have no status, got %q", trades[1].Status)
+	}
+}
+
 func TestGetServiceHealth(t *testing.T) {
 	mockClient := &mockHTTPClient{}
 	tokenConfig := tokens.NewConfig()
@@ -489,6 +901,51 @@ func TestValidateTradeRequest(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "min confidence out of range",
+			req: &TradeRequest{
+				WalletAddress: "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g",
+				Limit:         10,
+				MinConfidence: 1.5,
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown side",
+			req: &TradeRequest{
+				WalletAddress: "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g",
+				Limit:         10,
+				TradeFilters:  TradeFilters{Side: "SWAP"},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown counter asset",
+			req: &TradeRequest{
+				WalletAddress: "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g",
+				Limit:         10,
+				TradeFilters:  TradeFilters{CounterAsset: "BONK"},
+			},
+			expectError: true,
+		},
+		{
+			name: "min amount above max amount",
+			req: &TradeRequest{
+				WalletAddress: "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g",
+				Limit:         10,
+				TradeFilters:  TradeFilters{MinAmount: "5", MaxAmount: "1.5"},
+			},
+			expectError: true,
+		},
+		{
+			name: "negative amount",
+			req: &TradeRequest{
+				WalletAddress: "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g",
+				Limit:         10,
+				TradeFilters:  TradeFilters{MinAmount: "-1"},
+			},
+			expectError: true,
+		},
 		{
 			name: "limit exceeds max",
 			req: &TradeRequest{
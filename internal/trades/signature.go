@@ -0,0 +1,209 @@
+package trades
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// Where LookupSignature found a trade
+const (
+	SignatureSourceIndex = "index" // The trade index; the trade is counted in stored history
+	SignatureSourceRPC   = "rpc"   // Parsed from the transaction; no tracked wallet indexed it
+)
+
+// ErrTradeNotFound is returned by LookupSignature when a signature is unknown or is not an xSOL trade
+var ErrTradeNotFound = errors.New("no xSOL trade found for signature")
+
+// SignatureIndex finds indexed trades and a tenant's tracked wallets; implemented by store.Store
+type SignatureIndex interface {
+	TradeBySignature(signature string) (store.TradeRecord, bool)
+	GetWallet(tenant, address string) (store.TrackedWallet, bool)
+}
+
+// SignatureTrade is the trade a looked-up transaction made for one wallet
+type SignatureTrade struct {
+	Wallet string `json:"wallet"`
+
+	// Tracked is true when the caller's tenant registered the wallet and has not paused it
+	Tracked bool `json:"tracked"`
+
+	// Indexed is true when the trade is in the trade index, so it counts towards stored history and P&L
+	Indexed bool `json:"indexed"`
+
+	Trade *hylo.XSOLTrade `json:"trade"`
+}
+
+// SignatureLookup answers "was this trade counted?" for a transaction signature
+type SignatureLookup struct {
+	Signature string         `json:"signature"`
+	Chain     solana.ChainID `json:"chain"`
+
+	// Source is index when the trade index had the signature and rpc when the transaction was parsed instead
+	Source string `json:"source"`
+
+	// Indexed is true when any wallet's trade in the transaction is in the trade index
+	Indexed bool `json:"indexed"`
+
+	// Trades lists the trade the transaction made for each affected wallet
+	Trades []SignatureTrade `json:"trades"`
+
+	// TrackedWallets lists the affected wallets the caller's tenant tracks
+	TrackedWallets []string `json:"trackedWallets"`
+
+	// Raw is the transaction's undecoded instructions, when requested
+	Raw *RawTransaction `json:"raw,omitempty"`
+
+	ExplorerURL string    `json:"explorerUrl,omitempty"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+// LookupSignature finds the xSOL trade made by a transaction, from the trade index when a tracked wallet
+// indexed it and otherwise by fetching and parsing the transaction for every wallet whose xSOL it moved.
+// Tracked wallets are those of tenant. With includeRaw the lookup carries the transaction's raw instructions,
+// fetching it for indexed trades too
+func (s *TradeService) LookupSignature(ctx context.Context, index SignatureIndex, tenant, signature string, includeRaw bool) (*SignatureLookup, error) {
+	lookup := &SignatureLookup{
+		Signature:      signature,
+		Chain:          s.httpClient.Chain(),
+		Source:         SignatureSourceIndex,
+		TrackedWallets: []string{},
+		RequestedAt:    time.Now().UTC(),
+	}
+
+	var trades []SignatureTrade
+	var tx *solana.TransactionDetails
+	if record, ok := index.TradeBySignature(signature); ok {
+		trade := record.Trade
+		trades = []SignatureTrade{{Wallet: record.Wallet, Indexed: true, Trade: &trade}}
+	} else {
+		fetched, err := s.fetchSignatureTransaction(ctx, signature)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := s.parseSignatureTrades(ctx, fetched, signature)
+		if err != nil {
+			return nil, err
+		}
+		lookup.Source = SignatureSourceRPC
+		trades, tx = parsed, fetched
+	}
+
+	if includeRaw {
+		if tx == nil {
+			fetched, err := s.fetchSignatureTransaction(ctx, signature)
+			if err != nil {
+				return nil, err
+			}
+			tx = fetched
+		}
+		raw, err := newRawTransaction(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build raw transaction: %w", err)
+		}
+		lookup.Raw = raw
+	}
+
+	for i := range trades {
+		wallet, ok := index.GetWallet(tenant, trades[i].Wallet)
+		trades[i].Tracked = ok && wallet.Indexed()
+		if trades[i].Tracked {
+			lookup.TrackedWallets = append(lookup.TrackedWallets, trades[i].Wallet)
+		}
+		lookup.Indexed = lookup.Indexed || trades[i].Indexed
+	}
+	lookup.Trades = trades
+	return lookup, nil
+}
+
+// fetchSignatureTransaction fetches a transaction, reporting unknown signatures as ErrTradeNotFound
+func (s *TradeService) fetchSignatureTransaction(ctx context.Context, signature string) (*solana.TransactionDetails, error) {
+	tx, err := s.httpClient.GetTransactionForSignature(ctx, solana.SignatureInfo{Signature: signature})
+	if err != nil {
+		if errors.Is(err, solana.ErrTransactionNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrTradeNotFound, signature)
+		}
+		return nil, fmt.Errorf("failed to fetch transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// parseSignatureTrades parses a transaction for each owner of an xSOL account it touched
+func (s *TradeService) parseSignatureTrades(ctx context.Context, tx *solana.TransactionDetails, signature string) ([]SignatureTrade, error) {
+	if tx.Meta == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTradeNotFound, signature)
+	}
+
+	trades := make([]SignatureTrade, 0, 1)
+	sanity := s.newSupplyCheck()
+	for _, account := range s.xsolAccounts(tx) {
+		result, err := s.parser.ParseTransaction(ctx, tx, account.address, s.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse transaction: %w", err)
+		}
+		if result != nil && result.Trade != nil {
+			sanity.apply(ctx, result.Trade)
+			trades = append(trades, SignatureTrade{Wallet: account.owner, Trade: result.Trade})
+		}
+	}
+	if len(trades) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTradeNotFound, signature)
+	}
+	return trades, nil
+}
+
+// xsolAccount is an xSOL token account and its owner
+type xsolAccount struct {
+	address solana.Address
+	owner   string
+}
+
+// xsolAccounts lists the xSOL token accounts in a transaction's balances, once each, in order of appearance
+func (s *TradeService) xsolAccounts(tx *solana.TransactionDetails) []xsolAccount {
+	keys := tx.Transaction.Message.AccountKeys
+	seen := make(map[uint32]bool)
+	var accounts []xsolAccount
+	for _, balances := range [][]solana.TokenBalance{tx.Meta.PreTokenBalances, tx.Meta.PostTokenBalances} {
+		for _, balance := range balances {
+			if balance.Mint != s.tokenConfig.XSOLMint.String() || balance.Owner == nil || seen[balance.AccountIndex] {
+				continue
+			}
+			if int(balance.AccountIndex) >= len(keys) {
+				continue
+			}
+			seen[balance.AccountIndex] = true
+			accounts = append(accounts, xsolAccount{address: solana.Address(keys[balance.AccountIndex]), owner: *balance.Owner})
+		}
+	}
+	return accounts
+}
+
+// WithExplorer returns a copy of the lookup with transaction and trade links pointing at provider
+func (l *SignatureLookup) WithExplorer(provider explorer.Provider) *SignatureLookup {
+	linked := *l
+	linked.ExplorerURL = provider.TransactionURL(l.Signature)
+	linked.Trades = make([]SignatureTrade, len(l.Trades))
+	for i, trade := range l.Trades {
+		trade.Trade = trade.Trade.WithExplorer(provider)
+		linked.Trades[i] = trade
+	}
+	return &linked
+}
+
+// WithoutTracking returns a copy that reports no wallet as tracked, for callers not scoped to a tenant
+func (l *SignatureLookup) WithoutTracking() *SignatureLookup {
+	anonymous := *l
+	anonymous.TrackedWallets = []string{}
+	anonymous.Trades = make([]SignatureTrade, len(l.Trades))
+	for i, trade := range l.Trades {
+		trade.Tracked = false
+		anonymous.Trades[i] = trade
+	}
+	return &anonymous
+}
@@ -0,0 +1,85 @@
+package trades
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestLookupSignature(t *testing.T) {
+	const (
+		wallet  = "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+		xsolATA = solana.Address("Dqk1wW44Mw9LkKBcVjSfWDXeNYuNZ1KaXKFBAuVRzzhJ")
+	)
+	owner := wallet
+	tx := createMockTradeTransaction("sigRPC", 365528388, 1757360079, xsolATA, "1000000", "2000000", hylo.TradeSideBuy)
+	tx.Meta.PreTokenBalances[0].Owner = &owner
+	tx.Meta.PostTokenBalances[0].Owner = &owner
+
+	fetches := 0
+	client := &mockHTTPClient{
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			fetches++
+			if signature == "sigRPC" {
+				return tx, nil
+			}
+			return nil, solana.ErrTransactionNotFound
+		},
+	}
+	service, err := NewTradeService(client, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	st.TrackWallet(store.TrackedWallet{Address: wallet, Status: store.WalletStatusActive})
+	indexed := hylo.NewXSOLTrade("sigIndexed", 100, 1000)
+	indexed.SetTradeDetails(hylo.TradeSideSell, 1_500_000, 2_000_000, "hyUSD")
+	st.AddTrades([]store.TradeRecord{store.NewTradeRecord(wallet, indexed)})
+
+	// Indexed trades are served from the store without fetching the transaction
+	lookup, err := service.LookupSignature(context.Background(), st, store.DefaultTenant, "sigIndexed", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lookup.Source != SignatureSourceIndex || !lookup.Indexed || fetches != 0 {
+		t.Errorf("Expected an indexed lookup without RPC, got %+v after %d fetches", lookup, fetches)
+	}
+	if len(lookup.TrackedWallets) != 1 || len(lookup.Trades) != 1 || !lookup.Trades[0].Tracked || lookup.Trades[0].Trade.Side != hylo.TradeSideSell {
+		t.Errorf("Expected the tracked wallet's sell, got %+v", lookup.Trades)
+	}
+
+	// Unknown signatures are parsed for every wallet whose xSOL moved
+	lookup, err = service.LookupSignature(context.Background(), st, store.DefaultTenant, "sigRPC", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lookup.Source != SignatureSourceRPC || lookup.Indexed || fetches != 1 {
+		t.Errorf("Expected an RPC lookup that was not indexed, got %+v", lookup)
+	}
+	if len(lookup.Trades) != 1 || lookup.Trades[0].Wallet != wallet || !lookup.Trades[0].Tracked || lookup.Trades[0].Trade.Side != hylo.TradeSideBuy {
+		t.Errorf("Expected the tracked wallet's uncounted buy, got %+v", lookup.Trades)
+	}
+
+	if _, err := service.LookupSignature(context.Background(), st, store.DefaultTenant, "sigMissing", false); !errors.Is(err, ErrTradeNotFound) {
+		t.Errorf("Expected ErrTradeNotFound, got %v", err)
+	}
+
+	// Other tenants do not see the wallet as tracked, nor do anonymous callers
+	lookup, err = service.LookupSignature(context.Background(), st, "acme", "sigIndexed", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lookup.TrackedWallets) != 0 || lookup.Trades[0].Tracked || !lookup.Indexed {
+		t.Errorf("Expected another tenant's wallet not to be reported as tracked, got %+v", lookup)
+	}
+	lookup, _ = service.LookupSignature(context.Background(), st, store.DefaultTenant, "sigIndexed", false)
+	if anonymous := lookup.WithoutTracking(); len(anonymous.TrackedWallets) != 0 || anonymous.Trades[0].Tracked || !lookup.Trades[0].Tracked {
+		t.Errorf("Expected an untracked copy, got %+v", anonymous)
+	}
+}
@@ -0,0 +1,90 @@
+package trades
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// fullHistoryPageSize is the number of signatures requested per page when walking a complete history,
+// the getSignaturesForAddress maximum
+const fullHistoryPageSize = 1000
+
+// HistorySummary reports how far a full trade history walk got
+// Cursor is the oldest signature scanned; pass it as before to resume an interrupted walk
+type HistorySummary struct {
+	Wallet     string `json:"wallet"`
+	Signatures int    `json:"signatures"` // Signatures scanned
+	Trades     int    `json:"trades"`     // Trades emitted
+	Cursor     string `json:"cursor,omitempty"`
+	Complete   bool   `json:"complete"` // The walk reached the wallet's oldest signature
+	ElapsedMS  int64  `json:"elapsed_ms"`
+}
+
+// StreamTrades walks the wallet's complete xSOL signature history older than before (all of it when empty),
+// newest first, passing each trade to emit as soon as it is parsed
+// The walk stops at the first error from emit or as soon as ctx is done, aborting in-flight RPC requests;
+// the summary then reports the cursor to resume from
+func (s *TradeService) StreamTrades(ctx context.Context, walletAddr solana.Address, before string, includeFailed bool,
+	emit func(trade *hylo.XSOLTrade) error) (*HistorySummary, error) {
+	startTime := time.Now()
+	summary := &HistorySummary{Wallet: walletAddr.String(), Cursor: before}
+	defer func() { summary.ElapsedMS = time.Since(startTime).Milliseconds() }()
+
+	if err := walletAddr.Validate(); err != nil {
+		s.logger.LogValidationError(ctx, "stream_wallet_trades", "wallet", walletAddr, err)
+		return summary, fmt.Errorf("%w: %v", ErrInvalidWalletAddress, err)
+	}
+
+	xsolATA, err := tokens.DeriveAssociatedTokenAddress(walletAddr, tokens.XSOLMint)
+	if err != nil {
+		return summary, fmt.Errorf("%w: %v", ErrXSOLATADerivation, err)
+	}
+
+	// Complete histories keep dust, so exports add up to the wallet's balance
+	req := &TradeRequest{WalletAddress: walletAddr.String(), IncludeFailed: includeFailed, IncludeDust: true}
+	sanity := s.newSupplyCheck()
+	for {
+		signatures, err := s.httpClient.GetSignaturesForAddressWithOptions(ctx, xsolATA, solana.SignatureQuery{
+			Before: summary.Cursor,
+			Limit:  fullHistoryPageSize,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return summary, ctx.Err()
+			}
+			s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetSignaturesForAddress", err, 0,
+				slog.String("ata_address", xsolATA.String()))
+			return summary, fmt.Errorf("%w: %v", ErrSignatureFetch, err)
+		}
+		if len(signatures) == 0 {
+			summary.Complete = true
+			return summary, nil
+		}
+
+		// Scan in transaction batches so the cursor only advances past signatures fully processed
+		batchSize := s.transactionBatchSize()
+		for start := 0; start < len(signatures); start += batchSize {
+			batch := signatures[start:min(start+batchSize, len(signatures))]
+			err := s.scanSignatures(ctx, batch, xsolATA, req, sanity, nil, func(trade *hylo.XSOLTrade) error {
+				summary.Trades++
+				return emit(trade)
+			})
+			if err != nil {
+				return summary, err
+			}
+			summary.Signatures += len(batch)
+			summary.Cursor = batch[len(batch)-1].Signature
+		}
+
+		if len(signatures) < fullHistoryPageSize {
+			summary.Complete = true
+			return summary, nil
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package trades
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// pagedHistoryClient serves a signature history in pages, newest first, with a trade for every signature
+func pagedHistoryClient(t *testing.T, total int) (*mockHTTPClient, *[]string) {
+	t.Helper()
+
+	wallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	xsolATA, err := tokens.DeriveAssociatedTokenAddress(wallet, tokens.XSOLMint)
+	if err != nil {
+		t.Fatalf("failed to derive ATA: %v", err)
+	}
+
+	history := make([]solana.SignatureInfo, total)
+	transactions := make(map[string]*solana.TransactionDetails, total)
+	for i := range history {
+		signature := fmt.Sprintf("sig%d", i)
+		slot := uint64(400_000_000 - i)
+		history[i] = solana.SignatureInfo{Signature: signature, Slot: solana.Slot(slot), BlockTime: int64Ptr(1757360000)}
+		transactions[signature] = createMockTradeTransaction(signature, slot, 1757360000, xsolATA, "1000000", "2000000", hylo.TradeSideBuy)
+	}
+
+	var befores []string
+	client := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			befores = append(befores, query.Before)
+			start := 0
+			if query.Before != "" {
+				for i, info := range history {
+					if info.Signature == query.Before {
+						start = i + 1
+					}
+				}
+			}
+			end := min(start+query.Limit, len(history))
+			return append([]solana.SignatureInfo(nil), history[start:end]...), nil
+		},
+		getTransactionFunc: func(ctx context.Context, signature solana.Signature) (*solana.TransactionDetails, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return transactions[string(signature)], nil
+		},
+	}
+	return client, &befores
+}
+
+func TestStreamTrades_WalksCompleteHistory(t *testing.T) {
+	client, befores := pagedHistoryClient(t, fullHistoryPageSize+5)
+	service, err := NewTradeService(client, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	var emitted []string
+	summary, err := service.StreamTrades(context.Background(), "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g", "", false,
+		func(trade *hylo.XSOLTrade) error {
+			emitted = append(emitted, trade.Signature)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !summary.Complete || summary.Signatures != fullHistoryPageSize+5 || summary.Trades != len(emitted) {
+		t.Errorf("unexpected summary: %+v with %d trades emitted", summary, len(emitted))
+	}
+	if len(emitted) != fullHistoryPageSize+5 || emitted[0] != "sig0" || emitted[len(emitted)-1] != fmt.Sprintf("sig%d", fullHistoryPageSize+4) {
+		t.Errorf("expected every trade newest first, got %d", len(emitted))
+	}
+	if len(*befores) != 2 || (*befores)[1] != fmt.Sprintf("sig%d", fullHistoryPageSize-1) {
+		t.Errorf("expected a second page before the first page's oldest signature, got %v", *befores)
+	}
+}
+
+func TestStreamTrades_StopsWhenEmitFails(t *testing.T) {
+	client, befores := pagedHistoryClient(t, fullHistoryPageSize*3)
+	service, err := NewTradeService(client, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	errGone := errors.New("client gone")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	summary, err := service.StreamTrades(ctx, "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g", "", false,
+		func(trade *hylo.XSOLTrade) error {
+			cancel()
+			return errGone
+		})
+	if !errors.Is(err, errGone) {
+		t.Fatalf("expected the emit error, got %v", err)
+	}
+	if summary.Complete || summary.Trades != 1 || summary.Cursor != "" {
+		t.Errorf("expected the walk to stop at the first trade, got %+v", summary)
+	}
+	if len(*befores) != 1 {
+		t.Errorf("expected no further pages after the client went away, got %d", len(*befores))
+	}
+}
@@ -0,0 +1,353 @@
+package trades
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// First-seen scan limits
+const (
+	firstSeenPageSize   = 1000  // Maximum signatures per getSignaturesForAddress page
+	firstSeenMaxPages   = 50    // Caps each lookup's walk at 50k signatures per token account
+	firstSeenMaxProbes  = 64    // Caps the slot binary search; 64 halvings cover any slot range
+	firstSeenProbeLimit = 100   // Signatures the slot search leaves behind its cursor, so the tail likely holds a success
+	firstSeenAnchorSpan = 8     // Slots tried from a probe's midpoint before skipped slots end the search
+	firstSeenCacheSize  = 10000 // Wallets with a cached first activity, and token accounts with a saved walk
+)
+
+// BlockSignatureFetcher is implemented by clients that can name a transaction in a given slot
+// Any signature anchors a getSignaturesForAddress listing at its slot, so GetWalletSummary uses it
+// to binary search long histories by slot instead of walking every page
+type BlockSignatureFetcher interface {
+	GetBlockSignature(ctx context.Context, slot solana.Slot) (string, bool, error)
+}
+
+// firstSeenScan is the progress of a token account's walk towards its oldest successful signature
+// A truncated walk resumes from before on the next lookup instead of starting over
+type firstSeenScan struct {
+	oldest   *solana.SignatureInfo
+	before   string
+	complete bool
+}
+
+// advance folds the next (older) page of the walk into the scan
+func (scan *firstSeenScan) advance(page []solana.SignatureInfo) {
+	for i := range page {
+		if page[i].Err == nil {
+			oldest := page[i]
+			scan.oldest = &oldest
+		}
+	}
+	if len(page) < firstSeenPageSize {
+		scan.complete = true
+		return
+	}
+	scan.before = page[len(page)-1].Signature
+}
+
+// FirstActivity identifies the wallet's earliest Hylo-related transaction
+type FirstActivity struct {
+	Signature string    `json:"signature"`
+	Slot      uint64    `json:"slot"`
+	Timestamp time.Time `json:"timestamp"`
+	Token     string    `json:"token"` // Token account the transaction was found on (xSOL, hyUSD or sHYUSD)
+
+	ExplorerURL string `json:"explorer_url,omitempty"` // Transaction URL on the requested block explorer
+}
+
+// WalletSummary describes how long a wallet has been active in the protocol
+type WalletSummary struct {
+	WalletAddress     string         `json:"wallet_address"`
+	ExplorerURL       string         `json:"explorer_url,omitempty"` // Wallet page on the requested block explorer
+	FirstHyloActivity *FirstActivity `json:"first_hylo_activity"`    // Nil when the wallet has never touched a Hylo token
+	AccountAgeDays    *int           `json:"account_age_days"`       // Whole days since FirstHyloActivity
+	Truncated         bool           `json:"truncated"`              // History exceeded the scan cap; first activity may be older and the next lookup resumes the scan
+	RequestedAt       time.Time      `json:"requested_at"`
+}
+
+// GetWalletSummary finds the wallet's earliest Hylo token account transaction and derives its account age
+// The first activity never changes once found, so it is cached per wallet
+func (s *TradeService) GetWalletSummary(ctx context.Context, walletAddr solana.Address) (*WalletSummary, error) {
+	if err := walletAddr.Validate(); err != nil {
+		s.logger.LogValidationError(ctx, "get_wallet_summary", "wallet", walletAddr, err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWalletAddress, err)
+	}
+
+	now := time.Now()
+	summary := &WalletSummary{WalletAddress: walletAddr.String(), RequestedAt: now}
+
+	first, truncated, err := s.findFirstActivity(ctx, walletAddr)
+	if err != nil {
+		return nil, err
+	}
+	summary.FirstHyloActivity = first
+	summary.Truncated = truncated
+
+	if first != nil && !first.Timestamp.IsZero() {
+		days := int(now.Sub(first.Timestamp).Hours() / 24)
+		summary.AccountAgeDays = &days
+	}
+
+	return summary, nil
+}
+
+// FirstActivities returns the cached first activity of every wallet looked up so far, keyed by address
+func (s *TradeService) FirstActivities() map[string]*FirstActivity {
+	activities := make(map[string]*FirstActivity)
+	s.firstSeen.each(func(wallet solana.Address, first *FirstActivity) {
+		activities[wallet.String()] = first
+	})
+	return activities
+}
+
+// RestoreFirstActivities seeds the first activity cache, e.g. from a snapshot saved before a restart
+// Invalid addresses and empty entries are skipped
+func (s *TradeService) RestoreFirstActivities(activities map[string]*FirstActivity) {
+	for address, first := range activities {
+		wallet := solana.Address(address)
+		if first == nil || wallet.Validate() != nil {
+			continue
+		}
+		s.firstSeen.set(wallet, first)
+	}
+}
+
+// findFirstActivity returns the oldest successful signature across the wallet's Hylo token accounts
+func (s *TradeService) findFirstActivity(ctx context.Context, walletAddr solana.Address) (*FirstActivity, bool, error) {
+	if cached, ok := s.firstSeen.get(walletAddr); ok {
+		return cached, false, nil
+	}
+
+	var first *FirstActivity
+	truncated := false
+	atas := make([]solana.Address, 0, len(eventTokenMints))
+	for _, mint := range eventTokenMints {
+		ata, err := tokens.DeriveAssociatedTokenAddress(walletAddr, mint)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to derive token account for %s: %w", mint, err)
+		}
+		atas = append(atas, ata)
+
+		scan, err := s.oldestSignature(ctx, ata)
+		if err != nil {
+			return nil, false, err
+		}
+		truncated = truncated || !scan.complete
+
+		oldest := scan.oldest
+		if oldest != nil && (first == nil || uint64(oldest.Slot) < first.Slot) {
+			first = &FirstActivity{
+				Signature: oldest.Signature,
+				Slot:      uint64(oldest.Slot),
+				Timestamp: oldest.GetTime().UTC(),
+				Token:     tokens.GetTokenSymbol(mint),
+			}
+		}
+	}
+
+	// Only a complete scan proves nothing older exists; until then each token account keeps its walk
+	if truncated {
+		return first, true, nil
+	}
+	for _, ata := range atas {
+		s.firstSeenScans.delete(ata)
+	}
+	if first != nil {
+		s.firstSeen.set(walletAddr, first)
+	}
+	return first, false, nil
+}
+
+// oldestSignature finds an address's oldest successful signature
+// Signatures are cursor-paged newest first, so the tail is only reachable through a before cursor. Histories
+// longer than a page are binary searched by slot for a cursor close to the tail when the client can anchor
+// slots; otherwise, or when the tail holds no successful signature, the pages are walked, at most
+// firstSeenMaxPages per lookup, and a truncated walk is saved for the next lookup to resume
+func (s *TradeService) oldestSignature(ctx context.Context, address solana.Address) (firstSeenScan, error) {
+	scan, resumed := s.firstSeenScans.get(address)
+	if scan.complete {
+		return scan, nil
+	}
+
+	if !resumed {
+		page, err := s.firstSeenPage(ctx, address, "")
+		if err != nil {
+			return scan, err
+		}
+		scan.advance(page)
+		if scan.complete {
+			return scan, nil
+		}
+
+		if anchor, ok := s.searchOldestAnchor(ctx, address, page[len(page)-1]); ok {
+			tail, err := s.walkSignatures(ctx, address, firstSeenScan{before: anchor}, firstSeenMaxPages)
+			if err != nil {
+				return scan, err
+			}
+			if tail.complete && tail.oldest != nil {
+				s.firstSeenScans.set(address, tail)
+				return tail, nil
+			}
+		}
+	}
+
+	pages := firstSeenMaxPages
+	if !resumed {
+		pages-- // The first page was already listed
+	}
+	scan, err := s.walkSignatures(ctx, address, scan, pages)
+	if err != nil {
+		return scan, err
+	}
+	s.firstSeenScans.set(address, scan)
+	if !scan.complete {
+		s.logger.WarnContext(ctx, "Signature history exceeds first-seen scan cap; resuming on the next lookup",
+			slog.String("ata_address", address.String()),
+			slog.Int("max_pages", firstSeenMaxPages))
+	}
+	return scan, nil
+}
+
+// walkSignatures follows the scan's before cursor back through at most pages pages
+func (s *TradeService) walkSignatures(ctx context.Context, address solana.Address, scan firstSeenScan, pages int) (firstSeenScan, error) {
+	for page := 0; page < pages && !scan.complete; page++ {
+		signatures, err := s.firstSeenPage(ctx, address, scan.before)
+		if err != nil {
+			return scan, err
+		}
+		scan.advance(signatures)
+	}
+	return scan, nil
+}
+
+// firstSeenPage lists one page of the address's signatures older than before
+func (s *TradeService) firstSeenPage(ctx context.Context, address solana.Address, before string) ([]solana.SignatureInfo, error) {
+	signatures, err := s.httpClient.GetSignaturesForAddressWithOptions(ctx, address, solana.SignatureQuery{
+		Before: before,
+		Limit:  firstSeenPageSize,
+	})
+	if err != nil {
+		s.logger.LogExternalAPIError(ctx, "solana-rpc", "GetSignaturesForAddress", err, 0,
+			slog.String("ata_address", address.String()),
+			slog.String("before", before))
+		return nil, fmt.Errorf("%w: %v", ErrSignatureFetch, err)
+	}
+	return signatures, nil
+}
+
+// searchOldestAnchor binary searches the slots below upper for a cursor with only the oldest few signatures behind it
+// Each probe anchors a short listing at a block's first transaction; the result is the earliest anchor found with at
+// least firstSeenProbeLimit signatures behind it, so the tail fits in one page. Any anchor is a valid cursor, so
+// failed probes only end the search early. False means the client cannot anchor slots
+func (s *TradeService) searchOldestAnchor(ctx context.Context, address solana.Address, upper solana.SignatureInfo) (string, bool) {
+	fetcher, ok := s.httpClient.(BlockSignatureFetcher)
+	if !ok {
+		return "", false
+	}
+
+	// Fewer than firstSeenProbeLimit signatures precede lo; at least as many precede the anchor at hi
+	lo, hi, anchor := solana.Slot(0), upper.Slot, upper.Signature
+	for probe := 0; probe < firstSeenMaxProbes && lo+1 < hi; probe++ {
+		slot, signature, found, err := blockAnchor(ctx, fetcher, lo+(hi-lo)/2, hi)
+		if err != nil || !found {
+			if err != nil {
+				s.logger.WarnContext(ctx, "First-seen slot search stopped early",
+					slog.String("ata_address", address.String()),
+					slog.String("error", err.Error()))
+			}
+			break
+		}
+
+		older, err := s.httpClient.GetSignaturesForAddressWithOptions(ctx, address, solana.SignatureQuery{
+			Before: signature,
+			Limit:  firstSeenProbeLimit,
+		})
+		if err != nil {
+			s.logger.WarnContext(ctx, "First-seen slot search stopped early",
+				slog.String("ata_address", address.String()),
+				slog.String("error", err.Error()))
+			break
+		}
+		if len(older) >= firstSeenProbeLimit {
+			hi, anchor = slot, signature
+		} else {
+			lo = slot
+		}
+	}
+	return anchor, true
+}
+
+// blockAnchor returns the first transaction of the first block from slot up to firstSeenAnchorSpan slots on, below hi
+func blockAnchor(ctx context.Context, fetcher BlockSignatureFetcher, slot, hi solana.Slot) (solana.Slot, string, bool, error) {
+	for end := slot + firstSeenAnchorSpan; slot < end && slot < hi; slot++ {
+		signature, found, err := fetcher.GetBlockSignature(ctx, slot)
+		if err != nil {
+			return 0, "", false, err
+		}
+		if found {
+			return slot, signature, true, nil
+		}
+	}
+	return 0, "", false, nil
+}
+
+// boundedMap is a concurrency-safe map holding at most limit entries, evicting the oldest insert when full
+type boundedMap[K comparable, V any] struct {
+	mu     sync.Mutex
+	limit  int
+	values map[K]V
+	order  []K // Insertion order for eviction
+}
+
+func newBoundedMap[K comparable, V any](limit int) *boundedMap[K, V] {
+	return &boundedMap[K, V]{limit: limit, values: make(map[K]V)}
+}
+
+func (m *boundedMap[K, V]) get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *boundedMap[K, V]) set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.values[key]; !ok {
+		if len(m.order) >= m.limit {
+			delete(m.values, m.order[0])
+			m.order = m.order[1:]
+		}
+		m.order = append(m.order, key)
+	}
+	m.values[key] = value
+}
+
+func (m *boundedMap[K, V]) delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.order {
+		if k == key {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *boundedMap[K, V]) each(fn func(key K, value V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, value := range m.values {
+		fn(key, value)
+	}
+}
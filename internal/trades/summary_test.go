@@ -0,0 +1,273 @@
+package trades
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestGetWalletSummary(t *testing.T) {
+	testWallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	xsolATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.XSOLMint)
+	hyusdATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.HyUSDMint)
+
+	// xSOL history spans two pages; its oldest successful entry is older than anything on hyUSD
+	fullPage := make([]solana.SignatureInfo, firstSeenPageSize)
+	for i := range fullPage {
+		fullPage[i] = solana.SignatureInfo{Signature: fmt.Sprintf("xsol%d", i), Slot: solana.Slot(10_000 - i)}
+	}
+	blockTime := int64(1_700_000_000)
+	lastPage := []solana.SignatureInfo{
+		{Signature: "xsolFirst", Slot: 500, BlockTime: &blockTime},
+		{Signature: "xsolFailed", Slot: 400, Err: "InstructionError"},
+	}
+
+	calls := 0
+	mockClient := &mockHTTPClient{
+		getSignaturesQueryFunc: func(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+			calls++
+			switch address {
+			case xsolATA:
+				if query.Before == "" {
+					return fullPage, nil
+				}
+				if query.Before != fullPage[len(fullPage)-1].Signature {
+					t.Errorf("unexpected before cursor %q", query.Before)
+				}
+				return lastPage, nil
+			case hyusdATA:
+				return []solana.SignatureInfo{{Signature: "hyusdSig", Slot: 8_000}}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	service, err := NewTradeService(mockClient, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	summary, err := service.GetWalletSummary(context.Background(), testWallet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first := summary.FirstHyloActivity
+	if first == nil || first.Signature != "xsolFirst" || first.Token != tokens.XSOLSymbol {
+		t.Fatalf("expected xsolFirst on xSOL as first activity, got %+v", first)
+	}
+	if summary.AccountAgeDays == nil || *summary.AccountAgeDays <= 0 {
+		t.Errorf("expected positive account age, got %v", summary.AccountAgeDays)
+	}
+	if summary.Truncated {
+		t.Error("expected complete scan")
+	}
+
+	// Second lookup is served from the first-seen cache
+	callsBefore := calls
+	if _, err := service.GetWalletSummary(context.Background(), testWallet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != callsBefore {
+		t.Errorf("expected cached first activity, got %d extra RPC calls", calls-callsBefore)
+	}
+}
+
+func TestGetWalletSummary_NoActivity(t *testing.T) {
+	service, err := NewTradeService(&mockHTTPClient{}, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	summary, err := service.GetWalletSummary(context.Background(), solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.FirstHyloActivity != nil || summary.AccountAgeDays != nil {
+		t.Errorf("expected no activity, got %+v", summary)
+	}
+}
+
+func TestRestoreFirstActivities(t *testing.T) {
+	service, err := NewTradeService(&mockHTTPClient{}, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	wallet := "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+	first := &FirstActivity{Signature: "sig", Slot: 7, Timestamp: time.Now().Add(-72 * time.Hour), Token: "xSOL"}
+	service.RestoreFirstActivities(map[string]*FirstActivity{wallet: first, "not-a-wallet": first})
+
+	// The mock client has no signatures, so the result can only come from the restored cache
+	summary, err := service.GetWalletSummary(context.Background(), solana.Address(wallet))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.FirstHyloActivity == nil || summary.FirstHyloActivity.Signature != "sig" {
+		t.Fatalf("expected restored first activity, got %+v", summary.FirstHyloActivity)
+	}
+
+	if cached := service.FirstActivities(); len(cached) != 1 || cached[wallet] != first {
+		t.Errorf("expected only the valid wallet cached, got %v", cached)
+	}
+}
+
+// anchoringClient serves a token account history of count signatures, one every 100 slots below top,
+// and names "block<slot>" as the first transaction of every block except skipped ones, every seventh slot
+type anchoringClient struct {
+	mockHTTPClient
+	ata   solana.Address
+	top   solana.Slot
+	count int
+
+	listCalls  int
+	fullPages  int
+	blockCalls int
+}
+
+func (c *anchoringClient) slotOf(before string) solana.Slot {
+	var slot, index uint64
+	if _, err := fmt.Sscanf(before, "block%d", &slot); err == nil {
+		return solana.Slot(slot)
+	}
+	if _, err := fmt.Sscanf(before, "sig%d", &index); err == nil {
+		return c.top - solana.Slot(index)*100
+	}
+	return c.top + 1
+}
+
+func (c *anchoringClient) GetSignaturesForAddressWithOptions(ctx context.Context, address solana.Address, query solana.SignatureQuery) ([]solana.SignatureInfo, error) {
+	c.listCalls++
+	if address != c.ata {
+		return nil, nil
+	}
+	before := c.slotOf(query.Before)
+	var page []solana.SignatureInfo
+	for i := 0; i < c.count && len(page) < query.Limit; i++ {
+		slot := c.top - solana.Slot(i)*100
+		if slot >= before {
+			continue
+		}
+		info := solana.SignatureInfo{Signature: fmt.Sprintf("sig%d", i), Slot: slot}
+		if i == c.count-1 {
+			info.Err = "InstructionError" // The very oldest entry failed
+		}
+		page = append(page, info)
+	}
+	if len(page) == firstSeenPageSize {
+		c.fullPages++
+	}
+	return page, nil
+}
+
+func (c *anchoringClient) GetBlockSignature(ctx context.Context, slot solana.Slot) (string, bool, error) {
+	c.blockCalls++
+	if slot%7 == 0 {
+		return "", false, nil
+	}
+	return fmt.Sprintf("block%d", slot), true, nil
+}
+
+func TestGetWalletSummaryBinarySearchesLongHistory(t *testing.T) {
+	testWallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	xsolATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.XSOLMint)
+
+	client := &anchoringClient{ata: xsolATA, top: 100_000_000, count: 20_000}
+
+	service, err := NewTradeService(client, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	summary, err := service.GetWalletSummary(context.Background(), testWallet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The failed oldest entry is skipped in favour of the one before it
+	first := summary.FirstHyloActivity
+	if first == nil || first.Signature != fmt.Sprintf("sig%d", client.count-2) {
+		t.Fatalf("expected sig%d as first activity, got %+v", client.count-2, first)
+	}
+	if summary.Truncated {
+		t.Error("expected complete scan")
+	}
+
+	// Only the first page is listed in full; the 20 pages behind it are skipped by the slot search
+	if client.fullPages != 1 {
+		t.Errorf("expected 1 full page listed, got %d", client.fullPages)
+	}
+	if client.blockCalls > 2*firstSeenMaxProbes {
+		t.Errorf("expected a bounded slot search, got %d block lookups", client.blockCalls)
+	}
+}
+
+func TestGetWalletSummaryResumesTruncatedWalk(t *testing.T) {
+	testWallet := solana.Address("A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g")
+	xsolATA, _ := tokens.DeriveAssociatedTokenAddress(testWallet, tokens.XSOLMint)
+
+	// Without slot anchors the walk is capped, so the history takes two lookups
+	anchoring := &anchoringClient{ata: xsolATA, top: 100_000_000, count: (firstSeenMaxPages + 10) * firstSeenPageSize}
+	client := &mockHTTPClient{getSignaturesQueryFunc: anchoring.GetSignaturesForAddressWithOptions}
+
+	service, err := NewTradeService(client, tokens.NewConfig(), hylo.NewConfig())
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	summary, err := service.GetWalletSummary(context.Background(), testWallet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !summary.Truncated {
+		t.Fatal("expected the first lookup to be truncated")
+	}
+	if anchoring.fullPages != firstSeenMaxPages {
+		t.Fatalf("expected %d pages walked, got %d", firstSeenMaxPages, anchoring.fullPages)
+	}
+
+	summary, err = service.GetWalletSummary(context.Background(), testWallet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Truncated {
+		t.Error("expected the resumed lookup to complete")
+	}
+	if first := summary.FirstHyloActivity; first == nil || first.Signature != fmt.Sprintf("sig%d", anchoring.count-2) {
+		t.Fatalf("expected sig%d as first activity, got %+v", anchoring.count-2, first)
+	}
+
+	// The resumed walk continued from the saved cursor instead of listing the first 50 pages again
+	if anchoring.fullPages != firstSeenMaxPages+10 {
+		t.Errorf("expected %d pages walked in total, got %d", firstSeenMaxPages+10, anchoring.fullPages)
+	}
+	if _, saved := service.firstSeenScans.get(xsolATA); saved {
+		t.Error("expected the saved walk dropped once the first activity is cached")
+	}
+}
+
+func TestBoundedMapEvictsOldest(t *testing.T) {
+	cache := newBoundedMap[string, int](2)
+	cache.set("a", 1)
+	cache.set("b", 2)
+	cache.set("a", 3) // Updating keeps the original insert position
+	cache.set("c", 4)
+
+	if _, ok := cache.get("a"); ok {
+		t.Error("expected the oldest insert evicted")
+	}
+	if value, ok := cache.get("c"); !ok || value != 4 {
+		t.Errorf("expected c cached, got %d, %v", value, ok)
+	}
+
+	cache.delete("b")
+	cache.set("d", 5)
+	if _, ok := cache.get("c"); !ok {
+		t.Error("expected a deleted entry to free its slot")
+	}
+}
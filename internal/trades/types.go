@@ -2,9 +2,12 @@ package trades
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/utils"
 )
 
 // TradeRequest represents the input parameters for fetching wallet trades
@@ -18,8 +21,62 @@ type TradeRequest struct {
 	// Before is the signature cursor for pagination (optional)
 	// When provided, returns trades before this signature
 	Before string `json:"before,omitempty"`
+
+	// Until stops the scan at this signature (exclusive), e.g. the newest signature from a previous sync
+	Until string `json:"until,omitempty"`
+
+	// MinSlot and MaxSlot restrict results to an inclusive slot range (0 = unbounded)
+	MinSlot uint64 `json:"minSlot,omitempty"`
+	MaxSlot uint64 `json:"maxSlot,omitempty"`
+
+	// IncludeFailed returns reverted Hylo transactions with their failure reason
+	IncludeFailed bool `json:"includeFailed,omitempty"`
+
+	// MinConfidence drops parsed trades scored below it (0-1, 0 = no filter)
+	// NeedsReview returns only trades scored below hylo.ReviewConfidenceThreshold
+	// Neither applies to failed transactions, which are not scored
+	MinConfidence float64 `json:"minConfidence,omitempty"`
+	NeedsReview   bool    `json:"needsReview,omitempty"`
+
+	// IncludeDust returns trades below the configured minimum trade size (hylo.Config.DustMinXSOLRaw and DustMinUSD)
+	IncludeDust bool `json:"includeDust,omitempty"`
+
+	// dust drops and counts dust across every page of the request; nil when dust is not filtered
+	dust *dustCheck
+
+	// TradeFilters narrow results by side, counter asset and xSOL amount
+	TradeFilters
+}
+
+// TradeFilters select trades by side, counter asset and xSOL amount
+// Filtered requests keep scanning older signatures until the page fills, and the filters are
+// echoed in PaginationInfo so the next page can be requested with the same query
+type TradeFilters struct {
+	Side         string `json:"side,omitempty"`         // BUY, SELL or RECEIVE
+	CounterAsset string `json:"counterAsset,omitempty"` // hyUSD, SOL or USDC
+	MinAmount    string `json:"minAmount,omitempty"`    // Inclusive xSOL amount bounds as decimal strings
+	MaxAmount    string `json:"maxAmount,omitempty"`
 }
 
+// Active reports whether any filter is set
+func (f TradeFilters) Active() bool {
+	return f != TradeFilters{}
+}
+
+// filterSides and filterCounterAssets are the accepted filter values, keyed case-insensitively
+var (
+	filterSides = map[string]string{
+		"buy":     hylo.TradeSideBuy,
+		"sell":    hylo.TradeSideSell,
+		"receive": hylo.TradeSideReceive,
+	}
+	filterCounterAssets = map[string]string{
+		"hyusd": "hyUSD",
+		"sol":   "SOL",
+		"usdc":  "USDC",
+	}
+)
+
 // TradeResponse represents the response structure for wallet trades
 type TradeResponse struct {
 	// Trades is the array of xSOL trades for this wallet
@@ -29,9 +86,19 @@ type TradeResponse struct {
 	Pagination PaginationInfo `json:"pagination"`
 
 	// Request metadata
-	WalletAddress string    `json:"walletAddress"`
-	RequestedAt   time.Time `json:"requestedAt"`
-	Count         int       `json:"count"` // Number of trades returned
+	WalletAddress string         `json:"walletAddress"`
+	WalletURL     string         `json:"walletExplorerUrl,omitempty"` // Wallet page on the requested block explorer
+	Chain         solana.ChainID `json:"chain"`                       // SVM chain the trades were read from
+	RequestedAt   time.Time      `json:"requestedAt"`
+	Count         int            `json:"count"` // Number of trades returned
+	// Context is the slot, commitment and fetch time of the chain data behind the response
+	Context *solana.DataContext `json:"context,omitempty"`
+	// Dust reports the trades left out as dust; absent when no dust threshold is configured or dust was included
+	Dust *DustSummary `json:"dust,omitempty"`
+
+	// Unfetched lists scanned signatures whose transactions could not be fetched, so the page may be
+	// missing their trades; not serialized, the indexer queues them for another attempt
+	Unfetched []FetchFailure `json:"-"`
 }
 
 // PaginationInfo provides cursor-based pagination metadata
@@ -48,6 +115,9 @@ type PaginationInfo struct {
 
 	// Count is the number of items in the current response
 	Count int `json:"count"`
+
+	// Filters echoes the trade filters applied to this page; pass them again with NextCursor
+	Filters *TradeFilters `json:"filters,omitempty"`
 }
 
 // TradeServiceOptions provides configuration options for the trade service
@@ -71,6 +141,79 @@ func DefaultTradeServiceOptions() *TradeServiceOptions {
 	}
 }
 
+// matchesConfidence reports whether a parsed trade passes the request's confidence filters
+// Failed transactions are not scored and always pass
+func (r *TradeRequest) matchesConfidence(trade *hylo.XSOLTrade) bool {
+	if trade.Status == hylo.TradeStatusFailed {
+		return true
+	}
+	if trade.Confidence < r.MinConfidence {
+		return false
+	}
+	return !r.NeedsReview || trade.NeedsReview
+}
+
+// matches reports whether a trade passes the side, counter asset and amount filters
+// Trades without an xSOL amount, such as failed transactions, never match an amount range
+func (f *TradeFilters) matches(trade *hylo.XSOLTrade) bool {
+	if f.Side != "" && trade.Side != f.Side {
+		return false
+	}
+	if f.CounterAsset != "" && trade.CounterAsset != f.CounterAsset {
+		return false
+	}
+	if f.MinAmount == "" && f.MaxAmount == "" {
+		return true
+	}
+
+	amount, err := utils.ParseDecimal(trade.XSOLAmount)
+	if err != nil {
+		return false
+	}
+	if minAmount, err := utils.ParseDecimal(f.MinAmount); err == nil && amount.Cmp(minAmount) < 0 {
+		return false
+	}
+	if maxAmount, err := utils.ParseDecimal(f.MaxAmount); err == nil && amount.Cmp(maxAmount) > 0 {
+		return false
+	}
+	return true
+}
+
+// normalize canonicalizes side and counter asset spelling and validates the amount range
+func (f *TradeFilters) normalize() error {
+	if f.Side != "" {
+		side, ok := filterSides[strings.ToLower(f.Side)]
+		if !ok {
+			return ErrInvalidSide
+		}
+		f.Side = side
+	}
+
+	if f.CounterAsset != "" {
+		asset, ok := filterCounterAssets[strings.ToLower(f.CounterAsset)]
+		if !ok {
+			return ErrInvalidCounterAsset
+		}
+		f.CounterAsset = asset
+	}
+
+	var bounds [2]utils.Decimal
+	for i, value := range []string{f.MinAmount, f.MaxAmount} {
+		if value == "" {
+			continue
+		}
+		amount, err := utils.ParseDecimal(value)
+		if err != nil || amount.Sign() < 0 {
+			return ErrInvalidAmountRange
+		}
+		bounds[i] = amount
+	}
+	if f.MinAmount != "" && f.MaxAmount != "" && bounds[0].Cmp(bounds[1]) > 0 {
+		return ErrInvalidAmountRange
+	}
+	return nil
+}
+
 // ValidateTradeRequest validates the trade request parameters
 func ValidateTradeRequest(req *TradeRequest, options *TradeServiceOptions) error {
 	if req.WalletAddress == "" {
@@ -87,13 +230,26 @@ func ValidateTradeRequest(req *TradeRequest, options *TradeServiceOptions) error
 		req.Limit = options.MaxLimit
 	}
 
-	return nil
+	if req.MaxSlot != 0 && req.MinSlot > req.MaxSlot {
+		return ErrInvalidSlotRange
+	}
+
+	if req.MinConfidence < 0 || req.MinConfidence > 1 {
+		return ErrInvalidConfidence
+	}
+
+	return req.TradeFilters.normalize()
 }
 
 // Trade service errors
 var (
 	ErrInvalidWalletAddress = fmt.Errorf("wallet address is required and must be valid")
 	ErrInvalidLimit         = fmt.Errorf("limit must be between 1 and 50")
+	ErrInvalidSlotRange     = fmt.Errorf("invalid slot range: min_slot must not exceed max_slot")
+	ErrInvalidConfidence    = fmt.Errorf("min_confidence must be between 0 and 1")
+	ErrInvalidSide          = fmt.Errorf("invalid side: must be BUY, SELL or RECEIVE")
+	ErrInvalidCounterAsset  = fmt.Errorf("invalid counter_asset: must be hyUSD, SOL or USDC")
+	ErrInvalidAmountRange   = fmt.Errorf("invalid amount range: min_amount and max_amount must be non-negative decimals with min_amount not above max_amount")
 	ErrServiceNotReady      = fmt.Errorf("trade service is not properly initialized")
 	ErrXSOLATADerivation    = fmt.Errorf("failed to derive xSOL Associated Token Account")
 	ErrSignatureFetch       = fmt.Errorf("failed to fetch transaction signatures")
@@ -106,7 +262,7 @@ func NewTradeResponse(walletAddress string, trades []*hylo.XSOLTrade, hasMore bo
 	return &TradeResponse{
 		Trades:        trades,
 		WalletAddress: walletAddress,
-		RequestedAt:   time.Now(),
+		RequestedAt:   time.Now().UTC(),
 		Count:         len(trades),
 		Pagination: PaginationInfo{
 			HasMore:    hasMore,
@@ -0,0 +1,70 @@
+package trades
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/explorer"
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+)
+
+// benchTradeResponse builds a full page of n hyUSD-funded trades with every optional field set
+func benchTradeResponse(n int) *TradeResponse {
+	response := &TradeResponse{
+		Trades:        make([]*hylo.XSOLTrade, n),
+		Pagination:    PaginationInfo{Limit: n, Count: n, HasMore: true, NextCursor: "cursor"},
+		WalletAddress: "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g",
+		Chain:         solana.ChainSolana,
+		RequestedAt:   time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		Count:         n,
+	}
+
+	for i := range response.Trades {
+		side := hylo.TradeSideBuy
+		if i%2 == 1 {
+			side = hylo.TradeSideSell
+		}
+		trade := hylo.NewXSOLTrade(fmt.Sprintf("%088d", i), uint64(371_200_000+i), 1_760_000_000+int64(i))
+		trade.SetTradeDetails(side, 1_250_000+uint64(i), 30_000_000+uint64(i), "hyUSD")
+		trade.HistoricalPriceUSD = hylo.CalculateHistoricalXSOLPrice(trade)
+		trade.NetworkFeeSOL = "0.000005"
+		trade.ProtocolFee = "0.0045"
+		trade.Confidence = 1
+		trade.Source = "hylo_app"
+		response.Trades[i] = trade
+	}
+	return response
+}
+
+func BenchmarkTradeResponseJSON(b *testing.B) {
+	response := benchTradeResponse(50)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data, err := json.Marshal(response)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.SetBytes(int64(len(data)))
+	}
+}
+
+func TestTradeResponse_WithExplorer(t *testing.T) {
+	response := benchTradeResponse(2)
+	original := response.Trades[0].ExplorerURL
+
+	linked := response.WithExplorer(explorer.SolanaFM)
+
+	if want := "https://solana.fm/tx/" + response.Trades[0].Signature; linked.Trades[0].ExplorerURL != want {
+		t.Errorf("Expected %s, got %s", want, linked.Trades[0].ExplorerURL)
+	}
+	if want := "https://solana.fm/address/" + response.WalletAddress; linked.WalletURL != want {
+		t.Errorf("Expected wallet URL %s, got %s", want, linked.WalletURL)
+	}
+	if response.Trades[0].ExplorerURL != original || response.WalletURL != "" {
+		t.Error("Expected the original response to keep its links")
+	}
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Decimal errors
+var (
+	ErrAmountOverflow = errors.New("amount does not fit in a uint64 raw amount")
+	ErrNegativeAmount = errors.New("amount cannot be negative")
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrNotFinite      = errors.New("value must be a finite number")
+)
+
+// Decimal is an exact decimal number backed by big.Rat
+// Raw token amounts, prices and USD values are combined as Decimals so sums and products of large
+// supplies don't lose precision the way float64 does above 2^53; convert with Float64 only for output
+// The zero value is 0 and Decimals are immutable: every operation returns a new value
+type Decimal struct {
+	rat *big.Rat
+}
+
+// NewDecimalFromRaw returns rawAmount scaled down by 10^decimals
+func NewDecimalFromRaw(rawAmount uint64, decimals uint8) Decimal {
+	rat := new(big.Rat).SetFrac(new(big.Int).SetUint64(rawAmount), pow10(decimals))
+	return Decimal{rat: rat}
+}
+
+// NewDecimalFromFloat converts a float64 price or value exactly; NaN and infinities are rejected
+func NewDecimalFromFloat(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}, ErrNotFinite
+	}
+	return Decimal{rat: new(big.Rat).SetFloat64(value)}, nil
+}
+
+// DecimalFromFloat is NewDecimalFromFloat for computed prices and values; NaN and infinities become 0
+func DecimalFromFloat(value float64) Decimal {
+	d, _ := NewDecimalFromFloat(value)
+	return d
+}
+
+// ParseDecimal parses a decimal string such as "1.5", "-0.25" or "1e6"
+func ParseDecimal(value string) (Decimal, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Decimal{}, errors.New("empty decimal string")
+	}
+	// big.Rat also accepts fractions like "1/3", which are not decimal amounts
+	if strings.Contains(value, "/") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+	}
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", value)
+	}
+	return Decimal{rat: rat}, nil
+}
+
+// value returns the backing rational, treating the zero Decimal as 0
+func (d Decimal) value() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return d.rat
+}
+
+// Add returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Add(d.value(), other.value())}
+}
+
+// Sub returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Sub(d.value(), other.value())}
+}
+
+// Mul returns d * other
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{rat: new(big.Rat).Mul(d.value(), other.value())}
+}
+
+// Div returns d / other, or ErrDivisionByZero
+func (d Decimal) Div(other Decimal) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	return Decimal{rat: new(big.Rat).Quo(d.value(), other.value())}, nil
+}
+
+// Cmp compares d and other, returning -1, 0 or +1
+func (d Decimal) Cmp(other Decimal) int {
+	return d.value().Cmp(other.value())
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero reports whether d is 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 returns the nearest float64, for JSON fields and metrics; precision is lost only here
+func (d Decimal) Float64() float64 {
+	f, _ := d.value().Float64()
+	return f
+}
+
+// Raw scales d up by 10^decimals into a raw token amount, truncating extra precision
+// Returns ErrNegativeAmount or ErrAmountOverflow instead of wrapping around
+func (d Decimal) Raw(decimals uint8) (uint64, error) {
+	if d.Sign() < 0 {
+		return 0, ErrNegativeAmount
+	}
+	scaled := new(big.Rat).Mul(d.value(), new(big.Rat).SetInt(pow10(decimals)))
+	raw := new(big.Int).Quo(scaled.Num(), scaled.Denom())
+	if !raw.IsUint64() {
+		return 0, ErrAmountOverflow
+	}
+	return raw.Uint64(), nil
+}
+
+// StringFixed formats d rounded half away from zero to exactly places fractional digits
+func (d Decimal) StringFixed(places int) string {
+	return d.value().FloatString(places)
+}
+
+// Text formats d with up to places fractional digits, trimming trailing zeros
+func (d Decimal) Text(places int) string {
+	text := d.StringFixed(places)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	if text == "-0" {
+		return "0"
+	}
+	return text
+}
+
+// String formats d with up to 18 fractional digits
+func (d Decimal) String() string {
+	return d.Text(18)
+}
+
+// pow10 returns 10^n as a big.Int
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
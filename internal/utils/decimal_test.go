@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDecimalMaxUint64(t *testing.T) {
+	supply := NewDecimalFromRaw(math.MaxUint64, 9)
+	if got := supply.String(); got != "18446744073.709551615" {
+		t.Errorf("String() = %s, want 18446744073.709551615", got)
+	}
+	if got := FormatTokenAmount(math.MaxUint64, 0); got != "18446744073709551615" {
+		t.Errorf("FormatTokenAmount(MaxUint64, 0) = %s", got)
+	}
+
+	raw, err := supply.Raw(9)
+	if err != nil || raw != math.MaxUint64 {
+		t.Errorf("Raw(9) = %d, %v, want MaxUint64", raw, err)
+	}
+
+	doubled := supply.Add(supply)
+	if _, err := doubled.Raw(9); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("Expected ErrAmountOverflow for 2*MaxUint64, got %v", err)
+	}
+	if got := doubled.String(); got != "36893488147.41910323" {
+		t.Errorf("Sum lost precision: %s", got)
+	}
+
+	// 1 unit on top of a supply above 2^53 is lost in float64 but not in Decimal
+	large := NewDecimalFromRaw(1<<60, 0).Add(NewDecimalFromRaw(1, 0))
+	if got := large.String(); got != "1152921504606846977" {
+		t.Errorf("String() = %s, want 1152921504606846977", got)
+	}
+
+	if _, err := ParseDecimalAmount("18446744073709551616", 0); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("Expected ErrAmountOverflow parsing MaxUint64+1, got %v", err)
+	}
+	if raw, err := ParseDecimalAmount("18446744073.709551615", 9); err != nil || raw != math.MaxUint64 {
+		t.Errorf("ParseDecimalAmount(max, 9) = %d, %v", raw, err)
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	price, err := ParseDecimal("187.25")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	value := NewDecimalFromRaw(2_500_000_000, 9).Mul(price)
+	if got := value.StringFixed(2); got != "468.13" {
+		t.Errorf("Mul().StringFixed(2) = %s, want 468.13", got)
+	}
+
+	ratio, err := NewDecimalFromRaw(1, 0).Div(NewDecimalFromRaw(3, 0))
+	if err != nil || ratio.StringFixed(6) != "0.333333" {
+		t.Errorf("Div() = %s, %v", ratio.StringFixed(6), err)
+	}
+	if _, err := price.Div(Decimal{}); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Expected ErrDivisionByZero, got %v", err)
+	}
+
+	if _, err := NewDecimalFromRaw(1, 0).Sub(price).Raw(0); !errors.Is(err, ErrNegativeAmount) {
+		t.Errorf("Expected ErrNegativeAmount, got %v", err)
+	}
+	if got := (Decimal{}).String(); got != "0" {
+		t.Errorf("zero Decimal String() = %s, want 0", got)
+	}
+}
+
+func TestNewDecimalFromFloat(t *testing.T) {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := NewDecimalFromFloat(value); !errors.Is(err, ErrNotFinite) {
+			t.Errorf("NewDecimalFromFloat(%v) error = %v, want ErrNotFinite", value, err)
+		}
+		if !DecimalFromFloat(value).IsZero() {
+			t.Errorf("DecimalFromFloat(%v) should be 0", value)
+		}
+	}
+	if got := DecimalFromFloat(0.5).String(); got != "0.5" {
+		t.Errorf("DecimalFromFloat(0.5) = %s", got)
+	}
+}
+
+func TestParseDecimalRejectsFractions(t *testing.T) {
+	for _, input := range []string{"", "1/3", "abc"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("ParseDecimal(%q) should fail", input)
+		}
+	}
+}
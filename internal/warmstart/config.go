@@ -0,0 +1,41 @@
+package warmstart
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxAge discards snapshots older than this on startup; calibration windows go stale quickly
+const DefaultMaxAge = 6 * time.Hour
+
+// Config holds cache snapshot configuration
+type Config struct {
+	// Path is the JSON file caches are saved to on shutdown and restored from on startup; empty disables
+	Path string
+
+	// MaxAge is the oldest snapshot that is still restored
+	MaxAge time.Duration
+}
+
+// DefaultConfig returns a configuration with snapshots disabled
+func DefaultConfig() *Config {
+	return &Config{MaxAge: DefaultMaxAge}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	config.Path = strings.TrimSpace(os.Getenv("CACHE_SNAPSHOT_PATH"))
+
+	if value := os.Getenv("CACHE_SNAPSHOT_MAX_AGE_MIN"); value != "" {
+		if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+			config.MaxAge = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,91 @@
+// Package warmstart saves in-memory caches to disk on graceful shutdown and restores them on startup,
+// so a restart does not trigger a burst of RPC and DexScreener calls to rebuild them
+//
+// The last served prices and protocol state come back stale: prices refill the stale-while-revalidate cache,
+// which serves them while the first refresh runs, and the protocol state is the newest protocol snapshot again.
+// Trade watermarks (indexer cursors) live in the wallet store, which is flushed alongside it
+package warmstart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/trades"
+)
+
+// Version is bumped whenever the snapshot layout changes incompatibly; other versions are ignored
+const Version = 1
+
+// Snapshot is the on-disk cache state
+type Snapshot struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"saved_at"`
+
+	// PriceWindow is the rolling window of accepted SOL/USD prices used for bounds calibration
+	PriceWindow []float64 `json:"price_window,omitempty"`
+
+	// PriceHistory is the sampled SOL and xSOL price series behind the xSOL risk metrics
+	PriceHistory []price.PricePoint `json:"price_history,omitempty"`
+
+	// FirstActivity caches each wallet's earliest Hylo transaction, found by walking its full history
+	FirstActivity map[string]*trades.FirstActivity `json:"first_activity,omitempty"`
+
+	// Prices is the stale-while-revalidate cache's combined price response, fetched at PricesFetchedAt
+	Prices          *price.CombinedPriceResponse `json:"prices,omitempty"`
+	PricesFetchedAt time.Time                    `json:"prices_fetched_at,omitempty"`
+
+	// ProtocolState is the newest protocol snapshot, restored into a store that has none as recent
+	ProtocolState *store.ProtocolSnapshot `json:"protocol_state,omitempty"`
+}
+
+// Save writes snapshot to path atomically, stamping its version and save time
+func Save(path string, snapshot *Snapshot, now time.Time) error {
+	snapshot.Version = Version
+	snapshot.SavedAt = now.UTC()
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache snapshot directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cache snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace cache snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads the snapshot at path
+// Returns nil without error when the file does not exist, has another version or is older than maxAge
+func Load(path string, maxAge time.Duration, now time.Time) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode cache snapshot %s: %w", path, err)
+	}
+
+	if snapshot.Version != Version || now.Sub(snapshot.SavedAt) > maxAge {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
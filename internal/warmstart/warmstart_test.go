@@ -0,0 +1,82 @@
+package warmstart
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/trades"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "snapshot.json")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	first := &trades.FirstActivity{Signature: "sig", Slot: 42, Timestamp: now.AddDate(0, -1, 0), Token: "xSOL"}
+	err := Save(path, &Snapshot{
+		PriceWindow:     []float64{150, 151.5},
+		FirstActivity:   map[string]*trades.FirstActivity{"A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g": first},
+		Prices:          &price.CombinedPriceResponse{SOLUSD: 150, XSOLInUSD: 1.2},
+		PricesFetchedAt: now.Add(-time.Minute),
+		ProtocolState:   &store.ProtocolSnapshot{Slot: 100, Timestamp: now, CollateralRatio: 1.6},
+	}, now)
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	snapshot, err := Load(path, time.Hour, now.Add(time.Minute))
+	if err != nil || snapshot == nil {
+		t.Fatalf("Expected snapshot, got %v (%v)", snapshot, err)
+	}
+	if len(snapshot.PriceWindow) != 2 || snapshot.PriceWindow[1] != 151.5 {
+		t.Errorf("Expected price window restored, got %v", snapshot.PriceWindow)
+	}
+	if restored := snapshot.FirstActivity["A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"]; restored == nil || restored.Slot != 42 {
+		t.Errorf("Expected first activity restored, got %+v", restored)
+	}
+	if snapshot.Prices == nil || snapshot.Prices.XSOLInUSD != 1.2 || !snapshot.PricesFetchedAt.Equal(now.Add(-time.Minute)) {
+		t.Errorf("Expected prices restored with their fetch time, got %+v at %v", snapshot.Prices, snapshot.PricesFetchedAt)
+	}
+	if snapshot.ProtocolState == nil || snapshot.ProtocolState.Slot != 100 || snapshot.ProtocolState.CollateralRatio != 1.6 {
+		t.Errorf("Expected protocol state restored, got %+v", snapshot.ProtocolState)
+	}
+	if !snapshot.SavedAt.Equal(now) || snapshot.Version != Version {
+		t.Errorf("Expected version %d saved at %v, got %d at %v", Version, now, snapshot.Version, snapshot.SavedAt)
+	}
+}
+
+func TestLoadSkipsMissingStaleAndForeignSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if snapshot, err := Load(filepath.Join(dir, "missing.json"), time.Hour, now); snapshot != nil || err != nil {
+		t.Errorf("Expected nothing for a missing file, got %v (%v)", snapshot, err)
+	}
+
+	stale := filepath.Join(dir, "stale.json")
+	if err := Save(stale, &Snapshot{PriceWindow: []float64{150}}, now.Add(-2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot, err := Load(stale, time.Hour, now); snapshot != nil || err != nil {
+		t.Errorf("Expected stale snapshot to be skipped, got %v (%v)", snapshot, err)
+	}
+
+	foreign := filepath.Join(dir, "foreign.json")
+	if err := os.WriteFile(foreign, []byte(`{"version":99,"saved_at":"2025-01-01T12:00:00Z"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot, err := Load(foreign, time.Hour, now); snapshot != nil || err != nil {
+		t.Errorf("Expected other versions to be skipped, got %v (%v)", snapshot, err)
+	}
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte(`{`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(corrupt, time.Hour, now); err == nil {
+		t.Error("Expected error for a corrupt snapshot")
+	}
+}
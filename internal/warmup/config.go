@@ -0,0 +1,59 @@
+package warmup
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default warm-up settings
+const (
+	DefaultConcurrency = 4                // Wallets warmed in parallel
+	DefaultBudget      = 30 * time.Second // Time after which the server reports ready regardless
+)
+
+// Config holds startup warm-up configuration
+type Config struct {
+	// Enabled preloads caches for every tracked wallet before /ready reports ready
+	Enabled bool
+
+	// Concurrency bounds how many wallets are warmed at once
+	Concurrency int
+
+	// Budget caps the whole warm-up; wallets not finished by then are left cold
+	Budget time.Duration
+}
+
+// DefaultConfig returns a configuration with warm-up disabled
+func DefaultConfig() *Config {
+	return &Config{
+		Concurrency: DefaultConcurrency,
+		Budget:      DefaultBudget,
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("WARMUP_ENABLED"); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			config.Enabled = enabled
+		}
+	}
+
+	if value := os.Getenv("WARMUP_CONCURRENCY"); value != "" {
+		if concurrency, err := strconv.Atoi(value); err == nil && concurrency > 0 {
+			config.Concurrency = concurrency
+		}
+	}
+
+	if value := os.Getenv("WARMUP_BUDGET_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.Budget = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return config
+}
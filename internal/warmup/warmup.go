@@ -0,0 +1,75 @@
+// Package warmup preloads per-wallet caches on startup, so the first requests after a deploy do not all
+// pay cold-cache RPC latency. The server reports ready once the warm-up finished or ran out of time
+package warmup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Warmer preloads the caches of one wallet
+type Warmer func(ctx context.Context, wallet string) error
+
+// Result summarizes a warm-up run
+type Result struct {
+	Wallets int `json:"wallets"`
+	Warmed  int `json:"warmed"`
+	Failed  int `json:"failed"`
+	// Skipped wallets were not finished within the time budget
+	Skipped        int       `json:"skipped"`
+	BudgetExceeded bool      `json:"budget_exceeded"`
+	StartedAt      time.Time `json:"started_at"`
+	FinishedAt     time.Time `json:"finished_at"`
+	DurationMS     int64     `json:"duration_ms"`
+}
+
+// Run warms every wallet with at most config.Concurrency in flight and stops at config.Budget
+// Failures only leave a wallet cold, so they are counted rather than returned
+func Run(ctx context.Context, config *Config, wallets []string, warm Warmer) Result {
+	result := Result{Wallets: len(wallets), StartedAt: time.Now().UTC()}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Budget)
+	defer cancel()
+
+	var warmed, failed atomic.Int64
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < max(config.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for wallet := range queue {
+				err := warm(ctx, wallet)
+				switch {
+				case err == nil:
+					warmed.Add(1)
+				case ctx.Err() == nil:
+					failed.Add(1)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, wallet := range wallets {
+		select {
+		case queue <- wallet:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	// Wallets cut off by the budget or shutdown count as skipped rather than failed
+	result.BudgetExceeded = errors.Is(ctx.Err(), context.DeadlineExceeded)
+	result.Warmed = int(warmed.Load())
+	result.Failed = int(failed.Load())
+	result.Skipped = result.Wallets - result.Warmed - result.Failed
+	result.FinishedAt = time.Now().UTC()
+	result.DurationMS = result.FinishedAt.Sub(result.StartedAt).Milliseconds()
+	return result
+}
@@ -0,0 +1,51 @@
+package warmup
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	warm := func(ctx context.Context, wallet string) error {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := peak.Load()
+			if current <= observed || peak.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if wallet == "bad" {
+			return errors.New("rpc unavailable")
+		}
+		return nil
+	}
+
+	result := Run(context.Background(), &Config{Concurrency: 2, Budget: time.Second}, []string{"a", "b", "bad", "c", "d"}, warm)
+	if result.Wallets != 5 || result.Warmed != 4 || result.Failed != 1 || result.Skipped != 0 || result.BudgetExceeded {
+		t.Errorf("Expected 4 warmed and 1 failed, got %+v", result)
+	}
+	if peak.Load() > 2 {
+		t.Errorf("Expected at most 2 wallets in flight, got %d", peak.Load())
+	}
+}
+
+func TestRunBudget(t *testing.T) {
+	warm := func(ctx context.Context, wallet string) error {
+		if wallet == "slow" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	result := Run(context.Background(), &Config{Concurrency: 1, Budget: 20 * time.Millisecond}, []string{"a", "slow", "b"}, warm)
+	if !result.BudgetExceeded || result.Warmed != 1 || result.Failed != 0 || result.Skipped != 2 {
+		t.Errorf("Expected the slow wallet and the one behind it skipped, got %+v", result)
+	}
+}
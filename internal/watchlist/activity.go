@@ -0,0 +1,63 @@
+package watchlist
+
+import (
+	"fmt"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// Activity window defaults
+const (
+	DefaultActivityDays = 90
+	MaxActivityDays     = 365
+)
+
+// WalletActivity counts a wallet's indexed Hylo interactions by weekday and hour for heatmaps
+// Weekdays and hours are local to Timezone; weekday indexes follow time.Weekday: 0 is Sunday
+type WalletActivity struct {
+	Wallet     string    `json:"wallet"`
+	Days       int       `json:"days"`
+	Timezone   string    `json:"timezone"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	TotalCount int       `json:"total_count"`
+	// Heatmap[weekday][hour] is the number of interactions in that hour of that weekday
+	Heatmap     [7][24]int `json:"heatmap"`
+	ByWeekday   [7]int     `json:"by_weekday"`
+	ByHour      [24]int    `json:"by_hour"`
+	GeneratedAt time.Time  `json:"generated_at"`
+}
+
+// SummarizeActivity buckets the wallet's indexed transactions from the last days days into the heatmap
+// Failed transactions count too: they are still interactions with the protocol
+func SummarizeActivity(st store.Store, wallet string, days int, loc *time.Location, now time.Time) (*WalletActivity, error) {
+	if days < 1 || days > MaxActivityDays {
+		return nil, fmt.Errorf("invalid days %d: must be between 1 and %d", days, MaxActivityDays)
+	}
+
+	now = now.UTC()
+	activity := &WalletActivity{
+		Wallet:      wallet,
+		Days:        days,
+		Timezone:    loc.String(),
+		From:        now.AddDate(0, 0, -days),
+		To:          now,
+		GeneratedAt: now,
+	}
+
+	for _, record := range st.TradesSince(wallet, activity.From) {
+		at := record.Trade.Timestamp.In(loc)
+		if at.After(now) {
+			continue
+		}
+
+		weekday, hour := at.Weekday(), at.Hour()
+		activity.Heatmap[weekday][hour]++
+		activity.ByWeekday[weekday]++
+		activity.ByHour[hour]++
+		activity.TotalCount++
+	}
+
+	return activity, nil
+}
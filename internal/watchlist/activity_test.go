@@ -0,0 +1,51 @@
+package watchlist
+
+import (
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func TestSummarizeActivity(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	addTrade(t, st, walletA, "sig1", hylo.TradeSideBuy, 100, time.Date(2025, 1, 6, 9, 15, 0, 0, time.UTC), 1_000_000)  // Monday 09h
+	addTrade(t, st, walletA, "sig2", hylo.TradeSideSell, 200, time.Date(2025, 1, 6, 9, 45, 0, 0, time.UTC), 1_000_000) // Monday 09h
+	addTrade(t, st, walletA, "sig3", hylo.TradeSideBuy, 300, time.Date(2025, 1, 8, 11, 0, 0, 0, time.UTC), 1_000_000)  // Wednesday 11h
+	addTrade(t, st, walletA, "sig0", hylo.TradeSideBuy, 50, now.AddDate(0, 0, -10), 1_000_000)                         // Outside the window
+	addTrade(t, st, walletB, "sig4", hylo.TradeSideBuy, 400, now.Add(-time.Hour), 1_000_000)
+
+	activity, err := SummarizeActivity(st, walletA, 7, time.UTC, now)
+	if err != nil {
+		t.Fatalf("SummarizeActivity failed: %v", err)
+	}
+
+	if activity.TotalCount != 3 {
+		t.Errorf("Expected 3 interactions in the window, got %d", activity.TotalCount)
+	}
+	if activity.Heatmap[time.Monday][9] != 2 || activity.Heatmap[time.Wednesday][11] != 1 {
+		t.Errorf("Expected Monday 09h = 2 and Wednesday 11h = 1, got %v", activity.Heatmap)
+	}
+	if activity.ByWeekday[time.Monday] != 2 || activity.ByHour[9] != 2 || activity.ByHour[11] != 1 {
+		t.Errorf("Unexpected marginals: weekdays %v hours %v", activity.ByWeekday, activity.ByHour)
+	}
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	local, err := SummarizeActivity(st, walletA, 7, tokyo, now)
+	if err != nil {
+		t.Fatalf("SummarizeActivity failed: %v", err)
+	}
+	if local.Timezone != "Asia/Tokyo" || local.Heatmap[time.Monday][18] != 2 || local.Heatmap[time.Wednesday][20] != 1 {
+		t.Errorf("Expected hours shifted to Tokyo time, got %v", local.Heatmap)
+	}
+
+	if _, err := SummarizeActivity(st, walletA, 0, time.UTC, now); err == nil {
+		t.Error("Expected error for zero days")
+	}
+	if _, err := SummarizeActivity(st, walletA, MaxActivityDays+1, time.UTC, now); err == nil {
+		t.Error("Expected error for window above the maximum")
+	}
+}
@@ -0,0 +1,168 @@
+package watchlist
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// Aggregation bucket sizes
+const (
+	BucketDay  = "day"
+	BucketWeek = "week" // ISO weeks, starting Monday 00:00 in the aggregate's time zone
+)
+
+// Aggregation window defaults
+const (
+	DefaultAggregateDays = 30
+	MaxAggregateDays     = 365
+)
+
+// TradeAggregate is a wallet's indexed trade history bucketed for charting; buckets start at midnight in Timezone
+type TradeAggregate struct {
+	Wallet      string        `json:"wallet"`
+	Bucket      string        `json:"bucket"`
+	Timezone    string        `json:"timezone"`
+	From        time.Time     `json:"from"` // Start of the first bucket
+	To          time.Time     `json:"to"`   // End of the window (exclusive)
+	Buckets     []TradeBucket `json:"buckets"`
+	GeneratedAt time.Time     `json:"generated_at"`
+}
+
+// TradeBucket holds the totals of the trades whose block time falls inside [Start, Start+bucket)
+type TradeBucket struct {
+	Start          time.Time `json:"start"`
+	TradeCount     int       `json:"trade_count"`
+	BuyCount       int       `json:"buy_count"`
+	SellCount      int       `json:"sell_count"`
+	BuyVolumeXSOL  float64   `json:"buy_volume_xsol"`
+	SellVolumeXSOL float64   `json:"sell_volume_xsol"`
+	NetFlowXSOL    float64   `json:"net_flow_xsol"` // Buy minus sell volume
+	// VWAPUSD is the volume-weighted xSOL price over trades with a known USD price (stablecoin
+	// counter assets); nil when no trade in the bucket could be priced
+	VWAPUSD *float64 `json:"vwap_usd"`
+
+	pricedVolume float64
+	pricedValue  float64
+}
+
+// ValidBucket reports whether bucket is a supported aggregation size
+func ValidBucket(bucket string) bool {
+	return bucket == BucketDay || bucket == BucketWeek
+}
+
+// AggregateTrades buckets a wallet's stored trades over the last days days, oldest bucket first
+// Buckets start at midnight in loc, so a day's volume matches the calendar day of users in that zone
+// Every bucket in the window is returned, including empty ones, so charts need no gap filling
+func AggregateTrades(st store.Store, wallet, bucket string, days int, loc *time.Location, now time.Time) (*TradeAggregate, error) {
+	if !ValidBucket(bucket) {
+		return nil, fmt.Errorf("invalid bucket %q: must be %s or %s", bucket, BucketDay, BucketWeek)
+	}
+	if days < 1 || days > MaxAggregateDays {
+		return nil, fmt.Errorf("invalid days %d: must be between 1 and %d", days, MaxAggregateDays)
+	}
+
+	now = now.In(loc)
+	from := bucketStart(now.AddDate(0, 0, -days+1), bucket)
+
+	aggregate := &TradeAggregate{
+		Wallet:      wallet,
+		Bucket:      bucket,
+		Timezone:    loc.String(),
+		From:        from,
+		To:          now,
+		Buckets:     make([]TradeBucket, 0),
+		GeneratedAt: now,
+	}
+
+	index := make(map[int64]int) // Bucket start in Unix seconds -> position
+	for start := from; !start.After(now); start = nextBucket(start, bucket) {
+		index[start.Unix()] = len(aggregate.Buckets)
+		aggregate.Buckets = append(aggregate.Buckets, TradeBucket{Start: start})
+	}
+
+	for _, record := range st.TradesSince(wallet, from) {
+		trade := record.Trade
+		if trade.Status == hylo.TradeStatusFailed || trade.Timestamp.After(now) {
+			continue
+		}
+		i, ok := index[bucketStart(trade.Timestamp.In(loc), bucket).Unix()]
+		if !ok {
+			continue
+		}
+		aggregate.Buckets[i].add(&trade)
+	}
+
+	for i := range aggregate.Buckets {
+		aggregate.Buckets[i].finish()
+	}
+
+	return aggregate, nil
+}
+
+func (b *TradeBucket) add(trade *hylo.XSOLTrade) {
+	amount, err := strconv.ParseFloat(trade.XSOLAmount, 64)
+	if err != nil {
+		return
+	}
+
+	b.TradeCount++
+	switch trade.Side {
+	case hylo.TradeSideBuy:
+		b.BuyCount++
+		b.BuyVolumeXSOL += amount
+	case hylo.TradeSideSell:
+		b.SellCount++
+		b.SellVolumeXSOL += amount
+	}
+
+	if price, ok := tradePriceUSD(trade); ok {
+		b.pricedVolume += amount
+		b.pricedValue += amount * price
+	}
+}
+
+func (b *TradeBucket) finish() {
+	b.NetFlowXSOL = b.BuyVolumeXSOL - b.SellVolumeXSOL
+	if b.pricedVolume > 0 {
+		vwap := b.pricedValue / b.pricedVolume
+		b.VWAPUSD = &vwap
+	}
+}
+
+// tradePriceUSD returns the trade's historical xSOL price, deriving it from stablecoin amounts when not stored
+func tradePriceUSD(trade *hylo.XSOLTrade) (float64, bool) {
+	historical := trade.HistoricalPriceUSD
+	if historical == nil {
+		historical = hylo.CalculateHistoricalXSOLPrice(trade)
+	}
+	if historical == nil {
+		return 0, false
+	}
+
+	price, err := strconv.ParseFloat(*historical, 64)
+	if err != nil || price <= 0 {
+		return 0, false
+	}
+	return price, true
+}
+
+// bucketStart truncates t to the start of its day or ISO week in t's location
+func bucketStart(t time.Time, bucket string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if bucket == BucketWeek {
+		offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+func nextBucket(start time.Time, bucket string) time.Time {
+	if bucket == BucketWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
@@ -0,0 +1,127 @@
+package watchlist
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func addStableTrade(t *testing.T, st store.Store, wallet, signature, side string, slot uint64, at time.Time, xsolRaw, usdRaw uint64) {
+	t.Helper()
+
+	trade := hylo.NewXSOLTrade(signature, slot, at.Unix())
+	trade.SetTradeDetails(side, xsolRaw, usdRaw, "hyUSD")
+	if _, err := st.AddTrades([]store.TradeRecord{store.NewTradeRecord(wallet, trade)}); err != nil {
+		t.Fatalf("failed to add trade: %v", err)
+	}
+}
+
+func TestAggregateTradesByDay(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	addStableTrade(t, st, walletA, "sig1", hylo.TradeSideBuy, 400, now.Add(-2*time.Hour), 10_000_000, 20_000_000)
+	addStableTrade(t, st, walletA, "sig2", hylo.TradeSideBuy, 450, now.Add(-time.Hour), 30_000_000, 120_000_000)
+	addTrade(t, st, walletA, "sig3", hylo.TradeSideSell, 300, now.Add(-24*time.Hour), 5_000_000)
+	addTrade(t, st, walletA, "sig4", hylo.TradeSideBuy, 100, now.AddDate(0, 0, -10), 99_000_000) // Outside the window
+	addTrade(t, st, walletB, "sig5", hylo.TradeSideBuy, 500, now.Add(-time.Hour), 7_000_000)
+
+	aggregate, err := AggregateTrades(st, walletA, BucketDay, 3, time.UTC, now)
+	if err != nil {
+		t.Fatalf("AggregateTrades failed: %v", err)
+	}
+
+	if len(aggregate.Buckets) != 3 {
+		t.Fatalf("Expected 3 daily buckets, got %d", len(aggregate.Buckets))
+	}
+	if !aggregate.From.Equal(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected window to start on Jan 6, got %v", aggregate.From)
+	}
+
+	empty := aggregate.Buckets[0]
+	if empty.TradeCount != 0 || empty.VWAPUSD != nil {
+		t.Errorf("Expected empty first bucket, got %+v", empty)
+	}
+
+	sells := aggregate.Buckets[1]
+	if sells.SellCount != 1 || sells.SellVolumeXSOL != 5 || sells.NetFlowXSOL != -5 {
+		t.Errorf("Expected one 5 xSOL sell on Jan 7, got %+v", sells)
+	}
+	if sells.VWAPUSD != nil {
+		t.Errorf("Expected no VWAP for a SOL-priced trade, got %v", *sells.VWAPUSD)
+	}
+
+	buys := aggregate.Buckets[2]
+	if buys.BuyCount != 2 || buys.BuyVolumeXSOL != 40 || buys.NetFlowXSOL != 40 {
+		t.Errorf("Expected two buys totalling 40 xSOL on Jan 8, got %+v", buys)
+	}
+	if buys.VWAPUSD == nil || math.Abs(*buys.VWAPUSD-3.5) > 1e-9 {
+		t.Errorf("Expected VWAP $3.50, got %v", buys.VWAPUSD)
+	}
+}
+
+func TestAggregateTradesByWeek(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	addTrade(t, st, walletA, "sig1", hylo.TradeSideBuy, 1, time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC), 10_000_000)
+	addTrade(t, st, walletA, "sig2", hylo.TradeSideSell, 2, time.Date(2025, 1, 5, 23, 0, 0, 0, time.UTC), 4_000_000)
+
+	aggregate, err := AggregateTrades(st, walletA, BucketWeek, 7, time.UTC, now)
+	if err != nil {
+		t.Fatalf("AggregateTrades failed: %v", err)
+	}
+
+	if len(aggregate.Buckets) != 2 {
+		t.Fatalf("Expected 2 weekly buckets, got %d", len(aggregate.Buckets))
+	}
+	if !aggregate.Buckets[0].Start.Equal(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected first week to start Monday Dec 30, got %v", aggregate.Buckets[0].Start)
+	}
+	if aggregate.Buckets[0].SellCount != 1 || aggregate.Buckets[1].BuyCount != 1 {
+		t.Errorf("Expected Sunday sell and Monday buy in separate weeks, got %+v", aggregate.Buckets)
+	}
+}
+
+func TestAggregateTradesInTimezone(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 8, 12, 0, 0, 0, time.UTC) // 20:00 in Singapore
+	singapore, err := ParseTimezone("+08:00")
+	if err != nil {
+		t.Fatalf("ParseTimezone failed: %v", err)
+	}
+
+	// Both trades fall on Jan 7 UTC, but on either side of midnight in Singapore
+	addTrade(t, st, walletA, "sig1", hylo.TradeSideBuy, 1, time.Date(2025, 1, 7, 15, 0, 0, 0, time.UTC), 1_000_000)
+	addTrade(t, st, walletA, "sig2", hylo.TradeSideBuy, 2, time.Date(2025, 1, 7, 17, 0, 0, 0, time.UTC), 2_000_000)
+
+	aggregate, err := AggregateTrades(st, walletA, BucketDay, 2, singapore, now)
+	if err != nil {
+		t.Fatalf("AggregateTrades failed: %v", err)
+	}
+
+	if aggregate.Timezone != "+08:00" || !aggregate.From.Equal(time.Date(2025, 1, 6, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the window to start at midnight Jan 7 in +08:00, got %v in %s", aggregate.From, aggregate.Timezone)
+	}
+	if len(aggregate.Buckets) != 2 || aggregate.Buckets[0].BuyVolumeXSOL != 1 || aggregate.Buckets[1].BuyVolumeXSOL != 2 {
+		t.Errorf("Expected one trade on each local day, got %+v", aggregate.Buckets)
+	}
+}
+
+func TestAggregateTradesValidation(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Now()
+
+	if _, err := AggregateTrades(st, walletA, "month", 30, time.UTC, now); err == nil {
+		t.Error("Expected error for unsupported bucket")
+	}
+	if _, err := AggregateTrades(st, walletA, BucketDay, 0, time.UTC, now); err == nil {
+		t.Error("Expected error for zero days")
+	}
+	if _, err := AggregateTrades(st, walletA, BucketDay, MaxAggregateDays+1, time.UTC, now); err == nil {
+		t.Error("Expected error for window above the maximum")
+	}
+}
@@ -0,0 +1,188 @@
+package watchlist
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// ErrNoSnapshot is returned when no stored balance snapshot covers the requested point
+var ErrNoSnapshot = errors.New("no balance snapshot at or before the requested point")
+
+// tokenDecimals maps the symbols that can appear in balance snapshots to their precision
+var tokenDecimals = map[string]uint8{
+	tokens.HyUSDSymbol:   tokens.HyUSDDecimals,
+	tokens.SHyUSDSymbol:  tokens.SHyUSDDecimals,
+	tokens.XSOLSymbol:    tokens.XSOLDecimals,
+	tokens.USDCSymbol:    tokens.USDCDecimals,
+	tokens.JitoSOLSymbol: tokens.JitoSOLDecimals,
+}
+
+// Since is the point a balance delta is measured from: a slot when Slot is non-zero, otherwise Time
+type Since struct {
+	Time time.Time
+	Slot uint64
+}
+
+// ParseSince accepts a slot number or an RFC 3339 timestamp
+func ParseSince(value string) (Since, error) {
+	if slot, err := strconv.ParseUint(value, 10, 64); err == nil {
+		if slot == 0 {
+			return Since{}, fmt.Errorf("invalid since %q: slot must be positive", value)
+		}
+		return Since{Slot: slot}, nil
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return Since{}, fmt.Errorf("invalid since %q: must be a slot number or an RFC 3339 timestamp", value)
+	}
+	return Since{Time: at.UTC()}, nil
+}
+
+// BalanceDelta reports how a tracked wallet's holdings changed between two stored snapshots
+type BalanceDelta struct {
+	Wallet string `json:"wallet"`
+	// From is the newest snapshot at or before the requested point; To is the latest snapshot
+	From DeltaPoint `json:"from"`
+	To   DeltaPoint `json:"to"`
+	// Tokens lists every snapshotted token whose balance or trade flow changed, keyed by symbol
+	// Trade legs in assets the snapshots do not hold (e.g. SOL) are left out
+	Tokens      map[string]TokenDelta `json:"tokens"`
+	ChangeUSD   float64               `json:"change_usd"`
+	TradeCount  int                   `json:"trade_count"` // Indexed trades between the two snapshots
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// DeltaPoint identifies a snapshot a delta is measured against
+type DeltaPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Slot      uint64    `json:"slot"`
+	TotalUSD  float64   `json:"total_usd"`
+}
+
+// TokenDelta is the change in one token's balance; amounts are signed decimal strings
+// FromTrades is the part explained by indexed trades, Other the remainder (transfers, and any
+// activity the trade index does not cover)
+type TokenDelta struct {
+	Previous   string  `json:"previous"`
+	Current    string  `json:"current"`
+	Change     string  `json:"change"`
+	ChangeUSD  float64 `json:"change_usd"`
+	FromTrades string  `json:"from_trades"`
+	Other      string  `json:"other"`
+}
+
+// ComputeBalanceDelta diffs the wallet's latest snapshot against the one covering since and attributes
+// the change to the indexed trades in between
+// Returns ErrNoSnapshot when the wallet has no snapshot at or before since
+func ComputeBalanceDelta(st store.Store, wallet string, since Since, now time.Time) (*BalanceDelta, error) {
+	var from *store.BalanceSnapshot
+	var ok bool
+	if since.Slot != 0 {
+		from, ok = st.SnapshotAtSlot(wallet, since.Slot)
+	} else {
+		from, ok = st.SnapshotAt(wallet, since.Time)
+	}
+	if !ok {
+		return nil, ErrNoSnapshot
+	}
+
+	to, ok := st.LatestSnapshot(wallet)
+	if !ok {
+		return nil, ErrNoSnapshot
+	}
+
+	delta := &BalanceDelta{
+		Wallet:      wallet,
+		From:        DeltaPoint{Timestamp: from.Timestamp, Slot: from.Slot, TotalUSD: from.TotalUSD},
+		To:          DeltaPoint{Timestamp: to.Timestamp, Slot: to.Slot, TotalUSD: to.TotalUSD},
+		Tokens:      make(map[string]TokenDelta),
+		ChangeUSD:   to.TotalUSD - from.TotalUSD,
+		GeneratedAt: now.UTC(),
+	}
+
+	flows := make(map[string]int64)
+	for _, record := range st.TradesSince(wallet, from.Timestamp) {
+		if !between(record.Trade, from, to) {
+			continue
+		}
+		delta.TradeCount++
+		addTradeFlows(flows, record)
+	}
+
+	symbols := make(map[string]bool)
+	for symbol := range from.Holdings {
+		symbols[symbol] = true
+	}
+	for symbol := range to.Holdings {
+		symbols[symbol] = true
+	}
+
+	for symbol := range symbols {
+		decimals, known := tokenDecimals[symbol]
+		if !known {
+			continue
+		}
+
+		previous, current := from.Holdings[symbol], to.Holdings[symbol]
+		change := int64(current.RawAmount) - int64(previous.RawAmount)
+		if change == 0 && flows[symbol] == 0 {
+			continue
+		}
+
+		delta.Tokens[symbol] = TokenDelta{
+			Previous:   utils.FormatTokenAmount(previous.RawAmount, decimals),
+			Current:    utils.FormatTokenAmount(current.RawAmount, decimals),
+			Change:     formatSigned(change, decimals),
+			ChangeUSD:  current.USDValue - previous.USDValue,
+			FromTrades: formatSigned(flows[symbol], decimals),
+			Other:      formatSigned(change-flows[symbol], decimals),
+		}
+	}
+
+	return delta, nil
+}
+
+// between reports whether a trade landed after the from snapshot and no later than the to snapshot
+// Slots are compared when both snapshots carry one, block times otherwise
+func between(trade hylo.XSOLTrade, from, to *store.BalanceSnapshot) bool {
+	if from.Slot != 0 && to.Slot != 0 {
+		return trade.Slot > from.Slot && trade.Slot <= to.Slot
+	}
+	return trade.Timestamp.After(from.Timestamp) && !trade.Timestamp.After(to.Timestamp)
+}
+
+// addTradeFlows adds a trade's signed raw balance effect per token symbol; failed trades move nothing
+func addTradeFlows(flows map[string]int64, record store.TradeRecord) {
+	trade := record.Trade
+	if trade.Status == hylo.TradeStatusFailed {
+		return
+	}
+
+	xsol, counter := int64(record.XSOLAmountRaw), int64(record.CounterAmountRaw)
+	switch trade.Side {
+	case hylo.TradeSideBuy:
+		flows[tokens.XSOLSymbol] += xsol
+		flows[trade.CounterAsset] -= counter
+	case hylo.TradeSideSell:
+		flows[tokens.XSOLSymbol] -= xsol
+		flows[trade.CounterAsset] += counter
+	case hylo.TradeSideReceive:
+		flows[tokens.XSOLSymbol] += xsol
+	}
+}
+
+// formatSigned formats a signed raw amount as a decimal string with a leading "-" when negative
+func formatSigned(raw int64, decimals uint8) string {
+	if raw < 0 {
+		return "-" + utils.FormatTokenAmount(uint64(-raw), decimals)
+	}
+	return utils.FormatTokenAmount(uint64(raw), decimals)
+}
@@ -0,0 +1,114 @@
+package watchlist
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func TestComputeBalanceDelta(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	base := now.Add(-6 * time.Hour)
+
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: base, Slot: 100, TotalUSD: 150,
+		Holdings: map[string]store.Holding{
+			"xSOL":  {RawAmount: 100_000_000, USDValue: 50},
+			"hyUSD": {RawAmount: 100_000_000, USDValue: 100},
+		},
+	})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: base.Add(5 * time.Hour), Slot: 500, TotalUSD: 145,
+		Holdings: map[string]store.Holding{
+			"xSOL":   {RawAmount: 130_000_000, USDValue: 65},
+			"sHYUSD": {RawAmount: 20_000_000, USDValue: 20},
+			"hyUSD":  {RawAmount: 60_000_000, USDValue: 60},
+		},
+	})
+
+	addStableTrade(t, st, walletA, "sig1", hylo.TradeSideBuy, 200, base.Add(time.Hour), 40_000_000, 20_000_000)
+	addTrade(t, st, walletA, "sig2", hylo.TradeSideSell, 300, base.Add(2*time.Hour), 10_000_000)       // SOL counter asset
+	addStableTrade(t, st, walletA, "sig0", hylo.TradeSideBuy, 90, base.Add(-time.Hour), 99_000_000, 1) // Before the baseline
+	addStableTrade(t, st, walletA, "sig9", hylo.TradeSideBuy, 600, base.Add(5*time.Hour), 1, 1)        // After the latest snapshot
+
+	delta, err := ComputeBalanceDelta(st, walletA, Since{Time: base.Add(30 * time.Minute)}, now)
+	if err != nil {
+		t.Fatalf("ComputeBalanceDelta failed: %v", err)
+	}
+
+	if delta.From.Slot != 100 || delta.To.Slot != 500 {
+		t.Errorf("Expected delta between slots 100 and 500, got %d and %d", delta.From.Slot, delta.To.Slot)
+	}
+	if delta.TradeCount != 2 {
+		t.Errorf("Expected 2 trades between the snapshots, got %d", delta.TradeCount)
+	}
+	if math.Abs(delta.ChangeUSD+5) > 1e-9 {
+		t.Errorf("Expected -$5 total change, got %f", delta.ChangeUSD)
+	}
+
+	xsol := delta.Tokens["xSOL"]
+	if xsol.Change != "30" || xsol.FromTrades != "30" || xsol.Other != "0" {
+		t.Errorf("Expected +30 xSOL fully explained by trades, got %+v", xsol)
+	}
+	if math.Abs(xsol.ChangeUSD-15) > 1e-9 {
+		t.Errorf("Expected +$15 xSOL value change, got %f", xsol.ChangeUSD)
+	}
+
+	hyusd := delta.Tokens["hyUSD"]
+	if hyusd.Previous != "100" || hyusd.Current != "60" || hyusd.Change != "-40" || hyusd.FromTrades != "-20" || hyusd.Other != "-20" {
+		t.Errorf("Expected -40 hyUSD with -20 from trades, got %+v", hyusd)
+	}
+
+	if shyusd := delta.Tokens["sHYUSD"]; shyusd.Previous != "0" || shyusd.Change != "20" || shyusd.Other != "20" {
+		t.Errorf("Expected new sHYUSD position attributed to other activity, got %+v", shyusd)
+	}
+	if _, ok := delta.Tokens["SOL"]; ok {
+		t.Error("Expected SOL counter-asset legs to be left out")
+	}
+}
+
+func TestComputeBalanceDeltaSinceSlot(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	holding := map[string]store.Holding{"xSOL": {RawAmount: 1_000_000}}
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletA, Timestamp: now.Add(-2 * time.Hour), Slot: 100, Holdings: holding})
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletA, Timestamp: now.Add(-time.Hour), Slot: 200, Holdings: holding})
+
+	delta, err := ComputeBalanceDelta(st, walletA, Since{Slot: 150}, now)
+	if err != nil {
+		t.Fatalf("ComputeBalanceDelta failed: %v", err)
+	}
+	if delta.From.Slot != 100 {
+		t.Errorf("Expected baseline at slot 100, got %d", delta.From.Slot)
+	}
+	if len(delta.Tokens) != 0 {
+		t.Errorf("Expected unchanged holdings to be omitted, got %+v", delta.Tokens)
+	}
+
+	if _, err := ComputeBalanceDelta(st, walletA, Since{Slot: 50}, now); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected ErrNoSnapshot before the first snapshot, got %v", err)
+	}
+	if _, err := ComputeBalanceDelta(st, walletB, Since{Time: now}, now); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected ErrNoSnapshot for a wallet without snapshots, got %v", err)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	if since, err := ParseSince("123456"); err != nil || since.Slot != 123456 {
+		t.Errorf("Expected slot 123456, got %+v (%v)", since, err)
+	}
+	if since, err := ParseSince("2025-01-02T03:04:05+01:00"); err != nil || !since.Time.Equal(time.Date(2025, 1, 2, 2, 4, 5, 0, time.UTC)) {
+		t.Errorf("Expected UTC timestamp, got %+v (%v)", since, err)
+	}
+	for _, value := range []string{"", "0", "yesterday", "-5"} {
+		if _, err := ParseSince(value); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
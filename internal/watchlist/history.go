@@ -0,0 +1,118 @@
+package watchlist
+
+import (
+	"time"
+
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// Reconstruction directions
+const (
+	DirectionForward  = "forward"
+	DirectionBackward = "backward"
+)
+
+// ReconstructBalances rebuilds a tracked wallet's balances at a past slot or time without archival RPC
+// The newest snapshot at or before the point is rolled forward by the indexed trades after it; when the
+// point predates every snapshot, the latest snapshot is rolled back by the trades after the point instead.
+// Only indexed trades are replayed, so transfers the trade index does not cover are not reflected.
+// Tokens are valued at the base snapshot's prices; sHYUSD pool claims are not reconstructed
+// Returns ErrNoSnapshot when the wallet has no snapshot
+func ReconstructBalances(st store.Store, tokenConfig *tokens.Config, wallet solana.Address, at Since) (*tokens.WalletBalances, error) {
+	direction := DirectionForward
+	var base *store.BalanceSnapshot
+	var ok bool
+	if at.Slot != 0 {
+		base, ok = st.SnapshotAtSlot(wallet.String(), at.Slot)
+	} else {
+		base, ok = st.SnapshotAt(wallet.String(), at.Time)
+	}
+	if !ok {
+		direction = DirectionBackward
+		if base, ok = st.LatestSnapshot(wallet.String()); !ok {
+			return nil, ErrNoSnapshot
+		}
+	}
+
+	reconstruction := &tokens.Reconstruction{BaseSlot: base.Slot, BaseTimestamp: base.Timestamp, Direction: direction}
+	flows := make(map[string]int64)
+	for _, record := range st.TradesSince(wallet.String(), time.Time{}) {
+		var replay bool
+		if direction == DirectionForward {
+			replay = tradeAfter(record, base.Slot, base.Timestamp) && !tradeAfter(record, at.Slot, at.Time)
+		} else {
+			replay = tradeAfter(record, at.Slot, at.Time) && !tradeAfter(record, base.Slot, base.Timestamp)
+		}
+		if !replay {
+			continue
+		}
+		reconstruction.ReplayedTrades++
+		addTradeFlows(flows, record)
+	}
+
+	balances := tokens.NewWalletBalances(wallet, solana.Slot(at.Slot))
+	balances.UpdatedAt = at.Time
+	if at.Time.IsZero() {
+		balances.UpdatedAt = base.Timestamp
+	}
+	balances.Reconstructed = reconstruction
+
+	symbols := make(map[string]bool)
+	for symbol := range base.Holdings {
+		symbols[symbol] = true
+	}
+	for symbol := range flows {
+		symbols[symbol] = true
+	}
+
+	for symbol := range symbols {
+		holding := base.Holdings[symbol]
+		flow := flows[symbol]
+		if direction == DirectionBackward {
+			flow = -flow
+		}
+		// Untracked transfers can make a replayed balance negative; floor it at zero
+		raw := max(int64(holding.RawAmount)+flow, 0)
+
+		balance := tokenConfig.NewTokenBalanceBySymbol(symbol, uint64(raw))
+		if balance == nil {
+			continue
+		}
+		if unitUSD, known := unitPriceUSD(symbol, holding, base); known {
+			balance.SetUSDValue(utils.NewDecimalFromRaw(uint64(raw), balance.Decimals).Mul(utils.DecimalFromFloat(unitUSD)).Float64())
+		}
+		balances.Balances[symbol] = balance
+	}
+	balances.CalculateTotalUSDValue()
+
+	return balances, nil
+}
+
+// tradeAfter reports whether a trade landed after a point, comparing slots when both are known
+func tradeAfter(record store.TradeRecord, slot uint64, at time.Time) bool {
+	if slot != 0 && record.Trade.Slot != 0 {
+		return record.Trade.Slot > slot
+	}
+	return record.Trade.Timestamp.After(at)
+}
+
+// unitPriceUSD returns the USD price of one token implied by a snapshot
+func unitPriceUSD(symbol string, holding store.Holding, snapshot *store.BalanceSnapshot) (float64, bool) {
+	decimals, known := tokenDecimals[symbol]
+	if known && holding.RawAmount > 0 {
+		unit, err := utils.DecimalFromFloat(holding.USDValue).Div(utils.NewDecimalFromRaw(holding.RawAmount, decimals))
+		if err == nil {
+			return unit.Float64(), true
+		}
+	}
+	switch symbol {
+	case tokens.XSOLSymbol:
+		return snapshot.XSOLPriceUSD, snapshot.XSOLPriceUSD > 0
+	case tokens.HyUSDSymbol, tokens.USDCSymbol:
+		return 1, true
+	}
+	return 0, false
+}
@@ -0,0 +1,66 @@
+package watchlist
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+func TestReconstructBalances(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	tokenConfig := tokens.NewConfig()
+	base := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: base, Slot: 100, TotalUSD: 150, XSOLPriceUSD: 0.5,
+		Holdings: map[string]store.Holding{
+			"xSOL":  {RawAmount: 100_000_000, USDValue: 50},
+			"hyUSD": {RawAmount: 100_000_000, USDValue: 100},
+		},
+	})
+
+	addStableTrade(t, st, walletA, "sig0", hylo.TradeSideBuy, 90, base.Add(-time.Hour), 99_000_000, 1)             // Before both points
+	addStableTrade(t, st, walletA, "sig1", hylo.TradeSideBuy, 95, base.Add(-10*time.Minute), 5_000_000, 2_000_000) // Between the past point and the snapshot
+	addStableTrade(t, st, walletA, "sig2", hylo.TradeSideBuy, 200, base.Add(time.Hour), 40_000_000, 20_000_000)    // Replayed forward to slot 250
+	addTrade(t, st, walletA, "sig3", hylo.TradeSideSell, 300, base.Add(2*time.Hour), 10_000_000)                   // After slot 250
+
+	balances, err := ReconstructBalances(st, tokenConfig, solana.Address(walletA), Since{Slot: 250})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if balances.Slot != 250 || balances.Reconstructed.Direction != DirectionForward || balances.Reconstructed.ReplayedTrades != 1 {
+		t.Errorf("Expected one trade replayed forward to slot 250, got slot %d %+v", balances.Slot, balances.Reconstructed)
+	}
+	if xsol := balances.Balances["xSOL"]; xsol.FormattedAmount != "140" || math.Abs(*xsol.USDValue-70) > 1e-9 {
+		t.Errorf("Expected 140 xSOL worth $70, got %+v", xsol)
+	}
+	if hyusd := balances.Balances["hyUSD"]; hyusd.FormattedAmount != "80" {
+		t.Errorf("Expected 80 hyUSD after paying for the buy, got %+v", hyusd)
+	}
+	if math.Abs(*balances.TotalUSDValue-150) > 1e-9 {
+		t.Errorf("Expected $150 total, got %v", *balances.TotalUSDValue)
+	}
+
+	// The point predates every snapshot, so the snapshot is rolled back over sig1
+	past := base.Add(-30 * time.Minute)
+	balances, err = ReconstructBalances(st, tokenConfig, solana.Address(walletA), Since{Time: past})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !balances.UpdatedAt.Equal(past) || balances.Reconstructed.Direction != DirectionBackward || balances.Reconstructed.ReplayedTrades != 1 {
+		t.Errorf("Expected one trade unwound back to %v, got %v %+v", past, balances.UpdatedAt, balances.Reconstructed)
+	}
+	if balances.Balances["xSOL"].FormattedAmount != "95" || balances.Balances["hyUSD"].FormattedAmount != "102" {
+		t.Errorf("Expected 95 xSOL and 102 hyUSD, got %s and %s", balances.Balances["xSOL"].FormattedAmount, balances.Balances["hyUSD"].FormattedAmount)
+	}
+
+	if _, err := ReconstructBalances(st, tokenConfig, solana.Address(walletB), Since{Slot: 250}); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected ErrNoSnapshot for a wallet without snapshots, got %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package watchlist
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Leaderboard metrics
+const (
+	MetricRealizedPnL = "realized_pnl" // USD realized by xSOL sells in the window against the average buy cost
+	MetricVolume      = "volume"       // USD value of xSOL bought and sold in the window
+	MetricXSOLBalance = "xsol_balance" // xSOL held in the latest snapshot; not windowed
+)
+
+// Leaderboard defaults
+const (
+	DefaultLeaderboardWindow = 7 * 24 * time.Hour
+	DefaultLeaderboardTopN   = 10
+	MaxLeaderboardTopN       = 100
+)
+
+// Leaderboard ranks tracked wallets by one metric
+type Leaderboard struct {
+	Metric      string             `json:"metric"`
+	WindowHours float64            `json:"window_hours"` // 0 means all indexed history
+	Anonymized  bool               `json:"anonymized"`
+	Entries     []LeaderboardEntry `json:"entries"` // Best first
+	WalletCount int                `json:"wallet_count"`
+	GeneratedAt time.Time          `json:"generated_at"`
+}
+
+// LeaderboardEntry is one wallet's standing; every metric is reported, Value is the ranked one
+type LeaderboardEntry struct {
+	Rank   int     `json:"rank"`
+	Wallet string  `json:"wallet"` // Truncated when anonymized
+	Label  string  `json:"label,omitempty"`
+	Value  float64 `json:"value"`
+
+	RealizedPnLUSD float64 `json:"realized_pnl_usd"`
+	VolumeUSD      float64 `json:"volume_usd"`
+	XSOLBalance    float64 `json:"xsol_balance"`
+	XSOLBalanceUSD float64 `json:"xsol_balance_usd"`
+	TradeCount     int     `json:"trade_count"` // Executed trades in the window
+}
+
+// ValidateMetric checks a leaderboard metric name
+func ValidateMetric(metric string) error {
+	switch metric {
+	case MetricRealizedPnL, MetricVolume, MetricXSOLBalance:
+		return nil
+	}
+	return fmt.Errorf("invalid metric %q: must be %s, %s or %s", metric, MetricRealizedPnL, MetricVolume, MetricXSOLBalance)
+}
+
+// Rank builds the leaderboard of a tenant's indexed wallets from stored snapshots and the trade index
+// A window of 0 covers all indexed history. Trades are valued at their historical or stablecoin-implied price,
+// otherwise at the xSOL price of the wallet's snapshot at or before the trade, otherwise the latest price.
+// Realized P&L uses average cost over the wallet's whole trade index, so buys before the window set the
+// cost basis of sells inside it; sells of xSOL bought before indexing began have no basis and are skipped
+func Rank(st store.Store, tenant, metric string, now time.Time, window time.Duration, topN int, anonymize bool) (*Leaderboard, error) {
+	if err := ValidateMetric(metric); err != nil {
+		return nil, err
+	}
+
+	var wallets []store.TrackedWallet
+	for _, wallet := range st.ListTenantWallets(tenant) {
+		if wallet.Indexed() {
+			wallets = append(wallets, wallet)
+		}
+	}
+	var since time.Time
+	if window > 0 {
+		since = now.Add(-window)
+	}
+	xsolUSD := latestXSOLPrice(st, wallets)
+
+	entries := make([]LeaderboardEntry, 0, len(wallets))
+	for _, wallet := range wallets {
+		entry := walletStanding(st, wallet, since, xsolUSD)
+		switch metric {
+		case MetricRealizedPnL:
+			entry.Value = entry.RealizedPnLUSD
+		case MetricVolume:
+			entry.Value = entry.VolumeUSD
+		case MetricXSOLBalance:
+			entry.Value = entry.XSOLBalance
+		}
+		entries = append(entries, entry)
+	}
+
+	// Ties keep a stable order by address so pages do not reshuffle between requests
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Value != entries[j].Value {
+			return entries[i].Value > entries[j].Value
+		}
+		return entries[i].Wallet < entries[j].Wallet
+	})
+	if len(entries) > topN {
+		entries = entries[:topN]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if anonymize {
+			entries[i].Wallet = TruncateAddress(entries[i].Wallet)
+			entries[i].Label = ""
+		}
+	}
+
+	return &Leaderboard{
+		Metric:      metric,
+		WindowHours: window.Hours(),
+		Anonymized:  anonymize,
+		Entries:     entries,
+		WalletCount: len(wallets),
+		GeneratedAt: now,
+	}, nil
+}
+
+// walletStanding computes every leaderboard metric for one wallet
+func walletStanding(st store.Store, wallet store.TrackedWallet, since time.Time, xsolUSD float64) LeaderboardEntry {
+	entry := LeaderboardEntry{Wallet: wallet.Address, Label: wallet.Label}
+
+	if latest, ok := st.LatestSnapshot(wallet.Address); ok {
+		holding := latest.Holdings[tokens.XSOLSymbol]
+		entry.XSOLBalance, _ = strconv.ParseFloat(holding.FormattedAmount, 64)
+		entry.XSOLBalanceUSD = holding.USDValue
+	}
+
+	replay := replayTrades(st, wallet.Address, since, xsolUSD)
+	entry.RealizedPnLUSD = replay.RealizedPnLUSD
+	entry.VolumeUSD = replay.VolumeUSD
+	entry.TradeCount = replay.TradeCount
+	return entry
+}
+
+// tradeReplay is a wallet's trade index replayed under average-cost accounting
+type tradeReplay struct {
+	// Position is the open xSOL position left after every trade, and Cost its USD cost basis
+	Position float64
+	Cost     float64
+
+	// Realized P&L, volume and executed trade count within the window
+	RealizedPnLUSD float64
+	VolumeUSD      float64
+	TradeCount     int
+}
+
+// replayTrades replays every executed trade of the wallet, counting those at or after since
+func replayTrades(st store.Store, wallet string, since time.Time, xsolUSD float64) tradeReplay {
+	var replay tradeReplay
+
+	// The trade index is newest first; cost basis is built oldest first
+	records := st.TradesSince(wallet, time.Time{})
+	for i := len(records) - 1; i >= 0; i-- {
+		trade := records[i].Trade
+		if trade.Status == hylo.TradeStatusFailed {
+			continue
+		}
+		amount, err := strconv.ParseFloat(trade.XSOLAmount, 64)
+		if err != nil || amount <= 0 {
+			continue
+		}
+		priceUSD := executionPriceUSD(st, wallet, &trade, xsolUSD)
+		inWindow := !trade.Timestamp.Before(since)
+
+		switch trade.Side {
+		case hylo.TradeSideBuy:
+			replay.Position += amount
+			replay.Cost += amount * priceUSD
+		case hylo.TradeSideSell:
+			if matched := min(amount, replay.Position); matched > 0 {
+				averageCost := replay.Cost / replay.Position
+				if inWindow {
+					replay.RealizedPnLUSD += matched * (priceUSD - averageCost)
+				}
+				replay.Cost -= matched * averageCost
+				replay.Position -= matched
+			}
+		default:
+			continue
+		}
+
+		if inWindow {
+			replay.TradeCount++
+			replay.VolumeUSD += amount * priceUSD
+		}
+	}
+
+	return replay
+}
+
+// executionPriceUSD returns the xSOL price a trade executed at, as closely as stored data allows
+func executionPriceUSD(st store.Store, wallet string, trade *hylo.XSOLTrade, xsolUSD float64) float64 {
+	if price, ok := tradePriceUSD(trade); ok {
+		return price
+	}
+	if snapshot, ok := st.SnapshotAt(wallet, trade.Timestamp); ok && snapshot.XSOLPriceUSD > 0 {
+		return snapshot.XSOLPriceUSD
+	}
+	return xsolUSD
+}
+
+// TruncateAddress shortens an address to its first and last four characters for public display
+func TruncateAddress(address string) string {
+	if len(address) <= 8 {
+		return address
+	}
+	return address[:4] + "..." + address[len(address)-4:]
+}
@@ -0,0 +1,77 @@
+package watchlist
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func TestRank(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	st.TrackWallet(store.TrackedWallet{Address: walletA, Label: "whale"})
+	st.TrackWallet(store.TrackedWallet{Address: walletB})
+
+	// Wallet A buys 100 xSOL at $0.40 before the window and sells 60 at $0.50 inside it
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletA, Timestamp: now.Add(-10 * 24 * time.Hour), XSOLPriceUSD: 0.4})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: now.Add(-2 * 24 * time.Hour), XSOLPriceUSD: 0.5,
+		Holdings: map[string]store.Holding{"xSOL": {FormattedAmount: "40", USDValue: 20}},
+	})
+	addTrade(t, st, walletA, "sigA0", hylo.TradeSideBuy, 100, now.Add(-9*24*time.Hour), 100_000_000)
+	addTrade(t, st, walletA, "sigA1", hylo.TradeSideSell, 200, now.Add(-24*time.Hour), 60_000_000)
+
+	// Wallet B sells more than it ever bought; only the matched part is realized
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletB, Timestamp: now.Add(-3 * 24 * time.Hour), XSOLPriceUSD: 0.45})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletB, Timestamp: now.Add(-2 * 24 * time.Hour), XSOLPriceUSD: 0.5,
+		Holdings: map[string]store.Holding{"xSOL": {FormattedAmount: "500", USDValue: 225}},
+	})
+	addTrade(t, st, walletB, "sigB0", hylo.TradeSideBuy, 300, now.Add(-3*24*time.Hour+time.Minute), 100_000_000)
+	addTrade(t, st, walletB, "sigB1", hylo.TradeSideSell, 400, now.Add(-2*24*time.Hour+time.Minute), 300_000_000)
+
+	board, err := Rank(st, store.DefaultTenant, MetricRealizedPnL, now, DefaultLeaderboardWindow, DefaultLeaderboardTopN, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if board.WalletCount != 2 || len(board.Entries) != 2 {
+		t.Fatalf("Expected both wallets ranked, got %+v", board)
+	}
+	first, second := board.Entries[0], board.Entries[1]
+	if first.Wallet != walletA || first.Rank != 1 || first.Label != "whale" || math.Abs(first.Value-6) > 1e-9 {
+		t.Errorf("Expected wallet A first with $6 realized, got %+v", first)
+	}
+	if math.Abs(second.RealizedPnLUSD-5) > 1e-9 || second.TradeCount != 2 {
+		t.Errorf("Expected wallet B to realize $5 on its 100 matched xSOL, got %+v", second)
+	}
+	if math.Abs(first.VolumeUSD-30) > 1e-9 || first.TradeCount != 1 {
+		t.Errorf("Expected only the sell inside the window counted as volume, got %+v", first)
+	}
+
+	board, _ = Rank(st, store.DefaultTenant, MetricVolume, now, 0, 1, true)
+	if len(board.Entries) != 1 || board.Entries[0].Wallet != "7VHU...4BmE" || board.Entries[0].Value != 195 {
+		t.Errorf("Expected anonymized wallet B first by all-time volume, got %+v", board.Entries)
+	}
+
+	board, _ = Rank(st, store.DefaultTenant, MetricXSOLBalance, now, DefaultLeaderboardWindow, DefaultLeaderboardTopN, true)
+	if board.Entries[0].Value != 500 || board.Entries[1].Label != "" || board.Entries[1].XSOLBalanceUSD != 20 {
+		t.Errorf("Expected wallet B first by xSOL balance with labels hidden, got %+v", board.Entries)
+	}
+
+	if _, err := Rank(st, store.DefaultTenant, "roi", now, DefaultLeaderboardWindow, DefaultLeaderboardTopN, false); err == nil {
+		t.Error("Expected an error for an unknown metric")
+	}
+}
+
+func TestTruncateAddress(t *testing.T) {
+	if got := TruncateAddress(walletA); got != "A3wp...yc6g" {
+		t.Errorf("Expected A3wp...yc6g, got %s", got)
+	}
+	if got := TruncateAddress("short"); got != "short" {
+		t.Errorf("Expected short addresses unchanged, got %s", got)
+	}
+}
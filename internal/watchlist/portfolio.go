@@ -0,0 +1,193 @@
+package watchlist
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/utils"
+)
+
+// Portfolio limits
+const (
+	MaxPortfolioWallets    = 100
+	MaxPortfolioNameLength = 64
+)
+
+// PortfolioSummary aggregates a portfolio's holdings, trade activity and P&L over the window
+// Holdings, trades and movers are the watchlist summary restricted to the portfolio's wallets
+type PortfolioSummary struct {
+	Portfolio store.Portfolio `json:"portfolio"`
+	Summary
+	// RealizedPnLUSD is the sum of the wallets' realized P&L in the window
+	RealizedPnLUSD float64           `json:"realized_pnl_usd"`
+	Wallets        []PortfolioWallet `json:"wallets"` // In portfolio order
+	// LSTExposure lists liquid staking tokens valued through their SOL conversion rate and included in the totals
+	LSTExposure []LSTExposure `json:"lst_exposure,omitempty"`
+}
+
+// PortfolioWallet is one member wallet's standing inside a portfolio summary
+type PortfolioWallet struct {
+	Wallet         string     `json:"wallet"`
+	Label          string     `json:"label,omitempty"`
+	Status         string     `json:"status"` // Tracked wallet status; untracked when the wallet is not registered
+	TotalUSD       float64    `json:"total_usd"`
+	RealizedPnLUSD float64    `json:"realized_pnl_usd"`
+	VolumeUSD      float64    `json:"volume_usd"`
+	XSOLBalance    float64    `json:"xsol_balance"`
+	XSOLBalanceUSD float64    `json:"xsol_balance_usd"`
+	TradeCount     int        `json:"trade_count"` // Executed trades in the window
+	SnapshotAt     *time.Time `json:"snapshot_at,omitempty"`
+}
+
+// LSTExposure is a portfolio's combined liquid staking token holding converted to SOL and USD
+type LSTExposure struct {
+	Symbol        string  `json:"symbol"`
+	Amount        float64 `json:"amount"`
+	SOLPerToken   float64 `json:"sol_per_token"` // Conversion rate applied
+	SOLEquivalent float64 `json:"sol_equivalent"`
+	USDValue      float64 `json:"usd_value"`
+	RateSource    string  `json:"rate_source"` // sanctum or dexscreener
+}
+
+// PortfolioStatusUntracked marks portfolio members the indexer does not know about
+const PortfolioStatusUntracked = "untracked"
+
+// NewPortfolio validates a tenant's portfolio definition and assigns it an ID
+// Addresses are normalized and de-duplicated, keeping the order they were given in
+func NewPortfolio(tenant, name string, addresses []string, now time.Time) (store.Portfolio, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return store.Portfolio{}, fmt.Errorf("invalid portfolio name: must not be empty")
+	}
+	if len(name) > MaxPortfolioNameLength {
+		return store.Portfolio{}, fmt.Errorf("invalid portfolio name: must be at most %d characters", MaxPortfolioNameLength)
+	}
+	if len(addresses) == 0 || len(addresses) > MaxPortfolioWallets {
+		return store.Portfolio{}, fmt.Errorf("invalid wallets: must list between 1 and %d addresses", MaxPortfolioWallets)
+	}
+
+	wallets := make([]string, 0, len(addresses))
+	seen := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		wallet, err := tokens.SanitizeAddress(address)
+		if err != nil {
+			return store.Portfolio{}, fmt.Errorf("invalid wallet %q: %w", address, err)
+		}
+		if seen[wallet.String()] {
+			continue
+		}
+		seen[wallet.String()] = true
+		wallets = append(wallets, wallet.String())
+	}
+
+	id, err := newPortfolioID()
+	if err != nil {
+		return store.Portfolio{}, err
+	}
+	return store.Portfolio{Tenant: tenant, ID: id, Name: name, Wallets: wallets, CreatedAt: now.UTC()}, nil
+}
+
+func newPortfolioID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate portfolio ID: %w", err)
+	}
+	return "pf_" + hex.EncodeToString(buf), nil
+}
+
+// SummarizePortfolio computes a portfolio summary from stored snapshots and the trade index
+// Every member is included whatever its tracking status; wallets that were never indexed contribute nothing
+// Members are looked up in the portfolio tenant's registrations, so their labels and statuses are its own
+func SummarizePortfolio(st store.Store, portfolio store.Portfolio, now time.Time, window time.Duration, topN int) *PortfolioSummary {
+	members := make([]store.TrackedWallet, 0, len(portfolio.Wallets))
+	for _, address := range portfolio.Wallets {
+		wallet, ok := st.GetWallet(portfolio.Tenant, address)
+		if !ok {
+			wallet = store.TrackedWallet{Address: address, Status: PortfolioStatusUntracked}
+		}
+		members = append(members, wallet)
+	}
+
+	summary := &PortfolioSummary{
+		Portfolio: portfolio,
+		Summary:   *summarizeWallets(st, members, now, window, topN),
+		Wallets:   make([]PortfolioWallet, 0, len(members)),
+	}
+
+	since := now.Add(-window)
+	xsolUSD := latestXSOLPrice(st, members)
+	for _, wallet := range members {
+		standing := walletStanding(st, wallet, since, xsolUSD)
+		member := PortfolioWallet{
+			Wallet:         wallet.Address,
+			Label:          wallet.Label,
+			Status:         wallet.Status,
+			RealizedPnLUSD: standing.RealizedPnLUSD,
+			VolumeUSD:      standing.VolumeUSD,
+			XSOLBalance:    standing.XSOLBalance,
+			XSOLBalanceUSD: standing.XSOLBalanceUSD,
+			TradeCount:     standing.TradeCount,
+		}
+		if latest, ok := st.LatestSnapshot(wallet.Address); ok {
+			member.TotalUSD = latest.TotalUSD
+			member.SnapshotAt = &latest.Timestamp
+		}
+		summary.RealizedPnLUSD += standing.RealizedPnLUSD
+		summary.Wallets = append(summary.Wallets, member)
+	}
+
+	return summary
+}
+
+// ApplyLSTConversion values the portfolio's holdings of an LST at its SOL conversion rate and adds them to
+// the token holding, the member and the portfolio totals, replacing whatever USD value the snapshots gave it
+func (p *PortfolioSummary) ApplyLSTConversion(st store.Store, symbol string, conversion *hylo.LSTConversion) {
+	rate := utils.DecimalFromFloat(conversion.SOLPerToken)
+	solUSD := utils.DecimalFromFloat(conversion.SOLPriceUSD)
+
+	totalAmount, totalSOL := utils.Decimal{}, utils.Decimal{}
+	var previousUSD float64
+	for i := range p.Wallets {
+		latest, ok := st.LatestSnapshot(p.Wallets[i].Wallet)
+		if !ok {
+			continue
+		}
+		holding, ok := latest.Holdings[symbol]
+		if !ok {
+			continue
+		}
+		amount, err := utils.ParseDecimal(holding.FormattedAmount)
+		if err != nil || amount.IsZero() {
+			continue
+		}
+
+		valueSOL := amount.Mul(rate)
+		p.Wallets[i].TotalUSD += valueSOL.Mul(solUSD).Float64() - holding.USDValue
+		totalAmount = totalAmount.Add(amount)
+		totalSOL = totalSOL.Add(valueSOL)
+		previousUSD += holding.USDValue
+	}
+	if totalAmount.IsZero() {
+		return
+	}
+
+	exposure := LSTExposure{
+		Symbol:        symbol,
+		Amount:        totalAmount.Float64(),
+		SOLPerToken:   conversion.SOLPerToken,
+		SOLEquivalent: totalSOL.Float64(),
+		USDValue:      totalSOL.Mul(solUSD).Float64(),
+		RateSource:    conversion.RateSource,
+	}
+	holding := p.Holdings[symbol]
+	holding.USDValue = exposure.USDValue
+	p.Holdings[symbol] = holding
+	p.TotalUSD += exposure.USDValue - previousUSD
+	p.LSTExposure = append(p.LSTExposure, exposure)
+}
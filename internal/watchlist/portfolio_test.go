@@ -0,0 +1,129 @@
+package watchlist
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func TestNewPortfolio(t *testing.T) {
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	portfolio, err := NewPortfolio(store.DefaultTenant, "  Treasury ", []string{walletB, " " + walletA, walletB}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(portfolio.ID, "pf_") || portfolio.Name != "Treasury" || !portfolio.CreatedAt.Equal(now) {
+		t.Errorf("Expected a named portfolio with an ID, got %+v", portfolio)
+	}
+	if len(portfolio.Wallets) != 2 || portfolio.Wallets[0] != walletB || portfolio.Wallets[1] != walletA {
+		t.Errorf("Expected de-duplicated wallets in the given order, got %v", portfolio.Wallets)
+	}
+
+	invalid := []struct {
+		name    string
+		wallets []string
+	}{
+		{"", []string{walletA}},
+		{strings.Repeat("x", MaxPortfolioNameLength+1), []string{walletA}},
+		{"Empty", nil},
+		{"Bad address", []string{walletA, "not-an-address"}},
+	}
+	for _, tc := range invalid {
+		if _, err := NewPortfolio(store.DefaultTenant, tc.name, tc.wallets, now); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("Expected a validation error for %q %v, got %v", tc.name, tc.wallets, err)
+		}
+	}
+}
+
+func TestSummarizePortfolio(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	st.TrackWallet(store.TrackedWallet{Address: walletA, Label: "whale"})
+	st.TrackWallet(store.TrackedWallet{Address: walletB})
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletA, Timestamp: now.Add(-10 * 24 * time.Hour), XSOLPriceUSD: 0.4})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: now.Add(-2 * 24 * time.Hour), TotalUSD: 20, XSOLPriceUSD: 0.5,
+		Holdings: map[string]store.Holding{"xSOL": {FormattedAmount: "40", USDValue: 20}},
+	})
+	addTrade(t, st, walletA, "sigA0", hylo.TradeSideBuy, 100, now.Add(-9*24*time.Hour), 100_000_000)
+	addTrade(t, st, walletA, "sigA1", hylo.TradeSideSell, 200, now.Add(-24*time.Hour), 60_000_000)
+
+	// Wallet B is tracked but left out of the portfolio; its second member was never tracked
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletB, Timestamp: now.Add(-time.Hour), TotalUSD: 500,
+		Holdings: map[string]store.Holding{"xSOL": {FormattedAmount: "1000", USDValue: 500}},
+	})
+	untracked := "So11111111111111111111111111111111111111112"
+	portfolio := store.Portfolio{ID: "pf_1", Name: "Mine", Wallets: []string{walletA, untracked}}
+
+	summary := SummarizePortfolio(st, portfolio, now, DefaultLeaderboardWindow, DefaultTopN)
+
+	if summary.Portfolio.ID != "pf_1" || summary.WalletCount != 2 || summary.TotalUSD != 20 {
+		t.Errorf("Expected totals over the portfolio's wallets only, got %+v", summary.Summary)
+	}
+	if holding := summary.Holdings["xSOL"]; holding.Amount != 40 || holding.USDValue != 20 {
+		t.Errorf("Expected wallet A's xSOL only, got %+v", holding)
+	}
+	if summary.Trades.Count != 1 || summary.Trades.SellCount != 1 {
+		t.Errorf("Expected the sell inside the window, got %+v", summary.Trades)
+	}
+	if math.Abs(summary.RealizedPnLUSD-6) > 1e-9 {
+		t.Errorf("Expected $6 realized, got %v", summary.RealizedPnLUSD)
+	}
+	if len(summary.Wallets) != 2 || summary.Wallets[0].Label != "whale" || summary.Wallets[0].SnapshotAt == nil {
+		t.Fatalf("Expected both members in portfolio order, got %+v", summary.Wallets)
+	}
+	if member := summary.Wallets[1]; member.Wallet != untracked || member.Status != PortfolioStatusUntracked || member.TotalUSD != 0 {
+		t.Errorf("Expected the untracked member reported without data, got %+v", member)
+	}
+}
+
+func TestPortfolioSummary_ApplyLSTConversion(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	st.TrackWallet(store.TrackedWallet{Address: walletA})
+	st.TrackWallet(store.TrackedWallet{Address: walletB})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: now.Add(-time.Hour), TotalUSD: 20,
+		Holdings: map[string]store.Holding{
+			"xSOL":    {FormattedAmount: "40", USDValue: 20},
+			"jitoSOL": {FormattedAmount: "2"},
+		},
+	})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletB, Timestamp: now.Add(-time.Hour), TotalUSD: 0,
+		Holdings: map[string]store.Holding{"jitoSOL": {FormattedAmount: "0.5"}},
+	})
+	portfolio := store.Portfolio{ID: "pf_1", Name: "Mine", Wallets: []string{walletA, walletB}}
+
+	summary := SummarizePortfolio(st, portfolio, now, DefaultLeaderboardWindow, DefaultTopN)
+	summary.ApplyLSTConversion(st, "jitoSOL", &hylo.LSTConversion{SOLPerToken: 1.2, SOLPriceUSD: 100, RateSource: "sanctum"})
+
+	if len(summary.LSTExposure) != 1 {
+		t.Fatalf("Expected jitoSOL exposure, got %+v", summary.LSTExposure)
+	}
+	exposure := summary.LSTExposure[0]
+	if exposure.Amount != 2.5 || math.Abs(exposure.SOLEquivalent-3) > 1e-9 || math.Abs(exposure.USDValue-300) > 1e-9 || exposure.RateSource != "sanctum" {
+		t.Errorf("Expected 2.5 jitoSOL worth 3 SOL and $300, got %+v", exposure)
+	}
+	if math.Abs(summary.TotalUSD-320) > 1e-9 || math.Abs(summary.Holdings["jitoSOL"].USDValue-300) > 1e-9 {
+		t.Errorf("Expected jitoSOL included in the totals, got total %v and holding %+v", summary.TotalUSD, summary.Holdings["jitoSOL"])
+	}
+	if math.Abs(summary.Wallets[0].TotalUSD-260) > 1e-9 || math.Abs(summary.Wallets[1].TotalUSD-60) > 1e-9 {
+		t.Errorf("Expected member totals to include their jitoSOL, got %v and %v", summary.Wallets[0].TotalUSD, summary.Wallets[1].TotalUSD)
+	}
+
+	// Portfolios without the LST are left as they are
+	empty := SummarizePortfolio(st, store.Portfolio{ID: "pf_2", Wallets: []string{"So11111111111111111111111111111111111111112"}}, now, DefaultLeaderboardWindow, DefaultTopN)
+	empty.ApplyLSTConversion(st, "jitoSOL", &hylo.LSTConversion{SOLPerToken: 1.2, SOLPriceUSD: 100})
+	if empty.LSTExposure != nil || empty.TotalUSD != 0 {
+		t.Errorf("Expected no exposure without jitoSOL, got %+v", empty.LSTExposure)
+	}
+}
@@ -0,0 +1,196 @@
+package watchlist
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Risk window defaults; the window only bounds the trading frequency component
+const (
+	DefaultRiskDays = 30
+	MaxRiskDays     = 365
+)
+
+// Risk components
+const (
+	RiskLeverage      = "leverage"      // Share of the wallet's USD value held in leveraged xSOL
+	RiskConcentration = "concentration" // Herfindahl index of the holdings' USD values
+	RiskFrequency     = "frequency"     // Executed trades per day in the window
+	RiskDrawdown      = "drawdown"      // Unrealized loss of the open xSOL position against its average cost
+)
+
+// Risk levels by total score
+const (
+	RiskLevelLow      = "low"      // Below 25
+	RiskLevelModerate = "moderate" // 25 to 50
+	RiskLevelHigh     = "high"     // 50 to 75
+	RiskLevelSevere   = "severe"   // 75 and above
+)
+
+// riskWeights sum to 1 and list the components in response order
+var riskWeights = []struct {
+	name   string
+	weight float64
+}{
+	{RiskLeverage, 0.35},
+	{RiskConcentration, 0.20},
+	{RiskFrequency, 0.15},
+	{RiskDrawdown, 0.30},
+}
+
+// Component inputs at which a component scores 100
+const (
+	riskFrequencyCeiling = 5.0 // Trades per day
+	riskDrawdownCeiling  = 0.5 // Half the cost basis lost
+)
+
+// WalletRisk scores a tracked wallet's exposure from 0 (none) to 100
+type WalletRisk struct {
+	Wallet     string          `json:"wallet"`
+	Score      float64         `json:"score"`
+	Level      string          `json:"level"` // low, moderate, high or severe
+	Components []RiskComponent `json:"components"`
+	Days       int             `json:"days"`
+	SnapshotAt time.Time       `json:"snapshot_at"` // Snapshot the holdings were read from
+	// XSOLPosition and XSOLCostBasisUSD are the open position the drawdown is measured on
+	XSOLPosition     float64   `json:"xsol_position"`
+	XSOLCostBasisUSD float64   `json:"xsol_cost_basis_usd"`
+	GeneratedAt      time.Time `json:"generated_at"`
+}
+
+// RiskComponent is one input to the score
+// Value is the raw measure: a 0-1 share for leverage, concentration and drawdown, trades per day for frequency
+type RiskComponent struct {
+	Name         string  `json:"name"`
+	Value        float64 `json:"value"`
+	Score        float64 `json:"score"` // 0-100
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"` // Points added to the total score
+}
+
+// ScoreRisk scores the wallet from its latest snapshot and the last days days of its trade index
+// The open position is replayed over the whole index, like the leaderboard's realized P&L
+// Returns ErrNoSnapshot when the wallet has no snapshot
+func ScoreRisk(st store.Store, wallet string, days int, now time.Time) (*WalletRisk, error) {
+	if days < 1 || days > MaxRiskDays {
+		return nil, fmt.Errorf("invalid days %d: must be between 1 and %d", days, MaxRiskDays)
+	}
+
+	latest, ok := st.LatestSnapshot(wallet)
+	if !ok {
+		return nil, ErrNoSnapshot
+	}
+
+	now = now.UTC()
+	xsolUSD := snapshotXSOLPrice(latest)
+	replay := replayTrades(st, wallet, now.AddDate(0, 0, -days), xsolUSD)
+
+	values := map[string]float64{
+		RiskLeverage:      xsolShare(latest),
+		RiskConcentration: concentration(latest),
+		RiskFrequency:     float64(replay.TradeCount) / float64(days),
+		RiskDrawdown:      drawdown(replay, xsolUSD),
+	}
+
+	risk := &WalletRisk{
+		Wallet:           wallet,
+		Days:             days,
+		SnapshotAt:       latest.Timestamp,
+		XSOLPosition:     replay.Position,
+		XSOLCostBasisUSD: replay.Cost,
+		GeneratedAt:      now,
+	}
+	for _, component := range riskWeights {
+		value := values[component.name]
+		score := componentScore(component.name, value)
+		risk.Components = append(risk.Components, RiskComponent{
+			Name:         component.name,
+			Value:        value,
+			Score:        score,
+			Weight:       component.weight,
+			Contribution: roundScore(score * component.weight),
+		})
+		risk.Score += score * component.weight
+	}
+	risk.Score = roundScore(risk.Score)
+	risk.Level = riskLevel(risk.Score)
+
+	return risk, nil
+}
+
+// componentScore maps a component's raw value onto 0-100
+func componentScore(name string, value float64) float64 {
+	switch name {
+	case RiskFrequency:
+		value /= riskFrequencyCeiling
+	case RiskDrawdown:
+		value /= riskDrawdownCeiling
+	}
+	return roundScore(math.Min(math.Max(value, 0), 1) * 100)
+}
+
+// xsolShare is the fraction of the snapshot's USD value held in xSOL
+func xsolShare(snapshot *store.BalanceSnapshot) float64 {
+	if snapshot.TotalUSD <= 0 {
+		return 0
+	}
+	return snapshot.Holdings[tokens.XSOLSymbol].USDValue / snapshot.TotalUSD
+}
+
+// concentration is the sum of squared holding shares: 1 when everything sits in one token
+func concentration(snapshot *store.BalanceSnapshot) float64 {
+	if snapshot.TotalUSD <= 0 {
+		return 0
+	}
+	var index float64
+	for _, holding := range snapshot.Holdings {
+		if holding.USDValue > 0 {
+			share := holding.USDValue / snapshot.TotalUSD
+			index += share * share
+		}
+	}
+	return index
+}
+
+// drawdown is the unrealized loss of the open position as a fraction of its cost basis; gains count as 0
+func drawdown(replay tradeReplay, xsolUSD float64) float64 {
+	if replay.Cost <= 0 || replay.Position <= 0 || xsolUSD <= 0 {
+		return 0
+	}
+	return math.Max(1-replay.Position*xsolUSD/replay.Cost, 0)
+}
+
+// snapshotXSOLPrice returns the xSOL price a snapshot was valued at, derived from its holding if unset
+func snapshotXSOLPrice(snapshot *store.BalanceSnapshot) float64 {
+	if snapshot.XSOLPriceUSD > 0 {
+		return snapshot.XSOLPriceUSD
+	}
+	holding := snapshot.Holdings[tokens.XSOLSymbol]
+	amount, err := strconv.ParseFloat(holding.FormattedAmount, 64)
+	if err != nil || amount <= 0 {
+		return 0
+	}
+	return holding.USDValue / amount
+}
+
+func riskLevel(score float64) string {
+	switch {
+	case score >= 75:
+		return RiskLevelSevere
+	case score >= 50:
+		return RiskLevelHigh
+	case score >= 25:
+		return RiskLevelModerate
+	}
+	return RiskLevelLow
+}
+
+// roundScore rounds to one decimal so scores read cleanly and compare stably
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
@@ -0,0 +1,68 @@
+package watchlist
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func TestScoreRisk(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// 100 xSOL bought at $1 now worth $0.40, next to $10 of hyUSD
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletA, Timestamp: now.Add(-10 * 24 * time.Hour), XSOLPriceUSD: 1})
+	addTrade(t, st, walletA, "sigA0", hylo.TradeSideBuy, 100, now.Add(-9*24*time.Hour), 100_000_000)
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: now.Add(-time.Hour), TotalUSD: 50, XSOLPriceUSD: 0.4,
+		Holdings: map[string]store.Holding{
+			"xSOL":  {FormattedAmount: "100", USDValue: 40},
+			"hyUSD": {FormattedAmount: "10", USDValue: 10},
+		},
+	})
+
+	risk, err := ScoreRisk(st, walletA, DefaultRiskDays, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]float64{RiskLeverage: 80, RiskConcentration: 68, RiskFrequency: 0.7, RiskDrawdown: 100}
+	if len(risk.Components) != len(expected) {
+		t.Fatalf("Expected %d components, got %+v", len(expected), risk.Components)
+	}
+	for _, component := range risk.Components {
+		if component.Score != expected[component.Name] {
+			t.Errorf("Expected %s to score %v, got %+v", component.Name, expected[component.Name], component)
+		}
+	}
+	if risk.Score != 71.7 || risk.Level != RiskLevelHigh {
+		t.Errorf("Expected a high score of 71.7, got %v (%s)", risk.Score, risk.Level)
+	}
+	if risk.XSOLPosition != 100 || risk.XSOLCostBasisUSD != 100 {
+		t.Errorf("Expected the open position at its $100 cost, got %v at %v", risk.XSOLPosition, risk.XSOLCostBasisUSD)
+	}
+
+	if _, err := ScoreRisk(st, walletB, DefaultRiskDays, now); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected ErrNoSnapshot without a snapshot, got %v", err)
+	}
+	if _, err := ScoreRisk(st, walletA, MaxRiskDays+1, now); err == nil {
+		t.Error("Expected an error for days beyond the maximum")
+	}
+}
+
+func TestScoreRisk_EmptyWallet(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletA, Timestamp: now.Add(-time.Hour)})
+
+	risk, err := ScoreRisk(st, walletA, DefaultRiskDays, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if risk.Score != 0 || risk.Level != RiskLevelLow {
+		t.Errorf("Expected an empty wallet to carry no risk, got %v (%s)", risk.Score, risk.Level)
+	}
+}
@@ -0,0 +1,84 @@
+package watchlist
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// Scenario is a protocol SOL price shock applied to a tenant's tracked wallets
+type Scenario struct {
+	hylo.ProtocolScenario
+
+	// Wallets lists the impact on each tracked wallet with a snapshot, hardest hit first
+	Wallets []WalletShock `json:"wallets"`
+
+	// TotalChangeUSD is the combined USD change of the wallets
+	TotalChangeUSD float64 `json:"total_change_usd"`
+}
+
+// WalletShock is one tracked wallet's exposure to a SOL price shock
+// Only the xSOL holding is repriced; hyUSD and sHYUSD keep their snapshot value
+type WalletShock struct {
+	Wallet      string  `json:"wallet"`
+	Label       string  `json:"label,omitempty"`
+	XSOLBalance float64 `json:"xsol_balance"`
+
+	// XSOL values are at the current and the shocked xSOL price
+	XSOLValueUSD        float64 `json:"xsol_value_usd"`
+	ShockedXSOLValueUSD float64 `json:"shocked_xsol_value_usd"`
+
+	// TotalUSD is the wallet's holdings with xSOL at the current price, and ShockedTotalUSD at the shocked price
+	TotalUSD        float64 `json:"total_usd"`
+	ShockedTotalUSD float64 `json:"shocked_total_usd"`
+
+	ChangeUSD  float64   `json:"change_usd"`
+	ChangePct  float64   `json:"change_pct"` // Of TotalUSD
+	SnapshotAt time.Time `json:"snapshot_at"`
+}
+
+// ApplyScenario values the latest snapshot of each of the tenant's tracked wallets under the scenario
+// Wallets without a snapshot are left out
+func ApplyScenario(st store.Store, tenant string, scenario *hylo.ProtocolScenario) *Scenario {
+	result := &Scenario{ProtocolScenario: *scenario, Wallets: []WalletShock{}}
+
+	for _, wallet := range st.ListTenantWallets(tenant) {
+		latest, ok := st.LatestSnapshot(wallet.Address)
+		if !ok {
+			continue
+		}
+
+		holding := latest.Holdings[tokens.XSOLSymbol]
+		balance, _ := strconv.ParseFloat(holding.FormattedAmount, 64)
+		shock := WalletShock{
+			Wallet:              wallet.Address,
+			Label:               wallet.Label,
+			XSOLBalance:         balance,
+			XSOLValueUSD:        balance * scenario.Current.XSOLPriceUSD,
+			ShockedXSOLValueUSD: balance * scenario.Shocked.XSOLPriceUSD,
+			SnapshotAt:          latest.Timestamp,
+		}
+		others := latest.TotalUSD - holding.USDValue
+		shock.TotalUSD = others + shock.XSOLValueUSD
+		shock.ShockedTotalUSD = others + shock.ShockedXSOLValueUSD
+		shock.ChangeUSD = shock.ShockedTotalUSD - shock.TotalUSD
+		if shock.TotalUSD > 0 {
+			shock.ChangePct = shock.ChangeUSD / shock.TotalUSD * 100
+		}
+
+		result.Wallets = append(result.Wallets, shock)
+		result.TotalChangeUSD += shock.ChangeUSD
+	}
+
+	sort.SliceStable(result.Wallets, func(i, j int) bool {
+		if result.Wallets[i].ChangeUSD != result.Wallets[j].ChangeUSD {
+			return result.Wallets[i].ChangeUSD < result.Wallets[j].ChangeUSD
+		}
+		return result.Wallets[i].Wallet < result.Wallets[j].Wallet
+	})
+	return result
+}
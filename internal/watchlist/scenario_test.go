@@ -0,0 +1,39 @@
+package watchlist
+
+import (
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+func TestApplyScenario(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	st.TrackWallet(store.TrackedWallet{Address: walletA, Label: "whale"})
+	st.TrackWallet(store.TrackedWallet{Address: walletB})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: now, TotalUSD: 100,
+		Holdings: map[string]store.Holding{
+			"xSOL":  {FormattedAmount: "100", USDValue: 90},
+			"hyUSD": {FormattedAmount: "10", USDValue: 10},
+		},
+	})
+
+	// xSOL falls from $1 to $0.40
+	scenario := ApplyScenario(st, store.DefaultTenant, &hylo.ProtocolScenario{
+		Current: hylo.ScenarioState{XSOLPriceUSD: 1},
+		Shocked: hylo.ScenarioState{XSOLPriceUSD: 0.4},
+	})
+	if len(scenario.Wallets) != 1 {
+		t.Fatalf("expected only the wallet with a snapshot, got %+v", scenario.Wallets)
+	}
+	shock := scenario.Wallets[0]
+	if shock.Label != "whale" || shock.TotalUSD != 110 || shock.ShockedTotalUSD != 50 || shock.ChangeUSD != -60 {
+		t.Errorf("expected $110 repriced to $50, got %+v", shock)
+	}
+	if scenario.TotalChangeUSD != -60 {
+		t.Errorf("expected a total change of -60, got %v", scenario.TotalChangeUSD)
+	}
+}
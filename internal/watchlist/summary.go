@@ -0,0 +1,183 @@
+package watchlist
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+// Summary defaults
+const (
+	DefaultWindow = 24 * time.Hour // Trade and mover lookback
+	DefaultTopN   = 5              // Number of top movers returned
+	MaxTopN       = 50
+)
+
+// Summary aggregates holdings and recent activity across all tracked wallets
+type Summary struct {
+	WalletCount int                     `json:"wallet_count"`
+	Holdings    map[string]TokenHolding `json:"holdings"` // Keyed by token symbol
+	TotalUSD    float64                 `json:"total_usd"`
+	Trades      TradeActivity           `json:"trades"`
+	TopMovers   []Mover                 `json:"top_movers"`
+	WindowHours float64                 `json:"window_hours"`
+	// OldestSnapshotAt is the least recent latest-snapshot across wallets, indicating data freshness
+	OldestSnapshotAt *time.Time `json:"oldest_snapshot_at,omitempty"`
+	GeneratedAt      time.Time  `json:"generated_at"`
+}
+
+// TokenHolding is the combined position in one token across tracked wallets
+type TokenHolding struct {
+	Amount   float64 `json:"amount"`
+	USDValue float64 `json:"usd_value"`
+}
+
+// TradeActivity summarizes indexed trades inside the summary window
+type TradeActivity struct {
+	Count      int     `json:"count"`
+	BuyCount   int     `json:"buy_count"`
+	SellCount  int     `json:"sell_count"`
+	VolumeXSOL float64 `json:"volume_xsol"`
+	// VolumeUSD uses each trade's historical price when known, otherwise the current xSOL price
+	VolumeUSD float64 `json:"volume_usd"`
+}
+
+// Mover is a wallet ranked by absolute USD holdings change over the window
+type Mover struct {
+	Wallet        string  `json:"wallet"`
+	Label         string  `json:"label,omitempty"`
+	CurrentUSD    float64 `json:"current_usd"`
+	PreviousUSD   float64 `json:"previous_usd"`
+	ChangeUSD     float64 `json:"change_usd"`
+	ChangePercent float64 `json:"change_pct"`
+	TradeCount    int     `json:"trade_count"`
+}
+
+// Summarize computes the summary of a tenant's watchlist purely from stored snapshots and the trade index
+// Trades without a historical price are valued at the xSOL price of the most recent snapshot
+// Paused wallets are left out; their stored history is kept but no longer represents current holdings
+func Summarize(st store.Store, tenant string, now time.Time, window time.Duration, topN int) *Summary {
+	var wallets []store.TrackedWallet
+	for _, wallet := range st.ListTenantWallets(tenant) {
+		if wallet.Indexed() {
+			wallets = append(wallets, wallet)
+		}
+	}
+	return summarizeWallets(st, wallets, now, window, topN)
+}
+
+// summarizeWallets computes the summary of the given wallets
+func summarizeWallets(st store.Store, wallets []store.TrackedWallet, now time.Time, window time.Duration, topN int) *Summary {
+	since := now.Add(-window)
+	xsolUSD := latestXSOLPrice(st, wallets)
+
+	summary := &Summary{
+		WalletCount: len(wallets),
+		Holdings:    make(map[string]TokenHolding),
+		TopMovers:   make([]Mover, 0),
+		WindowHours: window.Hours(),
+		GeneratedAt: now,
+	}
+
+	movers := make([]Mover, 0, len(wallets))
+	for _, wallet := range wallets {
+		walletTrades := st.TradesSince(wallet.Address, since)
+		summary.Trades.add(walletTrades, xsolUSD)
+
+		latest, ok := st.LatestSnapshot(wallet.Address)
+		if !ok {
+			continue
+		}
+		if summary.OldestSnapshotAt == nil || latest.Timestamp.Before(*summary.OldestSnapshotAt) {
+			timestamp := latest.Timestamp
+			summary.OldestSnapshotAt = &timestamp
+		}
+
+		for symbol, holding := range latest.Holdings {
+			total := summary.Holdings[symbol]
+			amount, _ := strconv.ParseFloat(holding.FormattedAmount, 64)
+			total.Amount += amount
+			total.USDValue += holding.USDValue
+			summary.Holdings[symbol] = total
+		}
+		summary.TotalUSD += latest.TotalUSD
+
+		if previous, ok := st.SnapshotAt(wallet.Address, since); ok {
+			movers = append(movers, newMover(wallet, latest, previous, len(walletTrades)))
+		}
+	}
+
+	sort.Slice(movers, func(i, j int) bool {
+		return math.Abs(movers[i].ChangeUSD) > math.Abs(movers[j].ChangeUSD)
+	})
+	if len(movers) > topN {
+		movers = movers[:topN]
+	}
+	summary.TopMovers = append(summary.TopMovers, movers...)
+
+	return summary
+}
+
+func (a *TradeActivity) add(records []store.TradeRecord, xsolUSD float64) {
+	for _, record := range records {
+		trade := record.Trade
+		if trade.Status == hylo.TradeStatusFailed {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(trade.XSOLAmount, 64)
+		if err != nil {
+			continue
+		}
+
+		a.Count++
+		switch trade.Side {
+		case hylo.TradeSideBuy:
+			a.BuyCount++
+		case hylo.TradeSideSell:
+			a.SellCount++
+		}
+
+		a.VolumeXSOL += amount
+		priceUSD := xsolUSD
+		if trade.HistoricalPriceUSD != nil {
+			if historical, err := strconv.ParseFloat(*trade.HistoricalPriceUSD, 64); err == nil {
+				priceUSD = historical
+			}
+		}
+		a.VolumeUSD += amount * priceUSD
+	}
+}
+
+func newMover(wallet store.TrackedWallet, latest, previous *store.BalanceSnapshot, tradeCount int) Mover {
+	mover := Mover{
+		Wallet:      wallet.Address,
+		Label:       wallet.Label,
+		CurrentUSD:  latest.TotalUSD,
+		PreviousUSD: previous.TotalUSD,
+		ChangeUSD:   latest.TotalUSD - previous.TotalUSD,
+		TradeCount:  tradeCount,
+	}
+	if previous.TotalUSD != 0 {
+		mover.ChangePercent = mover.ChangeUSD / previous.TotalUSD * 100
+	}
+	return mover
+}
+
+// latestXSOLPrice returns the xSOL price recorded by the most recent snapshot across wallets
+func latestXSOLPrice(st store.Store, wallets []store.TrackedWallet) float64 {
+	var newest *store.BalanceSnapshot
+	for _, wallet := range wallets {
+		if snapshot, ok := st.LatestSnapshot(wallet.Address); ok && (newest == nil || snapshot.Timestamp.After(newest.Timestamp)) {
+			newest = snapshot
+		}
+	}
+	if newest == nil {
+		return 0
+	}
+	return newest.XSOLPriceUSD
+}
@@ -0,0 +1,85 @@
+package watchlist
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/store"
+)
+
+const (
+	walletA = "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+	walletB = "7VHUFJHWu2CuExkJcJrzhQPJ2oygupTWkL2A2For4BmE"
+)
+
+func addTrade(t *testing.T, st store.Store, wallet, signature, side string, slot uint64, at time.Time, xsolRaw uint64) {
+	t.Helper()
+
+	trade := hylo.NewXSOLTrade(signature, slot, at.Unix())
+	trade.SetTradeDetails(side, xsolRaw, 0, "SOL")
+	if _, err := st.AddTrades([]store.TradeRecord{store.NewTradeRecord(wallet, trade)}); err != nil {
+		t.Fatalf("failed to add trade: %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	st, _ := store.NewMemoryStore(store.DefaultConfig())
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	dayAgo := now.Add(-DefaultWindow)
+
+	st.TrackWallet(store.TrackedWallet{Address: walletA, Label: "a"})
+	st.TrackWallet(store.TrackedWallet{Address: walletB})
+
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletA, Timestamp: dayAgo.Add(-time.Hour), TotalUSD: 100})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletA, Timestamp: now.Add(-time.Minute), TotalUSD: 150, XSOLPriceUSD: 0.5,
+		Holdings: map[string]store.Holding{"xSOL": {FormattedAmount: "300", USDValue: 150}},
+	})
+	st.AddSnapshot(store.BalanceSnapshot{Wallet: walletB, Timestamp: dayAgo, TotalUSD: 200})
+	st.AddSnapshot(store.BalanceSnapshot{
+		Wallet: walletB, Timestamp: now.Add(-2 * time.Minute), TotalUSD: 180, XSOLPriceUSD: 0.4,
+		Holdings: map[string]store.Holding{"xSOL": {FormattedAmount: "100", USDValue: 40}, "hyUSD": {FormattedAmount: "140", USDValue: 140}},
+	})
+
+	addTrade(t, st, walletA, "sigA1", hylo.TradeSideBuy, 300, now.Add(-time.Hour), 10_000_000)
+	addTrade(t, st, walletA, "sigA0", hylo.TradeSideBuy, 100, dayAgo.Add(-time.Hour), 99_000_000)
+	addTrade(t, st, walletB, "sigB1", hylo.TradeSideSell, 200, now.Add(-2*time.Hour), 20_000_000)
+
+	summary := Summarize(st, store.DefaultTenant, now, DefaultWindow, DefaultTopN)
+
+	if summary.WalletCount != 2 {
+		t.Errorf("Expected 2 wallets, got %d", summary.WalletCount)
+	}
+	if summary.TotalUSD != 330 {
+		t.Errorf("Expected total USD 330, got %f", summary.TotalUSD)
+	}
+	if xsol := summary.Holdings["xSOL"]; xsol.Amount != 400 || xsol.USDValue != 190 {
+		t.Errorf("Expected combined xSOL 400 / $190, got %+v", xsol)
+	}
+
+	if summary.Trades.Count != 2 || summary.Trades.BuyCount != 1 || summary.Trades.SellCount != 1 {
+		t.Errorf("Expected 1 buy and 1 sell inside the window, got %+v", summary.Trades)
+	}
+	if summary.Trades.VolumeXSOL != 30 || math.Abs(summary.Trades.VolumeUSD-15) > 1e-9 {
+		t.Errorf("Expected volume 30 xSOL / $15 at the latest price, got %+v", summary.Trades)
+	}
+
+	if len(summary.TopMovers) != 2 || summary.TopMovers[0].Wallet != walletA {
+		t.Fatalf("Expected wallet A as top mover, got %+v", summary.TopMovers)
+	}
+	if summary.TopMovers[0].ChangeUSD != 50 || summary.TopMovers[0].ChangePercent != 50 {
+		t.Errorf("Expected +$50 (+50%%), got %+v", summary.TopMovers[0])
+	}
+	if summary.TopMovers[1].ChangeUSD != -20 {
+		t.Errorf("Expected wallet B change -$20, got %+v", summary.TopMovers[1])
+	}
+	if summary.OldestSnapshotAt == nil || !summary.OldestSnapshotAt.Equal(now.Add(-2*time.Minute)) {
+		t.Errorf("Expected oldest snapshot from wallet B, got %v", summary.OldestSnapshotAt)
+	}
+
+	if limited := Summarize(st, store.DefaultTenant, now, DefaultWindow, 1); len(limited.TopMovers) != 1 {
+		t.Errorf("Expected top movers limited to 1, got %d", len(limited.TopMovers))
+	}
+}
@@ -0,0 +1,48 @@
+package watchlist
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Bucket time zones must resolve in minimal containers without zoneinfo
+)
+
+// utcOffsetPattern matches fixed offsets such as +08:00, -0530 and +8
+var utcOffsetPattern = regexp.MustCompile(`^([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// ParseTimezone resolves the zone daily and weekly buckets are aligned to: an IANA name such as
+// Asia/Singapore, or a fixed UTC offset such as +08:00. An empty value means UTC
+// Hylo has no trading epochs, so buckets always follow calendar days in the resolved zone
+func ParseTimezone(value string) (*time.Location, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.UTC, nil
+	}
+
+	if match := utcOffsetPattern.FindStringSubmatch(value); match != nil {
+		hours, _ := strconv.Atoi(match[2])
+		minutes := 0
+		if match[3] != "" {
+			minutes, _ = strconv.Atoi(match[3])
+		}
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid timezone %q: offsets range from -14:00 to +14:00", value)
+		}
+		offset := hours*3600 + minutes*60
+		if match[1] == "-" {
+			offset = -offset
+		}
+		if offset == 0 {
+			return time.UTC, nil
+		}
+		return time.FixedZone(fmt.Sprintf("%s%02d:%02d", match[1], hours, minutes), offset), nil
+	}
+
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: must be an IANA time zone such as Asia/Singapore or an offset such as +08:00", value)
+	}
+	return loc, nil
+}
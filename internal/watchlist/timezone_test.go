@@ -0,0 +1,40 @@
+package watchlist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimezone(t *testing.T) {
+	at := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		name   string
+		offset int // Seconds east of UTC at Jan 8, 2025
+	}{
+		{"", "UTC", 0},
+		{"+00:00", "UTC", 0},
+		{"+08:00", "+08:00", 8 * 3600},
+		{"+8", "+08:00", 8 * 3600},
+		{"-0530", "-05:30", -(5*3600 + 30*60)},
+		{"Asia/Singapore", "Asia/Singapore", 8 * 3600},
+		{" Australia/Sydney ", "Australia/Sydney", 11 * 3600}, // Daylight saving time in January
+	}
+	for _, tt := range tests {
+		loc, err := ParseTimezone(tt.value)
+		if err != nil {
+			t.Errorf("ParseTimezone(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if _, offset := at.In(loc).Zone(); loc.String() != tt.name || offset != tt.offset {
+			t.Errorf("ParseTimezone(%q) = %s at %ds, expected %s at %ds", tt.value, loc, offset, tt.name, tt.offset)
+		}
+	}
+
+	for _, value := range []string{"Mars/Olympus", "+15:00", "+08:75", "8"} {
+		if _, err := ParseTimezone(value); err == nil {
+			t.Errorf("Expected ParseTimezone(%q) to fail", value)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Default webhook delivery settings
+const (
+	DefaultMaxAttempts     = 6                // Initial attempt plus retries before dead-lettering
+	DefaultInitialBackoff  = 2 * time.Second  // Delay before the first retry; doubles on each attempt
+	DefaultMaxBackoff      = 5 * time.Minute  // Upper bound for a single retry delay
+	DefaultRequestTimeout  = 10 * time.Second // Per-attempt HTTP timeout
+	DefaultDeadLetterLimit = 100              // Failed deliveries kept per subscription
+)
+
+// Config holds webhook delivery configuration
+type Config struct {
+	// MaxAttempts is the total number of delivery attempts per event
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the exponential retry delay
+	MaxBackoff time.Duration
+
+	// RequestTimeout bounds each delivery attempt
+	RequestTimeout time.Duration
+
+	// DeadLetterLimit is the number of failed deliveries retained per subscription
+	DeadLetterLimit int
+}
+
+// DefaultConfig returns the default webhook delivery configuration
+func DefaultConfig() *Config {
+	return &Config{
+		MaxAttempts:     DefaultMaxAttempts,
+		InitialBackoff:  DefaultInitialBackoff,
+		MaxBackoff:      DefaultMaxBackoff,
+		RequestTimeout:  DefaultRequestTimeout,
+		DeadLetterLimit: DefaultDeadLetterLimit,
+	}
+}
+
+// NewConfigFromEnvironment creates a Config loading values from environment variables
+// Falls back to defaults for any missing or invalid environment variables
+func NewConfigFromEnvironment() *Config {
+	config := DefaultConfig()
+
+	if value := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); value != "" {
+		if attempts, err := strconv.Atoi(value); err == nil && attempts > 0 {
+			config.MaxAttempts = attempts
+		}
+	}
+
+	if value := os.Getenv("WEBHOOK_INITIAL_BACKOFF_MS"); value != "" {
+		if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+			config.InitialBackoff = time.Duration(ms) * time.Millisecond
+		}
+	}
+
+	if value := os.Getenv("WEBHOOK_MAX_BACKOFF_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.MaxBackoff = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("WEBHOOK_TIMEOUT_SEC"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			config.RequestTimeout = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if value := os.Getenv("WEBHOOK_DEAD_LETTER_LIMIT"); value != "" {
+		if limit, err := strconv.Atoi(value); err == nil && limit > 0 {
+			config.DeadLetterLimit = limit
+		}
+	}
+
+	return config
+}
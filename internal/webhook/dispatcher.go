@@ -0,0 +1,345 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"hylo-wallet-tracker-api/internal/logger"
+	"hylo-wallet-tracker-api/internal/tokens"
+)
+
+// maxErrorBodyBytes bounds how much of a failed response body is kept in the dead-letter entry
+const maxErrorBodyBytes = 512
+
+// Dispatcher manages subscriptions and delivers events with at-least-once semantics
+// Failed deliveries are retried with exponential backoff and dead-lettered after MaxAttempts
+type Dispatcher struct {
+	mu       sync.RWMutex
+	subs     map[string]*Subscription
+	order    []string
+	failures map[string][]Delivery
+
+	config *Config
+	client *http.Client
+	logger *logger.Logger
+	tracks TenantResolver
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher using config
+func NewDispatcher(config *Config) *Dispatcher {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		subs:     make(map[string]*Subscription),
+		failures: make(map[string][]Delivery),
+		config:   config,
+		client:   &http.Client{Timeout: config.RequestTimeout},
+		logger:   logger.NewFromEnv().WithComponent("webhook"),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// TenantResolver reports whether tenant currently tracks wallet
+type TenantResolver func(tenant, wallet string) bool
+
+// SetTenantResolver sets how wallet events are matched to tenant subscriptions
+// Without a resolver, tenant subscriptions receive no wallet events
+func (d *Dispatcher) SetTenantResolver(resolver TenantResolver) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tracks = resolver
+}
+
+// Subscribe validates req and registers a new subscription for tenant with a freshly generated secret
+func (d *Dispatcher) Subscribe(tenant string, req SubscriptionRequest) (*Subscription, error) {
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("invalid url: must be an absolute http(s) URL")
+	}
+
+	events := make([]string, 0, len(req.Events))
+	for _, event := range req.Events {
+		if !containsOrEmpty(SupportedEvents, event) {
+			return nil, fmt.Errorf("invalid event %q: supported events are %v", event, SupportedEvents)
+		}
+		events = append(events, event)
+	}
+
+	wallets := make([]string, 0, len(req.Wallets))
+	for _, wallet := range req.Wallets {
+		address, err := tokens.SanitizeAddress(wallet)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, address.String())
+	}
+
+	id, err := newID("whk_")
+	if err != nil {
+		return nil, err
+	}
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		Tenant:    tenant,
+		ID:        id,
+		URL:       target.String(),
+		Wallets:   wallets,
+		Events:    events,
+		CreatedAt: time.Now().UTC(),
+		Secret:    secret,
+	}
+
+	d.mu.Lock()
+	d.subs[id] = sub
+	d.order = append(d.order, id)
+	d.mu.Unlock()
+
+	return sub, nil
+}
+
+// Unsubscribe removes a tenant's subscription and its dead-letter list; returns false if the tenant has no such subscription
+func (d *Dispatcher) Unsubscribe(tenant, id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if sub, exists := d.subs[id]; !exists || sub.Tenant != tenant {
+		return false
+	}
+	delete(d.subs, id)
+	delete(d.failures, id)
+	for i, existing := range d.order {
+		if existing == id {
+			d.order = append(d.order[:i], d.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Subscriptions returns the tenant's subscriptions in creation order
+func (d *Dispatcher) Subscriptions(tenant string) []Subscription {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	subs := make([]Subscription, 0)
+	for _, id := range d.order {
+		if sub := d.subs[id]; sub.Tenant == tenant {
+			subs = append(subs, *sub)
+		}
+	}
+	return subs
+}
+
+// Failures returns dead-lettered deliveries for a tenant's subscription, newest first
+func (d *Dispatcher) Failures(tenant, id string) ([]Delivery, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if sub, exists := d.subs[id]; !exists || sub.Tenant != tenant {
+		return nil, false
+	}
+
+	failures := d.failures[id]
+	result := make([]Delivery, 0, len(failures))
+	for i := len(failures) - 1; i >= 0; i-- {
+		result = append(result, failures[i])
+	}
+	return result, true
+}
+
+// Publish delivers event asynchronously to every matching subscription
+func (d *Dispatcher) Publish(event Event) {
+	d.Deliver(event)
+}
+
+// Deliver is Publish reporting how many deliveries were queued; 0 means no subscription receives event
+func (d *Dispatcher) Deliver(event Event) int {
+	if event.ID == "" {
+		if id, err := newID("evt_"); err == nil {
+			event.ID = id
+		}
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	d.mu.RLock()
+	var targets []Subscription
+	for _, id := range d.order {
+		if sub := d.subs[id]; sub.matches(event) && d.visibleLocked(sub, event) {
+			targets = append(targets, *sub)
+		}
+	}
+	d.mu.RUnlock()
+
+	queued := 0
+	for _, sub := range targets {
+		deliveryID, err := newID("dlv_")
+		if err != nil {
+			d.logger.Error("Failed to create webhook delivery", slog.String("subscription_id", sub.ID), slog.String("error", err.Error()))
+			continue
+		}
+
+		delivery := Delivery{ID: deliveryID, SubscriptionID: sub.ID, Event: event}
+		d.wg.Add(1)
+		go func(sub Subscription) {
+			defer d.wg.Done()
+			d.deliver(sub, delivery)
+		}(sub)
+		queued++
+	}
+	return queued
+}
+
+// visibleLocked reports whether the subscription's tenant may receive event; the caller holds mu
+// Operator subscriptions see everything, tenant subscriptions their own events, events of wallets
+// the tenant tracks and events that concern no wallet, such as protocol alerts
+func (d *Dispatcher) visibleLocked(sub *Subscription, event Event) bool {
+	switch {
+	case sub.Tenant == "":
+		return true
+	case event.Tenant != "":
+		return event.Tenant == sub.Tenant
+	case event.Wallet != "":
+		return d.tracks != nil && d.tracks(sub.Tenant, event.Wallet)
+	default:
+		return true
+	}
+}
+
+// Close stops retries and waits for in-flight deliveries; pending deliveries are dead-lettered
+func (d *Dispatcher) Close() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// deliver attempts delivery until success, a non-retryable response or MaxAttempts
+func (d *Dispatcher) deliver(sub Subscription, delivery Delivery) {
+	body, err := json.Marshal(Payload{DeliveryID: delivery.ID, Event: delivery.Event})
+	if err != nil {
+		delivery.LastError = fmt.Sprintf("failed to encode payload: %v", err)
+		d.deadLetter(delivery)
+		return
+	}
+
+	for attempt := 1; attempt <= d.config.MaxAttempts; attempt++ {
+		delivery.Attempts = attempt
+		delivery.LastAttemptAt = time.Now().UTC()
+		if attempt == 1 {
+			delivery.FirstAttemptAt = delivery.LastAttemptAt
+		}
+
+		statusCode, err := d.send(sub, delivery, body)
+		delivery.LastStatusCode = statusCode
+		if err == nil {
+			return
+		}
+		delivery.LastError = err.Error()
+
+		if !retryable(statusCode) || attempt == d.config.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-d.ctx.Done():
+			delivery.LastError = fmt.Sprintf("%s (dispatcher stopped before retry)", delivery.LastError)
+			d.deadLetter(delivery)
+			return
+		case <-time.After(d.backoff(attempt)):
+		}
+	}
+
+	d.deadLetter(delivery)
+}
+
+// send performs one signed delivery attempt; a nil error means the subscriber acknowledged with 2xx
+func (d *Dispatcher) send(sub Subscription, delivery Delivery, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, delivery.ID)
+	req.Header.Set(HeaderEvent, delivery.Event.Type)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, SignatureHeader(sub.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return resp.StatusCode, fmt.Errorf("subscriber returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+}
+
+// backoff returns InitialBackoff * 2^(attempt-1), capped at MaxBackoff
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.config.InitialBackoff
+	for i := 1; i < attempt && delay < d.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.config.MaxBackoff {
+		delay = d.config.MaxBackoff
+	}
+	return delay
+}
+
+func (d *Dispatcher) deadLetter(delivery Delivery) {
+	d.logger.Warn("Webhook delivery failed",
+		slog.String("subscription_id", delivery.SubscriptionID),
+		slog.String("delivery_id", delivery.ID),
+		slog.Int("attempts", delivery.Attempts),
+		slog.String("error", delivery.LastError))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.subs[delivery.SubscriptionID]; !exists {
+		return
+	}
+
+	failures := append(d.failures[delivery.SubscriptionID], delivery)
+	if len(failures) > d.config.DeadLetterLimit {
+		failures = failures[len(failures)-d.config.DeadLetterLimit:]
+	}
+	d.failures[delivery.SubscriptionID] = failures
+}
+
+// retryable reports whether a failed attempt should be retried
+// Network errors (status 0), timeouts, rate limits and server errors are retried; other 4xx are not
+func retryable(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
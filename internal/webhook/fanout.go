@@ -0,0 +1,28 @@
+package webhook
+
+import "time"
+
+// Publisher receives events; implemented by Dispatcher and stream.Manager
+type Publisher interface {
+	Publish(event Event)
+}
+
+// Fanout publishes every event to each publisher in order, so webhook deliveries and live streams
+// carry the same event ID and creation time
+type Fanout []Publisher
+
+// Publish assigns the event's ID and creation time if unset and hands it to every publisher
+func (f Fanout) Publish(event Event) {
+	if event.ID == "" {
+		if id, err := newID("evt_"); err == nil {
+			event.ID = id
+		}
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	for _, publisher := range f {
+		publisher.Publish(event)
+	}
+}
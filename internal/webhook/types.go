@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"time"
+)
+
+// Event types delivered to subscribers
+const (
+	EventTradeNew       = "trade.new"       // A new trade was indexed for a tracked wallet
+	EventBalanceChanged = "balance.changed" // A tracked wallet's token balances changed between indexer snapshots
+	EventWalletDigest   = "wallet.digest"   // Periodic change digest for a wallet opted in to digests
+	EventPegDepeg       = "peg.depeg"       // hyUSD's market price moved beyond the alert threshold from its peg
+	EventPegRestored    = "peg.restored"    // hyUSD's market price returned close to its peg after a depeg alert
+
+	EventDecimalsMismatch = "token.decimals_mismatch" // A token's configured decimals differ from its on-chain mint
+	EventSupplyChanged    = "protocol.supply_changed" // The mint supply of hyUSD, xSOL or sHYUSD changed
+
+	EventParserAnomaly   = "parser.anomaly"   // The parser's hourly error or low-confidence rate crossed its threshold
+	EventParserRecovered = "parser.recovered" // The parser's rates fell back under half their thresholds after an anomaly
+)
+
+// SupportedEvents lists the event types a subscription may filter on
+var SupportedEvents = []string{EventTradeNew, EventBalanceChanged, EventWalletDigest, EventPegDepeg, EventPegRestored, EventDecimalsMismatch, EventSupplyChanged, EventParserAnomaly, EventParserRecovered}
+
+// SubscriptionRequest is the body accepted when creating a subscription
+type SubscriptionRequest struct {
+	URL string `json:"url" example:"https://example.com/hooks/hylo"`
+	// Wallets restricts deliveries to these wallets; empty matches all tracked wallets
+	Wallets []string `json:"wallets,omitempty"`
+	// Events restricts deliveries to these event types; empty matches all events
+	Events []string `json:"events,omitempty"`
+}
+
+// Subscription is a registered webhook endpoint
+// Secret is only returned once, when the subscription is created
+type Subscription struct {
+	Tenant    string    `json:"tenant,omitempty"` // Empty for the operator's subscriptions, which receive every tenant's events
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Wallets   []string  `json:"wallets"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+	Secret    string    `json:"-"`
+}
+
+// Event is a notification published to matching subscriptions
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// Tenant restricts delivery to that tenant's subscriptions and the operator's; empty for events
+	// that are not addressed to one tenant
+	Tenant    string      `json:"-"`
+	Wallet    string      `json:"wallet,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Payload is the JSON body POSTed to subscribers
+type Payload struct {
+	DeliveryID string `json:"delivery_id"`
+	Event      Event  `json:"event"`
+}
+
+// Delivery tracks attempts to deliver one event to one subscription
+// The ID is reused for every retry so consumers can process deliveries idempotently
+type Delivery struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Event          Event     `json:"event"`
+	Attempts       int       `json:"attempts"`
+	LastStatusCode int       `json:"last_status_code,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	FirstAttemptAt time.Time `json:"first_attempt_at"`
+	LastAttemptAt  time.Time `json:"last_attempt_at"`
+}
+
+// matches reports whether the subscription wants the event
+// Protocol-wide events carry no wallet and are not restricted by the subscription's wallet filter
+func (s *Subscription) matches(event Event) bool {
+	return containsOrEmpty(s.Events, event.Type) && (event.Wallet == "" || containsOrEmpty(s.Wallets, event.Wallet))
+}
+
+func containsOrEmpty(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testWallet = "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+
+func newTestDispatcher() *Dispatcher {
+	return NewDispatcher(&Config{
+		MaxAttempts:     3,
+		InitialBackoff:  time.Millisecond,
+		MaxBackoff:      5 * time.Millisecond,
+		RequestTimeout:  time.Second,
+		DeadLetterLimit: 10,
+	})
+}
+
+// recordingServer answers with the given status codes in order and records request headers and bodies
+type recordingServer struct {
+	mu       sync.Mutex
+	statuses []int
+	headers  []http.Header
+	bodies   [][]byte
+}
+
+func (s *recordingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := http.StatusOK
+	if len(s.headers) < len(s.statuses) {
+		status = s.statuses[len(s.headers)]
+	}
+	s.headers = append(s.headers, r.Header.Clone())
+	s.bodies = append(s.bodies, body)
+	w.WriteHeader(status)
+}
+
+func TestSignatureRoundTrip(t *testing.T) {
+	body := []byte(`{"hello":"world"}`)
+	now := time.Unix(1_700_000_000, 0)
+	header := SignatureHeader("secret", now.Unix(), body)
+
+	if err := VerifySignature("secret", header, body, DefaultSignatureTolerance, now); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+	if err := VerifySignature("other", header, body, DefaultSignatureTolerance, now); err == nil {
+		t.Errorf("Expected wrong secret to be rejected")
+	}
+	if err := VerifySignature("secret", header, []byte(`{"hello":"there"}`), DefaultSignatureTolerance, now); err == nil {
+		t.Errorf("Expected tampered body to be rejected")
+	}
+	if err := VerifySignature("secret", header, body, DefaultSignatureTolerance, now.Add(time.Hour)); err == nil {
+		t.Errorf("Expected replayed payload to be rejected")
+	}
+	if err := VerifySignature("secret", "garbage", body, DefaultSignatureTolerance, now); err == nil {
+		t.Errorf("Expected malformed header to be rejected")
+	}
+}
+
+func TestSubscribeValidation(t *testing.T) {
+	d := newTestDispatcher()
+	defer d.Close()
+
+	tests := []struct {
+		name      string
+		request   SubscriptionRequest
+		wantError string
+	}{
+		{name: "valid", request: SubscriptionRequest{URL: "https://example.com/hook", Wallets: []string{testWallet}, Events: []string{EventTradeNew}}},
+		{name: "relative url", request: SubscriptionRequest{URL: "/hook"}, wantError: "invalid url"},
+		{name: "unsupported scheme", request: SubscriptionRequest{URL: "ftp://example.com"}, wantError: "invalid url"},
+		{name: "unknown event", request: SubscriptionRequest{URL: "https://example.com", Events: []string{"balance.frozen"}}, wantError: "invalid event"},
+		{name: "bad wallet", request: SubscriptionRequest{URL: "https://example.com", Wallets: []string{"not-a-wallet"}}, wantError: "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := d.Subscribe("", tt.request)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if sub.Secret == "" || !strings.HasPrefix(sub.ID, "whk_") {
+					t.Errorf("Expected generated id and secret, got %+v", sub)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestPublishSignsAndRetries(t *testing.T) {
+	recorder := &recordingServer{statuses: []int{http.StatusInternalServerError, http.StatusOK}}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	d := newTestDispatcher()
+	defer d.Close()
+	sub, _ := d.Subscribe("", SubscriptionRequest{URL: server.URL})
+
+	d.Publish(Event{Type: EventTradeNew, Wallet: testWallet, Data: map[string]string{"signature": "sig1"}})
+	d.wg.Wait()
+
+	if len(recorder.headers) != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", len(recorder.headers))
+	}
+	first, second := recorder.headers[0].Get(HeaderDeliveryID), recorder.headers[1].Get(HeaderDeliveryID)
+	if first == "" || first != second {
+		t.Errorf("Expected the same delivery id on retry, got %q and %q", first, second)
+	}
+
+	signature := recorder.headers[1].Get(HeaderSignature)
+	if err := VerifySignature(sub.Secret, signature, recorder.bodies[1], DefaultSignatureTolerance, time.Now()); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(recorder.bodies[1], &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.DeliveryID != first || payload.Event.Type != EventTradeNew || payload.Event.ID == "" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+
+	if failures, _ := d.Failures("", sub.ID); len(failures) != 0 {
+		t.Errorf("Expected no dead letters after successful retry, got %+v", failures)
+	}
+}
+
+func TestPublishDeadLetters(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+	}{
+		{name: "retries exhausted", statuses: []int{500, 502, 503}, wantAttempts: 3},
+		{name: "client error is not retried", statuses: []int{http.StatusGone}, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&recordingServer{statuses: tt.statuses})
+			defer server.Close()
+
+			d := newTestDispatcher()
+			defer d.Close()
+			sub, _ := d.Subscribe("", SubscriptionRequest{URL: server.URL})
+
+			d.Publish(Event{Type: EventTradeNew, Wallet: testWallet})
+			d.wg.Wait()
+
+			failures, ok := d.Failures("", sub.ID)
+			if !ok || len(failures) != 1 {
+				t.Fatalf("Expected 1 dead letter, got %+v", failures)
+			}
+			if failures[0].Attempts != tt.wantAttempts || failures[0].LastStatusCode != tt.statuses[len(tt.statuses)-1] {
+				t.Errorf("Unexpected dead letter %+v", failures[0])
+			}
+		})
+	}
+}
+
+func TestPublishFiltersSubscriptions(t *testing.T) {
+	recorder := &recordingServer{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	d := newTestDispatcher()
+	defer d.Close()
+	d.Subscribe("", SubscriptionRequest{URL: server.URL, Wallets: []string{testWallet}})
+	d.Subscribe("", SubscriptionRequest{URL: server.URL, Wallets: []string{"7VHUFJHWu2CuExkJcJrzhQPJ2oygupTWkL2A2For4BmE"}})
+
+	if queued := d.Deliver(Event{Type: EventTradeNew, Wallet: testWallet}); queued != 1 {
+		t.Errorf("Expected one delivery queued, got %d", queued)
+	}
+	d.wg.Wait()
+
+	if len(recorder.headers) != 1 {
+		t.Errorf("Expected only the matching subscription to be notified, got %d deliveries", len(recorder.headers))
+	}
+
+	// Protocol-wide events carry no wallet and reach every subscription
+	d.Publish(Event{Type: EventPegDepeg})
+	d.wg.Wait()
+
+	if len(recorder.headers) != 3 {
+		t.Errorf("Expected the peg event to reach both subscriptions, got %d deliveries in total", len(recorder.headers))
+	}
+}
+
+func TestPublishScopesTenants(t *testing.T) {
+	recorder := &recordingServer{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	d := newTestDispatcher()
+	defer d.Close()
+	d.SetTenantResolver(func(tenant, wallet string) bool { return tenant == "acme" && wallet == testWallet })
+	acme, _ := d.Subscribe("acme", SubscriptionRequest{URL: server.URL})
+	d.Subscribe("globex", SubscriptionRequest{URL: server.URL})
+
+	// Only acme tracks the wallet
+	d.Publish(Event{Type: EventTradeNew, Wallet: testWallet})
+	d.wg.Wait()
+	if len(recorder.headers) != 1 {
+		t.Fatalf("Expected only the tracking tenant to be notified, got %d deliveries", len(recorder.headers))
+	}
+
+	// Events addressed to a tenant skip the others
+	d.Publish(Event{Type: EventWalletDigest, Tenant: "globex", Wallet: testWallet})
+	d.wg.Wait()
+	if len(recorder.headers) != 2 {
+		t.Errorf("Expected the digest to reach globex only, got %d deliveries in total", len(recorder.headers))
+	}
+
+	if subs := d.Subscriptions("globex"); len(subs) != 1 || subs[0].ID == acme.ID {
+		t.Errorf("Expected globex to list only its own subscription, got %+v", subs)
+	}
+	if d.Unsubscribe("globex", acme.ID) {
+		t.Error("Expected a tenant not to remove another tenant's subscription")
+	}
+	if _, ok := d.Failures("", acme.ID); ok {
+		t.Error("Expected the operator scope not to expose tenant subscriptions")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := NewDispatcher(&Config{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second})
+	defer d.Close()
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, expected := range want {
+		if got := d.backoff(i + 1); got != expected {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, expected, got)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Package client is a typed Go client for the Hylo Wallet Tracker API
+//
+// It wraps the REST endpoints, the Server-Sent Events account stream and the NDJSON full trade
+// history stream, retries transient failures (network errors, 408, 429 and 5xx) with exponential
+// backoff and follows trade pagination cursors, so services consuming the tracker do not hand-roll
+// HTTP calls:
+//
+//	c, err := client.New(client.DefaultConfig("http://localhost:8080"))
+//	balances, err := c.GetBalances(ctx, wallet)
+//	err = c.EachTrade(ctx, wallet, nil, func(trade *client.Trade) error { ... })
+//	summary, err := c.StreamTrades(ctx, wallet, nil, func(trade *client.Trade) error { ... })
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default client settings
+const (
+	DefaultTimeout     = 30 * time.Second // Per-request timeout of the default HTTP client
+	DefaultMaxRetries  = 3                // Retries after the first attempt
+	DefaultBaseBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff  = 10 * time.Second
+)
+
+// Config holds client configuration
+type Config struct {
+	// BaseURL is the tracker API root, e.g. http://localhost:8080
+	BaseURL string
+
+	// HTTPClient sends every request, streams included, so its Timeout also ends streams
+	// When nil, requests use a DefaultTimeout client and streams one without a timeout
+	HTTPClient *http.Client
+
+	// MaxRetries is the number of retries of a failed idempotent request
+	MaxRetries int
+
+	// BaseBackoff is the delay before the first retry; doubles on each retry
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the retry delay
+	MaxBackoff time.Duration
+
+	// AdminAPIKey is sent as a bearer token when set
+	AdminAPIKey string
+}
+
+// DefaultConfig returns the default configuration for the API at baseURL
+func DefaultConfig(baseURL string) *Config {
+	return &Config{
+		BaseURL:     baseURL,
+		MaxRetries:  DefaultMaxRetries,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// Client calls the tracker API
+type Client struct {
+	baseURL    *url.URL
+	config     *Config
+	httpClient *http.Client
+	streamHTTP *http.Client // Without a timeout: streams stay open until ctx ends
+}
+
+// New creates a client from config
+func New(config *Config) (*Client, error) {
+	if config == nil {
+		return nil, errors.New("config cannot be nil")
+	}
+
+	baseURL, err := url.Parse(strings.TrimRight(config.BaseURL, "/"))
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", config.BaseURL)
+	}
+	if config.MaxRetries < 0 {
+		return nil, fmt.Errorf("max retries cannot be negative, got %d", config.MaxRetries)
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		config:     config,
+		httpClient: config.HTTPClient,
+		streamHTTP: config.HTTPClient,
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: DefaultTimeout}
+		c.streamHTTP = &http.Client{}
+	}
+	return c, nil
+}
+
+// APIError is a non-2xx response from the API
+type APIError struct {
+	StatusCode int
+	Message    string `json:"error"`
+	Details    string `json:"details,omitempty"`
+	Code       string `json:"code,omitempty"` // VALIDATION_ERROR, NOT_FOUND, NETWORK_ERROR, ...
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("tracker API returned %d", e.StatusCode)
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	if e.Details != "" {
+		message += " (" + e.Details + ")"
+	}
+	return message
+}
+
+// Temporary reports whether the request may succeed when retried
+func (e *APIError) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsNotFound reports whether err is a 404 from the API
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// getJSON sends GET path?query and decodes the response into result, retrying transient failures
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, result interface{}) error {
+	var lastErr error
+	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(c.backoff(attempt - 1)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		lastErr = c.doJSON(ctx, path, query, result)
+		if lastErr == nil || !retryable(ctx, lastErr) {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", c.config.MaxRetries+1, lastErr)
+}
+
+func (c *Client) doJSON(ctx context.Context, path string, query url.Values, result interface{}) error {
+	req, err := c.newRequest(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeAPIError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Client) newRequest(ctx context.Context, path string, query url.Values) (*http.Request, error) {
+	target := *c.baseURL
+	target.Path += path
+	target.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "hylo-wallet-tracker-client/1.0")
+	if c.config.AdminAPIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.AdminAPIKey)
+	}
+	return req, nil
+}
+
+func decodeAPIError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(body, apiErr) != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+// retryable reports whether a failed attempt should be retried; cancellation of ctx never is
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	// Transport failures (connection refused, reset, timeouts)
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// backoff returns BaseBackoff * 2^attempt capped at MaxBackoff
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.config.BaseBackoff << attempt
+	if delay <= 0 || delay > c.config.MaxBackoff {
+		delay = c.config.MaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testWallet = "A3wpCHTBFHQr7JeGFSA6cbTHJ4rkXgHZ2BLj2rZDyc6g"
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := DefaultConfig(server.URL)
+	config.BaseBackoff = time.Millisecond
+	config.MaxBackoff = time.Millisecond
+	c, err := New(config)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return c
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	if _, err := New(DefaultConfig("localhost:8080")); err == nil {
+		t.Error("Expected error for base URL without scheme")
+	}
+	config := DefaultConfig("http://localhost:8080")
+	config.MaxRetries = -1
+	if _, err := New(config); err == nil {
+		t.Error("Expected error for negative retries")
+	}
+}
+
+func TestGetPriceRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `{"error":"Network error","code":"NETWORK_ERROR"}`)
+			return
+		}
+		fmt.Fprint(w, `{"sol_usd":150.5,"xsol_sol":0.01,"xsol_usd":1.505,"updated_at":"2025-01-01T00:00:00Z"}`)
+	})
+
+	prices, err := c.GetPrice(context.Background())
+	if err != nil {
+		t.Fatalf("GetPrice failed: %v", err)
+	}
+	if prices.SOLUSD != 150.5 || calls.Load() != 3 {
+		t.Errorf("Expected price after 2 retries, got %+v after %d calls", prices, calls.Load())
+	}
+}
+
+func TestGetBalancesDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/wallet/bad/balances" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"Invalid wallet address format","details":"too short","code":"VALIDATION_ERROR"}`)
+	})
+
+	_, err := c.GetBalances(context.Background(), "bad")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "VALIDATION_ERROR" || apiErr.Details != "too short" {
+		t.Fatalf("Expected decoded validation error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestEachTradeFollowsCursor(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "50" {
+			t.Errorf("Expected default page size 50, got %q", r.URL.Query().Get("limit"))
+		}
+		switch r.URL.Query().Get("before") {
+		case "":
+			fmt.Fprint(w, `{"trades":[{"signature":"s1"},{"signature":"s2"}],"pagination":{"hasMore":true,"nextCursor":"s2","limit":50}}`)
+		case "s2":
+			fmt.Fprint(w, `{"trades":[{"signature":"s3"}],"pagination":{"hasMore":false,"limit":50}}`)
+		default:
+			t.Errorf("Unexpected cursor %q", r.URL.Query().Get("before"))
+		}
+	})
+
+	var signatures []string
+	err := c.EachTrade(context.Background(), testWallet, nil, func(trade *Trade) error {
+		signatures = append(signatures, trade.Signature)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachTrade failed: %v", err)
+	}
+	if fmt.Sprint(signatures) != "[s1 s2 s3]" {
+		t.Errorf("Expected trades from both pages, got %v", signatures)
+	}
+
+	signatures = nil
+	err = c.EachTrade(context.Background(), testWallet, nil, func(trade *Trade) error {
+		signatures = append(signatures, trade.Signature)
+		return ErrStopIteration
+	})
+	if err != nil || len(signatures) != 1 {
+		t.Errorf("Expected ErrStopIteration to stop after one trade, got %v (%v)", signatures, err)
+	}
+}
+
+func TestStreamAccountUpdates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": connected\n\n")
+		fmt.Fprint(w, "event: account\nid: 10\ndata: {\"wallet\":\""+testWallet+"\",\"token\":\"xSOL\",\"slot\":10}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: account\nid: 11\ndata: {\"wallet\":\""+testWallet+"\",\"token\":\"hyUSD\",\"slot\":11}\n\n")
+	})
+
+	var updates []*AccountUpdate
+	err := c.StreamAccountUpdates(context.Background(), testWallet, func(update *AccountUpdate) error {
+		updates = append(updates, update)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamAccountUpdates failed: %v", err)
+	}
+	if len(updates) != 2 || updates[0].Token != "xSOL" || updates[1].Slot != 11 {
+		t.Errorf("Expected two decoded updates, got %+v", updates)
+	}
+}
+
+func TestStreamTrades(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wallet/"+testWallet+"/trades/full" || r.URL.Query().Get("before") != "s0" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"type":"trade","trade":{"signature":"s1","side":"BUY"}}`)
+		fmt.Fprintln(w, `{"type":"trade","trade":{"signature":"s2","side":"SELL"}}`)
+		fmt.Fprintln(w, `{"type":"done","summary":{"wallet":"`+testWallet+`","signatures":40,"trades":2,"cursor":"s9","complete":true}}`)
+	})
+
+	var signatures []string
+	summary, err := c.StreamTrades(context.Background(), testWallet, &StreamTradesOptions{Before: "s0"}, func(trade *Trade) error {
+		signatures = append(signatures, trade.Signature)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamTrades failed: %v", err)
+	}
+	if fmt.Sprint(signatures) != "[s1 s2]" || !summary.Complete || summary.Signatures != 40 {
+		t.Errorf("Expected both trades and the server summary, got %v and %+v", signatures, summary)
+	}
+}
+
+func TestStreamTradesReportsFailureAfterTrades(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type":"trade","trade":{"signature":"s1"}}`)
+		fmt.Fprintln(w, `{"type":"error","error":{"error":"Network connectivity issue","code":"NETWORK_ERROR"}}`)
+	})
+
+	summary, err := c.StreamTrades(context.Background(), testWallet, nil, func(trade *Trade) error { return nil })
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.Temporary() {
+		t.Fatalf("Expected a temporary API error, got %v", err)
+	}
+	if summary.Cursor != "s1" || summary.Complete {
+		t.Errorf("Expected to resume after s1, got %+v", summary)
+	}
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// streamEventAccount is the SSE event name carrying an AccountUpdate
+const streamEventAccount = "account"
+
+// Line types of the full trade history NDJSON stream
+const (
+	tradeLineTrade = "trade"
+	tradeLineDone  = "done"
+	tradeLineError = "error"
+)
+
+// StreamAccountUpdates subscribes to the wallet's xSOL, hyUSD and sHYUSD token account changes
+// (GET /wallet/{address}/stream, Server-Sent Events) and calls fn for each update until ctx ends,
+// the server closes the stream or fn returns an error
+// Updates carry account data, not trades; callers that need the trades behind an update typically fetch
+// GetTrades with Until set to their newest known signature, and use StreamTrades for the complete history
+func (c *Client) StreamAccountUpdates(ctx context.Context, wallet string, fn func(*AccountUpdate) error) error {
+	req, err := c.newRequest(ctx, "/wallet/"+url.PathEscape(wallet)+"/stream", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.streamHTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeAPIError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+
+	var event string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// Blank line dispatches the buffered event
+			if event == streamEventAccount && data.Len() > 0 {
+				var update AccountUpdate
+				if err := json.Unmarshal([]byte(data.String()), &update); err != nil {
+					return fmt.Errorf("failed to decode stream update: %w", err)
+				}
+				if err := fn(&update); err != nil {
+					return err
+				}
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment (connected / keep-alive)
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// StreamTradesOptions selects where a full trade history walk starts and what it includes
+type StreamTradesOptions struct {
+	Before        string // Start below this signature, e.g. the Cursor of an interrupted walk
+	IncludeFailed bool
+}
+
+// tradeStreamLine is one line of the /wallet/{address}/trades/full response
+type tradeStreamLine struct {
+	Type    string          `json:"type"`
+	Trade   *Trade          `json:"trade,omitempty"`
+	Summary *HistorySummary `json:"summary,omitempty"`
+	Error   *APIError       `json:"error,omitempty"`
+}
+
+// StreamTrades walks the wallet's complete xSOL trade history, newest first (GET /wallet/{address}/trades/full,
+// newline-delimited JSON), and calls fn for each trade as the server parses it
+// It returns the server's summary once the walk ends. A walk cut short by fn, ctx or a broken connection returns
+// the error with a summary whose Cursor is the last trade received, so the walk can resume from there; ErrStopIteration
+// from fn ends it without an error. Streams are not retried
+func (c *Client) StreamTrades(ctx context.Context, wallet string, opts *StreamTradesOptions, fn func(*Trade) error) (*HistorySummary, error) {
+	query := url.Values{}
+	if opts != nil {
+		if opts.Before != "" {
+			query.Set("before", opts.Before)
+		}
+		if opts.IncludeFailed {
+			query.Set("include_failed", "true")
+		}
+	}
+	partial := &HistorySummary{Wallet: wallet}
+	if opts != nil {
+		partial.Cursor = opts.Before
+	}
+
+	req, err := c.newRequest(ctx, "/wallet/"+url.PathEscape(wallet)+"/trades/full", query)
+	if err != nil {
+		return partial, err
+	}
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := c.streamHTTP.Do(req)
+	if err != nil {
+		return partial, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return partial, decodeAPIError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64<<10), 4<<20)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var line tradeStreamLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return partial, fmt.Errorf("failed to decode trade stream line: %w", err)
+		}
+
+		switch line.Type {
+		case tradeLineTrade:
+			if line.Trade == nil {
+				continue
+			}
+			if err := fn(line.Trade); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return partial, nil
+				}
+				return partial, err
+			}
+			partial.Trades++
+			partial.Cursor = line.Trade.Signature
+		case tradeLineDone:
+			if line.Summary == nil {
+				return partial, nil
+			}
+			return line.Summary, nil
+		case tradeLineError:
+			apiErr := line.Error
+			if apiErr == nil {
+				apiErr = &APIError{Message: "trade stream failed"}
+			}
+			apiErr.StatusCode = streamErrorStatus(apiErr.Code)
+			return partial, apiErr
+		}
+	}
+
+	if ctx.Err() != nil {
+		return partial, ctx.Err()
+	}
+	if err := scanner.Err(); err != nil {
+		return partial, err
+	}
+	return partial, fmt.Errorf("trade stream ended without a summary: %w", io.ErrUnexpectedEOF)
+}
+
+// streamErrorStatus maps the code of an error line to the status the server would have answered with
+// before the stream started, so APIError.Temporary classifies it the same way
+func streamErrorStatus(code string) int {
+	switch code {
+	case "NETWORK_ERROR":
+		return http.StatusBadGateway
+	case "VALIDATION_ERROR":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Response types mirror the API's JSON and depend on nothing inside the server, so the client can be
+// vendored on its own; TestTypesMatchServerJSON keeps their fields in step with the server's types
+
+// WalletBalances is the /wallet/{address}/balances response
+type WalletBalances struct {
+	Wallet        string                   `json:"wallet"`
+	Chain         string                   `json:"chain"`
+	Slot          uint64                   `json:"slot"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+	Balances      map[string]*TokenBalance `json:"balances"` // Keyed by token symbol
+	Withheld      []string                 `json:"withheld,omitempty"`
+	TotalUSDValue *float64                 `json:"total_usd_value,omitempty"`
+	Currency      *CurrencyConversion      `json:"currency,omitempty"`
+	ExplorerURL   string                   `json:"explorer_url,omitempty"`
+	Reconstructed *Reconstruction          `json:"reconstructed,omitempty"`
+	Context       *DataContext             `json:"context,omitempty"`
+}
+
+// TokenBalance is a single token entry in WalletBalances
+type TokenBalance struct {
+	RawAmount       uint64              `json:"raw_amount"`
+	Decimals        uint8               `json:"decimals"`
+	FormattedAmount string              `json:"formatted_amount"`
+	USDValue        *float64            `json:"usd_value,omitempty"`
+	Underlying      *StabilityPoolClaim `json:"underlying,omitempty"` // sHYUSD only
+	Accounts        int                 `json:"accounts,omitempty"`
+	IconURL         string              `json:"icon_url,omitempty"`
+}
+
+// StabilityPoolClaim is the pro-rata stability pool claim backing an sHYUSD balance
+type StabilityPoolClaim struct {
+	PoolShare float64       `json:"pool_share"`
+	HyUSD     *TokenBalance `json:"hyusd"`
+	XSOL      *TokenBalance `json:"xsol"`
+	Source    string        `json:"source,omitempty"` // estimated while pool balances are read without the program IDL
+}
+
+// Reconstruction is set on balances rebuilt for a past slot or time
+type Reconstruction struct {
+	BaseSlot       uint64    `json:"base_slot"`
+	BaseTimestamp  time.Time `json:"base_timestamp"`
+	Direction      string    `json:"direction"` // forward or backward
+	ReplayedTrades int       `json:"replayed_trades"`
+}
+
+// CurrencyConversion is set when USD values were converted to another fiat currency
+type CurrencyConversion struct {
+	Currency  string    `json:"currency"`
+	Rate      float64   `json:"rate"` // Units of Currency per 1 USD
+	Source    string    `json:"source"`
+	RateDate  string    `json:"rate_date,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// DataContext is the slot, commitment and fetch time of the chain data behind a response
+type DataContext struct {
+	Slot       uint64    `json:"slot"`
+	Commitment string    `json:"commitment"`
+	FetchedAt  time.Time `json:"fetched_at"`
+}
+
+// TradeResponse is one page of the /wallet/{address}/trades response
+type TradeResponse struct {
+	Trades        []*Trade       `json:"trades"`
+	Pagination    PaginationInfo `json:"pagination"`
+	WalletAddress string         `json:"walletAddress"`
+	WalletURL     string         `json:"walletExplorerUrl,omitempty"`
+	Chain         string         `json:"chain"`
+	RequestedAt   time.Time      `json:"requestedAt"`
+	Count         int            `json:"count"`
+	Context       *DataContext   `json:"context,omitempty"`
+	Dust          *DustSummary   `json:"dust,omitempty"`
+}
+
+// PaginationInfo carries the cursor for the next trade page
+type PaginationInfo struct {
+	HasMore    bool          `json:"hasMore"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+	Limit      int           `json:"limit"`
+	Count      int           `json:"count"`
+	Filters    *TradeFilters `json:"filters,omitempty"` // Filters applied to the page; pass them again with NextCursor
+}
+
+// TradeFilters echoes the trade filters a page was served with
+type TradeFilters struct {
+	Side         string `json:"side,omitempty"`
+	CounterAsset string `json:"counterAsset,omitempty"`
+	MinAmount    string `json:"minAmount,omitempty"`
+	MaxAmount    string `json:"maxAmount,omitempty"`
+}
+
+// DustSummary reports the trades a page left out as dust
+type DustSummary struct {
+	MinXSOLRaw uint64  `json:"minXsolRaw,omitempty"`
+	MinUSD     float64 `json:"minUsd,omitempty"`
+	Filtered   int     `json:"filtered"`
+}
+
+// Trade is a single xSOL trade
+type Trade struct {
+	Signature string `json:"signature"`
+	Slot      uint64 `json:"slot"`
+	BlockTime int64  `json:"blockTime"`
+
+	Side          string `json:"side"` // BUY, SELL or RECEIVE
+	XSOLAmount    string `json:"xsolAmount"`
+	CounterAmount string `json:"counterAmount"`
+	CounterAsset  string `json:"counterAsset"`
+
+	HistoricalPriceUSD *string `json:"historical_price_usd,omitempty"`
+	NetworkFeeSOL      string  `json:"network_fee_sol,omitempty"`
+	ProtocolFee        string  `json:"protocol_fee,omitempty"`
+
+	Status      string `json:"status,omitempty"` // failed for reverted transactions
+	ErrorReason string `json:"errorReason,omitempty"`
+
+	Confidence        float64  `json:"confidence"`
+	ConfidenceReasons []string `json:"confidenceReasons,omitempty"`
+	NeedsReview       bool     `json:"needsReview,omitempty"`
+	Warnings          []string `json:"warnings,omitempty"`
+
+	Steps         []TradeStep         `json:"steps,omitempty"`
+	Source        string              `json:"source,omitempty"`
+	SourceProgram string              `json:"sourceProgram,omitempty"`
+	ProtocolState *TradeProtocolState `json:"protocolState,omitempty"`
+
+	Timestamp   time.Time `json:"timestamp"`
+	ExplorerURL string    `json:"explorerUrl"`
+}
+
+// TradeStep is one leg of a trade that also staked or unstaked sHYUSD
+type TradeStep struct {
+	Instruction  string `json:"instruction"`
+	Program      string `json:"program"`
+	InputAsset   string `json:"inputAsset"`
+	InputAmount  string `json:"inputAmount"`
+	OutputAsset  string `json:"outputAsset"`
+	OutputAmount string `json:"outputAmount"`
+}
+
+// TradeProtocolState is the protocol health recorded closest to a trade's slot
+type TradeProtocolState struct {
+	Slot              uint64    `json:"slot"`
+	SlotDistance      uint64    `json:"slotDistance"`
+	CollateralRatio   float64   `json:"collateralRatio"`
+	EffectiveLeverage float64   `json:"effectiveLeverage"`
+	CapturedAt        time.Time `json:"capturedAt"`
+}
+
+// HistorySummary reports how far a full trade history walk got; pass Cursor to StreamTrades to resume
+type HistorySummary struct {
+	Wallet     string `json:"wallet"`
+	Signatures int    `json:"signatures"`
+	Trades     int    `json:"trades"`
+	Cursor     string `json:"cursor,omitempty"`
+	Complete   bool   `json:"complete"` // The walk reached the wallet's oldest signature
+	ElapsedMS  int64  `json:"elapsed_ms"`
+}
+
+// Prices is the /price response
+type Prices struct {
+	SOLUSD    float64             `json:"sol_usd"`
+	XSOLInSOL float64             `json:"xsol_sol"`
+	XSOLInUSD float64             `json:"xsol_usd"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Currency  *CurrencyConversion `json:"currency,omitempty"`
+	Cache     *PriceCacheStatus   `json:"cache,omitempty"`
+	Context   *DataContext        `json:"context,omitempty"`
+}
+
+// PriceCacheStatus is set when prices were served from the stale-while-revalidate cache
+type PriceCacheStatus struct {
+	AgeSeconds     float64 `json:"age_seconds"`
+	SoftTTLSeconds float64 `json:"soft_ttl_seconds"`
+	HardTTLSeconds float64 `json:"hard_ttl_seconds"`
+	Stale          bool    `json:"stale"`
+}
+
+// AccountUpdate is one event of the /wallet/{address}/stream response
+type AccountUpdate struct {
+	Wallet     string          `json:"wallet"`
+	Token      string          `json:"token"`
+	Account    string          `json:"account"`
+	Slot       uint64          `json:"slot"`
+	Data       json.RawMessage `json:"data"`
+	ReceivedAt time.Time       `json:"received_at"`
+}
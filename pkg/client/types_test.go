@@ -0,0 +1,62 @@
+package client
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"hylo-wallet-tracker-api/internal/fx"
+	"hylo-wallet-tracker-api/internal/hylo"
+	"hylo-wallet-tracker-api/internal/price"
+	"hylo-wallet-tracker-api/internal/solana"
+	"hylo-wallet-tracker-api/internal/stream"
+	"hylo-wallet-tracker-api/internal/tokens"
+	"hylo-wallet-tracker-api/internal/trades"
+)
+
+// jsonFields lists the JSON names a struct serializes
+func jsonFields(value interface{}) []string {
+	var fields []string
+	typ := reflect.TypeOf(value)
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// TestTypesMatchServerJSON keeps the client's standalone DTOs in step with the server's response types
+func TestTypesMatchServerJSON(t *testing.T) {
+	pairs := []struct {
+		client, server interface{}
+	}{
+		{WalletBalances{}, tokens.WalletBalances{}},
+		{TokenBalance{}, tokens.TokenBalance{}},
+		{StabilityPoolClaim{}, tokens.StabilityPoolClaim{}},
+		{Reconstruction{}, tokens.Reconstruction{}},
+		{CurrencyConversion{}, fx.Conversion{}},
+		{DataContext{}, solana.DataContext{}},
+		{TradeResponse{}, trades.TradeResponse{}},
+		{PaginationInfo{}, trades.PaginationInfo{}},
+		{TradeFilters{}, trades.TradeFilters{}},
+		{DustSummary{}, trades.DustSummary{}},
+		{Trade{}, hylo.XSOLTrade{}},
+		{TradeStep{}, hylo.TradeStep{}},
+		{TradeProtocolState{}, hylo.TradeProtocolState{}},
+		{HistorySummary{}, trades.HistorySummary{}},
+		{Prices{}, price.CombinedPriceResponse{}},
+		{PriceCacheStatus{}, price.CacheStatus{}},
+		{AccountUpdate{}, stream.Update{}},
+	}
+
+	for _, pair := range pairs {
+		clientFields, serverFields := jsonFields(pair.client), jsonFields(pair.server)
+		if !reflect.DeepEqual(clientFields, serverFields) {
+			t.Errorf("%T fields %v do not match %T fields %v", pair.client, clientFields, pair.server, serverFields)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// MaxTradePageSize is the largest page the trades endpoint returns
+const MaxTradePageSize = 50
+
+// ErrStopIteration can be returned from an EachTrade callback to stop without an error
+var ErrStopIteration = errors.New("stop iteration")
+
+// TradesOptions filters a trade history request; the zero value fetches the newest page with the server default size
+type TradesOptions struct {
+	Limit         int    // Trades per page (1-50)
+	Before        string // Signature cursor: only trades older than it
+	Until         string // Stop at this signature (exclusive)
+	MinSlot       uint64
+	MaxSlot       uint64
+	IncludeFailed bool
+	MinConfidence float64 // Drop trades classified with less confidence (0-1)
+	NeedsReview   bool    // Only low-confidence trades flagged for manual review
+}
+
+func (o *TradesOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Before != "" {
+		query.Set("before", o.Before)
+	}
+	if o.Until != "" {
+		query.Set("until", o.Until)
+	}
+	if o.MinSlot > 0 {
+		query.Set("min_slot", strconv.FormatUint(o.MinSlot, 10))
+	}
+	if o.MaxSlot > 0 {
+		query.Set("max_slot", strconv.FormatUint(o.MaxSlot, 10))
+	}
+	if o.IncludeFailed {
+		query.Set("include_failed", "true")
+	}
+	if o.MinConfidence > 0 {
+		query.Set("min_confidence", strconv.FormatFloat(o.MinConfidence, 'f', -1, 64))
+	}
+	if o.NeedsReview {
+		query.Set("needs_review", "true")
+	}
+	return query
+}
+
+// GetBalances returns the wallet's hyUSD, sHYUSD and xSOL balances
+func (c *Client) GetBalances(ctx context.Context, wallet string) (*WalletBalances, error) {
+	var balances WalletBalances
+	if err := c.getJSON(ctx, "/wallet/"+url.PathEscape(wallet)+"/balances", nil, &balances); err != nil {
+		return nil, err
+	}
+	return &balances, nil
+}
+
+// GetTrades returns one page of the wallet's xSOL trade history, newest first
+// Pass resp.Pagination.NextCursor as Before to fetch the next page, or use EachTrade
+func (c *Client) GetTrades(ctx context.Context, wallet string, opts *TradesOptions) (*TradeResponse, error) {
+	var page TradeResponse
+	if err := c.getJSON(ctx, "/wallet/"+url.PathEscape(wallet)+"/trades", opts.query(), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// EachTrade calls fn for every trade matching opts, newest first, following pagination cursors
+// Pages default to MaxTradePageSize; iteration stops at the first error from fn, and ErrStopIteration
+// ends it without an error
+func (c *Client) EachTrade(ctx context.Context, wallet string, opts *TradesOptions, fn func(*Trade) error) error {
+	page := TradesOptions{Limit: MaxTradePageSize}
+	if opts != nil {
+		page = *opts
+		if page.Limit <= 0 {
+			page.Limit = MaxTradePageSize
+		}
+	}
+
+	for {
+		resp, err := c.GetTrades(ctx, wallet, &page)
+		if err != nil {
+			return err
+		}
+		for _, trade := range resp.Trades {
+			if err := fn(trade); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		next := resp.Pagination.NextCursor
+		if !resp.Pagination.HasMore || next == "" || next == page.Before {
+			return nil
+		}
+		page.Before = next
+	}
+}
+
+// GetPrice returns the current SOL/USD, xSOL/SOL and xSOL/USD prices
+func (c *Client) GetPrice(ctx context.Context) (*Prices, error) {
+	var prices Prices
+	if err := c.getJSON(ctx, "/price", nil, &prices); err != nil {
+		return nil, err
+	}
+	return &prices, nil
+}